@@ -3,18 +3,18 @@
 set -eu
 
 gen() {
-    rm -rf controller/gen
-    mkdir controller/gen
+    rm -rf controller/gen/common controller/gen/controller controller/gen/public
 
     for f in $@; do
         bin/protoc -I proto --go_out="plugins=grpc:$GOPATH/src" "$f"
     done
 
-    git add controller/gen
+    git add controller/gen/common controller/gen/controller controller/gen/public
 }
 
 go install ./vendor/github.com/golang/protobuf/protoc-gen-go
 
 gen proto/common/healthcheck.proto \
     proto/public.proto \
-    proto/controller/tap.proto
+    proto/controller/tap.proto \
+    proto/controller/identity.proto
@@ -0,0 +1,18 @@
+#!/bin/sh
+
+set -eu
+
+# Regenerates the ServiceProfile clientset, informers, listers, and deepcopy
+# functions in controller/gen from the types in controller/gen/apis.
+#
+# Requires a checkout of k8s.io/code-generator at kubernetes-1.11.1, located at
+# $CODEGEN_PKG (defaults to $GOPATH/src/k8s.io/code-generator).
+
+CODEGEN_PKG=${CODEGEN_PKG:-$GOPATH/src/k8s.io/code-generator}
+
+"$CODEGEN_PKG"/generate-groups.sh "deepcopy,client,informer,lister" \
+    github.com/linkerd/linkerd2/controller/gen/client \
+    github.com/linkerd/linkerd2/controller/gen/apis \
+    serviceprofile:v1alpha1
+
+git add controller/gen/apis controller/gen/client
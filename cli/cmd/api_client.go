@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc"
+)
+
+// lazyAPIClient is a public API client whose underlying client is built in
+// the background, so that parsing the kubeconfig and building the client's
+// transport overlap with the rest of a command's startup. Only its first RPC
+// waits for the client, and returns the error that building it failed with,
+// if any.
+type lazyAPIClient struct {
+	done   chan struct{}
+	client pb.ApiClient
+	err    error
+}
+
+func newLazyAPIClient(build func() (pb.ApiClient, error)) *lazyAPIClient {
+	c := &lazyAPIClient{done: make(chan struct{})}
+	go func() {
+		defer close(c.done)
+		c.client, c.err = build()
+	}()
+	return c
+}
+
+func (c *lazyAPIClient) get() (pb.ApiClient, error) {
+	select {
+	case <-c.done:
+	default:
+		defer startupProfile.begin("waiting for the API client")()
+		<-c.done
+	}
+	return c.client, c.err
+}
+
+func (c *lazyAPIClient) StatSummary(ctx context.Context, req *pb.StatSummaryRequest, opts ...grpc.CallOption) (*pb.StatSummaryResponse, error) {
+	client, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	defer startupProfile.begin("StatSummary")()
+	return client.StatSummary(ctx, req, opts...)
+}
+
+func (c *lazyAPIClient) ListPods(ctx context.Context, req *pb.ListPodsRequest, opts ...grpc.CallOption) (*pb.ListPodsResponse, error) {
+	client, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	defer startupProfile.begin("ListPods")()
+	return client.ListPods(ctx, req, opts...)
+}
+
+func (c *lazyAPIClient) Tap(ctx context.Context, req *pb.TapRequest, opts ...grpc.CallOption) (pb.Api_TapClient, error) {
+	client, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	defer startupProfile.begin("Tap")()
+	return client.Tap(ctx, req, opts...)
+}
+
+func (c *lazyAPIClient) TapByResource(ctx context.Context, req *pb.TapByResourceRequest, opts ...grpc.CallOption) (pb.Api_TapByResourceClient, error) {
+	client, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	defer startupProfile.begin("TapByResource")()
+	return client.TapByResource(ctx, req, opts...)
+}
+
+func (c *lazyAPIClient) Version(ctx context.Context, req *pb.Empty, opts ...grpc.CallOption) (*pb.VersionInfo, error) {
+	client, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	defer startupProfile.begin("Version")()
+	return client.Version(ctx, req, opts...)
+}
+
+func (c *lazyAPIClient) SelfCheck(ctx context.Context, req *healthcheckPb.SelfCheckRequest, opts ...grpc.CallOption) (*healthcheckPb.SelfCheckResponse, error) {
+	client, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	defer startupProfile.begin("SelfCheck")()
+	return client.SelfCheck(ctx, req, opts...)
+}
+
+// startupProfiler records the phases of a command's startup, which the hidden
+// --profile-startup flag prints once the command is done.
+type startupProfiler struct {
+	start  time.Time
+	mutex  sync.Mutex
+	phases []startupPhase
+}
+
+type startupPhase struct {
+	name     string
+	start    time.Time
+	duration time.Duration
+}
+
+var startupProfile = &startupProfiler{start: time.Now()}
+
+// begin starts recording the phase name, and returns the func that ends it.
+func (p *startupProfiler) begin(name string) func() {
+	start := time.Now()
+	return func() {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		p.phases = append(p.phases, startupPhase{name, start, time.Since(start)})
+	}
+}
+
+func (p *startupProfiler) print(w io.Writer) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	sort.Slice(p.phases, func(i, j int) bool {
+		return p.phases[i].start.Before(p.phases[j].start)
+	})
+	for _, phase := range p.phases {
+		fmt.Fprintf(w, "%-28s started at %-12s took %s\n", phase.name,
+			phase.start.Sub(p.start).Round(time.Microsecond),
+			phase.duration.Round(time.Microsecond))
+	}
+	fmt.Fprintf(w, "%-28s %s\n", "total", time.Since(p.start).Round(time.Microsecond))
+}
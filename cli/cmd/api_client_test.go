@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestLazyAPIClient(t *testing.T) {
+	t.Run("Builds the client while the command starts up", func(t *testing.T) {
+		// Parsing the kubeconfig and dialing the API each take a while, as
+		// does the rest of the command's startup.
+		const latency = 100 * time.Millisecond
+		mockClient := &public.MockApiClient{
+			VersionInfoToReturn: &pb.VersionInfo{ReleaseVersion: "stable-2.0.0"},
+		}
+
+		start := time.Now()
+		client := newLazyAPIClient(func() (pb.ApiClient, error) {
+			time.Sleep(latency)
+			return mockClient, nil
+		})
+		time.Sleep(latency)
+
+		rsp, err := client.Version(context.Background(), &pb.Empty{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.GetReleaseVersion() != "stable-2.0.0" {
+			t.Fatalf("Expected version [stable-2.0.0], got [%s]", rsp.GetReleaseVersion())
+		}
+
+		// Built serially, the client and the rest of the startup would take
+		// twice the latency.
+		if elapsed := time.Since(start); elapsed >= 2*latency {
+			t.Fatalf("Expected the client to be built concurrently, startup took %s", elapsed)
+		}
+	})
+
+	t.Run("Returns the error building the client from its first RPC", func(t *testing.T) {
+		expectedErr := errors.New("invalid kubeconfig")
+		client := newLazyAPIClient(func() (pb.ApiClient, error) {
+			return nil, expectedErr
+		})
+
+		_, err := client.StatSummary(context.Background(), &pb.StatSummaryRequest{})
+		if err != expectedErr {
+			t.Fatalf("Expected error [%s], got [%v]", expectedErr, err)
+		}
+	})
+}
+
+func TestNewPublicAPIClient(t *testing.T) {
+	t.Run("Doesn't parse the kubeconfig when --api-addr is set", func(t *testing.T) {
+		defer func(addr string) { apiAddr = addr }(apiAddr)
+		defer func(f func(string, string) (k8s.KubernetesApi, error)) { newKubernetesAPI = f }(newKubernetesAPI)
+
+		apiAddr = "localhost:8085"
+		parsed := false
+		newKubernetesAPI = func(string, string) (k8s.KubernetesApi, error) {
+			parsed = true
+			return nil, errors.New("unexpected kubeconfig parsing")
+		}
+
+		client, err := newPublicAPIClient()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, err := client.(*lazyAPIClient).get(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if parsed {
+			t.Fatalf("Expected the kubeconfig not to be parsed")
+		}
+	})
+}
+
+func TestStartupProfiler(t *testing.T) {
+	profiler := &startupProfiler{start: time.Now()}
+	endClient := profiler.begin("building the API client")
+	endRPC := profiler.begin("StatSummary")
+	endRPC()
+	endClient()
+
+	var buf bytes.Buffer
+	profiler.print(&buf)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got: %s", buf.String())
+	}
+	for i, prefix := range []string{"building the API client", "StatSummary", "total"} {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Fatalf("Expected line %d to start with [%s], got [%s]", i, prefix, lines[i])
+		}
+	}
+}
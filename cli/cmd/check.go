@@ -1,18 +1,42 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/ghodss/yaml"
 	"github.com/linkerd/linkerd2/controller/api/public"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	"github.com/linkerd/linkerd2/pkg/version"
+	promApi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
 	"github.com/spf13/cobra"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/api/core/v1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
 )
 
 const (
@@ -20,16 +44,170 @@ const (
 	okStatus        = "[ok]"
 	failStatus      = "[FAIL]"
 	errorStatus     = "[ERROR]"
+	skippedStatus   = "[skipped]"
 	versionCheckURL = "https://versioncheck.linkerd.io/version.json"
+
+	// remoteWriteQuery is empty unless Prometheus is configured with
+	// remote_write.
+	remoteWriteQuery            = "sum(increase(prometheus_remote_storage_succeeded_samples_total[5m]))"
+	remoteWriteCheckDescription = "remote storage accepted samples in the last 5 minutes"
+
+	grafanaSubsystemName          = "grafana"
+	grafanaHealthCheckDescription = "can query the Grafana health endpoint"
+
+	// grafanaHealthTimeout bounds the health check of an external Grafana,
+	// which is queried directly rather than through the Kubernetes API.
+	grafanaHealthTimeout = 10 * time.Second
+
+	serviceAccountSubsystemName              = "linkerd-service-account"
+	serviceAccountAnnotationCheckDescription = "workload identity annotations are valid"
+	controllerServiceAccountName             = "linkerd-controller"
+	eksRoleARNAnnotation                     = "eks.amazonaws.com/role-arn"
+	gkeServiceAccountAnnotation              = "iam.gke.io/gcp-service-account"
+	azureWorkloadIdentityClientIDAnnotation  = "azure.workload.identity/client-id"
+
+	profileValidatorSubsystemName = "linkerd-sp-validator"
+
+	// profileValidatorProbe is a ServiceProfile without routes, which the
+	// sp-validator webhook must reject.
+	profileValidatorProbe = `{"apiVersion":"linkerd.io/v1alpha1","kind":"ServiceProfile","metadata":{"name":"linkerd-check"},"spec":{"routes":[]}}`
+
+	endpointsSubsystemName        = "linkerd-endpoints"
+	endpointPopulationDescription = "control plane services have endpoints"
+
+	rbacSubsystemName               = "linkerd-rbac"
+	clusterRoleIntegrityDescription = "ClusterRoles have the expected rules"
+	clusterRolesAPIPath             = "/apis/rbac.authorization.k8s.io/v1beta1/clusterroles"
+
+	annotationsSubsystemName        = "linkerd-annotations"
+	deprecatedAnnotationDescription = "can scan workloads for deprecated annotations"
+
+	cniSubsystemName         = "linkerd-cni"
+	cniPluginHashDescription = "plugin binaries match the release's hash"
+
+	// cniInstallerBinary prints the hash of the plugin binary installed on
+	// the node of the linkerd-cni pod that it's run in.
+	cniInstallerContainerName = "install-cni"
+	cniInstallerBinary        = "/usr/local/bin/install-cni"
 )
 
+// deprecatedAnnotations maps the annotations that Linkerd no longer reads to
+// the ones that replaced them.
+var deprecatedAnnotations = map[string]string{
+	// Conduit was renamed to Linkerd2 in v18.7.1.
+	"conduit.io/created-by":    k8s.CreatedByAnnotation,
+	"conduit.io/proxy-version": k8s.ProxyVersionAnnotation,
+}
+
+// workloadIdentityAnnotations maps the ServiceAccount annotations that cloud
+// providers use to bind pods to cloud identities to the format of their
+// values.
+var workloadIdentityAnnotations = map[string]struct {
+	format  *regexp.Regexp
+	example string
+}{
+	// IAM roles for service accounts, on EKS.
+	eksRoleARNAnnotation: {
+		regexp.MustCompile(`^arn:aws(-[a-z]+)*:iam::[0-9]{12}:role/[\w+=,.@/-]+$`),
+		"arn:aws:iam::123456789012:role/linkerd-controller",
+	},
+	// Workload Identity, on GKE.
+	gkeServiceAccountAnnotation: {
+		regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]@[a-z][a-z0-9-]{4,28}[a-z0-9]\.iam\.gserviceaccount\.com$`),
+		"linkerd-controller@my-project.iam.gserviceaccount.com",
+	},
+	// Azure AD Workload Identity, on AKS.
+	azureWorkloadIdentityClientIDAnnotation: {
+		regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+		"00000000-0000-0000-0000-000000000000",
+	},
+}
+
 type checkOptions struct {
 	versionOverride string
+	output          string
 }
 
 func newCheckOptions() *checkOptions {
 	return &checkOptions{
 		versionOverride: "",
+		output:          "",
+	}
+}
+
+// Severity levels of the checks in the JSON output, for monitoring systems to
+// set the severity of alerts with.
+const (
+	infoSeverityLevel    = 1
+	warningSeverityLevel = 2
+	errorSeverityLevel   = 3
+	fatalSeverityLevel   = 4
+)
+
+// Statuses of the checks in the JSON output. Warnings are not the result of a
+// check, so they have their own status.
+const (
+	okCheckStatus      = "ok"
+	failCheckStatus    = "fail"
+	errorCheckStatus   = "error"
+	skippedCheckStatus = "skipped"
+	warningCheckStatus = "warning"
+)
+
+// apiClientCheckDescription describes the creation of the Linkerd API client,
+// which is reported as a failed check if the client can't be created.
+const apiClientCheckDescription = "can initialize the client"
+
+// checkResultJSON is the JSON output of a single check.
+type checkResultJSON struct {
+	Subsystem     string `json:"subsystem"`
+	Description   string `json:"description"`
+	Status        string `json:"status"`
+	SeverityLevel int    `json:"severityLevel"`
+	Message       string `json:"message,omitempty"`
+}
+
+// checkWarningJSON is the JSON output of a warning.
+type checkWarningJSON struct {
+	Message       string `json:"message"`
+	Status        string `json:"status"`
+	SeverityLevel int    `json:"severityLevel"`
+}
+
+// checkOutputJSON is the JSON output of `linkerd check`. Its status and
+// severity level are those of its most severe check.
+type checkOutputJSON struct {
+	Status        string             `json:"status"`
+	SeverityLevel int                `json:"severityLevel"`
+	Checks        []checkResultJSON  `json:"checks"`
+	Warnings      []checkWarningJSON `json:"warnings"`
+}
+
+// checkStatusName returns the name of status in the JSON output.
+func checkStatusName(status healthcheckPb.CheckStatus) string {
+	switch status {
+	case healthcheckPb.CheckStatus_FAIL:
+		return failCheckStatus
+	case healthcheckPb.CheckStatus_ERROR:
+		return errorCheckStatus
+	case healthcheckPb.CheckStatus_SKIPPED:
+		return skippedCheckStatus
+	default:
+		return okCheckStatus
+	}
+}
+
+// severityLevel returns the severity level of status. Checks that could not be
+// run at all, which are reported with the ERROR status, are fatal, as they
+// also take precedence over failed checks in the overall status.
+func severityLevel(status healthcheckPb.CheckStatus) int {
+	switch status {
+	case healthcheckPb.CheckStatus_FAIL:
+		return errorSeverityLevel
+	case healthcheckPb.CheckStatus_ERROR:
+		return fatalSeverityLevel
+	default:
+		return infoSeverityLevel
 	}
 }
 
@@ -45,10 +223,15 @@ problems were found.`,
 		Args: cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 
-			kubeApi, err := k8s.NewAPI(kubeconfigPath)
+			if options.output != "" && options.output != jsonOutput {
+				fmt.Fprintf(os.Stderr, "--output must be blank or \"%s\", was: %s\n", jsonOutput, options.output)
+				os.Exit(2)
+			}
+
+			kubeApi, err := k8s.NewAPI(kubeconfigPath, kubeContext)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error with Kubernetes API: %s\n", err.Error())
-				statusCheckResultWasError(os.Stdout)
+				setupCheckFailed(os.Stdout, options, k8s.KubeapiSubsystemName, k8s.KubeapiClientCheckDescription, err)
 				os.Exit(2)
 			}
 
@@ -60,14 +243,33 @@ problems were found.`,
 			}
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error with Linkerd API: %s\n", err.Error())
-				statusCheckResultWasError(os.Stdout)
+				setupCheckFailed(os.Stdout, options, public.ApiSubsystemName, apiClientCheckDescription, err)
 				os.Exit(2)
 			}
 
 			grpcStatusChecker := healthcheck.NewGrpcStatusChecker(public.ApiSubsystemName, apiClient)
 			versionStatusChecker := version.NewVersionStatusChecker(versionCheckURL, options.versionOverride, apiClient)
+			trustAnchorChecker := &trustAnchorStatusChecker{kubeAPI: kubeApi}
+			internalTLSChecker := &internalTLSStatusChecker{kubeAPI: kubeApi}
+			prometheusStorageChecker := &prometheusStorageStatusChecker{kubeAPI: kubeApi}
+			remoteWriteChecker := &remoteWriteStatusChecker{kubeAPI: kubeApi}
+			grafanaChecker := &grafanaStatusChecker{kubeAPI: kubeApi}
+			serviceAccountAnnotationChecker := &serviceAccountAnnotationStatusChecker{kubeAPI: kubeApi}
+			profileValidatorChecker := &profileValidatorStatusChecker{kubeAPI: kubeApi}
+			cniPluginChecker := &cniPluginStatusChecker{kubeAPI: kubeApi, releaseSHA256: version.CNIPluginSHA256}
+			endpointPopulationChecker := &endpointPopulationStatusChecker{kubeAPI: kubeApi}
+			clusterRoleIntegrityChecker := &clusterRoleIntegrityStatusChecker{kubeAPI: kubeApi}
+			deprecatedAnnotationChecker := &deprecatedAnnotationStatusChecker{kubeAPI: kubeApi}
 
-			err = checkStatus(os.Stdout, kubeApi, grpcStatusChecker, versionStatusChecker)
+			checkers := []healthcheck.StatusChecker{kubeApi, grpcStatusChecker, versionStatusChecker, trustAnchorChecker, internalTLSChecker, prometheusStorageChecker, remoteWriteChecker, grafanaChecker, serviceAccountAnnotationChecker, profileValidatorChecker, cniPluginChecker, endpointPopulationChecker, clusterRoleIntegrityChecker, deprecatedAnnotationChecker}
+			if options.output == jsonOutput {
+				results, status := performChecks(checkers...)
+				warnings := append(trustAnchorChecker.warnings, deprecatedAnnotationChecker.warnings...)
+				err = renderCheckResultsJSON(os.Stdout, results, status, warnings)
+			} else {
+				err = checkStatus(os.Stdout, checkers...)
+				printWarnings(os.Stdout, append(trustAnchorChecker.warnings, deprecatedAnnotationChecker.warnings...))
+			}
 			if err != nil {
 				os.Exit(2)
 			}
@@ -76,6 +278,7 @@ problems were found.`,
 
 	cmd.Args = cobra.NoArgs
 	cmd.PersistentFlags().StringVar(&options.versionOverride, "expected-version", options.versionOverride, "Overrides the version used when checking if Linkerd is running the latest version (mostly for testing)")
+	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output, "Output format; one of: \"json\"")
 
 	return cmd
 }
@@ -97,6 +300,8 @@ func checkStatus(w io.Writer, checkers ...healthcheck.StatusChecker) error {
 			fmt.Fprintf(w, "%s%s%s  -- %s%s", checkLabel, filler, failStatus, result.FriendlyMessageToUser, lineBreak)
 		case healthcheckPb.CheckStatus_ERROR:
 			fmt.Fprintf(w, "%s%s%s -- %s%s", checkLabel, filler, errorStatus, result.FriendlyMessageToUser, lineBreak)
+		case healthcheckPb.CheckStatus_SKIPPED:
+			fmt.Fprintf(w, "%s%s%s -- %s%s", checkLabel, filler, skippedStatus, result.FriendlyMessageToUser, lineBreak)
 		}
 	}
 
@@ -122,6 +327,99 @@ func checkStatus(w io.Writer, checkers ...healthcheck.StatusChecker) error {
 	return err
 }
 
+// performChecks runs the checks of checkers, and returns their results and
+// overall status.
+func performChecks(checkers ...healthcheck.StatusChecker) ([]*healthcheckPb.CheckResult, healthcheckPb.CheckStatus) {
+	checker := healthcheck.MakeHealthChecker()
+	for _, c := range checkers {
+		checker.Add(c)
+	}
+
+	results := []*healthcheckPb.CheckResult{}
+	status := checker.PerformCheck(func(result *healthcheckPb.CheckResult) {
+		results = append(results, result)
+	})
+	return results, status
+}
+
+// renderCheckResultsJSON renders the results of the checks, and warnings, as
+// JSON. Like checkStatus, it returns an error if the overall status is not OK.
+func renderCheckResultsJSON(w io.Writer, results []*healthcheckPb.CheckResult, status healthcheckPb.CheckStatus, warnings []string) error {
+	output := checkOutputJSON{
+		Status:        checkStatusName(status),
+		SeverityLevel: severityLevel(status),
+		Checks:        []checkResultJSON{},
+		Warnings:      []checkWarningJSON{},
+	}
+	for _, result := range results {
+		check := checkResultJSON{
+			Subsystem:     result.SubsystemName,
+			Description:   result.CheckDescription,
+			Status:        checkStatusName(result.Status),
+			SeverityLevel: severityLevel(result.Status),
+		}
+		if result.Status != healthcheckPb.CheckStatus_OK {
+			check.Message = result.FriendlyMessageToUser
+		}
+		output.Checks = append(output.Checks, check)
+	}
+	for _, warning := range warnings {
+		output.Warnings = append(output.Warnings, checkWarningJSON{
+			Message:       warning,
+			Status:        warningCheckStatus,
+			SeverityLevel: warningSeverityLevel,
+		})
+	}
+	// warnings only raise the overall severity of checks that all passed
+	if len(output.Warnings) > 0 && output.SeverityLevel < warningSeverityLevel {
+		output.SeverityLevel = warningSeverityLevel
+	}
+
+	out, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", out); err != nil {
+		return err
+	}
+
+	switch status {
+	case healthcheckPb.CheckStatus_FAIL:
+		return errors.New("failed status check")
+	case healthcheckPb.CheckStatus_ERROR:
+		return errors.New("error during status check")
+	}
+	return nil
+}
+
+// setupCheckFailed reports that the checks could not be run, because the
+// client of subsystem could not be created.
+func setupCheckFailed(w io.Writer, options *checkOptions, subsystem, description string, err error) {
+	if options.output != jsonOutput {
+		statusCheckResultWasError(w)
+		return
+	}
+
+	result := &healthcheckPb.CheckResult{
+		SubsystemName:         subsystem,
+		CheckDescription:      description,
+		Status:                healthcheckPb.CheckStatus_ERROR,
+		FriendlyMessageToUser: err.Error(),
+	}
+	renderCheckResultsJSON(w, []*healthcheckPb.CheckResult{result}, result.Status, nil)
+}
+
+func printWarnings(w io.Writer, warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\nWarnings:")
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "  * %s\n", warning)
+	}
+}
+
 func statusCheckResultWasOk(w io.Writer) error {
 	fmt.Fprintln(w, "Status check results are [ok]")
 	return nil
@@ -136,3 +434,947 @@ func statusCheckResultWasError(w io.Writer) error {
 	fmt.Fprintln(w, "Status check results are [ERROR]")
 	return errors.New("error during status check")
 }
+
+// trustAnchorStatusChecker checks the trust anchors distributed to the control
+// plane namespace. It reports no results if TLS is not enabled.
+type trustAnchorStatusChecker struct {
+	kubeAPI  k8s.KubernetesApi
+	warnings []string
+}
+
+func (c *trustAnchorStatusChecker) SelfCheck() []*healthcheckPb.CheckResult {
+	checkResult := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    tls.TrustAnchorsSubsystemName,
+		CheckDescription: tls.TrustAnchorsValidCheckDescription,
+	}
+
+	client, err := c.kubeAPI.NewClient()
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	var configMap v1.ConfigMap
+	err = getKubernetesObject(client, c.kubeAPI, controlPlaneNamespace, "/configmaps/"+k8s.TLSTrustAnchorConfigMapName, &configMap)
+	if err == errKubernetesObjectNotFound {
+		return nil
+	}
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to read configmap [%s]: %s", k8s.TLSTrustAnchorConfigMapName, err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	results, warnings := tls.CheckTrustAnchors(configMap.Data[k8s.TLSTrustAnchorFileName], configMap.Data[k8s.TLSIssuerFileName], time.Now())
+	c.warnings = warnings
+	return results
+}
+
+// internalTLSStatusChecker checks that the public API's serving certificate is
+// valid for its service name and that the API can be queried over TLS. It
+// reports no results if the control plane was installed without
+// --control-plane-internal-tls.
+type internalTLSStatusChecker struct {
+	kubeAPI k8s.KubernetesApi
+}
+
+func (c *internalTLSStatusChecker) SelfCheck() []*healthcheckPb.CheckResult {
+	certificateCheck := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    public.ApiSubsystemName,
+		CheckDescription: "serving certificate is valid for the API service",
+	}
+
+	client, err := c.kubeAPI.NewClient()
+	if err != nil {
+		certificateCheck.Status = healthcheckPb.CheckStatus_ERROR
+		certificateCheck.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{certificateCheck}
+	}
+
+	var secret v1.Secret
+	err = getKubernetesObject(client, c.kubeAPI, controlPlaneNamespace, "/secrets/"+internalTLSSecretName, &secret)
+	if err == errKubernetesObjectNotFound {
+		return nil
+	}
+	if err != nil {
+		certificateCheck.Status = healthcheckPb.CheckStatus_ERROR
+		certificateCheck.FriendlyMessageToUser = fmt.Sprintf("Failed to read secret [%s]: %s", internalTLSSecretName, err)
+		return []*healthcheckPb.CheckResult{certificateCheck}
+	}
+
+	clusterDNSDomain, err := fetchClusterDNSDomain(c.kubeAPI, controlPlaneNamespace)
+	if err != nil {
+		certificateCheck.Status = healthcheckPb.CheckStatus_ERROR
+		certificateCheck.FriendlyMessageToUser = fmt.Sprintf("Failed to read configmap [%s]: %s", k8s.LinkerdConfigMapName, err)
+		return []*healthcheckPb.CheckResult{certificateCheck}
+	}
+
+	if err := verifyInternalTLSCertificate(&secret, controlPlaneNamespace, clusterDNSDomain, time.Now()); err != nil {
+		certificateCheck.Status = healthcheckPb.CheckStatus_FAIL
+		certificateCheck.FriendlyMessageToUser = err.Error()
+	}
+
+	handshakeCheck := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    public.ApiSubsystemName,
+		CheckDescription: "can query the Linkerd API over TLS",
+	}
+
+	apiClient, err := public.NewExternalTLSClient(controlPlaneNamespace, c.kubeAPI)
+	if err == nil {
+		_, err = apiClient.Version(context.Background(), &pb.Empty{})
+	}
+	if err != nil {
+		handshakeCheck.Status = healthcheckPb.CheckStatus_FAIL
+		handshakeCheck.FriendlyMessageToUser = err.Error()
+	}
+
+	return []*healthcheckPb.CheckResult{certificateCheck, handshakeCheck}
+}
+
+// fetchClusterDNSDomain returns the DNS domain of the cluster that the control
+// plane in controlPlaneNamespace was installed into, or the default domain if
+// the control plane's Linkerd config doesn't record it.
+func fetchClusterDNSDomain(kubeAPI k8s.KubernetesApi, controlPlaneNamespace string) (string, error) {
+	clusterDNSDomain, err := fetchLinkerdConfig(kubeAPI, controlPlaneNamespace, k8s.LinkerdConfigClusterDNSDomainKey)
+	if err != nil {
+		return "", err
+	}
+	if clusterDNSDomain == "" {
+		return k8s.DefaultClusterDNSDomain, nil
+	}
+	return clusterDNSDomain, nil
+}
+
+// verifyInternalTLSCertificate returns an error unless secret holds a serving
+// certificate that is valid for the public API's service name in
+// clusterDNSDomain.
+func verifyInternalTLSCertificate(secret *v1.Secret, controlPlaneNamespace, clusterDNSDomain string, now time.Time) error {
+	serverName := fmt.Sprintf("api.%s.svc.%s", controlPlaneNamespace, clusterDNSDomain)
+	return tls.VerifyServingCertificate(secret.Data["api.crt"], secret.Data["ca.crt"], serverName, now)
+}
+
+// prometheusStorageStatusChecker checks that Prometheus' persistent volume
+// claims are bound. It reports no results if the control plane was installed
+// without --prometheus-storage-size.
+type prometheusStorageStatusChecker struct {
+	kubeAPI k8s.KubernetesApi
+}
+
+func (c *prometheusStorageStatusChecker) SelfCheck() []*healthcheckPb.CheckResult {
+	checkResult := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    public.PromClientSubsystemName,
+		CheckDescription: "persistent volume claims are bound",
+	}
+
+	client, err := c.kubeAPI.NewClient()
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	selector := url.QueryEscape(fmt.Sprintf("%s=prometheus", k8s.ControllerComponentLabel))
+	var claims v1.PersistentVolumeClaimList
+	err = getKubernetesObject(client, c.kubeAPI, controlPlaneNamespace, "/persistentvolumeclaims?labelSelector="+selector, &claims)
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to list persistent volume claims: %s", err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	return checkPersistentVolumeClaims(checkResult, claims.Items)
+}
+
+// checkPersistentVolumeClaims fails checkResult if any of the claims is not
+// bound. It returns no results if there are no claims.
+func checkPersistentVolumeClaims(checkResult *healthcheckPb.CheckResult, claims []v1.PersistentVolumeClaim) []*healthcheckPb.CheckResult {
+	if len(claims) == 0 {
+		return nil
+	}
+
+	var unbound []string
+	for _, claim := range claims {
+		if claim.Status.Phase != v1.ClaimBound {
+			unbound = append(unbound, fmt.Sprintf("[%s] is %s", claim.Name, claim.Status.Phase))
+		}
+	}
+	if len(unbound) > 0 {
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Persistent volume claim %s", strings.Join(unbound, ", "))
+	}
+
+	return []*healthcheckPb.CheckResult{checkResult}
+}
+
+// remoteWriteStatusChecker checks that Prometheus' remote storage endpoint has
+// recently accepted samples. It reports no results if the control plane was
+// installed without --prometheus-remote-write-url.
+type remoteWriteStatusChecker struct {
+	kubeAPI k8s.KubernetesApi
+}
+
+func (c *remoteWriteStatusChecker) SelfCheck() []*healthcheckPb.CheckResult {
+	promAPI, err := newPrometheusAPI(c.kubeAPI)
+	if err != nil {
+		return []*healthcheckPb.CheckResult{{
+			Status:                healthcheckPb.CheckStatus_ERROR,
+			SubsystemName:         public.PromClientSubsystemName,
+			CheckDescription:      remoteWriteCheckDescription,
+			FriendlyMessageToUser: err.Error(),
+		}}
+	}
+
+	return checkRemoteWrite(context.Background(), promAPI)
+}
+
+func checkRemoteWrite(ctx context.Context, promAPI promv1.API) []*healthcheckPb.CheckResult {
+	checkResult := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    public.PromClientSubsystemName,
+		CheckDescription: remoteWriteCheckDescription,
+	}
+
+	res, err := promAPI.Query(ctx, remoteWriteQuery, time.Time{})
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to query prometheus: %s", err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	vec, ok := res.(model.Vector)
+	if !ok {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Unexpected query result type (expected Vector): %s", res.Type())
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+	if len(vec) == 0 {
+		return nil
+	}
+
+	if vec[0].Value <= 0 {
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = "No samples were accepted by the remote storage endpoint; check the prometheus logs for errors"
+	}
+
+	return []*healthcheckPb.CheckResult{checkResult}
+}
+
+// grafanaStatusChecker checks that the Grafana that the dashboard links to,
+// either the bundled one or the external one configured at install time,
+// answers its health endpoint.
+type grafanaStatusChecker struct {
+	kubeAPI k8s.KubernetesApi
+}
+
+func (c *grafanaStatusChecker) SelfCheck() []*healthcheckPb.CheckResult {
+	checkResult := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_ERROR,
+		SubsystemName:    grafanaSubsystemName,
+		CheckDescription: grafanaHealthCheckDescription,
+	}
+
+	externalURL, err := fetchLinkerdConfig(c.kubeAPI, controlPlaneNamespace, k8s.LinkerdConfigGrafanaURLKey)
+	if err != nil {
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to read configmap [%s]: %s", k8s.LinkerdConfigMapName, err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	client := &http.Client{Timeout: grafanaHealthTimeout}
+	if externalURL == "" {
+		client, err = c.kubeAPI.NewClient()
+		if err != nil {
+			checkResult.FriendlyMessageToUser = err.Error()
+			return []*healthcheckPb.CheckResult{checkResult}
+		}
+	}
+
+	healthURL, err := grafanaURLFor(externalURL, "/api/health", c.kubeAPI.UrlFor)
+	if err != nil {
+		checkResult.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	return checkGrafanaHealth(client, healthURL)
+}
+
+func checkGrafanaHealth(client *http.Client, healthURL *url.URL) []*healthcheckPb.CheckResult {
+	checkResult := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    grafanaSubsystemName,
+		CheckDescription: grafanaHealthCheckDescription,
+	}
+
+	rsp, err := client.Get(healthURL.String())
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to query Grafana at [%s]: %s", healthURL, err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+	defer rsp.Body.Close()
+
+	// Grafana reports the state of its database, which is the only thing it
+	// needs to serve dashboards besides the datasources.
+	var health struct {
+		Database string `json:"database"`
+	}
+	if rsp.StatusCode != http.StatusOK {
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Grafana at [%s] is unhealthy: %s", healthURL, rsp.Status)
+	} else if err := json.NewDecoder(rsp.Body).Decode(&health); err != nil || health.Database != "ok" {
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Grafana at [%s] did not report a healthy database", healthURL)
+	}
+
+	return []*healthcheckPb.CheckResult{checkResult}
+}
+
+// serviceAccountAnnotationStatusChecker checks the workload identity
+// annotations of the controller's ServiceAccount, which bind the controller to
+// an identity in the cloud provider's IAM. It reports no results if the
+// ServiceAccount has none of these annotations.
+type serviceAccountAnnotationStatusChecker struct {
+	kubeAPI k8s.KubernetesApi
+}
+
+func (c *serviceAccountAnnotationStatusChecker) SelfCheck() []*healthcheckPb.CheckResult {
+	checkResult := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    serviceAccountSubsystemName,
+		CheckDescription: serviceAccountAnnotationCheckDescription,
+	}
+
+	client, err := c.kubeAPI.NewClient()
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	var serviceAccount v1.ServiceAccount
+	err = getKubernetesObject(client, c.kubeAPI, controlPlaneNamespace, "/serviceaccounts/"+controllerServiceAccountName, &serviceAccount)
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to read service account [%s]: %s", controllerServiceAccountName, err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	return checkServiceAccountAnnotations(checkResult, serviceAccount.Annotations)
+}
+
+// checkServiceAccountAnnotations fails checkResult if any of the workload
+// identity annotations is empty or malformed. It returns no results if there
+// are no such annotations.
+func checkServiceAccountAnnotations(checkResult *healthcheckPb.CheckResult, annotations map[string]string) []*healthcheckPb.CheckResult {
+	found := false
+	var invalid []string
+	for annotation, value := range annotations {
+		format, ok := workloadIdentityAnnotations[annotation]
+		if !ok {
+			continue
+		}
+		found = true
+
+		switch {
+		case strings.TrimSpace(value) == "":
+			invalid = append(invalid, fmt.Sprintf("[%s] is empty", annotation))
+		case !format.format.MatchString(value):
+			invalid = append(invalid, fmt.Sprintf("[%s] has invalid value [%s] (expected a value like [%s])", annotation, value, format.example))
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Service account [%s] annotation %s", controllerServiceAccountName, strings.Join(invalid, ", "))
+	}
+
+	return []*healthcheckPb.CheckResult{checkResult}
+}
+
+// profileValidatorStatusChecker checks that the sp-validator webhook's serving
+// certificate is valid for its service name, and that the webhook rejects an
+// invalid ServiceProfile. It reports no results if the control plane was
+// installed without --enable-profile-validation.
+type profileValidatorStatusChecker struct {
+	kubeAPI k8s.KubernetesApi
+}
+
+func (c *profileValidatorStatusChecker) SelfCheck() []*healthcheckPb.CheckResult {
+	certificateCheck := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    profileValidatorSubsystemName,
+		CheckDescription: "serving certificate is valid for the webhook service",
+	}
+
+	client, err := c.kubeAPI.NewClient()
+	if err != nil {
+		certificateCheck.Status = healthcheckPb.CheckStatus_ERROR
+		certificateCheck.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{certificateCheck}
+	}
+
+	var secret v1.Secret
+	err = getKubernetesObject(client, c.kubeAPI, controlPlaneNamespace, "/secrets/"+profileValidatorTLSSecretName, &secret)
+	if err == errKubernetesObjectNotFound {
+		return nil
+	}
+	if err != nil {
+		certificateCheck.Status = healthcheckPb.CheckStatus_ERROR
+		certificateCheck.FriendlyMessageToUser = fmt.Sprintf("Failed to read secret [%s]: %s", profileValidatorTLSSecretName, err)
+		return []*healthcheckPb.CheckResult{certificateCheck}
+	}
+
+	serverName := profileValidatorServerName(controlPlaneNamespace)
+	if err := tls.VerifyServingCertificate(secret.Data["tls.crt"], secret.Data["ca.crt"], serverName, time.Now()); err != nil {
+		certificateCheck.Status = healthcheckPb.CheckStatus_FAIL
+		certificateCheck.FriendlyMessageToUser = err.Error()
+	}
+
+	servingCheck := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    profileValidatorSubsystemName,
+		CheckDescription: "webhook rejects invalid service profiles",
+	}
+
+	review := admissionv1beta1.AdmissionReview{
+		Request: &admissionv1beta1.AdmissionRequest{
+			UID:       "linkerd-check",
+			Namespace: controlPlaneNamespace,
+			Operation: admissionv1beta1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(profileValidatorProbe)},
+		},
+	}
+	body, err := json.Marshal(&review)
+	if err != nil {
+		servingCheck.Status = healthcheckPb.CheckStatus_ERROR
+		servingCheck.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{certificateCheck, servingCheck}
+	}
+
+	// The API server proxies the probe to the webhook, like it would an
+	// admission review, but without verifying its certificate.
+	proxyURL, err := c.kubeAPI.UrlFor(controlPlaneNamespace, "/services/https:sp-validator:443/proxy/")
+	if err != nil {
+		servingCheck.Status = healthcheckPb.CheckStatus_ERROR
+		servingCheck.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{certificateCheck, servingCheck}
+	}
+
+	rsp, err := client.Post(proxyURL.String(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		servingCheck.Status = healthcheckPb.CheckStatus_FAIL
+		servingCheck.FriendlyMessageToUser = fmt.Sprintf("Failed to reach the webhook: %s", err)
+		return []*healthcheckPb.CheckResult{certificateCheck, servingCheck}
+	}
+	defer rsp.Body.Close()
+
+	rspBody, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		servingCheck.Status = healthcheckPb.CheckStatus_FAIL
+		servingCheck.FriendlyMessageToUser = fmt.Sprintf("Failed to read the webhook's response: %s", err)
+		return []*healthcheckPb.CheckResult{certificateCheck, servingCheck}
+	}
+
+	return []*healthcheckPb.CheckResult{certificateCheck, checkProfileValidatorResponse(servingCheck, rsp.StatusCode, rspBody)}
+}
+
+// checkProfileValidatorResponse fails checkResult unless the sp-validator
+// webhook responded to the probe with a review that rejects it.
+func checkProfileValidatorResponse(checkResult *healthcheckPb.CheckResult, status int, body []byte) *healthcheckPb.CheckResult {
+	if status != http.StatusOK {
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Webhook responded with HTTP status [%d]: %s", status, strings.TrimSpace(string(body)))
+		return checkResult
+	}
+
+	var review admissionv1beta1.AdmissionReview
+	if err := json.Unmarshal(body, &review); err != nil || review.Response == nil {
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = "Webhook did not respond with an admission review"
+		return checkResult
+	}
+
+	if review.Response.Allowed {
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = "Webhook admitted a service profile without routes"
+	}
+	return checkResult
+}
+
+// cniPluginStatusChecker checks that the plugin binary that the linkerd-cni
+// DaemonSet installed on each node has the hash of the release's plugin
+// binary. It reports no results if linkerd-cni isn't installed, and skips the
+// check if the DaemonSet was installed without --cni-plugin-sha256.
+type cniPluginStatusChecker struct {
+	kubeAPI k8s.KubernetesApi
+
+	// releaseSHA256 is the hash of the plugin binary of the release that the
+	// CLI was built for, if the build recorded it.
+	releaseSHA256 string
+}
+
+func (c *cniPluginStatusChecker) SelfCheck() []*healthcheckPb.CheckResult {
+	checkResult := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    cniSubsystemName,
+		CheckDescription: cniPluginHashDescription,
+	}
+
+	client, err := c.kubeAPI.NewClient()
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	var configMap v1.ConfigMap
+	err = getKubernetesObject(client, c.kubeAPI, controlPlaneNamespace, "/configmaps/"+k8s.CNIConfigMapName, &configMap)
+	if err == errKubernetesObjectNotFound {
+		return nil
+	}
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to read config map [%s]: %s", k8s.CNIConfigMapName, err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+	installedWith := configMap.Data[k8s.CNIPluginSHA256Key]
+	if installedWith == "" {
+		checkResult.Status = healthcheckPb.CheckStatus_SKIPPED
+		checkResult.FriendlyMessageToUser = "linkerd-cni was installed without --cni-plugin-sha256"
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	expected, err := expectedCNIPluginSHA256(installedWith, c.releaseSHA256)
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	selector := url.QueryEscape(fmt.Sprintf("%s=cni", k8s.ControllerComponentLabel))
+	var pods v1.PodList
+	err = getKubernetesObject(client, c.kubeAPI, controlPlaneNamespace, "/pods?labelSelector="+selector, &pods)
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to list linkerd-cni pods: %s", err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	return []*healthcheckPb.CheckResult{checkCNIPluginHashes(checkResult, expected, pods.Items, installedCNIPluginSHA256)}
+}
+
+// expectedCNIPluginSHA256 returns the hash that the plugin binaries installed
+// on the nodes must have: releaseSHA256, the hash of the plugin binary of the
+// CLI's release, rather than installedWith, the hash that linkerd-cni was
+// installed with, which is stored alongside the DaemonSet and so can't be
+// trusted on its own. The latter is only used by builds of the CLI that don't
+// record the release's hash, and must otherwise match it.
+func expectedCNIPluginSHA256(installedWith, releaseSHA256 string) (string, error) {
+	if releaseSHA256 == "" {
+		return installedWith, nil
+	}
+	if !strings.EqualFold(installedWith, releaseSHA256) {
+		return "", fmt.Errorf("linkerd-cni was installed with --cni-plugin-sha256 [%s], but the plugin binary of this release has hash [%s]", installedWith, releaseSHA256)
+	}
+	return releaseSHA256, nil
+}
+
+// installedCNIPluginSHA256 returns the hash of the plugin binary that a
+// linkerd-cni pod installed on its node, as printed by the pod's installer.
+// It's a variable so that tests don't run kubectl.
+var installedCNIPluginSHA256 = func(pod v1.Pod) (string, error) {
+	args := []string{"exec", pod.Name, "--namespace", pod.Namespace, "--container", cniInstallerContainerName}
+	if kubeconfigPath != "" {
+		args = append(args, "--kubeconfig", kubeconfigPath)
+	}
+	if kubeContext != "" {
+		args = append(args, "--context", kubeContext)
+	}
+	args = append(args, "--", cniInstallerBinary, "-print-installed-sha256")
+
+	out, err := exec.Command("kubectl", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to hash the plugin binary: %s", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// checkCNIPluginHashes fails checkResult unless hashOf returns expected for
+// every running linkerd-cni pod in pods.
+func checkCNIPluginHashes(checkResult *healthcheckPb.CheckResult, expected string, pods []v1.Pod, hashOf func(v1.Pod) (string, error)) *healthcheckPb.CheckResult {
+	var problems []string
+	running := 0
+	for _, pod := range pods {
+		if pod.Status.Phase != v1.PodRunning {
+			continue
+		}
+		running++
+
+		hash, err := hashOf(pod)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("pod [%s] on node [%s]: %s", pod.Name, pod.Spec.NodeName, err))
+			continue
+		}
+		if !strings.EqualFold(hash, expected) {
+			problems = append(problems, fmt.Sprintf("pod [%s] on node [%s] installed a binary with hash [%s]", pod.Name, pod.Spec.NodeName, hash))
+		}
+	}
+
+	switch {
+	case running == 0:
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = "No running linkerd-cni pods to check the plugin binaries of"
+	case len(problems) > 0:
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Expected plugin binaries with hash [%s]; %s", expected, strings.Join(problems, "; "))
+	}
+	return checkResult
+}
+
+// endpointPopulationStatusChecker checks that every control plane Service has
+// endpoints, which it doesn't when its selector matches no pod, or when none of
+// the pods it matches is ready.
+type endpointPopulationStatusChecker struct {
+	kubeAPI k8s.KubernetesApi
+}
+
+func (c *endpointPopulationStatusChecker) SelfCheck() []*healthcheckPb.CheckResult {
+	checkResult := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    endpointsSubsystemName,
+		CheckDescription: endpointPopulationDescription,
+	}
+
+	client, err := c.kubeAPI.NewClient()
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	selector := url.QueryEscape(k8s.ControllerComponentLabel)
+	var services v1.ServiceList
+	err = getKubernetesObject(client, c.kubeAPI, controlPlaneNamespace, "/services?labelSelector="+selector, &services)
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to list services: %s", err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	var endpoints v1.EndpointsList
+	err = getKubernetesObject(client, c.kubeAPI, controlPlaneNamespace, "/endpoints", &endpoints)
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to list endpoints: %s", err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	return []*healthcheckPb.CheckResult{checkServiceEndpoints(checkResult, services.Items, endpoints.Items)}
+}
+
+// checkServiceEndpoints fails checkResult if any of the services that select
+// pods has no Endpoints, or Endpoints without subsets.
+func checkServiceEndpoints(checkResult *healthcheckPb.CheckResult, services []v1.Service, endpoints []v1.Endpoints) *healthcheckPb.CheckResult {
+	populated := make(map[string]bool)
+	for _, e := range endpoints {
+		populated[e.Name] = len(e.Subsets) > 0
+	}
+
+	var empty []string
+	for _, service := range services {
+		// services without a selector have their endpoints managed by hand
+		if len(service.Spec.Selector) == 0 {
+			continue
+		}
+		if !populated[service.Name] {
+			empty = append(empty, fmt.Sprintf("[%s]", service.Name))
+		}
+	}
+	if len(empty) > 0 {
+		sort.Strings(empty)
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Services with no endpoints: %s", strings.Join(empty, ", "))
+	}
+
+	return checkResult
+}
+
+// clusterRoleIntegrityStatusChecker checks that the control plane's
+// ClusterRoles have the rules that this version of the CLI installs them with,
+// so that roles that were edited after the install are caught. The roles of
+// optional components that aren't installed are skipped.
+type clusterRoleIntegrityStatusChecker struct {
+	kubeAPI k8s.KubernetesApi
+}
+
+func (c *clusterRoleIntegrityStatusChecker) SelfCheck() []*healthcheckPb.CheckResult {
+	checkResult := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    rbacSubsystemName,
+		CheckDescription: clusterRoleIntegrityDescription,
+	}
+
+	expected, err := expectedClusterRoleRules()
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to render the expected ClusterRoles: %s", err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	actual, err := fetchClusterRoleRules(c.kubeAPI)
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to list ClusterRoles: %s", err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	return []*healthcheckPb.CheckResult{checkClusterRoleRules(checkResult, expected, actual)}
+}
+
+// expectedClusterRoleRules returns the rules of the ClusterRoles that install
+// renders for the control plane namespace, by name, with every optional
+// component that has a ClusterRole enabled.
+func expectedClusterRoleRules() (map[string][]rbacv1beta1.PolicyRule, error) {
+	options := newInstallOptions()
+	options.tls = optionalTLS
+	options.linkerdCNI = true
+	options.adminRBACGroup = "linkerd-check"
+
+	config, err := validateAndBuildConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	if err := render(*config, buf, options); err != nil {
+		return nil, err
+	}
+
+	rules := make(map[string][]rbacv1beta1.PolicyRule)
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReader(buf))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var role rbacv1beta1.ClusterRole
+		if err := yaml.Unmarshal(doc, &role); err != nil {
+			return nil, err
+		}
+		if role.Kind == "ClusterRole" {
+			rules[role.Name] = role.Rules
+		}
+	}
+	return rules, nil
+}
+
+// fetchClusterRoleRules returns the rules of all the ClusterRoles in the
+// cluster, by name.
+func fetchClusterRoleRules(kubeAPI k8s.KubernetesApi) (map[string][]rbacv1beta1.PolicyRule, error) {
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var roles rbacv1beta1.ClusterRoleList
+	if err := getClusterObject(client, kubeAPI, clusterRolesAPIPath, &roles); err != nil {
+		return nil, err
+	}
+
+	rules := make(map[string][]rbacv1beta1.PolicyRule)
+	for _, role := range roles.Items {
+		rules[role.Name] = role.Rules
+	}
+	return rules, nil
+}
+
+// checkClusterRoleRules fails checkResult, with a diff of the rules that each
+// role is missing and has in addition, unless every role in expected that's in
+// actual has the same rules. Neither the order of the rules nor the order of
+// the values in each rule matter.
+func checkClusterRoleRules(checkResult *healthcheckPb.CheckResult, expected, actual map[string][]rbacv1beta1.PolicyRule) *healthcheckPb.CheckResult {
+	var modified []string
+	for name, expectedRules := range expected {
+		actualRules, ok := actual[name]
+		if !ok {
+			continue
+		}
+
+		want := make(map[string]bool)
+		for _, rule := range expectedRules {
+			want[formatPolicyRule(rule)] = true
+		}
+		have := make(map[string]bool)
+		for _, rule := range actualRules {
+			have[formatPolicyRule(rule)] = true
+		}
+
+		var diff []string
+		for rule := range want {
+			if !have[rule] {
+				diff = append(diff, "-"+rule)
+			}
+		}
+		for rule := range have {
+			if !want[rule] {
+				diff = append(diff, "+"+rule)
+			}
+		}
+		if len(diff) > 0 {
+			sort.Strings(diff)
+			modified = append(modified, fmt.Sprintf("[%s] (%s)", name, strings.Join(diff, ", ")))
+		}
+	}
+	if len(modified) > 0 {
+		sort.Strings(modified)
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Modified ClusterRoles: %s", strings.Join(modified, ", "))
+	}
+
+	return checkResult
+}
+
+// formatPolicyRule formats rule's non-empty fields, with their values sorted.
+func formatPolicyRule(rule rbacv1beta1.PolicyRule) string {
+	fields := []struct {
+		name   string
+		values []string
+	}{
+		{"apiGroups", rule.APIGroups},
+		{"resources", rule.Resources},
+		{"resourceNames", rule.ResourceNames},
+		{"nonResourceURLs", rule.NonResourceURLs},
+		{"verbs", rule.Verbs},
+	}
+
+	var parts []string
+	for _, field := range fields {
+		if len(field.values) == 0 {
+			continue
+		}
+		values := append([]string{}, field.values...)
+		sort.Strings(values)
+		parts = append(parts, fmt.Sprintf("%s: %q", field.name, values))
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// deprecatedAnnotationStatusChecker warns about the pods and Deployments, in
+// all namespaces, that have any of the deprecatedAnnotations. The deprecated
+// annotations are only reported as warnings, so the check itself only fails
+// if the workloads can't be listed.
+type deprecatedAnnotationStatusChecker struct {
+	kubeAPI  k8s.KubernetesApi
+	warnings []string
+}
+
+func (c *deprecatedAnnotationStatusChecker) SelfCheck() []*healthcheckPb.CheckResult {
+	checkResult := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    annotationsSubsystemName,
+		CheckDescription: deprecatedAnnotationDescription,
+	}
+
+	client, err := c.kubeAPI.NewClient()
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	var pods []v1.Pod
+	err = listClusterObjects(client, c.kubeAPI, "/api/v1/pods", func() (metav1.ListInterface, func()) {
+		page := &v1.PodList{}
+		return page, func() { pods = append(pods, page.Items...) }
+	})
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to list pods: %s", err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	// apps/v1beta2, rather than apps/v1, is served by every supported
+	// Kubernetes version.
+	var deployments []appsv1beta2.Deployment
+	err = listClusterObjects(client, c.kubeAPI, "/apis/apps/v1beta2/deployments", func() (metav1.ListInterface, func()) {
+		page := &appsv1beta2.DeploymentList{}
+		return page, func() { deployments = append(deployments, page.Items...) }
+	})
+	if err != nil {
+		checkResult.Status = healthcheckPb.CheckStatus_ERROR
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Failed to list deployments: %s", err)
+		return []*healthcheckPb.CheckResult{checkResult}
+	}
+
+	c.warnings = findDeprecatedAnnotations(pods, deployments)
+	return []*healthcheckPb.CheckResult{checkResult}
+}
+
+// findDeprecatedAnnotations returns a warning, with the replacement, for each
+// of the deprecatedAnnotations of each of pods, deployments, and their pod
+// templates.
+func findDeprecatedAnnotations(pods []v1.Pod, deployments []appsv1beta2.Deployment) []string {
+	var warnings []string
+	check := func(description string, annotations map[string]string) {
+		for key := range annotations {
+			if replacement, ok := deprecatedAnnotations[key]; ok {
+				warnings = append(warnings, fmt.Sprintf("%s has the deprecated annotation [%s]; use [%s] instead", description, key, replacement))
+			}
+		}
+	}
+
+	for _, pod := range pods {
+		check(fmt.Sprintf("Pod %s/%s", pod.Namespace, pod.Name), pod.Annotations)
+	}
+	for _, deployment := range deployments {
+		check(fmt.Sprintf("Deployment %s/%s", deployment.Namespace, deployment.Name), deployment.Annotations)
+		check(fmt.Sprintf("The pod template of Deployment %s/%s", deployment.Namespace, deployment.Name), deployment.Spec.Template.Annotations)
+	}
+
+	sort.Strings(warnings)
+	return warnings
+}
+
+// newPrometheusAPI returns a client for the control plane's Prometheus: the
+// external one that it was installed with, if any, and the bundled one,
+// through the Kubernetes API's service proxy, otherwise.
+func newPrometheusAPI(kubeAPI k8s.KubernetesApi) (promv1.API, error) {
+	externalURL, err := fetchLinkerdConfig(kubeAPI, controlPlaneNamespace, k8s.LinkerdConfigPrometheusURLKey)
+	if err != nil {
+		return nil, err
+	}
+	if externalURL != "" {
+		promClient, err := promApi.NewClient(promApi.Config{Address: externalURL})
+		if err != nil {
+			return nil, err
+		}
+		return promv1.NewAPI(promClient), nil
+	}
+
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	proxyURL, err := kubeAPI.UrlFor(controlPlaneNamespace, "/services/prometheus:9090/proxy")
+	if err != nil {
+		return nil, err
+	}
+
+	promClient, err := promApi.NewClient(promApi.Config{Address: proxyURL.String(), RoundTripper: client.Transport})
+	if err != nil {
+		return nil, err
+	}
+	return promv1.NewAPI(promClient), nil
+}
@@ -2,11 +2,28 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/linkerd/linkerd2/controller/api/public"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	"github.com/prometheus/common/model"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	"k8s.io/api/core/v1"
+	rbacv1beta1 "k8s.io/api/rbac/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestCheckStatus(t *testing.T) {
@@ -31,6 +48,12 @@ func TestCheckStatus(t *testing.T) {
 				Status:                healthcheckPb.CheckStatus_ERROR,
 				FriendlyMessageToUser: "This should contain instructions for err",
 			},
+			{
+				SubsystemName:         k8s.KubeapiSubsystemName,
+				CheckDescription:      k8s.KubeapiAccessCheckDescription,
+				Status:                healthcheckPb.CheckStatus_SKIPPED,
+				FriendlyMessageToUser: "This should say why the check was skipped",
+			},
 		}
 
 		output := bytes.NewBufferString("")
@@ -48,3 +71,798 @@ func TestCheckStatus(t *testing.T) {
 		}
 	})
 }
+
+func TestSeverityLevel(t *testing.T) {
+	testCases := []struct {
+		status        healthcheckPb.CheckStatus
+		expectedName  string
+		expectedLevel int
+	}{
+		{healthcheckPb.CheckStatus_OK, "ok", 1},
+		{healthcheckPb.CheckStatus_FAIL, "fail", 3},
+		{healthcheckPb.CheckStatus_ERROR, "error", 4},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.status.String(), func(t *testing.T) {
+			if name := checkStatusName(tc.status); name != tc.expectedName {
+				t.Fatalf("Expected status name %s, got %s", tc.expectedName, name)
+			}
+			if level := severityLevel(tc.status); level != tc.expectedLevel {
+				t.Fatalf("Expected severity level %d, got %d", tc.expectedLevel, level)
+			}
+		})
+	}
+}
+
+func TestRenderCheckResultsJSON(t *testing.T) {
+	t.Run("Renders the severity level of each check", func(t *testing.T) {
+		kubeApi := &k8s.MockKubeApi{}
+		kubeApi.SelfCheckResultsToReturn = []*healthcheckPb.CheckResult{
+			{
+				SubsystemName:         k8s.KubeapiSubsystemName,
+				CheckDescription:      k8s.KubeapiClientCheckDescription,
+				Status:                healthcheckPb.CheckStatus_FAIL,
+				FriendlyMessageToUser: "This should contain instructions for fail",
+			},
+			{
+				SubsystemName:         k8s.KubeapiSubsystemName,
+				CheckDescription:      k8s.KubeapiAccessCheckDescription,
+				Status:                healthcheckPb.CheckStatus_OK,
+				FriendlyMessageToUser: "This shouldn't be printed",
+			},
+			{
+				SubsystemName:         k8s.KubeapiSubsystemName,
+				CheckDescription:      k8s.KubeapiVersionCheckDescription,
+				Status:                healthcheckPb.CheckStatus_ERROR,
+				FriendlyMessageToUser: "This should contain instructions for err",
+			},
+		}
+
+		results, status := performChecks(kubeApi)
+		output := bytes.NewBufferString("")
+		err := renderCheckResultsJSON(output, results, status, []string{"The trust anchor expires soon"})
+		if err == nil || err.Error() != "error during status check" {
+			t.Fatalf("Expected error during status check, got: %v", err)
+		}
+
+		diffCompare(t, output.String(), readOptionalTestFile(t, "check_output_json.golden"))
+	})
+
+	t.Run("Warnings raise the severity level of passing checks", func(t *testing.T) {
+		results := []*healthcheckPb.CheckResult{
+			{
+				SubsystemName:    k8s.KubeapiSubsystemName,
+				CheckDescription: k8s.KubeapiAccessCheckDescription,
+				Status:           healthcheckPb.CheckStatus_OK,
+			},
+		}
+
+		output := bytes.NewBufferString("")
+		if err := renderCheckResultsJSON(output, results, healthcheckPb.CheckStatus_OK, []string{"The trust anchor expires soon"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var rendered checkOutputJSON
+		if err := json.Unmarshal(output.Bytes(), &rendered); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if rendered.Status != "ok" || rendered.SeverityLevel != 2 {
+			t.Fatalf("Expected status ok with severity level 2, got %s with %d", rendered.Status, rendered.SeverityLevel)
+		}
+		if rendered.Checks[0].SeverityLevel != 1 {
+			t.Fatalf("Expected the check's severity level to be 1, got %d", rendered.Checks[0].SeverityLevel)
+		}
+	})
+}
+
+func TestVerifyInternalTLSCertificate(t *testing.T) {
+	ca, err := tls.NewInternalCA("linkerd-internal-ca.linkerd", time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	crt, err := ca.IssueServingCertificate("api.linkerd.svc.example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	secret := &v1.Secret{
+		Data: map[string][]byte{
+			"api.crt": []byte(crt.CertificatePEM),
+			"ca.crt":  []byte(ca.CertificatePEM()),
+		},
+	}
+
+	if err := verifyInternalTLSCertificate(secret, "linkerd", "example.com", time.Now()); err != nil {
+		t.Fatalf("Expected the certificate to be valid in the example.com domain, got: %v", err)
+	}
+	if err := verifyInternalTLSCertificate(secret, "linkerd", "cluster.local", time.Now()); err == nil {
+		t.Fatalf("Expected the certificate to be invalid in the cluster.local domain")
+	}
+}
+
+func TestFetchClusterDNSDomain(t *testing.T) {
+	newMockKubeAPI := func(t *testing.T, server *httptest.Server) *k8s.MockKubeApi {
+		u, err := url.Parse(server.URL + "/api/v1/namespaces/linkerd/configmaps/linkerd-config")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return &k8s.MockKubeApi{UrlForUrlToReturn: u, NewClientClientToReturn: server.Client()}
+	}
+
+	t.Run("Returns the domain in the Linkerd config", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(`{"kind":"ConfigMap","data":{"clusterDnsDomain":"example.com"}}`))
+		}))
+		defer server.Close()
+
+		clusterDNSDomain, err := fetchClusterDNSDomain(newMockKubeAPI(t, server), "linkerd")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if clusterDNSDomain != "example.com" {
+			t.Fatalf("Expected domain [example.com], got [%s]", clusterDNSDomain)
+		}
+	})
+
+	t.Run("Returns the default domain without the Linkerd config", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		clusterDNSDomain, err := fetchClusterDNSDomain(newMockKubeAPI(t, server), "linkerd")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if clusterDNSDomain != k8s.DefaultClusterDNSDomain {
+			t.Fatalf("Expected domain [%s], got [%s]", k8s.DefaultClusterDNSDomain, clusterDNSDomain)
+		}
+	})
+}
+
+func TestCheckPersistentVolumeClaims(t *testing.T) {
+	claim := func(name string, phase v1.PersistentVolumeClaimPhase) v1.PersistentVolumeClaim {
+		return v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: phase},
+		}
+	}
+
+	t.Run("Reports nothing without persistence", func(t *testing.T) {
+		results := checkPersistentVolumeClaims(&healthcheckPb.CheckResult{}, nil)
+		if len(results) != 0 {
+			t.Fatalf("Expected no results, got %v", results)
+		}
+	})
+
+	t.Run("Passes when all claims are bound", func(t *testing.T) {
+		claims := []v1.PersistentVolumeClaim{
+			claim("data-prometheus-0", v1.ClaimBound),
+			claim("data-prometheus-1", v1.ClaimBound),
+		}
+		results := checkPersistentVolumeClaims(&healthcheckPb.CheckResult{Status: healthcheckPb.CheckStatus_OK}, claims)
+		if len(results) != 1 || results[0].Status != healthcheckPb.CheckStatus_OK {
+			t.Fatalf("Expected an OK result, got %v", results)
+		}
+	})
+
+	t.Run("Fails when a claim is not bound", func(t *testing.T) {
+		claims := []v1.PersistentVolumeClaim{
+			claim("data-prometheus-0", v1.ClaimBound),
+			claim("data-prometheus-1", v1.ClaimPending),
+		}
+		results := checkPersistentVolumeClaims(&healthcheckPb.CheckResult{Status: healthcheckPb.CheckStatus_OK}, claims)
+		if len(results) != 1 || results[0].Status != healthcheckPb.CheckStatus_FAIL {
+			t.Fatalf("Expected a FAIL result, got %v", results)
+		}
+		expected := "Persistent volume claim [data-prometheus-1] is Pending"
+		if results[0].FriendlyMessageToUser != expected {
+			t.Fatalf("Expected message [%s], got [%s]", expected, results[0].FriendlyMessageToUser)
+		}
+	})
+}
+
+func TestCheckServiceEndpoints(t *testing.T) {
+	service := func(name string, selector map[string]string) v1.Service {
+		return v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1.ServiceSpec{Selector: selector},
+		}
+	}
+	endpoints := func(name string, addresses ...string) v1.Endpoints {
+		e := v1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if len(addresses) > 0 {
+			subset := v1.EndpointSubset{}
+			for _, address := range addresses {
+				subset.Addresses = append(subset.Addresses, v1.EndpointAddress{IP: address})
+			}
+			e.Subsets = []v1.EndpointSubset{subset}
+		}
+		return e
+	}
+	selector := map[string]string{k8s.ControllerComponentLabel: "controller"}
+
+	testCases := []struct {
+		name      string
+		services  []v1.Service
+		endpoints []v1.Endpoints
+		status    healthcheckPb.CheckStatus
+		message   string
+	}{
+		{
+			"Passes when all services have endpoints",
+			[]v1.Service{service("api", selector), service("proxy-api", selector)},
+			[]v1.Endpoints{endpoints("api", "10.1.1.1"), endpoints("proxy-api", "10.1.1.1", "10.1.1.2")},
+			healthcheckPb.CheckStatus_OK,
+			"",
+		},
+		{
+			"Fails when a service's endpoints have no subsets",
+			[]v1.Service{service("web", selector), service("api", selector), service("grafana", selector)},
+			[]v1.Endpoints{endpoints("web"), endpoints("api", "10.1.1.1"), endpoints("grafana")},
+			healthcheckPb.CheckStatus_FAIL,
+			"Services with no endpoints: [grafana], [web]",
+		},
+		{
+			"Fails when a service has no endpoints",
+			[]v1.Service{service("api", selector), service("prometheus", selector)},
+			[]v1.Endpoints{endpoints("api", "10.1.1.1")},
+			healthcheckPb.CheckStatus_FAIL,
+			"Services with no endpoints: [prometheus]",
+		},
+		{
+			"Ignores services without a selector",
+			[]v1.Service{service("api", selector), service("external", nil)},
+			[]v1.Endpoints{endpoints("api", "10.1.1.1"), endpoints("external")},
+			healthcheckPb.CheckStatus_OK,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			result := checkServiceEndpoints(&healthcheckPb.CheckResult{Status: healthcheckPb.CheckStatus_OK}, tc.services, tc.endpoints)
+			if result.Status != tc.status {
+				t.Fatalf("Expected status %s, got %s", tc.status, result.Status)
+			}
+			if result.FriendlyMessageToUser != tc.message {
+				t.Fatalf("Expected message [%s], got [%s]", tc.message, result.FriendlyMessageToUser)
+			}
+		})
+	}
+}
+
+func TestExpectedClusterRoleRules(t *testing.T) {
+	rules, err := expectedClusterRoleRules()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, component := range []string{"controller", "prometheus", "ca", "cni", "cli-admin"} {
+		name := fmt.Sprintf("linkerd-%s-%s", controlPlaneNamespace, component)
+		if len(rules[name]) == 0 {
+			t.Errorf("Expected rules for ClusterRole [%s]", name)
+		}
+	}
+}
+
+func TestCheckClusterRoleRules(t *testing.T) {
+	podsRule := rbacv1beta1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"list", "get", "watch"}}
+	jobsRule := rbacv1beta1.PolicyRule{APIGroups: []string{"batch"}, Resources: []string{"jobs"}, Verbs: []string{"list", "get", "watch"}}
+	expected := map[string][]rbacv1beta1.PolicyRule{
+		"linkerd-linkerd-controller": {podsRule, jobsRule},
+		"linkerd-linkerd-prometheus": {podsRule},
+	}
+
+	testCases := []struct {
+		name    string
+		actual  map[string][]rbacv1beta1.PolicyRule
+		status  healthcheckPb.CheckStatus
+		message string
+	}{
+		{
+			"Passes when the roles have the expected rules, in any order",
+			map[string][]rbacv1beta1.PolicyRule{
+				"linkerd-linkerd-controller": {
+					jobsRule,
+					{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"watch", "list", "get"}},
+				},
+				"linkerd-linkerd-prometheus": {podsRule},
+				"cluster-admin":              {{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+			},
+			healthcheckPb.CheckStatus_OK,
+			"",
+		},
+		{
+			"Skips roles that aren't installed",
+			map[string][]rbacv1beta1.PolicyRule{
+				"linkerd-linkerd-controller": {podsRule, jobsRule},
+			},
+			healthcheckPb.CheckStatus_OK,
+			"",
+		},
+		{
+			"Fails when a role is missing a rule",
+			map[string][]rbacv1beta1.PolicyRule{
+				"linkerd-linkerd-controller": {podsRule},
+				"linkerd-linkerd-prometheus": {podsRule},
+			},
+			healthcheckPb.CheckStatus_FAIL,
+			`Modified ClusterRoles: [linkerd-linkerd-controller] (-{apiGroups: ["batch"] resources: ["jobs"] verbs: ["get" "list" "watch"]})`,
+		},
+		{
+			"Fails with a diff when roles' rules were modified",
+			map[string][]rbacv1beta1.PolicyRule{
+				"linkerd-linkerd-controller": {
+					jobsRule,
+					{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"list", "get", "watch", "delete"}},
+				},
+				"linkerd-linkerd-prometheus": {
+					podsRule,
+					{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}},
+				},
+			},
+			healthcheckPb.CheckStatus_FAIL,
+			`Modified ClusterRoles: [linkerd-linkerd-controller] (+{apiGroups: [""] resources: ["pods"] verbs: ["delete" "get" "list" "watch"]}, -{apiGroups: [""] resources: ["pods"] verbs: ["get" "list" "watch"]}), [linkerd-linkerd-prometheus] (+{apiGroups: [""] resources: ["secrets"] verbs: ["get"]})`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			result := checkClusterRoleRules(&healthcheckPb.CheckResult{Status: healthcheckPb.CheckStatus_OK}, expected, tc.actual)
+			if result.Status != tc.status {
+				t.Fatalf("Expected status %s, got %s", tc.status, result.Status)
+			}
+			if result.FriendlyMessageToUser != tc.message {
+				t.Fatalf("Expected message [%s], got [%s]", tc.message, result.FriendlyMessageToUser)
+			}
+		})
+	}
+}
+
+func TestFindDeprecatedAnnotations(t *testing.T) {
+	pod := func(name string, annotations map[string]string) v1.Pod {
+		return v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "emojivoto", Name: name, Annotations: annotations}}
+	}
+	deployment := func(name string, annotations, templateAnnotations map[string]string) appsv1beta2.Deployment {
+		d := appsv1beta2.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "emojivoto", Name: name, Annotations: annotations}}
+		d.Spec.Template.Annotations = templateAnnotations
+		return d
+	}
+
+	testCases := []struct {
+		name        string
+		pods        []v1.Pod
+		deployments []appsv1beta2.Deployment
+		warnings    []string
+	}{
+		{
+			"No warnings without deprecated annotations",
+			[]v1.Pod{pod("web-1", map[string]string{k8s.CreatedByAnnotation: "linkerd/cli", k8s.ProxyVersionAnnotation: "v18.8.1"}), pod("emoji-1", nil)},
+			[]appsv1beta2.Deployment{deployment("web", nil, map[string]string{k8s.CreatedByAnnotation: "linkerd/cli"})},
+			nil,
+		},
+		{
+			"Warns about deprecated annotations of pods",
+			[]v1.Pod{pod("web-1", map[string]string{"conduit.io/created-by": "conduit/cli", "conduit.io/proxy-version": "v0.5.0"}), pod("emoji-1", nil)},
+			nil,
+			[]string{
+				"Pod emojivoto/web-1 has the deprecated annotation [conduit.io/created-by]; use [linkerd.io/created-by] instead",
+				"Pod emojivoto/web-1 has the deprecated annotation [conduit.io/proxy-version]; use [linkerd.io/proxy-version] instead",
+			},
+		},
+		{
+			"Warns about deprecated annotations of deployments and their pod templates",
+			nil,
+			[]appsv1beta2.Deployment{
+				deployment("web", map[string]string{"conduit.io/created-by": "conduit/cli"}, nil),
+				deployment("voting", nil, map[string]string{"conduit.io/proxy-version": "v0.5.0", "app": "voting"}),
+			},
+			[]string{
+				"Deployment emojivoto/web has the deprecated annotation [conduit.io/created-by]; use [linkerd.io/created-by] instead",
+				"The pod template of Deployment emojivoto/voting has the deprecated annotation [conduit.io/proxy-version]; use [linkerd.io/proxy-version] instead",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			warnings := findDeprecatedAnnotations(tc.pods, tc.deployments)
+			if !reflect.DeepEqual(warnings, tc.warnings) {
+				t.Fatalf("Expected warnings %v, got %v", tc.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestDeprecatedAnnotationStatusChecker(t *testing.T) {
+	pages := map[string]string{
+		"/api/v1/pods?limit=500":                   `{"metadata":{"continue":"page-2"},"items":[{"metadata":{"namespace":"emojivoto","name":"web-1","annotations":{"conduit.io/created-by":"conduit/cli"}}}]}`,
+		"/api/v1/pods?continue=page-2&limit=500":   `{"metadata":{},"items":[{"metadata":{"namespace":"emojivoto","name":"voting-1","annotations":{"conduit.io/proxy-version":"v0.5.0"}}}]}`,
+		"/apis/apps/v1beta2/deployments?limit=500": `{"metadata":{},"items":[{"metadata":{"namespace":"emojivoto","name":"web","annotations":{"conduit.io/created-by":"conduit/cli"}}}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		page, ok := pages[req.URL.RequestURI()]
+		if !ok {
+			t.Errorf("Unexpected request to %s", req.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL + "/api/v1/namespaces/" + controlPlaneNamespace + "/")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checker := &deprecatedAnnotationStatusChecker{
+		kubeAPI: &k8s.MockKubeApi{UrlForUrlToReturn: u, NewClientClientToReturn: server.Client()},
+	}
+
+	results := checker.SelfCheck()
+	if len(results) != 1 || results[0].Status != healthcheckPb.CheckStatus_OK {
+		t.Fatalf("Expected %s, got %v", healthcheckPb.CheckStatus_OK, results)
+	}
+
+	expected := []string{
+		"Deployment emojivoto/web has the deprecated annotation [conduit.io/created-by]; use [linkerd.io/created-by] instead",
+		"Pod emojivoto/voting-1 has the deprecated annotation [conduit.io/proxy-version]; use [linkerd.io/proxy-version] instead",
+		"Pod emojivoto/web-1 has the deprecated annotation [conduit.io/created-by]; use [linkerd.io/created-by] instead",
+	}
+	if !reflect.DeepEqual(checker.warnings, expected) {
+		t.Fatalf("Expected warnings %v, got %v", expected, checker.warnings)
+	}
+}
+
+func TestCheckRemoteWrite(t *testing.T) {
+	testCases := []struct {
+		name     string
+		res      model.Vector
+		expected []healthcheckPb.CheckStatus
+	}{
+		{"Reports nothing without remote write", model.Vector{}, nil},
+		{"Passes when samples were accepted", model.Vector{&model.Sample{Value: 1200}}, []healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_OK}},
+		{"Fails when no samples were accepted", model.Vector{&model.Sample{Value: 0}}, []healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_FAIL}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockProm := &public.MockProm{Res: tc.res}
+			results := checkRemoteWrite(context.Background(), mockProm)
+
+			if len(mockProm.QueriesExecuted) != 1 || mockProm.QueriesExecuted[0] != remoteWriteQuery {
+				t.Fatalf("Unexpected queries: %v", mockProm.QueriesExecuted)
+			}
+			if len(results) != len(tc.expected) {
+				t.Fatalf("Expected %d results, got %v", len(tc.expected), results)
+			}
+			for i, result := range results {
+				if result.Status != tc.expected[i] {
+					t.Fatalf("Expected %s, got %s: %s", tc.expected[i], result.Status, result.FriendlyMessageToUser)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckGrafanaHealth(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		body     string
+		expected healthcheckPb.CheckStatus
+	}{
+		{"Passes when Grafana's database is ok", http.StatusOK, `{"commit":"unknown","database":"ok","version":"5.1.3"}`, healthcheckPb.CheckStatus_OK},
+		{"Fails when Grafana's database is failing", http.StatusOK, `{"database":"failing"}`, healthcheckPb.CheckStatus_FAIL},
+		{"Fails when Grafana is unavailable", http.StatusServiceUnavailable, "", healthcheckPb.CheckStatus_FAIL},
+		{"Fails when Grafana does not answer with JSON", http.StatusOK, "<html></html>", healthcheckPb.CheckStatus_FAIL},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				path = req.URL.Path
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			healthURL, err := url.Parse(server.URL + "/api/health")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			results := checkGrafanaHealth(server.Client(), healthURL)
+			if path != "/api/health" {
+				t.Fatalf("Expected a request to /api/health, got %s", path)
+			}
+			if len(results) != 1 || results[0].Status != tc.expected {
+				t.Fatalf("Expected %s, got %v", tc.expected, results)
+			}
+		})
+	}
+
+	t.Run("Errors when Grafana cannot be reached", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		healthURL, err := url.Parse(server.URL + "/api/health")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		server.Close()
+
+		results := checkGrafanaHealth(http.DefaultClient, healthURL)
+		if len(results) != 1 || results[0].Status != healthcheckPb.CheckStatus_ERROR {
+			t.Fatalf("Expected %s, got %v", healthcheckPb.CheckStatus_ERROR, results)
+		}
+	})
+}
+
+func TestCheckServiceAccountAnnotations(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    []healthcheckPb.CheckStatus
+		message     string
+	}{
+		{
+			"Reports nothing without workload identity annotations",
+			map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"},
+			nil,
+			"",
+		},
+		{
+			"Passes with a valid EKS role ARN",
+			map[string]string{eksRoleARNAnnotation: "arn:aws:iam::123456789012:role/linkerd-controller"},
+			[]healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_OK},
+			"",
+		},
+		{
+			"Passes with a valid EKS role ARN with a path in another partition",
+			map[string]string{eksRoleARNAnnotation: "arn:aws-us-gov:iam::123456789012:role/linkerd/linkerd-controller"},
+			[]healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_OK},
+			"",
+		},
+		{
+			"Fails with an empty EKS role ARN",
+			map[string]string{eksRoleARNAnnotation: ""},
+			[]healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_FAIL},
+			"Service account [linkerd-controller] annotation [eks.amazonaws.com/role-arn] is empty",
+		},
+		{
+			"Fails with an EKS ARN that isn't a role",
+			map[string]string{eksRoleARNAnnotation: "arn:aws:iam::123456789012:user/linkerd-controller"},
+			[]healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_FAIL},
+			"Service account [linkerd-controller] annotation [eks.amazonaws.com/role-arn] has invalid value [arn:aws:iam::123456789012:user/linkerd-controller] (expected a value like [arn:aws:iam::123456789012:role/linkerd-controller])",
+		},
+		{
+			"Fails with an EKS role ARN with a malformed account ID",
+			map[string]string{eksRoleARNAnnotation: "arn:aws:iam::1234:role/linkerd-controller"},
+			[]healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_FAIL},
+			"Service account [linkerd-controller] annotation [eks.amazonaws.com/role-arn] has invalid value [arn:aws:iam::1234:role/linkerd-controller] (expected a value like [arn:aws:iam::123456789012:role/linkerd-controller])",
+		},
+		{
+			"Passes with a valid GKE service account",
+			map[string]string{gkeServiceAccountAnnotation: "linkerd-controller@my-project.iam.gserviceaccount.com"},
+			[]healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_OK},
+			"",
+		},
+		{
+			"Fails with a blank GKE service account",
+			map[string]string{gkeServiceAccountAnnotation: "  "},
+			[]healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_FAIL},
+			"Service account [linkerd-controller] annotation [iam.gke.io/gcp-service-account] is empty",
+		},
+		{
+			"Fails with a GKE service account that isn't a GCP service account email",
+			map[string]string{gkeServiceAccountAnnotation: "linkerd-controller@example.com"},
+			[]healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_FAIL},
+			"Service account [linkerd-controller] annotation [iam.gke.io/gcp-service-account] has invalid value [linkerd-controller@example.com] (expected a value like [linkerd-controller@my-project.iam.gserviceaccount.com])",
+		},
+		{
+			"Passes with a valid Azure client ID",
+			map[string]string{azureWorkloadIdentityClientIDAnnotation: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+			[]healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_OK},
+			"",
+		},
+		{
+			"Fails with an Azure client ID that isn't a UUID",
+			map[string]string{azureWorkloadIdentityClientIDAnnotation: "linkerd-controller"},
+			[]healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_FAIL},
+			"Service account [linkerd-controller] annotation [azure.workload.identity/client-id] has invalid value [linkerd-controller] (expected a value like [00000000-0000-0000-0000-000000000000])",
+		},
+		{
+			"Reports every invalid annotation",
+			map[string]string{
+				eksRoleARNAnnotation:        "",
+				gkeServiceAccountAnnotation: "",
+			},
+			[]healthcheckPb.CheckStatus{healthcheckPb.CheckStatus_FAIL},
+			"Service account [linkerd-controller] annotation [eks.amazonaws.com/role-arn] is empty, [iam.gke.io/gcp-service-account] is empty",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			results := checkServiceAccountAnnotations(&healthcheckPb.CheckResult{Status: healthcheckPb.CheckStatus_OK}, tc.annotations)
+
+			if len(results) != len(tc.expected) {
+				t.Fatalf("Expected %d results, got %v", len(tc.expected), results)
+			}
+			for i, result := range results {
+				if result.Status != tc.expected[i] {
+					t.Fatalf("Expected %s, got %s: %s", tc.expected[i], result.Status, result.FriendlyMessageToUser)
+				}
+				if result.FriendlyMessageToUser != tc.message {
+					t.Fatalf("Expected message [%s], got [%s]", tc.message, result.FriendlyMessageToUser)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckProfileValidatorResponse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		body     string
+		expected healthcheckPb.CheckStatus
+		message  string
+	}{
+		{
+			"Passes when the webhook rejects the probe",
+			http.StatusOK,
+			`{"response":{"uid":"linkerd-check","allowed":false,"status":{"message":"ServiceProfile \"linkerd-check\" is invalid: profile must have at least one route"}}}`,
+			healthcheckPb.CheckStatus_OK,
+			"",
+		},
+		{
+			"Fails when the webhook admits the probe",
+			http.StatusOK,
+			`{"response":{"uid":"linkerd-check","allowed":true}}`,
+			healthcheckPb.CheckStatus_FAIL,
+			"Webhook admitted a service profile without routes",
+		},
+		{
+			"Fails when the webhook doesn't respond with a review",
+			http.StatusOK,
+			`{}`,
+			healthcheckPb.CheckStatus_FAIL,
+			"Webhook did not respond with an admission review",
+		},
+		{
+			"Fails when the webhook isn't serving",
+			http.StatusServiceUnavailable,
+			"no endpoints available for service \"sp-validator\"\n",
+			healthcheckPb.CheckStatus_FAIL,
+			"Webhook responded with HTTP status [503]: no endpoints available for service \"sp-validator\"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checkResult := &healthcheckPb.CheckResult{Status: healthcheckPb.CheckStatus_OK}
+
+			result := checkProfileValidatorResponse(checkResult, tc.status, []byte(tc.body))
+			if result.Status != tc.expected {
+				t.Fatalf("Expected status [%s], got [%s]", tc.expected, result.Status)
+			}
+			if result.FriendlyMessageToUser != tc.message {
+				t.Fatalf("Expected message [%s], got [%s]", tc.message, result.FriendlyMessageToUser)
+			}
+		})
+	}
+}
+
+func TestExpectedCNIPluginSHA256(t *testing.T) {
+	const (
+		release = "9a3a45d01531a20e89ac6ae10b0b0beb0492acd7216a368aa062d1a5fecaf9cd"
+		other   = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	)
+
+	testCases := []struct {
+		installedWith string
+		release       string
+		expected      string
+		err           string
+	}{
+		{release, release, release, ""},
+		{strings.ToUpper(release), release, release, ""},
+		{other, "", other, ""},
+		{other, release, "", "linkerd-cni was installed with --cni-plugin-sha256 [" + other + "], but the plugin binary of this release has hash [" + release + "]"},
+	}
+
+	for i, tc := range testCases {
+		expected, err := expectedCNIPluginSHA256(tc.installedWith, tc.release)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("%d: Expected error [%s], got: %v", i, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: Unexpected error: %v", i, err)
+		}
+		if expected != tc.expected {
+			t.Fatalf("%d: Expected hash [%s], got [%s]", i, tc.expected, expected)
+		}
+	}
+}
+
+func TestCheckCNIPluginHashes(t *testing.T) {
+	const expected = "9a3a45d01531a20e89ac6ae10b0b0beb0492acd7216a368aa062d1a5fecaf9cd"
+
+	pod := func(name, node string, phase v1.PodPhase) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "linkerd"},
+			Spec:       v1.PodSpec{NodeName: node},
+			Status:     v1.PodStatus{Phase: phase},
+		}
+	}
+	pods := []v1.Pod{
+		pod("linkerd-cni-4xk2p", "node-1", v1.PodRunning),
+		pod("linkerd-cni-9wq7z", "node-2", v1.PodRunning),
+		pod("linkerd-cni-m3v8c", "node-3", v1.PodPending),
+	}
+
+	testCases := []struct {
+		name     string
+		pods     []v1.Pod
+		hashes   map[string]string
+		expected healthcheckPb.CheckStatus
+		message  string
+	}{
+		{
+			"Passes when every installed binary matches the hash",
+			pods,
+			map[string]string{"linkerd-cni-4xk2p": expected, "linkerd-cni-9wq7z": strings.ToUpper(expected)},
+			healthcheckPb.CheckStatus_OK,
+			"",
+		},
+		{
+			"Fails when an installed binary doesn't match the hash",
+			pods,
+			map[string]string{"linkerd-cni-4xk2p": expected, "linkerd-cni-9wq7z": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			healthcheckPb.CheckStatus_FAIL,
+			"Expected plugin binaries with hash [" + expected + "]; pod [linkerd-cni-9wq7z] on node [node-2] installed a binary with hash [e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855]",
+		},
+		{
+			"Fails when an installed binary can't be hashed",
+			pods,
+			map[string]string{"linkerd-cni-4xk2p": expected},
+			healthcheckPb.CheckStatus_FAIL,
+			"Expected plugin binaries with hash [" + expected + "]; pod [linkerd-cni-9wq7z] on node [node-2]: failed to hash the plugin binary: exit status 1",
+		},
+		{
+			"Fails without running pods",
+			pods[2:],
+			nil,
+			healthcheckPb.CheckStatus_FAIL,
+			"No running linkerd-cni pods to check the plugin binaries of",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			hashOf := func(pod v1.Pod) (string, error) {
+				hash, ok := tc.hashes[pod.Name]
+				if !ok {
+					return "", errors.New("failed to hash the plugin binary: exit status 1")
+				}
+				return hash, nil
+			}
+
+			result := checkCNIPluginHashes(&healthcheckPb.CheckResult{Status: healthcheckPb.CheckStatus_OK}, expected, tc.pods, hashOf)
+			if result.Status != tc.expected {
+				t.Fatalf("Expected %s, got %s: %s", tc.expected, result.Status, result.FriendlyMessageToUser)
+			}
+			if result.FriendlyMessageToUser != tc.message {
+				t.Fatalf("Expected message [%s], got [%s]", tc.message, result.FriendlyMessageToUser)
+			}
+		})
+	}
+}
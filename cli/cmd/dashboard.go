@@ -3,7 +3,11 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
@@ -11,6 +15,7 @@ import (
 	"github.com/pkg/browser"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
 )
 
 // These constants are used by the `show` flag.
@@ -23,17 +28,27 @@ const (
 
 	// showURL displays dashboard URLs without opening a browser.
 	showURL = "url"
+
+	// showGrafanaCredentials displays the Grafana admin credentials, when
+	// Grafana was installed with --grafana-auth.
+	showGrafanaCredentials = "grafana-credentials"
 )
 
+// openTimeInterval is how often the dashboard command reports how long the
+// proxy has been open for.
+const openTimeInterval = 30 * time.Second
+
 type dashboardOptions struct {
 	dashboardProxyPort int
 	dashboardShow      string
+	kubeContext        string
 }
 
 func newDashboardOptions() *dashboardOptions {
 	return &dashboardOptions{
 		dashboardProxyPort: 0,
 		dashboardShow:      showLinkerd,
+		kubeContext:        "",
 	}
 }
 
@@ -48,36 +63,71 @@ func newCmdDashboard() *cobra.Command {
 				return fmt.Errorf("port must be greater than or equal to zero, was %d", options.dashboardProxyPort)
 			}
 
-			if options.dashboardShow != showLinkerd && options.dashboardShow != showGrafana && options.dashboardShow != showURL {
-				return fmt.Errorf("unknown value for 'show' param, was: %s, must be one of: %s, %s, %s",
-					options.dashboardShow, showLinkerd, showGrafana, showURL)
+			if options.dashboardShow != showLinkerd && options.dashboardShow != showGrafana && options.dashboardShow != showURL && options.dashboardShow != showGrafanaCredentials {
+				return fmt.Errorf("unknown value for 'show' param, was: %s, must be one of: %s, %s, %s, %s",
+					options.dashboardShow, showLinkerd, showGrafana, showURL, showGrafanaCredentials)
 			}
 
-			kubernetesProxy, err := k8s.NewProxy(kubeconfigPath, options.dashboardProxyPort)
+			selectedContext := kubeContextFor(options.kubeContext)
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, selectedContext)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to initialize proxy: %s\n", err)
+				return err
+			}
+
+			externalGrafanaURL, err := fetchLinkerdConfig(kubeAPI, controlPlaneNamespace, k8s.LinkerdConfigGrafanaURLKey)
+			if err != nil {
+				log.Debugf("Error fetching the Grafana URL: %s", err)
+			}
+
+			credentials, err := fetchGrafanaCredentials(kubeAPI, controlPlaneNamespace)
+			if options.dashboardShow == showGrafanaCredentials {
+				if err != nil {
+					return err
+				}
+				if credentials == nil {
+					return fmt.Errorf("Grafana does not require login in the \"%s\" namespace; install with --grafana-auth to enable it", controlPlaneNamespace)
+				}
+				fmt.Print(renderGrafanaCredentials(credentials))
+				return nil
+			}
+			if err != nil {
+				log.Debugf("Error fetching Grafana credentials: %s", err)
+			}
+
+			// The dashboard's availability is checked while the proxy starts.
+			client, err := newPublicAPIClientForContext(selectedContext)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to initialize Linkerd API client: %+v\n", err)
 				os.Exit(1)
 			}
+			dashboardCheck := make(chan error, 1)
+			var dashboardAvailable bool
+			go func() {
+				var err error
+				dashboardAvailable, err = isDashboardAvailable(client)
+				dashboardCheck <- err
+			}()
 
-			url, err := kubernetesProxy.URLFor(controlPlaneNamespace, "/services/web:http/proxy/")
+			kubernetesProxy, err := k8s.NewProxy(kubeconfigPath, selectedContext, options.dashboardProxyPort)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to generate URL for dashboard: %s\n", err)
+				fmt.Fprintf(os.Stderr, "Failed to initialize proxy: %s\n", err)
 				os.Exit(1)
 			}
 
-			grafanaUrl, err := kubernetesProxy.URLFor(controlPlaneNamespace, "/services/grafana:http/proxy/")
+			url, err := kubernetesProxy.URLFor(controlPlaneNamespace, "/services/web:http/proxy/")
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to generate URL for Grafana: %s\n", err)
+				fmt.Fprintf(os.Stderr, "Failed to generate URL for dashboard: %s\n", err)
 				os.Exit(1)
 			}
 
-			client, err := newPublicAPIClient()
+			grafanaUrl, err := grafanaURLFor(externalGrafanaURL, "/", kubernetesProxy.URLFor)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to initialize Linkerd API client: %+v\n", err)
+				fmt.Fprintf(os.Stderr, "Failed to generate URL for Grafana: %s\n", err)
 				os.Exit(1)
 			}
 
-			dashboardAvailable, err := isDashboardAvailable(client)
+			err = <-dashboardCheck
 			if err != nil {
 				log.Debugf("Error checking dashboard availability: %s", err)
 			}
@@ -90,6 +140,10 @@ func newCmdDashboard() *cobra.Command {
 
 			fmt.Printf("Linkerd dashboard available at:\n%s\n", url.String())
 			fmt.Printf("Grafana dashboard available at:\n%s\n", grafanaUrl.String())
+			if credentials != nil {
+				// Grafana redirects to its login page, and back, within the proxy.
+				fmt.Printf("Grafana requires login, run `linkerd dashboard --show %s` for the admin credentials\n", showGrafanaCredentials)
+			}
 
 			switch options.dashboardShow {
 			case showLinkerd:
@@ -112,6 +166,8 @@ func newCmdDashboard() *cobra.Command {
 				// no-op, we already printed the URLs
 			}
 
+			go reportOpenTime(os.Stdout, time.Now(), time.Tick(openTimeInterval))
+
 			// blocks until killed
 			err = kubernetesProxy.Run()
 			if err != nil {
@@ -126,7 +182,8 @@ func newCmdDashboard() *cobra.Command {
 	cmd.Args = cobra.NoArgs
 	// This is identical to what `kubectl proxy --help` reports, `--port 0` indicates a random port.
 	cmd.PersistentFlags().IntVarP(&options.dashboardProxyPort, "port", "p", options.dashboardProxyPort, "The port on which to run the proxy (when set to 0, a random port will be used)")
-	cmd.PersistentFlags().StringVar(&options.dashboardShow, "show", options.dashboardShow, "Open a dashboard in a browser or show URLs in the CLI (one of: linkerd, grafana, url)")
+	cmd.PersistentFlags().StringVar(&options.dashboardShow, "show", options.dashboardShow, "Open a dashboard in a browser, show URLs in the CLI, or show the Grafana admin credentials (one of: linkerd, grafana, url, grafana-credentials)")
+	addKubeContextFlag(cmd, &options.kubeContext)
 
 	return cmd
 }
@@ -144,3 +201,78 @@ func isDashboardAvailable(client pb.ApiClient) (bool, error) {
 	}
 	return true, nil
 }
+
+// grafanaCredentials are the credentials of the Grafana admin user.
+type grafanaCredentials struct {
+	username string
+	password string
+}
+
+// fetchGrafanaCredentials reads the Grafana admin credentials from the secret
+// in controlPlaneNamespace, or returns nil if there is no such secret because
+// Grafana does not require login.
+func fetchGrafanaCredentials(kubeAPI k8s.KubernetesApi, controlPlaneNamespace string) (*grafanaCredentials, error) {
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var secret v1.Secret
+	err = getKubernetesObject(client, kubeAPI, controlPlaneNamespace, "/secrets/"+k8s.GrafanaAdminSecretName, &secret)
+	if err == errKubernetesObjectNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &grafanaCredentials{
+		username: string(secret.Data[k8s.GrafanaAdminUsernameKey]),
+		password: string(secret.Data[k8s.GrafanaAdminPasswordKey]),
+	}, nil
+}
+
+// fetchLinkerdConfig reads the value of key in the Linkerd config that the
+// control plane in controlPlaneNamespace was installed with, such as the URL
+// of an external Grafana. It returns an empty value if the control plane was
+// installed by a version of Linkerd that predates the Linkerd config.
+func fetchLinkerdConfig(kubeAPI k8s.KubernetesApi, controlPlaneNamespace, key string) (string, error) {
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return "", err
+	}
+
+	var configMap v1.ConfigMap
+	err = getKubernetesObject(client, kubeAPI, controlPlaneNamespace, "/configmaps/"+k8s.LinkerdConfigMapName, &configMap)
+	if err == errKubernetesObjectNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return configMap.Data[key], nil
+}
+
+// grafanaURLFor returns the URL of path on the external Grafana at externalURL
+// if it is set, and on the bundled Grafana, through the Kubernetes API proxy
+// that urlFor generates URLs for, otherwise.
+func grafanaURLFor(externalURL, path string, urlFor func(namespace, extraPathStartingWithSlash string) (*url.URL, error)) (*url.URL, error) {
+	if externalURL != "" {
+		return url.Parse(strings.TrimSuffix(externalURL, "/") + path)
+	}
+	return urlFor(controlPlaneNamespace, "/services/grafana:http/proxy"+path)
+}
+
+func renderGrafanaCredentials(credentials *grafanaCredentials) string {
+	return fmt.Sprintf("username: %s\npassword: %s\n", credentials.username, credentials.password)
+}
+
+// reportOpenTime writes how long the dashboard has been open for, since start,
+// on every tick, so that users can tell when their dashboard link may have
+// been closed by an idle timeout.
+func reportOpenTime(w io.Writer, start time.Time, ticks <-chan time.Time) {
+	for now := range ticks {
+		fmt.Fprintf(w, "(open for %s)\n", now.Sub(start).Round(time.Second))
+	}
+}
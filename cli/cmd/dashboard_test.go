@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"bytes"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
 )
 
 func TestDashboardAvailability(t *testing.T) {
@@ -71,3 +77,150 @@ func TestDashboardAvailability(t *testing.T) {
 		}
 	})
 }
+
+func TestReportOpenTime(t *testing.T) {
+	start := time.Unix(1500000000, 0)
+	ticks := make(chan time.Time, 3)
+	ticks <- start.Add(30 * time.Second)
+	ticks <- start.Add(60*time.Second + 400*time.Millisecond)
+	ticks <- start.Add(90*time.Second + 600*time.Millisecond)
+	close(ticks)
+
+	buf := &bytes.Buffer{}
+	reportOpenTime(buf, start, ticks)
+
+	expectedOutput := `(open for 30s)
+(open for 1m0s)
+(open for 1m31s)
+`
+	if buf.String() != expectedOutput {
+		t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, buf.String())
+	}
+}
+
+func TestFetchGrafanaCredentials(t *testing.T) {
+	newMockKubeAPI := func(t *testing.T, server *httptest.Server) *k8s.MockKubeApi {
+		u, err := url.Parse(server.URL + "/api/v1/namespaces/linkerd/secrets/linkerd-grafana-admin")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return &k8s.MockKubeApi{UrlForUrlToReturn: u, NewClientClientToReturn: server.Client()}
+	}
+
+	t.Run("Returns the credentials in the Grafana admin secret", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(`{"kind":"Secret","data":{"username":"YWRtaW4=","password":"czNjcjN0"}}`))
+		}))
+		defer server.Close()
+		kubeAPI := newMockKubeAPI(t, server)
+
+		credentials, err := fetchGrafanaCredentials(kubeAPI, "linkerd")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if kubeAPI.UrlForNamespaceReceived != "linkerd" || kubeAPI.UrlExtraPathStartingWithSlashReceived != "/secrets/linkerd-grafana-admin" {
+			t.Fatalf("Expected to fetch secret linkerd/linkerd-grafana-admin, got %s%s", kubeAPI.UrlForNamespaceReceived, kubeAPI.UrlExtraPathStartingWithSlashReceived)
+		}
+
+		expectedOutput := "username: admin\npassword: s3cr3t\n"
+		if output := renderGrafanaCredentials(credentials); output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Returns nil if Grafana does not require login", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		credentials, err := fetchGrafanaCredentials(newMockKubeAPI(t, server), "linkerd")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if credentials != nil {
+			t.Fatalf("Expected no credentials, got %+v", credentials)
+		}
+	})
+
+	t.Run("Returns an error if the secret cannot be read", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		if _, err := fetchGrafanaCredentials(newMockKubeAPI(t, server), "linkerd"); err == nil {
+			t.Fatalf("Expected an error")
+		}
+	})
+}
+
+func TestFetchLinkerdConfig(t *testing.T) {
+	newMockKubeAPI := func(t *testing.T, server *httptest.Server) *k8s.MockKubeApi {
+		u, err := url.Parse(server.URL + "/api/v1/namespaces/linkerd/configmaps/linkerd-config")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return &k8s.MockKubeApi{UrlForUrlToReturn: u, NewClientClientToReturn: server.Client()}
+	}
+
+	t.Run("Returns the value of a key in the Linkerd config", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(`{"kind":"ConfigMap","data":{"grafanaUrl":"https://grafana.example.com"}}`))
+		}))
+		defer server.Close()
+		kubeAPI := newMockKubeAPI(t, server)
+
+		grafanaURL, err := fetchLinkerdConfig(kubeAPI, "linkerd", k8s.LinkerdConfigGrafanaURLKey)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if kubeAPI.UrlForNamespaceReceived != "linkerd" || kubeAPI.UrlExtraPathStartingWithSlashReceived != "/configmaps/linkerd-config" {
+			t.Fatalf("Expected to fetch configmap linkerd/linkerd-config, got %s%s", kubeAPI.UrlForNamespaceReceived, kubeAPI.UrlExtraPathStartingWithSlashReceived)
+		}
+		if grafanaURL != "https://grafana.example.com" {
+			t.Fatalf("Expected the external Grafana URL, got [%s]", grafanaURL)
+		}
+	})
+
+	t.Run("Returns an empty value without the Linkerd config", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		grafanaURL, err := fetchLinkerdConfig(newMockKubeAPI(t, server), "linkerd", k8s.LinkerdConfigGrafanaURLKey)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if grafanaURL != "" {
+			t.Fatalf("Expected no external Grafana URL, got [%s]", grafanaURL)
+		}
+	})
+}
+
+func TestGrafanaURLFor(t *testing.T) {
+	urlFor := func(namespace, extraPathStartingWithSlash string) (*url.URL, error) {
+		return url.Parse("http://127.0.0.1:8001/api/v1/namespaces/" + namespace + extraPathStartingWithSlash)
+	}
+
+	testCases := []struct {
+		externalURL string
+		path        string
+		expected    string
+	}{
+		{"", "/", "http://127.0.0.1:8001/api/v1/namespaces/linkerd/services/grafana:http/proxy/"},
+		{"", "/api/health", "http://127.0.0.1:8001/api/v1/namespaces/linkerd/services/grafana:http/proxy/api/health"},
+		{"https://grafana.example.com", "/", "https://grafana.example.com/"},
+		{"https://grafana.example.com/", "/api/health", "https://grafana.example.com/api/health"},
+		{"https://example.com/grafana", "/api/health", "https://example.com/grafana/api/health"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			u, err := grafanaURLFor(tc.externalURL, tc.path, urlFor)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if u.String() != tc.expected {
+				t.Fatalf("Expected %s, got %s", tc.expected, u)
+			}
+		})
+	}
+}
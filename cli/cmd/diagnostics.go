@@ -0,0 +1,1390 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// tapLatencyMaxRps is the rate limit of the tap session opened by
+// `diagnostics tap-latency`. It is high enough that every request sent during
+// the measurement is tapped.
+const tapLatencyMaxRps = 100.0
+
+// tapSessionTimeout is how long `diagnostics tap-latency` sends warm-up
+// requests for, waiting for its tap session to report the first of them,
+// before giving up.
+const tapSessionTimeout = 30 * time.Second
+
+// tapWarmUpInterval is how long `diagnostics tap-latency` waits for a warm-up
+// request to be tapped before sending another.
+const tapWarmUpInterval = time.Second
+
+type tapLatencyOptions struct {
+	namespace string
+	container string
+	url       string
+	requests  uint
+}
+
+type resourceRequestsOptions struct {
+	namespace string
+}
+
+type checkCertsOptions struct {
+	expiryWindow string
+}
+
+// tlsSecretCertificate is a certificate held by one of the keys of a Linkerd
+// TLS Secret.
+type tlsSecretCertificate struct {
+	namespace string
+	secret    string
+	key       string
+	subject   string
+	notAfter  time.Time
+}
+
+type injectReportOptions struct {
+	namespace string
+}
+
+// injectReport explains why a pod has, or hasn't, had the proxy injected.
+type injectReport struct {
+	pod      string
+	injected bool
+	reasons  []string
+}
+
+// proxyContainerName is the name of the container that inject adds the proxy
+// as.
+const proxyContainerName = "linkerd-proxy"
+
+// metricsAPIPath is the path of the metrics-server's resource metrics API.
+const metricsAPIPath = "/apis/metrics.k8s.io/v1beta1"
+
+// podMetricsList is the subset of the metrics-server's PodMetricsList that
+// reports the resource usage of pods' containers.
+type podMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Containers []struct {
+			Name  string          `json:"name"`
+			Usage v1.ResourceList `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// proxyResourceUsage compares the resources that a proxy container requests
+// with the resources that it uses. Usage is nil when the metrics-server has
+// no metrics for the container yet.
+type proxyResourceUsage struct {
+	pod       string
+	container string
+	requests  v1.ResourceList
+	usage     v1.ResourceList
+}
+
+type bundleOptions struct {
+	output string
+	redact bool
+}
+
+// bundleFile is a file of a diagnostics bundle, named relative to the
+// bundle's directory.
+type bundleFile struct {
+	name    string
+	content []byte
+}
+
+// ipv4Regex matches the IPv4 addresses that `diagnostics bundle --redact`
+// hashes.
+var ipv4Regex = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+
+// ipv6CandidateRegex matches the words that may be IPv6 addresses. Only the
+// ones that parse as IPv6 addresses are hashed, so that e.g. times and Rust
+// module paths in the logs are kept.
+var ipv6CandidateRegex = regexp.MustCompile(`[\w.:]*:[\w.:]*`)
+
+type tlsCheckOptions struct {
+	namespace string
+	container string
+	port      uint
+	caFile    string
+	certDepth int
+}
+
+// certSummary describes a certificate in the chain presented by a TLS server,
+// as reported by openssl.
+type certSummary struct {
+	depth   int
+	subject string
+	issuer  string
+}
+
+// tlsCheckResult is the outcome of an `openssl s_client` connection.
+type tlsCheckResult struct {
+	chain      []certSummary
+	verifyCode int
+	verifyMsg  string
+	errors     []string
+}
+
+var (
+	// chainCertRegex matches the subject line of a certificate in the
+	// "Certificate chain" section of s_client's output; older versions of
+	// openssl omit the space after "s:".
+	chainCertRegex = regexp.MustCompile(`^\s*(\d+) s:\s*(.*)$`)
+	// chainIssuerRegex matches the issuer line following a subject line.
+	chainIssuerRegex = regexp.MustCompile(`^\s*i:\s*(.*)$`)
+	// verifyReturnCodeRegex matches s_client's summary of certificate
+	// verification, e.g. "Verify return code: 0 (ok)".
+	verifyReturnCodeRegex = regexp.MustCompile(`Verify return code: (\d+) \((.*)\)`)
+	// sslErrorRegex matches errors logged by the OpenSSL library, e.g.
+	// "139775223:error:1416F086:SSL routines:tls_process_server_certificate:certificate verify failed:...".
+	sslErrorRegex = regexp.MustCompile(`^[0-9A-Fa-f]*:error:`)
+)
+
+func newTLSCheckOptions() *tlsCheckOptions {
+	return &tlsCheckOptions{
+		namespace: "default",
+		container: "",
+		port:      4143,
+		caFile:    "/var/linkerd-io/trust-anchors/" + k8s.TLSTrustAnchorFileName,
+		certDepth: 10,
+	}
+}
+
+func newBundleOptions() *bundleOptions {
+	return &bundleOptions{
+		output: "",
+		redact: false,
+	}
+}
+
+func newResourceRequestsOptions() *resourceRequestsOptions {
+	return &resourceRequestsOptions{
+		namespace: "default",
+	}
+}
+
+func newCheckCertsOptions() *checkCertsOptions {
+	return &checkCertsOptions{
+		expiryWindow: "30d",
+	}
+}
+
+func newInjectReportOptions() *injectReportOptions {
+	return &injectReportOptions{
+		namespace: "default",
+	}
+}
+
+func newTapLatencyOptions() *tapLatencyOptions {
+	return &tapLatencyOptions{
+		namespace: "default",
+		container: "",
+		url:       "",
+		requests:  20,
+	}
+}
+
+// latencyStats summarizes a set of request latencies.
+type latencyStats struct {
+	count int
+	mean  time.Duration
+	p50   time.Duration
+	p95   time.Duration
+	max   time.Duration
+}
+
+// tapOverhead is the latency added by an active tap session, as the
+// difference between the latencies measured with and without one.
+type tapOverhead struct {
+	baseline    latencyStats
+	tapped      latencyStats
+	mean        time.Duration
+	p50         time.Duration
+	p95         time.Duration
+	meanPercent float64
+}
+
+func newCmdDiagnostics() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diagnostics [flags]",
+		Short: "Commands used to diagnose Linkerd components",
+		Long:  `Commands used to diagnose Linkerd components.`,
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(newCmdDiagnosticsBundle())
+	cmd.AddCommand(newCmdDiagnosticsCheckCerts())
+	cmd.AddCommand(newCmdDiagnosticsInjectReport())
+	cmd.AddCommand(newCmdDiagnosticsResourceRequests())
+	cmd.AddCommand(newCmdDiagnosticsTapLatency())
+	cmd.AddCommand(newCmdDiagnosticsTLSCheck())
+
+	return cmd
+}
+
+func newCmdDiagnosticsBundle() *cobra.Command {
+	options := newBundleOptions()
+
+	cmd := &cobra.Command{
+		Use:   "bundle [flags]",
+		Short: "Collect diagnostic information into an archive",
+		Long: `Collect diagnostic information into an archive.
+
+  Writes a timestamped .tar.gz archive, to attach to support issues, with the
+  output of "linkerd check", the stats of the deployments in all namespaces,
+  the logs of the control plane's containers, and the contents of the
+  ` + k8s.LinkerdConfigMapName + ` ConfigMap. Information that can't be collected is replaced
+  with the error that prevented it. With --redact, IPv4 and IPv6 addresses and
+  the values of the ` + k8s.LinkerdConfigMapName + ` ConfigMap are replaced with their hashes,
+  so that identical values can still be told apart.`,
+		Example: `  # collect diagnostic information, hashing sensitive values
+  linkerd diagnostics bundle --redact`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeAPI, err := newKubernetesAPI(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+
+			client, err := newPublicAPIClient()
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			name := fmt.Sprintf("linkerd-diagnostics-%s", now.UTC().Format("20060102T150405Z"))
+			output := options.output
+			if output == "" {
+				output = name + ".tar.gz"
+			}
+
+			files := collectDiagnosticsBundle(kubeAPI, client, os.Stderr)
+			if options.redact {
+				files = redactBundleFiles(files)
+			}
+
+			f, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			if err := writeDiagnosticsBundle(f, name, files, now); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+
+			fmt.Printf("Wrote diagnostic information to %s\n", output)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output,
+		"Path to write the archive to (by default, linkerd-diagnostics-<timestamp>.tar.gz in the current directory)")
+	cmd.PersistentFlags().BoolVar(&options.redact, "redact", options.redact,
+		"Replace IPv4 and IPv6 addresses and the values of the "+k8s.LinkerdConfigMapName+" ConfigMap with their hashes")
+
+	return cmd
+}
+
+func newCmdDiagnosticsCheckCerts() *cobra.Command {
+	options := newCheckCertsOptions()
+
+	cmd := &cobra.Command{
+		Use:   "check-certs [flags]",
+		Short: "List the Linkerd certificates that expire soon",
+		Long: `List the Linkerd certificates that expire soon.
+
+  Reads every certificate in the Secrets, in all namespaces, that Linkerd
+  stores its certificates in, which are labeled ` + k8s.TLSSecretLabel + `=true,
+  and lists those that expire within the expiry window, soonest first. Exits
+  with a non-zero status if any do.`,
+		Example: `  # list the Linkerd certificates that expire within the next week
+  linkerd diagnostics check-certs --expiry-window 7d`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			window, err := model.ParseDuration(options.expiryWindow)
+			if err != nil || window <= 0 {
+				return fmt.Errorf("--expiry-window must be a positive duration, such as 12h or 30d")
+			}
+
+			kubeAPI, err := newKubernetesAPI(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+
+			certs, err := fetchTLSSecretCertificates(kubeAPI)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			expiring := expiringCertificates(certs, now, time.Duration(window))
+			if len(expiring) == 0 {
+				fmt.Printf("None of the %d certificates expire within %s.\n", len(certs), options.expiryWindow)
+				return nil
+			}
+
+			renderExpiringCertificates(expiring, now, os.Stdout)
+			os.Exit(1)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.expiryWindow, "expiry-window", options.expiryWindow,
+		"List the certificates that expire within this long (e.g. 12h, 30d)")
+
+	return cmd
+}
+
+func newCmdDiagnosticsInjectReport() *cobra.Command {
+	options := newInjectReportOptions()
+
+	cmd := &cobra.Command{
+		Use:   "inject-report [flags] POD",
+		Short: "Explain why a pod was or wasn't injected with the proxy",
+		Long: `Explain why a pod was or wasn't injected with the proxy.
+
+  Pods are injected by running the resource that creates them through
+  "linkerd inject", which skips pods that can't run the proxy; namespace labels
+  and pod annotations don't inject pods on their own. This command reports
+  whether the pod has the proxy, and if it doesn't, which of inject's checks
+  the pod fails, or which resource to inject instead.`,
+		Example: `  # explain why the vote-bot pod in the emojivoto namespace wasn't injected
+  linkerd diagnostics inject-report -n emojivoto vote-bot-5b7f5657f6-xbjjw`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeAPI, err := newKubernetesAPI(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+
+			var pod v1.Pod
+			err = getKubernetesObject(client, kubeAPI, options.namespace, "/pods/"+args[0], &pod)
+			if err == errKubernetesObjectNotFound {
+				return fmt.Errorf("pod \"%s\" not found in the \"%s\" namespace", args[0], options.namespace)
+			}
+			if err != nil {
+				return err
+			}
+
+			renderInjectReport(buildInjectReport(pod), os.Stdout)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace,
+		"Namespace of the pod")
+
+	return cmd
+}
+
+func newCmdDiagnosticsResourceRequests() *cobra.Command {
+	options := newResourceRequestsOptions()
+
+	cmd := &cobra.Command{
+		Use:   "resource-requests [flags]",
+		Short: "Compare the resources that proxies request with what they use",
+		Long: `Compare the resources that proxies request with what they use.
+
+  Lists the CPU and memory that each proxy container in the namespace requests,
+  next to the CPU and memory that it currently uses, as reported by the
+  metrics-server, which must be installed in the cluster. Usage is "-" for
+  proxies that the metrics-server has no metrics for yet.`,
+		Example: `  # compare the requested and actual resources of the proxies in the emojivoto namespace
+  linkerd diagnostics resource-requests -n emojivoto`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeAPI, err := newKubernetesAPI(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+
+			usages, err := fetchProxyResourceUsage(kubeAPI, options.namespace)
+			if err != nil {
+				return err
+			}
+
+			if len(usages) == 0 {
+				fmt.Fprintf(os.Stderr, "No proxies found in the \"%s\" namespace.\n", options.namespace)
+				return nil
+			}
+
+			renderProxyResourceUsage(usages, os.Stdout)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace,
+		"Namespace of the proxies")
+
+	return cmd
+}
+
+func newCmdDiagnosticsTapLatency() *cobra.Command {
+	options := newTapLatencyOptions()
+
+	cmd := &cobra.Command{
+		Use:   "tap-latency [flags] POD",
+		Short: "Measure the latency added by tapping a pod",
+		Long: `Measure the latency added by tapping a pod.
+
+  Sends a series of requests from the POD to the --url, first without and then
+  with an active tap session on the POD, and reports the difference in response
+  latency as the overhead of tap. The tapped requests are only sent once the
+  tap session has tapped a warm-up request. Requests are sent with curl through
+  "kubectl exec", so curl must be available in the POD's container.`,
+		Example: `  # measure tap overhead on requests from the web-dlbvj pod to the voting service
+  linkerd diagnostics tap-latency web-dlbvj -n emojivoto --url http://voting-svc:8080/`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.url == "" {
+				return errors.New("--url must be set")
+			}
+			if options.requests == 0 {
+				return errors.New("--requests must be greater than 0")
+			}
+
+			client, err := newPublicAPIClient()
+			if err != nil {
+				return err
+			}
+
+			overhead, err := measureTapLatency(client, args[0], options)
+			if err != nil {
+				return err
+			}
+
+			renderTapOverhead(overhead, os.Stdout)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace,
+		"Namespace of the specified pod")
+	cmd.PersistentFlags().StringVarP(&options.container, "container", "c", options.container,
+		"Container in the pod to send requests from (by default, the pod's first container)")
+	cmd.PersistentFlags().StringVar(&options.url, "url", options.url,
+		"URL to send requests to from the pod")
+	cmd.PersistentFlags().UintVar(&options.requests, "requests", options.requests,
+		"Number of requests to send with and without tap")
+
+	return cmd
+}
+
+func newCmdDiagnosticsTLSCheck() *cobra.Command {
+	options := newTLSCheckOptions()
+
+	cmd := &cobra.Command{
+		Use:   "tls-check [flags] CLIENT-POD SERVER-POD",
+		Short: "Check TLS between two pods with openssl",
+		Long: `Check TLS between two pods with openssl.
+
+  Connects from the CLIENT-POD to the Linkerd proxy of the SERVER-POD with
+  "openssl s_client", through "kubectl exec", and reports the certificate chain
+  presented by the SERVER-POD along with any errors found by openssl while
+  verifying it. openssl and the Linkerd trust anchors (see --ca-file) must be
+  available in the CLIENT-POD's container.`,
+		Example: `  # check TLS from the web-dlbvj pod to the voting-65b9fffd77-rlwsd pod
+  linkerd diagnostics tls-check web-dlbvj voting-65b9fffd77-rlwsd -n emojivoto`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.port == 0 || options.port > 65535 {
+				return errors.New("--port must be between 1 and 65535")
+			}
+			if options.certDepth < 0 {
+				return errors.New("--cert-depth must not be negative")
+			}
+
+			addr, err := getPodIP(args[1], options.namespace)
+			if err != nil {
+				return err
+			}
+
+			result, err := checkTLS(args[0], addr, options)
+			renderTLSCheck(result, os.Stdout)
+			return err
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace,
+		"Namespace of the specified pods")
+	cmd.PersistentFlags().StringVarP(&options.container, "container", "c", options.container,
+		"Container in the client pod to run openssl in (by default, the pod's first container)")
+	cmd.PersistentFlags().UintVar(&options.port, "port", options.port,
+		"Port on the server pod to connect to (by default, the proxy's inbound port)")
+	cmd.PersistentFlags().StringVar(&options.caFile, "ca-file", options.caFile,
+		"Path to the trust anchors in the client pod's container")
+	cmd.PersistentFlags().IntVar(&options.certDepth, "cert-depth", options.certDepth,
+		"Maximum depth of the certificate chain to verify")
+
+	return cmd
+}
+
+// getPodIP returns the IP address of a pod, as reported by kubectl.
+func getPodIP(pod, namespace string) (string, error) {
+	args := kubectlArgs("get", "pod", pod, "--namespace", namespace, "--output", "jsonpath={.status.podIP}")
+
+	log.Debugf("Running: kubectl %s", strings.Join(args, " "))
+	out, err := exec.Command("kubectl", args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the IP of pod [%s]: %s", pod, err)
+	}
+
+	ip := strings.TrimSpace(string(out))
+	if ip == "" {
+		return "", fmt.Errorf("pod [%s] has no IP", pod)
+	}
+	return ip, nil
+}
+
+// checkTLS connects from pod to addr with openssl s_client, and parses the
+// result. An error is returned if openssl fails, along with whatever result
+// could be parsed from its output.
+func checkTLS(pod, addr string, options *tlsCheckOptions) (tlsCheckResult, error) {
+	args := tlsCheckArgs(pod, addr, options)
+
+	log.Debugf("Running: kubectl %s", strings.Join(args, " "))
+	out, execErr := exec.Command("kubectl", args...).CombinedOutput()
+	log.Debugf("openssl output:\n%s", out)
+
+	result := parseSClientOutput(string(out))
+	if len(result.errors) > 0 {
+		return result, fmt.Errorf("TLS check from pod [%s] to %s failed", pod, addr)
+	}
+	if execErr != nil {
+		return result, fmt.Errorf("failed to run openssl in pod [%s]: %s", pod, execErr)
+	}
+
+	return result, nil
+}
+
+// tlsCheckArgs returns the kubectl arguments that run openssl s_client in pod
+// against the proxy at addr. kubectl exec doesn't attach stdin, so s_client
+// exits as soon as the handshake completes.
+func tlsCheckArgs(pod, addr string, options *tlsCheckOptions) []string {
+	args := kubectlArgs("exec", pod, "--namespace", options.namespace)
+	if options.container != "" {
+		args = append(args, "--container", options.container)
+	}
+
+	return append(args, "--",
+		"openssl", "s_client",
+		"-connect", fmt.Sprintf("%s:%d", addr, options.port),
+		"-CAfile", options.caFile,
+		"-verify_depth", strconv.Itoa(options.certDepth),
+		"-verify_return_error",
+		"-showcerts",
+	)
+}
+
+// parseSClientOutput extracts the certificate chain, the verification result,
+// and any errors from the output of openssl s_client.
+func parseSClientOutput(out string) tlsCheckResult {
+	result := tlsCheckResult{verifyCode: -1}
+
+	inChain := false
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if line == "Certificate chain" {
+			inChain = true
+			continue
+		}
+		if inChain {
+			if line == "---" {
+				inChain = false
+				continue
+			}
+			if match := chainCertRegex.FindStringSubmatch(line); match != nil {
+				depth, _ := strconv.Atoi(match[1])
+				result.chain = append(result.chain, certSummary{depth: depth, subject: match[2]})
+				continue
+			}
+			if match := chainIssuerRegex.FindStringSubmatch(line); match != nil && len(result.chain) > 0 {
+				result.chain[len(result.chain)-1].issuer = match[1]
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "verify error:"):
+			result.errors = append(result.errors, line)
+		case sslErrorRegex.MatchString(line):
+			result.errors = append(result.errors, line)
+		case strings.HasPrefix(line, "connect:errno=") || strings.HasPrefix(line, "connect: "):
+			result.errors = append(result.errors, line)
+		}
+
+		if match := verifyReturnCodeRegex.FindStringSubmatch(line); match != nil {
+			result.verifyCode, _ = strconv.Atoi(match[1])
+			result.verifyMsg = match[2]
+		}
+	}
+
+	if result.verifyCode > 0 && len(result.errors) == 0 {
+		result.errors = append(result.errors, fmt.Sprintf("verify return code: %d (%s)", result.verifyCode, result.verifyMsg))
+	}
+
+	return result
+}
+
+func renderTLSCheck(result tlsCheckResult, w io.Writer) {
+	if len(result.chain) > 0 {
+		fmt.Fprintln(w, "Certificate chain:")
+		for _, cert := range result.chain {
+			fmt.Fprintf(w, "  %d subject: %s\n", cert.depth, cert.subject)
+			fmt.Fprintf(w, "    issuer:  %s\n", cert.issuer)
+		}
+		fmt.Fprintln(w)
+	}
+
+	for _, err := range result.errors {
+		fmt.Fprintf(w, "%s %s\n", failStatus, err)
+	}
+	if len(result.errors) == 0 && result.verifyCode == 0 {
+		fmt.Fprintf(w, "%s certificate chain verified (%s)\n", okStatus, result.verifyMsg)
+	}
+}
+
+// measureTapLatency sends requests from pod without an active tap session,
+// then again while tapping pod, and compares the latencies. The tapped
+// requests are only sent once the tap session has reported a warm-up request,
+// so that none of them are missed or delayed by the session's setup.
+func measureTapLatency(client pb.ApiClient, pod string, options *tapLatencyOptions) (tapOverhead, error) {
+	baseline, err := sendRequestsFromPod(pod, options, options.requests)
+	if err != nil {
+		return tapOverhead{}, err
+	}
+
+	req, err := util.BuildTapByResourceRequest(util.TapRequestParams{
+		Resource:  k8s.Pod + "/" + pod,
+		Namespace: options.namespace,
+		MaxRps:    tapLatencyMaxRps,
+	})
+	if err != nil {
+		return tapOverhead{}, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rsp, err := client.TapByResource(ctx, req)
+	if err != nil {
+		return tapOverhead{}, err
+	}
+
+	started := make(chan struct{})
+	ended := make(chan error, 1)
+	go func() {
+		for first := true; ; first = false {
+			if _, err := rsp.Recv(); err != nil {
+				log.Debugf("Tap session ended: %s", err)
+				ended <- err
+				return
+			}
+			if first {
+				close(started)
+			}
+		}
+	}()
+
+	warmUp := func() error {
+		_, err := sendRequestsFromPod(pod, options, 1)
+		return err
+	}
+	if err := awaitTapSession(started, ended, warmUp, tapSessionTimeout); err != nil {
+		return tapOverhead{}, err
+	}
+
+	tapped, err := sendRequestsFromPod(pod, options, options.requests)
+	if err != nil {
+		return tapOverhead{}, err
+	}
+
+	return compareLatencies(baseline, tapped), nil
+}
+
+// awaitTapSession calls warmUp until the tap session reports its first event
+// by closing started, or until it ends or timeout elapses. The latencies of
+// the warm-up requests, which include the session's setup, are discarded.
+func awaitTapSession(started <-chan struct{}, ended <-chan error, warmUp func() error, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for {
+		if err := warmUp(); err != nil {
+			return err
+		}
+
+		select {
+		case <-started:
+			return nil
+		case err := <-ended:
+			return fmt.Errorf("tap session ended before any requests were tapped: %s", err)
+		case <-deadline:
+			return fmt.Errorf("no requests were tapped within %s", timeout)
+		case <-time.After(tapWarmUpInterval):
+		}
+	}
+}
+
+// sendRequestsFromPod sends requests to options.url from pod, returning the
+// latency of each.
+func sendRequestsFromPod(pod string, options *tapLatencyOptions, requests uint) ([]time.Duration, error) {
+	args := sendRequestArgs(pod, options)
+
+	latencies := make([]time.Duration, 0, requests)
+	for i := uint(0); i < requests; i++ {
+		log.Debugf("Running: kubectl %s", strings.Join(args, " "))
+		out, err := exec.Command("kubectl", args...).Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to send request from pod [%s]: %s", pod, err)
+		}
+
+		latency, err := parseCurlTime(string(out))
+		if err != nil {
+			return nil, err
+		}
+		latencies = append(latencies, latency)
+	}
+
+	return latencies, nil
+}
+
+// sendRequestArgs returns the kubectl arguments that send a request to
+// options.url from pod with curl, and write the request's latency.
+func sendRequestArgs(pod string, options *tapLatencyOptions) []string {
+	args := kubectlArgs("exec", pod, "--namespace", options.namespace)
+	if options.container != "" {
+		args = append(args, "--container", options.container)
+	}
+
+	return append(args, "--", "curl", "--silent", "--output", "/dev/null", "--write-out", "%{time_total}", options.url)
+}
+
+// parseCurlTime parses a time written by curl's --write-out, in seconds.
+func parseCurlTime(out string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(out), 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected output from curl: %s", out)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func summarizeLatencies(latencies []time.Duration) latencyStats {
+	if len(latencies) == 0 {
+		return latencyStats{}
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	return latencyStats{
+		count: len(sorted),
+		mean:  total / time.Duration(len(sorted)),
+		p50:   latencyPercentile(sorted, 50),
+		p95:   latencyPercentile(sorted, 95),
+		max:   sorted[len(sorted)-1],
+	}
+}
+
+// latencyPercentile returns the nearest-rank percentile of the sorted
+// latencies.
+func latencyPercentile(sorted []time.Duration, percentile int) time.Duration {
+	rank := (percentile*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func compareLatencies(baseline, tapped []time.Duration) tapOverhead {
+	overhead := tapOverhead{
+		baseline: summarizeLatencies(baseline),
+		tapped:   summarizeLatencies(tapped),
+	}
+
+	overhead.mean = overhead.tapped.mean - overhead.baseline.mean
+	overhead.p50 = overhead.tapped.p50 - overhead.baseline.p50
+	overhead.p95 = overhead.tapped.p95 - overhead.baseline.p95
+	if overhead.baseline.mean > 0 {
+		overhead.meanPercent = 100 * float64(overhead.mean) / float64(overhead.baseline.mean)
+	}
+
+	return overhead
+}
+
+func renderTapOverhead(overhead tapOverhead, w io.Writer) {
+	tableWriter := tabwriter.NewWriter(w, 0, 0, padding, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(tableWriter, strings.Join([]string{"", "REQUESTS", "MEAN", "P50", "P95", "MAX", ""}, "\t"))
+	for _, row := range []struct {
+		name  string
+		stats latencyStats
+	}{
+		{"without tap", overhead.baseline},
+		{"with tap", overhead.tapped},
+	} {
+		fmt.Fprintf(tableWriter, "%s\t%d\t%s\t%s\t%s\t%s\t\n",
+			row.name,
+			row.stats.count,
+			formatLatency(row.stats.mean),
+			formatLatency(row.stats.p50),
+			formatLatency(row.stats.p95),
+			formatLatency(row.stats.max),
+		)
+	}
+	fmt.Fprintf(tableWriter, "overhead\t\t%s\t%s\t%s\t\t\n",
+		formatLatency(overhead.mean),
+		formatLatency(overhead.p50),
+		formatLatency(overhead.p95),
+	)
+	tableWriter.Flush()
+
+	fmt.Fprintf(w, "\nTap added %s (%.1f%%) to mean request latency.\n", formatLatency(overhead.mean), overhead.meanPercent)
+}
+
+func formatLatency(latency time.Duration) string {
+	return latency.Round(time.Microsecond).String()
+}
+
+// fetchTLSSecretCertificates returns the certificates in the keys, ending in
+// ".crt", of the Linkerd TLS Secrets in all namespaces.
+func fetchTLSSecretCertificates(kubeAPI k8s.KubernetesApi) ([]tlsSecretCertificate, error) {
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// The Secrets are listed across namespaces, from the endpoint next to the
+	// namespaced one that kubeAPI generates URLs for.
+	secretsURL, err := kubeAPI.UrlFor(controlPlaneNamespace, "/secrets")
+	if err != nil {
+		return nil, err
+	}
+	secretsURL.Path = strings.TrimSuffix(secretsURL.Path, fmt.Sprintf("/namespaces/%s/secrets", controlPlaneNamespace)) + "/secrets"
+	secretsURL.RawQuery = "labelSelector=" + url.QueryEscape(fmt.Sprintf("%s=true", k8s.TLSSecretLabel))
+
+	rsp, err := client.Get(secretsURL.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP GET request to endpoint [%s] resulted in Status: [%s]", secretsURL.String(), rsp.Status)
+	}
+
+	var secrets v1.SecretList
+	if err := json.NewDecoder(rsp.Body).Decode(&secrets); err != nil {
+		return nil, err
+	}
+
+	certs := []tlsSecretCertificate{}
+	for _, secret := range secrets.Items {
+		for key, data := range secret.Data {
+			if !strings.HasSuffix(key, ".crt") {
+				continue
+			}
+
+			for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+				if block.Type != "CERTIFICATE" {
+					continue
+				}
+				crt, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					return nil, fmt.Errorf("invalid certificate in [%s] of secret %s/%s: %s", key, secret.Namespace, secret.Name, err)
+				}
+				certs = append(certs, tlsSecretCertificate{
+					namespace: secret.Namespace,
+					secret:    secret.Name,
+					key:       key,
+					subject:   crt.Subject.String(),
+					notAfter:  crt.NotAfter.UTC(),
+				})
+			}
+		}
+	}
+	return certs, nil
+}
+
+// expiringCertificates returns the certificates that expire within window of
+// now, including those that have already expired, soonest first.
+func expiringCertificates(certs []tlsSecretCertificate, now time.Time, window time.Duration) []tlsSecretCertificate {
+	expiring := []tlsSecretCertificate{}
+	for _, crt := range certs {
+		if crt.notAfter.Before(now.Add(window)) {
+			expiring = append(expiring, crt)
+		}
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		if !expiring[i].notAfter.Equal(expiring[j].notAfter) {
+			return expiring[i].notAfter.Before(expiring[j].notAfter)
+		}
+		return expiring[i].namespace+"/"+expiring[i].secret+"/"+expiring[i].key <
+			expiring[j].namespace+"/"+expiring[j].secret+"/"+expiring[j].key
+	})
+	return expiring
+}
+
+func renderExpiringCertificates(certs []tlsSecretCertificate, now time.Time, w io.Writer) {
+	tableWriter := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+
+	fmt.Fprintln(tableWriter, strings.Join([]string{"NAMESPACE", "SECRET", "KEY", "SUBJECT", "EXPIRES"}, "\t"))
+	for _, crt := range certs {
+		expires := "in " + formatExpiry(crt.notAfter.Sub(now))
+		if !crt.notAfter.After(now) {
+			expires = "expired " + formatExpiry(now.Sub(crt.notAfter)) + " ago"
+		}
+		fmt.Fprintln(tableWriter, strings.Join([]string{crt.namespace, crt.secret, crt.key, crt.subject, expires}, "\t"))
+	}
+	tableWriter.Flush()
+}
+
+// formatExpiry formats d in days, or in hours when it's less than a day.
+func formatExpiry(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// buildInjectReport reconstructs the decisions that `linkerd inject` made, or
+// would make, about the pod.
+func buildInjectReport(pod v1.Pod) injectReport {
+	report := injectReport{pod: pod.Name}
+
+	for _, container := range pod.Spec.Containers {
+		if container.Name == proxyContainerName {
+			report.injected = true
+		}
+	}
+	if report.injected {
+		createdBy := pod.Annotations[k8s.CreatedByAnnotation]
+		if createdBy == "" {
+			createdBy = "an unknown version of linkerd inject"
+		}
+		report.reasons = append(report.reasons, fmt.Sprintf("it has a %s container, which was added by %s", proxyContainerName, createdBy))
+		if version := pod.Annotations[k8s.ProxyVersionAnnotation]; version != "" {
+			report.reasons = append(report.reasons, fmt.Sprintf("the proxy's version is %s", version))
+		}
+		return report
+	}
+
+	if pod.Spec.HostNetwork {
+		report.reasons = append(report.reasons,
+			"it uses the host's network (hostNetwork: true), and inject skips such pods, as their linkerd-init container would rewrite the node's iptables rules")
+	}
+
+	owner := metav1.GetControllerOf(&pod)
+	if owner != nil && !isInjectSupportedKind(owner.Kind) {
+		report.reasons = append(report.reasons, fmt.Sprintf("it was created by %s \"%s\", and inject does not support kind %s; supported kinds are: %s",
+			owner.Kind, owner.Name, owner.Kind, strings.Join(injectSupportedKinds, ", ")))
+	}
+
+	if len(report.reasons) == 0 {
+		resource := fmt.Sprintf("the pod \"%s\"", pod.Name)
+		if owner != nil {
+			resource = fmt.Sprintf("its %s \"%s\"", owner.Kind, owner.Name)
+			if owner.Kind == "ReplicaSet" {
+				resource += ", or the Deployment that owns it,"
+			}
+		}
+		report.reasons = append(report.reasons, fmt.Sprintf("%s was not passed through linkerd inject; inject it, and apply the result", resource))
+	}
+	return report
+}
+
+func isInjectSupportedKind(kind string) bool {
+	for _, supported := range injectSupportedKinds {
+		if kind == supported {
+			return true
+		}
+	}
+	return false
+}
+
+func renderInjectReport(report injectReport, w io.Writer) {
+	if report.injected {
+		fmt.Fprintf(w, "Pod %s is injected:\n", report.pod)
+	} else {
+		fmt.Fprintf(w, "Pod %s is not injected because:\n", report.pod)
+	}
+	for _, reason := range report.reasons {
+		fmt.Fprintf(w, "  * %s\n", reason)
+	}
+}
+
+// fetchProxyResourceUsage returns the requested and used resources of the
+// proxy containers of the pods in namespace, sorted by pod.
+func fetchProxyResourceUsage(kubeAPI k8s.KubernetesApi, namespace string) ([]proxyResourceUsage, error) {
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var pods v1.PodList
+	if err := getKubernetesObject(client, kubeAPI, namespace, "/pods", &pods); err != nil {
+		return nil, err
+	}
+
+	metrics, err := getPodMetrics(client, kubeAPI, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	usageByContainer := map[string]v1.ResourceList{}
+	for _, pod := range metrics.Items {
+		for _, container := range pod.Containers {
+			usageByContainer[pod.Metadata.Name+"/"+container.Name] = container.Usage
+		}
+	}
+
+	usages := []proxyResourceUsage{}
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			if container.Name != proxyContainerName {
+				continue
+			}
+			usages = append(usages, proxyResourceUsage{
+				pod:       pod.Name,
+				container: container.Name,
+				requests:  container.Resources.Requests,
+				usage:     usageByContainer[pod.Name+"/"+container.Name],
+			})
+		}
+	}
+
+	sort.Slice(usages, func(i, j int) bool { return usages[i].pod < usages[j].pod })
+	return usages, nil
+}
+
+// getPodMetrics returns the metrics of the pods in namespace from the
+// metrics-server's API, which is served by the Kubernetes API server next to
+// the core API that kubeAPI generates URLs for.
+func getPodMetrics(client *http.Client, kubeAPI k8s.KubernetesApi, namespace string) (*podMetricsList, error) {
+	podsURL, err := kubeAPI.UrlFor(namespace, "/pods")
+	if err != nil {
+		return nil, err
+	}
+
+	metricsURL := *podsURL
+	metricsURL.Path = strings.TrimSuffix(podsURL.Path, fmt.Sprintf("/api/v1/namespaces/%s/pods", namespace)) +
+		fmt.Sprintf("%s/namespaces/%s/pods", metricsAPIPath, namespace)
+
+	rsp, err := client.Get(metricsURL.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusNotFound || rsp.StatusCode == http.StatusServiceUnavailable {
+		return nil, fmt.Errorf("the metrics API is not available (%s); is the metrics-server installed?", rsp.Status)
+	}
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP GET request to endpoint [%s] resulted in Status: [%s]", metricsURL.String(), rsp.Status)
+	}
+
+	var metrics podMetricsList
+	if err := json.NewDecoder(rsp.Body).Decode(&metrics); err != nil {
+		return nil, err
+	}
+	return &metrics, nil
+}
+
+func renderProxyResourceUsage(usages []proxyResourceUsage, w io.Writer) {
+	tableWriter := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+
+	fmt.Fprintln(tableWriter, strings.Join([]string{"POD", "CONTAINER", "CPU-REQUEST", "CPU-USAGE", "MEMORY-REQUEST", "MEMORY-USAGE"}, "\t"))
+	for _, usage := range usages {
+		fmt.Fprintf(tableWriter, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			usage.pod,
+			usage.container,
+			formatCPU(usage.requests),
+			formatCPU(usage.usage),
+			formatMemory(usage.requests),
+			formatMemory(usage.usage),
+		)
+	}
+	tableWriter.Flush()
+}
+
+// formatCPU returns the CPU in resources in millicores, or "-" if it's not
+// set.
+func formatCPU(resources v1.ResourceList) string {
+	cpu, ok := resources[v1.ResourceCPU]
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%dm", cpu.MilliValue())
+}
+
+// formatMemory returns the memory in resources in mebibytes, or "-" if it's
+// not set.
+func formatMemory(resources v1.ResourceList) string {
+	memory, ok := resources[v1.ResourceMemory]
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%.1fMi", float64(memory.Value())/(1<<20))
+}
+
+// collectDiagnosticsBundle collects the files of a diagnostics bundle. The
+// errors that prevent a file from being collected are written to errw, and
+// the file holds the error instead.
+func collectDiagnosticsBundle(kubeAPI k8s.KubernetesApi, client pb.ApiClient, errw io.Writer) []bundleFile {
+	files := []bundleFile{}
+	add := func(name string, content []byte, err error) {
+		if err != nil {
+			fmt.Fprintf(errw, "Failed to collect %s: %s\n", name, err)
+			content = append(content, []byte(fmt.Sprintf("failed to collect %s: %s\n", name, err))...)
+		}
+		files = append(files, bundleFile{name: name, content: content})
+	}
+
+	check, err := runLinkerdCommand("check")
+	add("check.txt", check, err)
+
+	stats, err := collectDeploymentStats(client)
+	add("stat.txt", stats, err)
+
+	config, err := collectLinkerdConfig(kubeAPI)
+	add(k8s.LinkerdConfigMapName+".json", config, err)
+
+	pods, err := fetchControlPlanePods(kubeAPI)
+	if err != nil {
+		add("logs.txt", nil, err)
+		return files
+	}
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			logs, err := getContainerLogs(pod.Name, container.Name)
+			add(path.Join("logs", pod.Name, container.Name+".log"), logs, err)
+		}
+	}
+
+	return files
+}
+
+// runLinkerdCommand runs this CLI with args, and the global flags it was run
+// with, and returns its combined output. Commands that exit with a non-zero
+// status, e.g. check when checks fail, still return their output.
+func runLinkerdCommand(args ...string) ([]byte, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	args = append(args, "--linkerd-namespace", controlPlaneNamespace)
+	if kubeconfigPath != "" {
+		args = append(args, "--kubeconfig", kubeconfigPath)
+	}
+	if kubeContext != "" {
+		args = append(args, "--context", kubeContext)
+	}
+	if apiAddr != "" {
+		args = append(args, "--api-addr", apiAddr)
+	}
+
+	log.Debugf("Running: %s %s", executable, strings.Join(args, " "))
+	out, err := exec.Command(executable, args...).CombinedOutput()
+	if _, ok := err.(*exec.ExitError); ok {
+		return out, nil
+	}
+	return out, err
+}
+
+// collectDeploymentStats returns the stats of the deployments in all
+// namespaces, as rendered by `linkerd stat`.
+func collectDeploymentStats(client pb.ApiClient) ([]byte, error) {
+	options := newStatOptions()
+	options.allNamespaces = true
+
+	req, err := buildStatSummaryRequest([]string{k8s.Deployment}, options)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := requestStatsFromAPI(client, req, options)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(stats), nil
+}
+
+// collectLinkerdConfig returns the Linkerd config ConfigMap of the control
+// plane, as JSON.
+func collectLinkerdConfig(kubeAPI k8s.KubernetesApi) ([]byte, error) {
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var configMap v1.ConfigMap
+	if err := getKubernetesObject(client, kubeAPI, controlPlaneNamespace, "/configmaps/"+k8s.LinkerdConfigMapName, &configMap); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(configMap, "", "  ")
+}
+
+// fetchControlPlanePods returns the pods in the control plane namespace.
+func fetchControlPlanePods(kubeAPI k8s.KubernetesApi) ([]v1.Pod, error) {
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var pods v1.PodList
+	if err := getKubernetesObject(client, kubeAPI, controlPlaneNamespace, "/pods", &pods); err != nil {
+		return nil, err
+	}
+	return pods.Items, nil
+}
+
+// kubectlArgs returns args, followed by the global flags that select the
+// cluster this CLI was run against, so that kubectl runs against it too.
+func kubectlArgs(args ...string) []string {
+	if kubeconfigPath != "" {
+		args = append(args, "--kubeconfig", kubeconfigPath)
+	}
+	if kubeContext != "" {
+		args = append(args, "--context", kubeContext)
+	}
+	return args
+}
+
+// getContainerLogs returns the logs of a container of a control plane pod, as
+// reported by kubectl.
+func getContainerLogs(pod, container string) ([]byte, error) {
+	args := kubectlArgs("logs", pod, "--namespace", controlPlaneNamespace, "--container", container)
+
+	log.Debugf("Running: kubectl %s", strings.Join(args, " "))
+	out, err := exec.Command("kubectl", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the logs of container [%s] of pod [%s]: %s", container, pod, err)
+	}
+	return out, nil
+}
+
+// redactBundleFiles replaces the IPv4 and IPv6 addresses in files, and the values of the
+// Linkerd config ConfigMap, with their hashes.
+func redactBundleFiles(files []bundleFile) []bundleFile {
+	redacted := make([]bundleFile, 0, len(files))
+	for _, file := range files {
+		content := file.content
+		if file.name == k8s.LinkerdConfigMapName+".json" {
+			content = redactConfigMapData(content)
+		}
+		content = ipv6CandidateRegex.ReplaceAllFunc(content, func(word []byte) []byte {
+			if net.ParseIP(string(word)) == nil {
+				return word
+			}
+			return []byte(redactedValue(string(word)))
+		})
+		content = ipv4Regex.ReplaceAllFunc(content, func(ip []byte) []byte {
+			return []byte(redactedValue(string(ip)))
+		})
+		redacted = append(redacted, bundleFile{name: file.name, content: content})
+	}
+	return redacted
+}
+
+// redactConfigMapData replaces the values of the ConfigMap in content, as
+// JSON, with their hashes. Content that isn't a ConfigMap, e.g. the error that
+// prevented it from being collected, is returned as is.
+func redactConfigMapData(content []byte) []byte {
+	var configMap v1.ConfigMap
+	if err := json.Unmarshal(content, &configMap); err != nil || configMap.Data == nil {
+		return content
+	}
+
+	for key, value := range configMap.Data {
+		configMap.Data[key] = redactedValue(value)
+	}
+	redacted, err := json.MarshalIndent(configMap, "", "  ")
+	if err != nil {
+		return content
+	}
+	return redacted
+}
+
+// redactedValue returns the hash that replaces value in a redacted bundle.
+func redactedValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "redacted-" + hex.EncodeToString(sum[:6])
+}
+
+// writeDiagnosticsBundle writes files to w as a gzipped tar archive, in a
+// directory named name.
+func writeDiagnosticsBundle(w io.Writer, name string, files []bundleFile, modTime time.Time) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    path.Join(name, file.name),
+			Mode:    0644,
+			Size:    int64(len(file.content)),
+			ModTime: modTime,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(file.content); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
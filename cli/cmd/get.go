@@ -14,12 +14,14 @@ import (
 type getOptions struct {
 	namespace     string
 	allNamespaces bool
+	kubeContext   string
 }
 
 func newGetOptions() *getOptions {
 	return &getOptions{
 		namespace:     "default",
 		allNamespaces: false,
+		kubeContext:   "",
 	}
 }
 
@@ -54,7 +56,7 @@ Only pod resources (aka pods, po) are supported.`,
 			if err != nil || resourceType != k8s.Pod {
 				return fmt.Errorf("invalid resource type %s, valid types: %s", friendlyName, k8s.Pod)
 			}
-			client, err := newPublicAPIClient()
+			client, err := newPublicAPIClientForContext(kubeContextFor(options.kubeContext))
 			if err != nil {
 				return err
 			}
@@ -79,6 +81,7 @@ Only pod resources (aka pods, po) are supported.`,
 
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of pods")
 	cmd.PersistentFlags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, returns pods across all namespaces, ignoring the \"--namespace\" flag")
+	addKubeContextFlag(cmd, &options.kubeContext)
 	return cmd
 }
 
@@ -0,0 +1,421 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// These constants are used to indicate how close a certificate is to expiry.
+const (
+	// expiryGreen indicates that the certificate is valid and not close to
+	// expiry.
+	expiryGreen = "green"
+
+	// expiryYellow indicates that less than expiryWarningFraction of the
+	// certificate's validity period remains.
+	expiryYellow = "yellow"
+
+	// expiryRed indicates that the certificate has expired or is not yet valid.
+	expiryRed = "red"
+
+	expiryWarningFraction = 0.2
+
+	jsonOutput = "json"
+
+	// proxySecretVolumeName is the name of the volume that `linkerd inject`
+	// adds to hold the proxy's certificate and private key.
+	proxySecretVolumeName = "linkerd-secrets"
+
+	// clusterListPageSize is the number of objects that listClusterObjects
+	// requests per page.
+	clusterListPageSize = 500
+)
+
+var errKubernetesObjectNotFound = errors.New("not found")
+
+type identityOptions struct {
+	namespace string
+	output    string
+}
+
+// proxyCertificate describes the leaf certificate that a proxy is using.
+type proxyCertificate struct {
+	Subject   string    `json:"subject"`
+	SANs      []string  `json:"sans"`
+	Issuer    string    `json:"issuer"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+	Trusted   bool      `json:"trusted"`
+	Expiry    string    `json:"expiry"`
+}
+
+// proxyIdentity describes the identity of a single pod's proxy. Certificate is
+// nil if the proxy has no identity, in which case Message says why.
+type proxyIdentity struct {
+	Namespace   string            `json:"namespace"`
+	Pod         string            `json:"pod"`
+	Certificate *proxyCertificate `json:"certificate,omitempty"`
+	Message     string            `json:"message,omitempty"`
+}
+
+func newIdentityOptions() *identityOptions {
+	return &identityOptions{
+		namespace: "default",
+		output:    "",
+	}
+}
+
+func newCmdIdentity() *cobra.Command {
+	options := newIdentityOptions()
+
+	cmd := &cobra.Command{
+		Use:   "identity [flags] (POD | RESOURCE NAME)",
+		Short: "Display the TLS identity of one or many proxies",
+		Long: `Display the TLS identity of one or many proxies.
+
+Prints the leaf certificate that each proxy is currently using, and whether it
+chains to the control plane's trust anchors.
+
+Valid resource types include:
+  * pods (aka pod, po)
+  * deployments (aka deployment, deploy)`,
+		Example: `  # the identity of the web-6b8b6f8c6-xkqvz pod in the emojivoto namespace
+  linkerd identity web-6b8b6f8c6-xkqvz -n emojivoto
+
+  # the identities of all pods in the web deployment, as JSON
+  linkerd identity deploy web -n emojivoto -o json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resourceType, name, err := parseIdentityArgs(args)
+			if err != nil {
+				return err
+			}
+
+			if options.output != "" && options.output != jsonOutput {
+				return fmt.Errorf("--output must be blank or \"%s\", was: %s", jsonOutput, options.output)
+			}
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+
+			identities, err := fetchProxyIdentities(kubeAPI, resourceType, name, options.namespace, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if len(identities) == 0 {
+				fmt.Fprintln(os.Stderr, "No resources found.")
+				os.Exit(0)
+			}
+
+			return renderIdentities(identities, os.Stdout, options.output)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
+	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output, "Output format; one of: \"json\"")
+
+	return cmd
+}
+
+func parseIdentityArgs(args []string) (string, string, error) {
+	var friendlyName, name string
+	switch len(args) {
+	case 1:
+		friendlyName = k8s.Pod
+		name = args[0]
+		if parts := strings.Split(args[0], "/"); len(parts) == 2 {
+			friendlyName, name = parts[0], parts[1]
+		}
+	case 2:
+		friendlyName, name = args[0], args[1]
+	default:
+		return "", "", errors.New("please specify a pod, or a resource type and name")
+	}
+
+	resourceType, err := k8s.CanonicalResourceNameFromFriendlyName(friendlyName)
+	if err != nil || (resourceType != k8s.Pod && resourceType != k8s.Deployment) {
+		return "", "", fmt.Errorf("invalid resource type %s, valid types: %s, %s", friendlyName, k8s.Pod, k8s.Deployment)
+	}
+
+	return resourceType, name, nil
+}
+
+// fetchProxyIdentities reads the certificate that the proxy in each of the
+// named resource's pods has mounted, along with the trust anchors in the
+// resource's namespace, and describes each proxy's identity.
+func fetchProxyIdentities(kubeAPI k8s.KubernetesApi, resourceType, name, namespace string, now time.Time) ([]proxyIdentity, error) {
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []v1.Pod
+	if resourceType == k8s.Pod {
+		var pod v1.Pod
+		err = getKubernetesObject(client, kubeAPI, namespace, "/pods/"+name, &pod)
+		if err == errKubernetesObjectNotFound {
+			return nil, fmt.Errorf("pod %s/%s not found", namespace, name)
+		}
+		pods = append(pods, pod)
+	} else {
+		var podList v1.PodList
+		selector := url.QueryEscape(fmt.Sprintf("%s=%s", k8s.ProxyDeploymentLabel, name))
+		err = getKubernetesObject(client, kubeAPI, namespace, "/pods?labelSelector="+selector, &podList)
+		pods = podList.Items
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var trustAnchors v1.ConfigMap
+	err = getKubernetesObject(client, kubeAPI, namespace, "/configmaps/"+k8s.TLSTrustAnchorConfigMapName, &trustAnchors)
+	if err != nil && err != errKubernetesObjectNotFound {
+		return nil, err
+	}
+	trustAnchorsPEM := trustAnchors.Data[k8s.TLSTrustAnchorFileName]
+
+	identities := make([]proxyIdentity, 0)
+	for _, pod := range pods {
+		identity := proxyIdentity{Namespace: pod.Namespace, Pod: pod.Name}
+
+		secretName := proxySecretName(pod.Spec)
+		if secretName == "" {
+			identity.Message = "no identity; TLS is not enabled for this pod"
+			identities = append(identities, identity)
+			continue
+		}
+
+		var secret v1.Secret
+		err = getKubernetesObject(client, kubeAPI, pod.Namespace, "/secrets/"+secretName, &secret)
+		if err == errKubernetesObjectNotFound {
+			identity.Message = fmt.Sprintf("no identity; secret %s has not been created yet", secretName)
+			identities = append(identities, identity)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		identity.Certificate, err = parseProxyCertificate(secret.Data[k8s.TLSCertFileName], trustAnchorsPEM, now)
+		if err != nil {
+			identity.Message = fmt.Sprintf("invalid certificate in secret %s: %s", secretName, err)
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, nil
+}
+
+// proxySecretName returns the name of the secret that holds the proxy's
+// certificate, or an empty string if the pod was injected without TLS.
+func proxySecretName(spec v1.PodSpec) string {
+	for _, volume := range spec.Volumes {
+		if volume.Name == proxySecretVolumeName && volume.Secret != nil {
+			return volume.Secret.SecretName
+		}
+	}
+	return ""
+}
+
+func getKubernetesObject(client *http.Client, kubeAPI k8s.KubernetesApi, namespace, path string, obj interface{}) error {
+	url, err := kubeAPI.UrlFor(namespace, path)
+	if err != nil {
+		return err
+	}
+	return getObjectAt(client, url, obj)
+}
+
+// getClusterObject is like getKubernetesObject, for an absolute API path that
+// isn't namespaced, e.g. /apis/apps/v1/deployments, whose URL is derived from
+// the namespaced one that kubeAPI generates.
+func getClusterObject(client *http.Client, kubeAPI k8s.KubernetesApi, path string, obj interface{}) error {
+	objectURL, err := clusterURLFor(kubeAPI, path)
+	if err != nil {
+		return err
+	}
+	return getObjectAt(client, objectURL, obj)
+}
+
+// listClusterObjects is like getClusterObject for a list, e.g. /api/v1/pods,
+// that's requested in pages of up to clusterListPageSize objects, so that the
+// objects of large clusters aren't all sent in one response. newPage returns
+// the list to decode each page into, and a func that's called once it's
+// decoded.
+func listClusterObjects(client *http.Client, kubeAPI k8s.KubernetesApi, path string, newPage func() (metav1.ListInterface, func())) error {
+	listURL, err := clusterURLFor(kubeAPI, path)
+	if err != nil {
+		return err
+	}
+
+	continueToken := ""
+	for {
+		query := url.Values{"limit": {strconv.Itoa(clusterListPageSize)}}
+		if continueToken != "" {
+			query.Set("continue", continueToken)
+		}
+		listURL.RawQuery = query.Encode()
+
+		page, decoded := newPage()
+		if err := getObjectAt(client, listURL, page); err != nil {
+			return err
+		}
+		decoded()
+
+		continueToken = page.GetContinue()
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
+
+// clusterURLFor returns the URL of an absolute API path, derived from the
+// namespaced one that kubeAPI generates.
+func clusterURLFor(kubeAPI k8s.KubernetesApi, path string) (*url.URL, error) {
+	namespaceURL, err := kubeAPI.UrlFor(controlPlaneNamespace, "/")
+	if err != nil {
+		return nil, err
+	}
+	clusterURL := *namespaceURL
+	clusterURL.Path = strings.TrimSuffix(clusterURL.Path, fmt.Sprintf("/api/v1/namespaces/%s/", controlPlaneNamespace)) + path
+	return &clusterURL, nil
+}
+
+// getObjectAt decodes the object at objectURL into obj.
+func getObjectAt(client *http.Client, objectURL *url.URL, obj interface{}) error {
+	rsp, err := client.Get(objectURL.String())
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusNotFound {
+		return errKubernetesObjectNotFound
+	}
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP GET request to endpoint [%s] resulted in Status: [%s]", objectURL, rsp.Status)
+	}
+
+	return json.NewDecoder(rsp.Body).Decode(obj)
+}
+
+// parseProxyCertificate parses a DER-encoded leaf certificate and checks
+// whether it chains to any of the PEM-encoded trust anchors.
+func parseProxyCertificate(der []byte, trustAnchorsPEM string, now time.Time) (*proxyCertificate, error) {
+	crt, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proxyCertificate{
+		Subject:   crt.Subject.String(),
+		SANs:      crt.DNSNames,
+		Issuer:    crt.Issuer.String(),
+		NotBefore: crt.NotBefore.UTC(),
+		NotAfter:  crt.NotAfter.UTC(),
+		Trusted:   isTrusted(crt, trustAnchorsPEM),
+		Expiry:    certificateExpiry(crt, now),
+	}, nil
+}
+
+func isTrusted(crt *x509.Certificate, trustAnchorsPEM string) bool {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM([]byte(trustAnchorsPEM)) {
+		return false
+	}
+
+	// Verify the chain as of the start of the certificate's validity period,
+	// so that expiry is reported separately from trust.
+	_, err := crt.Verify(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: crt.NotBefore,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil
+}
+
+func certificateExpiry(crt *x509.Certificate, now time.Time) string {
+	if now.Before(crt.NotBefore) || !now.Before(crt.NotAfter) {
+		return expiryRed
+	}
+
+	validity := crt.NotAfter.Sub(crt.NotBefore)
+	if crt.NotAfter.Sub(now) < time.Duration(float64(validity)*expiryWarningFraction) {
+		return expiryYellow
+	}
+
+	return expiryGreen
+}
+
+func renderIdentities(identities []proxyIdentity, w io.Writer, output string) error {
+	if output == jsonOutput {
+		out, err := json.MarshalIndent(identities, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", out)
+		return err
+	}
+
+	for i, identity := range identities {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "POD %s/%s\n", identity.Namespace, identity.Pod)
+
+		crt := identity.Certificate
+		if crt == nil {
+			fmt.Fprintf(w, "  %s\n", identity.Message)
+			continue
+		}
+
+		fmt.Fprintf(w, "  Subject:     %s\n", valueOrDash(crt.Subject))
+		fmt.Fprintf(w, "  SANs:        %s\n", valueOrDash(strings.Join(crt.SANs, ", ")))
+		fmt.Fprintf(w, "  Issuer:      %s\n", valueOrDash(crt.Issuer))
+		fmt.Fprintf(w, "  Not Before:  %s\n", crt.NotBefore.Format(time.RFC3339))
+		fmt.Fprintf(w, "  Not After:   %s\n", crt.NotAfter.Format(time.RFC3339))
+		fmt.Fprintf(w, "  Expiry:      %s\n", colorizeExpiry(crt.Expiry))
+		fmt.Fprintf(w, "  Trusted:     %s\n", yesOrNo(crt.Trusted))
+	}
+
+	return nil
+}
+
+func colorizeExpiry(expiry string) string {
+	colors := map[string]string{
+		expiryGreen:  "32",
+		expiryYellow: "33",
+		expiryRed:    "31",
+	}
+	return fmt.Sprintf("\x1b[%sm%s\x1b[0m", colors[expiry], expiry)
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func yesOrNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
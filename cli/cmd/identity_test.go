@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+)
+
+var (
+	testNotBefore = time.Date(2018, 8, 1, 0, 0, 0, 0, time.UTC)
+	testNotAfter  = time.Date(2018, 8, 11, 0, 0, 0, 0, time.UTC)
+)
+
+type testCertificate struct {
+	crt *x509.Certificate
+	key *ecdsa.PrivateKey
+}
+
+// newTestCertificate creates a certificate from template, signed by parent,
+// or self-signed if parent is nil.
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	issuer, issuerKey := template, key
+	if parent != nil {
+		issuer, issuerKey = parent.crt, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	crt, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return &testCertificate{crt: crt, key: key}
+}
+
+func newTestCA(t *testing.T, name string) *testCertificate {
+	return newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             testNotBefore.Add(-time.Hour),
+		NotAfter:              testNotAfter.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+}
+
+func newTestLeaf(t *testing.T, ca *testCertificate) *testCertificate {
+	return newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    testNotBefore,
+		NotAfter:     testNotAfter,
+		DNSNames:     []string{"web.deployment.emojivoto.linkerd-managed.linkerd.svc.cluster.local"},
+	}, ca)
+}
+
+func toPEM(crt *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: crt.Raw}))
+}
+
+func TestParseProxyCertificate(t *testing.T) {
+	ca := newTestCA(t, "Cluster-local Managed Pod CA")
+	otherCA := newTestCA(t, "Some Other CA")
+	leaf := newTestLeaf(t, ca)
+	now := testNotBefore.Add(24 * time.Hour)
+
+	t.Run("Describes a certificate that chains to the trust anchors", func(t *testing.T) {
+		crt, err := parseProxyCertificate(leaf.crt.Raw, toPEM(otherCA.crt)+toPEM(ca.crt), now)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := &proxyCertificate{
+			Subject:   "",
+			SANs:      []string{"web.deployment.emojivoto.linkerd-managed.linkerd.svc.cluster.local"},
+			Issuer:    "CN=Cluster-local Managed Pod CA",
+			NotBefore: testNotBefore,
+			NotAfter:  testNotAfter,
+			Trusted:   true,
+			Expiry:    expiryGreen,
+		}
+		if !reflect.DeepEqual(crt, expected) {
+			t.Fatalf("Expected %+v, got %+v", expected, crt)
+		}
+	})
+
+	t.Run("Reports a certificate that does not chain to the trust anchors", func(t *testing.T) {
+		for _, anchors := range []string{toPEM(otherCA.crt), ""} {
+			crt, err := parseProxyCertificate(leaf.crt.Raw, anchors, now)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if crt.Trusted {
+				t.Fatalf("Expected certificate not to be trusted by [%s]", anchors)
+			}
+		}
+	})
+
+	t.Run("Returns an error for a malformed certificate", func(t *testing.T) {
+		_, err := parseProxyCertificate([]byte("not a certificate"), toPEM(ca.crt), now)
+		if err == nil {
+			t.Fatalf("Expected error, got nil")
+		}
+	})
+}
+
+func TestCertificateExpiry(t *testing.T) {
+	crt := &x509.Certificate{NotBefore: testNotBefore, NotAfter: testNotAfter}
+
+	testCases := []struct {
+		now      time.Time
+		expected string
+	}{
+		{testNotBefore.Add(-time.Second), expiryRed},
+		{testNotBefore, expiryGreen},
+		{testNotAfter.Add(-3 * 24 * time.Hour), expiryGreen},
+		{testNotAfter.Add(-24 * time.Hour), expiryYellow},
+		{testNotAfter.Add(-time.Second), expiryYellow},
+		{testNotAfter, expiryRed},
+	}
+
+	for _, tc := range testCases {
+		expiry := certificateExpiry(crt, tc.now)
+		if expiry != tc.expected {
+			t.Fatalf("Expected expiry [%s] at %s, got [%s]", tc.expected, tc.now, expiry)
+		}
+	}
+}
+
+func TestProxySecretName(t *testing.T) {
+	spec := v1.PodSpec{
+		Volumes: []v1.Volume{
+			{Name: "linkerd-trust-anchors"},
+			{
+				Name: proxySecretVolumeName,
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{SecretName: "web-deployment-tls-linkerd-io"},
+				},
+			},
+		},
+	}
+
+	if name := proxySecretName(spec); name != "web-deployment-tls-linkerd-io" {
+		t.Fatalf("Expected secret name [web-deployment-tls-linkerd-io], got [%s]", name)
+	}
+	if name := proxySecretName(v1.PodSpec{}); name != "" {
+		t.Fatalf("Expected no secret name, got [%s]", name)
+	}
+}
+
+func TestParseIdentityArgs(t *testing.T) {
+	testCases := []struct {
+		args         []string
+		resourceType string
+		name         string
+	}{
+		{[]string{"web-123"}, k8s.Pod, "web-123"},
+		{[]string{"po/web-123"}, k8s.Pod, "web-123"},
+		{[]string{"deploy", "web"}, k8s.Deployment, "web"},
+		{[]string{"deployment/web"}, k8s.Deployment, "web"},
+	}
+
+	for _, tc := range testCases {
+		resourceType, name, err := parseIdentityArgs(tc.args)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if resourceType != tc.resourceType || name != tc.name {
+			t.Fatalf("Expected [%s %s], got [%s %s]", tc.resourceType, tc.name, resourceType, name)
+		}
+	}
+
+	expectedError := "invalid resource type svc, valid types: pod, deployment"
+	_, _, err := parseIdentityArgs([]string{"svc", "web"})
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected error [%s], got [%v]", expectedError, err)
+	}
+}
+
+func TestRenderIdentities(t *testing.T) {
+	identities := []proxyIdentity{
+		{
+			Namespace: "emojivoto",
+			Pod:       "web-6b8b6f8c6-xkqvz",
+			Certificate: &proxyCertificate{
+				SANs:      []string{"web.deployment.emojivoto.linkerd-managed.linkerd.svc.cluster.local"},
+				Issuer:    "CN=Cluster-local Managed Pod CA",
+				NotBefore: testNotBefore,
+				NotAfter:  testNotAfter,
+				Trusted:   true,
+				Expiry:    expiryYellow,
+			},
+		},
+		{
+			Namespace: "emojivoto",
+			Pod:       "vote-bot-5c5d9cd8f-8r5zs",
+			Message:   "no identity; TLS is not enabled for this pod",
+		},
+	}
+
+	t.Run("Renders a description of each identity", func(t *testing.T) {
+		expectedOutput := `POD emojivoto/web-6b8b6f8c6-xkqvz
+  Subject:     -
+  SANs:        web.deployment.emojivoto.linkerd-managed.linkerd.svc.cluster.local
+  Issuer:      CN=Cluster-local Managed Pod CA
+  Not Before:  2018-08-01T00:00:00Z
+  Not After:   2018-08-11T00:00:00Z
+  Expiry:      ` + "\x1b[33myellow\x1b[0m" + `
+  Trusted:     yes
+
+POD emojivoto/vote-bot-5c5d9cd8f-8r5zs
+  no identity; TLS is not enabled for this pod
+`
+
+		var buf bytes.Buffer
+		if err := renderIdentities(identities, &buf, ""); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		diffCompare(t, buf.String(), expectedOutput)
+	})
+
+	t.Run("Renders JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := renderIdentities(identities, &buf, jsonOutput); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var actual []proxyIdentity
+		if err := json.Unmarshal(buf.Bytes(), &actual); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(actual, identities) {
+			t.Fatalf("Expected %+v, got %+v", identities, actual)
+		}
+	})
+}
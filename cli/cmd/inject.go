@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -18,8 +19,10 @@ import (
 	"k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
 	k8sMeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
 )
 
@@ -31,26 +34,260 @@ const (
 	ControlPlanePodName = "controller"
 	// The name of the variable used to pass the pod's namespace.
 	PodNamespaceEnvVarName = "LINKERD2_PROXY_POD_NAMESPACE"
+	// The name of the variable used to enable the proxy's pprof endpoints.
+	PprofEnvVarName = "LINKERD2_PROXY_ENABLE_PPROF"
+	// The name of the variable used to set the timeout of the proxy's
+	// protocol detection on inbound connections.
+	InboundDetectTimeoutEnvVarName = "LINKERD2_PROXY_INBOUND_DETECT_TIMEOUT"
+	// The name of the variable used to override the init container's
+	// iptables mode.
+	IptablesModeEnvVarName = "LINKERD2_PROXY_INIT_IPTABLES_MODE"
+	// The name of the variable used to override whether the init container
+	// redirects IPv6 traffic.
+	IPv6ModeEnvVarName = "LINKERD2_PROXY_INIT_IPV6_MODE"
+
+	// reservedLabelDomain is the domain, and parent domain, of the labels that
+	// --add-label cannot add.
+	reservedLabelDomain = "linkerd.io"
+
+	// noPartition is the value of --partition that leaves the partition of
+	// StatefulSets' rolling updates unchanged.
+	noPartition = -1
 )
 
+// injectSupportedKinds are the kinds of resources that inject adds the proxy
+// to, along with Lists of them.
+var injectSupportedKinds = []string{"Deployment", "ReplicationController", "ReplicaSet", "Job", "DaemonSet", "StatefulSet", "Pod", "List"}
+
+// iptablesModes are the valid values of --iptables-mode, which must match the
+// modes that proxy-init supports.
+var iptablesModes = []string{"auto", "legacy", "nft"}
+
+// ipv6Modes are the valid values of --ipv6-mode, which must match the modes
+// that proxy-init supports.
+var ipv6Modes = []string{"auto", "enabled", "disabled"}
+
 type injectOptions struct {
-	inboundPort         uint
-	outboundPort        uint
-	ignoreInboundPorts  []uint
-	ignoreOutboundPorts []uint
+	inboundPort           uint
+	outboundPort          uint
+	ignoreInboundPorts    []uint
+	ignoreOutboundPorts   []uint
+	skipOwnerUIDs         []uint
+	skipOwnerGIDs         []uint
+	skipOutboundCIDRs     []string
+	initImagePullPolicy   string
+	cpuProfileAnnotations bool
+	addLabels             []string
+	addInitContainers     []string
+	iptablesMode          string
+	ipv6Mode              string
+	partition             int32
+	strict                bool
+	removeProbes          bool
+	removeLivenessOnly    bool
+	removeReadinessOnly   bool
 	*proxyConfigOptions
 }
 
 func newInjectOptions() *injectOptions {
 	return &injectOptions{
-		inboundPort:         4143,
-		outboundPort:        4140,
-		ignoreInboundPorts:  nil,
-		ignoreOutboundPorts: nil,
-		proxyConfigOptions:  newProxyConfigOptions(),
+		inboundPort:           4143,
+		outboundPort:          4140,
+		ignoreInboundPorts:    nil,
+		ignoreOutboundPorts:   nil,
+		skipOwnerUIDs:         nil,
+		skipOwnerGIDs:         nil,
+		skipOutboundCIDRs:     nil,
+		initImagePullPolicy:   "",
+		cpuProfileAnnotations: false,
+		addLabels:             nil,
+		addInitContainers:     nil,
+		iptablesMode:          "",
+		ipv6Mode:              "",
+		partition:             noPartition,
+		strict:                false,
+		removeProbes:          false,
+		removeLivenessOnly:    false,
+		removeReadinessOnly:   false,
+		proxyConfigOptions:    newProxyConfigOptions(),
 	}
 }
 
+func (options *injectOptions) validate() error {
+	if err := options.proxyConfigOptions.validate(); err != nil {
+		return err
+	}
+	if options.initImagePullPolicy != "" && !isValidPullPolicy(options.initImagePullPolicy) {
+		return fmt.Errorf("--init-image-pull-policy must be one of: Always, IfNotPresent, Never")
+	}
+	for _, label := range options.addLabels {
+		if err := validateAddLabel(label); err != nil {
+			return err
+		}
+	}
+	initContainerNames := map[string]bool{}
+	for _, spec := range options.addInitContainers {
+		container, err := parseAddInitContainer(spec)
+		if err != nil {
+			return err
+		}
+		if initContainerNames[container.Name] {
+			return fmt.Errorf("--add-init-container adds more than one init container named [%s]", container.Name)
+		}
+		initContainerNames[container.Name] = true
+	}
+	for _, cidr := range options.skipOutboundCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("--skip-outbound-cidrs has an invalid CIDR [%s]", cidr)
+		}
+	}
+	if options.iptablesMode != "" && !isValidIptablesMode(options.iptablesMode) {
+		return fmt.Errorf("--iptables-mode must be one of: %s", strings.Join(iptablesModes, ", "))
+	}
+	if options.ipv6Mode != "" && !isValidIPv6Mode(options.ipv6Mode) {
+		return fmt.Errorf("--ipv6-mode must be one of: %s", strings.Join(ipv6Modes, ", "))
+	}
+	if options.partition < noPartition {
+		return fmt.Errorf("--partition must be a pod ordinal, was: %d", options.partition)
+	}
+	removeFlags := 0
+	for _, set := range []bool{options.removeProbes, options.removeLivenessOnly, options.removeReadinessOnly} {
+		if set {
+			removeFlags++
+		}
+	}
+	if removeFlags > 1 {
+		return fmt.Errorf("only one of --remove-probes, --remove-liveness-only and --remove-readiness-only may be set")
+	}
+	return nil
+}
+
+// removesLivenessProbes returns whether the application containers' liveness
+// probes are stripped from the injected pod templates.
+func (options *injectOptions) removesLivenessProbes() bool {
+	return options.removeProbes || options.removeLivenessOnly
+}
+
+// removesReadinessProbes returns whether the application containers'
+// readiness probes are stripped from the injected pod templates.
+func (options *injectOptions) removesReadinessProbes() bool {
+	return options.removeProbes || options.removeReadinessOnly
+}
+
+func isValidIPv6Mode(mode string) bool {
+	for _, valid := range ipv6Modes {
+		if mode == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// redirectsIPv6 returns whether the init container may redirect the pod's
+// IPv6 traffic to the proxy, which must then accept it.
+func (options *injectOptions) redirectsIPv6() bool {
+	return options.ipv6Mode == "auto" || options.ipv6Mode == "enabled"
+}
+
+func isValidIptablesMode(mode string) bool {
+	for _, valid := range iptablesModes {
+		if mode == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// skipOwnerUIDsWithoutProxy returns the --skip-owner-uid user IDs without the
+// proxy's own, which the init container always skips with --proxy-uid, so that
+// the user's IDs can't change or duplicate the proxy's exclusion.
+func (options *injectOptions) skipOwnerUIDsWithoutProxy() []string {
+	uids := []string{}
+	for _, uid := range options.skipOwnerUIDs {
+		if int64(uid) != options.proxyUID {
+			uids = append(uids, strconv.Itoa(int(uid)))
+		}
+	}
+	return uids
+}
+
+func uintsToStrings(values []uint) []string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = strconv.Itoa(int(v))
+	}
+	return strs
+}
+
+// validateAddLabel checks that label is a key=value pair that is a valid
+// Kubernetes label outside of the domain that Linkerd reserves.
+func validateAddLabel(label string) error {
+	parts := strings.SplitN(label, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("--add-label must be a key=value pair, got [%s]", label)
+	}
+	key, value := parts[0], parts[1]
+
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("--add-label has an invalid key [%s]: %s", key, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return fmt.Errorf("--add-label has an invalid value [%s]: %s", value, strings.Join(errs, ", "))
+	}
+	if prefix := strings.SplitN(key, "/", 2); len(prefix) == 2 && (prefix[0] == reservedLabelDomain || strings.HasSuffix(prefix[0], "."+reservedLabelDomain)) {
+		return fmt.Errorf("--add-label cannot add [%s], %s labels are reserved for Linkerd", key, reservedLabelDomain)
+	}
+	return nil
+}
+
+// parseAddInitContainer parses an --add-init-container spec, a comma-separated
+// list of name=X, image=Y and command=Z fields, into an init container. The
+// name and image are required, and the command is split on whitespace.
+func parseAddInitContainer(spec string) (v1.Container, error) {
+	container := v1.Container{}
+	for _, field := range strings.Split(spec, ",") {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return v1.Container{}, fmt.Errorf("--add-init-container must be a list of name=X,image=Y,command=Z fields, got [%s]", spec)
+		}
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+
+		switch key {
+		case "name":
+			container.Name = value
+		case "image":
+			container.Image = value
+		case "command":
+			container.Command = strings.Fields(value)
+		default:
+			return v1.Container{}, fmt.Errorf("--add-init-container has an unknown field [%s], must be one of: name, image, command", key)
+		}
+	}
+
+	if container.Name == "" {
+		return v1.Container{}, fmt.Errorf("--add-init-container is missing a name in [%s]", spec)
+	}
+	if errs := validation.IsDNS1123Label(container.Name); len(errs) > 0 {
+		return v1.Container{}, fmt.Errorf("--add-init-container has an invalid name [%s]: %s", container.Name, strings.Join(errs, ", "))
+	}
+	if container.Name == "linkerd-init" || container.Name == "linkerd-proxy" {
+		return v1.Container{}, fmt.Errorf("--add-init-container cannot add [%s], the name is reserved for Linkerd", container.Name)
+	}
+	if container.Image == "" {
+		return v1.Container{}, fmt.Errorf("--add-init-container is missing an image in [%s]", spec)
+	}
+	return container, nil
+}
+
+// initContainerPullPolicy returns the pull policy for the linkerd-init
+// container, which defaults to the --image-pull-policy used for the proxy.
+func (options *injectOptions) initContainerPullPolicy() string {
+	if options.initImagePullPolicy != "" {
+		return options.initImagePullPolicy
+	}
+	return options.imagePullPolicy
+}
+
 func newCmdInject() *cobra.Command {
 	options := newInjectOptions()
 
@@ -90,6 +327,21 @@ sub-folder. e.g. linkerd inject <folder> | kubectl apply -f -
 	cmd.PersistentFlags().UintVar(&options.outboundPort, "outbound-port", options.outboundPort, "Proxy port to use for outbound traffic")
 	cmd.PersistentFlags().UintSliceVar(&options.ignoreInboundPorts, "skip-inbound-ports", options.ignoreInboundPorts, "Ports that should skip the proxy and send directly to the application")
 	cmd.PersistentFlags().UintSliceVar(&options.ignoreOutboundPorts, "skip-outbound-ports", options.ignoreOutboundPorts, "Outbound ports that should skip the proxy")
+	cmd.PersistentFlags().UintSliceVar(&options.skipOwnerUIDs, "skip-owner-uid", options.skipOwnerUIDs, "User IDs whose outbound traffic should skip the proxy, e.g. of other sidecars; the proxy's own --proxy-uid always skips it")
+	cmd.PersistentFlags().UintSliceVar(&options.skipOwnerGIDs, "skip-owner-gid", options.skipOwnerGIDs, "Group IDs whose outbound traffic should skip the proxy")
+	cmd.PersistentFlags().StringSliceVar(&options.skipOutboundCIDRs, "skip-outbound-cidrs", options.skipOutboundCIDRs, "Outbound destinations, as IPv4 or IPv6 CIDRs, that should skip the proxy, e.g. a node-local DNS cache")
+	cmd.PersistentFlags().StringVar(&options.initImagePullPolicy, "init-image-pull-policy", options.initImagePullPolicy, "Docker image pull policy for the init container (defaults to --image-pull-policy)")
+	cmd.PersistentFlags().StringSliceVar(&options.addLabels, "add-label", options.addLabels, "Labels, as key=value, to add to the injected pod templates (may be repeated)")
+	cmd.PersistentFlags().StringArrayVar(&options.addInitContainers, "add-init-container", options.addInitContainers, "Init container, as name=X,image=Y,command=Z, to add to the injected pod templates after linkerd-init (may be repeated); the proxy isn't running yet, so its outbound traffic must skip the proxy")
+	cmd.PersistentFlags().StringVar(&options.iptablesMode, "iptables-mode", options.iptablesMode, "iptables backend that the init container programs the pod's rules with, one of: "+strings.Join(iptablesModes, ", ")+"; annotates the injected pod templates with "+k8s.ProxyInitIptablesModeAnnotation+", which can be changed to override it (by default the backend that the node uses is detected)")
+	cmd.PersistentFlags().StringVar(&options.ipv6Mode, "ipv6-mode", options.ipv6Mode, "Whether the init container also redirects the pod's IPv6 traffic to the proxy, one of: "+strings.Join(ipv6Modes, ", ")+"; auto does if the pod has IPv6 addresses; annotates the injected pod templates with "+k8s.ProxyInitIPv6ModeAnnotation+", which can be changed to override it (by default IPv6 traffic skips the proxy)")
+	cmd.PersistentFlags().BoolVar(&options.linkerdCNI, "linkerd-cni", options.linkerdCNI, "Omit the init container, as the linkerd-cni plugin, installed with \"linkerd install-cni\", programs the pods' iptables rules instead")
+	cmd.PersistentFlags().Int32Var(&options.partition, "partition", options.partition, "Set the partition of the injected StatefulSets' rolling updates, so that only the pods with an ordinal of at least this value are updated to run the proxy (by default the partition is unchanged)")
+	cmd.PersistentFlags().BoolVar(&options.strict, "strict", options.strict, "Fail on resources of kinds that can't be injected, instead of outputting them unchanged with a warning")
+	cmd.PersistentFlags().BoolVar(&options.removeProbes, "remove-probes", options.removeProbes, "Remove the liveness and readiness probes of the application containers, but not the proxy's, from the injected pod templates")
+	cmd.PersistentFlags().BoolVar(&options.removeLivenessOnly, "remove-liveness-only", options.removeLivenessOnly, "Remove only the liveness probes of the application containers from the injected pod templates")
+	cmd.PersistentFlags().BoolVar(&options.removeReadinessOnly, "remove-readiness-only", options.removeReadinessOnly, "Remove only the readiness probes of the application containers from the injected pod templates")
+	cmd.PersistentFlags().BoolVar(&options.cpuProfileAnnotations, "cpu-profile-annotations", options.cpuProfileAnnotations, "Enable pprof CPU profiling on the injected proxies, and annotate their pods with "+k8s.ProxyEnablePprofAnnotation)
 
 	return cmd
 }
@@ -118,7 +370,7 @@ func runInjectCmd(inputs []io.Reader, errWriter, outWriter io.Writer, options *i
 	postInjectBuf := &bytes.Buffer{}
 
 	for _, input := range inputs {
-		err := InjectYAML(input, postInjectBuf, options)
+		err := InjectYAML(input, postInjectBuf, errWriter, options)
 		if err != nil {
 			fmt.Fprintf(errWriter, "Error injecting linkerd proxy: %v\n", err)
 			return 1
@@ -141,10 +393,38 @@ func injectObjectMeta(t *metaV1.ObjectMeta, k8sLabels map[string]string, options
 	}
 	t.Annotations[k8s.CreatedByAnnotation] = k8s.CreatedByAnnotationValue()
 	t.Annotations[k8s.ProxyVersionAnnotation] = options.linkerdVersion
+	if options.cpuProfileAnnotations {
+		t.Annotations[k8s.ProxyEnablePprofAnnotation] = "true"
+	}
+	if options.iptablesMode != "" {
+		t.Annotations[k8s.ProxyInitIptablesModeAnnotation] = options.iptablesMode
+	}
+	if options.ipv6Mode != "" {
+		t.Annotations[k8s.ProxyInitIPv6ModeAnnotation] = options.ipv6Mode
+	}
+	if uids := options.skipOwnerUIDsWithoutProxy(); len(uids) > 0 {
+		t.Annotations[k8s.ProxyInitSkipOwnerUIDsAnnotation] = strings.Join(uids, ",")
+	}
+	if len(options.skipOwnerGIDs) > 0 {
+		t.Annotations[k8s.ProxyInitSkipOwnerGIDsAnnotation] = strings.Join(uintsToStrings(options.skipOwnerGIDs), ",")
+	}
+	if len(options.skipOutboundCIDRs) > 0 {
+		t.Annotations[k8s.ProxyInitSkipOutboundCIDRsAnnotation] = strings.Join(options.skipOutboundCIDRs, ",")
+	}
+	if len(options.ignoreInboundPorts) > 0 {
+		t.Annotations[k8s.ProxyInitSkipInboundPortsAnnotation] = strings.Join(uintsToStrings(options.ignoreInboundPorts), ",")
+	}
+	if len(options.ignoreOutboundPorts) > 0 {
+		t.Annotations[k8s.ProxyInitSkipOutboundPortsAnnotation] = strings.Join(uintsToStrings(options.ignoreOutboundPorts), ",")
+	}
 
 	if t.Labels == nil {
 		t.Labels = make(map[string]string)
 	}
+	for _, label := range options.addLabels {
+		parts := strings.SplitN(label, "=", 2)
+		t.Labels[parts[0]] = parts[1]
+	}
 	t.Labels[k8s.ControllerNSLabel] = controlPlaneNamespace
 	for k, v := range k8sLabels {
 		t.Labels[k] = v
@@ -191,10 +471,25 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 		initArgs = append(initArgs, strings.Join(outboundSkipPortsStr, ","))
 	}
 
+	if uids := options.skipOwnerUIDsWithoutProxy(); len(uids) > 0 {
+		initArgs = append(initArgs, "--skip-owner-uid")
+		initArgs = append(initArgs, strings.Join(uids, ","))
+	}
+
+	if len(options.skipOwnerGIDs) > 0 {
+		initArgs = append(initArgs, "--skip-owner-gid")
+		initArgs = append(initArgs, strings.Join(uintsToStrings(options.skipOwnerGIDs), ","))
+	}
+
+	if len(options.skipOutboundCIDRs) > 0 {
+		initArgs = append(initArgs, "--skip-outbound-cidrs")
+		initArgs = append(initArgs, strings.Join(options.skipOutboundCIDRs, ","))
+	}
+
 	initContainer := v1.Container{
 		Name:                     "linkerd-init",
 		Image:                    options.taggedProxyInitImage(),
-		ImagePullPolicy:          v1.PullPolicy(options.imagePullPolicy),
+		ImagePullPolicy:          v1.PullPolicy(options.initContainerPullPolicy()),
 		TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
 		Args: initArgs,
 		SecurityContext: &v1.SecurityContext{
@@ -204,7 +499,41 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 			Privileged: &f,
 		},
 	}
-	controlPlaneDNS := fmt.Sprintf("proxy-api.%s.svc.cluster.local", controlPlaneNamespace)
+
+	// The init container reads the iptables and IPv6 modes from the pod's
+	// annotations, so that they can be overridden without re-injecting the
+	// pod.
+	if options.iptablesMode != "" {
+		initContainer.Env = append(initContainer.Env, v1.EnvVar{
+			Name: IptablesModeEnvVarName,
+			ValueFrom: &v1.EnvVarSource{
+				FieldRef: &v1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", k8s.ProxyInitIptablesModeAnnotation),
+				},
+			},
+		})
+	}
+	if options.ipv6Mode != "" {
+		initContainer.Env = append(initContainer.Env, v1.EnvVar{
+			Name: IPv6ModeEnvVarName,
+			ValueFrom: &v1.EnvVarSource{
+				FieldRef: &v1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", k8s.ProxyInitIPv6ModeAnnotation),
+				},
+			},
+		})
+	}
+
+	// The rules redirect IPv6 traffic to the proxy's ports on the pod's IPv6
+	// addresses, so the listeners that it's redirected to must accept both
+	// families. The outbound listener is no longer only on loopback, but
+	// inbound traffic to its port is redirected to the inbound one anyway.
+	privateListenerAddr, publicListenerAddr := "127.0.0.1", "0.0.0.0"
+	if options.redirectsIPv6() {
+		privateListenerAddr, publicListenerAddr = "[::]", "[::]"
+	}
+
+	controlPlaneDNS := fmt.Sprintf("proxy-api.%s.svc.%s", controlPlaneNamespace, options.clusterDNSDomain)
 	if controlPlaneDNSNameOverride != "" {
 		controlPlaneDNS = controlPlaneDNSNameOverride
 	}
@@ -236,8 +565,8 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 			},
 			{Name: "LINKERD2_PROXY_CONTROL_LISTENER", Value: fmt.Sprintf("tcp://0.0.0.0:%d", options.proxyControlPort)},
 			{Name: "LINKERD2_PROXY_METRICS_LISTENER", Value: fmt.Sprintf("tcp://0.0.0.0:%d", options.proxyMetricsPort)},
-			{Name: "LINKERD2_PROXY_PRIVATE_LISTENER", Value: fmt.Sprintf("tcp://127.0.0.1:%d", options.outboundPort)},
-			{Name: "LINKERD2_PROXY_PUBLIC_LISTENER", Value: fmt.Sprintf("tcp://0.0.0.0:%d", options.inboundPort)},
+			{Name: "LINKERD2_PROXY_PRIVATE_LISTENER", Value: fmt.Sprintf("tcp://%s:%d", privateListenerAddr, options.outboundPort)},
+			{Name: "LINKERD2_PROXY_PUBLIC_LISTENER", Value: fmt.Sprintf("tcp://%s:%d", publicListenerAddr, options.inboundPort)},
 			{
 				Name:      PodNamespaceEnvVarName,
 				ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
@@ -263,6 +592,26 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 		}
 	}
 
+	// Like the outbound capacity, the proxy's CPU request is keyed off of the
+	// images of the pod's containers. This is currently only used by the
+	// control plane's pods when the controller is autoscaled.
+	for _, container := range t.Containers {
+		if cpu, ok := options.proxyCPURequest[container.Image]; ok {
+			sidecar.Resources = v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+			}
+			break
+		}
+	}
+
+	if options.cpuProfileAnnotations {
+		sidecar.Env = append(sidecar.Env, v1.EnvVar{Name: PprofEnvVarName, Value: "true"})
+	}
+
+	if options.disableDetectTimeout {
+		sidecar.Env = append(sidecar.Env, v1.EnvVar{Name: InboundDetectTimeoutEnvVarName, Value: "0s"})
+	}
+
 	if options.enableTLS() {
 		yes := true
 
@@ -309,14 +658,38 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 		t.Volumes = append(t.Volumes, configMapVolume, secretVolume)
 	}
 
+	// Strip the probes of the application containers only, before the proxy
+	// is added
+	for i := range t.Containers {
+		if options.removesLivenessProbes() {
+			t.Containers[i].LivenessProbe = nil
+		}
+		if options.removesReadinessProbes() {
+			t.Containers[i].ReadinessProbe = nil
+		}
+	}
+
 	t.Containers = append(t.Containers, sidecar)
-	t.InitContainers = append(t.InitContainers, initContainer)
+	if !options.linkerdCNI {
+		t.InitContainers = append(t.InitContainers, initContainer)
+	}
+
+	// The added init containers run after linkerd-init, but before the proxy
+	// starts, so any outbound traffic of theirs must skip the proxy, e.g. with
+	// --skip-outbound-ports. They're validated by options.validate().
+	for _, spec := range options.addInitContainers {
+		container, _ := parseAddInitContainer(spec)
+		t.InitContainers = append(t.InitContainers, container)
+	}
 
 	return true
 }
 
 // InjectYAML takes an input stream of YAML, outputting injected YAML to out.
-func InjectYAML(in io.Reader, out io.Writer, options *injectOptions) error {
+// Resources of kinds that can't be injected are output unchanged, with a
+// warning written to report, unless options.strict is set, in which case they
+// are an error.
+func InjectYAML(in io.Reader, out io.Writer, report io.Writer, options *injectOptions) error {
 	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(in, 4096))
 
 	// Iterate over all YAML objects in the input
@@ -330,7 +703,7 @@ func InjectYAML(in io.Reader, out io.Writer, options *injectOptions) error {
 			return err
 		}
 
-		result, err := injectResource(bytes, options)
+		result, err := injectResource(bytes, report, options)
 		if err != nil {
 			return err
 		}
@@ -342,7 +715,7 @@ func InjectYAML(in io.Reader, out io.Writer, options *injectOptions) error {
 	return nil
 }
 
-func injectList(b []byte, options *injectOptions) ([]byte, error) {
+func injectList(b []byte, report io.Writer, options *injectOptions) ([]byte, error) {
 	var sourceList v1.List
 	if err := yaml.Unmarshal(b, &sourceList); err != nil {
 		return nil, err
@@ -351,7 +724,7 @@ func injectList(b []byte, options *injectOptions) ([]byte, error) {
 	items := []runtime.RawExtension{}
 
 	for _, item := range sourceList.Items {
-		result, err := injectResource(item.Raw, options)
+		result, err := injectResource(item.Raw, report, options)
 		if err != nil {
 			return nil, err
 		}
@@ -371,7 +744,7 @@ func injectList(b []byte, options *injectOptions) ([]byte, error) {
 	return yaml.Marshal(sourceList)
 }
 
-func injectResource(bytes []byte, options *injectOptions) ([]byte, error) {
+func injectResource(bytes []byte, report io.Writer, options *injectOptions) ([]byte, error) {
 	// The Kuberentes API is versioned and each version has an API modeled
 	// with its own distinct Go types. If we tell `yaml.Unmarshal()` which
 	// version we support then it will provide a representation of that
@@ -395,6 +768,7 @@ func injectResource(bytes []byte, options *injectOptions) ([]byte, error) {
 	var podSpec *v1.PodSpec
 	var objectMeta *metaV1.ObjectMeta
 	var DNSNameOverride string
+	var statefulSet *appsV1.StatefulSet
 	k8sLabels := map[string]string{}
 
 	// When injecting the linkerd proxy into a linkerd controller pod. The linkerd proxy's
@@ -476,6 +850,7 @@ func injectResource(bytes []byte, options *injectOptions) ([]byte, error) {
 		}
 
 		obj = &statefulset
+		statefulSet = &statefulset
 		k8sLabels[k8s.ProxyStatefulSetLabel] = statefulset.Name
 		podSpec = &statefulset.Spec.Template.Spec
 		objectMeta = &statefulset.Spec.Template.ObjectMeta
@@ -494,8 +869,15 @@ func injectResource(bytes []byte, options *injectOptions) ([]byte, error) {
 		// Lists are a little different than the other types. There's no immediate
 		// pod template. Because of this, we do a recursive call for each element
 		// in the list (instead of just marshaling the injected pod template).
-		return injectList(bytes, options)
+		return injectList(bytes, report, options)
 
+	case "":
+		// Empty documents, such as ones that only hold comments, have no kind.
+
+	default:
+		if err := checkUnsupportedKind(bytes, meta.Kind, report, options); err != nil {
+			return nil, err
+		}
 	}
 
 	// If we don't inject anything into the pod template then output the
@@ -516,10 +898,24 @@ func injectResource(bytes []byte, options *injectOptions) ([]byte, error) {
 			Kind:                strings.ToLower(meta.Kind),
 			Namespace:           "$" + PodNamespaceEnvVarName,
 			ControllerNamespace: controlPlaneNamespace,
+			ClusterDNSDomain:    options.clusterDNSDomain,
 		}
 
 		if injectPodSpec(podSpec, identity, DNSNameOverride, options) {
+			k8sLabels[k8s.ProxyServiceAccountLabel] = k8s.GetServiceAccountName(podSpec)
 			injectObjectMeta(objectMeta, k8sLabels, options)
+
+			if statefulSet != nil && options.partition != noPartition {
+				setRollingUpdatePartition(statefulSet, options.partition, report)
+			}
+
+			// kubectl apply diffs the object with the configuration that it
+			// last applied, which this annotation holds, and which is the
+			// uninjected object, so it must not be carried over.
+			annotations := metaAccessor.GetAnnotations()
+			delete(annotations, v1.LastAppliedConfigAnnotation)
+			metaAccessor.SetAnnotations(annotations)
+
 			var err error
 			output, err = yaml.Marshal(obj)
 			if err != nil {
@@ -531,6 +927,44 @@ func injectResource(bytes []byte, options *injectOptions) ([]byte, error) {
 	return output, nil
 }
 
+// setRollingUpdatePartition sets the partition of the StatefulSet's rolling
+// updates. StatefulSets that are updated when their pods are deleted have no
+// partition, and are reported instead.
+func setRollingUpdatePartition(statefulSet *appsV1.StatefulSet, partition int32, report io.Writer) {
+	strategy := &statefulSet.Spec.UpdateStrategy
+	if strategy.Type == appsV1.OnDeleteStatefulSetStrategyType {
+		fmt.Fprintf(report, "Warning: StatefulSet %q uses the %s update strategy, which has no partition; --partition is ignored\n",
+			statefulSet.Name, strategy.Type)
+		return
+	}
+
+	strategy.Type = appsV1.RollingUpdateStatefulSetStrategyType
+	if strategy.RollingUpdate == nil {
+		strategy.RollingUpdate = &appsV1.RollingUpdateStatefulSetStrategy{}
+	}
+	strategy.RollingUpdate.Partition = &partition
+}
+
+// checkUnsupportedKind reports a resource of a kind that inject doesn't
+// support, which is an error in strict mode and a warning otherwise.
+func checkUnsupportedKind(bytes []byte, kind string, report io.Writer, options *injectOptions) error {
+	var resource struct {
+		Metadata metaV1.ObjectMeta `json:"metadata"`
+	}
+	if err := yaml.Unmarshal(bytes, &resource); err != nil {
+		return err
+	}
+
+	if options.strict {
+		return fmt.Errorf("%s %q cannot be injected, kind %s is not supported; supported kinds are: %s",
+			kind, resource.Metadata.Name, kind, strings.Join(injectSupportedKinds, ", "))
+	}
+
+	fmt.Fprintf(report, "Warning: %s %q was not injected, kind %s is not supported; it is output unchanged\n",
+		kind, resource.Metadata.Name, kind)
+	return nil
+}
+
 // walk walks the file tree rooted at path. path may be a file or a directory.
 // Creates a reader for each file found.
 func walk(path string) ([]io.Reader, error) {
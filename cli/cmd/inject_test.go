@@ -8,7 +8,13 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestInjectYAML(t *testing.T) {
@@ -46,7 +52,7 @@ func TestInjectYAML(t *testing.T) {
 
 			output := new(bytes.Buffer)
 
-			err = InjectYAML(read, output, tc.testInjectOptions)
+			err = InjectYAML(read, output, ioutil.Discard, tc.testInjectOptions)
 			if err != nil {
 				t.Errorf("Unexpected error injecting YAML: %v\n", err)
 			}
@@ -63,6 +69,690 @@ func TestInjectYAML(t *testing.T) {
 	}
 }
 
+func TestInjectImagePullPolicies(t *testing.T) {
+	testCases := []struct {
+		imagePullPolicy     string
+		initImagePullPolicy string
+		expectedProxy       v1.PullPolicy
+		expectedInit        v1.PullPolicy
+	}{
+		{"IfNotPresent", "", v1.PullIfNotPresent, v1.PullIfNotPresent},
+		{"Always", "", v1.PullAlways, v1.PullAlways},
+		{"IfNotPresent", "Always", v1.PullIfNotPresent, v1.PullAlways},
+		{"Always", "Never", v1.PullAlways, v1.PullNever},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d: %s/%s", i, tc.imagePullPolicy, tc.initImagePullPolicy), func(t *testing.T) {
+			options := newInjectOptions()
+			options.imagePullPolicy = tc.imagePullPolicy
+			options.initImagePullPolicy = tc.initImagePullPolicy
+			if err := options.validate(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			podSpec := &v1.PodSpec{}
+			if !injectPodSpec(podSpec, k8s.TLSIdentity{}, "", options) {
+				t.Fatalf("Expected pod spec to be injected")
+			}
+
+			if policy := podSpec.Containers[0].ImagePullPolicy; policy != tc.expectedProxy {
+				t.Fatalf("Expected proxy container pull policy to be %s, got %s", tc.expectedProxy, policy)
+			}
+			if policy := podSpec.InitContainers[0].ImagePullPolicy; policy != tc.expectedInit {
+				t.Fatalf("Expected init container pull policy to be %s, got %s", tc.expectedInit, policy)
+			}
+		})
+	}
+
+	t.Run("rejects an invalid init container pull policy", func(t *testing.T) {
+		options := newInjectOptions()
+		options.initImagePullPolicy = "Sometimes"
+		if err := options.validate(); err == nil {
+			t.Fatalf("Expected error for invalid --init-image-pull-policy, got nil")
+		}
+	})
+}
+
+func TestInjectCPUProfileAnnotations(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("cpu-profile-annotations=%t", enabled), func(t *testing.T) {
+			options := newInjectOptions()
+			options.cpuProfileAnnotations = enabled
+
+			podSpec := &v1.PodSpec{}
+			if !injectPodSpec(podSpec, k8s.TLSIdentity{}, "", options) {
+				t.Fatalf("Expected pod spec to be injected")
+			}
+			objectMeta := &metaV1.ObjectMeta{}
+			injectObjectMeta(objectMeta, map[string]string{}, options)
+
+			annotation, ok := objectMeta.Annotations[k8s.ProxyEnablePprofAnnotation]
+			if ok != enabled || (enabled && annotation != "true") {
+				t.Fatalf("Expected %s annotation to be set: %t, got %q", k8s.ProxyEnablePprofAnnotation, enabled, annotation)
+			}
+
+			var env *v1.EnvVar
+			for i := range podSpec.Containers[0].Env {
+				if podSpec.Containers[0].Env[i].Name == PprofEnvVarName {
+					env = &podSpec.Containers[0].Env[i]
+				}
+			}
+			if (env != nil) != enabled || (enabled && env.Value != "true") {
+				t.Fatalf("Expected %s to be set on the proxy: %t, got %+v", PprofEnvVarName, enabled, env)
+			}
+		})
+	}
+}
+
+func TestInjectIptablesMode(t *testing.T) {
+	t.Run("does not override the iptables mode by default", func(t *testing.T) {
+		options := newInjectOptions()
+
+		podSpec := &v1.PodSpec{}
+		if !injectPodSpec(podSpec, k8s.TLSIdentity{}, "", options) {
+			t.Fatalf("Expected pod spec to be injected")
+		}
+		objectMeta := &metaV1.ObjectMeta{}
+		injectObjectMeta(objectMeta, map[string]string{}, options)
+
+		if annotation, ok := objectMeta.Annotations[k8s.ProxyInitIptablesModeAnnotation]; ok {
+			t.Fatalf("Expected no %s annotation, got %q", k8s.ProxyInitIptablesModeAnnotation, annotation)
+		}
+		if env := podSpec.InitContainers[0].Env; len(env) != 0 {
+			t.Fatalf("Expected no env on the init container, got %+v", env)
+		}
+	})
+
+	t.Run("reads the iptables mode from the pod's annotation", func(t *testing.T) {
+		options := newInjectOptions()
+		options.iptablesMode = "nft"
+		if err := options.validate(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		podSpec := &v1.PodSpec{}
+		if !injectPodSpec(podSpec, k8s.TLSIdentity{}, "", options) {
+			t.Fatalf("Expected pod spec to be injected")
+		}
+		objectMeta := &metaV1.ObjectMeta{}
+		injectObjectMeta(objectMeta, map[string]string{}, options)
+
+		if annotation := objectMeta.Annotations[k8s.ProxyInitIptablesModeAnnotation]; annotation != "nft" {
+			t.Fatalf("Expected %s annotation to be nft, got %q", k8s.ProxyInitIptablesModeAnnotation, annotation)
+		}
+
+		expected := []v1.EnvVar{
+			{
+				Name: IptablesModeEnvVarName,
+				ValueFrom: &v1.EnvVarSource{
+					FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.annotations['config.linkerd.io/iptables-mode']"},
+				},
+			},
+		}
+		if env := podSpec.InitContainers[0].Env; !reflect.DeepEqual(env, expected) {
+			t.Fatalf("Expected init container env %+v, got %+v", expected, env)
+		}
+	})
+
+	t.Run("rejects an invalid iptables mode", func(t *testing.T) {
+		options := newInjectOptions()
+		options.iptablesMode = "xtables"
+		expected := "--iptables-mode must be one of: auto, legacy, nft"
+		if err := options.validate(); err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+}
+
+func TestInjectIPv6Mode(t *testing.T) {
+	listeners := func(podSpec *v1.PodSpec) map[string]string {
+		listeners := map[string]string{}
+		for _, env := range podSpec.Containers[0].Env {
+			if strings.HasSuffix(env.Name, "_LISTENER") {
+				listeners[env.Name] = env.Value
+			}
+		}
+		return listeners
+	}
+	ipv4Listeners := map[string]string{
+		"LINKERD2_PROXY_CONTROL_LISTENER": "tcp://0.0.0.0:4190",
+		"LINKERD2_PROXY_METRICS_LISTENER": "tcp://0.0.0.0:4191",
+		"LINKERD2_PROXY_PRIVATE_LISTENER": "tcp://127.0.0.1:4140",
+		"LINKERD2_PROXY_PUBLIC_LISTENER":  "tcp://0.0.0.0:4143",
+	}
+	ipv6Env := []v1.EnvVar{
+		{
+			Name: IPv6ModeEnvVarName,
+			ValueFrom: &v1.EnvVarSource{
+				FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.annotations['config.linkerd.io/ipv6-mode']"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		ipv6Mode  string
+		env       []v1.EnvVar
+		listeners map[string]string
+	}{
+		{
+			name:      "IPv6 traffic skips the proxy by default",
+			ipv6Mode:  "",
+			env:       nil,
+			listeners: ipv4Listeners,
+		},
+		{
+			name:     "the proxy accepts IPv6 traffic that may be redirected",
+			ipv6Mode: "auto",
+			env:      ipv6Env,
+			listeners: map[string]string{
+				"LINKERD2_PROXY_CONTROL_LISTENER": "tcp://0.0.0.0:4190",
+				"LINKERD2_PROXY_METRICS_LISTENER": "tcp://0.0.0.0:4191",
+				"LINKERD2_PROXY_PRIVATE_LISTENER": "tcp://[::]:4140",
+				"LINKERD2_PROXY_PUBLIC_LISTENER":  "tcp://[::]:4143",
+			},
+		},
+		{
+			name:      "disabling IPv6 can be overridden by the pod's annotation",
+			ipv6Mode:  "disabled",
+			env:       ipv6Env,
+			listeners: ipv4Listeners,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			options := newInjectOptions()
+			options.ipv6Mode = tc.ipv6Mode
+			if err := options.validate(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			podSpec := &v1.PodSpec{}
+			if !injectPodSpec(podSpec, k8s.TLSIdentity{}, "", options) {
+				t.Fatalf("Expected pod spec to be injected")
+			}
+			objectMeta := &metaV1.ObjectMeta{}
+			injectObjectMeta(objectMeta, map[string]string{}, options)
+
+			if annotation, ok := objectMeta.Annotations[k8s.ProxyInitIPv6ModeAnnotation]; annotation != tc.ipv6Mode || ok != (tc.ipv6Mode != "") {
+				t.Fatalf("Expected %s annotation to be %q, got %q", k8s.ProxyInitIPv6ModeAnnotation, tc.ipv6Mode, annotation)
+			}
+			if env := podSpec.InitContainers[0].Env; !reflect.DeepEqual(env, tc.env) {
+				t.Fatalf("Expected init container env %+v, got %+v", tc.env, env)
+			}
+			if actual := listeners(podSpec); !reflect.DeepEqual(actual, tc.listeners) {
+				t.Fatalf("Expected proxy listeners %v, got %v", tc.listeners, actual)
+			}
+		})
+	}
+
+	t.Run("rejects an invalid IPv6 mode", func(t *testing.T) {
+		options := newInjectOptions()
+		options.ipv6Mode = "on"
+		expected := "--ipv6-mode must be one of: auto, enabled, disabled"
+		if err := options.validate(); err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+}
+
+func TestInjectSkipOutbound(t *testing.T) {
+	t.Run("skips outbound traffic by owner and destination", func(t *testing.T) {
+		options := newInjectOptions()
+		options.skipOwnerUIDs = []uint{1337, 2102, 2103}
+		options.skipOwnerGIDs = []uint{1500}
+		options.skipOutboundCIDRs = []string{"169.254.20.10/32", "fd00:10:96::a/128", "10.0.0.0/8"}
+		if err := options.validate(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		podSpec := &v1.PodSpec{}
+		if !injectPodSpec(podSpec, k8s.TLSIdentity{}, "", options) {
+			t.Fatalf("Expected pod spec to be injected")
+		}
+		objectMeta := &metaV1.ObjectMeta{}
+		injectObjectMeta(objectMeta, map[string]string{}, options)
+
+		expectedArgs := []string{
+			"--incoming-proxy-port", "4143",
+			"--outgoing-proxy-port", "4140",
+			"--proxy-uid", "2102",
+			"--inbound-ports-to-ignore", "4190,4191",
+			"--skip-owner-uid", "1337,2103",
+			"--skip-owner-gid", "1500",
+			"--skip-outbound-cidrs", "169.254.20.10/32,fd00:10:96::a/128,10.0.0.0/8",
+		}
+		if args := podSpec.InitContainers[0].Args; !reflect.DeepEqual(args, expectedArgs) {
+			t.Fatalf("Expected init container args %v, got %v", expectedArgs, args)
+		}
+
+		expectedAnnotations := map[string]string{
+			k8s.ProxyInitSkipOwnerUIDsAnnotation:     "1337,2103",
+			k8s.ProxyInitSkipOwnerGIDsAnnotation:     "1500",
+			k8s.ProxyInitSkipOutboundCIDRsAnnotation: "169.254.20.10/32,fd00:10:96::a/128,10.0.0.0/8",
+		}
+		for name, expected := range expectedAnnotations {
+			if annotation := objectMeta.Annotations[name]; annotation != expected {
+				t.Fatalf("Expected %s annotation to be %q, got %q", name, expected, annotation)
+			}
+		}
+	})
+
+	t.Run("keeps the proxy's user ID as its only exclusion", func(t *testing.T) {
+		options := newInjectOptions()
+		options.proxyUID = 1337
+		options.skipOwnerUIDs = []uint{1337}
+
+		podSpec := &v1.PodSpec{}
+		if !injectPodSpec(podSpec, k8s.TLSIdentity{}, "", options) {
+			t.Fatalf("Expected pod spec to be injected")
+		}
+		objectMeta := &metaV1.ObjectMeta{}
+		injectObjectMeta(objectMeta, map[string]string{}, options)
+
+		for _, arg := range podSpec.InitContainers[0].Args {
+			if arg == "--skip-owner-uid" {
+				t.Fatalf("Expected no --skip-owner-uid arg, got %v", podSpec.InitContainers[0].Args)
+			}
+		}
+		if annotation, ok := objectMeta.Annotations[k8s.ProxyInitSkipOwnerUIDsAnnotation]; ok {
+			t.Fatalf("Expected no %s annotation, got %q", k8s.ProxyInitSkipOwnerUIDsAnnotation, annotation)
+		}
+	})
+
+	testCases := []struct {
+		cidr     string
+		expected string
+	}{
+		{"10.0.0.0", "--skip-outbound-cidrs has an invalid CIDR [10.0.0.0]"},
+		{"10.0.0.0/33", "--skip-outbound-cidrs has an invalid CIDR [10.0.0.0/33]"},
+		{"fd00::8", "--skip-outbound-cidrs has an invalid CIDR [fd00::8]"},
+	}
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run("rejects "+tc.cidr, func(t *testing.T) {
+			options := newInjectOptions()
+			options.skipOutboundCIDRs = []string{tc.cidr}
+			if err := options.validate(); err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error %q, got: %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestInjectAddLabels(t *testing.T) {
+	t.Run("adds the labels to injected pod templates", func(t *testing.T) {
+		options := newInjectOptions()
+		options.addLabels = []string{"team=payments", "example.com/tier=backend"}
+		if err := options.validate(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		objectMeta := &metaV1.ObjectMeta{Labels: map[string]string{"app": "web", "team": "checkout"}}
+		injectObjectMeta(objectMeta, map[string]string{k8s.ProxyDeploymentLabel: "web"}, options)
+
+		expected := map[string]string{
+			"app":                    "web",
+			"team":                   "payments",
+			"example.com/tier":       "backend",
+			k8s.ControllerNSLabel:    controlPlaneNamespace,
+			k8s.ProxyDeploymentLabel: "web",
+		}
+		if !reflect.DeepEqual(objectMeta.Labels, expected) {
+			t.Fatalf("Expected labels %v, got %v", expected, objectMeta.Labels)
+		}
+	})
+
+	t.Run("adds the labels to the output", func(t *testing.T) {
+		options := newInjectOptions()
+		options.linkerdVersion = "testinjectversion"
+		options.addLabels = []string{"team=payments"}
+
+		file, err := os.Open("testdata/inject_emojivoto_deployment.input.yml")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer file.Close()
+
+		output := new(bytes.Buffer)
+		if err := InjectYAML(file, output, ioutil.Discard, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !strings.Contains(output.String(), "        team: payments\n") {
+			t.Fatalf("Expected the pod template to be labeled team=payments, got:\n%s", output)
+		}
+	})
+
+	for _, label := range []string{
+		"team",
+		"=payments",
+		"team=pay ments",
+		"linkerd.io/control-plane-ns=linkerd",
+		"config.linkerd.io/team=payments",
+	} {
+		t.Run(fmt.Sprintf("rejects %s", label), func(t *testing.T) {
+			options := newInjectOptions()
+			options.addLabels = []string{label}
+			if err := options.validate(); err == nil {
+				t.Fatalf("Expected error for --add-label %s, got nil", label)
+			}
+		})
+	}
+}
+
+func TestInjectPartition(t *testing.T) {
+	injectFile := func(t *testing.T, fileName string, options *injectOptions) string {
+		file, err := os.Open(fileName)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer file.Close()
+
+		output := new(bytes.Buffer)
+		if err := InjectYAML(file, output, ioutil.Discard, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return output.String()
+	}
+
+	options := newInjectOptions()
+	options.linkerdVersion = "testinjectversion"
+	options.partition = 2
+	if err := options.validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("sets the partition of StatefulSets", func(t *testing.T) {
+		output := injectFile(t, "testdata/inject_emojivoto_statefulset.input.yml", options)
+		expected := "  updateStrategy:\n    rollingUpdate:\n      partition: 2\n    type: RollingUpdate\n"
+		if !strings.Contains(output, expected) {
+			t.Fatalf("Expected the StatefulSet to have partition 2, got:\n%s", output)
+		}
+	})
+
+	t.Run("leaves the partition of StatefulSets unset by default", func(t *testing.T) {
+		defaultOptions := newInjectOptions()
+		defaultOptions.linkerdVersion = "testinjectversion"
+		output := injectFile(t, "testdata/inject_emojivoto_statefulset.input.yml", defaultOptions)
+		if strings.Contains(output, "partition") {
+			t.Fatalf("Expected the StatefulSet to have no partition, got:\n%s", output)
+		}
+	})
+
+	for _, fileName := range []string{
+		"testdata/inject_emojivoto_deployment.input.yml",
+		"testdata/inject_emojivoto_pod.input.yml",
+		"testdata/inject_emojivoto_list.input.yml",
+	} {
+		fileName := fileName // pin
+		t.Run(fmt.Sprintf("doesn't set a partition in %s", fileName), func(t *testing.T) {
+			if output := injectFile(t, fileName, options); strings.Contains(output, "partition") {
+				t.Fatalf("Expected no partition, got:\n%s", output)
+			}
+		})
+	}
+
+	t.Run("warns about StatefulSets that are updated on delete", func(t *testing.T) {
+		input := `apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: web
+spec:
+  updateStrategy:
+    type: OnDelete
+  template:
+    spec:
+      containers:
+      - name: web
+        image: buoyantio/emojivoto-web:v3
+`
+		output := new(bytes.Buffer)
+		report := new(bytes.Buffer)
+		if err := InjectYAML(strings.NewReader(input), output, report, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if strings.Contains(output.String(), "partition") {
+			t.Fatalf("Expected no partition, got:\n%s", output)
+		}
+		expectedReport := "Warning: StatefulSet \"web\" uses the OnDelete update strategy, which has no partition; --partition is ignored\n"
+		if report.String() != expectedReport {
+			t.Fatalf("Expected report [%s], got [%s]", expectedReport, report)
+		}
+	})
+
+	t.Run("rejects negative partitions", func(t *testing.T) {
+		options := newInjectOptions()
+		options.partition = -2
+		if err := options.validate(); err == nil {
+			t.Fatalf("Expected error for --partition -2, got nil")
+		}
+	})
+}
+
+func TestInjectDaemonSetUpdateStrategy(t *testing.T) {
+	daemonSet := func(apiVersion, updateStrategy string) string {
+		return fmt.Sprintf(`apiVersion: %s
+kind: DaemonSet
+metadata:
+  name: node-agent
+spec:
+  selector:
+    matchLabels:
+      app: node-agent
+  template:
+    metadata:
+      labels:
+        app: node-agent
+    spec:
+      containers:
+      - image: buoyantio/node-agent:v1
+        name: node-agent
+%s`, apiVersion, updateStrategy)
+	}
+
+	testCases := []struct {
+		apiVersion     string
+		updateStrategy string
+	}{
+		{"apps/v1", "  updateStrategy:\n    type: OnDelete\n"},
+		{"apps/v1", "  updateStrategy:\n    rollingUpdate:\n      maxUnavailable: 25%\n    type: RollingUpdate\n"},
+		{"extensions/v1beta1", "  updateStrategy:\n    type: OnDelete\n"},
+		{"extensions/v1beta1", "  updateStrategy:\n    rollingUpdate:\n      maxUnavailable: 2\n    type: RollingUpdate\n"},
+	}
+
+	options := newInjectOptions()
+	options.linkerdVersion = "testinjectversion"
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d: %s", i, tc.apiVersion), func(t *testing.T) {
+			output := new(bytes.Buffer)
+			if err := InjectYAML(strings.NewReader(daemonSet(tc.apiVersion, tc.updateStrategy)), output, ioutil.Discard, options); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.Contains(output.String(), "linkerd-proxy") {
+				t.Fatalf("Expected the DaemonSet to be injected, got:\n%s", output)
+			}
+			if !strings.Contains(output.String(), tc.updateStrategy) {
+				t.Fatalf("Expected the DaemonSet to keep its update strategy:\n%s\ngot:\n%s", tc.updateStrategy, output)
+			}
+		})
+	}
+}
+
+func TestInjectAddInitContainers(t *testing.T) {
+	t.Run("adds the init containers after linkerd-init", func(t *testing.T) {
+		options := newInjectOptions()
+		options.addInitContainers = []string{
+			"name=migrate,image=example.com/migrate:v1,command=/bin/migrate --up",
+			"name=warm-cache,image=example.com/cache:v2",
+		}
+		if err := options.validate(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		podSpec := &v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "setup", Image: "busybox"}},
+		}
+		if !injectPodSpec(podSpec, k8s.TLSIdentity{}, "", options) {
+			t.Fatalf("Expected pod spec to be injected")
+		}
+
+		names := make([]string, len(podSpec.InitContainers))
+		for i, container := range podSpec.InitContainers {
+			names[i] = container.Name
+		}
+		expectedNames := []string{"setup", "linkerd-init", "migrate", "warm-cache"}
+		if !reflect.DeepEqual(names, expectedNames) {
+			t.Fatalf("Expected init containers %v, got %v", expectedNames, names)
+		}
+
+		expected := []v1.Container{
+			{Name: "migrate", Image: "example.com/migrate:v1", Command: []string{"/bin/migrate", "--up"}},
+			{Name: "warm-cache", Image: "example.com/cache:v2"},
+		}
+		if added := podSpec.InitContainers[2:]; !reflect.DeepEqual(added, expected) {
+			t.Fatalf("Expected added init containers %+v, got %+v", expected, added)
+		}
+	})
+
+	testCases := []struct {
+		specs    []string
+		expected string
+	}{
+		{[]string{"migrate"}, "--add-init-container must be a list of name=X,image=Y,command=Z fields, got [migrate]"},
+		{[]string{"name=migrate,image=migrate,args=--up"}, "--add-init-container has an unknown field [args], must be one of: name, image, command"},
+		{[]string{"image=migrate"}, "--add-init-container is missing a name in [image=migrate]"},
+		{[]string{"name=migrate"}, "--add-init-container is missing an image in [name=migrate]"},
+		{[]string{"name=Migrate_DB,image=migrate"}, "--add-init-container has an invalid name [Migrate_DB]: a DNS-1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"},
+		{[]string{"name=linkerd-init,image=migrate"}, "--add-init-container cannot add [linkerd-init], the name is reserved for Linkerd"},
+		{[]string{"name=migrate,image=migrate", "name=migrate,image=migrate:v2"}, "--add-init-container adds more than one init container named [migrate]"},
+	}
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d: rejects %v", i, tc.specs), func(t *testing.T) {
+			options := newInjectOptions()
+			options.addInitContainers = tc.specs
+			if err := options.validate(); err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error %q, got: %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestInjectLinkerdCNI(t *testing.T) {
+	options := newInjectOptions()
+	options.linkerdCNI = true
+	options.ignoreInboundPorts = []uint{8443}
+	options.ignoreOutboundPorts = []uint{3306, 5432}
+	options.addInitContainers = []string{"name=migrate,image=example.com/migrate:v1"}
+	if err := options.validate(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	podSpec := &v1.PodSpec{}
+	if !injectPodSpec(podSpec, k8s.TLSIdentity{}, "", options) {
+		t.Fatalf("Expected pod spec to be injected")
+	}
+	objectMeta := &metaV1.ObjectMeta{}
+	injectObjectMeta(objectMeta, map[string]string{}, options)
+
+	names := []string{}
+	for _, container := range podSpec.InitContainers {
+		names = append(names, container.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"migrate"}) {
+		t.Fatalf("Expected only the added init containers, got %v", names)
+	}
+	if len(podSpec.Containers) != 1 || podSpec.Containers[0].Name != "linkerd-proxy" {
+		t.Fatalf("Expected the proxy to be injected, got %+v", podSpec.Containers)
+	}
+
+	// The plugin reads the pod's skipped ports from its annotations, in place
+	// of linkerd-init's args
+	expectedAnnotations := map[string]string{
+		k8s.ProxyInitSkipInboundPortsAnnotation:  "8443",
+		k8s.ProxyInitSkipOutboundPortsAnnotation: "3306,5432",
+	}
+	for name, expected := range expectedAnnotations {
+		if annotation := objectMeta.Annotations[name]; annotation != expected {
+			t.Fatalf("Expected %s annotation to be %q, got %q", name, expected, annotation)
+		}
+	}
+}
+
+func TestInjectRemoveProbes(t *testing.T) {
+	newPodSpec := func() *v1.PodSpec {
+		probe := func(path string) *v1.Probe {
+			return &v1.Probe{
+				Handler: v1.Handler{HTTPGet: &v1.HTTPGetAction{Path: path}},
+			}
+		}
+		return &v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "web", Image: "web", LivenessProbe: probe("/live"), ReadinessProbe: probe("/ready")},
+				{Name: "worker", Image: "worker", LivenessProbe: probe("/live")},
+			},
+		}
+	}
+
+	testCases := []struct {
+		desc          string
+		set           func(*injectOptions)
+		keepLiveness  bool
+		keepReadiness bool
+	}{
+		{"keeps probes by default", func(*injectOptions) {}, true, true},
+		{"--remove-probes", func(o *injectOptions) { o.removeProbes = true }, false, false},
+		{"--remove-liveness-only", func(o *injectOptions) { o.removeLivenessOnly = true }, false, true},
+		{"--remove-readiness-only", func(o *injectOptions) { o.removeReadinessOnly = true }, true, false},
+	}
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.desc, func(t *testing.T) {
+			options := newInjectOptions()
+			tc.set(options)
+			if err := options.validate(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			podSpec := newPodSpec()
+			expected := newPodSpec()
+			if !injectPodSpec(podSpec, k8s.TLSIdentity{}, "", options) {
+				t.Fatalf("Expected pod spec to be injected")
+			}
+
+			for i, container := range expected.Containers {
+				if !tc.keepLiveness {
+					container.LivenessProbe = nil
+				}
+				if !tc.keepReadiness {
+					container.ReadinessProbe = nil
+				}
+				if actual := podSpec.Containers[i]; !reflect.DeepEqual(actual, container) {
+					t.Fatalf("Expected container %+v, got %+v", container, actual)
+				}
+			}
+			if proxy := podSpec.Containers[len(podSpec.Containers)-1]; proxy.Name != "linkerd-proxy" {
+				t.Fatalf("Expected the proxy to be injected, got %+v", podSpec.Containers)
+			}
+		})
+	}
+
+	t.Run("rejects more than one removal flag", func(t *testing.T) {
+		options := newInjectOptions()
+		options.removeProbes = true
+		options.removeLivenessOnly = true
+		expected := "only one of --remove-probes, --remove-liveness-only and --remove-readiness-only may be set"
+		if err := options.validate(); err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+}
+
 func TestRunInjectCmd(t *testing.T) {
 	testInjectOptions := newInjectOptions()
 	testInjectOptions.linkerdVersion = "testinjectversion"
@@ -111,6 +801,143 @@ func TestRunInjectCmd(t *testing.T) {
 	}
 }
 
+func TestInjectStripsLastAppliedConfiguration(t *testing.T) {
+	testCases := []struct {
+		kind  string
+		input string
+	}{
+		{
+			kind: "Deployment",
+			input: `apiVersion: apps/v1beta1
+kind: Deployment
+metadata:
+  name: web
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"kind":"Deployment"}'
+    keep: me
+spec:
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: buoyantio/emojivoto-web:v3
+`,
+		},
+		{
+			kind: "Pod",
+			input: `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"kind":"Pod"}'
+    keep: me
+spec:
+  containers:
+  - name: web
+    image: buoyantio/emojivoto-web:v3
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.kind, func(t *testing.T) {
+			options := newInjectOptions()
+			options.linkerdVersion = "testinjectversion"
+
+			errBuffer := &bytes.Buffer{}
+			outBuffer := &bytes.Buffer{}
+			if exitCode := runInjectCmd([]io.Reader{strings.NewReader(tc.input)}, errBuffer, outBuffer, options); exitCode != 0 {
+				t.Fatalf("Expected exit code to be 0 but got: %d, %s", exitCode, errBuffer.String())
+			}
+
+			output := outBuffer.String()
+			if !strings.Contains(output, k8s.ProxyVersionAnnotation) {
+				t.Fatalf("Expected %s to be injected, got:\n%s", tc.kind, output)
+			}
+			if strings.Contains(output, v1.LastAppliedConfigAnnotation) {
+				t.Fatalf("Expected %s annotation to be stripped, got:\n%s", v1.LastAppliedConfigAnnotation, output)
+			}
+			if !strings.Contains(output, "keep: me") {
+				t.Fatalf("Expected other annotations to be kept, got:\n%s", output)
+			}
+		})
+	}
+}
+
+func TestInjectUnsupportedKinds(t *testing.T) {
+	t.Run("Warns about resources that can't be injected", func(t *testing.T) {
+		options := newInjectOptions()
+		options.linkerdVersion = "testinjectversion"
+
+		in, err := os.Open("testdata/inject_unsupported_kinds.input.yml")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		errBuffer := &bytes.Buffer{}
+		outBuffer := &bytes.Buffer{}
+		if exitCode := runInjectCmd([]io.Reader{in}, errBuffer, outBuffer, options); exitCode != 0 {
+			t.Fatalf("Expected exit code to be 0 but got: %d", exitCode)
+		}
+
+		diffCompare(t, outBuffer.String(), readOptionalTestFile(t, "inject_unsupported_kinds.golden.yml"))
+
+		expectedStdErr := `Warning: Service "nginx" was not injected, kind Service is not supported; it is output unchanged
+Warning: Rollout "nginx-canary" was not injected, kind Rollout is not supported; it is output unchanged
+Warning: ConfigMap "nginx-config" was not injected, kind ConfigMap is not supported; it is output unchanged
+`
+		diffCompare(t, errBuffer.String(), expectedStdErr)
+	})
+
+	t.Run("Fails on resources that can't be injected in strict mode", func(t *testing.T) {
+		options := newInjectOptions()
+		options.linkerdVersion = "testinjectversion"
+		options.strict = true
+
+		in, err := os.Open("testdata/inject_unsupported_kinds.input.yml")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		errBuffer := &bytes.Buffer{}
+		outBuffer := &bytes.Buffer{}
+		if exitCode := runInjectCmd([]io.Reader{in}, errBuffer, outBuffer, options); exitCode != 1 {
+			t.Fatalf("Expected exit code to be 1 but got: %d", exitCode)
+		}
+
+		expectedStdErr := "Error injecting linkerd proxy: Service \"nginx\" cannot be injected, kind Service is not supported; " +
+			"supported kinds are: Deployment, ReplicationController, ReplicaSet, Job, DaemonSet, StatefulSet, Pod, List\n"
+		diffCompare(t, errBuffer.String(), expectedStdErr)
+		if outBuffer.Len() != 0 {
+			t.Fatalf("Expected no output, got: %s", outBuffer.String())
+		}
+	})
+
+	t.Run("Injects supported resources in strict mode", func(t *testing.T) {
+		options := newInjectOptions()
+		options.linkerdVersion = "testinjectversion"
+		options.strict = true
+
+		in, err := os.Open("testdata/inject_emojivoto_list.input.yml")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		errBuffer := &bytes.Buffer{}
+		outBuffer := &bytes.Buffer{}
+		if exitCode := runInjectCmd([]io.Reader{in}, errBuffer, outBuffer, options); exitCode != 0 {
+			t.Fatalf("Expected exit code to be 0 but got: %d, %s", exitCode, errBuffer.String())
+		}
+
+		diffCompare(t, outBuffer.String(), readOptionalTestFile(t, "inject_emojivoto_list.golden.yml"))
+	})
+}
+
 func TestInjectFilePath(t *testing.T) {
 	var (
 		resourceFolder = filepath.Join("testdata", "inject-filepath", "resources")
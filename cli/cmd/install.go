@@ -1,21 +1,47 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
 	"text/template"
+	"time"
 
+	"github.com/ghodss/yaml"
 	"github.com/linkerd/linkerd2/cli/install"
+	"github.com/linkerd/linkerd2/controller/api/public"
+	"github.com/linkerd/linkerd2/pkg/flags"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/registry"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	"github.com/prometheus/common/model"
 	uuid "github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
 )
 
 type installConfig struct {
-	Namespace                   string
+	Namespace string
+
+	// The control plane's services are in ClusterDNSDomain, which is stored
+	// under LinkerdConfigClusterDNSDomainKey in the LinkerdConfigMapName
+	// ConfigMap for commands such as check.
+	ClusterDNSDomain                 string
+	LinkerdConfigClusterDNSDomainKey string
+
 	ControllerImage             string
 	WebImage                    string
 	PrometheusImage             string
@@ -27,30 +53,277 @@ type installConfig struct {
 	UUID                        string
 	CliVersion                  string
 	ControllerLogLevel          string
+	LogFormat                   string
 	ControllerComponentLabel    string
+	TLSSecretLabel              string
 	CreatedByAnnotation         string
 	ProxyAPIPort                uint
 	EnableTLS                   bool
 	TLSTrustAnchorConfigMapName string
+	TLSTrustAnchorFileName      string
+
+	// TLSIdentityTrustAnchors is the PEM bundle of additional trust anchors,
+	// indented for inclusion in a YAML block scalar.
+	TLSIdentityTrustAnchors              string
+	TLSIdentityTrustAnchorsConfigMapName string
+
+	// TLSIdentityTrustAnchors are also output in the TrustBundleConfigMapName
+	// ConfigMap, if it is set, which is annotated with
+	// CertManagerInjectAnnotation for cert-manager's cainjector to inject the
+	// CA of the TrustAnchorSecretName Secret.
+	TrustBundleConfigMapName    string
+	TrustAnchorSecretName       string
+	CertManagerInjectAnnotation string
+
+	// The InternalTLS PEM fields are indented for inclusion in a YAML block
+	// scalar.
+	ControlPlaneInternalTLS bool
+	InternalTLSSecretName   string
+	InternalTLSCACert       string
+	InternalTLSAPICert      string
+	InternalTLSAPIKey       string
+
+	// The sp-validator admission webhook rejects invalid ServiceProfiles if
+	// ProfileValidation is set. Its PEM fields are indented for inclusion in
+	// a YAML block scalar, and ProfileValidatorCABundle is the base64-encoded
+	// CA certificate that the API server verifies the webhook's certificate
+	// with.
+	ProfileValidation             bool
+	ProfileValidatorTLSSecretName string
+	ProfileValidatorCACert        string
+	ProfileValidatorCert          string
+	ProfileValidatorKey           string
+	ProfileValidatorCABundle      string
+
+	// The controller Deployment, which runs the destination service, is
+	// scaled by a HorizontalPodAutoscaler if EnableHPA is set. Its containers
+	// request HPACPURequest, since the CPU threshold is a percentage of the
+	// requested CPU.
+	EnableHPA       bool
+	HPAMinReplicas  uint
+	HPAMaxReplicas  uint
+	HPACPUThreshold uint
+	HPACPURequest   string
+
+	// The control plane queries PrometheusURL, which is the bundled
+	// Prometheus unless PrometheusExternalURL is set, in which case the
+	// bundled Prometheus is not installed.
+	PrometheusURL                 string
+	PrometheusExternalURL         string
+	LinkerdConfigPrometheusURLKey string
+
+	// PrometheusRecordingRules is the Prometheus rule file, indented for
+	// inclusion in a YAML block scalar.
+	PrometheusRecordingRules string
+
+	// Prometheus is deployed as a StatefulSet with a persistent volume of
+	// PrometheusStorageSize if it is set, and as a Deployment otherwise.
+	PrometheusRetentionTime  string
+	PrometheusStorageSize    string
+	PrometheusExternalLabels map[string]string
+
+	// PrometheusCPULimit and PrometheusMemoryLimit are the resource limits of
+	// the prometheus container, which has none if they are unset.
+	PrometheusCPULimit    string
+	PrometheusMemoryLimit string
+
+	// The remote write password is read from the password key of the
+	// PrometheusRemoteWritePasswordSecret secret, which is mounted into the
+	// Prometheus pod.
+	PrometheusRemoteWriteURL            string
+	PrometheusRemoteWriteUsername       string
+	PrometheusRemoteWritePasswordSecret string
+
+	// PrometheusDropLabelsRegex matches the proxy metric labels that
+	// Prometheus drops at scrape time, if any.
+	PrometheusDropLabelsRegex string
+
+	// PrometheusPodLabelsRegex matches the pod labels that Prometheus copies
+	// onto proxy metrics at scrape time, if any.
+	PrometheusPodLabelsRegex string
+
+	EnableServiceMonitor bool
+
+	// The public API computes the heartbeat metrics every HeartbeatInterval,
+	// and pushes them to HeartbeatPushgatewayURL if it is set.
+	HeartbeatInterval       string
+	HeartbeatPushgatewayURL string
+
+	// Grafana provisions the bundled dashboards from a ConfigMap each, and
+	// the dashboards in GrafanaExtraDashboardsConfigMap if it is set.
+	GrafanaDashboards               []grafanaDashboard
+	GrafanaExtraDashboardsConfigMap string
+
+	// Grafana requires users to log in if GrafanaAuth is set, as the admin
+	// user whose password is stored in the GrafanaAdminSecretName secret, and
+	// grants anonymous users read-only access if GrafanaAnonymousViewer is
+	// also set. Otherwise, anonymous users are editors.
+	GrafanaAuth             bool
+	GrafanaAnonymousViewer  bool
+	GrafanaAdminSecretName  string
+	GrafanaAdminUsernameKey string
+	GrafanaAdminPasswordKey string
+	GrafanaAdminUsername    string
+	GrafanaAdminPassword    string
+
+	// The bundled dashboards query the GrafanaDatasourceName datasource, which
+	// is the bundled Prometheus unless GrafanaDatasourceURL says otherwise.
+	GrafanaDatasourceName string
+	GrafanaDatasourceURL  string
+
+	// GrafanaURL is the base URL of an external Grafana, stored under
+	// LinkerdConfigGrafanaURLKey in the LinkerdConfigMapName ConfigMap. The
+	// bundled Grafana is not installed if it is set.
+	GrafanaURL                 string
+	LinkerdConfigMapName       string
+	LinkerdConfigGrafanaURLKey string
+
+	// The linkerd-cni DaemonSet of CNIConfig is installed, if it is set, to
+	// program the iptables rules of the control plane's pods, which are
+	// injected without the linkerd-init container.
+	CNIConfig *installCNIConfig
+
+	// A ClusterRole that grants the permissions that the CLI's commands need
+	// is bound to the AdminRBACGroup group, if it is set, so that its members
+	// can use the CLI without being cluster admins.
+	AdminRBACGroup string
+
+	// The next steps to take after installing are output after the other
+	// resources, in the InstallNotesKey key of the InstallNotesConfigMapName
+	// ConfigMap, if it is set.
+	InstallNotesConfigMapName string
+	InstallNotesKey           string
+}
+
+// grafanaDashboard is a bundled Grafana dashboard, with its JSON indented for
+// inclusion in a YAML block scalar.
+type grafanaDashboard struct {
+	Name string
+	JSON string
 }
 
 type installOptions struct {
-	controllerReplicas uint
-	webReplicas        uint
-	prometheusReplicas uint
-	controllerLogLevel string
+	controllerReplicas       uint
+	webReplicas              uint
+	prometheusReplicas       uint
+	controllerLogLevel       string
+	logFormat                string
+	identityTrustAnchorsFile string
+	trustBundleConfigMap     bool
+	controlPlaneInternalTLS  bool
+	profileValidation        bool
+	enableHPA                bool
+	hpaMinReplicas           uint
+	hpaMaxReplicas           uint
+	hpaCPUThreshold          uint
+	imageDigestPinning       bool
+	digestFile               string
+	prometheusRetentionTime  string
+	prometheusStorageSize    string
+	prometheusExternalLabels []string
+	prometheusCPULimit       string
+	prometheusMemoryLimit    string
+	remoteWriteURL           string
+	remoteWriteUsername      string
+	remoteWriteSecret        string
+	prometheusDropLabels     []string
+	prometheusPodLabels      []string
+	prometheusExternalURL    string
+	enableServiceMonitor     bool
+	heartbeatInterval        time.Duration
+	heartbeatPushgatewayURL  string
+	grafanaExtraDashboards   string
+	grafanaAuth              bool
+	grafanaAnonymousViewer   bool
+	grafanaDatasourceName    string
+	grafanaDatasourceURL     string
+	grafanaURL               string
+	adminRBACGroup           string
+	notes                    bool
+	only                     []string
 	*proxyConfigOptions
 }
 
-const prometheusProxyOutboundCapacity = 10000
+const (
+	prometheusProxyOutboundCapacity = 10000
+
+	internalTLSSecretName = "linkerd-internal-tls"
+
+	profileValidatorTLSSecretName = "linkerd-sp-validator-tls"
+
+	// hpaCPURequest is the CPU requested by each container of the controller
+	// pod, including its proxy, when it is autoscaled.
+	hpaCPURequest = "100m"
+
+	// internalTLSValidity is the validity period of the internal CA and the
+	// serving certificates it issues; they are replaced by re-running install.
+	internalTLSValidity = 365 * 24 * time.Hour
+
+	registryTimeout = 30 * time.Second
+
+	grafanaAdminUsername = "admin"
+
+	// The components that --only selects the resources of.
+	crdsComponent         = "crds"
+	controlPlaneComponent = "control-plane"
+	rbacComponent         = "rbac"
+	configComponent       = "config"
+	vizComponent          = "viz"
+
+	// defaultGrafanaDatasourceName is the datasource that the bundled
+	// dashboards are written against.
+	defaultGrafanaDatasourceName = "prometheus"
+
+	// grafanaAdminPasswordBytes is the number of random bytes that the
+	// generated Grafana admin password encodes.
+	grafanaAdminPasswordBytes = 24
+
+	// minHeartbeatInterval keeps the heartbeat metrics' rates over several
+	// Prometheus scrapes of the proxies.
+	minHeartbeatInterval = 30 * time.Second
+)
 
 func newInstallOptions() *installOptions {
 	return &installOptions{
-		controllerReplicas: 1,
-		webReplicas:        1,
-		prometheusReplicas: 1,
-		controllerLogLevel: "info",
-		proxyConfigOptions: newProxyConfigOptions(),
+		controllerReplicas:       1,
+		webReplicas:              1,
+		prometheusReplicas:       1,
+		controllerLogLevel:       "info",
+		logFormat:                flags.PlainLogFormat,
+		identityTrustAnchorsFile: "",
+		trustBundleConfigMap:     false,
+		controlPlaneInternalTLS:  false,
+		profileValidation:        false,
+		enableHPA:                false,
+		hpaMinReplicas:           2,
+		hpaMaxReplicas:           10,
+		hpaCPUThreshold:          80,
+		imageDigestPinning:       false,
+		digestFile:               "",
+		prometheusRetentionTime:  "6h",
+		prometheusStorageSize:    "",
+		prometheusExternalLabels: []string{},
+		prometheusCPULimit:       "",
+		prometheusMemoryLimit:    "",
+		remoteWriteURL:           "",
+		remoteWriteUsername:      "",
+		remoteWriteSecret:        "",
+		prometheusDropLabels:     defaultPrometheusDropLabels,
+		prometheusPodLabels:      []string{},
+		prometheusExternalURL:    "",
+		enableServiceMonitor:     false,
+		heartbeatInterval:        time.Minute,
+		heartbeatPushgatewayURL:  "",
+		grafanaExtraDashboards:   "",
+		grafanaAuth:              false,
+		grafanaAnonymousViewer:   false,
+		grafanaDatasourceName:    defaultGrafanaDatasourceName,
+		grafanaDatasourceURL:     "",
+		grafanaURL:               "",
+		adminRBACGroup:           "",
+		notes:                    false,
+		only:                     []string{},
+		proxyConfigOptions:       newProxyConfigOptions(),
 	}
 }
 
@@ -60,7 +333,12 @@ func newCmdInstall() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "install [flags]",
 		Short: "Output Kubernetes configs to install Linkerd",
-		Long:  "Output Kubernetes configs to install Linkerd.",
+		Long: `Output Kubernetes configs to install Linkerd.
+
+To rotate the trust anchor used for TLS, re-run install with
+--identity-trust-anchors-file set to a PEM bundle containing the new trust
+anchor, and apply the output. Proxies continue to trust the existing trust
+anchors until they expire.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			config, err := validateAndBuildConfig(options)
 			if err != nil {
@@ -76,6 +354,42 @@ func newCmdInstall() *cobra.Command {
 	cmd.PersistentFlags().UintVar(&options.webReplicas, "web-replicas", options.webReplicas, "Replicas of the web server to deploy")
 	cmd.PersistentFlags().UintVar(&options.prometheusReplicas, "prometheus-replicas", options.prometheusReplicas, "Replicas of prometheus to deploy")
 	cmd.PersistentFlags().StringVar(&options.controllerLogLevel, "controller-log-level", options.controllerLogLevel, "Log level for the controller and web components")
+	cmd.PersistentFlags().StringVar(&options.logFormat, "log-format", options.logFormat, fmt.Sprintf("Log format for the control plane components, one of: %s, %s", flags.PlainLogFormat, flags.JSONLogFormat))
+	cmd.PersistentFlags().StringVar(&options.prometheusRetentionTime, "prometheus-retention-time", options.prometheusRetentionTime, "How long prometheus keeps metrics for (e.g. 6h, 15d)")
+	cmd.PersistentFlags().StringVar(&options.prometheusStorageSize, "prometheus-storage-size", options.prometheusStorageSize, "Size of the persistent volume to store prometheus metrics on (e.g. 10Gi); metrics are not persisted across restarts if unset")
+	cmd.PersistentFlags().StringSliceVar(&options.prometheusExternalLabels, "prometheus-external-labels", options.prometheusExternalLabels, "Labels, as key=value, that prometheus adds to metrics it sends to federating servers and remote storage")
+	cmd.PersistentFlags().StringVar(&options.prometheusCPULimit, "prometheus-cpu-limit", options.prometheusCPULimit, "Maximum amount of CPU that prometheus can use (e.g. 2, 500m); unlimited if unset")
+	cmd.PersistentFlags().StringVar(&options.prometheusMemoryLimit, "prometheus-memory-limit", options.prometheusMemoryLimit, "Maximum amount of memory that prometheus can use (e.g. 4Gi); unlimited if unset")
+	cmd.PersistentFlags().StringVar(&options.remoteWriteURL, "prometheus-remote-write-url", options.remoteWriteURL, "URL of a remote storage endpoint to send proxy metrics to from prometheus")
+	cmd.PersistentFlags().StringVar(&options.remoteWriteUsername, "prometheus-remote-write-username", options.remoteWriteUsername, "Username to authenticate to --prometheus-remote-write-url with, using basic auth (requires --prometheus-remote-write-password-secret)")
+	cmd.PersistentFlags().StringVar(&options.remoteWriteSecret, "prometheus-remote-write-password-secret", options.remoteWriteSecret, "Name of a secret in the control plane namespace whose \"password\" key holds the password for --prometheus-remote-write-username")
+	cmd.PersistentFlags().StringSliceVar(&options.prometheusDropLabels, "prometheus-drop-labels", options.prometheusDropLabels, "Labels that prometheus drops from proxy metrics when scraping them, to reduce their cardinality; set to \"\" to keep all labels")
+	cmd.PersistentFlags().StringSliceVar(&options.prometheusPodLabels, "prometheus-pod-labels", options.prometheusPodLabels, "Pod labels, e.g. version, that prometheus copies onto proxy metrics when scraping them, so that \"linkerd stat --include-label\" can split stats by them; each adds a label to every proxy series")
+	cmd.PersistentFlags().StringVar(&options.prometheusExternalURL, "prometheus-external-url", options.prometheusExternalURL, "URL of an existing Prometheus that scrapes the proxies, for the control plane and Grafana to query instead of installing the bundled prometheus")
+	cmd.PersistentFlags().BoolVar(&options.enableServiceMonitor, "enable-service-monitor", options.enableServiceMonitor, "Generate ServiceMonitor resources for the Prometheus Operator to scrape the control plane components with, in addition to the bundled Prometheus")
+	cmd.PersistentFlags().DurationVar(&options.heartbeatInterval, "heartbeat-interval", options.heartbeatInterval, "How often the controller computes the heartbeat metrics, a small set of mesh-wide KPIs served on its admin port at /metrics/heartbeat")
+	cmd.PersistentFlags().StringVar(&options.heartbeatPushgatewayURL, "heartbeat-pushgateway-url", options.heartbeatPushgatewayURL, "URL of a Prometheus pushgateway that the controller pushes the heartbeat metrics to")
+	cmd.PersistentFlags().StringVar(&options.grafanaExtraDashboards, "grafana-extra-dashboards-configmap", options.grafanaExtraDashboards, "Name of a ConfigMap in the control plane namespace whose keys are Grafana dashboard JSON files to provision alongside the bundled dashboards")
+	cmd.PersistentFlags().BoolVar(&options.grafanaAuth, "grafana-auth", options.grafanaAuth, "Require users to log in to Grafana as an admin, with a generated password that \"linkerd dashboard --show grafana-credentials\" prints, instead of granting anonymous users editor access")
+	cmd.PersistentFlags().BoolVar(&options.grafanaAnonymousViewer, "grafana-anonymous-viewer", options.grafanaAnonymousViewer, "Keep granting anonymous users read-only access to Grafana (requires --grafana-auth)")
+	cmd.PersistentFlags().StringVar(&options.grafanaDatasourceName, "grafana-datasource-name", options.grafanaDatasourceName, "Name of the Grafana datasource that the bundled dashboards query")
+	cmd.PersistentFlags().StringVar(&options.grafanaDatasourceURL, "grafana-datasource-url", options.grafanaDatasourceURL, "URL of the Prometheus that the bundled Grafana queries, instead of the bundled Prometheus")
+	cmd.PersistentFlags().StringVar(&options.grafanaURL, "grafana-url", options.grafanaURL, "Base URL of an external Grafana that the dashboard links to, instead of installing the bundled Grafana")
+	cmd.PersistentFlags().BoolVar(&options.controlPlaneInternalTLS, "control-plane-internal-tls", options.controlPlaneInternalTLS, "Use TLS between the web server and the public API")
+	cmd.PersistentFlags().BoolVar(&options.profileValidation, "enable-profile-validation", options.profileValidation, "Install an admission webhook that rejects invalid ServiceProfiles; annotate a profile with linkerd.io/skip-profile-validation=true to bypass it")
+	cmd.PersistentFlags().BoolVar(&options.enableHPA, "enable-hpa", options.enableHPA, "Scale the controller, which runs the destination service, with a HorizontalPodAutoscaler instead of --controller-replicas")
+	cmd.PersistentFlags().UintVar(&options.hpaMinReplicas, "hpa-min-replicas", options.hpaMinReplicas, "Minimum replicas of the controller that the autoscaler keeps (requires --enable-hpa)")
+	cmd.PersistentFlags().UintVar(&options.hpaMaxReplicas, "hpa-max-replicas", options.hpaMaxReplicas, "Maximum replicas of the controller that the autoscaler scales up to (requires --enable-hpa)")
+	cmd.PersistentFlags().UintVar(&options.hpaCPUThreshold, "hpa-cpu-threshold", options.hpaCPUThreshold, "Average CPU utilization of the controller, as a percentage of its CPU request, above which the autoscaler adds replicas (requires --enable-hpa)")
+	cmd.PersistentFlags().BoolVar(&options.imageDigestPinning, "image-digest-pinning", options.imageDigestPinning, "Reference all images by their SHA256 digest instead of by tag, resolving tags with the registry unless --digest-file is set")
+	cmd.PersistentFlags().StringVar(&options.digestFile, "digest-file", options.digestFile, "Path to a file of \"<image>:<tag> sha256:<digest>\" lines to pin images with, instead of querying registries (requires --image-digest-pinning)")
+	cmd.PersistentFlags().BoolVar(&options.linkerdCNI, "linkerd-cni", options.linkerdCNI, "Install the linkerd-cni DaemonSet, whose CNI plugin programs the iptables rules of the control plane's pods instead of the linkerd-init container; like \"linkerd install-cni\", which can install it beforehand")
+	cmd.PersistentFlags().BoolVar(&options.disableDetectTimeout, "disable-proxy-protocol-detection-timeout", options.disableDetectTimeout, "Set the timeout of the control plane's proxies' protocol detection on inbound connections to zero, for clients that always send data first, so that the first request isn't delayed")
+	cmd.PersistentFlags().StringSliceVar(&options.only, "only", options.only, fmt.Sprintf("If present, only outputs the resources of these components, one of: %s; may be repeated", strings.Join(installComponents, ", ")))
+	cmd.PersistentFlags().StringVar(&options.adminRBACGroup, "generate-admin-rbac", options.adminRBACGroup, "Name of a group to bind a ClusterRole to, which grants its members the permissions that all the CLI's commands need, without being cluster admins")
+	cmd.PersistentFlags().BoolVar(&options.notes, "notes", options.notes, fmt.Sprintf("Also output the next steps to take after installing, in a %s ConfigMap after the other resources", k8s.InstallNotesConfigMapName))
+	cmd.PersistentFlags().StringVar(&options.identityTrustAnchorsFile, "identity-trust-anchors-file", options.identityTrustAnchorsFile, "Path to a PEM bundle of trust anchors that proxies should trust in addition to the CA's own (requires --tls)")
+	cmd.PersistentFlags().BoolVar(&options.trustBundleConfigMap, "generate-trust-bundle-configmap", options.trustBundleConfigMap, fmt.Sprintf("Also output the \"--identity-trust-anchors-file\" trust anchors in the %s ConfigMap, annotated for cert-manager's cainjector to inject the CA of the %s Secret", k8s.TrustBundleConfigMapName, k8s.TrustAnchorSecretName))
 
 	return cmd
 }
@@ -84,27 +398,423 @@ func validateAndBuildConfig(options *installOptions) (*installConfig, error) {
 	if err := validate(options); err != nil {
 		return nil, err
 	}
-	return &installConfig{
-		Namespace:                   controlPlaneNamespace,
-		ControllerImage:             fmt.Sprintf("%s/controller:%s", options.dockerRegistry, options.linkerdVersion),
-		WebImage:                    fmt.Sprintf("%s/web:%s", options.dockerRegistry, options.linkerdVersion),
-		PrometheusImage:             "prom/prometheus:v2.3.1",
-		GrafanaImage:                fmt.Sprintf("%s/grafana:%s", options.dockerRegistry, options.linkerdVersion),
-		ControllerReplicas:          options.controllerReplicas,
-		WebReplicas:                 options.webReplicas,
-		PrometheusReplicas:          options.prometheusReplicas,
-		ImagePullPolicy:             options.imagePullPolicy,
-		UUID:                        uuid.NewV4().String(),
-		CliVersion:                  k8s.CreatedByAnnotationValue(),
-		ControllerLogLevel:          options.controllerLogLevel,
-		ControllerComponentLabel:    k8s.ControllerComponentLabel,
-		CreatedByAnnotation:         k8s.CreatedByAnnotation,
-		ProxyAPIPort:                options.proxyAPIPort,
-		EnableTLS:                   options.enableTLS(),
-		TLSTrustAnchorConfigMapName: k8s.TLSTrustAnchorConfigMapName,
+
+	identityTrustAnchors, err := readIdentityTrustAnchors(options.identityTrustAnchorsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	externalLabels, err := parsePrometheusExternalLabels(options.prometheusExternalLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &installConfig{
+		Namespace:                            controlPlaneNamespace,
+		ClusterDNSDomain:                     options.clusterDNSDomain,
+		ControllerImage:                      fmt.Sprintf("%s/controller:%s", options.dockerRegistry, options.linkerdVersion),
+		WebImage:                             fmt.Sprintf("%s/web:%s", options.dockerRegistry, options.linkerdVersion),
+		PrometheusImage:                      "prom/prometheus:v2.3.1",
+		GrafanaImage:                         fmt.Sprintf("%s/grafana:%s", options.dockerRegistry, options.linkerdVersion),
+		ControllerReplicas:                   options.controllerReplicas,
+		WebReplicas:                          options.webReplicas,
+		PrometheusReplicas:                   options.prometheusReplicas,
+		ImagePullPolicy:                      options.imagePullPolicy,
+		UUID:                                 uuid.NewV4().String(),
+		CliVersion:                           k8s.CreatedByAnnotationValue(),
+		ControllerLogLevel:                   options.controllerLogLevel,
+		LogFormat:                            options.logFormat,
+		ControllerComponentLabel:             k8s.ControllerComponentLabel,
+		TLSSecretLabel:                       k8s.TLSSecretLabel,
+		CreatedByAnnotation:                  k8s.CreatedByAnnotation,
+		ProxyAPIPort:                         options.proxyAPIPort,
+		EnableTLS:                            options.enableTLS(),
+		TLSTrustAnchorConfigMapName:          k8s.TLSTrustAnchorConfigMapName,
+		TLSTrustAnchorFileName:               k8s.TLSTrustAnchorFileName,
+		TLSIdentityTrustAnchors:              identityTrustAnchors,
+		TLSIdentityTrustAnchorsConfigMapName: k8s.TLSIdentityTrustAnchorsConfigMapName,
+		ControlPlaneInternalTLS:              options.controlPlaneInternalTLS,
+		InternalTLSSecretName:                internalTLSSecretName,
+		ProfileValidation:                    options.profileValidation,
+		ProfileValidatorTLSSecretName:        profileValidatorTLSSecretName,
+		EnableHPA:                            options.enableHPA,
+		HPAMinReplicas:                       options.hpaMinReplicas,
+		HPAMaxReplicas:                       options.hpaMaxReplicas,
+		HPACPUThreshold:                      options.hpaCPUThreshold,
+		HPACPURequest:                        hpaCPURequest,
+		PrometheusRecordingRules:             renderRecordingRules(),
+		PrometheusRetentionTime:              options.prometheusRetentionTime,
+		PrometheusStorageSize:                options.prometheusStorageSize,
+		PrometheusExternalLabels:             externalLabels,
+		PrometheusCPULimit:                   options.prometheusCPULimit,
+		PrometheusMemoryLimit:                options.prometheusMemoryLimit,
+		PrometheusRemoteWriteURL:             options.remoteWriteURL,
+		PrometheusRemoteWriteUsername:        options.remoteWriteUsername,
+		PrometheusRemoteWritePasswordSecret:  options.remoteWriteSecret,
+		PrometheusDropLabelsRegex:            prometheusDropLabelsRegex(options.prometheusDropLabels),
+		PrometheusPodLabelsRegex:             prometheusPodLabelsRegex(options.prometheusPodLabels),
+		PrometheusURL:                        fmt.Sprintf("http://prometheus.%s.svc.%s:9090", controlPlaneNamespace, options.clusterDNSDomain),
+		PrometheusExternalURL:                options.prometheusExternalURL,
+		LinkerdConfigPrometheusURLKey:        k8s.LinkerdConfigPrometheusURLKey,
+		EnableServiceMonitor:                 options.enableServiceMonitor,
+		HeartbeatInterval:                    options.heartbeatInterval.String(),
+		HeartbeatPushgatewayURL:              options.heartbeatPushgatewayURL,
+		GrafanaDashboards:                    renderGrafanaDashboards(options.grafanaDatasourceName),
+		GrafanaExtraDashboardsConfigMap:      options.grafanaExtraDashboards,
+		GrafanaAuth:                          options.grafanaAuth,
+		GrafanaAnonymousViewer:               options.grafanaAnonymousViewer,
+		GrafanaAdminSecretName:               k8s.GrafanaAdminSecretName,
+		GrafanaAdminUsernameKey:              k8s.GrafanaAdminUsernameKey,
+		GrafanaAdminPasswordKey:              k8s.GrafanaAdminPasswordKey,
+		GrafanaDatasourceName:                options.grafanaDatasourceName,
+		GrafanaDatasourceURL:                 options.grafanaDatasourceURL,
+		GrafanaURL:                           strings.TrimSuffix(options.grafanaURL, "/"),
+		LinkerdConfigMapName:                 k8s.LinkerdConfigMapName,
+		LinkerdConfigGrafanaURLKey:           k8s.LinkerdConfigGrafanaURLKey,
+		LinkerdConfigClusterDNSDomainKey:     k8s.LinkerdConfigClusterDNSDomainKey,
+		AdminRBACGroup:                       options.adminRBACGroup,
+	}
+
+	if config.PrometheusExternalURL != "" {
+		config.PrometheusURL = config.PrometheusExternalURL
+	}
+	if config.GrafanaDatasourceURL == "" {
+		config.GrafanaDatasourceURL = config.PrometheusURL
+	}
+
+	if options.grafanaAuth {
+		password, err := generateGrafanaAdminPassword()
+		if err != nil {
+			return nil, err
+		}
+		config.GrafanaAdminUsername = grafanaAdminUsername
+		config.GrafanaAdminPassword = password
+	}
+
+	if options.controlPlaneInternalTLS {
+		if err := issueInternalTLSCertificates(config); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.profileValidation {
+		if err := issueProfileValidatorCertificates(config); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.notes {
+		config.InstallNotesConfigMapName = k8s.InstallNotesConfigMapName
+		config.InstallNotesKey = k8s.InstallNotesKey
+	}
+
+	if options.trustBundleConfigMap {
+		config.TrustBundleConfigMapName = k8s.TrustBundleConfigMapName
+		config.TrustAnchorSecretName = k8s.TrustAnchorSecretName
+		config.CertManagerInjectAnnotation = k8s.CertManagerInjectCAFromSecretAnnotation
+	}
+
+	if options.linkerdCNI {
+		cniConfig, err := validateAndBuildCNIConfig(installCNIOptionsFor(options.proxyConfigOptions))
+		if err != nil {
+			return nil, err
+		}
+		config.CNIConfig = cniConfig
+	}
+
+	if options.imageDigestPinning {
+		resolve, err := newDigestResolver(options.digestFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := pinImages(config, options, resolve); err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+// newDigestResolver returns a function that resolves a tagged image reference
+// to its digest, using digestFile if set, or the image's registry otherwise.
+func newDigestResolver(digestFile string) (func(string) (string, error), error) {
+	if digestFile == "" {
+		resolver := registry.NewResolver(&http.Client{Timeout: registryTimeout})
+		return resolver.Digest, nil
+	}
+
+	digests, err := registry.ReadDigestFile(digestFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --digest-file: %s", err)
+	}
+	return func(image string) (string, error) {
+		digest, ok := digests[image]
+		if !ok {
+			return "", fmt.Errorf("--digest-file has no digest for [%s]", image)
+		}
+		return digest, nil
 	}, nil
 }
 
+// pinImages replaces the tag of every image in config, and of the proxy images
+// injected by render, with its digest.
+func pinImages(config *installConfig, options *installOptions, resolve func(string) (string, error)) error {
+	pin := func(image string) (string, error) {
+		digest, err := resolve(image)
+		if err != nil {
+			return "", err
+		}
+		return registry.PinnedReference(image, digest), nil
+	}
+
+	images := []*string{&config.ControllerImage, &config.WebImage, &config.PrometheusImage, &config.GrafanaImage}
+	if config.CNIConfig != nil {
+		images = append(images, &config.CNIConfig.CNIPluginImage)
+	}
+	for _, image := range images {
+		pinned, err := pin(*image)
+		if err != nil {
+			return err
+		}
+		*image = pinned
+	}
+
+	for _, image := range []string{options.taggedProxyImage(), options.taggedProxyInitImage()} {
+		pinned, err := pin(image)
+		if err != nil {
+			return err
+		}
+		options.imageDigests[image] = pinned
+	}
+
+	return nil
+}
+
+// generateGrafanaAdminPassword returns a random password for the Grafana admin
+// user. A new one is generated every time install is run.
+func generateGrafanaAdminPassword() (string, error) {
+	b := make([]byte, grafanaAdminPasswordBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate Grafana admin password: %s", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// issueInternalTLSCertificates creates a new CA and uses it to issue the
+// public API's serving certificate.
+func issueInternalTLSCertificates(config *installConfig) error {
+	ca, err := tls.NewInternalCA(fmt.Sprintf("linkerd-internal-ca.%s", config.Namespace), internalTLSValidity)
+	if err != nil {
+		return fmt.Errorf("failed to create internal CA: %s", err)
+	}
+
+	apiCrt, err := ca.IssueServingCertificate(fmt.Sprintf("api.%s.svc.%s", config.Namespace, config.ClusterDNSDomain))
+	if err != nil {
+		return fmt.Errorf("failed to issue public API certificate: %s", err)
+	}
+
+	config.InternalTLSCACert = indentBlockScalar(ca.CertificatePEM())
+	config.InternalTLSAPICert = indentBlockScalar(apiCrt.CertificatePEM)
+	config.InternalTLSAPIKey = indentBlockScalar(apiCrt.PrivateKeyPEM)
+	return nil
+}
+
+// issueProfileValidatorCertificates creates a new CA and uses it to issue the
+// sp-validator webhook's serving certificate. The CA is only trusted by the
+// API server, through the webhook configuration's caBundle.
+func issueProfileValidatorCertificates(config *installConfig) error {
+	ca, err := tls.NewInternalCA(fmt.Sprintf("linkerd-sp-validator-ca.%s", config.Namespace), internalTLSValidity)
+	if err != nil {
+		return fmt.Errorf("failed to create sp-validator CA: %s", err)
+	}
+
+	crt, err := ca.IssueServingCertificate(profileValidatorServerName(config.Namespace))
+	if err != nil {
+		return fmt.Errorf("failed to issue sp-validator certificate: %s", err)
+	}
+
+	config.ProfileValidatorCACert = indentBlockScalar(ca.CertificatePEM())
+	config.ProfileValidatorCert = indentBlockScalar(crt.CertificatePEM)
+	config.ProfileValidatorKey = indentBlockScalar(crt.PrivateKeyPEM)
+	config.ProfileValidatorCABundle = base64.StdEncoding.EncodeToString([]byte(ca.CertificatePEM()))
+	return nil
+}
+
+// profileValidatorServerName is the name that the API server verifies the
+// sp-validator webhook's certificate against.
+func profileValidatorServerName(namespace string) string {
+	return fmt.Sprintf("sp-validator.%s.svc", namespace)
+}
+
+// validateHPAOptions checks that the --hpa flags are only set along with
+// --enable-hpa, and that they describe an autoscaler that can scale.
+func validateHPAOptions(options *installOptions) error {
+	defaults := newInstallOptions()
+	if !options.enableHPA {
+		if options.hpaMinReplicas != defaults.hpaMinReplicas || options.hpaMaxReplicas != defaults.hpaMaxReplicas || options.hpaCPUThreshold != defaults.hpaCPUThreshold {
+			return fmt.Errorf("--hpa-min-replicas, --hpa-max-replicas, and --hpa-cpu-threshold require --enable-hpa")
+		}
+		return nil
+	}
+
+	if options.controllerReplicas != defaults.controllerReplicas {
+		return fmt.Errorf("--enable-hpa cannot be combined with --controller-replicas; use --hpa-min-replicas instead")
+	}
+	if options.hpaMinReplicas == 0 {
+		return fmt.Errorf("--hpa-min-replicas must be at least 1")
+	}
+	if options.hpaMaxReplicas < options.hpaMinReplicas {
+		return fmt.Errorf("--hpa-max-replicas must be at least --hpa-min-replicas (%d)", options.hpaMinReplicas)
+	}
+	if options.hpaCPUThreshold == 0 || options.hpaCPUThreshold > 100 {
+		return fmt.Errorf("--hpa-cpu-threshold must be a percentage between 1 and 100")
+	}
+	return nil
+}
+
+// readIdentityTrustAnchors reads and validates the PEM bundle of trust anchors
+// at path, and returns it indented for use in the install template.
+func readIdentityTrustAnchors(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	bundle, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read --identity-trust-anchors-file: %s", err)
+	}
+	anchors, err := tls.ParseTrustAnchors(string(bundle))
+	if err != nil {
+		return "", fmt.Errorf("invalid --identity-trust-anchors-file: %s", err)
+	}
+
+	return indentBlockScalar(tls.EncodeTrustAnchors(anchors)), nil
+}
+
+// parsePrometheusExternalLabels parses a list of key=value labels.
+func parsePrometheusExternalLabels(labels []string) (map[string]string, error) {
+	parsed := make(map[string]string)
+	for _, label := range labels {
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("--prometheus-external-labels must be key=value pairs, got [%s]", label)
+		}
+
+		name := model.LabelName(parts[0])
+		if !name.IsValid() || strings.HasPrefix(parts[0], model.ReservedLabelPrefix) {
+			return nil, fmt.Errorf("--prometheus-external-labels has an invalid label name [%s]", parts[0])
+		}
+		if _, ok := parsed[parts[0]]; ok {
+			return nil, fmt.Errorf("--prometheus-external-labels has duplicate label [%s]", parts[0])
+		}
+		parsed[parts[0]] = parts[1]
+	}
+	return parsed, nil
+}
+
+// defaultPrometheusDropLabels are the proxy metric labels that prometheus
+// drops unless --prometheus-drop-labels is set. The pod template hash of a
+// destination changes with each of its rollouts, leaving behind stale series
+// that no query selects.
+var defaultPrometheusDropLabels = []string{"dst_pod_template_hash"}
+
+// prometheusTargetLabels are the labels that Prometheus attaches to every
+// metric to identify the target it was scraped from.
+var prometheusTargetLabels = []string{"job", "instance"}
+
+// validatePrometheusDropLabels checks that none of labels are needed by the
+// public API's queries, or by Prometheus itself.
+func validatePrometheusDropLabels(labels []string) error {
+	required := make(map[string]struct{})
+	for _, name := range append(public.RequiredLabelNames(), prometheusTargetLabels...) {
+		required[name] = struct{}{}
+	}
+
+	for _, label := range labels {
+		if !model.LabelName(label).IsValid() || strings.HasPrefix(label, model.ReservedLabelPrefix) {
+			return fmt.Errorf("--prometheus-drop-labels has an invalid label name [%s]", label)
+		}
+		if _, ok := required[label]; ok {
+			return fmt.Errorf("--prometheus-drop-labels cannot drop [%s], which Linkerd relies on", label)
+		}
+	}
+	return nil
+}
+
+// prometheusDropLabelsRegex returns a regex that matches exactly the given
+// label names, or an empty string if there are none.
+func prometheusDropLabelsRegex(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("^(%s)$", strings.Join(labels, "|"))
+}
+
+// prometheusPodLabelsRegex returns a regex that matches the Prometheus
+// service discovery labels of exactly the given pod label keys, capturing
+// their names, or an empty string if there are none.
+func prometheusPodLabelsRegex(keys []string) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = public.PodLabelKeyName(key)
+	}
+	return fmt.Sprintf("__meta_kubernetes_pod_label_(%s)", strings.Join(names, "|"))
+}
+
+// renderRecordingRules renders the recording rules that the public API uses to
+// answer stat queries as a Prometheus rule file.
+func renderRecordingRules() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("groups:\n")
+	for _, group := range public.RecordingRuleGroups() {
+		fmt.Fprintf(buf, "- name: %s\n", group.Name)
+		buf.WriteString("  rules:\n")
+		for _, rule := range group.Rules {
+			fmt.Fprintf(buf, "  - record: %s\n", rule.Record)
+			if len(rule.Labels) > 0 {
+				buf.WriteString("    labels:\n")
+				names := make([]string, 0, len(rule.Labels))
+				for name := range rule.Labels {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				for _, name := range names {
+					fmt.Fprintf(buf, "      %s: %q\n", name, rule.Labels[name])
+				}
+			}
+			fmt.Fprintf(buf, "    expr: %s\n", rule.Expr)
+		}
+	}
+	return indentBlockScalar(buf.String())
+}
+
+// renderGrafanaDashboards returns the bundled Grafana dashboards, sorted by
+// name, with their datasource references pointed at datasourceName.
+func renderGrafanaDashboards(datasourceName string) []grafanaDashboard {
+	datasource, _ := json.Marshal(datasourceName)
+	replacer := strings.NewReplacer(
+		fmt.Sprintf(`"datasource": %q`, defaultGrafanaDatasourceName),
+		fmt.Sprintf(`"datasource": %s`, datasource),
+	)
+
+	dashboards := make([]grafanaDashboard, 0, len(install.GrafanaDashboards))
+	for name, dashboard := range install.GrafanaDashboards {
+		dashboards = append(dashboards, grafanaDashboard{Name: name, JSON: indentBlockScalar(replacer.Replace(dashboard))})
+	}
+	sort.Slice(dashboards, func(i, j int) bool { return dashboards[i].Name < dashboards[j].Name })
+	return dashboards
+}
+
+// indentBlockScalar indents each line of s for use as the value of a
+// top-level key's YAML block scalar.
+func indentBlockScalar(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return "    " + strings.Join(lines, "\n    ")
+}
+
 func render(config installConfig, w io.Writer, options *installOptions) error {
 	template, err := template.New("linkerd").Parse(install.Template)
 	if err != nil {
@@ -125,18 +835,286 @@ func render(config installConfig, w io.Writer, options *installOptions) error {
 			return err
 		}
 	}
+	if config.EnableServiceMonitor {
+		serviceMonitorTemplate, err := template.New("linkerd").Parse(install.ServiceMonitorTemplate)
+		if err != nil {
+			return err
+		}
+		err = serviceMonitorTemplate.Execute(buf, config)
+		if err != nil {
+			return err
+		}
+	}
+	if config.CNIConfig != nil {
+		if err := renderCNI(*config.CNIConfig, buf); err != nil {
+			return err
+		}
+	}
+	if config.AdminRBACGroup != "" {
+		adminRBACTemplate, err := template.New("linkerd").Parse(install.AdminRBACTemplate)
+		if err != nil {
+			return err
+		}
+		err = adminRBACTemplate.Execute(buf, config)
+		if err != nil {
+			return err
+		}
+	}
+	if len(options.only) > 0 {
+		if buf, err = filterInstallComponents(buf, options.only); err != nil {
+			return err
+		}
+	}
 	injectOptions := newInjectOptions()
 	injectOptions.proxyConfigOptions = options.proxyConfigOptions
 
 	// Special case for linkerd-proxy running in the Prometheus pod.
 	injectOptions.proxyOutboundCapacity[config.PrometheusImage] = prometheusProxyOutboundCapacity
 
-	return InjectYAML(buf, w, injectOptions)
+	// The autoscaler can only compute the controller's CPU utilization if
+	// every container in its pod, including the proxy, requests CPU.
+	if config.EnableHPA {
+		injectOptions.proxyCPURequest[config.ControllerImage] = config.HPACPURequest
+	}
+
+	// The control plane's Services, ConfigMaps, and other resources that
+	// can't be injected are expected, so they aren't warned about.
+	if err := InjectYAML(buf, w, ioutil.Discard, injectOptions); err != nil {
+		return err
+	}
+
+	// The notes are output last, whichever components are output, so that
+	// they're the first thing read after applying the manifest.
+	if config.InstallNotesConfigMapName != "" {
+		notesTemplate, err := template.New("linkerd").Parse(install.NotesTemplate)
+		if err != nil {
+			return err
+		}
+		return notesTemplate.Execute(w, config)
+	}
+	return nil
+}
+
+// installComponents are the components that --only can select, in the order
+// that they are installed in.
+var installComponents = []string{crdsComponent, rbacComponent, configComponent, controlPlaneComponent, vizComponent}
+
+// vizControlPlaneComponents are the values of the control plane component
+// label of the resources that make up the viz component.
+var vizControlPlaneComponents = map[string]bool{"web": true, "prometheus": true, "grafana": true}
+
+func isInstallComponent(component string) bool {
+	for _, c := range installComponents {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// installComponentOf returns the component that a resource of kind, with
+// labels, belongs to. The namespace belongs to every component but the CRDs,
+// which are cluster-scoped, so that each can be installed on its own; it is
+// reported as the empty component.
+func installComponentOf(kind string, labels map[string]string) string {
+	switch kind {
+	case "Namespace":
+		return ""
+	case "CustomResourceDefinition":
+		return crdsComponent
+	case "ServiceAccount", "ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding", "PodSecurityPolicy":
+		return rbacComponent
+	}
+	if vizControlPlaneComponents[labels[k8s.ControllerComponentLabel]] {
+		return vizComponent
+	}
+	if kind == "ConfigMap" || kind == "Secret" {
+		return configComponent
+	}
+	return controlPlaneComponent
+}
+
+// filterInstallComponents returns the resources of in that belong to one of
+// the components in only.
+func filterInstallComponents(in io.Reader, only []string) (*bytes.Buffer, error) {
+	selected := map[string]bool{}
+	for _, component := range only {
+		selected[component] = true
+	}
+	// The namespace is output with every component but the CRDs.
+	selected[""] = len(selected) > 1 || !selected[crdsComponent]
+
+	out := &bytes.Buffer{}
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(in, 4096))
+	heading := []byte{}
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// The template puts each resource's "### <Resource> ###" heading before
+		// the separator that starts it, so it's read as part of the previous
+		// resource, and moved back to its own one.
+		resourceHeading := heading
+		doc, heading = splitTrailingComments(doc)
+
+		var resource struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal(doc, &resource); err != nil {
+			return nil, err
+		}
+		if resource.Kind == "" || !selected[installComponentOf(resource.Kind, resource.Metadata.Labels)] {
+			continue
+		}
+
+		out.Write(resourceHeading)
+		out.Write(doc)
+		out.WriteString("---\n")
+	}
+	return out, nil
+}
+
+// splitTrailingComments splits the comment lines at the end of a YAML document
+// from the rest of it.
+func splitTrailingComments(doc []byte) ([]byte, []byte) {
+	lines := strings.SplitAfter(string(doc), "\n")
+	i := len(lines)
+	for i > 0 {
+		line := strings.TrimSpace(lines[i-1])
+		if line != "" && !strings.HasPrefix(line, "#") {
+			break
+		}
+		i--
+	}
+	for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+		i++
+	}
+	return []byte(strings.Join(lines[:i], "")), []byte(strings.Join(lines[i:], ""))
 }
 
 func validate(options *installOptions) error {
 	if _, err := log.ParseLevel(options.controllerLogLevel); err != nil {
 		return fmt.Errorf("--controller-log-level must be one of: panic, fatal, error, warn, info, debug")
 	}
+	if options.logFormat != flags.PlainLogFormat && options.logFormat != flags.JSONLogFormat {
+		return fmt.Errorf("--log-format must be one of: %s, %s", flags.PlainLogFormat, flags.JSONLogFormat)
+	}
+	if options.digestFile != "" && !options.imageDigestPinning {
+		return fmt.Errorf("--digest-file requires --image-digest-pinning")
+	}
+	if options.identityTrustAnchorsFile != "" && !options.enableTLS() {
+		return fmt.Errorf("--identity-trust-anchors-file requires --tls=%s", optionalTLS)
+	}
+	if options.trustBundleConfigMap && options.identityTrustAnchorsFile == "" {
+		return fmt.Errorf("--generate-trust-bundle-configmap requires --identity-trust-anchors-file")
+	}
+	if retention, err := model.ParseDuration(options.prometheusRetentionTime); err != nil || retention <= 0 {
+		return fmt.Errorf("--prometheus-retention-time must be a positive duration, such as 6h or 15d")
+	}
+	if options.remoteWriteURL != "" {
+		u, err := url.Parse(options.remoteWriteURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("--prometheus-remote-write-url must be an http or https URL")
+		}
+	}
+	if (options.remoteWriteUsername != "" || options.remoteWriteSecret != "") && options.remoteWriteURL == "" {
+		return fmt.Errorf("--prometheus-remote-write-username and --prometheus-remote-write-password-secret require --prometheus-remote-write-url")
+	}
+	if (options.remoteWriteUsername == "") != (options.remoteWriteSecret == "") {
+		return fmt.Errorf("--prometheus-remote-write-username and --prometheus-remote-write-password-secret must be set together")
+	}
+	if err := validatePrometheusDropLabels(options.prometheusDropLabels); err != nil {
+		return err
+	}
+	for _, key := range options.prometheusPodLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("--prometheus-pod-labels has an invalid label key [%s]: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	if options.heartbeatInterval < minHeartbeatInterval {
+		return fmt.Errorf("--heartbeat-interval must be at least %s", minHeartbeatInterval)
+	}
+	if options.heartbeatPushgatewayURL != "" {
+		u, err := url.Parse(options.heartbeatPushgatewayURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("--heartbeat-pushgateway-url must be an http or https URL")
+		}
+	}
+	if options.prometheusExternalURL != "" {
+		u, err := url.Parse(options.prometheusExternalURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("--prometheus-external-url must be an http or https URL")
+		}
+		defaults := newInstallOptions()
+		if options.prometheusReplicas != defaults.prometheusReplicas || options.prometheusRetentionTime != defaults.prometheusRetentionTime ||
+			options.prometheusStorageSize != "" || len(options.prometheusExternalLabels) > 0 ||
+			options.prometheusCPULimit != "" || options.prometheusMemoryLimit != "" || len(options.prometheusPodLabels) > 0 ||
+			options.remoteWriteURL != "" || strings.Join(options.prometheusDropLabels, ",") != strings.Join(defaults.prometheusDropLabels, ",") {
+			return fmt.Errorf("--prometheus-external-url replaces the bundled prometheus, and cannot be combined with the other --prometheus flags")
+		}
+	}
+	if options.grafanaAnonymousViewer && !options.grafanaAuth {
+		return fmt.Errorf("--grafana-anonymous-viewer requires --grafana-auth")
+	}
+	if options.grafanaExtraDashboards != "" {
+		if errs := validation.IsDNS1123Subdomain(options.grafanaExtraDashboards); len(errs) > 0 {
+			return fmt.Errorf("--grafana-extra-dashboards-configmap must be a valid ConfigMap name: %s", strings.Join(errs, ", "))
+		}
+	}
+	if options.grafanaDatasourceName == "" {
+		return fmt.Errorf("--grafana-datasource-name must not be empty")
+	}
+	if options.grafanaDatasourceURL != "" {
+		u, err := url.Parse(options.grafanaDatasourceURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("--grafana-datasource-url must be an http or https URL")
+		}
+	}
+	if options.grafanaURL != "" {
+		u, err := url.Parse(options.grafanaURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("--grafana-url must be an http or https URL")
+		}
+		if options.grafanaAuth || options.grafanaExtraDashboards != "" || options.grafanaDatasourceURL != "" || options.grafanaDatasourceName != defaultGrafanaDatasourceName {
+			return fmt.Errorf("--grafana-url disables the bundled Grafana, and cannot be combined with the other --grafana flags")
+		}
+	}
+	if options.adminRBACGroup != "" && strings.TrimSpace(options.adminRBACGroup) != options.adminRBACGroup {
+		return fmt.Errorf("--generate-admin-rbac must be the name of a group, got [%s]", options.adminRBACGroup)
+	}
+	for _, component := range options.only {
+		if !isInstallComponent(component) {
+			return fmt.Errorf("--only must be one of: %s, got [%s]", strings.Join(installComponents, ", "), component)
+		}
+	}
+	if err := validateHPAOptions(options); err != nil {
+		return err
+	}
+	if options.prometheusStorageSize != "" {
+		size, err := resource.ParseQuantity(options.prometheusStorageSize)
+		if err != nil || size.Sign() <= 0 {
+			return fmt.Errorf("--prometheus-storage-size must be a positive quantity, such as 10Gi")
+		}
+	}
+	if options.prometheusCPULimit != "" {
+		limit, err := resource.ParseQuantity(options.prometheusCPULimit)
+		if err != nil || limit.Sign() <= 0 {
+			return fmt.Errorf("--prometheus-cpu-limit must be a positive quantity, such as 500m")
+		}
+	}
+	if options.prometheusMemoryLimit != "" {
+		limit, err := resource.ParseQuantity(options.prometheusMemoryLimit)
+		if err != nil || limit.Sign() <= 0 {
+			return fmt.Errorf("--prometheus-memory-limit must be a positive quantity, such as 4Gi")
+		}
+	}
 	return options.validate()
 }
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/linkerd/linkerd2/cli/install"
+	"github.com/linkerd/linkerd2/cni-plugin/plugin"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// sha256Hex matches hex-encoded SHA-256 hashes.
+var sha256Hex = regexp.MustCompile("^[0-9a-fA-F]{64}$")
+
+type installCNIConfig struct {
+	Namespace                string
+	CreateNamespace          bool
+	CNIPluginImage           string
+	ImagePullPolicy          string
+	CliVersion               string
+	ControllerComponentLabel string
+	CreatedByAnnotation      string
+
+	// The plugin's binary and network configuration are installed in the
+	// node's DestCNIBinDir and DestCNINetDir. NetworkConfig is the plugin's
+	// network configuration, as JSON.
+	DestCNIBinDir string
+	DestCNINetDir string
+	NetworkConfig string
+
+	// CNIPluginSHA256 is the expected hash of the plugin binary, which is
+	// stored in the CNI ConfigMap. `linkerd check` only verifies the binary
+	// installed on each node if it's set, and against the release's hash if
+	// the CLI knows it.
+	CNIPluginSHA256    string
+	CNIConfigMapName   string
+	CNIPluginSHA256Key string
+}
+
+type installCNIOptions struct {
+	linkerdVersion      string
+	dockerRegistry      string
+	cniPluginImage      string
+	imagePullPolicy     string
+	inboundPort         uint
+	outboundPort        uint
+	proxyUID            int64
+	proxyControlPort    uint
+	proxyMetricsPort    uint
+	ignoreInboundPorts  []uint
+	ignoreOutboundPorts []uint
+	iptablesMode        string
+	ipv6Mode            string
+	destCNIBinDir       string
+	destCNINetDir       string
+	cniPluginSHA256     string
+}
+
+func newInstallCNIOptions() *installCNIOptions {
+	return &installCNIOptions{
+		linkerdVersion:      version.Version,
+		dockerRegistry:      defaultDockerRegistry,
+		cniPluginImage:      defaultDockerRegistry + "/cni-plugin",
+		imagePullPolicy:     "IfNotPresent",
+		inboundPort:         4143,
+		outboundPort:        4140,
+		proxyUID:            2102,
+		proxyControlPort:    4190,
+		proxyMetricsPort:    4191,
+		ignoreInboundPorts:  nil,
+		ignoreOutboundPorts: nil,
+		iptablesMode:        "",
+		ipv6Mode:            "",
+		destCNIBinDir:       "/opt/cni/bin",
+		destCNINetDir:       "/etc/cni/net.d",
+		cniPluginSHA256:     "",
+	}
+}
+
+// installCNIOptionsFor returns the options of the linkerd-cni DaemonSet that
+// `linkerd install --linkerd-cni` installs, which programs the rules of pods
+// injected with the proxy configuration of options.
+func installCNIOptionsFor(options *proxyConfigOptions) *installCNIOptions {
+	cniOptions := newInstallCNIOptions()
+	cniOptions.linkerdVersion = options.linkerdVersion
+	cniOptions.dockerRegistry = options.dockerRegistry
+	cniOptions.imagePullPolicy = options.imagePullPolicy
+	cniOptions.proxyUID = options.proxyUID
+	cniOptions.proxyControlPort = options.proxyControlPort
+	cniOptions.proxyMetricsPort = options.proxyMetricsPort
+	return cniOptions
+}
+
+func (options *installCNIOptions) validate() error {
+	if !alphaNumDashDot.MatchString(options.linkerdVersion) {
+		return fmt.Errorf("%s is not a valid version", options.linkerdVersion)
+	}
+	if !alphaNumDashDotSlash.MatchString(options.dockerRegistry) {
+		return fmt.Errorf("%s is not a valid Docker registry", options.dockerRegistry)
+	}
+	if !isValidPullPolicy(options.imagePullPolicy) {
+		return fmt.Errorf("--image-pull-policy must be one of: Always, IfNotPresent, Never")
+	}
+	if options.iptablesMode != "" && !isValidIptablesMode(options.iptablesMode) {
+		return fmt.Errorf("--iptables-mode must be one of: %s", strings.Join(iptablesModes, ", "))
+	}
+	if options.ipv6Mode != "" && !isValidIPv6Mode(options.ipv6Mode) {
+		return fmt.Errorf("--ipv6-mode must be one of: %s", strings.Join(ipv6Modes, ", "))
+	}
+	if !filepath.IsAbs(options.destCNIBinDir) {
+		return fmt.Errorf("--dest-cni-bin-dir must be an absolute path, got [%s]", options.destCNIBinDir)
+	}
+	if !filepath.IsAbs(options.destCNINetDir) {
+		return fmt.Errorf("--dest-cni-net-dir must be an absolute path, got [%s]", options.destCNINetDir)
+	}
+	if options.cniPluginSHA256 != "" && !sha256Hex.MatchString(options.cniPluginSHA256) {
+		return fmt.Errorf("--cni-plugin-sha256 must be a hex-encoded SHA-256 hash, got [%s]", options.cniPluginSHA256)
+	}
+	return nil
+}
+
+func (options *installCNIOptions) taggedCNIPluginImage() string {
+	image := strings.Replace(options.cniPluginImage, defaultDockerRegistry, options.dockerRegistry, 1)
+	return fmt.Sprintf("%s:%s", image, options.linkerdVersion)
+}
+
+// networkConfig returns the plugin's network configuration, which programs the
+// rules that linkerd-init would with the same options. The installer adds the
+// plugin's type and kubeconfig.
+func (options *installCNIOptions) networkConfig() (string, error) {
+	inboundPortsToIgnore := []int{int(options.proxyControlPort), int(options.proxyMetricsPort)}
+	for _, port := range options.ignoreInboundPorts {
+		inboundPortsToIgnore = append(inboundPortsToIgnore, int(port))
+	}
+	outboundPortsToIgnore := []int{}
+	for _, port := range options.ignoreOutboundPorts {
+		outboundPortsToIgnore = append(outboundPortsToIgnore, int(port))
+	}
+
+	config := map[string]interface{}{
+		"linkerd": plugin.ProxyInitConf{
+			IncomingProxyPort:     int(options.inboundPort),
+			OutgoingProxyPort:     int(options.outboundPort),
+			ProxyUID:              int(options.proxyUID),
+			InboundPortsToIgnore:  inboundPortsToIgnore,
+			OutboundPortsToIgnore: outboundPortsToIgnore,
+			IptablesMode:          options.iptablesMode,
+			IPv6Mode:              options.ipv6Mode,
+		},
+	}
+	b, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func newCmdInstallCNI() *cobra.Command {
+	options := newInstallCNIOptions()
+
+	cmd := &cobra.Command{
+		Use:   "install-cni [flags]",
+		Short: "Output Kubernetes configs to install the Linkerd CNI plugin",
+		Long: `Output Kubernetes configs to install the Linkerd CNI plugin.
+
+The linkerd-cni DaemonSet installs a CNI plugin on each node, which is chained
+after the node's CNI plugin, and which programs the iptables rules of pods
+injected with --linkerd-cni when their network is created. Pods injected this
+way don't need the NET_ADMIN capability that the linkerd-init container
+requires.
+
+The plugin only programs the rules of pods created after it's installed on
+their node. "linkerd install --linkerd-cni" installs it along with the control
+plane, whose pods are injected without linkerd-init. Deleting the DaemonSet
+removes the plugin from the nodes.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := validateAndBuildCNIConfig(options)
+			if err != nil {
+				return err
+			}
+			config.CreateNamespace = true
+
+			return renderCNI(*config, os.Stdout)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.linkerdVersion, "linkerd-version", "v", options.linkerdVersion, "Tag to be used for the Linkerd CNI plugin image")
+	cmd.PersistentFlags().StringVar(&options.dockerRegistry, "registry", options.dockerRegistry, "Docker registry to pull images from")
+	cmd.PersistentFlags().StringVar(&options.cniPluginImage, "cni-image", options.cniPluginImage, "Linkerd CNI plugin image name")
+	cmd.PersistentFlags().StringVar(&options.imagePullPolicy, "image-pull-policy", options.imagePullPolicy, "Docker image pull policy")
+	cmd.PersistentFlags().UintVar(&options.inboundPort, "inbound-port", options.inboundPort, "Proxy port to use for inbound traffic")
+	cmd.PersistentFlags().UintVar(&options.outboundPort, "outbound-port", options.outboundPort, "Proxy port to use for outbound traffic")
+	cmd.PersistentFlags().Int64Var(&options.proxyUID, "proxy-uid", options.proxyUID, "User ID that the proxy runs under, whose outbound traffic skips the proxy")
+	cmd.PersistentFlags().UintVar(&options.proxyControlPort, "control-port", options.proxyControlPort, "Proxy port to use for control")
+	cmd.PersistentFlags().UintVar(&options.proxyMetricsPort, "metrics-port", options.proxyMetricsPort, "Proxy port to serve metrics on")
+	cmd.PersistentFlags().UintSliceVar(&options.ignoreInboundPorts, "skip-inbound-ports", options.ignoreInboundPorts, "Ports that should skip the proxy and send directly to the application, in all pods; inject's --skip-inbound-ports adds to them")
+	cmd.PersistentFlags().UintSliceVar(&options.ignoreOutboundPorts, "skip-outbound-ports", options.ignoreOutboundPorts, "Outbound ports that should skip the proxy, in all pods; inject's --skip-outbound-ports adds to them")
+	cmd.PersistentFlags().StringVar(&options.iptablesMode, "iptables-mode", options.iptablesMode, "iptables backend that the plugin programs the pods' rules with, one of: "+strings.Join(iptablesModes, ", ")+"; the "+k8s.ProxyInitIptablesModeAnnotation+" annotation overrides it (by default the backend that the node uses is detected)")
+	cmd.PersistentFlags().StringVar(&options.ipv6Mode, "ipv6-mode", options.ipv6Mode, "Whether the plugin also redirects the pods' IPv6 traffic to their proxies, one of: "+strings.Join(ipv6Modes, ", ")+"; the "+k8s.ProxyInitIPv6ModeAnnotation+" annotation overrides it (by default IPv6 traffic skips the proxy)")
+	cmd.PersistentFlags().StringVar(&options.destCNIBinDir, "dest-cni-bin-dir", options.destCNIBinDir, "Directory of the nodes' CNI plugin binaries")
+	cmd.PersistentFlags().StringVar(&options.destCNINetDir, "dest-cni-net-dir", options.destCNINetDir, "Directory of the nodes' CNI network configurations")
+	cmd.PersistentFlags().StringVar(&options.cniPluginSHA256, "cni-plugin-sha256", options.cniPluginSHA256, "SHA-256 hash of the plugin binary in the CNI plugin image, which must match the release's hash; \"linkerd check\" skips verifying the binary installed on each node without it")
+
+	return cmd
+}
+
+func validateAndBuildCNIConfig(options *installCNIOptions) (*installCNIConfig, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	networkConfig, err := options.networkConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &installCNIConfig{
+		Namespace:                controlPlaneNamespace,
+		CNIPluginImage:           options.taggedCNIPluginImage(),
+		ImagePullPolicy:          options.imagePullPolicy,
+		CliVersion:               k8s.CreatedByAnnotationValue(),
+		ControllerComponentLabel: k8s.ControllerComponentLabel,
+		CreatedByAnnotation:      k8s.CreatedByAnnotation,
+		DestCNIBinDir:            options.destCNIBinDir,
+		DestCNINetDir:            options.destCNINetDir,
+		NetworkConfig:            networkConfig,
+		CNIPluginSHA256:          strings.ToLower(options.cniPluginSHA256),
+		CNIConfigMapName:         k8s.CNIConfigMapName,
+		CNIPluginSHA256Key:       k8s.CNIPluginSHA256Key,
+	}, nil
+}
+
+func renderCNI(config installCNIConfig, w io.Writer) error {
+	template, err := template.New("linkerd-cni").Parse(install.CNITemplate)
+	if err != nil {
+		return err
+	}
+	buf := &bytes.Buffer{}
+	if err := template.Execute(buf, config); err != nil {
+		return err
+	}
+	_, err = io.Copy(w, buf)
+	return err
+}
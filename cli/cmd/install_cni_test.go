@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"testing"
+)
+
+func TestRenderCNI(t *testing.T) {
+	defaultOptions := newInstallCNIOptions()
+	defaultConfig, err := validateAndBuildCNIConfig(defaultOptions)
+	if err != nil {
+		t.Fatalf("Unexpected error from validateAndBuildCNIConfig(): %v", err)
+	}
+	defaultConfig.CreateNamespace = true
+
+	// A configuration for nodes whose CNI directories are elsewhere, e.g. on
+	// GKE, with pods that skip the proxy on some ports.
+	customOptions := newInstallCNIOptions()
+	customOptions.dockerRegistry = "registry.example.com/linkerd"
+	customOptions.imagePullPolicy = "Always"
+	customOptions.inboundPort = 5143
+	customOptions.outboundPort = 5140
+	customOptions.proxyUID = 1337
+	customOptions.ignoreInboundPorts = []uint{8443}
+	customOptions.ignoreOutboundPorts = []uint{3306, 5432}
+	customOptions.iptablesMode = "nft"
+	customOptions.ipv6Mode = "auto"
+	customOptions.destCNIBinDir = "/home/kubernetes/bin"
+	customOptions.destCNINetDir = "/etc/cni/custom.d"
+	customOptions.cniPluginSHA256 = "9A3A45D01531A20E89AC6AE10B0B0BEB0492ACD7216A368AA062D1A5FECAF9CD"
+	customConfig, err := validateAndBuildCNIConfig(customOptions)
+	if err != nil {
+		t.Fatalf("Unexpected error from validateAndBuildCNIConfig(): %v", err)
+	}
+	customConfig.CreateNamespace = true
+
+	testCases := []struct {
+		config         installCNIConfig
+		goldenFileName string
+	}{
+		{*defaultConfig, "testdata/install_cni_default.golden"},
+		{*customConfig, "testdata/install_cni_custom.golden"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d: %s", i, tc.goldenFileName), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := renderCNI(tc.config, &buf); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			goldenFileBytes, err := ioutil.ReadFile(tc.goldenFileName)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			diffCompare(t, buf.String(), string(goldenFileBytes))
+		})
+	}
+}
+
+func TestValidateInstallCNI(t *testing.T) {
+	testCases := []struct {
+		update   func(*installCNIOptions)
+		expected string
+	}{
+		{func(o *installCNIOptions) { o.linkerdVersion = "v1/2" }, "v1/2 is not a valid version"},
+		{func(o *installCNIOptions) { o.dockerRegistry = "registry:5000" }, "registry:5000 is not a valid Docker registry"},
+		{func(o *installCNIOptions) { o.imagePullPolicy = "Sometimes" }, "--image-pull-policy must be one of: Always, IfNotPresent, Never"},
+		{func(o *installCNIOptions) { o.iptablesMode = "ipvs" }, "--iptables-mode must be one of: auto, legacy, nft"},
+		{func(o *installCNIOptions) { o.ipv6Mode = "on" }, "--ipv6-mode must be one of: auto, enabled, disabled"},
+		{func(o *installCNIOptions) { o.destCNIBinDir = "opt/cni/bin" }, "--dest-cni-bin-dir must be an absolute path, got [opt/cni/bin]"},
+		{func(o *installCNIOptions) { o.destCNINetDir = "" }, "--dest-cni-net-dir must be an absolute path, got []"},
+		{func(o *installCNIOptions) { o.cniPluginSHA256 = "9a3a45d0" }, "--cni-plugin-sha256 must be a hex-encoded SHA-256 hash, got [9a3a45d0]"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d: %s", i, tc.expected), func(t *testing.T) {
+			options := newInstallCNIOptions()
+			tc.update(options)
+			if err := options.validate(); err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error %q, got: %v", tc.expected, err)
+			}
+		})
+	}
+}
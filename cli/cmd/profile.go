@@ -0,0 +1,432 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ghodss/yaml"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// profileTemplate is a commented skeleton ServiceProfile. The routes it
+// contains are examples, but are valid so that the output can be applied
+// as-is and edited later.
+const profileTemplate = `### ServiceProfile for {{.ServiceName}}.{{.ServiceNamespace}} ###
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  # The name of a ServiceProfile is the fully qualified name of the service
+  # that it describes.
+  name: {{.ServiceName}}.{{.ServiceNamespace}}.svc.{{.ClusterDNSDomain}}
+  # ServiceProfiles live in the Linkerd control plane's namespace.
+  namespace: {{.ControlPlaneNamespace}}
+spec:
+  # A service has a list of routes. Each request is matched against the
+  # routes in order, and is attributed to the first route that it matches.
+  routes:
+  - name: '/authors/{id}'
+    # Each route has a condition which determines whether a request matches
+    # the route. A condition must set exactly one of:
+    #   method:    the request's HTTP method
+    #   pathRegex: a regular expression that must match the entire path
+    #   all:       a list of conditions that must all match
+    #   any:       a list of conditions of which at least one must match
+    #   not:       a condition that must not match
+    condition:
+      all:
+      - method: GET
+      - pathRegex: '/authors/[^/]*'
+    # A route may have a list of response classes, which determine whether a
+    # response is considered a failure. The first class that matches a
+    # response is used. By default, 5XX responses are failures.
+    responseClasses:
+    - condition:
+        # A response condition sets exactly one of status (an inclusive range
+        # of HTTP status codes, where min or max may be omitted), grpcStatus
+        # (the same, for gRPC status codes), all, any, or not.
+        status:
+          min: 500
+          max: 599
+      isFailure: true
+`
+
+type profileOptions struct {
+	namespace string
+	template  bool
+	openAPI   string
+	proto     string
+	service   string
+
+	tap               string
+	tapDuration       time.Duration
+	tapRouteLimit     int
+	tapMinSamples     int
+	tapShareThreshold float64
+
+	// kubeContext is the kubeconfig context of the cluster that --tap
+	// watches.
+	kubeContext string
+
+	// clusterDNSDomain is the DNS domain of the service's cluster, which the
+	// profile's name is in.
+	clusterDNSDomain string
+}
+
+type profileTemplateConfig struct {
+	ServiceName           string
+	ServiceNamespace      string
+	ClusterDNSDomain      string
+	ControlPlaneNamespace string
+}
+
+// openAPISpec holds the subset of an OpenAPI (Swagger) 2.0 specification
+// needed to generate a ServiceProfile.
+type openAPISpec struct {
+	BasePath string                     `json:"basePath"`
+	Paths    map[string]openAPIPathItem `json:"paths"`
+}
+
+type openAPIPathItem struct {
+	Get     *openAPIOperation `json:"get"`
+	Put     *openAPIOperation `json:"put"`
+	Post    *openAPIOperation `json:"post"`
+	Delete  *openAPIOperation `json:"delete"`
+	Options *openAPIOperation `json:"options"`
+	Head    *openAPIOperation `json:"head"`
+	Patch   *openAPIOperation `json:"patch"`
+}
+
+type openAPIOperation struct {
+	OperationID string `json:"operationId"`
+}
+
+// pathParamRegex matches a path template parameter, such as "{id}".
+var pathParamRegex = regexp.MustCompile(`\{[^/{}]*\}`)
+
+func newProfileOptions() *profileOptions {
+	return &profileOptions{
+		namespace: "default",
+		template:  false,
+		openAPI:   "",
+		proto:     "",
+		service:   "",
+
+		tap:               "",
+		tapDuration:       5 * time.Second,
+		tapRouteLimit:     20,
+		tapMinSamples:     10,
+		tapShareThreshold: 0.01,
+
+		kubeContext:      "",
+		clusterDNSDomain: k8s.DefaultClusterDNSDomain,
+	}
+}
+
+func (options *profileOptions) validate() error {
+	modes := 0
+	for _, set := range []bool{options.template, options.openAPI != "", options.proto != "", options.tap != ""} {
+		if set {
+			modes++
+		}
+	}
+	if modes != 1 {
+		return errors.New("you must specify exactly one of --template, --open-api, --proto, or --tap")
+	}
+	if options.service != "" && options.proto == "" {
+		return errors.New("--service can only be used with --proto")
+	}
+	if options.tapDuration <= 0 {
+		return errors.New("--tap-duration must be greater than 0")
+	}
+	if options.tapRouteLimit <= 0 {
+		return errors.New("--tap-route-limit must be greater than 0")
+	}
+	if options.tapMinSamples < 0 {
+		return errors.New("--tap-min-samples must not be negative")
+	}
+	if options.tapShareThreshold < 0 || options.tapShareThreshold > 1 {
+		return errors.New("--tap-share-threshold must be between 0 and 1")
+	}
+	if !alphaNumDash.MatchString(options.namespace) {
+		return fmt.Errorf("%s is not a valid namespace", options.namespace)
+	}
+	if errs := validation.IsDNS1123Subdomain(options.clusterDNSDomain); len(errs) > 0 {
+		return fmt.Errorf("--cluster-dns-domain has an invalid domain [%s]: %s", options.clusterDNSDomain, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func newCmdProfile() *cobra.Command {
+	options := newProfileOptions()
+
+	cmd := &cobra.Command{
+		Use:   "profile [flags] (--template | --open-api FILENAME | --proto FILENAME | --tap RESOURCE) SERVICE",
+		Short: "Output service profile config for Kubernetes",
+		Long: `Output service profile config for Kubernetes.
+
+With --template, outputs a commented skeleton ServiceProfile for the service
+that can be edited and applied.
+
+With --open-api, generates a ServiceProfile with a route for each path and
+method in an OpenAPI (Swagger) 2.0 specification, in JSON or YAML. Routes are
+named after the operationId of each operation, or after its method and path if
+it has none.
+
+With --proto, generates a ServiceProfile with a route for each rpc of the gRPC
+service defined in a .proto file, including streaming rpcs. Responses with a
+grpc-status other than OK are classified as failures. If the file defines more
+than one service, use --service to pick one.
+
+With --tap, taps the given resource for --tap-duration and learns routes from
+the inbound requests that it observes. Path segments that are numbers or UUIDs
+are collapsed into parameters, so that requests such as "GET /books/1" and
+"GET /books/2" become a single "GET /books/{id}" route. Routes that received
+less than --tap-share-threshold of the observed requests are left out, as are
+all but the --tap-route-limit busiest routes.`,
+		Example: `  # Output a template ServiceProfile for the web service in the emojivoto namespace
+  linkerd profile --template web -n emojivoto
+
+  # Generate a ServiceProfile from an OpenAPI spec and apply it
+  linkerd profile --open-api web.swagger web -n emojivoto | kubectl apply -f -
+
+  # Generate a ServiceProfile for the Emoji service defined in a proto file
+  linkerd profile --proto Emoji.proto --service EmojiService emoji-svc -n emojivoto
+
+  # Generate a ServiceProfile for the web service by watching the web deployment's traffic for 30 seconds
+  linkerd profile --tap deploy/web --tap-duration 30s web-svc -n emojivoto`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+
+			if options.template {
+				return renderProfileTemplate(options, args[0], os.Stdout)
+			}
+
+			if options.tap != "" {
+				client, err := newPublicAPIClientForContext(kubeContextFor(options.kubeContext))
+				if err != nil {
+					return err
+				}
+
+				return renderTapProfile(client, options, args[0], os.Stdout)
+			}
+
+			if options.proto != "" {
+				file, err := os.Open(options.proto)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+
+				return renderProtoProfile(file, options, args[0], os.Stdout)
+			}
+
+			var in io.Reader
+			if options.openAPI == "-" {
+				in = os.Stdin
+			} else {
+				file, err := os.Open(options.openAPI)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				in = file
+			}
+
+			return renderOpenAPIProfile(in, options, args[0], os.Stdout)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the service")
+	cmd.PersistentFlags().BoolVar(&options.template, "template", options.template, "Output a service profile template")
+	cmd.PersistentFlags().StringVar(&options.openAPI, "open-api", options.openAPI, "Output a service profile based on the given OpenAPI spec file, or \"-\" to read from stdin")
+	cmd.PersistentFlags().StringVar(&options.proto, "proto", options.proto, "Output a service profile based on the given Protobuf spec file")
+	cmd.PersistentFlags().StringVar(&options.service, "service", options.service, "Name of the gRPC service in the Protobuf spec file to generate routes for (required if the file defines more than one)")
+	cmd.PersistentFlags().StringVar(&options.tap, "tap", options.tap, "Output a service profile based on tap data for the given resource, e.g. deploy/web")
+	cmd.PersistentFlags().DurationVar(&options.tapDuration, "tap-duration", options.tapDuration, "Duration over which tap data is collected")
+	cmd.PersistentFlags().IntVar(&options.tapRouteLimit, "tap-route-limit", options.tapRouteLimit, "Maximum number of routes to output, keeping those with the most traffic")
+	cmd.PersistentFlags().IntVar(&options.tapMinSamples, "tap-min-samples", options.tapMinSamples, "Minimum number of requests that must be observed to output a service profile")
+	cmd.PersistentFlags().Float64Var(&options.tapShareThreshold, "tap-share-threshold", options.tapShareThreshold, "Minimum share of the observed requests, between 0 and 1, that a route must receive to be output")
+	cmd.PersistentFlags().StringVar(&options.clusterDNSDomain, "cluster-dns-domain", options.clusterDNSDomain, "DNS domain of the Kubernetes cluster, which the service's fully qualified name, and so the profile's name, is in")
+	addKubeContextFlag(cmd, &options.kubeContext)
+
+	cmd.AddCommand(newCmdProfileDiff())
+
+	return cmd
+}
+
+func renderProfileTemplate(options *profileOptions, service string, w io.Writer) error {
+	tmpl, err := template.New("profile").Parse(profileTemplate)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(w, profileTemplateConfig{
+		ServiceName:           service,
+		ServiceNamespace:      options.namespace,
+		ClusterDNSDomain:      options.clusterDNSDomain,
+		ControlPlaneNamespace: controlPlaneNamespace,
+	})
+}
+
+func renderOpenAPIProfile(in io.Reader, options *profileOptions, service string, w io.Writer) error {
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	spec, err := parseOpenAPI(data)
+	if err != nil {
+		return err
+	}
+
+	profile := profileFromOpenAPI(spec, profiles.ServiceProfileName(service, options.namespace, options.clusterDNSDomain), controlPlaneNamespace)
+	return writeProfile(profile, w)
+}
+
+func renderProtoProfile(in io.Reader, options *profileOptions, service string, w io.Writer) error {
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	file, err := parseProto(data)
+	if err != nil {
+		return err
+	}
+
+	protoService, err := selectProtoService(file, options.service)
+	if err != nil {
+		return err
+	}
+	if len(protoService.methods) == 0 {
+		return fmt.Errorf("service %s does not define any rpcs", protoService.name)
+	}
+
+	profile := profileFromProto(file, protoService, profiles.ServiceProfileName(service, options.namespace, options.clusterDNSDomain), controlPlaneNamespace)
+	return writeProfile(profile, w)
+}
+
+// writeProfile validates a generated ServiceProfile and writes it as YAML.
+func writeProfile(profile *sp.ServiceProfile, w io.Writer) error {
+	if err := profiles.Validate(profile); err != nil {
+		return fmt.Errorf("generated an invalid service profile: %s", err)
+	}
+
+	output, err := yaml.Marshal(profile)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(output)
+	return err
+}
+
+// parseOpenAPI parses an OpenAPI 2.0 spec in either JSON or YAML.
+func parseOpenAPI(data []byte) (*openAPISpec, error) {
+	j, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %s", err)
+	}
+
+	var spec openAPISpec
+	if err := json.Unmarshal(j, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %s", err)
+	}
+	if len(spec.Paths) == 0 {
+		return nil, errors.New("OpenAPI spec has no paths")
+	}
+
+	return &spec, nil
+}
+
+func profileFromOpenAPI(spec *openAPISpec, name, controlPlaneNamespace string) *sp.ServiceProfile {
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	routes := []*sp.RouteSpec{}
+	names := make(map[string]bool)
+	for _, path := range paths {
+		fullPath := strings.TrimSuffix(spec.BasePath, "/") + path
+		item := spec.Paths[path]
+
+		for _, op := range []struct {
+			method    string
+			operation *openAPIOperation
+		}{
+			{"GET", item.Get},
+			{"PUT", item.Put},
+			{"POST", item.Post},
+			{"DELETE", item.Delete},
+			{"OPTIONS", item.Options},
+			{"HEAD", item.Head},
+			{"PATCH", item.Patch},
+		} {
+			if op.operation == nil {
+				continue
+			}
+
+			// operationIds are supposed to be unique, but fall back to the
+			// method and path rather than generating duplicate route names.
+			name := op.operation.OperationID
+			if name == "" || names[name] {
+				name = fmt.Sprintf("%s %s", op.method, fullPath)
+			}
+			names[name] = true
+
+			routes = append(routes, &sp.RouteSpec{
+				Name: name,
+				Condition: &sp.RequestMatch{
+					All: []*sp.RequestMatch{
+						{Method: op.method},
+						{PathRegex: pathToRegex(fullPath)},
+					},
+				},
+			})
+		}
+	}
+
+	return &sp.ServiceProfile{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: sp.SchemeGroupVersion.String(),
+			Kind:       "ServiceProfile",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: controlPlaneNamespace,
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: routes,
+		},
+	}
+}
+
+// pathToRegex converts an OpenAPI path template into a regex that matches
+// the paths it describes. Each path parameter matches a single path segment,
+// or part of one; everything else is matched literally.
+func pathToRegex(path string) string {
+	literals := pathParamRegex.Split(path, -1)
+	for i, literal := range literals {
+		literals[i] = regexp.QuoteMeta(literal)
+	}
+	return strings.Join(literals, "[^/]*")
+}
@@ -0,0 +1,432 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	spclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// defaultRouteName is the name under which requests that match none of a
+// profile's routes are reported.
+const defaultRouteName = "[DEFAULT]"
+
+type profileDiffOptions struct {
+	namespace        string
+	tap              string
+	tapDuration      time.Duration
+	tapMinSamples    int
+	unusedThreshold  float64
+	defaultThreshold float64
+	suggest          bool
+	clusterDNSDomain string
+}
+
+// routeUsage is the number of observed requests that a profile's route
+// matched, and the name of the profile that the route came from.
+type routeUsage struct {
+	name   string
+	source string
+	count  int
+	share  float64
+}
+
+// profileDiff compares the ServiceProfile named profile, merged with its
+// default profiles, with the requests observed to its service. Requests are
+// attributed to the first route that they match, like the proxy does, and to
+// the default route if they match none.
+type profileDiff struct {
+	profile         string
+	total           int
+	routes          []routeUsage
+	defaultCount    int
+	defaultShare    float64
+	defaultRequests []observedRequest
+}
+
+func newProfileDiffOptions() *profileDiffOptions {
+	return &profileDiffOptions{
+		namespace:        "default",
+		tap:              "",
+		tapDuration:      10 * time.Second,
+		tapMinSamples:    10,
+		unusedThreshold:  0,
+		defaultThreshold: 0.05,
+		suggest:          false,
+		clusterDNSDomain: k8s.DefaultClusterDNSDomain,
+	}
+}
+
+func (options *profileDiffOptions) validate() error {
+	if options.tap == "" {
+		return errors.New("--tap must name the resource that serves the service, e.g. deploy/web")
+	}
+	if options.tapDuration <= 0 {
+		return errors.New("--tap-duration must be greater than 0")
+	}
+	if options.tapMinSamples < 0 {
+		return errors.New("--tap-min-samples must not be negative")
+	}
+	if options.unusedThreshold < 0 || options.unusedThreshold > 1 {
+		return errors.New("--unused-threshold must be between 0 and 1")
+	}
+	if options.defaultThreshold < 0 || options.defaultThreshold > 1 {
+		return errors.New("--default-threshold must be between 0 and 1")
+	}
+	if !alphaNumDash.MatchString(options.namespace) {
+		return fmt.Errorf("%s is not a valid namespace", options.namespace)
+	}
+	if errs := validation.IsDNS1123Subdomain(options.clusterDNSDomain); len(errs) > 0 {
+		return fmt.Errorf("--cluster-dns-domain has an invalid domain [%s]: %s", options.clusterDNSDomain, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func newCmdProfileDiff() *cobra.Command {
+	options := newProfileDiffOptions()
+
+	cmd := &cobra.Command{
+		Use:   "diff [flags] --tap RESOURCE SERVICE",
+		Short: "Compare a service's profile with its live traffic",
+		Long: `Compare a service's profile with its live traffic.
+
+Fetches the service's ServiceProfile, merged with the namespace and cluster
+default profiles, taps the resource that serves the service for
+--tap-duration, and attributes each inbound request to the first route of the
+merged profile that it matches, or to the default route if it matches none.
+Reports which profile each route came from, the service's own routes that
+received at most --unused-threshold of the requests, and the share of requests
+that fell through to the default route.
+
+With --suggest, outputs an updated ServiceProfile for the service instead,
+without its unused routes and with routes learned from the requests that fell
+through to the default route, the same way "linkerd profile --tap" learns
+them. Routes from the default profiles are left out, as they still apply.`,
+		Example: `  # Compare the web service's profile with 30 seconds of its traffic
+  linkerd profile diff --tap deploy/web --tap-duration 30s web-svc -n emojivoto
+
+  # Update the web service's profile to match its traffic
+  linkerd profile diff --tap deploy/web --suggest web-svc -n emojivoto | kubectl apply -f -`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return err
+			}
+
+			spClient, err := k8s.NewServiceProfileClient(kubeconfigPath, kubeContext)
+			if err != nil {
+				return err
+			}
+
+			client, err := newPublicAPIClient()
+			if err != nil {
+				return err
+			}
+
+			return renderProfileDiff(spClient, client, options, args[0], os.Stdout)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the service")
+	cmd.PersistentFlags().StringVar(&options.tap, "tap", options.tap, "Resource that serves the service, whose inbound traffic is tapped, e.g. deploy/web")
+	cmd.PersistentFlags().DurationVar(&options.tapDuration, "tap-duration", options.tapDuration, "Duration over which tap data is collected")
+	cmd.PersistentFlags().IntVar(&options.tapMinSamples, "tap-min-samples", options.tapMinSamples, "Minimum number of requests that must be observed to compare the profile with")
+	cmd.PersistentFlags().Float64Var(&options.unusedThreshold, "unused-threshold", options.unusedThreshold, "Share of the observed requests, between 0 and 1, at or below which a route is reported as unused")
+	cmd.PersistentFlags().Float64Var(&options.defaultThreshold, "default-threshold", options.defaultThreshold, "Share of the observed requests, between 0 and 1, above which traffic to the default route is reported")
+	cmd.PersistentFlags().BoolVar(&options.suggest, "suggest", options.suggest, "Output an updated service profile instead of a report")
+	cmd.PersistentFlags().StringVar(&options.clusterDNSDomain, "cluster-dns-domain", options.clusterDNSDomain, "DNS domain of the Kubernetes cluster, which the names of the service's profile and the default profiles are in")
+
+	return cmd
+}
+
+func renderProfileDiff(spClient spclient.Interface, client pb.ApiClient, options *profileDiffOptions, service string, w io.Writer) error {
+	profile, defaults, err := getServiceProfiles(spClient, service, options.namespace, options.clusterDNSDomain)
+	if err != nil {
+		return err
+	}
+
+	requests, err := tapRequests(client, options.tap, options.namespace, options.tapDuration)
+	if err != nil {
+		return err
+	}
+
+	if len(requests) == 0 || len(requests) < options.tapMinSamples {
+		return fmt.Errorf("observed %d requests to %s in %s, fewer than the %d required by --tap-min-samples; try a longer --tap-duration",
+			len(requests), options.tap, options.tapDuration, options.tapMinSamples)
+	}
+
+	spec, sources := profiles.Merge(append([]*sp.ServiceProfile{profile}, defaults...)...)
+	diff := diffProfile(profiles.ServiceProfileName(service, options.namespace, options.clusterDNSDomain), spec, sources, requests)
+
+	if options.suggest {
+		suggested := suggestProfile(profile, diff, options.unusedThreshold)
+		if len(suggested.Spec.Routes) == 0 {
+			return fmt.Errorf("none of the routes of %s are used, and no routes were learned from requests to the default route; the default profiles cover the observed traffic", diff.profile)
+		}
+		return writeProfile(suggested, w)
+	}
+
+	return printProfileDiff(w, diff, options)
+}
+
+// getServiceProfiles returns the ServiceProfile of a service, and the
+// namespace and cluster default profiles that are merged under it, from most
+// to least specific. Profiles that don't exist are nil, but at least one of
+// them must exist.
+func getServiceProfiles(spClient spclient.Interface, service, namespace, clusterDNSDomain string) (*sp.ServiceProfile, []*sp.ServiceProfile, error) {
+	names := []string{
+		profiles.ServiceProfileName(service, namespace, clusterDNSDomain),
+		profiles.NamespaceDefaultName(namespace, clusterDNSDomain),
+		profiles.ClusterDefaultName(clusterDNSDomain),
+	}
+
+	found := make([]*sp.ServiceProfile, len(names))
+	exists := false
+	for i, name := range names {
+		profile, err := spClient.LinkerdV1alpha1().ServiceProfiles(controlPlaneNamespace).Get(name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get service profile %s: %s", name, err)
+		}
+		found[i] = profile
+		exists = true
+	}
+
+	if !exists {
+		return nil, nil, fmt.Errorf("no service profile %s, and no default profile %s or %s, found in the %s namespace",
+			names[0], names[1], names[2], controlPlaneNamespace)
+	}
+
+	return found[0], found[1:], nil
+}
+
+// diffProfile attributes each of the requests to a route of the spec merged
+// from the profile named name and its defaults, whose routes came from the
+// profiles named by sources.
+func diffProfile(name string, spec *sp.ServiceProfileSpec, sources []string, requests []observedRequest) *profileDiff {
+	matcher := newRequestMatcher()
+	diff := &profileDiff{profile: name, total: len(requests)}
+
+	counts := make([]int, len(spec.Routes))
+	for _, req := range requests {
+		matched := false
+		for i, route := range spec.Routes {
+			if matcher.matches(route.Condition, req) {
+				counts[i]++
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			diff.defaultRequests = append(diff.defaultRequests, req)
+		}
+	}
+
+	for i, route := range spec.Routes {
+		diff.routes = append(diff.routes, routeUsage{
+			name:   route.Name,
+			source: sources[i],
+			count:  counts[i],
+			share:  float64(counts[i]) / float64(diff.total),
+		})
+	}
+	diff.defaultCount = len(diff.defaultRequests)
+	diff.defaultShare = float64(diff.defaultCount) / float64(diff.total)
+
+	return diff
+}
+
+// unusedRoutes returns the routes from the profile named source that received
+// at most threshold of the observed requests. Routes from default profiles
+// are shared with other services, so they're only reported for the default
+// profiles themselves.
+func (diff *profileDiff) unusedRoutes(threshold float64, source string) []string {
+	unused := []string{}
+	for _, route := range diff.routes {
+		if route.source != source {
+			continue
+		}
+		if route.count == 0 || route.share <= threshold {
+			unused = append(unused, route.name)
+		}
+	}
+	return unused
+}
+
+// suggestProfile returns a copy of the service's profile, which may be nil,
+// without its unused routes, and with the routes learned from the requests
+// that fell through to the default route appended, so that they don't change
+// which route existing requests are attributed to. Learned routes that would
+// themselves be unused are left out. Routes from default profiles aren't
+// copied, since they still apply under the suggested profile.
+func suggestProfile(profile *sp.ServiceProfile, diff *profileDiff, unusedThreshold float64) *sp.ServiceProfile {
+	unused := make(map[string]bool)
+	for _, route := range diff.unusedRoutes(unusedThreshold, diff.profile) {
+		unused[route] = true
+	}
+
+	suggested := &sp.ServiceProfile{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: sp.SchemeGroupVersion.String(),
+			Kind:       "ServiceProfile",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      diff.profile,
+			Namespace: controlPlaneNamespace,
+		},
+	}
+	if profile != nil {
+		suggested.Spec = *profile.Spec.DeepCopy()
+	}
+
+	routes := []*sp.RouteSpec{}
+	for _, route := range suggested.Spec.Routes {
+		if route == nil || unused[route.Name] {
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	// Learned routes must not share a name with any route of the merged
+	// profile, including default routes, which they would replace.
+	names := make(map[string]bool)
+	for _, route := range diff.routes {
+		names[route.name] = true
+	}
+
+	learned := clusterRoutes(diff.defaultRequests, 0, len(diff.defaultRequests))
+	for i, route := range profileFromTap(learned, "", "").Spec.Routes {
+		if names[route.Name] || float64(learned[i].count)/float64(diff.total) <= unusedThreshold {
+			continue
+		}
+		routes = append(routes, route)
+		names[route.Name] = true
+	}
+
+	suggested.Spec.Routes = routes
+	return suggested
+}
+
+func printProfileDiff(w io.Writer, diff *profileDiff, options *profileDiffOptions) error {
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"ROUTE", "FROM", "REQUESTS", "SHARE"}, "\t"))
+	for _, route := range diff.routes {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.2f%%\n", route.name, routeSourceLabel(route.source, diff.profile, options.clusterDNSDomain), route.count, route.share*100)
+	}
+	fmt.Fprintf(tw, "%s\t%s\t%d\t%.2f%%\n", defaultRouteName, "-", diff.defaultCount, diff.defaultShare*100)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nObserved %d requests to %s in %s.\n", diff.total, options.tap, options.tapDuration)
+
+	stale := false
+	if unused := diff.unusedRoutes(options.unusedThreshold, diff.profile); len(unused) > 0 {
+		stale = true
+		fmt.Fprintf(w, "\nUnused routes, which received at most %.2f%% of requests:\n", options.unusedThreshold*100)
+		for _, name := range unused {
+			fmt.Fprintf(w, "  %s\n", name)
+		}
+	}
+
+	if diff.defaultShare > options.defaultThreshold {
+		stale = true
+		fmt.Fprintf(w, "\n%.2f%% of requests matched no route and fell through to %s, more than the %.2f%% allowed by --default-threshold.\n",
+			diff.defaultShare*100, defaultRouteName, options.defaultThreshold*100)
+	}
+
+	if stale {
+		fmt.Fprintln(w, "\nRun with --suggest to output an updated service profile.")
+	} else {
+		fmt.Fprintln(w, "\nThe service profile matches the observed traffic.")
+	}
+
+	return nil
+}
+
+// routeSourceLabel describes the profile, named source, that a route of the
+// profile named service, in the cluster whose DNS domain is clusterDNSDomain,
+// came from.
+func routeSourceLabel(source, service, clusterDNSDomain string) string {
+	switch {
+	case source == service:
+		return "service"
+	case source == profiles.ClusterDefaultName(clusterDNSDomain):
+		return "cluster default"
+	default:
+		return "namespace default"
+	}
+}
+
+// requestMatcher evaluates the request conditions of a ServiceProfile's
+// routes against observed requests, caching compiled path regexes.
+type requestMatcher struct {
+	regexes map[string]*regexp.Regexp
+}
+
+func newRequestMatcher() *requestMatcher {
+	return &requestMatcher{regexes: make(map[string]*regexp.Regexp)}
+}
+
+// matches reports whether req satisfies the condition. A pathRegex must match
+// the entire path, without its query string. Conditions that can't be
+// evaluated, such as invalid regexes, match nothing.
+func (m *requestMatcher) matches(match *sp.RequestMatch, req observedRequest) bool {
+	if match == nil {
+		return false
+	}
+
+	switch {
+	case match.All != nil:
+		for _, c := range match.All {
+			if !m.matches(c, req) {
+				return false
+			}
+		}
+		return len(match.All) > 0
+
+	case match.Any != nil:
+		for _, c := range match.Any {
+			if m.matches(c, req) {
+				return true
+			}
+		}
+		return false
+
+	case match.Not != nil:
+		return !m.matches(match.Not, req)
+
+	case match.PathRegex != "":
+		re, ok := m.regexes[match.PathRegex]
+		if !ok {
+			re, _ = regexp.Compile("^(?:" + match.PathRegex + ")$")
+			m.regexes[match.PathRegex] = re
+		}
+		path := req.path
+		if i := strings.IndexAny(path, "?#"); i >= 0 {
+			path = path[:i]
+		}
+		return re != nil && re.MatchString(path)
+
+	case match.Method != "":
+		return strings.EqualFold(match.Method, req.method)
+	}
+
+	return false
+}
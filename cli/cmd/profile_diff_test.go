@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	"github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/fake"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRequestMatcher(t *testing.T) {
+	get := &sp.RequestMatch{Method: "GET"}
+	books := &sp.RequestMatch{PathRegex: `/books/[0-9]+`}
+
+	testCases := []struct {
+		name     string
+		match    *sp.RequestMatch
+		req      observedRequest
+		expected bool
+	}{
+		{"method", get, observedRequest{"GET", "/"}, true},
+		{"other method", get, observedRequest{"POST", "/"}, false},
+		{"path", books, observedRequest{"GET", "/books/1"}, true},
+		{"path with query", books, observedRequest{"GET", "/books/1?page=2"}, true},
+		{"path prefix", books, observedRequest{"GET", "/books/1/edit"}, false},
+		{"path suffix", books, observedRequest{"GET", "/v1/books/1"}, false},
+		{"all", &sp.RequestMatch{All: []*sp.RequestMatch{get, books}}, observedRequest{"GET", "/books/1"}, true},
+		{"not all", &sp.RequestMatch{All: []*sp.RequestMatch{get, books}}, observedRequest{"PUT", "/books/1"}, false},
+		{"empty all", &sp.RequestMatch{All: []*sp.RequestMatch{}}, observedRequest{"GET", "/"}, false},
+		{"any", &sp.RequestMatch{Any: []*sp.RequestMatch{get, books}}, observedRequest{"PUT", "/books/1"}, true},
+		{"not any", &sp.RequestMatch{Any: []*sp.RequestMatch{get, books}}, observedRequest{"PUT", "/authors/1"}, false},
+		{"not", &sp.RequestMatch{Not: get}, observedRequest{"POST", "/"}, true},
+		{"invalid regex", &sp.RequestMatch{PathRegex: `/books/(`}, observedRequest{"GET", "/books/("}, false},
+		{"empty condition", &sp.RequestMatch{}, observedRequest{"GET", "/"}, false},
+		{"no condition", nil, observedRequest{"GET", "/"}, false},
+	}
+
+	matcher := newRequestMatcher()
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			if got := matcher.matches(tc.match, tc.req); got != tc.expected {
+				t.Fatalf("Expected %t for %+v, got %t", tc.expected, tc.req, got)
+			}
+		})
+	}
+}
+
+func TestRenderProfileDiff(t *testing.T) {
+	profile := &sp.ServiceProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "books.books.svc.cluster.local",
+			Namespace:       controlPlaneNamespace,
+			ResourceVersion: "42",
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: []*sp.RouteSpec{
+				{
+					Name: "GET /books/{id}",
+					Condition: &sp.RequestMatch{
+						All: []*sp.RequestMatch{
+							{Method: "GET"},
+							{PathRegex: `/books/[^/]+`},
+						},
+					},
+				},
+				{
+					Name: "DELETE /books/{id}",
+					Condition: &sp.RequestMatch{
+						All: []*sp.RequestMatch{
+							{Method: "DELETE"},
+							{PathRegex: `/books/[^/]+`},
+						},
+					},
+				},
+				{
+					Name: "POST /books",
+					Condition: &sp.RequestMatch{
+						All: []*sp.RequestMatch{
+							{Method: "POST"},
+							{PathRegex: `/books`},
+						},
+					},
+					ResponseClasses: []*sp.ResponseClass{
+						{
+							Condition: &sp.ResponseMatch{Status: &sp.Range{Min: 500, Max: 599}},
+							IsFailure: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	reqEvent := func(method pb.HttpMethod_Registered, path string) pb.TapEvent {
+		return pb.TapEvent{
+			ProxyDirection: pb.TapEvent_INBOUND,
+			Event: &pb.TapEvent_Http_{
+				Http: &pb.TapEvent_Http{
+					Event: &pb.TapEvent_Http_RequestInit_{
+						RequestInit: &pb.TapEvent_Http_RequestInit{
+							Method: &pb.HttpMethod{
+								Type: &pb.HttpMethod_Registered_{Registered: method},
+							},
+							Path: path,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	events := []pb.TapEvent{}
+	for i := 0; i < 6; i++ {
+		events = append(events, reqEvent(pb.HttpMethod_GET, "/books/"+strconv.Itoa(i)))
+	}
+	for i := 0; i < 3; i++ {
+		events = append(events, reqEvent(pb.HttpMethod_POST, "/books"))
+	}
+	// Requests that fall through to the default route.
+	events = append(events,
+		reqEvent(pb.HttpMethod_GET, "/authors/1"),
+		reqEvent(pb.HttpMethod_GET, "/authors/2"),
+		reqEvent(pb.HttpMethod_GET, "/healthz?probe=1"),
+	)
+
+	mockClient := func() *public.MockApiClient {
+		eventsToReturn := make([]pb.TapEvent, len(events))
+		copy(eventsToReturn, events)
+		return &public.MockApiClient{
+			Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{
+				TapEventsToReturn: eventsToReturn,
+			},
+		}
+	}
+
+	newOptions := func() *profileDiffOptions {
+		options := newProfileDiffOptions()
+		options.namespace = "books"
+		options.tap = "deploy/books"
+		return options
+	}
+
+	t.Run("Reports unused routes and traffic to the default route", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := renderProfileDiff(fake.NewSimpleClientset(profile), mockClient(), newOptions(), "books", &buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompare(t, buf.String(), readOptionalTestFile(t, "profile_diff_books.golden"))
+	})
+
+	t.Run("Reports a profile that matches the observed traffic", func(t *testing.T) {
+		options := newOptions()
+		options.unusedThreshold = 0
+		options.defaultThreshold = 0.5
+
+		matching := profile.DeepCopy()
+		matching.Spec.Routes = []*sp.RouteSpec{profile.Spec.Routes[0], profile.Spec.Routes[2]}
+
+		var buf bytes.Buffer
+		err := renderProfileDiff(fake.NewSimpleClientset(matching), mockClient(), options, "books", &buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `ROUTE             FROM      REQUESTS   SHARE
+GET /books/{id}   service   6          50.00%
+POST /books       service   3          25.00%
+[DEFAULT]         -         3          25.00%
+
+Observed 12 requests to deploy/books in 10s.
+
+The service profile matches the observed traffic.
+`
+		diffCompare(t, buf.String(), expected)
+	})
+
+	t.Run("Suggests an updated profile", func(t *testing.T) {
+		options := newOptions()
+		options.suggest = true
+		options.unusedThreshold = 0.1
+
+		var buf bytes.Buffer
+		err := renderProfileDiff(fake.NewSimpleClientset(profile), mockClient(), options, "books", &buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if err := profiles.ValidateYAML(buf.Bytes()); err != nil {
+			t.Fatalf("Suggested an invalid service profile: %v", err)
+		}
+
+		diffCompare(t, buf.String(), readOptionalTestFile(t, "profile_diff_books_suggest.golden"))
+	})
+
+	// Default profiles for the books namespace and the cluster. The namespace
+	// default's GET route is replaced by the service's route of the same name.
+	namespaceDefault := &sp.ServiceProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "books.svc.cluster.local",
+			Namespace: controlPlaneNamespace,
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: []*sp.RouteSpec{
+				{
+					Name: "GET /books/{id}",
+					Condition: &sp.RequestMatch{
+						All: []*sp.RequestMatch{
+							{Method: "GET"},
+							{PathRegex: `/.*`},
+						},
+					},
+				},
+				{
+					Name:      "GET /healthz",
+					Condition: &sp.RequestMatch{PathRegex: `/healthz`},
+				},
+			},
+		},
+	}
+	clusterDefault := &sp.ServiceProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc.cluster.local",
+			Namespace: controlPlaneNamespace,
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: []*sp.RouteSpec{
+				{
+					Name:      "GET /metrics",
+					Condition: &sp.RequestMatch{PathRegex: `/metrics`},
+				},
+			},
+		},
+	}
+
+	t.Run("Merges the namespace and cluster default profiles under the service's", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := renderProfileDiff(fake.NewSimpleClientset(profile, namespaceDefault, clusterDefault), mockClient(), newOptions(), "books", &buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompare(t, buf.String(), readOptionalTestFile(t, "profile_diff_books_defaults.golden"))
+	})
+
+	t.Run("Compares the default profiles of services without a profile", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := renderProfileDiff(fake.NewSimpleClientset(namespaceDefault, clusterDefault), mockClient(), newOptions(), "books", &buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `ROUTE             FROM                REQUESTS   SHARE
+GET /books/{id}   namespace default   9          75.00%
+GET /healthz      namespace default   0          0.00%
+GET /metrics      cluster default     0          0.00%
+[DEFAULT]         -                   3          25.00%
+
+Observed 12 requests to deploy/books in 10s.
+
+25.00% of requests matched no route and fell through to [DEFAULT], more than the 5.00% allowed by --default-threshold.
+
+Run with --suggest to output an updated service profile.
+`
+		diffCompare(t, buf.String(), expected)
+	})
+
+	t.Run("Suggests a profile without the default profiles' routes", func(t *testing.T) {
+		options := newOptions()
+		options.suggest = true
+
+		var buf bytes.Buffer
+		err := renderProfileDiff(fake.NewSimpleClientset(namespaceDefault, clusterDefault), mockClient(), options, "books", &buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  creationTimestamp: null
+  name: books.books.svc.cluster.local
+  namespace: linkerd
+spec:
+  routes:
+  - condition:
+      all:
+      - method: POST
+      - pathRegex: /books
+    name: POST /books
+`
+		diffCompare(t, buf.String(), expected)
+	})
+
+	t.Run("Finds the profiles in the cluster's DNS domain", func(t *testing.T) {
+		options := newOptions()
+		options.clusterDNSDomain = "example.com"
+
+		renamed := func(profile *sp.ServiceProfile, name string) *sp.ServiceProfile {
+			profile = profile.DeepCopy()
+			profile.Name = name
+			return profile
+		}
+		client := fake.NewSimpleClientset(
+			renamed(profile, "books.books.svc.example.com"),
+			renamed(namespaceDefault, "books.svc.example.com"),
+			renamed(clusterDefault, "svc.example.com"),
+		)
+
+		var buf bytes.Buffer
+		err := renderProfileDiff(client, mockClient(), options, "books", &buf)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompare(t, buf.String(), readOptionalTestFile(t, "profile_diff_books_defaults.golden"))
+
+		err = renderProfileDiff(fake.NewSimpleClientset(profile, namespaceDefault, clusterDefault), mockClient(), options, "books", &buf)
+		expected := "no service profile books.books.svc.example.com, and no default profile books.svc.example.com or svc.example.com, found in the linkerd namespace"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+
+	t.Run("Returns an error when the service and the defaults have no profile", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := renderProfileDiff(fake.NewSimpleClientset(), mockClient(), newOptions(), "books", &buf)
+		expected := "no service profile books.books.svc.cluster.local, and no default profile books.svc.cluster.local or svc.cluster.local, found in the linkerd namespace"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+
+	t.Run("Returns an error when too few requests are observed", func(t *testing.T) {
+		options := newOptions()
+		options.tapMinSamples = 13
+
+		var buf bytes.Buffer
+		err := renderProfileDiff(fake.NewSimpleClientset(profile), mockClient(), options, "books", &buf)
+		expected := "observed 12 requests to deploy/books in 10s, fewer than the 13 required by --tap-min-samples; try a longer --tap-duration"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+}
@@ -0,0 +1,420 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// protoFile holds the subset of a .proto file needed to generate a
+// ServiceProfile: its package and the rpcs of each service it defines.
+type protoFile struct {
+	pkg      string
+	services []*protoService
+}
+
+type protoService struct {
+	name    string
+	methods []*protoMethod
+}
+
+type protoMethod struct {
+	name            string
+	clientStreaming bool
+	serverStreaming bool
+}
+
+// protoParser is a minimal recursive descent parser for .proto files. It
+// understands packages, services, and rpcs; everything else (messages, enums,
+// options, imports, ...) is skipped without being interpreted.
+type protoParser struct {
+	tokens []protoToken
+	pos    int
+}
+
+type protoToken struct {
+	text string
+	line int
+}
+
+// parseProto parses the services defined in a proto2 or proto3 file.
+func parseProto(data []byte) (*protoFile, error) {
+	tokens, err := tokenizeProto(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proto file: %s", err)
+	}
+
+	p := &protoParser{tokens: tokens}
+	file, err := p.parseFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proto file: %s", err)
+	}
+
+	return file, nil
+}
+
+// tokenizeProto splits a .proto file into identifiers (including dotted full
+// names), string and number literals, and single character symbols,
+// discarding whitespace and comments.
+func tokenizeProto(s string) ([]protoToken, error) {
+	tokens := []protoToken{}
+	line := 1
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+			i++
+
+		case strings.HasPrefix(s[i:], "//"):
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			comment := s[i : i+2+end+2]
+			line += strings.Count(comment, "\n")
+			i += len(comment)
+
+		case c == '"' || c == '\'':
+			start := i
+			for i++; ; i++ {
+				if i >= len(s) || s[i] == '\n' {
+					return nil, fmt.Errorf("line %d: unterminated string", line)
+				}
+				if s[i] == '\\' {
+					i++
+					continue
+				}
+				if s[i] == c {
+					i++
+					break
+				}
+			}
+			tokens = append(tokens, protoToken{s[start:i], line})
+
+		case isProtoIdentRune(rune(c)) || c == '.':
+			start := i
+			for i < len(s) && (isProtoIdentRune(rune(s[i])) || s[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, protoToken{s[start:i], line})
+
+		default:
+			tokens = append(tokens, protoToken{string(c), line})
+			i++
+		}
+	}
+
+	return tokens, nil
+}
+
+func isProtoIdentRune(r rune) bool {
+	return r == '_' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+func (p *protoParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *protoParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos].text
+}
+
+func (p *protoParser) next() string {
+	text := p.peek()
+	p.pos++
+	return text
+}
+
+func (p *protoParser) errorf(format string, args ...interface{}) error {
+	line := 0
+	if p.done() {
+		if len(p.tokens) > 0 {
+			line = p.tokens[len(p.tokens)-1].line
+		}
+	} else {
+		line = p.tokens[p.pos].line
+	}
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func (p *protoParser) expect(text string) error {
+	if p.done() {
+		return p.errorf("expected %q, got end of file", text)
+	}
+	if got := p.peek(); got != text {
+		return p.errorf("expected %q, got %q", text, got)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *protoParser) ident() (string, error) {
+	if p.done() {
+		return "", p.errorf("expected an identifier, got end of file")
+	}
+	text := p.peek()
+	if !protoIdentRegex.MatchString(text) {
+		return "", p.errorf("expected an identifier, got %q", text)
+	}
+	p.pos++
+	return text, nil
+}
+
+var protoIdentRegex = regexp.MustCompile(`^\.?[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+func (p *protoParser) parseFile() (*protoFile, error) {
+	file := &protoFile{}
+
+	for !p.done() {
+		switch p.peek() {
+		case "package":
+			p.next()
+			pkg, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(";"); err != nil {
+				return nil, err
+			}
+			file.pkg = pkg
+
+		case "service":
+			p.next()
+			service, err := p.parseService()
+			if err != nil {
+				return nil, err
+			}
+			file.services = append(file.services, service)
+
+		default:
+			// syntax, import, option, message, enum, extend, and empty
+			// statements.
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return file, nil
+}
+
+func (p *protoParser) parseService() (*protoService, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	service := &protoService{name: name}
+	for {
+		switch p.peek() {
+		case "}":
+			p.next()
+			return service, nil
+
+		case "rpc":
+			p.next()
+			method, err := p.parseRPC()
+			if err != nil {
+				return nil, err
+			}
+			service.methods = append(service.methods, method)
+
+		case "":
+			return nil, p.errorf("expected \"}\", got end of file")
+
+		default:
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// parseRPC parses an rpc definition following the "rpc" keyword, of the form
+// `Name ([stream] Request) returns ([stream] Response)`, terminated by either
+// ";" or an options body.
+func (p *protoParser) parseRPC() (*protoMethod, error) {
+	name, err := p.ident()
+	if err != nil {
+		return nil, err
+	}
+	method := &protoMethod{name: name}
+
+	if method.clientStreaming, err = p.parseRPCType(); err != nil {
+		return nil, err
+	}
+	if err := p.expect("returns"); err != nil {
+		return nil, err
+	}
+	if method.serverStreaming, err = p.parseRPCType(); err != nil {
+		return nil, err
+	}
+
+	if p.peek() == ";" {
+		p.next()
+		return method, nil
+	}
+	if p.peek() != "{" {
+		return nil, p.errorf("expected \";\" or \"{\", got %q", p.peek())
+	}
+	if err := p.skipBlock(); err != nil {
+		return nil, err
+	}
+
+	return method, nil
+}
+
+// parseRPCType parses a parenthesized rpc request or response type, and
+// returns whether it is streamed.
+func (p *protoParser) parseRPCType() (bool, error) {
+	if err := p.expect("("); err != nil {
+		return false, err
+	}
+
+	// "stream" may also be the name of a message type.
+	stream := false
+	if p.peek() == "stream" && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].text != ")" {
+		p.next()
+		stream = true
+	}
+
+	if _, err := p.ident(); err != nil {
+		return false, err
+	}
+	if err := p.expect(")"); err != nil {
+		return false, err
+	}
+
+	return stream, nil
+}
+
+// skipStatement skips a statement terminated either by ";" or by a block.
+func (p *protoParser) skipStatement() error {
+	for !p.done() {
+		switch p.peek() {
+		case ";":
+			p.next()
+			return nil
+		case "{":
+			return p.skipBlock()
+		case "}":
+			return p.errorf("unexpected \"}\"")
+		}
+		p.next()
+	}
+	return p.errorf("unexpected end of file")
+}
+
+// skipBlock skips a brace-delimited block, including any nested blocks.
+func (p *protoParser) skipBlock() error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for depth := 1; depth > 0; {
+		switch p.next() {
+		case "{":
+			depth++
+		case "}":
+			depth--
+		case "":
+			return p.errorf("expected \"}\", got end of file")
+		}
+	}
+	return nil
+}
+
+// selectProtoService returns the service in the file with the given name, or
+// the file's only service if name is empty. The name may be qualified with
+// the file's package.
+func selectProtoService(file *protoFile, name string) (*protoService, error) {
+	if len(file.services) == 0 {
+		return nil, errors.New("proto file does not define any services")
+	}
+
+	if name == "" {
+		if len(file.services) > 1 {
+			names := make([]string, len(file.services))
+			for i, service := range file.services {
+				names[i] = service.name
+			}
+			return nil, fmt.Errorf("proto file defines multiple services (%s); use --service to pick one", strings.Join(names, ", "))
+		}
+		return file.services[0], nil
+	}
+
+	for _, service := range file.services {
+		if name == service.name || file.pkg != "" && name == file.pkg+"."+service.name {
+			return service, nil
+		}
+	}
+
+	return nil, fmt.Errorf("proto file does not define a service named %s", name)
+}
+
+// profileFromProto generates a ServiceProfile with a route for each rpc of a
+// gRPC service. Every rpc is a POST to /package.Service/Method, including
+// streaming rpcs; responses with a non-OK grpc-status are failures. The
+// profile is named name.
+func profileFromProto(file *protoFile, protoService *protoService, name, controlPlaneNamespace string) *sp.ServiceProfile {
+	fullName := protoService.name
+	if file.pkg != "" {
+		fullName = file.pkg + "." + protoService.name
+	}
+
+	routes := []*sp.RouteSpec{}
+	for _, method := range protoService.methods {
+		path := fmt.Sprintf("/%s/%s", fullName, method.name)
+		routes = append(routes, &sp.RouteSpec{
+			Name: path,
+			Condition: &sp.RequestMatch{
+				All: []*sp.RequestMatch{
+					{Method: "POST"},
+					{PathRegex: regexp.QuoteMeta(path)},
+				},
+			},
+			ResponseClasses: []*sp.ResponseClass{
+				{
+					Condition: &sp.ResponseMatch{
+						GRPCStatus: &sp.Range{Min: 1},
+					},
+					IsFailure: true,
+				},
+			},
+		})
+	}
+
+	return &sp.ServiceProfile{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: sp.SchemeGroupVersion.String(),
+			Kind:       "ServiceProfile",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: controlPlaneNamespace,
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: routes,
+		},
+	}
+}
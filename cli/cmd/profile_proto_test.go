@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/profiles"
+)
+
+func TestRenderProtoProfile(t *testing.T) {
+	testCases := []struct {
+		inputFileName  string
+		service        string
+		goldenFileName string
+	}{
+		{"profile_proto_books.input.proto", "", "profile_proto_books.golden"},
+		{"profile_proto_books.input.proto", "Books", "profile_proto_books.golden"},
+		{"profile_proto_books.input.proto", "books.v1.Books", "profile_proto_books.golden"},
+		{"profile_proto_multiple_services.input.proto", "Authors", "profile_proto_multiple_services_authors.golden"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.inputFileName+" "+tc.service, func(t *testing.T) {
+			data, err := ioutil.ReadFile("testdata/" + tc.inputFileName)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			options := newProfileOptions()
+			options.namespace = "books"
+			options.proto = tc.inputFileName
+			options.service = tc.service
+
+			var buf bytes.Buffer
+			if err := renderProtoProfile(bytes.NewReader(data), options, "books", &buf); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if err := profiles.ValidateYAML(buf.Bytes()); err != nil {
+				t.Fatalf("Generated an invalid service profile: %v", err)
+			}
+
+			diffCompare(t, buf.String(), readOptionalTestFile(t, tc.goldenFileName))
+		})
+	}
+
+	t.Run("Returns an error", func(t *testing.T) {
+		multiple, err := ioutil.ReadFile("testdata/profile_proto_multiple_services.input.proto")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		testCases := []struct {
+			input   string
+			service string
+			err     string
+		}{
+			{string(multiple), "", "proto file defines multiple services (Authors, Books); use --service to pick one"},
+			{string(multiple), "Publishers", "proto file does not define a service named Publishers"},
+			{`syntax = "proto3"; message Book {}`, "", "proto file does not define any services"},
+			{`service Books {}`, "", "service Books does not define any rpcs"},
+			{`service Books { rpc GetBook (Req) returns (Rsp) }`, "", "failed to parse proto file: line 1: expected \";\" or \"{\", got \"}\""},
+			{"service Books {\n  rpc GetBook (Req) returns (Rsp);\n", "", "failed to parse proto file: line 2: expected \"}\", got end of file"},
+			{`/* unterminated`, "", "failed to parse proto file: line 1: unterminated comment"},
+		}
+
+		for _, tc := range testCases {
+			options := newProfileOptions()
+			options.proto = "books.proto"
+			options.service = tc.service
+
+			var buf bytes.Buffer
+			err := renderProtoProfile(strings.NewReader(tc.input), options, "books", &buf)
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("Expected error %q, got: %v", tc.err, err)
+			}
+		}
+	})
+}
+
+func TestParseProto(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/profile_proto_books.input.proto")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	file, err := parseProto(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &protoFile{
+		pkg: "books.v1",
+		services: []*protoService{
+			{
+				name: "Books",
+				methods: []*protoMethod{
+					{name: "GetBook"},
+					{name: "ListBooks", serverStreaming: true},
+					{name: "ImportBooks", clientStreaming: true},
+					{name: "Chat", clientStreaming: true, serverStreaming: true},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(file, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, file)
+	}
+
+	t.Run("Treats stream as a type name when it is one", func(t *testing.T) {
+		file, err := parseProto([]byte(`service S { rpc M (stream) returns (stream stream); }`))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		method := file.services[0].methods[0]
+		if method.clientStreaming || !method.serverStreaming {
+			t.Fatalf("Expected only a streamed response, got %+v", method)
+		}
+	})
+}
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// profileTapMaxRps is the rate limit of the tap session opened by
+// `profile --tap`.
+const profileTapMaxRps = 100.0
+
+// observedRequest is a request seen by the tap session of `profile --tap`.
+type observedRequest struct {
+	method string
+	path   string
+}
+
+// observedRoute is a route learned from observed requests, and the number of
+// requests that it matched.
+type observedRoute struct {
+	method   string
+	template string
+	count    int
+	share    float64
+}
+
+// pathSegmentPattern is a kind of path segment that varies between requests
+// to the same route, and is collapsed into a parameter of a route template.
+type pathSegmentPattern struct {
+	param string
+	regex string
+	re    *regexp.Regexp
+}
+
+// pathSegmentPatterns are tried in order against each segment of an observed
+// path.
+var pathSegmentPatterns = []pathSegmentPattern{
+	newPathSegmentPattern("{id}", `[0-9]+`),
+	newPathSegmentPattern("{uuid}", `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`),
+}
+
+func newPathSegmentPattern(param, regex string) pathSegmentPattern {
+	return pathSegmentPattern{
+		param: param,
+		regex: regex,
+		re:    regexp.MustCompile("^" + regex + "$"),
+	}
+}
+
+func renderTapProfile(client pb.ApiClient, options *profileOptions, service string, w io.Writer) error {
+	requests, err := tapRequests(client, options.tap, options.namespace, options.tapDuration)
+	if err != nil {
+		return err
+	}
+
+	if len(requests) < options.tapMinSamples {
+		return fmt.Errorf("observed %d requests to %s in %s, fewer than the %d required by --tap-min-samples; try a longer --tap-duration",
+			len(requests), options.tap, options.tapDuration, options.tapMinSamples)
+	}
+
+	routes := clusterRoutes(requests, options.tapShareThreshold, options.tapRouteLimit)
+	if len(routes) == 0 {
+		return fmt.Errorf("none of the %d requests observed to %s belong to a route with at least %g of traffic; try a lower --tap-share-threshold",
+			len(requests), options.tap, options.tapShareThreshold)
+	}
+
+	profile := profileFromTap(routes, profiles.ServiceProfileName(service, options.namespace, options.clusterDNSDomain), controlPlaneNamespace)
+	return writeProfile(profile, w)
+}
+
+// tapRequests taps resource for duration, and returns the inbound requests
+// that were observed.
+func tapRequests(client pb.ApiClient, resource, namespace string, duration time.Duration) ([]observedRequest, error) {
+	req, err := util.BuildTapByResourceRequest(util.TapRequestParams{
+		Resource:  resource,
+		Namespace: namespace,
+		MaxRps:    profileTapMaxRps,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	rsp, err := client.TapByResource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := []observedRequest{}
+	for {
+		event, err := rsp.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				log.Debugf("Tap session ended: %s", err)
+				break
+			}
+			return nil, err
+		}
+
+		data := newTapEventTemplateData(event)
+		if data.Type != "req" || data.Direction != "in" || data.Http.Method == "" || data.Http.Path == "" {
+			continue
+		}
+		requests = append(requests, observedRequest{method: data.Http.Method, path: data.Http.Path})
+	}
+
+	return requests, nil
+}
+
+// routeTemplate collapses the segments of a request path that look like
+// identifiers into parameters, so that requests for different resources of
+// the same kind share a template. The query string is ignored.
+func routeTemplate(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		for _, pattern := range pathSegmentPatterns {
+			if pattern.re.MatchString(segment) {
+				segments[i] = pattern.param
+				break
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// templateToRegex converts a route template produced by routeTemplate into a
+// regex that matches the paths it was learned from.
+func templateToRegex(template string) string {
+	segments := strings.Split(template, "/")
+	for i, segment := range segments {
+		segments[i] = regexp.QuoteMeta(segment)
+		for _, pattern := range pathSegmentPatterns {
+			if segment == pattern.param {
+				segments[i] = pattern.regex
+				break
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// clusterRoutes groups requests by method and route template, and returns the
+// routes that received at least minShare of all requests, ordered by
+// decreasing traffic and then by name. At most limit routes are returned.
+// Requests to the remaining routes are left to the default route.
+func clusterRoutes(requests []observedRequest, minShare float64, limit int) []observedRoute {
+	byName := make(map[string]*observedRoute)
+	for _, req := range requests {
+		template := routeTemplate(req.path)
+		name := fmt.Sprintf("%s %s", req.method, template)
+		if _, ok := byName[name]; !ok {
+			byName[name] = &observedRoute{method: req.method, template: template}
+		}
+		byName[name].count++
+	}
+
+	routes := []observedRoute{}
+	for _, route := range byName {
+		route.share = float64(route.count) / float64(len(requests))
+		if route.share < minShare {
+			continue
+		}
+		routes = append(routes, *route)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].count != routes[j].count {
+			return routes[i].count > routes[j].count
+		}
+		if routes[i].template != routes[j].template {
+			return routes[i].template < routes[j].template
+		}
+		return routes[i].method < routes[j].method
+	})
+
+	if len(routes) > limit {
+		routes = routes[:limit]
+	}
+	return routes
+}
+
+func profileFromTap(routes []observedRoute, name, controlPlaneNamespace string) *sp.ServiceProfile {
+	routeSpecs := []*sp.RouteSpec{}
+	for _, route := range routes {
+		routeSpecs = append(routeSpecs, &sp.RouteSpec{
+			Name: fmt.Sprintf("%s %s", route.method, route.template),
+			Condition: &sp.RequestMatch{
+				All: []*sp.RequestMatch{
+					{Method: route.method},
+					{PathRegex: templateToRegex(route.template)},
+				},
+			},
+		})
+	}
+
+	return &sp.ServiceProfile{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: sp.SchemeGroupVersion.String(),
+			Kind:       "ServiceProfile",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: controlPlaneNamespace,
+		},
+		Spec: sp.ServiceProfileSpec{
+			Routes: routeSpecs,
+		},
+	}
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+)
+
+func TestRouteTemplate(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{"/", "/"},
+		{"/books", "/books"},
+		{"/books/", "/books/"},
+		{"/books/123", "/books/{id}"},
+		{"/books/0/edit", "/books/{id}/edit"},
+		{"/authors/7/books/42", "/authors/{id}/books/{id}"},
+		{"/books/6ba7b810-9dad-11d1-80b4-00c04fd430c8", "/books/{uuid}"},
+		{"/books/6BA7B810-9DAD-11D1-80B4-00C04FD430C8/reviews", "/books/{uuid}/reviews"},
+		{"/books/123?page=2", "/books/{id}"},
+		{"/books/123#reviews", "/books/{id}"},
+		{"/books/123abc", "/books/123abc"},
+		{"/books/-1", "/books/-1"},
+		{"/books/6ba7b810-9dad-11d1-80b4", "/books/6ba7b810-9dad-11d1-80b4"},
+		{"/v2/books", "/v2/books"},
+		{"/books/1.json", "/books/1.json"},
+	}
+
+	for _, tc := range testCases {
+		if actual := routeTemplate(tc.path); actual != tc.expected {
+			t.Errorf("routeTemplate(%q): expected %q, got %q", tc.path, tc.expected, actual)
+		}
+	}
+}
+
+func TestTemplateToRegex(t *testing.T) {
+	testCases := []struct {
+		template string
+		expected string
+	}{
+		{"/", "/"},
+		{"/books", "/books"},
+		{"/books/{id}", "/books/[0-9]+"},
+		{"/books/{uuid}/reviews", "/books/[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}/reviews"},
+		{"/books/1.json", `/books/1\.json`},
+	}
+
+	for _, tc := range testCases {
+		if actual := templateToRegex(tc.template); actual != tc.expected {
+			t.Errorf("templateToRegex(%q): expected %q, got %q", tc.template, tc.expected, actual)
+		}
+	}
+}
+
+func TestClusterRoutes(t *testing.T) {
+	// 100 requests in total.
+	requests := []observedRequest{}
+	add := func(n int, method string, paths ...string) {
+		for i := 0; i < n; i++ {
+			requests = append(requests, observedRequest{method: method, path: paths[i%len(paths)]})
+		}
+	}
+	add(40, "GET", "/books/1", "/books/2", "/books/30", "/books/400?format=json")
+	add(20, "GET", "/books")
+	add(20, "POST", "/books")
+	add(10, "GET", "/authors/6ba7b810-9dad-11d1-80b4-00c04fd430c8", "/authors/6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	add(5, "DELETE", "/books/7")
+	add(3, "GET", "/healthz")
+	add(2, "PUT", "/books/8")
+
+	expected := []observedRoute{
+		{method: "GET", template: "/books/{id}", count: 40, share: 0.4},
+		{method: "GET", template: "/books", count: 20, share: 0.2},
+		{method: "POST", template: "/books", count: 20, share: 0.2},
+		{method: "GET", template: "/authors/{uuid}", count: 10, share: 0.1},
+		{method: "DELETE", template: "/books/{id}", count: 5, share: 0.05},
+		{method: "GET", template: "/healthz", count: 3, share: 0.03},
+		{method: "PUT", template: "/books/{id}", count: 2, share: 0.02},
+	}
+
+	t.Run("Groups requests into routes ordered by traffic", func(t *testing.T) {
+		routes := clusterRoutes(requests, 0, 100)
+		if !reflect.DeepEqual(routes, expected) {
+			t.Fatalf("Expected routes:\n%+v\ngot:\n%+v", expected, routes)
+		}
+	})
+
+	t.Run("Is independent of the order of requests", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 10; i++ {
+			shuffled := make([]observedRequest, len(requests))
+			for j, k := range r.Perm(len(requests)) {
+				shuffled[j] = requests[k]
+			}
+
+			routes := clusterRoutes(shuffled, 0, 100)
+			if !reflect.DeepEqual(routes, expected) {
+				t.Fatalf("Expected routes:\n%+v\ngot:\n%+v", expected, routes)
+			}
+		}
+	})
+
+	t.Run("Leaves out routes below the share threshold", func(t *testing.T) {
+		routes := clusterRoutes(requests, 0.05, 100)
+		if !reflect.DeepEqual(routes, expected[:5]) {
+			t.Fatalf("Expected routes:\n%+v\ngot:\n%+v", expected[:5], routes)
+		}
+	})
+
+	t.Run("Keeps only the busiest routes", func(t *testing.T) {
+		routes := clusterRoutes(requests, 0, 3)
+		if !reflect.DeepEqual(routes, expected[:3]) {
+			t.Fatalf("Expected routes:\n%+v\ngot:\n%+v", expected[:3], routes)
+		}
+	})
+}
+
+func TestRenderTapProfile(t *testing.T) {
+	reqEvent := func(direction pb.TapEvent_ProxyDirection, method pb.HttpMethod_Registered, path string) pb.TapEvent {
+		return pb.TapEvent{
+			ProxyDirection: direction,
+			Event: &pb.TapEvent_Http_{
+				Http: &pb.TapEvent_Http{
+					Event: &pb.TapEvent_Http_RequestInit_{
+						RequestInit: &pb.TapEvent_Http_RequestInit{
+							Method: &pb.HttpMethod{
+								Type: &pb.HttpMethod_Registered_{Registered: method},
+							},
+							Path: path,
+						},
+					},
+				},
+			},
+		}
+	}
+	rspEvent := pb.TapEvent{
+		ProxyDirection: pb.TapEvent_INBOUND,
+		Event: &pb.TapEvent_Http_{
+			Http: &pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_ResponseInit_{
+					ResponseInit: &pb.TapEvent_Http_ResponseInit{HttpStatus: 200},
+				},
+			},
+		},
+	}
+
+	events := []pb.TapEvent{}
+	for i := 0; i < 6; i++ {
+		events = append(events, reqEvent(pb.TapEvent_INBOUND, pb.HttpMethod_GET, "/books/"+strconv.Itoa(i)), rspEvent)
+	}
+	for i := 0; i < 3; i++ {
+		events = append(events, reqEvent(pb.TapEvent_INBOUND, pb.HttpMethod_POST, "/books"), rspEvent)
+	}
+	events = append(events,
+		reqEvent(pb.TapEvent_INBOUND, pb.HttpMethod_GET, "/healthz"),
+		// Requests sent by the tapped pods aren't part of the service's profile.
+		reqEvent(pb.TapEvent_OUTBOUND, pb.HttpMethod_GET, "/authors/1"),
+		reqEvent(pb.TapEvent_OUTBOUND, pb.HttpMethod_GET, "/authors/2"),
+	)
+
+	mockClient := func() *public.MockApiClient {
+		eventsToReturn := make([]pb.TapEvent, len(events))
+		copy(eventsToReturn, events)
+		return &public.MockApiClient{
+			Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{
+				TapEventsToReturn: eventsToReturn,
+			},
+		}
+	}
+
+	t.Run("Outputs a profile of the observed routes", func(t *testing.T) {
+		options := newProfileOptions()
+		options.namespace = "books"
+		options.tap = "deploy/books"
+		options.tapShareThreshold = 0.2
+
+		var buf bytes.Buffer
+		if err := renderTapProfile(mockClient(), options, "books", &buf); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if err := profiles.ValidateYAML(buf.Bytes()); err != nil {
+			t.Fatalf("Generated an invalid service profile: %v", err)
+		}
+
+		diffCompare(t, buf.String(), readOptionalTestFile(t, "profile_tap_books.golden"))
+	})
+
+	t.Run("Returns an error when too few requests are observed", func(t *testing.T) {
+		options := newProfileOptions()
+		options.namespace = "books"
+		options.tap = "deploy/books"
+		options.tapMinSamples = 11
+
+		var buf bytes.Buffer
+		err := renderTapProfile(mockClient(), options, "books", &buf)
+		expected := "observed 10 requests to deploy/books in 5s, fewer than the 11 required by --tap-min-samples; try a longer --tap-duration"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+
+	t.Run("Returns an error when no route is above the share threshold", func(t *testing.T) {
+		options := newProfileOptions()
+		options.namespace = "books"
+		options.tap = "deploy/books"
+		options.tapShareThreshold = 0.7
+
+		var buf bytes.Buffer
+		err := renderTapProfile(mockClient(), options, "books", &buf)
+		expected := "none of the 10 requests observed to deploy/books belong to a route with at least 0.7 of traffic; try a lower --tap-share-threshold"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/profiles"
+)
+
+func TestRenderProfileTemplate(t *testing.T) {
+	options := newProfileOptions()
+	options.namespace = "emojivoto"
+	options.template = true
+
+	var buf bytes.Buffer
+	if err := renderProfileTemplate(options, "web", &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := profiles.ValidateYAML(buf.Bytes()); err != nil {
+		t.Fatalf("Template is not a valid service profile: %v", err)
+	}
+
+	diffCompare(t, buf.String(), readOptionalTestFile(t, "profile_template.golden"))
+
+	t.Run("Names the profile in the cluster's DNS domain", func(t *testing.T) {
+		options := newProfileOptions()
+		options.namespace = "emojivoto"
+		options.template = true
+		options.clusterDNSDomain = "example.com"
+
+		var buf bytes.Buffer
+		if err := renderProfileTemplate(options, "web", &buf); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if !strings.Contains(buf.String(), "name: web.emojivoto.svc.example.com\n") {
+			t.Fatalf("Expected the profile to be named web.emojivoto.svc.example.com, got:\n%s", buf.String())
+		}
+	})
+}
+
+func TestRenderOpenAPIProfile(t *testing.T) {
+	testCases := []struct {
+		inputFileName  string
+		goldenFileName string
+	}{
+		{"profile_open_api_books.input.json", "profile_open_api_books_json.golden"},
+		{"profile_open_api_books.input.yaml", "profile_open_api_books_yaml.golden"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.inputFileName, func(t *testing.T) {
+			file, err := os.Open("testdata/" + tc.inputFileName)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			defer file.Close()
+
+			options := newProfileOptions()
+			options.namespace = "books"
+			options.openAPI = tc.inputFileName
+
+			var buf bytes.Buffer
+			if err := renderOpenAPIProfile(file, options, "books", &buf); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if err := profiles.ValidateYAML(buf.Bytes()); err != nil {
+				t.Fatalf("Generated an invalid service profile: %v", err)
+			}
+
+			diffCompare(t, buf.String(), readOptionalTestFile(t, tc.goldenFileName))
+		})
+	}
+
+	t.Run("Rejects a spec with no paths", func(t *testing.T) {
+		options := newProfileOptions()
+		options.openAPI = "-"
+
+		var buf bytes.Buffer
+		err := renderOpenAPIProfile(bytes.NewBufferString(`{"swagger": "2.0"}`), options, "books", &buf)
+		if err == nil || err.Error() != "OpenAPI spec has no paths" {
+			t.Fatalf("Expected error about missing paths, got: %v", err)
+		}
+	})
+}
+
+func TestPathToRegex(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{"/books", "/books"},
+		{"/books/{id}", "/books/[^/]*"},
+		{"/books/{id}/", "/books/[^/]*/"},
+		{"/authors/{authorId}/books/{bookId}", "/authors/[^/]*/books/[^/]*"},
+		{"/books/{id}.json", `/books/[^/]*\.json`},
+		{"/v1.0/books", `/v1\.0/books`},
+		{"/", "/"},
+	}
+
+	for _, tc := range testCases {
+		if actual := pathToRegex(tc.path); actual != tc.expected {
+			t.Errorf("pathToRegex(%q): expected %q, got %q", tc.path, tc.expected, actual)
+		}
+	}
+}
+
+func TestProfileOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		template bool
+		openAPI  string
+		proto    string
+		service  string
+		valid    bool
+	}{
+		{false, "", "", "", false},
+		{true, "", "", "", true},
+		{false, "spec.json", "", "", true},
+		{true, "spec.json", "", "", false},
+		{false, "", "spec.proto", "", true},
+		{false, "", "spec.proto", "Books", true},
+		{false, "spec.json", "spec.proto", "", false},
+		{false, "spec.json", "", "Books", false},
+	}
+
+	for _, tc := range testCases {
+		options := newProfileOptions()
+		options.template = tc.template
+		options.openAPI = tc.openAPI
+		options.proto = tc.proto
+		options.service = tc.service
+
+		err := options.validate()
+		if tc.valid && err != nil {
+			t.Errorf("Expected %+v to be valid, got: %v", options, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("Expected %+v to be invalid", options)
+		}
+	}
+
+	t.Run("Validates tap options", func(t *testing.T) {
+		options := newProfileOptions()
+		options.tap = "deploy/web"
+		if err := options.validate(); err != nil {
+			t.Fatalf("Expected %+v to be valid, got: %v", options, err)
+		}
+
+		for _, invalidate := range []func(*profileOptions){
+			func(o *profileOptions) { o.template = true },
+			func(o *profileOptions) { o.tapDuration = 0 },
+			func(o *profileOptions) { o.tapRouteLimit = 0 },
+			func(o *profileOptions) { o.tapMinSamples = -1 },
+			func(o *profileOptions) { o.tapShareThreshold = 1.5 },
+		} {
+			options := newProfileOptions()
+			options.tap = "deploy/web"
+			invalidate(options)
+			if err := options.validate(); err == nil {
+				t.Errorf("Expected %+v to be invalid", options)
+			}
+		}
+	})
+}
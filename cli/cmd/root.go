@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -12,12 +13,15 @@ import (
 	"github.com/linkerd/linkerd2/pkg/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 var controlPlaneNamespace string
 var apiAddr string // An empty value means "use the Kubernetes configuration"
 var kubeconfigPath string
+var kubeContext string
 var verbose bool
+var profileStartup bool
 
 var (
 	// These regexs are not as strict as they could be, but are a quick and dirty
@@ -45,34 +49,81 @@ var RootCmd = &cobra.Command{
 
 		return nil
 	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if profileStartup {
+			startupProfile.print(os.Stderr)
+		}
+	},
 }
 
 func init() {
 	RootCmd.PersistentFlags().StringVarP(&controlPlaneNamespace, "linkerd-namespace", "l", "linkerd", "Namespace in which Linkerd is installed")
 	RootCmd.PersistentFlags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to the kubeconfig file to use for CLI requests")
+	RootCmd.PersistentFlags().StringVar(&kubeContext, "context", "", "Name of the kubeconfig context to use")
 	RootCmd.PersistentFlags().StringVar(&apiAddr, "api-addr", "", "Override kubeconfig and communicate directly with the control plane at host:port (mostly for testing)")
 	RootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Turn on debug logging")
+	RootCmd.PersistentFlags().BoolVar(&profileStartup, "profile-startup", false, "Print how long each phase of the command's startup took")
+	RootCmd.PersistentFlags().MarkHidden("profile-startup")
 
 	RootCmd.AddCommand(newCmdCheck())
 	RootCmd.AddCommand(newCmdCompletion())
 	RootCmd.AddCommand(newCmdDashboard())
+	RootCmd.AddCommand(newCmdDiagnostics())
 	RootCmd.AddCommand(newCmdGet())
+	RootCmd.AddCommand(newCmdIdentity())
 	RootCmd.AddCommand(newCmdInject())
 	RootCmd.AddCommand(newCmdInstall())
+	RootCmd.AddCommand(newCmdInstallCNI())
+	RootCmd.AddCommand(newCmdProfile())
 	RootCmd.AddCommand(newCmdStat())
 	RootCmd.AddCommand(newCmdTap())
 	RootCmd.AddCommand(newCmdVersion())
 }
 
 func newPublicAPIClient() (pb.ApiClient, error) {
-	if apiAddr != "" {
-		return public.NewInternalClient(apiAddr)
-	}
-	kubeAPI, err := k8s.NewAPI(kubeconfigPath)
-	if err != nil {
-		return nil, err
+	return newPublicAPIClientForContext(kubeContext)
+}
+
+// newKubernetesAPI builds the Kubernetes API client of the CLI's kubeconfig.
+// It is replaced in tests.
+var newKubernetesAPI = k8s.NewAPI
+
+// newPublicAPIClientForContext returns a client for the public API of the
+// control plane in the cluster of the given kubeconfig context. The client is
+// built in the background, and errors building it are returned by its first
+// RPC. The kubeconfig isn't parsed when --api-addr is set.
+func newPublicAPIClientForContext(context string) (pb.ApiClient, error) {
+	return newLazyAPIClient(func() (pb.ApiClient, error) {
+		if apiAddr != "" {
+			return public.NewInternalClient(apiAddr)
+		}
+
+		endKubeconfig := startupProfile.begin("parsing the kubeconfig")
+		kubeAPI, err := newKubernetesAPI(kubeconfigPath, context)
+		endKubeconfig()
+		if err != nil {
+			return nil, err
+		}
+
+		defer startupProfile.begin("building the API client")()
+		return public.NewExternalClient(controlPlaneNamespace, kubeAPI)
+	}), nil
+}
+
+// kubeContextFor returns the kubeconfig context that commands with a
+// --kube-context flag use: its value if set, or the --context flag's
+// otherwise.
+func kubeContextFor(override string) string {
+	if override != "" {
+		return override
 	}
-	return public.NewExternalClient(controlPlaneNamespace, kubeAPI)
+	return kubeContext
+}
+
+// addKubeContextFlag adds the --kube-context flag of the commands that query
+// the control plane, which overrides the --context flag for the command.
+func addKubeContextFlag(cmd *cobra.Command, override *string) {
+	cmd.PersistentFlags().StringVar(override, "kube-context", *override, "Name of the kubeconfig context to query, overriding --context for this command")
 }
 
 type proxyConfigOptions struct {
@@ -89,6 +140,23 @@ type proxyConfigOptions struct {
 	proxyMetricsPort      uint
 	proxyOutboundCapacity map[string]uint
 	tls                   string
+	clusterDNSDomain      string
+
+	// linkerdCNI omits the linkerd-init container from injected pods, whose
+	// rules the linkerd-cni plugin programs instead.
+	linkerdCNI bool
+
+	// disableDetectTimeout sets the timeout of the proxy's protocol
+	// detection on inbound connections to zero.
+	disableDetectTimeout bool
+
+	// proxyCPURequest maps container images to the CPU that the proxy
+	// requests in pods that run them; proxies request no CPU otherwise.
+	proxyCPURequest map[string]string
+
+	// imageDigests maps tagged image references to the digest-pinned
+	// references that replace them, when images are pinned by install.
+	imageDigests map[string]string
 }
 
 const (
@@ -111,6 +179,11 @@ func newProxyConfigOptions() *proxyConfigOptions {
 		proxyMetricsPort:      4191,
 		proxyOutboundCapacity: map[string]uint{},
 		tls: "",
+		clusterDNSDomain:      k8s.DefaultClusterDNSDomain,
+		linkerdCNI:            false,
+		disableDetectTimeout:  false,
+		proxyCPURequest:       map[string]string{},
+		imageDigests:          map[string]string{},
 	}
 }
 
@@ -121,7 +194,7 @@ func (options *proxyConfigOptions) validate() error {
 	if !alphaNumDashDotSlash.MatchString(options.dockerRegistry) {
 		return fmt.Errorf("%s is not a valid Docker registry", options.dockerRegistry)
 	}
-	if options.imagePullPolicy != "Always" && options.imagePullPolicy != "IfNotPresent" && options.imagePullPolicy != "Never" {
+	if !isValidPullPolicy(options.imagePullPolicy) {
 		return fmt.Errorf("--image-pull-policy must be one of: Always, IfNotPresent, Never")
 	}
 	if _, err := time.ParseDuration(options.proxyBindTimeout); err != nil {
@@ -130,21 +203,37 @@ func (options *proxyConfigOptions) validate() error {
 	if options.tls != "" && options.tls != optionalTLS {
 		return fmt.Errorf("--tls must be blank or set to \"%s\"", optionalTLS)
 	}
+	if errs := validation.IsDNS1123Subdomain(options.clusterDNSDomain); len(errs) > 0 {
+		return fmt.Errorf("--cluster-dns-domain has an invalid domain [%s]: %s", options.clusterDNSDomain, strings.Join(errs, ", "))
+	}
 	return nil
 }
 
+func isValidPullPolicy(policy string) bool {
+	return policy == "Always" || policy == "IfNotPresent" || policy == "Never"
+}
+
 func (options *proxyConfigOptions) enableTLS() bool {
 	return options.tls == optionalTLS
 }
 
 func (options *proxyConfigOptions) taggedProxyImage() string {
 	image := strings.Replace(options.proxyImage, defaultDockerRegistry, options.dockerRegistry, 1)
-	return fmt.Sprintf("%s:%s", image, options.linkerdVersion)
+	return options.pinnedImage(fmt.Sprintf("%s:%s", image, options.linkerdVersion))
 }
 
 func (options *proxyConfigOptions) taggedProxyInitImage() string {
 	image := strings.Replace(options.initImage, defaultDockerRegistry, options.dockerRegistry, 1)
-	return fmt.Sprintf("%s:%s", image, options.linkerdVersion)
+	return options.pinnedImage(fmt.Sprintf("%s:%s", image, options.linkerdVersion))
+}
+
+// pinnedImage returns the digest-pinned reference for image, if there is one,
+// or image itself otherwise.
+func (options *proxyConfigOptions) pinnedImage(image string) string {
+	if pinned, ok := options.imageDigests[image]; ok {
+		return pinned
+	}
+	return image
 }
 
 func addProxyConfigFlags(cmd *cobra.Command, options *proxyConfigOptions) {
@@ -160,4 +249,5 @@ func addProxyConfigFlags(cmd *cobra.Command, options *proxyConfigOptions) {
 	cmd.PersistentFlags().UintVar(&options.proxyControlPort, "control-port", options.proxyControlPort, "Proxy port to use for control")
 	cmd.PersistentFlags().UintVar(&options.proxyMetricsPort, "metrics-port", options.proxyMetricsPort, "Proxy port to serve metrics on")
 	cmd.PersistentFlags().StringVar(&options.tls, "tls", options.tls, "Enable TLS; valid settings: \"optional\"")
+	cmd.PersistentFlags().StringVar(&options.clusterDNSDomain, "cluster-dns-domain", options.clusterDNSDomain, "DNS domain of the Kubernetes cluster, which the control plane's service names and the proxies' TLS identities are in")
 }
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"text/template"
 	"time"
 
 	"github.com/linkerd/linkerd2/controller/api/util"
@@ -18,27 +20,67 @@ import (
 )
 
 type statOptions struct {
-	namespace     string
-	timeWindow    string
-	toNamespace   string
-	toResource    string
-	fromNamespace string
-	fromResource  string
-	allNamespaces bool
+	namespace          string
+	timeWindow         string
+	toNamespace        string
+	toResource         string
+	fromNamespace      string
+	fromResource       string
+	allNamespaces      bool
+	successThreshold   float64
+	includeLabel       string
+	grpcOnly           bool
+	hidePodDetails     bool
+	pageSize           uint
+	page               uint
+	minTrafficDuration string
+	authority          string
+	format             string
+	kubeContext        string
 }
 
+// successThresholdExitCode is the exit code used when one or more resources
+// have a success rate below the --success-threshold.
+const successThresholdExitCode = 1
+
 func newStatOptions() *statOptions {
 	return &statOptions{
-		namespace:     "default",
-		timeWindow:    "1m",
-		toNamespace:   "",
-		toResource:    "",
-		fromNamespace: "",
-		fromResource:  "",
-		allNamespaces: false,
+		namespace:          "default",
+		timeWindow:         "1m",
+		toNamespace:        "",
+		toResource:         "",
+		fromNamespace:      "",
+		fromResource:       "",
+		allNamespaces:      false,
+		successThreshold:   0.0,
+		includeLabel:       "",
+		grpcOnly:           false,
+		hidePodDetails:     false,
+		pageSize:           0,
+		page:               1,
+		minTrafficDuration: "",
+		authority:          "",
+		format:             "",
+		kubeContext:        "",
 	}
 }
 
+// statRowTemplateData is the view of a row of stats that is exposed to
+// templates passed via `--format`. Stats are formatted as in the standard
+// table, and are "-" for resources that received no traffic.
+type statRowTemplateData struct {
+	Namespace   string
+	Name        string
+	Label       string
+	Meshed      string
+	SuccessRate string
+	RequestRate string
+	P50         string
+	P95         string
+	P99         string
+	TLS         string
+}
+
 func newCmdStat() *cobra.Command {
 	options := newStatOptions()
 
@@ -67,6 +109,9 @@ Valid resource types include:
   * replicationcontrollers
   * authorities (not supported in --from)
   * services (only supported if a --from is also specified, or as a --to)
+  * serviceaccounts
+  * jobs
+  * cronjobs (the stats of the most recent job of the named cronjob, not supported in --from or --to)
   * all (all resource types, not supported in --from or --to)
 
 This command will hide resources that have completed, such as pods that are in the Succeeded or Failed phases.
@@ -83,6 +128,9 @@ If no resource name is specified, displays stats about all resources of the spec
   # Get all inbound stats to the web deployment.
   linkerd stat deploy/web
 
+  # Get inbound stats aggregated across all pods that run as the web service account.
+  linkerd stat sa/web -n test
+
   # Get all pods in all namespaces that call the hello1 deployment in the test namesapce.
   linkerd stat pods --to deploy/hello1 --to-namespace test --all-namespaces
 
@@ -90,28 +138,72 @@ If no resource name is specified, displays stats about all resources of the spec
   linkerd stat pods --to svc/hello1 --to-namespace test --all-namespaces
 
   # Get all services in all namespaces that receive calls from hello1 deployment in the test namesapce.
-  linkerd stat services --from deploy/hello1 --from-namespace test --all-namespaces`,
+  linkerd stat services --from deploy/hello1 --from-namespace test --all-namespaces
+
+  # Exit with a non-zero status if any deployment in the test namespace has a success rate below 99%.
+  linkerd stat deployments -n test --success-threshold 0.99
+
+  # Get the stats of the most recent run of the backup cronjob in the test namespace.
+  linkerd stat cronjob/backup -n test
+
+  # Get the web deployment's stats for each value of its pods' version label,
+  # which must be copied onto proxy metrics with "linkerd install --prometheus-pod-labels version".
+  linkerd stat deploy/web --include-label version
+
+  # Get the stats of only the gRPC traffic of each deployment in the test namespace.
+  linkerd stat deployments -n test --grpc-only
+
+  # Get the stats of all resources in the test namespace, without a row for each pod.
+  linkerd stat all -n test --hide-pod-details
+
+  # Get only the name and success rate of each deployment in the test namespace.
+  linkerd stat deployments -n test --format '{{.Name}} {{.SuccessRate}}'`,
 		Args:      cobra.RangeArgs(1, 2),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			client, err := newPublicAPIClient()
+			if _, err := parseStatFormat(options.format); err != nil {
+				return err
+			}
+
+			client, err := newPublicAPIClientForContext(kubeContextFor(options.kubeContext))
 			if err != nil {
 				return fmt.Errorf("error creating api client while making stats request: %v", err)
 			}
 
+			if options.successThreshold < 0.0 || options.successThreshold > 1.0 {
+				return fmt.Errorf("--success-threshold must be between 0.0 and 1.0, was: %v", options.successThreshold)
+			}
+
+			if options.page == 0 {
+				return fmt.Errorf("--page must be at least 1, was: %d", options.page)
+			}
+
 			req, err := buildStatSummaryRequest(args, options)
 			if err != nil {
 				return fmt.Errorf("error creating metrics request while making stats request: %v", err)
 			}
 
-			output, err := requestStatsFromAPI(client, req, options)
+			resp, err := requestStatSummaryFromAPI(client, req)
 			if err != nil {
 				return err
 			}
 
-			_, err = fmt.Print(output)
+			_, err = fmt.Print(renderStats(resp, req.Selector.Resource.Type, options))
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(os.Stderr, renderPrometheusErrors(resp))
+
+			exitCode, failed := checkSuccessThreshold(resp, options.successThreshold)
+			if exitCode != 0 {
+				fmt.Fprintf(os.Stderr, "\nSuccess rate below threshold of %.2f%% for:\n", options.successThreshold*100)
+				for _, resource := range failed {
+					fmt.Fprintf(os.Stderr, "  * %s\n", resource)
+				}
+				os.Exit(exitCode)
+			}
 
-			return err
+			return nil
 		},
 	}
 
@@ -122,24 +214,107 @@ If no resource name is specified, displays stats about all resources of the spec
 	cmd.PersistentFlags().StringVar(&options.fromResource, "from", options.fromResource, "If present, restricts outbound stats from the specified resource name")
 	cmd.PersistentFlags().StringVar(&options.fromNamespace, "from-namespace", options.fromNamespace, "Sets the namespace used from lookup the \"--from\" resource; by default the current \"--namespace\" is used")
 	cmd.PersistentFlags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, returns stats across all namespaces, ignoring the \"--namespace\" flag")
+	cmd.PersistentFlags().Float64Var(&options.successThreshold, "success-threshold", options.successThreshold, "If present, exits with a non-zero status if any resource's success rate is below this value (between 0.0 and 1.0)")
+	cmd.PersistentFlags().StringVar(&options.includeLabel, "include-label", options.includeLabel, "If present, splits each resource's stats by the value of this pod label, e.g. version; the label must be one of those given to \"linkerd install --prometheus-pod-labels\"")
+	cmd.PersistentFlags().BoolVar(&options.grpcOnly, "grpc-only", options.grpcOnly, "If present, only includes gRPC traffic in the stats, and omits resources that received none")
+	cmd.PersistentFlags().BoolVar(&options.hidePodDetails, "hide-pod-details", options.hidePodDetails, "If present, omits the row of each pod, leaving only the rows of the resources they belong to")
+	cmd.PersistentFlags().UintVar(&options.pageSize, "page-size", options.pageSize, "If present, returns at most this many resources of each type, ordered by namespace and name; by default all resources are returned")
+	cmd.PersistentFlags().UintVar(&options.page, "page", options.page, "The page of \"--page-size\" resources to return, starting at 1")
+	cmd.PersistentFlags().StringVar(&options.minTrafficDuration, "min-traffic-duration", options.minTrafficDuration, "If present, omits resources whose oldest proxy started less than this long ago (for example: \"1m\", \"10m\"), whose stats are based on too little traffic")
+	cmd.PersistentFlags().StringVar(&options.authority, "authority", options.authority, "If present, only includes requests whose :authority matches this pattern in the stats; patterns with \"*\" or \"?\" wildcards (for example: \"*.example.com\") match whole authorities, and others match authorities they prefix")
+	cmd.PersistentFlags().StringVar(&options.format, "format", options.format, "If present, renders each row with this Go template instead of the standard table; fields are .Namespace, .Name, .Label, .Meshed, .SuccessRate, .RequestRate, .P50, .P95, .P99, and .TLS")
+	addKubeContextFlag(cmd, &options.kubeContext)
 
 	return cmd
 }
 
 func requestStatsFromAPI(client pb.ApiClient, req *pb.StatSummaryRequest, options *statOptions) (string, error) {
+	resp, err := requestStatSummaryFromAPI(client, req)
+	if err != nil {
+		return "", err
+	}
+
+	return renderStats(resp, req.Selector.Resource.Type, options), nil
+}
+
+func requestStatSummaryFromAPI(client pb.ApiClient, req *pb.StatSummaryRequest) (*pb.StatSummaryResponse, error) {
 	resp, err := client.StatSummary(context.Background(), req)
 	if err != nil {
-		return "", fmt.Errorf("StatSummary API error: %v", err)
+		return nil, fmt.Errorf("StatSummary API error: %v", err)
 	}
 	if e := resp.GetError(); e != nil {
-		return "", fmt.Errorf("StatSummary API response error: %v", e.Error)
+		return nil, fmt.Errorf("StatSummary API response error: %v", e.Error)
 	}
 
-	return renderStats(resp, req.Selector.Resource.Type, options), nil
+	return resp, nil
+}
+
+// checkSuccessThreshold returns successThresholdExitCode and a description of
+// each resource whose success rate is below threshold, or 0 if there are none.
+// Resources that received no requests are not checked.
+func checkSuccessThreshold(resp *pb.StatSummaryResponse, threshold float64) (int, []string) {
+	failed := make([]string, 0)
+	for _, statTable := range resp.GetOk().GetStatTables() {
+		for _, r := range statTable.GetPodGroup().GetRows() {
+			if r.Stats == nil || r.Stats.SuccessCount+r.Stats.FailureCount == 0 {
+				continue
+			}
+
+			successRate := getSuccessRate(*r)
+			if successRate >= threshold {
+				continue
+			}
+
+			name := getNamePrefix(r.Resource.Type) + r.Resource.Name
+			if r.Resource.Namespace != "" {
+				name = r.Resource.Namespace + "/" + name
+			}
+			if r.LabelValue != "" {
+				name = fmt.Sprintf("%s [%s]", name, r.LabelValue)
+			}
+			failed = append(failed, fmt.Sprintf("%s (%.2f%%)", name, successRate*100))
+		}
+	}
+
+	if len(failed) == 0 {
+		return 0, nil
+	}
+	sort.Strings(failed)
+	return successThresholdExitCode, failed
+}
+
+// parseStatFormat validates the value of the `--format` flag. It returns a nil
+// template when the standard table should be used. Templates are executed
+// once against an empty row, so that references to unknown fields are
+// reported before any stats are requested.
+func parseStatFormat(format string) (*template.Template, error) {
+	if format == "" {
+		return nil, nil
+	}
+
+	tmpl, err := template.New("stat").Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format template: %s", err)
+	}
+	if err := tmpl.Execute(ioutil.Discard, statRowTemplateData{}); err != nil {
+		return nil, fmt.Errorf("invalid --format template: %s", err)
+	}
+
+	return tmpl, nil
 }
 
 func renderStats(resp *pb.StatSummaryResponse, resourceType string, options *statOptions) string {
 	var buffer bytes.Buffer
+
+	// Rows rendered with --format are left-aligned, and only split into
+	// columns where the template outputs tabs.
+	if options.format != "" {
+		w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', 0)
+		writeStatsToBuffer(resp, resourceType, w, options)
+		w.Flush()
+		return buffer.String()
+	}
+
 	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
 	writeStatsToBuffer(resp, resourceType, w, options)
 	w.Flush()
@@ -151,9 +326,27 @@ func renderStats(resp *pb.StatSummaryResponse, resourceType string, options *sta
 	return out
 }
 
+// renderPrometheusErrors returns a warning listing the Prometheus instances
+// that failed to answer, if any, since the stats then only include the metrics
+// of the others.
+func renderPrometheusErrors(resp *pb.StatSummaryResponse) string {
+	promErrors := resp.GetOk().GetPrometheusErrors()
+	if len(promErrors) == 0 {
+		return ""
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("\nStats are partial, these Prometheus instances failed to answer:\n")
+	for _, promErr := range promErrors {
+		fmt.Fprintf(&buffer, "  * %s: %s\n", promErr.Url, promErr.Error)
+	}
+	return buffer.String()
+}
+
 const padding = 3
 
 type rowStats struct {
+	requests    uint64
 	requestRate float64
 	successRate float64
 	tlsPercent  float64
@@ -175,6 +368,7 @@ var (
 func writeStatsToBuffer(resp *pb.StatSummaryResponse, reqResourceType string, w *tabwriter.Writer, options *statOptions) {
 	maxNameLength := len(nameHeader)
 	maxNamespaceLength := len(namespaceHeader)
+	maxLabelValueLength := len(options.includeLabel)
 	statTables := make(map[string]map[string]*row)
 
 	for _, statTable := range resp.GetOk().StatTables {
@@ -183,13 +377,24 @@ func writeStatsToBuffer(resp *pb.StatSummaryResponse, reqResourceType string, w
 		for _, r := range table.Rows {
 			name := r.Resource.Name
 			nameWithPrefix := name
-			if reqResourceType == k8s.All {
+			if reqResourceType == k8s.All || reqResourceType == k8s.CronJob {
 				nameWithPrefix = getNamePrefix(r.Resource.Type) + nameWithPrefix
 			}
 
 			namespace := r.Resource.Namespace
 			key := fmt.Sprintf("%s/%s", namespace, name)
 			resourceKey := r.Resource.Type
+			if options.hidePodDetails && resourceKey == k8s.Pod {
+				continue
+			}
+
+			// label values cannot contain "/"
+			if options.includeLabel != "" {
+				key = fmt.Sprintf("%s/%s", key, r.LabelValue)
+				if len(r.LabelValue) > maxLabelValueLength {
+					maxLabelValueLength = len(r.LabelValue)
+				}
+			}
 
 			if _, ok := statTables[resourceKey]; !ok {
 				statTables[resourceKey] = make(map[string]*row)
@@ -213,6 +418,7 @@ func writeStatsToBuffer(resp *pb.StatSummaryResponse, reqResourceType string, w
 
 			if r.Stats != nil {
 				statTables[resourceKey][key].rowStats = &rowStats{
+					requests:    r.Stats.SuccessCount + r.Stats.FailureCount,
 					requestRate: getRequestRate(*r),
 					successRate: getSuccessRate(*r),
 					tlsPercent:  getPercentTls(*r),
@@ -238,24 +444,39 @@ func writeStatsToBuffer(resp *pb.StatSummaryResponse, reqResourceType string, w
 					fmt.Fprint(w, "\n")
 				}
 				firstDisplayedStat = false
-				printStatTable(stats, resourceType, w, maxNameLength, maxNamespaceLength, options)
+				printStatTable(stats, resourceType, w, maxNameLength, maxNamespaceLength, maxLabelValueLength, options)
 			}
 		}
+	case k8s.CronJob:
+		// the stats of a CronJob are those of its most recent Job, which is
+		// named so that it's clear which run they're from
+		if stats, ok := statTables[k8s.Job]; ok {
+			printStatTable(stats, k8s.Job, w, maxNameLength, maxNamespaceLength, maxLabelValueLength, options)
+		}
 	default:
 		if stats, ok := statTables[reqResourceType]; ok {
-			printStatTable(stats, "", w, maxNameLength, maxNamespaceLength, options)
+			printStatTable(stats, "", w, maxNameLength, maxNamespaceLength, maxLabelValueLength, options)
 		}
 	}
 }
 
-func printStatTable(stats map[string]*row, resourceType string, w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, options *statOptions) {
+func printStatTable(stats map[string]*row, resourceType string, w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, maxLabelValueLength int, options *statOptions) {
+	if options.format != "" {
+		printStatTableWithFormat(stats, resourceType, w, options)
+		return
+	}
+
 	headers := make([]string, 0)
 	if options.allNamespaces {
 		headers = append(headers,
 			namespaceHeader+strings.Repeat(" ", maxNamespaceLength-len(namespaceHeader)))
 	}
+	headers = append(headers, nameHeader+strings.Repeat(" ", maxNameLength-len(nameHeader)))
+	if options.includeLabel != "" {
+		labelHeader := strings.ToUpper(options.includeLabel)
+		headers = append(headers, labelHeader+strings.Repeat(" ", maxLabelValueLength-len(labelHeader)))
+	}
 	headers = append(headers, []string{
-		nameHeader + strings.Repeat(" ", maxNameLength-len(nameHeader)),
 		"MESHED",
 		"SUCCESS",
 		"RPS",
@@ -275,37 +496,93 @@ func printStatTable(stats map[string]*row, resourceType string, w *tabwriter.Wri
 		namespace := parts[0]
 		name := namePrefix + parts[1]
 		values := make([]interface{}, 0)
-		templateString := "%s\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t%.f%%\t\n"
-		templateStringEmpty := "%s\t%s\t-\t-\t-\t-\t-\t-\t\n"
+		templateString := "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n"
 
 		if options.allNamespaces {
 			values = append(values,
 				namespace+strings.Repeat(" ", maxNamespaceLength-len(namespace)))
 			templateString = "%s\t" + templateString
-			templateStringEmpty = "%s\t" + templateStringEmpty
 		}
-		values = append(values, []interface{}{
-			name + strings.Repeat(" ", maxNameLength-len(name)),
+		values = append(values, name+strings.Repeat(" ", maxNameLength-len(name)))
+		if options.includeLabel != "" {
+			labelValue := parts[2]
+			if labelValue == "" {
+				labelValue = "-"
+			}
+			values = append(values, labelValue+strings.Repeat(" ", maxLabelValueLength-len(labelValue)))
+			templateString = "%s\t" + templateString
+		}
+
+		var data statRowTemplateData
+		data.setStats(stats[key].rowStats)
+		values = append(values,
 			stats[key].meshed,
-		}...)
-
-		if stats[key].rowStats != nil {
-			values = append(values, []interface{}{
-				stats[key].successRate * 100,
-				stats[key].requestRate,
-				stats[key].latencyP50,
-				stats[key].latencyP95,
-				stats[key].latencyP99,
-				stats[key].tlsPercent * 100,
-			}...)
-
-			fmt.Fprintf(w, templateString, values...)
-		} else {
-			fmt.Fprintf(w, templateStringEmpty, values...)
+			data.SuccessRate,
+			data.RequestRate,
+			data.P50,
+			data.P95,
+			data.P99,
+			data.TLS,
+		)
+
+		fmt.Fprintf(w, templateString, values...)
+	}
+}
+
+// printStatTableWithFormat renders each row of stats with the `--format`
+// template, which must already have been validated by parseStatFormat.
+func printStatTableWithFormat(stats map[string]*row, resourceType string, w *tabwriter.Writer, options *statOptions) {
+	tmpl := template.Must(parseStatFormat(options.format))
+	namePrefix := getNamePrefix(resourceType)
+
+	for _, key := range sortStatsKeys(stats) {
+		parts := strings.Split(key, "/")
+		data := statRowTemplateData{
+			Namespace: parts[0],
+			Name:      namePrefix + parts[1],
+			Label:     "-",
+			Meshed:    stats[key].meshed,
+		}
+		if options.includeLabel != "" && parts[2] != "" {
+			data.Label = parts[2]
+		}
+		data.setStats(stats[key].rowStats)
+
+		if err := tmpl.Execute(w, data); err != nil {
+			log.Errorf("Failed to render row [%s]: %s", key, err)
+			continue
 		}
+		fmt.Fprintln(w)
 	}
 }
 
+// setStats renders the stats of a row. Rows without stats are rendered as "-".
+// Rows without requests in the time window have no success rate, latencies or
+// TLS percentage, so their success rate is rendered as "N/A" rather than as a
+// 0.00% that reads as every request failing, and the others as "-".
+func (d *statRowTemplateData) setStats(s *rowStats) {
+	d.SuccessRate = "-"
+	d.RequestRate = "-"
+	d.P50 = "-"
+	d.P95 = "-"
+	d.P99 = "-"
+	d.TLS = "-"
+	if s == nil {
+		return
+	}
+
+	d.RequestRate = fmt.Sprintf("%.1frps", s.requestRate)
+	if s.requests == 0 {
+		d.SuccessRate = "N/A"
+		return
+	}
+	d.SuccessRate = fmt.Sprintf("%.2f%%", s.successRate*100)
+	d.P50 = fmt.Sprintf("%dms", s.latencyP50)
+	d.P95 = fmt.Sprintf("%dms", s.latencyP95)
+	d.P99 = fmt.Sprintf("%dms", s.latencyP99)
+	d.TLS = fmt.Sprintf("%.f%%", s.tlsPercent*100)
+}
+
 func getNamePrefix(resourceType string) string {
 	if resourceType == "" {
 		return ""
@@ -321,6 +598,10 @@ func buildStatSummaryRequest(resource []string, options *statOptions) (*pb.StatS
 		return nil, err
 	}
 
+	if options.hidePodDetails && target.Type == k8s.Pod {
+		return nil, fmt.Errorf("--hide-pod-details cannot be used when requesting the stats of pods")
+	}
+
 	var toRes, fromRes pb.Resource
 	if options.toResource != "" {
 		toRes, err = util.BuildResource(options.toNamespace, options.toResource)
@@ -336,17 +617,23 @@ func buildStatSummaryRequest(resource []string, options *statOptions) (*pb.StatS
 	}
 
 	requestParams := util.StatSummaryRequestParams{
-		TimeWindow:    options.timeWindow,
-		ResourceName:  target.Name,
-		ResourceType:  target.Type,
-		Namespace:     options.namespace,
-		ToName:        toRes.Name,
-		ToType:        toRes.Type,
-		ToNamespace:   options.toNamespace,
-		FromName:      fromRes.Name,
-		FromType:      fromRes.Type,
-		FromNamespace: options.fromNamespace,
-		AllNamespaces: options.allNamespaces,
+		TimeWindow:         options.timeWindow,
+		ResourceName:       target.Name,
+		ResourceType:       target.Type,
+		Namespace:          options.namespace,
+		ToName:             toRes.Name,
+		ToType:             toRes.Type,
+		ToNamespace:        options.toNamespace,
+		FromName:           fromRes.Name,
+		FromType:           fromRes.Type,
+		FromNamespace:      options.fromNamespace,
+		AllNamespaces:      options.allNamespaces,
+		IncludeLabel:       options.includeLabel,
+		GrpcOnly:           options.grpcOnly,
+		PageSize:           uint32(options.pageSize),
+		Page:               uint32(options.page),
+		MinTrafficDuration: options.minTrafficDuration,
+		Authority:          options.authority,
 	}
 
 	return util.BuildStatSummaryRequest(requestParams)
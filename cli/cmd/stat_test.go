@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 )
 
@@ -42,6 +46,179 @@ emoji      1/2   100.00%   2.0rps         123ms         123ms         123ms   10
 		}
 	})
 
+	t.Run("Renders N/A for the success rate of resources without traffic", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		response := public.GenStatSummaryResponse("emoji", k8s.Namespace, "emojivoto", &public.PodCounts{MeshedPods: 1, RunningPods: 1})
+		rows := response.GetOk().StatTables[0].GetPodGroup().Rows
+		rows[0].Stats = &pb.BasicStats{}
+
+		mockClient.StatSummaryResponseToReturn = &response
+
+		expectedOutput := `NAME    MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99   TLS
+emoji      1/1       N/A   0.0rps             -             -             -     -
+`
+
+		options := newStatOptions()
+		req, err := buildStatSummaryRequest([]string{"ns"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output, err := requestStatsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Returns a row for each value of an included label", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		response := public.GenStatSummaryResponse("web", k8s.Deployment, "emojivoto", &public.PodCounts{MeshedPods: 1, RunningPods: 1})
+		rows := &response.GetOk().StatTables[0].GetPodGroup().Rows
+		blue := *(*rows)[0]
+		blue.LabelValue = "blue"
+		green := *(*rows)[0]
+		green.LabelValue = "green"
+		*rows = []*pb.StatTable_PodGroup_Row{&green, &blue}
+
+		mockClient.StatSummaryResponseToReturn = &response
+
+		expectedOutput := `NAME   VERSION   MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+web    blue         1/1   100.00%   2.0rps         123ms         123ms         123ms   100%
+web    green        1/1   100.00%   2.0rps         123ms         123ms         123ms   100%
+`
+
+		options := newStatOptions()
+		options.includeLabel = "version"
+		args := []string{"deploy"}
+		req, err := buildStatSummaryRequest(args, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if req.IncludeLabel != "version" {
+			t.Fatalf("Expected request to include label [version], got [%s]", req.IncludeLabel)
+		}
+
+		output, err := requestStatsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Requests only gRPC stats with --grpc-only", func(t *testing.T) {
+		options := newStatOptions()
+		options.grpcOnly = true
+		req, err := buildStatSummaryRequest([]string{"deploy"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !req.GrpcOnly {
+			t.Fatalf("Expected request to only include gRPC traffic, got: %+v", req)
+		}
+	})
+
+	t.Run("Requests a page of resources with --page-size and --page", func(t *testing.T) {
+		options := newStatOptions()
+		options.pageSize = 10
+		options.page = 3
+		req, err := buildStatSummaryRequest([]string{"deploy"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if req.Limit != 10 || req.Offset != 20 {
+			t.Fatalf("Expected request to have limit 10 and offset 20, got: %+v", req)
+		}
+	})
+
+	t.Run("Returns an error for --page without --page-size", func(t *testing.T) {
+		options := newStatOptions()
+		options.page = 2
+		expectedError := "a page cannot be requested without a page size"
+
+		_, err := buildStatSummaryRequest([]string{"deploy"}, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
+	t.Run("Returns the stats of a cronjob's most recent job", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		response := public.GenStatSummaryResponse("backup-1539648000", k8s.Job, "emojivoto", &public.PodCounts{MeshedPods: 1, RunningPods: 1})
+		mockClient.StatSummaryResponseToReturn = &response
+
+		expectedOutput := `NAME                    MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+job/backup-1539648000      1/1   100.00%   2.0rps         123ms         123ms         123ms   100%
+`
+
+		options := newStatOptions()
+		req, err := buildStatSummaryRequest([]string{"cronjob/backup"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if req.Selector.Resource.Type != k8s.CronJob {
+			t.Fatalf("Expected a cronjob request, got: %+v", req)
+		}
+
+		output, err := requestStatsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Omits pod rows with --hide-pod-details", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+
+		response := public.GenStatSummaryResponse("web", k8s.Deployment, "emojivoto", &public.PodCounts{MeshedPods: 1, RunningPods: 1})
+		pods := public.GenStatSummaryResponse("web-5f7d8c9b4-x2x7k", k8s.Pod, "emojivoto", &public.PodCounts{MeshedPods: 1, RunningPods: 1})
+		response.GetOk().StatTables = append(response.GetOk().StatTables, pods.GetOk().StatTables...)
+		mockClient.StatSummaryResponseToReturn = &response
+
+		expectedOutput := `NAME         MESHED   SUCCESS      RPS   LATENCY_P50   LATENCY_P95   LATENCY_P99    TLS
+deploy/web      1/1   100.00%   2.0rps         123ms         123ms         123ms   100%
+`
+
+		options := newStatOptions()
+		options.hidePodDetails = true
+		req, err := buildStatSummaryRequest([]string{"all"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output, err := requestStatsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if output != expectedOutput {
+			t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", expectedOutput, output)
+		}
+	})
+
+	t.Run("Returns an error for pod queries with the --hide-pod-details flag", func(t *testing.T) {
+		options := newStatOptions()
+		options.hidePodDetails = true
+		expectedError := "--hide-pod-details cannot be used when requesting the stats of pods"
+
+		_, err := buildStatSummaryRequest([]string{"po"}, options)
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s] instead got [%s]", expectedError, err)
+		}
+	})
+
 	t.Run("Returns an error for named resource queries with the --all-namespaces flag", func(t *testing.T) {
 		options := newStatOptions()
 		options.allNamespaces = true
@@ -54,3 +231,180 @@ emoji      1/2   100.00%   2.0rps         123ms         123ms         123ms   10
 		}
 	})
 }
+
+func TestStatFormat(t *testing.T) {
+	response := public.GenStatSummaryResponse("web", k8s.Deployment, "emojivoto", &public.PodCounts{MeshedPods: 1, RunningPods: 2})
+	rows := &response.GetOk().StatTables[0].GetPodGroup().Rows
+	idle := *(*rows)[0]
+	idle.Resource = &pb.Resource{Namespace: "emojivoto", Type: k8s.Deployment, Name: "vote-bot"}
+	idle.Stats = nil
+	*rows = append(*rows, &idle)
+
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{
+			"{{.Name}} {{.SuccessRate}}",
+			"vote-bot -\nweb 100.00%\n",
+		},
+		{
+			"{{.Name}}\t{{.SuccessRate}}\t{{.RequestRate}}\t{{.P99}}",
+			"vote-bot   -         -        -\nweb        100.00%   2.0rps   123ms\n",
+		},
+		{
+			"{{.Namespace}}/{{.Name}}: meshed={{.Meshed}} p50={{.P50}} p95={{.P95}} tls={{.TLS}}",
+			"emojivoto/vote-bot: meshed=1/2 p50=- p95=- tls=-\nemojivoto/web: meshed=1/2 p50=123ms p95=123ms tls=100%\n",
+		},
+		{
+			`{{if ne .SuccessRate "-"}}{{.Name}}{{else}}{{.Name}} (idle){{end}}`,
+			"vote-bot (idle)\nweb\n",
+		},
+		{
+			`{{printf "%-10s|%s" .Name .Label}}`,
+			"vote-bot  |-\nweb       |-\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			mockClient := &public.MockApiClient{StatSummaryResponseToReturn: &response}
+
+			options := newStatOptions()
+			options.format = tc.format
+			req, err := buildStatSummaryRequest([]string{"deploy"}, options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			output, err := requestStatsFromAPI(mockClient, req, options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if output != tc.expected {
+				t.Fatalf("Wrong output:\n expected: \n%s\n, got: \n%s", tc.expected, output)
+			}
+		})
+	}
+}
+
+func TestSetStats(t *testing.T) {
+	testCases := []struct {
+		stats    *rowStats
+		expected statRowTemplateData
+	}{
+		{
+			nil,
+			statRowTemplateData{SuccessRate: "-", RequestRate: "-", P50: "-", P95: "-", P99: "-", TLS: "-"},
+		},
+		{
+			&rowStats{},
+			statRowTemplateData{SuccessRate: "N/A", RequestRate: "0.0rps", P50: "-", P95: "-", P99: "-", TLS: "-"},
+		},
+		{
+			&rowStats{requests: 10, requestRate: 0.2, latencyP50: 5, latencyP95: 10, latencyP99: 20},
+			statRowTemplateData{SuccessRate: "0.00%", RequestRate: "0.2rps", P50: "5ms", P95: "10ms", P99: "20ms", TLS: "0%"},
+		},
+		{
+			&rowStats{requests: 4, requestRate: 2, successRate: 0.75, tlsPercent: 1, latencyP50: 1, latencyP95: 2, latencyP99: 3},
+			statRowTemplateData{SuccessRate: "75.00%", RequestRate: "2.0rps", P50: "1ms", P95: "2ms", P99: "3ms", TLS: "100%"},
+		},
+	}
+
+	for i, tc := range testCases {
+		var data statRowTemplateData
+		data.setStats(tc.stats)
+		if data != tc.expected {
+			t.Fatalf("%d: Expected %+v, got %+v", i, tc.expected, data)
+		}
+	}
+}
+
+func TestParseStatFormat(t *testing.T) {
+	testCases := []struct {
+		format string
+		err    string
+	}{
+		{"", ""},
+		{"{{.Name}} {{.SuccessRate}}", ""},
+		{"{{.Name", "unclosed action"},
+		{"{{.Name}} {{.Latency}}", "can't evaluate field Latency"},
+	}
+
+	for _, tc := range testCases {
+		tmpl, err := parseStatFormat(tc.format)
+		if tc.err == "" {
+			if err != nil {
+				t.Errorf("Unexpected error for format [%s]: %v", tc.format, err)
+			}
+			if (tmpl == nil) != (tc.format == "") {
+				t.Errorf("Expected a template only for a non-empty format, got %v for [%s]", tmpl, tc.format)
+			}
+			continue
+		}
+		if err == nil || !strings.HasPrefix(err.Error(), "invalid --format template: ") || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("Expected error about [%s] for format [%s], got [%v]", tc.err, tc.format, err)
+		}
+	}
+}
+
+func TestRenderPrometheusErrors(t *testing.T) {
+	response := public.GenStatSummaryResponse("web", k8s.Deployment, "emojivoto", nil)
+	if warning := renderPrometheusErrors(&response); warning != "" {
+		t.Fatalf("Expected no warning, got %s", warning)
+	}
+
+	response.GetOk().PrometheusErrors = []*pb.PrometheusError{
+		&pb.PrometheusError{Url: "http://prometheus-0:9090", Error: "connection refused"},
+		&pb.PrometheusError{Url: "http://prometheus-1:9090", Error: "timeout"},
+	}
+	expectedWarning := `
+Stats are partial, these Prometheus instances failed to answer:
+  * http://prometheus-0:9090: connection refused
+  * http://prometheus-1:9090: timeout
+`
+	if warning := renderPrometheusErrors(&response); warning != expectedWarning {
+		t.Fatalf("Wrong warning:\n expected: \n%s\n, got: \n%s", expectedWarning, warning)
+	}
+}
+
+func TestCheckSuccessThreshold(t *testing.T) {
+	response := public.GenStatSummaryResponse("web", k8s.Deployment, "emojivoto", nil)
+	// 123 successes and 7 failures
+	response.GetOk().StatTables[0].GetPodGroup().Rows[0].Stats.FailureCount = 7
+
+	emptyResponse := public.GenStatSummaryResponse("voting", k8s.Deployment, "emojivoto", nil)
+	emptyResponse.GetOk().StatTables[0].GetPodGroup().Rows[0].Stats.SuccessCount = 0
+
+	labeledResponse := public.GenStatSummaryResponse("web", k8s.Deployment, "emojivoto", nil)
+	labeledResponse.GetOk().StatTables[0].GetPodGroup().Rows[0].Stats.FailureCount = 7
+	labeledResponse.GetOk().StatTables[0].GetPodGroup().Rows[0].LabelValue = "blue"
+
+	testCases := []struct {
+		response         pb.StatSummaryResponse
+		threshold        float64
+		expectedExitCode int
+		expectedFailed   []string
+	}{
+		{response, 0.0, 0, nil},
+		{response, 0.9, 0, nil},
+		{response, 123.0 / 130.0, 0, nil},
+		{response, 0.95, successThresholdExitCode, []string{"emojivoto/deploy/web (94.62%)"}},
+		{response, 1.0, successThresholdExitCode, []string{"emojivoto/deploy/web (94.62%)"}},
+		{emptyResponse, 1.0, 0, nil},
+		{labeledResponse, 1.0, successThresholdExitCode, []string{"emojivoto/deploy/web [blue] (94.62%)"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("threshold %v", tc.threshold), func(t *testing.T) {
+			exitCode, failed := checkSuccessThreshold(&tc.response, tc.threshold)
+			if exitCode != tc.expectedExitCode {
+				t.Fatalf("Expected exit code %d, got %d", tc.expectedExitCode, exitCode)
+			}
+			if !reflect.DeepEqual(failed, tc.expectedFailed) {
+				t.Fatalf("Expected failed resources %v, got %v", tc.expectedFailed, failed)
+			}
+		})
+	}
+}
@@ -1,40 +1,103 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
+	"path"
 	"strings"
+	"sync"
+	"syscall"
 	"text/tabwriter"
+	"text/template"
+	"time"
 
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/linkerd/linkerd2/controller/api/util"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/addr"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// templateOutputPrefix is the prefix of the `--output` flag value that selects
+// custom formatting of tap events with a Go template.
+const templateOutputPrefix = "template="
+
+// The delay before reconnecting an interrupted tap stream starts at
+// tapReconnectInitialBackoff and doubles after each failed attempt, up to
+// tapReconnectMaxBackoff.
+const (
+	tapReconnectInitialBackoff = time.Second
+	tapReconnectMaxBackoff     = 30 * time.Second
+)
+
+// tapWebhookTimeout bounds each request that sends tap events to the
+// `--webhook` URL.
+const tapWebhookTimeout = 10 * time.Second
+
+// Batches of tap events are sent to the `--webhook` URL at least every
+// tapWebhookFlushInterval by default, and up to tapWebhookQueueSize batches
+// wait to be sent while the webhook is slow.
+const (
+	tapWebhookFlushInterval = 5 * time.Second
+	tapWebhookQueueSize     = 16
 )
 
 type tapOptions struct {
-	namespace   string
-	toResource  string
-	toNamespace string
-	maxRps      float32
-	scheme      string
-	method      string
-	authority   string
-	path        string
+	namespace    string
+	toResource   string
+	toNamespace  string
+	maxRps       float32
+	bufferSize   uint32
+	scheme       string
+	method       string
+	authority    string
+	path         string
+	excludePaths []string
+	output       string
+	reconnect    bool
+	webhook      string
+	webhookBatch uint
+	webhookFlush time.Duration
+	webhookAuth  string
+	kubeContext  string
+
+	humanReadableLatency bool
 }
 
 func newTapOptions() *tapOptions {
 	return &tapOptions{
-		namespace:   "default",
-		toResource:  "",
-		toNamespace: "",
-		maxRps:      1.0,
-		scheme:      "",
-		method:      "",
-		authority:   "",
-		path:        "",
+		namespace:    "default",
+		toResource:   "",
+		toNamespace:  "",
+		maxRps:       1.0,
+		bufferSize:   1000,
+		scheme:       "",
+		method:       "",
+		authority:    "",
+		path:         "",
+		excludePaths: []string{},
+		output:       "",
+		reconnect:    false,
+		webhook:      "",
+		webhookBatch: 1,
+		webhookFlush: tapWebhookFlushInterval,
+		webhookAuth:  "",
+		kubeContext:  "",
+
+		humanReadableLatency: isTerminal(os.Stdout),
 	}
 }
 
@@ -69,16 +132,50 @@ func newCmdTap() *cobra.Command {
   linkerd tap pod/web-dlbvj
 
   # tap the test namespace, filter by request to prod namespace
-  linkerd tap ns/test --to ns/prod`,
+  linkerd tap ns/test --to ns/prod
+
+  # tap the web deployment, printing a custom line per event
+  linkerd tap deploy/web --output 'template={{.Type}} {{.Source.Name}} -> {{.Destination.Name}} {{.Http.Path}}'
+
+  # tap the web deployment, also posting its events to a webhook in batches of 100
+  linkerd tap deploy/web --webhook https://events.example.com/tap --webhook-batch 100`,
 		Args:      cobra.RangeArgs(1, 2),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			tmpl, err := parseTapOutputTemplate(options.output)
+			if err != nil {
+				return err
+			}
+
+			filter, err := newTapPathFilter(options.excludePaths)
+			if err != nil {
+				return err
+			}
+
+			webhook, err := newTapWebhook(options.webhook, options.webhookBatch, options.webhookFlush, options.webhookAuth, os.Stderr)
+			if err != nil {
+				return err
+			}
+			if webhook != nil {
+				// Send the events that are still waiting when the tap is
+				// interrupted. A second interrupt exits right away.
+				stop := make(chan os.Signal, 1)
+				signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+				go func() {
+					<-stop
+					signal.Stop(stop)
+					webhook.close()
+					os.Exit(0)
+				}()
+			}
+
 			requestParams := util.TapRequestParams{
 				Resource:    strings.Join(args, "/"),
 				Namespace:   options.namespace,
 				ToResource:  options.toResource,
 				ToNamespace: options.toNamespace,
 				MaxRps:      options.maxRps,
+				BufferSize:  options.bufferSize,
 				Scheme:      options.scheme,
 				Method:      options.method,
 				Authority:   options.authority,
@@ -90,12 +187,17 @@ func newCmdTap() *cobra.Command {
 				return err
 			}
 
-			client, err := newPublicAPIClient()
+			client, err := newPublicAPIClientForContext(kubeContextFor(options.kubeContext))
 			if err != nil {
 				return err
 			}
 
-			return requestTapByResourceFromAPI(os.Stdout, client, req)
+			var backoff *tapBackoff
+			if options.reconnect {
+				backoff = newTapBackoff(os.Stderr)
+			}
+
+			return requestTapByResourceFromAPI(os.Stdout, os.Stderr, client, req, tmpl, options.humanReadableLatency, filter, webhook, backoff)
 		},
 	}
 
@@ -107,6 +209,8 @@ func newCmdTap() *cobra.Command {
 		"Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
 	cmd.PersistentFlags().Float32Var(&options.maxRps, "max-rps", options.maxRps,
 		"Maximum requests per second to tap.")
+	cmd.PersistentFlags().Uint32Var(&options.bufferSize, "buffer-size", options.bufferSize,
+		"Number of events the tap server buffers while sending them; events are dropped while the buffer is full")
 	cmd.PersistentFlags().StringVar(&options.scheme, "scheme", options.scheme,
 		"Display requests with this scheme")
 	cmd.PersistentFlags().StringVar(&options.method, "method", options.method,
@@ -115,45 +219,639 @@ func newCmdTap() *cobra.Command {
 		"Display requests with this :authority")
 	cmd.PersistentFlags().StringVar(&options.path, "path", options.path,
 		"Display requests with paths that start with this prefix")
+	cmd.PersistentFlags().StringSliceVar(&options.excludePaths, "exclude-paths", options.excludePaths,
+		"Hide requests, and their responses, with paths that match any of these glob patterns, e.g. \"/healthz,/ready*\"; the query string isn't matched")
+	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output,
+		"Output format; one of: \"template=<go-template>\" (by default, the standard tap format is used)")
+	cmd.PersistentFlags().BoolVar(&options.reconnect, "reconnect", options.reconnect,
+		fmt.Sprintf("Reconnect when the tap stream is interrupted by a transient error, e.g. while the tap server restarts, backing off exponentially from %s to %s between attempts", tapReconnectInitialBackoff, tapReconnectMaxBackoff))
+	cmd.PersistentFlags().StringVar(&options.webhook, "webhook", options.webhook,
+		"URL to also send the events to, as a JSON array of events POSTed per \"--webhook-batch\" events, or every \"--webhook-flush-interval\"")
+	cmd.PersistentFlags().UintVar(&options.webhookBatch, "webhook-batch", options.webhookBatch,
+		"Number of events to send to the \"--webhook\" URL per request")
+	cmd.PersistentFlags().DurationVar(&options.webhookFlush, "webhook-flush-interval", options.webhookFlush,
+		"Maximum time to wait for a batch of \"--webhook-batch\" events before sending the events received so far")
+	cmd.PersistentFlags().StringVar(&options.webhookAuth, "webhook-auth-header", options.webhookAuth,
+		"Header, as \"Name: value\", to authenticate to the \"--webhook\" URL with, e.g. \"Authorization: Bearer <token>\"")
+	cmd.PersistentFlags().BoolVar(&options.humanReadableLatency, "human-readable-latency", options.humanReadableLatency,
+		"Display latencies and durations in the standard tap format as e.g. \"12ms\" or \"1.2s\", rather than in microseconds; by default, only when the output is a terminal")
+	addKubeContextFlag(cmd, &options.kubeContext)
 
 	return cmd
 }
 
-func requestTapByResourceFromAPI(w io.Writer, client pb.ApiClient, req *pb.TapByResourceRequest) error {
+// parseTapOutputTemplate validates the value of the `--output` flag. It
+// returns a nil template when the standard tap format should be used.
+// Templates are executed once against an empty event, so that references to
+// unknown fields are reported before the tap stream is opened.
+func parseTapOutputTemplate(output string) (*template.Template, error) {
+	if output == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(output, templateOutputPrefix) {
+		return nil, fmt.Errorf("--output must be blank or of the form \"%s<go-template>\", was: %s", templateOutputPrefix, output)
+	}
+
+	tmpl, err := template.New("tap").Option("missingkey=zero").Parse(strings.TrimPrefix(output, templateOutputPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --output template: %s", err)
+	}
+	if err := tmpl.Execute(ioutil.Discard, tapEventTemplateData{}); err != nil {
+		return nil, fmt.Errorf("invalid --output template: %s", err)
+	}
+
+	return tmpl, nil
+}
+
+// tapStreamKey identifies the HTTP stream that a tap event belongs to.
+type tapStreamKey struct {
+	base   uint32
+	stream uint64
+}
+
+// tapPathFilter hides the events of requests whose paths match any of its glob
+// patterns. Only the request's first event has its path, so the streams of the
+// hidden requests are tracked to hide their responses' events too.
+type tapPathFilter struct {
+	patterns []string
+	excluded map[tapStreamKey]bool
+}
+
+// newTapPathFilter validates the patterns of the `--exclude-paths` flag. It
+// returns a nil filter, which hides no events, when there are none.
+func newTapPathFilter(patterns []string) (*tapPathFilter, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid --exclude-paths pattern [%s]: %s", pattern, err)
+		}
+	}
+
+	return &tapPathFilter{
+		patterns: patterns,
+		excluded: map[tapStreamKey]bool{},
+	}, nil
+}
+
+// excludesPath returns true if p, without its query string, matches any of the
+// filter's patterns.
+func (f *tapPathFilter) excludesPath(p string) bool {
+	if i := strings.Index(p, "?"); i >= 0 {
+		p = p[:i]
+	}
+	for _, pattern := range f.patterns {
+		if matched, _ := path.Match(pattern, p); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// excludes returns true if event should be hidden.
+func (f *tapPathFilter) excludes(event *pb.TapEvent) bool {
+	if f == nil {
+		return false
+	}
+
+	httpEvent := event.GetHttp()
+	if init := httpEvent.GetRequestInit(); init != nil {
+		if !f.excludesPath(init.GetPath()) {
+			return false
+		}
+		f.excluded[tapStreamKey{init.GetId().GetBase(), init.GetId().GetStream()}] = true
+		return true
+	}
+	if init := httpEvent.GetResponseInit(); init != nil {
+		return f.excluded[tapStreamKey{init.GetId().GetBase(), init.GetId().GetStream()}]
+	}
+	if end := httpEvent.GetResponseEnd(); end != nil {
+		key := tapStreamKey{end.GetId().GetBase(), end.GetId().GetStream()}
+		if f.excluded[key] {
+			delete(f.excluded, key)
+			return true
+		}
+	}
+	return false
+}
+
+// tapWebhook sends tap events to a URL, as JSON arrays of up to batchSize
+// events. Events are buffered until a batch is full, until flushInterval has
+// passed, or until flush is called. Batches are sent in the background, so a
+// slow webhook doesn't hold up the tap stream; up to tapWebhookQueueSize
+// batches wait to be sent, and further batches are dropped.
+type tapWebhook struct {
+	url        string
+	batchSize  int
+	authHeader string
+	authValue  string
+	client     *http.Client
+	marshaler  jsonpb.Marshaler
+	errw       io.Writer
+
+	// mu guards batch and closed, and the queue against sends after close.
+	mu     sync.Mutex
+	batch  []json.RawMessage
+	closed bool
+
+	queue     chan []json.RawMessage
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newTapWebhook validates the `--webhook` flags, and starts sending batches
+// to the URL. It returns a nil webhook, which sends no events, when there's
+// no URL. Errors sending events are written to errw, rather than interrupting
+// the tap.
+func newTapWebhook(webhookURL string, batchSize uint, flushInterval time.Duration, authHeader string, errw io.Writer) (*tapWebhook, error) {
+	if webhookURL == "" {
+		if batchSize != 1 || flushInterval != tapWebhookFlushInterval || authHeader != "" {
+			return nil, fmt.Errorf("--webhook-batch, --webhook-flush-interval and --webhook-auth-header require --webhook")
+		}
+		return nil, nil
+	}
+
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("--webhook must be an http or https URL, was: %s", webhookURL)
+	}
+	if batchSize == 0 {
+		return nil, fmt.Errorf("--webhook-batch must be at least 1, was: %d", batchSize)
+	}
+	if flushInterval <= 0 {
+		return nil, fmt.Errorf("--webhook-flush-interval must be positive, was: %s", flushInterval)
+	}
+
+	webhook := &tapWebhook{
+		url:       webhookURL,
+		batchSize: int(batchSize),
+		client:    &http.Client{Timeout: tapWebhookTimeout},
+		marshaler: jsonpb.Marshaler{EmitDefaults: true},
+		errw:      errw,
+		queue:     make(chan []json.RawMessage, tapWebhookQueueSize),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	if authHeader != "" {
+		parts := strings.SplitN(authHeader, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("--webhook-auth-header must be of the form \"Name: value\"")
+		}
+		webhook.authHeader = strings.TrimSpace(parts[0])
+		webhook.authValue = strings.TrimSpace(parts[1])
+	}
+
+	go webhook.sendQueued()
+	go webhook.flushEvery(flushInterval)
+
+	return webhook, nil
+}
+
+// send adds event to the current batch, and queues the batch once it's full.
+func (h *tapWebhook) send(event *pb.TapEvent) {
+	if h == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.marshaler.Marshal(&buf, event); err != nil {
+		fmt.Fprintf(h.errw, "failed to encode tap event for the webhook: %s\n", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.batch = append(h.batch, json.RawMessage(buf.Bytes()))
+	if len(h.batch) >= h.batchSize {
+		h.enqueue()
+	}
+}
+
+// flush queues the events of the current batch, if any.
+func (h *tapWebhook) flush() {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.closed {
+		h.enqueue()
+	}
+}
+
+// close queues the current batch, and waits for the queued batches to be
+// sent. Events sent to the webhook after it's closed are dropped.
+func (h *tapWebhook) close() {
+	if h == nil {
+		return
+	}
+
+	h.closeOnce.Do(func() {
+		h.mu.Lock()
+		h.enqueue()
+		h.closed = true
+		close(h.queue)
+		h.mu.Unlock()
+		close(h.stop)
+	})
+	<-h.done
+}
+
+// enqueue hands the current batch, if any, to the sender, without blocking
+// the tap stream. The batch is dropped if the queue is full. h.mu must be
+// held.
+func (h *tapWebhook) enqueue() {
+	if len(h.batch) == 0 {
+		return
+	}
+
+	events := h.batch
+	h.batch = nil
+
+	select {
+	case h.queue <- events:
+	default:
+		fmt.Fprintf(h.errw, "failed to send %d tap events to the webhook: %d batches are already waiting to be sent\n", len(events), tapWebhookQueueSize)
+	}
+}
+
+// flushEvery flushes the current batch every interval, until the webhook is
+// closed, so that events aren't held back while traffic is low.
+func (h *tapWebhook) flushEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			h.flush()
+		case <-h.stop:
+			return
+		}
+	}
+}
+
+// sendQueued posts the queued batches, in order, until the webhook is closed
+// and its queue is drained.
+func (h *tapWebhook) sendQueued() {
+	defer close(h.done)
+	for events := range h.queue {
+		h.post(events)
+	}
+}
+
+// post sends a batch of events. The batch is dropped if it can't be sent.
+func (h *tapWebhook) post(events []json.RawMessage) {
+	body, err := json.Marshal(events)
+	if err != nil {
+		fmt.Fprintf(h.errw, "failed to encode %d tap events for the webhook: %s\n", len(events), err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		fmt.Fprintf(h.errw, "failed to send %d tap events to the webhook: %s\n", len(events), err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.authHeader != "" {
+		req.Header.Set(h.authHeader, h.authValue)
+	}
+
+	rsp, err := h.client.Do(req)
+	if err != nil {
+		fmt.Fprintf(h.errw, "failed to send %d tap events to the webhook: %s\n", len(events), err)
+		return
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		fmt.Fprintf(h.errw, "failed to send %d tap events to the webhook: %s\n", len(events), rsp.Status)
+	}
+}
+
+// requestTapByResourceFromAPI writes the events of a tap stream to w until the
+// stream ends, after writing the tap server that serves the stream to errw.
+// Events are written with tmpl, if it's non-nil, or in the standard tap format,
+// with human-readable latencies if humanReadableLatency is set.
+// Events that filter excludes are skipped, and the others are also sent to
+// webhook, if it's non-nil, which is closed once the stream ends, so that its
+// remaining events are sent. If the stream is interrupted by a transient error
+// and backoff is non-nil, it reconnects and carries on writing events; other
+// errors are returned. Without backoff, it prints the error to errw and
+// returns.
+func requestTapByResourceFromAPI(w io.Writer, errw io.Writer, client pb.ApiClient, req *pb.TapByResourceRequest, tmpl *template.Template, humanReadableLatency bool, filter *tapPathFilter, webhook *tapWebhook, backoff *tapBackoff) error {
+	defer webhook.close()
+
 	rsp, err := client.TapByResource(context.Background(), req)
 	if err != nil {
 		return err
 	}
-	return renderTap(w, rsp)
-}
 
-func renderTap(w io.Writer, tapClient pb.Api_TapByResourceClient) error {
 	tableWriter := tabwriter.NewWriter(w, 0, 0, 0, ' ', tabwriter.AlignRight)
-	err := writeTapEventsToBuffer(tapClient, tableWriter)
+	for {
+		writeTapConnection(errw, rsp)
+
+		received, streamErr, err := writeTapEventsToBuffer(rsp, tableWriter, errw, tmpl, humanReadableLatency, filter, webhook)
+		webhook.flush()
+		if err != nil {
+			return err
+		}
+		tableWriter.Flush()
+
+		if streamErr == nil {
+			return nil
+		}
+		if backoff == nil {
+			fmt.Fprintln(errw, streamErr)
+			return nil
+		}
+
+		if !isTransientTapError(streamErr) {
+			return streamErr
+		}
+
+		if received > 0 {
+			backoff.reset()
+		}
+		fmt.Fprintf(backoff.out, "tap stream interrupted: %s\n", streamErr)
+		rsp, err = backoff.reconnect(client, req)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// writeTapConnection writes the pod and IP of the tap server that serves
+// tapClient's stream, and the protocol it's served over. Tap servers that
+// don't report them are skipped.
+func writeTapConnection(w io.Writer, tapClient pb.Api_TapByResourceClient) {
+	header, err := tapClient.Header()
 	if err != nil {
-		return err
+		log.Debugf("Error reading the tap stream header: %s", err)
+		return
 	}
-	tableWriter.Flush()
 
-	return nil
+	pod := header[util.TapServerPodHeader]
+	ip := header[util.TapServerIPHeader]
+	protocol := header[util.TapServerProtocolHeader]
+	if len(pod) == 0 || len(ip) == 0 || len(protocol) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "Tapping via %s/%s (protocol: %s)\n", pod[0], ip[0], protocol[0])
 }
 
-func writeTapEventsToBuffer(tapClient pb.Api_TapByResourceClient, w *tabwriter.Writer) error {
+// writeTapEventsToBuffer writes events from tapClient until the stream ends,
+// and sends them to webhook. The events that the tap server dropped are
+// reported to errw.
+// It returns the number of events received and, separately from errors writing
+// them, the error that interrupted the stream, if it didn't end cleanly.
+func writeTapEventsToBuffer(tapClient pb.Api_TapByResourceClient, w *tabwriter.Writer, errw io.Writer, tmpl *template.Template, humanReadableLatency bool, filter *tapPathFilter, webhook *tapWebhook) (int, error, error) {
+	received := 0
 	for {
 		log.Debug("Waiting for data...")
 		event, err := tapClient.Recv()
 		if err == io.EOF {
-			break
+			return received, nil, nil
 		}
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			break
+			return received, err, nil
+		}
+		received++
+
+		if dropped := event.GetDroppedEvents(); dropped > 0 {
+			fmt.Fprintf(errw, "the tap server dropped %d events because its buffer was full; increase --buffer-size to keep up with bursts of requests\n", dropped)
+		}
+
+		if filter.excludes(event) {
+			continue
+		}
+		webhook.send(event)
+
+		if tmpl != nil {
+			err = renderTapEventTemplate(w, tmpl, event)
+		} else if humanReadableLatency {
+			_, err = fmt.Fprintln(w, util.RenderTapEventHumanReadable(event))
+		} else {
+			_, err = fmt.Fprintln(w, util.RenderTapEvent(event))
 		}
-		_, err = fmt.Fprintln(w, util.RenderTapEvent(event))
 		if err != nil {
-			return err
+			return received, nil, err
 		}
 	}
+}
+
+// tapBackoff reconnects interrupted tap streams, waiting exponentially longer
+// between consecutive attempts.
+type tapBackoff struct {
+	initial time.Duration
+	max     time.Duration
+	next    time.Duration
+	out     io.Writer
+	sleep   func(time.Duration)
+}
+
+func newTapBackoff(out io.Writer) *tapBackoff {
+	return &tapBackoff{
+		initial: tapReconnectInitialBackoff,
+		max:     tapReconnectMaxBackoff,
+		next:    tapReconnectInitialBackoff,
+		out:     out,
+		sleep:   time.Sleep,
+	}
+}
+
+// reset restarts the backoff from its initial delay, once a reconnected stream
+// has delivered events again.
+func (b *tapBackoff) reset() {
+	b.next = b.initial
+}
 
-	return nil
+// reconnect retries the tap request until it succeeds, printing the delay
+// before each attempt. It gives up, returning the error, once the request
+// fails with an error that retrying won't fix.
+func (b *tapBackoff) reconnect(client pb.ApiClient, req *pb.TapByResourceRequest) (pb.Api_TapByResourceClient, error) {
+	for {
+		delay := b.next
+		b.next *= 2
+		if b.next > b.max {
+			b.next = b.max
+		}
+
+		fmt.Fprintf(b.out, "reconnecting in %s…\n", delay)
+		b.sleep(delay)
+
+		rsp, err := client.TapByResource(context.Background(), req)
+		if err == nil {
+			return rsp, nil
+		}
+		if !isTransientTapError(err) {
+			return nil, err
+		}
+		fmt.Fprintf(b.out, "failed to reconnect: %s\n", err)
+	}
+}
+
+// isTransientTapError returns true if err has a gRPC status code that means
+// the tap request may succeed if it's sent again, e.g. while the tap server
+// restarts.
+func isTransientTapError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// tapEventTemplateData is the view of a TapEvent that is exposed to templates
+// passed via `--output template=<go-template>`.
+type tapEventTemplateData struct {
+	// Type is one of "req", "rsp", "end", or "unknown", matching the standard
+	// tap output.
+	Type        string
+	Direction   string
+	TLS         string
+	Source      tapEndpointTemplateData
+	Destination tapEndpointTemplateData
+	Http        tapHttpTemplateData
+}
+
+type tapEndpointTemplateData struct {
+	Address   string
+	Port      uint32
+	Namespace string
+	Name      string
+	Labels    map[string]string
+}
+
+type tapHttpTemplateData struct {
+	ID            string
+	Method        string
+	Scheme        string
+	Authority     string
+	Path          string
+	Status        uint32
+	GrpcStatus    string
+	ResetError    uint32
+	Latency       time.Duration
+	Duration      time.Duration
+	ResponseBytes uint64
+}
+
+func renderTapEventTemplate(w io.Writer, tmpl *template.Template, event *pb.TapEvent) error {
+	err := tmpl.Execute(w, newTapEventTemplateData(event))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w)
+	return err
+}
+
+func newTapEventTemplateData(event *pb.TapEvent) tapEventTemplateData {
+	data := tapEventTemplateData{
+		Type:        "unknown",
+		Source:      newTapEndpointTemplateData(event.GetSource(), event.GetSourceMeta()),
+		Destination: newTapEndpointTemplateData(event.GetDestination(), event.GetDestinationMeta()),
+	}
+
+	switch event.GetProxyDirection() {
+	case pb.TapEvent_INBOUND:
+		data.Direction = "in"
+		data.TLS = data.Source.Labels["tls"]
+	case pb.TapEvent_OUTBOUND:
+		data.Direction = "out"
+		data.TLS = data.Destination.Labels["tls"]
+	}
+
+	streamID := func(id *pb.TapEvent_Http_StreamId) string {
+		return fmt.Sprintf("%d:%d", id.GetBase(), id.GetStream())
+	}
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *pb.TapEvent_Http_RequestInit_:
+		data.Type = "req"
+		data.Http.ID = streamID(ev.RequestInit.GetId())
+		if method := ev.RequestInit.GetMethod(); method != nil {
+			data.Http.Method = method.GetRegistered().String()
+			if method.GetUnregistered() != "" {
+				data.Http.Method = method.GetUnregistered()
+			}
+		}
+		if scheme := ev.RequestInit.GetScheme(); scheme != nil {
+			data.Http.Scheme = scheme.GetRegistered().String()
+			if scheme.GetUnregistered() != "" {
+				data.Http.Scheme = scheme.GetUnregistered()
+			}
+		}
+		data.Http.Authority = ev.RequestInit.GetAuthority()
+		data.Http.Path = ev.RequestInit.GetPath()
+
+	case *pb.TapEvent_Http_ResponseInit_:
+		data.Type = "rsp"
+		data.Http.ID = streamID(ev.ResponseInit.GetId())
+		data.Http.Status = ev.ResponseInit.GetHttpStatus()
+		data.Http.Latency = toDuration(ev.ResponseInit.GetSinceRequestInit())
+
+	case *pb.TapEvent_Http_ResponseEnd_:
+		data.Type = "end"
+		data.Http.ID = streamID(ev.ResponseEnd.GetId())
+		data.Http.Duration = toDuration(ev.ResponseEnd.GetSinceResponseInit())
+		data.Http.ResponseBytes = ev.ResponseEnd.GetResponseBytes()
+
+		switch eos := ev.ResponseEnd.GetEos().GetEnd().(type) {
+		case *pb.Eos_GrpcStatusCode:
+			data.Http.GrpcStatus = codes.Code(eos.GrpcStatusCode).String()
+		case *pb.Eos_ResetErrorCode:
+			data.Http.ResetError = eos.ResetErrorCode
+		}
+	}
+
+	return data
+}
+
+func newTapEndpointTemplateData(tcpAddr *pb.TcpAddress, meta *pb.TapEvent_EndpointMeta) tapEndpointTemplateData {
+	labels := meta.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	return tapEndpointTemplateData{
+		Address:   addr.PublicAddressToString(tcpAddr),
+		Port:      tcpAddr.GetPort(),
+		Namespace: labels["namespace"],
+		Name:      labels["pod"],
+		Labels:    labels,
+	}
+}
+
+// isTerminal returns whether f is a terminal, rather than e.g. a file or a
+// pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func toDuration(d *duration.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	converted, err := ptypes.Duration(d)
+	if err != nil {
+		log.Debugf("Invalid duration in tap event: %s", err)
+		return 0
+	}
+	return converted
 }
@@ -2,10 +2,18 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/linkerd/linkerd2/controller/api/public"
@@ -13,7 +21,10 @@ import (
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/addr"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestRequestTapByResourceFromAPI(t *testing.T) {
@@ -21,17 +32,21 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		resourceType := k8s.Pod
 		targetName := "pod-666"
 		params := util.TapRequestParams{
-			Resource:  resourceType + "/" + targetName,
-			Scheme:    "https",
-			Method:    "GET",
-			Authority: "localhost",
-			Path:      "/some/path",
+			Resource:   resourceType + "/" + targetName,
+			Scheme:     "https",
+			Method:     "GET",
+			Authority:  "localhost",
+			Path:       "/some/path",
+			BufferSize: 500,
 		}
 
 		req, err := util.BuildTapByResourceRequest(params)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
+		if req.BufferSize != params.BufferSize {
+			t.Fatalf("Expected request to set buffer size [%d], got [%d]", params.BufferSize, req.BufferSize)
+		}
 
 		event1 := createEvent(
 			&pb.TapEvent_Http{
@@ -78,7 +93,7 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		}
 
 		writer := bytes.NewBufferString("")
-		err = requestTapByResourceFromAPI(writer, mockApiClient, req)
+		err = requestTapByResourceFromAPI(writer, ioutil.Discard, mockApiClient, req, nil, false, nil, nil, nil)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -116,7 +131,7 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		}
 
 		writer := bytes.NewBufferString("")
-		err = requestTapByResourceFromAPI(writer, mockApiClient, req)
+		err = requestTapByResourceFromAPI(writer, ioutil.Discard, mockApiClient, req, nil, false, nil, nil, nil)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -132,6 +147,81 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		}
 	})
 
+	t.Run("Should print the tap server to stderr when the session begins", func(t *testing.T) {
+		event := createEvent(
+			&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_RequestInit_{
+					RequestInit: &pb.TapEvent_Http_RequestInit{Path: "/some/path"},
+				},
+			},
+			map[string]string{},
+		)
+		tmpl, err := parseTapOutputTemplate("template={{.Http.Path}}")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		mockApiClient := &public.MockApiClient{}
+		mockApiClient.Api_TapByResourceClientToReturn = &public.MockApi_TapByResourceClient{
+			TapEventsToReturn: []pb.TapEvent{event},
+			HeaderToReturn: metadata.Pairs(
+				util.TapServerPodHeader, "linkerd-tap-6c9d4c5b7f-x2w8p",
+				util.TapServerIPHeader, "10.1.2.3",
+				util.TapServerProtocolHeader, "h2c",
+			),
+		}
+
+		// stdout and stderr share a buffer, to check that the line comes first
+		var out bytes.Buffer
+		err = requestTapByResourceFromAPI(&out, &out, mockApiClient, &pb.TapByResourceRequest{}, tmpl, false, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := "Tapping via linkerd-tap-6c9d4c5b7f-x2w8p/10.1.2.3 (protocol: h2c)\n/some/path\n"
+		if out.String() != expected {
+			t.Fatalf("Expected output [%q], got [%q]", expected, out.String())
+		}
+	})
+
+	t.Run("Should report the events that the tap server dropped to stderr", func(t *testing.T) {
+		event := func(path string, dropped uint64) pb.TapEvent {
+			e := createEvent(
+				&pb.TapEvent_Http{
+					Event: &pb.TapEvent_Http_RequestInit_{
+						RequestInit: &pb.TapEvent_Http_RequestInit{Path: path},
+					},
+				},
+				map[string]string{},
+			)
+			e.DroppedEvents = dropped
+			return e
+		}
+		tmpl, err := parseTapOutputTemplate("template={{.Http.Path}}")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		mockApiClient := &public.MockApiClient{}
+		mockApiClient.Api_TapByResourceClientToReturn = &public.MockApi_TapByResourceClient{
+			TapEventsToReturn: []pb.TapEvent{event("/a", 0), event("/b", 12), event("/c", 0)},
+		}
+
+		var out, errw bytes.Buffer
+		err = requestTapByResourceFromAPI(&out, &errw, mockApiClient, &pb.TapByResourceRequest{}, tmpl, false, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if out.String() != "/a\n/b\n/c\n" {
+			t.Fatalf("Expected all the events to be written, got [%q]", out.String())
+		}
+		expected := "the tap server dropped 12 events because its buffer was full; increase --buffer-size to keep up with bursts of requests\n"
+		if errw.String() != expected {
+			t.Fatalf("Expected stderr [%q], got [%q]", expected, errw.String())
+		}
+	})
+
 	t.Run("Should return error if stream returned error", func(t *testing.T) {
 		t.SkipNow()
 		resourceType := k8s.Pod
@@ -155,13 +245,152 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		}
 
 		writer := bytes.NewBufferString("")
-		err = requestTapByResourceFromAPI(writer, mockApiClient, req)
+		err = requestTapByResourceFromAPI(writer, ioutil.Discard, mockApiClient, req, nil, false, nil, nil, nil)
 		if err == nil {
 			t.Fatalf("Expecting error, got nothing but output [%s]", writer.String())
 		}
 	})
 }
 
+func TestTapOutputTemplate(t *testing.T) {
+	event := createEvent(
+		&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_RequestInit_{
+				RequestInit: &pb.TapEvent_Http_RequestInit{
+					Id: &pb.TapEvent_Http_StreamId{
+						Base:   1,
+						Stream: 2,
+					},
+					Method: &pb.HttpMethod{
+						Type: &pb.HttpMethod_Registered_{
+							Registered: pb.HttpMethod_POST,
+						},
+					},
+					Authority: "hello.default:7777",
+					Path:      "/hello.v1.HelloService/Hello",
+				},
+			},
+		},
+		map[string]string{
+			"namespace": "emojivoto",
+			"pod":       "web-1234",
+			"tls":       "true",
+		},
+	)
+	event.SourceMeta = &pb.TapEvent_EndpointMeta{
+		Labels: map[string]string{
+			"namespace":  "emojivoto",
+			"pod":        "vote-bot-5678",
+			"deployment": "vote-bot",
+		},
+	}
+
+	end := createEvent(
+		&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_ResponseEnd_{
+				ResponseEnd: &pb.TapEvent_Http_ResponseEnd{
+					Id: &pb.TapEvent_Http_StreamId{
+						Base:   1,
+						Stream: 2,
+					},
+					Eos: &pb.Eos{
+						End: &pb.Eos_GrpcStatusCode{GrpcStatusCode: uint32(codes.NotFound)},
+					},
+					SinceResponseInit: &duration.Duration{
+						Nanos: 1500000,
+					},
+					ResponseBytes: 42,
+				},
+			},
+		},
+		map[string]string{},
+	)
+
+	testCases := []struct {
+		output   string
+		events   []pb.TapEvent
+		expected string
+	}{
+		{
+			"template={{.Type}} {{.Source.Namespace}}/{{.Source.Name}} -> {{.Destination.Namespace}}/{{.Destination.Name}}",
+			[]pb.TapEvent{event},
+			"req emojivoto/vote-bot-5678 -> emojivoto/web-1234\n",
+		},
+		{
+			"template={{.Http.ID}} {{.Http.Method}} {{.Http.Authority}}{{.Http.Path}} tls={{.TLS}}",
+			[]pb.TapEvent{event},
+			"1:2 POST hello.default:7777/hello.v1.HelloService/Hello tls=true\n",
+		},
+		{
+			"template={{.Direction}} {{.Source.Address}} {{.Source.Labels.deployment}}",
+			[]pb.TapEvent{event},
+			"out 0.0.0.1:0 vote-bot\n",
+		},
+		{
+			"template={{.Type}} {{.Http.GrpcStatus}} {{.Http.Duration}} {{.Http.ResponseBytes}}B",
+			[]pb.TapEvent{event, end},
+			"req  0s 0B\nend NotFound 1.5ms 42B\n",
+		},
+		{
+			"template={{if eq .Type \"end\"}}{{.Http.ID}} done{{else}}{{.Http.ID}} started{{end}}",
+			[]pb.TapEvent{event, end},
+			"1:2 started\n1:2 done\n",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d: %s", i, tc.output), func(t *testing.T) {
+			tmpl, err := parseTapOutputTemplate(tc.output)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			mockApiClient := &public.MockApiClient{}
+			mockApiClient.Api_TapByResourceClientToReturn = &public.MockApi_TapByResourceClient{
+				TapEventsToReturn: tc.events,
+			}
+
+			writer := bytes.NewBufferString("")
+			err = requestTapByResourceFromAPI(writer, ioutil.Discard, mockApiClient, &pb.TapByResourceRequest{}, tmpl, false, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if writer.String() != tc.expected {
+				t.Fatalf("Expected output [%q], got [%q]", tc.expected, writer.String())
+			}
+		})
+	}
+
+	t.Run("Uses the standard output when no template is set", func(t *testing.T) {
+		tmpl, err := parseTapOutputTemplate("")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if tmpl != nil {
+			t.Fatalf("Expected no template, got: %v", tmpl)
+		}
+	})
+
+	t.Run("Returns an error for invalid templates", func(t *testing.T) {
+		testCases := []struct {
+			output        string
+			expectedError string
+		}{
+			{"json", "--output must be blank or of the form \"template=<go-template>\", was: json"},
+			{"template={{.Type", "invalid --output template: template: tap:1: unclosed action"},
+			{"template={{.Http.Nope}}", "invalid --output template: template: tap:1:7: executing \"tap\" at <.Http.Nope>: can't evaluate field Nope in type cmd.tapHttpTemplateData"},
+		}
+
+		for _, tc := range testCases {
+			_, err := parseTapOutputTemplate(tc.output)
+			if err == nil || err.Error() != tc.expectedError {
+				t.Fatalf("Expected error [%s], got [%v]", tc.expectedError, err)
+			}
+		}
+	})
+}
+
 func TestEventToString(t *testing.T) {
 	toTapEvent := func(httpEvent *pb.TapEvent_Http) *pb.TapEvent {
 		streamId := &pb.TapEvent_Http_StreamId{
@@ -315,6 +544,23 @@ func TestEventToString(t *testing.T) {
 		}
 	})
 
+	t.Run("Converts HTTP response init event to string with a human-readable latency", func(t *testing.T) {
+		event := toTapEvent(&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_ResponseInit_{
+				ResponseInit: &pb.TapEvent_Http_ResponseInit{
+					SinceRequestInit: &duration.Duration{Nanos: 12000000},
+					HttpStatus:       http.StatusOK,
+				},
+			},
+		})
+
+		expectedOutput := "rsp id=7:8 proxy=out src=1.2.3.4:5555 dst=2.3.4.5:6666 tls= :status=200 latency=12ms"
+		output := util.RenderTapEventHumanReadable(event)
+		if output != expectedOutput {
+			t.Fatalf("Expecting command output to be [%s], got [%s]", expectedOutput, output)
+		}
+	})
+
 	t.Run("Handles unknown event types", func(t *testing.T) {
 		event := toTapEvent(&pb.TapEvent_Http{})
 
@@ -352,3 +598,484 @@ func createEvent(event_http *pb.TapEvent_Http, dstMeta map[string]string) pb.Tap
 	}
 	return event
 }
+
+// sequencedTapClient returns the next of its streams or errors on each call
+// to TapByResource, the way a tap server that restarts between calls would.
+type sequencedTapClient struct {
+	public.MockApiClient
+	streams []pb.Api_TapByResourceClient
+	errors  []error
+	calls   int
+}
+
+func (c *sequencedTapClient) TapByResource(ctx context.Context, in *pb.TapByResourceRequest, opts ...grpc.CallOption) (pb.Api_TapByResourceClient, error) {
+	i := c.calls
+	c.calls++
+	return c.streams[i], c.errors[i]
+}
+
+func TestTapReconnect(t *testing.T) {
+	reqEvent := func(path string) pb.TapEvent {
+		return createEvent(
+			&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_RequestInit_{
+					RequestInit: &pb.TapEvent_Http_RequestInit{Path: path},
+				},
+			},
+			map[string]string{},
+		)
+	}
+
+	tmpl, err := parseTapOutputTemplate("template={{.Http.Path}}")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	newBackoff := func(out io.Writer, slept *[]time.Duration) *tapBackoff {
+		backoff := newTapBackoff(out)
+		backoff.sleep = func(d time.Duration) { *slept = append(*slept, d) }
+		return backoff
+	}
+
+	t.Run("Reconnects when the stream is interrupted", func(t *testing.T) {
+		client := &sequencedTapClient{
+			streams: []pb.Api_TapByResourceClient{
+				&public.MockApi_TapByResourceClient{
+					TapEventsToReturn: []pb.TapEvent{reqEvent("/first")},
+					ErrorsToReturn:    []error{nil, status.Error(codes.Unavailable, "unexpected EOF")},
+				},
+				nil,
+				&public.MockApi_TapByResourceClient{
+					TapEventsToReturn: []pb.TapEvent{reqEvent("/second")},
+				},
+			},
+			errors: []error{nil, status.Error(codes.Unavailable, "connection refused"), nil},
+		}
+
+		var stdout, stderr bytes.Buffer
+		var slept []time.Duration
+		err := requestTapByResourceFromAPI(&stdout, &stderr, client, &pb.TapByResourceRequest{}, tmpl, false, nil, nil, newBackoff(&stderr, &slept))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if stdout.String() != "/first\n/second\n" {
+			t.Fatalf("Expected events from both streams, got [%q]", stdout.String())
+		}
+
+		expectedStderr := "tap stream interrupted: rpc error: code = Unavailable desc = unexpected EOF\n" +
+			"reconnecting in 1s…\n" +
+			"failed to reconnect: rpc error: code = Unavailable desc = connection refused\n" +
+			"reconnecting in 2s…\n"
+		if stderr.String() != expectedStderr {
+			t.Fatalf("Expected stderr [%q], got [%q]", expectedStderr, stderr.String())
+		}
+
+		expectedSlept := []time.Duration{time.Second, 2 * time.Second}
+		if !reflect.DeepEqual(slept, expectedSlept) {
+			t.Fatalf("Expected to back off for %v, backed off for %v", expectedSlept, slept)
+		}
+	})
+
+	t.Run("Exits when the stream is interrupted without --reconnect", func(t *testing.T) {
+		client := &sequencedTapClient{
+			streams: []pb.Api_TapByResourceClient{
+				&public.MockApi_TapByResourceClient{
+					TapEventsToReturn: []pb.TapEvent{reqEvent("/first")},
+					ErrorsToReturn:    []error{nil, status.Error(codes.Unavailable, "unexpected EOF")},
+				},
+			},
+			errors: []error{nil},
+		}
+
+		var stdout bytes.Buffer
+		err := requestTapByResourceFromAPI(&stdout, ioutil.Discard, client, &pb.TapByResourceRequest{}, tmpl, false, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if stdout.String() != "/first\n" || client.calls != 1 {
+			t.Fatalf("Expected a single stream to be written, got [%q] from %d calls", stdout.String(), client.calls)
+		}
+	})
+
+	t.Run("Exits when the stream fails with an error that isn't transient", func(t *testing.T) {
+		notFound := status.Error(codes.NotFound, "deploy/nope not found")
+		client := &sequencedTapClient{
+			streams: []pb.Api_TapByResourceClient{
+				&public.MockApi_TapByResourceClient{
+					TapEventsToReturn: []pb.TapEvent{reqEvent("/first")},
+					ErrorsToReturn:    []error{nil, notFound},
+				},
+			},
+			errors: []error{nil},
+		}
+
+		var stdout bytes.Buffer
+		var slept []time.Duration
+		err := requestTapByResourceFromAPI(&stdout, ioutil.Discard, client, &pb.TapByResourceRequest{}, tmpl, false, nil, nil, newBackoff(ioutil.Discard, &slept))
+		if err != notFound {
+			t.Fatalf("Expected error [%v], got: %v", notFound, err)
+		}
+		if client.calls != 1 || len(slept) != 0 {
+			t.Fatalf("Expected no reconnect attempts, got %d calls after backing off for %v", client.calls, slept)
+		}
+	})
+
+	t.Run("Stops reconnecting when the server returns an error that isn't transient", func(t *testing.T) {
+		notFound := status.Error(codes.NotFound, "deploy/web not found")
+		client := &sequencedTapClient{
+			streams: []pb.Api_TapByResourceClient{
+				&public.MockApi_TapByResourceClient{
+					TapEventsToReturn: []pb.TapEvent{reqEvent("/first")},
+					ErrorsToReturn:    []error{nil, status.Error(codes.Unavailable, "unexpected EOF")},
+				},
+				nil,
+				nil,
+			},
+			errors: []error{nil, status.Error(codes.Unavailable, "connection refused"), notFound},
+		}
+
+		var stdout bytes.Buffer
+		var slept []time.Duration
+		err := requestTapByResourceFromAPI(&stdout, ioutil.Discard, client, &pb.TapByResourceRequest{}, tmpl, false, nil, nil, newBackoff(ioutil.Discard, &slept))
+		if err != notFound {
+			t.Fatalf("Expected error [%v], got: %v", notFound, err)
+		}
+		if client.calls != 3 {
+			t.Fatalf("Expected the command to exit after 3 calls, got %d", client.calls)
+		}
+	})
+
+	t.Run("Caps the backoff and resets it once events are received", func(t *testing.T) {
+		interrupted := func() pb.Api_TapByResourceClient {
+			return &public.MockApi_TapByResourceClient{
+				TapEventsToReturn: []pb.TapEvent{reqEvent("/")},
+				ErrorsToReturn:    []error{nil, status.Error(codes.Unavailable, "unexpected EOF")},
+			}
+		}
+		unavailable := status.Error(codes.Unavailable, "connection refused")
+
+		client := &sequencedTapClient{
+			streams: []pb.Api_TapByResourceClient{interrupted(), nil, nil, nil, nil, nil, nil, interrupted(), &public.MockApi_TapByResourceClient{}},
+			errors:  []error{nil, unavailable, unavailable, unavailable, unavailable, unavailable, unavailable, nil, nil},
+		}
+
+		var stdout, stderr bytes.Buffer
+		var slept []time.Duration
+		err := requestTapByResourceFromAPI(&stdout, &stderr, client, &pb.TapByResourceRequest{}, tmpl, false, nil, nil, newBackoff(&stderr, &slept))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedSlept := []time.Duration{
+			1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+			16 * time.Second, 30 * time.Second, 30 * time.Second,
+			1 * time.Second,
+		}
+		if !reflect.DeepEqual(slept, expectedSlept) {
+			t.Fatalf("Expected to back off for %v, backed off for %v", expectedSlept, slept)
+		}
+	})
+}
+
+func TestTapPathFilter(t *testing.T) {
+	t.Run("Matches paths against glob patterns", func(t *testing.T) {
+		testCases := []struct {
+			patterns []string
+			path     string
+			excluded bool
+		}{
+			{[]string{"/healthz"}, "/healthz", true},
+			{[]string{"/healthz"}, "/healthz?verbose=1", true},
+			{[]string{"/healthz"}, "/healthz/live", false},
+			{[]string{"/healthz"}, "/api/healthz", false},
+			{[]string{"/healthz", "/readyz"}, "/readyz", true},
+			{[]string{"/health*"}, "/health", true},
+			{[]string{"/health*"}, "/healthcheck", true},
+			{[]string{"/health*"}, "/health/live", false},
+			{[]string{"/health/*"}, "/health/live", true},
+			{[]string{"/*/ping"}, "/admin/ping", true},
+			{[]string{"/*/ping"}, "/ping", false},
+			{[]string{"/ready[yz]"}, "/readyz", true},
+			{[]string{"/ready?"}, "/ready", false},
+		}
+
+		for i, tc := range testCases {
+			filter, err := newTapPathFilter(tc.patterns)
+			if err != nil {
+				t.Fatalf("%d: Unexpected error: %v", i, err)
+			}
+			if excluded := filter.excludesPath(tc.path); excluded != tc.excluded {
+				t.Fatalf("%d: Expected %v to exclude [%s]: %t, got %t", i, tc.patterns, tc.path, tc.excluded, excluded)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid patterns", func(t *testing.T) {
+		expectedError := "invalid --exclude-paths pattern [/ready[z]: syntax error in pattern"
+		_, err := newTapPathFilter([]string{"/healthz", "/ready[z"})
+		if err == nil || err.Error() != expectedError {
+			t.Fatalf("Expected error [%s], got [%v]", expectedError, err)
+		}
+	})
+
+	t.Run("Returns a nil filter without patterns", func(t *testing.T) {
+		filter, err := newTapPathFilter([]string{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if filter != nil || filter.excludes(&pb.TapEvent{}) {
+			t.Fatalf("Expected a nil filter that excludes nothing, got %+v", filter)
+		}
+	})
+
+	t.Run("Hides the responses of excluded requests", func(t *testing.T) {
+		id := func(stream uint64) *pb.TapEvent_Http_StreamId {
+			return &pb.TapEvent_Http_StreamId{Base: 1, Stream: stream}
+		}
+		req := func(stream uint64, path string) pb.TapEvent {
+			return createEvent(&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_RequestInit_{
+					RequestInit: &pb.TapEvent_Http_RequestInit{Id: id(stream), Path: path},
+				},
+			}, map[string]string{})
+		}
+		rsp := func(stream uint64) pb.TapEvent {
+			return createEvent(&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_ResponseInit_{
+					ResponseInit: &pb.TapEvent_Http_ResponseInit{Id: id(stream), HttpStatus: 200},
+				},
+			}, map[string]string{})
+		}
+		end := func(stream uint64) pb.TapEvent {
+			return createEvent(&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_ResponseEnd_{
+					ResponseEnd: &pb.TapEvent_Http_ResponseEnd{Id: id(stream)},
+				},
+			}, map[string]string{})
+		}
+
+		mockApiClient := &public.MockApiClient{
+			Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{
+				TapEventsToReturn: []pb.TapEvent{
+					req(1, "/healthz"), req(2, "/api/vote"), rsp(1), rsp(2), end(2), end(1),
+				},
+			},
+		}
+
+		filter, err := newTapPathFilter([]string{"/healthz", "/readyz"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		tmpl, err := parseTapOutputTemplate("template={{.Type}} {{.Http.ID}} {{.Http.Path}}")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var out bytes.Buffer
+		err = requestTapByResourceFromAPI(&out, ioutil.Discard, mockApiClient, &pb.TapByResourceRequest{}, tmpl, false, filter, nil, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := "req 1:2 /api/vote\nrsp 1:2 \nend 1:2 \n"
+		if out.String() != expected {
+			t.Fatalf("Expected output [%q], got [%q]", expected, out.String())
+		}
+		if len(filter.excluded) != 0 {
+			t.Fatalf("Expected the excluded streams to be forgotten once they end, got %v", filter.excluded)
+		}
+	})
+}
+
+func TestTapWebhook(t *testing.T) {
+	requestInit := func(stream uint64, path string) pb.TapEvent {
+		return createEvent(&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_RequestInit_{
+				RequestInit: &pb.TapEvent_Http_RequestInit{
+					Id:     &pb.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+					Method: &pb.HttpMethod{Type: &pb.HttpMethod_Registered_{Registered: pb.HttpMethod_GET}},
+					Path:   path,
+				},
+			},
+		}, map[string]string{})
+	}
+
+	t.Run("Sends batches of events", func(t *testing.T) {
+		var batches []int
+		var authHeaders []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var events []map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&events); err != nil {
+				t.Errorf("Unexpected error decoding events: %v", err)
+			}
+			batches = append(batches, len(events))
+			authHeaders = append(authHeaders, req.Header.Get("Authorization"))
+		}))
+		defer server.Close()
+
+		mockApiClient := &public.MockApiClient{
+			Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{
+				TapEventsToReturn: []pb.TapEvent{
+					requestInit(1, "/a"), requestInit(2, "/healthz"), requestInit(3, "/b"), requestInit(4, "/c"), requestInit(5, "/d"), requestInit(6, "/e"),
+				},
+			},
+		}
+
+		filter, err := newTapPathFilter([]string{"/healthz"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var errw bytes.Buffer
+		webhook, err := newTapWebhook(server.URL, 2, tapWebhookFlushInterval, "Authorization: Bearer token", &errw)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var out bytes.Buffer
+		err = requestTapByResourceFromAPI(&out, ioutil.Discard, mockApiClient, &pb.TapByResourceRequest{}, nil, false, filter, webhook, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if expected := []int{2, 2, 1}; !reflect.DeepEqual(batches, expected) {
+			t.Fatalf("Expected batches of %v events, got %v", expected, batches)
+		}
+		for _, header := range authHeaders {
+			if header != "Bearer token" {
+				t.Fatalf("Expected the auth header to be [Bearer token], got [%s]", header)
+			}
+		}
+		if errw.Len() != 0 {
+			t.Fatalf("Expected no errors, got [%s]", errw.String())
+		}
+		if lines := strings.Count(out.String(), "\n"); lines != 5 {
+			t.Fatalf("Expected the events to still be printed, got:\n%s", out.String())
+		}
+	})
+
+	t.Run("Reports failures to send events", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		var errw bytes.Buffer
+		webhook, err := newTapWebhook(server.URL, 1, tapWebhookFlushInterval, "", &errw)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		event := requestInit(1, "/a")
+		webhook.send(&event)
+		webhook.close()
+
+		expected := "failed to send 1 tap events to the webhook: 503 Service Unavailable\n"
+		if errw.String() != expected {
+			t.Fatalf("Expected error [%s], got [%s]", expected, errw.String())
+		}
+		if len(webhook.batch) != 0 {
+			t.Fatalf("Expected the failed batch to be dropped, got %v", webhook.batch)
+		}
+	})
+
+	t.Run("Sends partial batches every flush interval", func(t *testing.T) {
+		batches := make(chan int, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var events []map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&events); err != nil {
+				t.Errorf("Unexpected error decoding events: %v", err)
+			}
+			batches <- len(events)
+		}))
+		defer server.Close()
+
+		webhook, err := newTapWebhook(server.URL, 10, 10*time.Millisecond, "", ioutil.Discard)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer webhook.close()
+
+		event := requestInit(1, "/a")
+		webhook.send(&event)
+
+		select {
+		case batch := <-batches:
+			if batch != 1 {
+				t.Fatalf("Expected a batch of 1 event, got %d", batch)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected the partial batch to be sent")
+		}
+	})
+
+	t.Run("Drops batches while the queue is full", func(t *testing.T) {
+		received := make(chan struct{}, tapWebhookQueueSize+2)
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			received <- struct{}{}
+			<-release
+		}))
+		defer server.Close()
+
+		var errw bytes.Buffer
+		webhook, err := newTapWebhook(server.URL, 1, tapWebhookFlushInterval, "", &errw)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		// The first batch holds up the sender, so the next ones fill the queue
+		// until the last one is dropped.
+		event := requestInit(1, "/a")
+		webhook.send(&event)
+		<-received
+		for i := 0; i < tapWebhookQueueSize+1; i++ {
+			webhook.send(&event)
+		}
+
+		expected := fmt.Sprintf("failed to send 1 tap events to the webhook: %d batches are already waiting to be sent\n", tapWebhookQueueSize)
+		if errw.String() != expected {
+			t.Fatalf("Expected error [%s], got [%s]", expected, errw.String())
+		}
+
+		close(release)
+		webhook.close()
+		if sent := len(received) + 1; sent != tapWebhookQueueSize+1 {
+			t.Fatalf("Expected %d batches to be sent, got %d", tapWebhookQueueSize+1, sent)
+		}
+	})
+
+	t.Run("Validates the webhook flags", func(t *testing.T) {
+		testCases := []struct {
+			url           string
+			batchSize     uint
+			flushInterval time.Duration
+			authHeader    string
+			valid         bool
+		}{
+			{"", 1, tapWebhookFlushInterval, "", true},
+			{"https://events.example.com/tap", 1, tapWebhookFlushInterval, "", true},
+			{"http://events.example.com/tap", 100, time.Minute, "X-Api-Key: secret", true},
+			{"events.example.com/tap", 1, tapWebhookFlushInterval, "", false},
+			{"ftp://events.example.com/tap", 1, tapWebhookFlushInterval, "", false},
+			{"https://events.example.com/tap", 0, tapWebhookFlushInterval, "", false},
+			{"https://events.example.com/tap", 1, 0, "", false},
+			{"https://events.example.com/tap", 1, tapWebhookFlushInterval, "Bearer token", false},
+			{"https://events.example.com/tap", 1, tapWebhookFlushInterval, ": token", false},
+			{"", 10, tapWebhookFlushInterval, "", false},
+			{"", 1, time.Minute, "", false},
+			{"", 1, tapWebhookFlushInterval, "Authorization: Bearer token", false},
+		}
+
+		for i, tc := range testCases {
+			webhook, err := newTapWebhook(tc.url, tc.batchSize, tc.flushInterval, tc.authHeader, ioutil.Discard)
+			webhook.close()
+			if tc.valid && err != nil {
+				t.Fatalf("%d: Unexpected error: %v", i, err)
+			}
+			if !tc.valid && err == nil {
+				t.Fatalf("%d: Expected error for %+v, got nil", i, tc)
+			}
+		}
+	})
+}
@@ -0,0 +1,54 @@
+// +build ignore
+
+// gen_dashboards.go embeds the Grafana dashboards in grafana/dashboards into
+// dashboards.go, so that `linkerd install` can render them as ConfigMaps.
+// Run it with `go generate ./cli/install` after changing a dashboard.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	dashboardsDir = "../../grafana/dashboards"
+	outputFile    = "dashboards.go"
+)
+
+func main() {
+	paths, err := filepath.Glob(filepath.Join(dashboardsDir, "*.json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	sort.Strings(paths)
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("// Code generated by gen_dashboards.go. DO NOT EDIT.\n\n")
+	buf.WriteString("package install\n\n")
+	buf.WriteString("// GrafanaDashboards holds the JSON of the bundled Grafana dashboards, by\n")
+	buf.WriteString("// name, from grafana/dashboards.\n")
+	buf.WriteString("var GrafanaDashboards = map[string]string{\n")
+	for _, path := range paths {
+		dashboard, err := ioutil.ReadFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		fmt.Fprintf(buf, "%q: %q,\n", name, dashboard)
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(outputFile, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
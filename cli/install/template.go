@@ -1,5 +1,7 @@
 package install
 
+//go:generate go run gen_dashboards.go
+
 // Template provides the base template for the `linkerd install` command.
 const Template = `### Namespace ###
 kind: Namespace
@@ -7,6 +9,22 @@ apiVersion: v1
 metadata:
   name: {{.Namespace}}
 
+### Config ###
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: {{.LinkerdConfigMapName}}
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: controller
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+data:
+  {{.LinkerdConfigGrafanaURLKey}}: {{printf "%q" .GrafanaURL}}
+  {{.LinkerdConfigPrometheusURLKey}}: {{printf "%q" .PrometheusExternalURL}}
+  {{.LinkerdConfigClusterDNSDomainKey}}: {{printf "%q" .ClusterDNSDomain}}
+
 ### Service Account Controller ###
 ---
 kind: ServiceAccount
@@ -25,8 +43,14 @@ rules:
 - apiGroups: ["extensions", "apps"]
   resources: ["deployments", "replicasets"]
   verbs: ["list", "get", "watch"]
+- apiGroups: ["batch"]
+  resources: ["jobs"]
+  verbs: ["list", "get", "watch"]
 - apiGroups: [""]
-  resources: ["pods", "endpoints", "services", "namespaces", "replicationcontrollers"]
+  resources: ["pods", "endpoints", "services", "serviceaccounts", "namespaces", "replicationcontrollers"]
+  verbs: ["list", "get", "watch"]
+- apiGroups: ["linkerd.io"]
+  resources: ["serviceprofiles"]
   verbs: ["list", "get", "watch"]
 
 ---
@@ -43,6 +67,81 @@ subjects:
   name: linkerd-controller
   namespace: {{.Namespace}}
 
+### Service Profile CRD ###
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: serviceprofiles.linkerd.io
+  labels:
+    {{.ControllerComponentLabel}}: controller
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  group: linkerd.io
+  version: v1alpha1
+  scope: Namespaced
+  names:
+    plural: serviceprofiles
+    singular: serviceprofile
+    kind: ServiceProfile
+    shortNames:
+    - sp
+  validation:
+    openAPIV3Schema:
+      properties:
+        spec:
+          required:
+          - routes
+          properties:
+            routes:
+              type: array
+              items:
+                type: object
+                required:
+                - name
+                - condition
+                properties:
+                  name:
+                    type: string
+                  condition:
+                    type: object
+                  responseClasses:
+                    type: array
+                    items:
+                      type: object
+                      required:
+                      - condition
+                      properties:
+                        condition:
+                          type: object
+                        isFailure:
+                          type: boolean
+                  isRetryable:
+                    type: boolean
+                    description: Not yet enforced, as proxies are not yet served profiles; no requests are retried.
+                  timeout:
+                    type: string
+                    description: Not yet enforced, as proxies are not yet served profiles; no requests time out.
+            retryBudget:
+              type: object
+              description: Not yet enforced, as proxies are not yet served profiles; no requests are retried.
+              required:
+              - retryRatio
+              - minRetriesPerSecond
+              - ttl
+              properties:
+                retryRatio:
+                  type: number
+                  minimum: 0
+                  maximum: 1
+                minRetriesPerSecond:
+                  type: integer
+                  minimum: 0
+                ttl:
+                  type: string
+{{- if not .PrometheusExternalURL}}
+
 ### Service Account Prometheus ###
 ---
 kind: ServiceAccount
@@ -75,8 +174,30 @@ subjects:
 - kind: ServiceAccount
   name: linkerd-prometheus
   namespace: {{.Namespace}}
+{{- end}}
 
 ### Controller ###
+{{- if .ControlPlaneInternalTLS}}
+---
+kind: Secret
+apiVersion: v1
+metadata:
+  name: {{.InternalTLSSecretName}}
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: controller
+    {{.TLSSecretLabel}}: "true"
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+type: Opaque
+stringData:
+  ca.crt: |
+{{.InternalTLSCACert}}
+  api.crt: |
+{{.InternalTLSAPICert}}
+  api.key: |
+{{.InternalTLSAPIKey}}
+{{- end}}
 ---
 kind: Service
 apiVersion: v1
@@ -95,6 +216,11 @@ spec:
   - name: http
     port: 8085
     targetPort: 8085
+  {{- if .ControlPlaneInternalTLS}}
+  - name: https
+    port: 8443
+    targetPort: 8443
+  {{- end}}
 
 ---
 kind: Service
@@ -126,7 +252,9 @@ metadata:
   annotations:
     {{.CreatedByAnnotation}}: {{.CliVersion}}
 spec:
+  {{- if not .EnableHPA}}
   replicas: {{.ControllerReplicas}}
+  {{- end}}
   template:
     metadata:
       labels:
@@ -135,20 +263,54 @@ spec:
         {{.CreatedByAnnotation}}: {{.CliVersion}}
     spec:
       serviceAccount: linkerd-controller
+      {{- if .ControlPlaneInternalTLS}}
+      volumes:
+      - name: internal-tls
+        secret:
+          secretName: {{.InternalTLSSecretName}}
+          items:
+          - key: api.crt
+            path: api.crt
+          - key: api.key
+            path: api.key
+      {{- end}}
       containers:
       - name: public-api
         ports:
         - name: http
           containerPort: 8085
+        {{- if .ControlPlaneInternalTLS}}
+        - name: https
+          containerPort: 8443
+        {{- end}}
         - name: admin-http
           containerPort: 9995
         image: {{.ControllerImage}}
         imagePullPolicy: {{.ImagePullPolicy}}
         args:
         - "public-api"
-        - "-prometheus-url=http://prometheus.{{.Namespace}}.svc.cluster.local:9090"
+        - "-prometheus-url={{.PrometheusURL}}"
         - "-controller-namespace={{.Namespace}}"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.LogFormat}}"
+        - "-heartbeat-interval={{.HeartbeatInterval}}"
+        {{- if .HeartbeatPushgatewayURL}}
+        - "-heartbeat-pushgateway-url={{.HeartbeatPushgatewayURL}}"
+        {{- end}}
+        {{- if .ControlPlaneInternalTLS}}
+        - "-tls-addr=:8443"
+        - "-tls-cert=/var/linkerd-io/internal-tls/api.crt"
+        - "-tls-key=/var/linkerd-io/internal-tls/api.key"
+        volumeMounts:
+        - name: internal-tls
+          mountPath: /var/linkerd-io/internal-tls
+          readOnly: true
+        {{- end}}
+        {{- if .EnableHPA}}
+        resources:
+          requests:
+            cpu: {{.HPACPURequest}}
+        {{- end}}
         livenessProbe:
           httpGet:
             path: /ping
@@ -170,7 +332,14 @@ spec:
         args:
         - "destination"
         - "-enable-tls={{.EnableTLS}}"
+        - "-kubernetes-dns-zone={{.ClusterDNSDomain}}"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.LogFormat}}"
+        {{- if .EnableHPA}}
+        resources:
+          requests:
+            cpu: {{.HPACPURequest}}
+        {{- end}}
         livenessProbe:
           httpGet:
             path: /ping
@@ -193,6 +362,12 @@ spec:
         - "proxy-api"
         - "-addr=:{{.ProxyAPIPort}}"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.LogFormat}}"
+        {{- if .EnableHPA}}
+        resources:
+          requests:
+            cpu: {{.HPACPURequest}}
+        {{- end}}
         livenessProbe:
           httpGet:
             path: /ping
@@ -214,6 +389,23 @@ spec:
         args:
         - "tap"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.LogFormat}}"
+        - "-pod-name=$(POD_NAME)"
+        - "-pod-ip=$(POD_IP)"
+        env:
+        - name: POD_NAME
+          valueFrom:
+            fieldRef:
+              fieldPath: metadata.name
+        - name: POD_IP
+          valueFrom:
+            fieldRef:
+              fieldPath: status.podIP
+        {{- if .EnableHPA}}
+        resources:
+          requests:
+            cpu: {{.HPACPURequest}}
+        {{- end}}
         livenessProbe:
           httpGet:
             path: /ping
@@ -224,6 +416,151 @@ spec:
             path: /ready
             port: 9998
           failureThreshold: 7
+{{- if .EnableHPA}}
+
+---
+kind: HorizontalPodAutoscaler
+apiVersion: autoscaling/v1
+metadata:
+  name: controller
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: controller
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  scaleTargetRef:
+    apiVersion: extensions/v1beta1
+    kind: Deployment
+    name: controller
+  minReplicas: {{.HPAMinReplicas}}
+  maxReplicas: {{.HPAMaxReplicas}}
+  targetCPUUtilizationPercentage: {{.HPACPUThreshold}}
+{{- end}}
+{{- if .ProfileValidation}}
+
+### Service Profile Validator ###
+---
+kind: Secret
+apiVersion: v1
+metadata:
+  name: {{.ProfileValidatorTLSSecretName}}
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: sp-validator
+    {{.TLSSecretLabel}}: "true"
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+type: Opaque
+stringData:
+  ca.crt: |
+{{.ProfileValidatorCACert}}
+  tls.crt: |
+{{.ProfileValidatorCert}}
+  tls.key: |
+{{.ProfileValidatorKey}}
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: sp-validator
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: sp-validator
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  type: ClusterIP
+  selector:
+    {{.ControllerComponentLabel}}: sp-validator
+  ports:
+  - name: https
+    port: 443
+    targetPort: 8443
+
+---
+kind: Deployment
+apiVersion: extensions/v1beta1
+metadata:
+  name: sp-validator
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: sp-validator
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  replicas: {{.ControllerReplicas}}
+  template:
+    metadata:
+      labels:
+        {{.ControllerComponentLabel}}: sp-validator
+      annotations:
+        {{.CreatedByAnnotation}}: {{.CliVersion}}
+    spec:
+      volumes:
+      - name: tls
+        secret:
+          secretName: {{.ProfileValidatorTLSSecretName}}
+          items:
+          - key: tls.crt
+            path: tls.crt
+          - key: tls.key
+            path: tls.key
+      containers:
+      - name: sp-validator
+        ports:
+        - name: https
+          containerPort: 8443
+        - name: admin-http
+          containerPort: 9994
+        image: {{.ControllerImage}}
+        imagePullPolicy: {{.ImagePullPolicy}}
+        args:
+        - "sp-validator"
+        - "-addr=:8443"
+        - "-tls-cert=/var/linkerd-io/sp-validator-tls/tls.crt"
+        - "-tls-key=/var/linkerd-io/sp-validator-tls/tls.key"
+        - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.LogFormat}}"
+        volumeMounts:
+        - name: tls
+          mountPath: /var/linkerd-io/sp-validator-tls
+          readOnly: true
+        livenessProbe:
+          httpGet:
+            path: /ping
+            port: 9994
+          initialDelaySeconds: 10
+        readinessProbe:
+          httpGet:
+            path: /ready
+            port: 9994
+          failureThreshold: 7
+
+---
+kind: ValidatingWebhookConfiguration
+apiVersion: admissionregistration.k8s.io/v1beta1
+metadata:
+  name: linkerd-{{.Namespace}}-sp-validator
+  labels:
+    {{.ControllerComponentLabel}}: sp-validator
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+webhooks:
+- name: sp-validator.linkerd.io
+  clientConfig:
+    service:
+      name: sp-validator
+      namespace: {{.Namespace}}
+      path: /
+    caBundle: {{.ProfileValidatorCABundle}}
+  rules:
+  - operations: ["CREATE", "UPDATE"]
+    apiGroups: ["linkerd.io"]
+    apiVersions: ["v1alpha1"]
+    resources: ["serviceprofiles"]
+  failurePolicy: Fail
+{{- end}}
 
 ### Web ###
 ---
@@ -267,6 +604,15 @@ spec:
       annotations:
         {{.CreatedByAnnotation}}: {{.CliVersion}}
     spec:
+      {{- if .ControlPlaneInternalTLS}}
+      volumes:
+      - name: internal-tls
+        secret:
+          secretName: {{.InternalTLSSecretName}}
+          items:
+          - key: ca.crt
+            path: ca.crt
+      {{- end}}
       containers:
       - name: web
         ports:
@@ -277,12 +623,30 @@ spec:
         image: {{.WebImage}}
         imagePullPolicy: {{.ImagePullPolicy}}
         args:
-        - "-api-addr=api.{{.Namespace}}.svc.cluster.local:8085"
+        {{- if .ControlPlaneInternalTLS}}
+        - "-api-addr=api.{{.Namespace}}.svc.{{.ClusterDNSDomain}}:8443"
+        - "-api-ca-file=/var/linkerd-io/internal-tls/ca.crt"
+        {{- else}}
+        - "-api-addr=api.{{.Namespace}}.svc.{{.ClusterDNSDomain}}:8085"
+        {{- end}}
         - "-static-dir=/dist"
-        - "-template-dir=/templates"
         - "-uuid={{.UUID}}"
         - "-controller-namespace={{.Namespace}}"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.LogFormat}}"
+        - "-grafana-url=$(GRAFANA_URL)"
+        env:
+        - name: GRAFANA_URL
+          valueFrom:
+            configMapKeyRef:
+              name: {{.LinkerdConfigMapName}}
+              key: {{.LinkerdConfigGrafanaURLKey}}
+        {{- if .ControlPlaneInternalTLS}}
+        volumeMounts:
+        - name: internal-tls
+          mountPath: /var/linkerd-io/internal-tls
+          readOnly: true
+        {{- end}}
         livenessProbe:
           httpGet:
             path: /ping
@@ -293,6 +657,7 @@ spec:
             path: /ready
             port: 9994
           failureThreshold: 7
+{{- if not .PrometheusExternalURL}}
 
 ### Prometheus ###
 ---
@@ -315,8 +680,8 @@ spec:
     targetPort: 9090
 
 ---
-kind: Deployment
-apiVersion: extensions/v1beta1
+kind: {{if .PrometheusStorageSize}}StatefulSet{{else}}Deployment{{end}}
+apiVersion: {{if .PrometheusStorageSize}}apps/v1{{else}}extensions/v1beta1{{end}}
 metadata:
   name: prometheus
   namespace: {{.Namespace}}
@@ -326,6 +691,23 @@ metadata:
     {{.CreatedByAnnotation}}: {{.CliVersion}}
 spec:
   replicas: {{.PrometheusReplicas}}
+  {{- if .PrometheusStorageSize}}
+  serviceName: prometheus
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: prometheus
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+      labels:
+        {{.ControllerComponentLabel}}: prometheus
+    spec:
+      accessModes:
+      - ReadWriteOnce
+      resources:
+        requests:
+          storage: {{.PrometheusStorageSize}}
+  {{- end}}
   template:
     metadata:
       labels:
@@ -334,10 +716,21 @@ spec:
         {{.CreatedByAnnotation}}: {{.CliVersion}}
     spec:
       serviceAccount: linkerd-prometheus
+      {{- if .PrometheusStorageSize}}
+      # the prometheus image runs as nobody, which must be able to write to the
+      # data volume
+      securityContext:
+        fsGroup: 65534
+      {{- end}}
       volumes:
       - name: prometheus-config
         configMap:
           name: prometheus-config
+      {{- if .PrometheusRemoteWritePasswordSecret}}
+      - name: remote-write-credentials
+        secret:
+          secretName: {{.PrometheusRemoteWritePasswordSecret}}
+      {{- end}}
       containers:
       - name: prometheus
         ports:
@@ -347,11 +740,36 @@ spec:
         - name: prometheus-config
           mountPath: /etc/prometheus
           readOnly: true
+        {{- if .PrometheusStorageSize}}
+        - name: data
+          mountPath: /data
+        {{- end}}
+        {{- if .PrometheusRemoteWritePasswordSecret}}
+        - name: remote-write-credentials
+          mountPath: /var/run/linkerd-io/remote-write
+          readOnly: true
+        {{- end}}
         image: {{.PrometheusImage}}
         imagePullPolicy: {{.ImagePullPolicy}}
         args:
-        - "--storage.tsdb.retention=6h"
+        - "--storage.tsdb.retention={{.PrometheusRetentionTime}}"
+        {{- if .PrometheusStorageSize}}
+        - "--storage.tsdb.path=/data"
+        {{- end}}
         - "--config.file=/etc/prometheus/prometheus.yml"
+        {{- if eq .LogFormat "json"}}
+        - "--log.format=json"
+        {{- end}}
+        {{- if or .PrometheusCPULimit .PrometheusMemoryLimit}}
+        resources:
+          limits:
+            {{- if .PrometheusCPULimit}}
+            cpu: {{.PrometheusCPULimit}}
+            {{- end}}
+            {{- if .PrometheusMemoryLimit}}
+            memory: {{.PrometheusMemoryLimit}}
+            {{- end}}
+        {{- end}}
         readinessProbe:
           httpGet:
             path: /-/ready
@@ -381,11 +799,41 @@ data:
       scrape_interval: 10s
       scrape_timeout: 10s
       evaluation_interval: 10s
+      {{- if .PrometheusExternalLabels}}
+      external_labels:
+        {{- range $name, $value := .PrometheusExternalLabels}}
+        {{$name}}: {{printf "%q" $value}}
+        {{- end}}
+      {{- end}}
+
+    rule_files:
+    - /etc/prometheus/recording_rules.yml
+
+    # To federate proxy metrics into another Prometheus, scrape this server's
+    # /federate endpoint with:
+    #   match[]: '{job="linkerd-proxy"}'
+    #   match[]: '{__name__=~".+:response_.+"}'
+    {{- if .PrometheusRemoteWriteURL}}
+
+    remote_write:
+    - url: {{printf "%q" .PrometheusRemoteWriteURL}}
+      {{- if .PrometheusRemoteWriteUsername}}
+      basic_auth:
+        username: {{printf "%q" .PrometheusRemoteWriteUsername}}
+        password_file: /var/run/linkerd-io/remote-write/password
+      {{- end}}
+      # only send proxy metrics
+      write_relabel_configs:
+      - source_labels: [job]
+        action: keep
+        regex: ^linkerd-proxy$
+    {{- end}}
 
     scrape_configs:
     - job_name: 'prometheus'
       static_configs:
       - targets: ['localhost:9090']
+    {{- if not .GrafanaURL}}
 
     - job_name: 'grafana'
       kubernetes_sd_configs:
@@ -397,6 +845,7 @@ data:
         - __meta_kubernetes_pod_container_name
         action: keep
         regex: ^grafana$
+    {{- end}}
 
     - job_name: 'linkerd-controller'
       kubernetes_sd_configs:
@@ -423,6 +872,10 @@ data:
         - __meta_kubernetes_pod_label_linkerd_io_control_plane_ns
         action: keep
         regex: ^linkerd-proxy;linkerd-metrics;{{.Namespace}}$
+      # skip pods that opt out of being scraped
+      - source_labels: [__meta_kubernetes_pod_annotation_prometheus_io_scrape]
+        action: drop
+        regex: ^false$
       - source_labels: [__meta_kubernetes_namespace]
         action: replace
         target_label: namespace
@@ -447,8 +900,44 @@ data:
       # foo=bar
       - action: labelmap
         regex: __meta_kubernetes_pod_label_linkerd_io_(.+)
+      {{- if .PrometheusPodLabelsRegex}}
+      # copy the pod labels given with --prometheus-pod-labels, for stats split
+      # by label with linkerd stat --include-label
+      # __meta_kubernetes_pod_label_version=blue =>
+      # label_version=blue
+      - action: labelmap
+        regex: {{.PrometheusPodLabelsRegex}}
+        replacement: label_$1
+      {{- end}}
+      {{- if .PrometheusDropLabelsRegex}}
+      metric_relabel_configs:
+      # drop high-cardinality labels that Linkerd does not query
+      - action: labeldrop
+        regex: {{.PrometheusDropLabelsRegex}}
+      {{- end}}
+
+  recording_rules.yml: |-
+{{.PrometheusRecordingRules}}
+{{- end}}
 
 ### Grafana ###
+{{- if not .GrafanaURL}}
+{{- if .GrafanaAuth}}
+---
+kind: Secret
+apiVersion: v1
+metadata:
+  name: {{.GrafanaAdminSecretName}}
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: grafana
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+type: Opaque
+stringData:
+  {{.GrafanaAdminUsernameKey}}: {{.GrafanaAdminUsername}}
+  {{.GrafanaAdminPasswordKey}}: {{.GrafanaAdminPassword}}
+{{- end}}
 ---
 kind: Service
 apiVersion: v1
@@ -498,6 +987,18 @@ spec:
             path: provisioning/datasources/datasources.yaml
           - key: dashboards.yaml
             path: provisioning/dashboards/dashboards.yaml
+      - name: grafana-dashboards
+        projected:
+          sources:
+          {{- range .GrafanaDashboards}}
+          - configMap:
+              name: grafana-dashboard-{{.Name}}
+          {{- end}}
+      {{- if .GrafanaExtraDashboardsConfigMap}}
+      - name: grafana-extra-dashboards
+        configMap:
+          name: {{.GrafanaExtraDashboardsConfigMap}}
+      {{- end}}
       containers:
       - name: grafana
         ports:
@@ -507,8 +1008,35 @@ spec:
         - name: grafana-config
           mountPath: /etc/grafana
           readOnly: true
+        - name: grafana-dashboards
+          mountPath: /var/lib/grafana/dashboards
+          readOnly: true
+        {{- if .GrafanaExtraDashboardsConfigMap}}
+        - name: grafana-extra-dashboards
+          mountPath: /var/lib/grafana/extra-dashboards
+          readOnly: true
+        {{- end}}
         image: {{.GrafanaImage}}
         imagePullPolicy: {{.ImagePullPolicy}}
+        {{- if or .GrafanaAuth (eq .LogFormat "json")}}
+        env:
+        {{- end}}
+        {{- if eq .LogFormat "json"}}
+        - name: GF_LOG_CONSOLE_FORMAT
+          value: json
+        {{- end}}
+        {{- if .GrafanaAuth}}
+        - name: GF_SECURITY_ADMIN_USER
+          valueFrom:
+            secretKeyRef:
+              name: {{.GrafanaAdminSecretName}}
+              key: {{.GrafanaAdminUsernameKey}}
+        - name: GF_SECURITY_ADMIN_PASSWORD
+          valueFrom:
+            secretKeyRef:
+              name: {{.GrafanaAdminSecretName}}
+              key: {{.GrafanaAdminPasswordKey}}
+        {{- end}}
         livenessProbe:
           httpGet:
             path: /api/health
@@ -540,14 +1068,19 @@ data:
     root_url = %(protocol)s://%(domain)s:/api/v1/namespaces/{{.Namespace}}/services/grafana:http/proxy/
 
     [auth]
-    disable_login_form = true
+    disable_login_form = {{not .GrafanaAuth}}
 
     [auth.anonymous]
+    {{- if .GrafanaAuth}}
+    enabled = {{.GrafanaAnonymousViewer}}
+    org_role = Viewer
+    {{- else}}
     enabled = true
     org_role = Editor
+    {{- end}}
 
     [auth.basic]
-    enabled = false
+    enabled = {{.GrafanaAuth}}
 
     [analytics]
     check_for_updates = false
@@ -555,11 +1088,11 @@ data:
   datasources.yaml: |-
     apiVersion: 1
     datasources:
-    - name: prometheus
+    - name: {{printf "%q" .GrafanaDatasourceName}}
       type: prometheus
       access: proxy
       orgId: 1
-      url: http://prometheus.{{.Namespace}}.svc.cluster.local:9090
+      url: {{.GrafanaDatasourceURL}}
       isDefault: true
       jsonData:
         timeInterval: "5s"
@@ -578,6 +1111,33 @@ data:
       options:
         path: /var/lib/grafana/dashboards
         homeDashboardId: linkerd-top-line
+    {{- if .GrafanaExtraDashboardsConfigMap}}
+    - name: 'extra'
+      orgId: 1
+      folder: ''
+      type: file
+      disableDeletion: true
+      editable: true
+      options:
+        path: /var/lib/grafana/extra-dashboards
+    {{- end}}
+{{- range .GrafanaDashboards}}
+
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: grafana-dashboard-{{.Name}}
+  namespace: {{$.Namespace}}
+  labels:
+    {{$.ControllerComponentLabel}}: grafana
+  annotations:
+    {{$.CreatedByAnnotation}}: {{$.CliVersion}}
+data:
+  {{.Name}}.json: |-
+{{.JSON}}
+{{- end}}
+{{- end}}
 `
 
 const TlsTemplate = `
@@ -602,7 +1162,11 @@ rules:
 - apiGroups: [""]
   resources: ["configmaps"]
   resourceNames: [{{.TLSTrustAnchorConfigMapName}}]
-  verbs: ["update"]
+  verbs: ["get", "update"]
+- apiGroups: [""]
+  resources: ["configmaps"]
+  resourceNames: [{{.TLSIdentityTrustAnchorsConfigMapName}}]
+  verbs: ["get"]
 - apiGroups: [""]
   resources: ["pods"]
   verbs: ["list", "get", "watch"]
@@ -612,6 +1176,9 @@ rules:
 - apiGroups: [""]
   resources: ["secrets"]
   verbs: ["create", "update"]
+- apiGroups: ["authentication.k8s.io"]
+  resources: ["tokenreviews"]
+  verbs: ["create"]
 
 ---
 kind: ClusterRoleBinding
@@ -626,8 +1193,62 @@ subjects:
 - kind: ServiceAccount
   name: linkerd-ca
   namespace: {{.Namespace}}
+{{- if .TLSIdentityTrustAnchors}}
+
+### Identity Trust Anchors ###
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: {{.TLSIdentityTrustAnchorsConfigMapName}}
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: ca
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+data:
+  {{.TLSTrustAnchorFileName}}: |
+{{.TLSIdentityTrustAnchors}}
+{{- end}}
+{{- if .TrustBundleConfigMapName}}
+
+### Trust Bundle ###
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: {{.TrustBundleConfigMapName}}
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: ca
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+    {{.CertManagerInjectAnnotation}}: {{.Namespace}}/{{.TrustAnchorSecretName}}
+data:
+  {{.TLSTrustAnchorFileName}}: |
+{{.TLSIdentityTrustAnchors}}
+{{- end}}
 
 ### CA ###
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: identity
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: ca
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  type: ClusterIP
+  selector:
+    {{.ControllerComponentLabel}}: ca
+  ports:
+  - name: grpc
+    port: 8083
+    targetPort: 8083
+
 ---
 kind: Deployment
 apiVersion: extensions/v1beta1
@@ -646,11 +1267,15 @@ spec:
         {{.ControllerComponentLabel}}: ca
       annotations:
         {{.CreatedByAnnotation}}: {{.CliVersion}}
+        prometheus.io/scrape: "true"
+        prometheus.io/port: "9997"
     spec:
       serviceAccount: linkerd-ca
       containers:
       - name: ca
         ports:
+        - name: grpc
+          containerPort: 8083
         - name: admin-http
           containerPort: 9997
         image: {{.ControllerImage}}
@@ -658,7 +1283,9 @@ spec:
         args:
         - "ca"
         - "-controller-namespace={{.Namespace}}"
+        - "-cluster-dns-domain={{.ClusterDNSDomain}}"
         - "-log-level={{.ControllerLogLevel}}"
+        - "-log-format={{.LogFormat}}"
         livenessProbe:
           httpGet:
             path: /ping
@@ -670,3 +1297,385 @@ spec:
             port: 9997
           failureThreshold: 7
 `
+
+// ServiceMonitorTemplate provides the additional configuration for the
+// `linkerd install --enable-service-monitor` command.
+const ServiceMonitorTemplate = `
+### Service Monitors ###
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: linkerd-controller-metrics
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: controller
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  clusterIP: None
+  selector:
+    {{.ControllerComponentLabel}}: controller
+  ports:
+  - name: public-api-admin
+    port: 9995
+    targetPort: 9995
+  - name: proxy-api-admin
+    port: 9996
+    targetPort: 9996
+  - name: tap-admin
+    port: 9998
+    targetPort: 9998
+  - name: destination-admin
+    port: 9999
+    targetPort: 9999
+
+---
+kind: ServiceMonitor
+apiVersion: monitoring.coreos.com/v1
+metadata:
+  name: linkerd-controller
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: controller
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: controller
+  endpoints:
+  - port: public-api-admin
+  - port: proxy-api-admin
+  - port: tap-admin
+  - port: destination-admin
+
+---
+kind: ServiceMonitor
+apiVersion: monitoring.coreos.com/v1
+metadata:
+  name: linkerd-web
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: web
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: web
+  endpoints:
+  - port: admin-http
+{{- if not .PrometheusExternalURL}}
+
+---
+kind: ServiceMonitor
+apiVersion: monitoring.coreos.com/v1
+metadata:
+  name: linkerd-prometheus
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: prometheus
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: prometheus
+  endpoints:
+  - port: admin-http
+{{- end}}
+{{- if not .GrafanaURL}}
+
+---
+kind: ServiceMonitor
+apiVersion: monitoring.coreos.com/v1
+metadata:
+  name: linkerd-grafana
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: grafana
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: grafana
+  endpoints:
+  - port: http
+    path: /metrics
+{{- end}}
+{{- if .EnableTLS}}
+
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: linkerd-ca-metrics
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: ca
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  clusterIP: None
+  selector:
+    {{.ControllerComponentLabel}}: ca
+  ports:
+  - name: admin-http
+    port: 9997
+    targetPort: 9997
+
+---
+kind: ServiceMonitor
+apiVersion: monitoring.coreos.com/v1
+metadata:
+  name: linkerd-ca
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: ca
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: ca
+  endpoints:
+  - port: admin-http
+{{- end}}
+`
+
+// AdminRBACTemplate provides the additional configuration for the `linkerd
+// install --generate-admin-rbac` command.
+const AdminRBACTemplate = `
+### CLI Admin RBAC ###
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: linkerd-{{.Namespace}}-cli-admin
+  labels:
+    {{.ControllerComponentLabel}}: cli-admin
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+rules:
+# stat, tap, get, and the dashboard call the public API, web UI, Grafana,
+# and Prometheus through the API server's service proxy; check probes the
+# sp-validator webhook through it
+- apiGroups: [""]
+  resources: ["services/proxy"]
+  verbs: ["get", "create"]
+# check and identity read the control plane's configuration and the proxies'
+# identities; diagnostics lists pods
+- apiGroups: [""]
+  resources: ["pods", "configmaps", "secrets", "serviceaccounts", "persistentvolumeclaims"]
+  verbs: ["get", "list"]
+# check compares the control plane's services with their endpoints
+- apiGroups: [""]
+  resources: ["services", "endpoints"]
+  verbs: ["list"]
+# check compares the control plane's ClusterRoles with the installed ones
+- apiGroups: ["rbac.authorization.k8s.io"]
+  resources: ["clusterroles"]
+  verbs: ["list"]
+# check scans Deployments for deprecated annotations
+- apiGroups: ["apps"]
+  resources: ["deployments"]
+  verbs: ["list"]
+# diagnostics and check run commands in pods with kubectl exec
+- apiGroups: [""]
+  resources: ["pods/exec"]
+  verbs: ["create"]
+# diagnostics bundle reads the logs of the control plane's containers
+- apiGroups: [""]
+  resources: ["pods/log"]
+  verbs: ["get"]
+# profile compares the service profiles that it generates with the installed
+# ones
+- apiGroups: ["linkerd.io"]
+  resources: ["serviceprofiles"]
+  verbs: ["get", "list"]
+# diagnostics resource-requests reads the proxies' usage from the
+# metrics-server
+- apiGroups: ["metrics.k8s.io"]
+  resources: ["pods"]
+  verbs: ["get", "list"]
+# check queries the API server's version
+- nonResourceURLs: ["/version"]
+  verbs: ["get"]
+
+---
+kind: ClusterRoleBinding
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: linkerd-{{.Namespace}}-cli-admin
+  labels:
+    {{.ControllerComponentLabel}}: cli-admin
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: linkerd-{{.Namespace}}-cli-admin
+subjects:
+- kind: Group
+  apiGroup: rbac.authorization.k8s.io
+  name: {{printf "%q" .AdminRBACGroup}}
+`
+
+// NotesTemplate provides the additional configuration for the `linkerd install
+// --notes` command. It follows the injected resources, whose output ends with
+// a separator.
+const NotesTemplate = `### Install Notes ###
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: {{.InstallNotesConfigMapName}}
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: controller
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+data:
+  {{.InstallNotesKey}}: |
+    Linkerd ({{.CliVersion}}) is being installed in the {{.Namespace}} namespace.
+
+    Next steps:
+
+    1. Wait for the control plane to be ready, and validate the installation:
+
+         linkerd --linkerd-namespace {{.Namespace}} check
+
+    2. Add a first workload to the service mesh, by injecting the proxy into
+       its deployment:
+
+         kubectl get deploy/<name> -n <namespace> -o yaml \
+           | linkerd --linkerd-namespace {{.Namespace}} inject - \
+           | kubectl apply -f -
+
+    3. Once its pods are restarted, watch the workload's traffic:
+
+         linkerd --linkerd-namespace {{.Namespace}} stat deploy -n <namespace>
+         linkerd --linkerd-namespace {{.Namespace}} tap deploy/<name> -n <namespace>
+
+    4. Open the dashboard to explore the rest of the service mesh:
+
+         linkerd --linkerd-namespace {{.Namespace}} dashboard
+
+    These notes can be read again with:
+
+         kubectl describe configmap/{{.InstallNotesConfigMapName}} -n {{.Namespace}}
+`
+
+// CNITemplate provides the configuration for the `linkerd install-cni`
+// command, and the additional configuration for the `linkerd install
+// --linkerd-cni` command.
+const CNITemplate = `{{- if .CreateNamespace}}### Namespace ###
+kind: Namespace
+apiVersion: v1
+metadata:
+  name: {{.Namespace}}
+{{end}}
+### Service Account CNI ###
+---
+kind: ServiceAccount
+apiVersion: v1
+metadata:
+  name: linkerd-cni
+  namespace: {{.Namespace}}
+
+### CNI RBAC ###
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: linkerd-{{.Namespace}}-cni
+rules:
+- apiGroups: [""]
+  resources: ["pods"]
+  verbs: ["get"]
+
+---
+kind: ClusterRoleBinding
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: linkerd-{{.Namespace}}-cni
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: linkerd-{{.Namespace}}-cni
+subjects:
+- kind: ServiceAccount
+  name: linkerd-cni
+  namespace: {{.Namespace}}
+
+{{if .CNIPluginSHA256 -}}
+### CNI Config ###
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: {{.CNIConfigMapName}}
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: cni
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+data:
+  {{.CNIPluginSHA256Key}}: {{.CNIPluginSHA256}}
+
+{{end -}}
+### CNI ###
+---
+kind: DaemonSet
+apiVersion: extensions/v1beta1
+metadata:
+  name: linkerd-cni
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: cni
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: cni
+  updateStrategy:
+    type: RollingUpdate
+  template:
+    metadata:
+      labels:
+        {{.ControllerComponentLabel}}: cni
+      annotations:
+        {{.CreatedByAnnotation}}: {{.CliVersion}}
+    spec:
+      serviceAccount: linkerd-cni
+      # The installer runs on the host network, so that it runs on nodes
+      # whose pod network isn't ready yet, and tolerates all taints, so that
+      # it runs on every node that the pods it programs the rules of can run
+      # on.
+      hostNetwork: true
+      tolerations:
+      - operator: Exists
+      containers:
+      - name: install-cni
+        image: {{.CNIPluginImage}}
+        imagePullPolicy: {{.ImagePullPolicy}}
+        args:
+        - "-cni-bin-dir=/host/opt/cni/bin"
+        - "-cni-net-dir=/host/etc/cni/net.d"
+        - "-host-cni-net-dir={{.DestCNINetDir}}"
+        - {{printf "-network-config=%s" .NetworkConfig | printf "%q"}}
+        volumeMounts:
+        - name: cni-bin-dir
+          mountPath: /host/opt/cni/bin
+        - name: cni-net-dir
+          mountPath: /host/etc/cni/net.d
+      volumes:
+      - name: cni-bin-dir
+        hostPath:
+          path: {{.DestCNIBinDir}}
+      - name: cni-net-dir
+        hostPath:
+          path: {{.DestCNINetDir}}
+`
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/linkerd/linkerd2/cni-plugin/installer"
+	"github.com/linkerd/linkerd2/pkg/flags"
+	log "github.com/sirupsen/logrus"
+)
+
+// install-cni runs in the linkerd-cni DaemonSet, installing the plugin on each
+// node, and uninstalling it when its pod is deleted.
+func main() {
+	pluginBinary := flag.String("plugin-binary", "/opt/cni/bin/linkerd-cni", "path of the plugin binary to install")
+	cniBinDir := flag.String("cni-bin-dir", "/host/opt/cni/bin", "directory of the node's CNI plugin binaries")
+	cniNetDir := flag.String("cni-net-dir", "/host/etc/cni/net.d", "directory of the node's network configurations")
+	hostCNINetDir := flag.String("host-cni-net-dir", "/etc/cni/net.d", "directory of the node's network configurations, on the node")
+	kubeconfigName := flag.String("kubeconfig-name", "ZZZ-linkerd-cni-kubeconfig", "name of the plugin's kubeconfig in the network configuration directory")
+	networkConfig := flag.String("network-config", "", "the plugin's network configuration, as JSON")
+	syncInterval := flag.Duration("sync-interval", 10*time.Second, "how often the plugin is chained again, if other agents rewrote the network configurations")
+	printInstalledSHA256 := flag.Bool("print-installed-sha256", false, "print the SHA-256 hash of the plugin binary installed in cni-bin-dir and exit")
+	flags.ConfigureAndParse(flag.CommandLine, os.Args[1:])
+
+	if *printInstalledSHA256 {
+		i := &installer.Installer{PluginBinary: *pluginBinary, CNIBinDir: *cniBinDir}
+		hash, err := i.InstalledPluginSHA256()
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Println(hash)
+		return
+	}
+
+	config := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(*networkConfig), &config); err != nil {
+		log.Fatalf("invalid -network-config: %s", err)
+	}
+
+	i := &installer.Installer{
+		PluginBinary:      *pluginBinary,
+		CNIBinDir:         *cniBinDir,
+		CNINetDir:         *cniNetDir,
+		HostCNINetDir:     *hostCNINetDir,
+		KubeconfigName:    *kubeconfigName,
+		ServiceAccountDir: "/var/run/secrets/kubernetes.io/serviceaccount",
+		APIServerHost:     os.Getenv("KUBERNETES_SERVICE_HOST"),
+		APIServerPort:     os.Getenv("KUBERNETES_SERVICE_PORT"),
+		NetworkConfig:     config,
+	}
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+	if err := i.Install(); err != nil {
+		log.Fatalf("failed to install the plugin: %s", err)
+	}
+
+	ticker := time.NewTicker(*syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := i.Sync(); err != nil {
+				log.Errorf("failed to chain the plugin: %s", err)
+			}
+		case <-stop:
+			log.Info("uninstalling the plugin")
+			if err := i.Uninstall(); err != nil {
+				log.Fatalf("failed to uninstall the plugin: %s", err)
+			}
+			return
+		}
+	}
+}
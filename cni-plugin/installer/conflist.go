@@ -0,0 +1,199 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/cni-plugin/plugin"
+)
+
+// configExtensions are the extensions of the network configuration files that
+// the kubelet loads.
+var configExtensions = []string{".conf", ".conflist", ".json"}
+
+// IsConfigFile returns whether the kubelet loads the network configuration
+// file name.
+func IsConfigFile(name string) bool {
+	ext := filepath.Ext(name)
+	for _, configExt := range configExtensions {
+		if ext == configExt {
+			return true
+		}
+	}
+	return false
+}
+
+// PrimaryConfigFile returns the network configuration file, of names, that the
+// kubelet uses, which is the first one in lexicographic order, or "" if there
+// is none.
+func PrimaryConfigFile(names []string) string {
+	configs := []string{}
+	for _, name := range names {
+		if IsConfigFile(name) {
+			configs = append(configs, name)
+		}
+	}
+	if len(configs) == 0 {
+		return ""
+	}
+	sort.Strings(configs)
+	return configs[0]
+}
+
+// ConflistName returns the name of the network configuration list that the
+// network configuration file name is written to once the plugin is added.
+func ConflistName(name string) string {
+	if filepath.Ext(name) == ".conflist" {
+		return name
+	}
+	return strings.TrimSuffix(name, filepath.Ext(name)) + ".conflist"
+}
+
+// AddPlugin chains pluginConfig after the plugins of the network configuration
+// config, which is either a configuration list or the configuration of a
+// single plugin, which is converted to a list. The plugin is moved to the end
+// of the list if it's already in it, and replaced if its configuration
+// changed, so that it always runs after the primary plugin and any others
+// that chain after it. AddPlugin returns the configuration list, and whether
+// it differs from config.
+func AddPlugin(config []byte, pluginConfig map[string]interface{}) ([]byte, bool, error) {
+	conflist, err := parseConflist(config)
+	if err != nil {
+		return nil, false, err
+	}
+	original, err := parseConflist(config)
+	if err != nil {
+		return nil, false, err
+	}
+
+	plugins, err := removePlugins(conflist)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(plugins) == 0 {
+		return nil, false, fmt.Errorf("network configuration has no plugins to chain after")
+	}
+
+	pluginConfig = copyConfig(pluginConfig)
+	pluginConfig["type"] = plugin.PluginType
+	conflist["plugins"] = append(plugins, pluginConfig)
+
+	return marshalConflist(conflist, original)
+}
+
+// RemovePlugin removes the plugin from the network configuration list config,
+// returning the list, and whether it differs from config.
+func RemovePlugin(config []byte) ([]byte, bool, error) {
+	conflist, err := parseConflist(config)
+	if err != nil {
+		return nil, false, err
+	}
+	original, err := parseConflist(config)
+	if err != nil {
+		return nil, false, err
+	}
+
+	plugins, err := removePlugins(conflist)
+	if err != nil {
+		return nil, false, err
+	}
+	conflist["plugins"] = plugins
+
+	return marshalConflist(conflist, original)
+}
+
+// HasPlugin returns whether the plugin is in the network configuration config.
+func HasPlugin(config []byte) (bool, error) {
+	conflist, err := parseConflist(config)
+	if err != nil {
+		return false, err
+	}
+	plugins, err := pluginsOf(conflist)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range plugins {
+		if p["type"] == plugin.PluginType {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseConflist parses the network configuration config into a configuration
+// list, converting the configuration of a single plugin into a list of it.
+// Unknown fields are kept, as other plugins may read them.
+func parseConflist(config []byte) (map[string]interface{}, error) {
+	parsed := map[string]interface{}{}
+	if err := json.Unmarshal(config, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid network configuration: %s", err)
+	}
+
+	if _, ok := parsed["plugins"]; ok {
+		return parsed, nil
+	}
+
+	if _, ok := parsed["type"].(string); !ok {
+		return nil, fmt.Errorf("invalid network configuration: neither a list of plugins nor a plugin with a type")
+	}
+	conflist := map[string]interface{}{"plugins": []interface{}{parsed}}
+	for _, key := range []string{"cniVersion", "name"} {
+		if value, ok := parsed[key]; ok {
+			conflist[key] = value
+		}
+	}
+	return conflist, nil
+}
+
+// pluginsOf returns the plugins in conflist.
+func pluginsOf(conflist map[string]interface{}) ([]map[string]interface{}, error) {
+	list, ok := conflist["plugins"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid network configuration: plugins must be a list")
+	}
+	plugins := make([]map[string]interface{}, len(list))
+	for i, p := range list {
+		pluginConfig, ok := p.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid network configuration: plugin %d must be an object", i)
+		}
+		plugins[i] = pluginConfig
+	}
+	return plugins, nil
+}
+
+// removePlugins returns the plugins in conflist other than this one.
+func removePlugins(conflist map[string]interface{}) ([]interface{}, error) {
+	plugins, err := pluginsOf(conflist)
+	if err != nil {
+		return nil, err
+	}
+	others := []interface{}{}
+	for _, p := range plugins {
+		if p["type"] != plugin.PluginType {
+			others = append(others, p)
+		}
+	}
+	return others, nil
+}
+
+func marshalConflist(conflist, original map[string]interface{}) ([]byte, bool, error) {
+	out, err := json.MarshalIndent(conflist, "", "  ")
+	if err != nil {
+		return nil, false, err
+	}
+	return append(out, '\n'), !reflect.DeepEqual(conflist, original), nil
+}
+
+// copyConfig returns a deep copy of config, by round-tripping it through JSON,
+// so that it compares equal to the parsed configuration it's added to.
+func copyConfig(config map[string]interface{}) map[string]interface{} {
+	copied := map[string]interface{}{}
+	b, _ := json.Marshal(config)
+	json.Unmarshal(b, &copied)
+	return copied
+}
@@ -0,0 +1,324 @@
+package installer
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var linkerdPlugin = map[string]interface{}{
+	"kubernetes": map[string]interface{}{"kubeconfig": "/etc/cni/net.d/ZZZ-linkerd-cni-kubeconfig"},
+	"linkerd":    map[string]interface{}{"incoming-proxy-port": 4143, "outgoing-proxy-port": 4140},
+}
+
+const linkerdPluginJSON = `{
+  "type": "linkerd-cni",
+  "kubernetes": {"kubeconfig": "/etc/cni/net.d/ZZZ-linkerd-cni-kubeconfig"},
+  "linkerd": {"incoming-proxy-port": 4143, "outgoing-proxy-port": 4140}
+}`
+
+// assertJSON checks that actual is the same JSON as expected, ignoring
+// formatting and the order of keys.
+func assertJSON(t *testing.T, actual []byte, expected string) {
+	t.Helper()
+
+	var actualValue, expectedValue interface{}
+	if err := json.Unmarshal(actual, &actualValue); err != nil {
+		t.Fatalf("Invalid JSON %s: %s", actual, err)
+	}
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatalf("Invalid expected JSON %s: %s", expected, err)
+	}
+	if !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, actual)
+	}
+}
+
+func TestPrimaryConfigFile(t *testing.T) {
+	testCases := []struct {
+		names    []string
+		expected string
+	}{
+		{[]string{}, ""},
+		{[]string{"ZZZ-linkerd-cni-kubeconfig", "README", "calico-kubeconfig"}, ""},
+		{[]string{"10-calico.conflist"}, "10-calico.conflist"},
+		{[]string{"20-flannel.conf", "10-calico.conflist"}, "10-calico.conflist"},
+		{[]string{"10-calico.conflist", "10-calico.conf"}, "10-calico.conf"},
+		{[]string{"99-loopback.json", "ZZZ-linkerd-cni-kubeconfig", "10-calico.conflist.linkerd-cni.tmp"}, "99-loopback.json"},
+		{[]string{"calico-kubeconfig", "10-weave.conflist", "05-cilium.conf"}, "05-cilium.conf"},
+	}
+
+	for _, tc := range testCases {
+		if primary := PrimaryConfigFile(tc.names); primary != tc.expected {
+			t.Fatalf("Expected %v to have primary configuration %q, got %q", tc.names, tc.expected, primary)
+		}
+	}
+}
+
+func TestConflistName(t *testing.T) {
+	testCases := map[string]string{
+		"10-calico.conflist": "10-calico.conflist",
+		"10-flannel.conf":    "10-flannel.conflist",
+		"99-bridge.json":     "99-bridge.conflist",
+	}
+	for name, expected := range testCases {
+		if conflist := ConflistName(name); conflist != expected {
+			t.Fatalf("Expected %s to be written to %s, got %s", name, expected, conflist)
+		}
+	}
+}
+
+func TestAddPlugin(t *testing.T) {
+	testCases := []struct {
+		name            string
+		config          string
+		expected        string
+		expectedChanged bool
+	}{
+		{
+			name: "chains after the plugins of a list",
+			config: `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "plugins": [
+    {"type": "calico", "ipam": {"type": "calico-ipam"}, "mtu": 1440},
+    {"type": "portmap", "capabilities": {"portMappings": true}, "snat": true}
+  ]
+}`,
+			expected: `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "plugins": [
+    {"type": "calico", "ipam": {"type": "calico-ipam"}, "mtu": 1440},
+    {"type": "portmap", "capabilities": {"portMappings": true}, "snat": true},
+    ` + linkerdPluginJSON + `
+  ]
+}`,
+			expectedChanged: true,
+		},
+		{
+			name:   "converts a plugin's configuration to a list",
+			config: `{"cniVersion": "0.3.0", "name": "cbr0", "type": "flannel", "delegate": {"hairpinMode": true, "isDefaultGateway": true}}`,
+			expected: `{
+  "cniVersion": "0.3.0",
+  "name": "cbr0",
+  "plugins": [
+    {"cniVersion": "0.3.0", "name": "cbr0", "type": "flannel", "delegate": {"hairpinMode": true, "isDefaultGateway": true}},
+    ` + linkerdPluginJSON + `
+  ]
+}`,
+			expectedChanged: true,
+		},
+		{
+			name: "keeps a list that already chains the plugin last",
+			config: `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "plugins": [{"type": "calico"}, ` + linkerdPluginJSON + `]
+}`,
+			expected: `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "plugins": [{"type": "calico"}, ` + linkerdPluginJSON + `]
+}`,
+			expectedChanged: false,
+		},
+		{
+			name: "moves the plugin after plugins that were chained after it",
+			config: `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "plugins": [{"type": "calico"}, ` + linkerdPluginJSON + `, {"type": "bandwidth"}]
+}`,
+			expected: `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "plugins": [{"type": "calico"}, {"type": "bandwidth"}, ` + linkerdPluginJSON + `]
+}`,
+			expectedChanged: true,
+		},
+		{
+			name: "replaces an outdated configuration of the plugin",
+			config: `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "plugins": [{"type": "calico"}, {"type": "linkerd-cni", "linkerd": {"incoming-proxy-port": 4000}}]
+}`,
+			expected: `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "plugins": [{"type": "calico"}, ` + linkerdPluginJSON + `]
+}`,
+			expectedChanged: true,
+		},
+		{
+			name: "removes duplicates of the plugin",
+			config: `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "plugins": [{"type": "calico"}, ` + linkerdPluginJSON + `, ` + linkerdPluginJSON + `]
+}`,
+			expected: `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "plugins": [{"type": "calico"}, ` + linkerdPluginJSON + `]
+}`,
+			expectedChanged: true,
+		},
+		{
+			name: "keeps unknown fields",
+			config: `{
+  "cniVersion": "0.3.1",
+  "name": "weave",
+  "disableCheck": true,
+  "plugins": [{"type": "weave-net", "hairpinMode": true, "x-vendor": [1, 2, 3]}]
+}`,
+			expected: `{
+  "cniVersion": "0.3.1",
+  "name": "weave",
+  "disableCheck": true,
+  "plugins": [{"type": "weave-net", "hairpinMode": true, "x-vendor": [1, 2, 3]}, ` + linkerdPluginJSON + `]
+}`,
+			expectedChanged: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			conflist, changed, err := AddPlugin([]byte(tc.config), linkerdPlugin)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			assertJSON(t, conflist, tc.expected)
+			if changed != tc.expectedChanged {
+				t.Fatalf("Expected changed to be %t, got %t", tc.expectedChanged, changed)
+			}
+
+			// Adding the plugin again changes nothing
+			again, changed, err := AddPlugin(conflist, linkerdPlugin)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			assertJSON(t, again, tc.expected)
+			if changed {
+				t.Fatalf("Expected adding the plugin again to change nothing, got:\n%s", again)
+			}
+		})
+	}
+
+	t.Run("does not change the plugin's configuration", func(t *testing.T) {
+		pluginConfig := map[string]interface{}{"linkerd": map[string]interface{}{"proxy-uid": 2102}}
+		if _, _, err := AddPlugin([]byte(`{"plugins": [{"type": "calico"}]}`), pluginConfig); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if _, ok := pluginConfig["type"]; ok {
+			t.Fatalf("Expected the plugin's configuration to be unchanged, got %v", pluginConfig)
+		}
+	})
+
+	errorCases := []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{"invalid JSON", `{"plugins": [`, "invalid network configuration: unexpected end of JSON input"},
+		{"not an object", `["calico"]`, "invalid network configuration: json: cannot unmarshal array into Go value of type map[string]interface {}"},
+		{"plugin without a type", `{"cniVersion": "0.3.1", "name": "k8s"}`, "invalid network configuration: neither a list of plugins nor a plugin with a type"},
+		{"plugins not a list", `{"plugins": {"type": "calico"}}`, "invalid network configuration: plugins must be a list"},
+		{"plugin not an object", `{"plugins": [{"type": "calico"}, "portmap"]}`, "invalid network configuration: plugin 1 must be an object"},
+		{"empty list", `{"plugins": []}`, "network configuration has no plugins to chain after"},
+		{"list of only the plugin", `{"plugins": [` + linkerdPluginJSON + `]}`, "network configuration has no plugins to chain after"},
+	}
+	for _, tc := range errorCases {
+		tc := tc // pin
+		t.Run("rejects "+tc.name, func(t *testing.T) {
+			_, _, err := AddPlugin([]byte(tc.config), linkerdPlugin)
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("Expected error %q, got: %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestRemovePlugin(t *testing.T) {
+	testCases := []struct {
+		name            string
+		config          string
+		expected        string
+		expectedChanged bool
+	}{
+		{
+			name:            "removes the plugin",
+			config:          `{"cniVersion": "0.3.1", "name": "k8s", "plugins": [{"type": "calico"}, ` + linkerdPluginJSON + `, {"type": "portmap"}]}`,
+			expected:        `{"cniVersion": "0.3.1", "name": "k8s", "plugins": [{"type": "calico"}, {"type": "portmap"}]}`,
+			expectedChanged: true,
+		},
+		{
+			name:            "removes duplicates of the plugin",
+			config:          `{"plugins": [` + linkerdPluginJSON + `, {"type": "calico"}, ` + linkerdPluginJSON + `]}`,
+			expected:        `{"plugins": [{"type": "calico"}]}`,
+			expectedChanged: true,
+		},
+		{
+			name:            "keeps a list without the plugin",
+			config:          `{"cniVersion": "0.3.1", "name": "k8s", "plugins": [{"type": "calico"}]}`,
+			expected:        `{"cniVersion": "0.3.1", "name": "k8s", "plugins": [{"type": "calico"}]}`,
+			expectedChanged: false,
+		},
+		{
+			name:            "keeps a plugin's configuration, as a list",
+			config:          `{"cniVersion": "0.3.0", "name": "cbr0", "type": "flannel"}`,
+			expected:        `{"cniVersion": "0.3.0", "name": "cbr0", "plugins": [{"cniVersion": "0.3.0", "name": "cbr0", "type": "flannel"}]}`,
+			expectedChanged: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			conflist, changed, err := RemovePlugin([]byte(tc.config))
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			assertJSON(t, conflist, tc.expected)
+			if changed != tc.expectedChanged {
+				t.Fatalf("Expected changed to be %t, got %t", tc.expectedChanged, changed)
+			}
+		})
+	}
+
+	t.Run("undoes adding the plugin", func(t *testing.T) {
+		config := `{"cniVersion": "0.3.1", "name": "k8s", "plugins": [{"type": "calico", "mtu": 1440}, {"type": "portmap"}]}`
+		added, _, err := AddPlugin([]byte(config), linkerdPlugin)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		removed, _, err := RemovePlugin(added)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		assertJSON(t, removed, config)
+	})
+}
+
+func TestHasPlugin(t *testing.T) {
+	testCases := []struct {
+		config   string
+		expected bool
+	}{
+		{`{"plugins": [{"type": "calico"}, ` + linkerdPluginJSON + `]}`, true},
+		{`{"plugins": [{"type": "calico"}]}`, false},
+		{`{"type": "flannel"}`, false},
+		{`{"type": "linkerd-cni"}`, true},
+	}
+	for _, tc := range testCases {
+		hasPlugin, err := HasPlugin([]byte(tc.config))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if hasPlugin != tc.expected {
+			t.Fatalf("Expected %s to have the plugin: %t, got %t", tc.config, tc.expected, hasPlugin)
+		}
+	}
+}
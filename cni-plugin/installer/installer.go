@@ -0,0 +1,260 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// tmpSuffix is the suffix of the files that the installer writes before
+	// renaming them into place, which the kubelet doesn't load.
+	tmpSuffix = ".linkerd-cni.tmp"
+
+	kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- name: local
+  cluster:
+    server: https://%s
+    certificate-authority-data: %s
+users:
+- name: linkerd-cni
+  user:
+    token: %s
+contexts:
+- name: linkerd-cni
+  context:
+    cluster: local
+    user: linkerd-cni
+current-context: linkerd-cni
+`
+)
+
+// Installer installs the plugin on a node: its binary, the kubeconfig that it
+// gets pods with, and its configuration, which is chained after the node's
+// primary CNI plugin.
+type Installer struct {
+	// PluginBinary is the plugin's binary, which is copied to CNIBinDir.
+	PluginBinary string
+	CNIBinDir    string
+
+	// CNINetDir is the directory of the node's network configurations, which
+	// is HostCNINetDir on the node itself.
+	CNINetDir     string
+	HostCNINetDir string
+
+	// KubeconfigName is the name of the kubeconfig in CNINetDir, which is
+	// written with the service account in ServiceAccountDir, and the API
+	// server at APIServerHost and APIServerPort.
+	KubeconfigName    string
+	ServiceAccountDir string
+	APIServerHost     string
+	APIServerPort     string
+
+	// NetworkConfig is the plugin's network configuration.
+	NetworkConfig map[string]interface{}
+}
+
+// Install copies the plugin's binary and writes its kubeconfig, then chains
+// it after the primary CNI plugin.
+func (i *Installer) Install() error {
+	binary, err := ioutil.ReadFile(i.PluginBinary)
+	if err != nil {
+		return fmt.Errorf("failed to read the plugin binary: %s", err)
+	}
+	if err := writeFile(i.pluginBinaryPath(), binary, 0755); err != nil {
+		return fmt.Errorf("failed to install the plugin binary: %s", err)
+	}
+	log.Infof("installed %s", i.pluginBinaryPath())
+
+	kubeconfig, err := i.kubeconfig()
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(i.CNINetDir, i.KubeconfigName), kubeconfig, 0600); err != nil {
+		return fmt.Errorf("failed to write the kubeconfig: %s", err)
+	}
+
+	return i.Sync()
+}
+
+// Sync chains the plugin after the primary CNI plugin, if it isn't already, and
+// removes it from other network configurations. It is run periodically, as
+// other agents, such as the primary plugin's, may rewrite or replace the
+// network configurations.
+func (i *Installer) Sync() error {
+	names, err := i.configFiles()
+	if err != nil {
+		return err
+	}
+	primary := PrimaryConfigFile(names)
+	if primary == "" {
+		log.Infof("no network configuration in %s yet, waiting for the primary CNI plugin", i.CNINetDir)
+		return nil
+	}
+
+	for _, name := range names {
+		if name != primary {
+			if err := i.removeFrom(name); err != nil {
+				return err
+			}
+		}
+	}
+
+	path := filepath.Join(i.CNINetDir, primary)
+	config, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	conflist, changed, err := AddPlugin(config, i.networkConfig())
+	if err != nil {
+		return fmt.Errorf("failed to chain the plugin in %s: %s", path, err)
+	}
+	if !changed && ConflistName(primary) == primary {
+		return nil
+	}
+
+	// A plugin's configuration is converted to a list, which the kubelet
+	// loads instead once the plugin's configuration is removed
+	conflistPath := filepath.Join(i.CNINetDir, ConflistName(primary))
+	if err := writeFile(conflistPath, conflist, 0644); err != nil {
+		return err
+	}
+	if conflistPath != path {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	log.Infof("chained the plugin in %s", conflistPath)
+	return nil
+}
+
+// Uninstall removes the plugin from all the network configurations, and removes
+// its kubeconfig and binary, so that it no longer runs for new pods.
+func (i *Installer) Uninstall() error {
+	names, err := i.configFiles()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := i.removeFrom(name); err != nil {
+			return err
+		}
+	}
+
+	for _, path := range []string{filepath.Join(i.CNINetDir, i.KubeconfigName), i.pluginBinaryPath()} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		log.Infof("removed %s", path)
+	}
+	return nil
+}
+
+// InstalledPluginSHA256 returns the hex-encoded SHA-256 hash of the plugin
+// binary installed in CNIBinDir, which `linkerd check` compares with the hash
+// that the plugin was released with.
+func (i *Installer) InstalledPluginSHA256() (string, error) {
+	binary, err := ioutil.ReadFile(i.pluginBinaryPath())
+	if err != nil {
+		return "", fmt.Errorf("failed to read the installed plugin binary: %s", err)
+	}
+	hash := sha256.Sum256(binary)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// removeFrom removes the plugin from the network configuration name, if it's
+// in it.
+func (i *Installer) removeFrom(name string) error {
+	path := filepath.Join(i.CNINetDir, name)
+	config, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if hasPlugin, err := HasPlugin(config); err != nil || !hasPlugin {
+		// Configurations that the kubelet can't load don't chain the plugin
+		return nil
+	}
+
+	conflist, _, err := RemovePlugin(config)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(path, conflist, 0644); err != nil {
+		return err
+	}
+	log.Infof("removed the plugin from %s", path)
+	return nil
+}
+
+// configFiles returns the names of the network configurations in CNINetDir.
+func (i *Installer) configFiles() ([]string, error) {
+	files, err := ioutil.ReadDir(i.CNINetDir)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, file := range files {
+		if !file.IsDir() && IsConfigFile(file.Name()) {
+			names = append(names, file.Name())
+		}
+	}
+	return names, nil
+}
+
+func (i *Installer) pluginBinaryPath() string {
+	return filepath.Join(i.CNIBinDir, filepath.Base(i.PluginBinary))
+}
+
+// networkConfig returns the plugin's network configuration, with the path of
+// its kubeconfig on the node.
+func (i *Installer) networkConfig() map[string]interface{} {
+	config := copyConfig(i.NetworkConfig)
+	config["kubernetes"] = map[string]interface{}{
+		"kubeconfig": filepath.Join(i.HostCNINetDir, i.KubeconfigName),
+	}
+	return config
+}
+
+// kubeconfig returns the plugin's kubeconfig, which authenticates as the
+// installer's service account.
+func (i *Installer) kubeconfig() ([]byte, error) {
+	token, err := ioutil.ReadFile(filepath.Join(i.ServiceAccountDir, "token"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the service account token: %s", err)
+	}
+	ca, err := ioutil.ReadFile(filepath.Join(i.ServiceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the service account CA: %s", err)
+	}
+	if i.APIServerHost == "" || i.APIServerPort == "" {
+		return nil, fmt.Errorf("the Kubernetes API server's host and port are unknown")
+	}
+
+	kubeconfig := fmt.Sprintf(kubeconfigTemplate,
+		net.JoinHostPort(i.APIServerHost, i.APIServerPort),
+		base64.StdEncoding.EncodeToString(ca),
+		token)
+	return []byte(kubeconfig), nil
+}
+
+// writeFile writes data to path by renaming a temporary file into place, so
+// that the kubelet never loads a partially written file.
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	tmp := path + tmpSuffix
+	if err := ioutil.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,305 @@
+package installer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const calicoConflist = `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "plugins": [{"type": "calico"}, {"type": "portmap"}]
+}`
+
+func newTestInstaller(t *testing.T) (*Installer, func()) {
+	t.Helper()
+
+	root, err := ioutil.TempDir("", "linkerd-cni")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, dir := range []string{"image", "bin", "net.d", "serviceaccount"} {
+		if err := os.Mkdir(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+	writeTestFile(t, filepath.Join(root, "image", "linkerd-cni"), "binary")
+	writeTestFile(t, filepath.Join(root, "serviceaccount", "token"), "token")
+	writeTestFile(t, filepath.Join(root, "serviceaccount", "ca.crt"), "ca")
+
+	i := &Installer{
+		PluginBinary:      filepath.Join(root, "image", "linkerd-cni"),
+		CNIBinDir:         filepath.Join(root, "bin"),
+		CNINetDir:         filepath.Join(root, "net.d"),
+		HostCNINetDir:     "/etc/cni/net.d",
+		KubeconfigName:    "ZZZ-linkerd-cni-kubeconfig",
+		ServiceAccountDir: filepath.Join(root, "serviceaccount"),
+		APIServerHost:     "10.96.0.1",
+		APIServerPort:     "443",
+		NetworkConfig: map[string]interface{}{
+			"linkerd": map[string]interface{}{"incoming-proxy-port": 4143},
+		},
+	}
+	return i, func() { os.RemoveAll(root) }
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return string(content)
+}
+
+func assertFiles(t *testing.T, dir string, expected ...string) {
+	t.Helper()
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	names := []string{}
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected %s to have files %v, got %v", dir, expected, names)
+	}
+}
+
+func assertChained(t *testing.T, path string, plugins ...string) {
+	t.Helper()
+	conflist, err := parseConflist([]byte(readTestFile(t, path)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	chained, err := pluginsOf(conflist)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	types := []string{}
+	for _, p := range chained {
+		types = append(types, p["type"].(string))
+	}
+	if strings.Join(types, ",") != strings.Join(plugins, ",") {
+		t.Fatalf("Expected %s to chain %v, got %v", path, plugins, types)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	t.Run("installs the binary, kubeconfig, and configuration", func(t *testing.T) {
+		i, cleanup := newTestInstaller(t)
+		defer cleanup()
+		writeTestFile(t, filepath.Join(i.CNINetDir, "10-calico.conflist"), calicoConflist)
+		writeTestFile(t, filepath.Join(i.CNINetDir, "calico-kubeconfig"), "calico")
+
+		if err := i.Install(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		binary := filepath.Join(i.CNIBinDir, "linkerd-cni")
+		if content := readTestFile(t, binary); content != "binary" {
+			t.Fatalf("Expected the plugin binary to be installed, got %q", content)
+		}
+		if info, _ := os.Stat(binary); info.Mode().Perm() != 0755 {
+			t.Fatalf("Expected the plugin binary to be executable, got %s", info.Mode())
+		}
+
+		kubeconfig := readTestFile(t, filepath.Join(i.CNINetDir, "ZZZ-linkerd-cni-kubeconfig"))
+		for _, expected := range []string{"server: https://10.96.0.1:443", "certificate-authority-data: Y2E=", "token: token"} {
+			if !strings.Contains(kubeconfig, expected) {
+				t.Fatalf("Expected the kubeconfig to contain %q, got:\n%s", expected, kubeconfig)
+			}
+		}
+
+		assertFiles(t, i.CNINetDir, "10-calico.conflist", "ZZZ-linkerd-cni-kubeconfig", "calico-kubeconfig")
+		assertChained(t, filepath.Join(i.CNINetDir, "10-calico.conflist"), "calico", "portmap", "linkerd-cni")
+
+		conflist := readTestFile(t, filepath.Join(i.CNINetDir, "10-calico.conflist"))
+		if !strings.Contains(conflist, `"kubeconfig": "/etc/cni/net.d/ZZZ-linkerd-cni-kubeconfig"`) {
+			t.Fatalf("Expected the plugin to be configured with the kubeconfig on the node, got:\n%s", conflist)
+		}
+	})
+
+	t.Run("converts the configuration of a plugin to a list", func(t *testing.T) {
+		i, cleanup := newTestInstaller(t)
+		defer cleanup()
+		writeTestFile(t, filepath.Join(i.CNINetDir, "10-flannel.conf"), `{"cniVersion": "0.3.0", "name": "cbr0", "type": "flannel"}`)
+
+		if err := i.Install(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		assertFiles(t, i.CNINetDir, "10-flannel.conflist", "ZZZ-linkerd-cni-kubeconfig")
+		assertChained(t, filepath.Join(i.CNINetDir, "10-flannel.conflist"), "flannel", "linkerd-cni")
+	})
+
+	t.Run("waits for the primary plugin's configuration", func(t *testing.T) {
+		i, cleanup := newTestInstaller(t)
+		defer cleanup()
+
+		if err := i.Install(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		assertFiles(t, i.CNINetDir, "ZZZ-linkerd-cni-kubeconfig")
+
+		writeTestFile(t, filepath.Join(i.CNINetDir, "10-calico.conflist"), calicoConflist)
+		if err := i.Sync(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		assertChained(t, filepath.Join(i.CNINetDir, "10-calico.conflist"), "calico", "portmap", "linkerd-cni")
+	})
+
+	t.Run("fails without the service account", func(t *testing.T) {
+		i, cleanup := newTestInstaller(t)
+		defer cleanup()
+		os.Remove(filepath.Join(i.ServiceAccountDir, "token"))
+
+		err := i.Install()
+		if err == nil || !strings.HasPrefix(err.Error(), "failed to read the service account token") {
+			t.Fatalf("Expected the service account token to be required, got: %v", err)
+		}
+	})
+}
+
+func TestSync(t *testing.T) {
+	t.Run("chains the plugin again after the configuration is rewritten", func(t *testing.T) {
+		i, cleanup := newTestInstaller(t)
+		defer cleanup()
+		path := filepath.Join(i.CNINetDir, "10-calico.conflist")
+		writeTestFile(t, path, calicoConflist)
+
+		if err := i.Install(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		writeTestFile(t, path, calicoConflist)
+		if err := i.Sync(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		assertChained(t, path, "calico", "portmap", "linkerd-cni")
+	})
+
+	t.Run("leaves a chained configuration unchanged", func(t *testing.T) {
+		i, cleanup := newTestInstaller(t)
+		defer cleanup()
+		path := filepath.Join(i.CNINetDir, "10-calico.conflist")
+		writeTestFile(t, path, calicoConflist)
+
+		if err := i.Install(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		installed := readTestFile(t, path)
+		if err := i.Sync(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if synced := readTestFile(t, path); synced != installed {
+			t.Fatalf("Expected the configuration to be unchanged, got:\n%s", synced)
+		}
+	})
+
+	t.Run("moves the plugin to a new primary configuration", func(t *testing.T) {
+		i, cleanup := newTestInstaller(t)
+		defer cleanup()
+		writeTestFile(t, filepath.Join(i.CNINetDir, "20-calico.conflist"), calicoConflist)
+
+		if err := i.Install(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		// The primary plugin rewrites its configuration next to the one that
+		// was converted to a list, which the kubelet now loads instead
+		writeTestFile(t, filepath.Join(i.CNINetDir, "10-flannel.conf"), `{"cniVersion": "0.3.0", "name": "cbr0", "type": "flannel"}`)
+		if err := i.Sync(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		assertFiles(t, i.CNINetDir, "10-flannel.conflist", "20-calico.conflist", "ZZZ-linkerd-cni-kubeconfig")
+		assertChained(t, filepath.Join(i.CNINetDir, "10-flannel.conflist"), "flannel", "linkerd-cni")
+		assertChained(t, filepath.Join(i.CNINetDir, "20-calico.conflist"), "calico", "portmap")
+	})
+
+	t.Run("skips configurations it can't parse", func(t *testing.T) {
+		i, cleanup := newTestInstaller(t)
+		defer cleanup()
+		writeTestFile(t, filepath.Join(i.CNINetDir, "10-calico.conflist"), calicoConflist)
+		writeTestFile(t, filepath.Join(i.CNINetDir, "99-broken.conf"), "{")
+
+		if err := i.Install(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		assertChained(t, filepath.Join(i.CNINetDir, "10-calico.conflist"), "calico", "portmap", "linkerd-cni")
+	})
+
+	t.Run("fails on a primary configuration it can't parse", func(t *testing.T) {
+		i, cleanup := newTestInstaller(t)
+		defer cleanup()
+		writeTestFile(t, filepath.Join(i.CNINetDir, "10-broken.conf"), "{")
+
+		if err := i.Install(); err == nil {
+			t.Fatalf("Expected the primary configuration to be invalid")
+		}
+		if content := readTestFile(t, filepath.Join(i.CNINetDir, "10-broken.conf")); content != "{" {
+			t.Fatalf("Expected the primary configuration to be unchanged, got %q", content)
+		}
+	})
+}
+
+func TestUninstall(t *testing.T) {
+	i, cleanup := newTestInstaller(t)
+	defer cleanup()
+	writeTestFile(t, filepath.Join(i.CNINetDir, "10-flannel.conf"), `{"cniVersion": "0.3.0", "name": "cbr0", "type": "flannel"}`)
+
+	if err := i.Install(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if err := i.Uninstall(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	assertFiles(t, i.CNIBinDir)
+	assertFiles(t, i.CNINetDir, "10-flannel.conflist")
+	assertChained(t, filepath.Join(i.CNINetDir, "10-flannel.conflist"), "flannel")
+
+	// Uninstalling again finds nothing to remove
+	if err := i.Uninstall(); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+}
+
+func TestInstalledPluginSHA256(t *testing.T) {
+	t.Run("hashes the installed binary", func(t *testing.T) {
+		i, cleanup := newTestInstaller(t)
+		defer cleanup()
+		writeTestFile(t, filepath.Join(i.CNIBinDir, "linkerd-cni"), "binary")
+
+		hash, err := i.InstalledPluginSHA256()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := "9a3a45d01531a20e89ac6ae10b0b0beb0492acd7216a368aa062d1a5fecaf9cd"
+		if hash != expected {
+			t.Fatalf("Expected hash %s, got %s", expected, hash)
+		}
+	})
+
+	t.Run("fails when the plugin isn't installed", func(t *testing.T) {
+		i, cleanup := newTestInstaller(t)
+		defer cleanup()
+
+		_, err := i.InstalledPluginSHA256()
+		if err == nil || !strings.HasPrefix(err.Error(), "failed to read the installed plugin binary") {
+			t.Fatalf("Expected the installed binary to be required, got: %v", err)
+		}
+	})
+}
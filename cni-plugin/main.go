@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+
+	"github.com/linkerd/linkerd2/cni-plugin/plugin"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/proxy-init/iptables"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The linkerd-cni plugin is run by the container runtime, chained after the
+// node's primary CNI plugin, and redirects the traffic of injected pods to
+// their proxies.
+func main() {
+	p := &plugin.Plugin{
+		GetPod: func(kubeconfig, namespace, name string) (*v1.Pod, error) {
+			clientset, err := k8s.NewClientSet(kubeconfig)
+			if err != nil {
+				return nil, err
+			}
+			return clientset.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+		},
+		ConfigureFirewall: iptables.ConfigureFirewall,
+	}
+	os.Exit(p.Run(plugin.ArgsFromEnv(os.Getenv), os.Stdin, os.Stdout))
+}
@@ -0,0 +1,395 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/proxy-init/iptables"
+	"k8s.io/api/core/v1"
+)
+
+const (
+	// PluginType is the type of the plugin in network configurations.
+	PluginType = "linkerd-cni"
+
+	// The CNI error codes of the plugin. Codes below 100 are reserved for
+	// the well-known errors of the CNI specification.
+	errCodeInvalidEnvironment = 4
+	errCodeDecodingFailure    = 6
+	errCodeInvalidConfig      = 7
+	errCodeFirewallFailure    = 100
+
+	// proxyInitContainerName is the init container that inject adds to pods,
+	// unless the plugin programs their rules instead.
+	proxyInitContainerName = "linkerd-init"
+)
+
+// supportedVersions are the CNI specification versions that the plugin
+// supports, all of which chain plugins with a prevResult.
+var supportedVersions = []string{"0.3.0", "0.3.1", "0.4.0"}
+
+// NetConf is the plugin's network configuration.
+type NetConf struct {
+	CNIVersion string          `json:"cniVersion"`
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`
+	PrevResult json.RawMessage `json:"prevResult,omitempty"`
+
+	Kubernetes struct {
+		// Kubeconfig is the path of the kubeconfig that the plugin gets pods
+		// with, which the installer writes.
+		Kubeconfig string `json:"kubeconfig"`
+	} `json:"kubernetes"`
+
+	Linkerd ProxyInitConf `json:"linkerd"`
+}
+
+// ProxyInitConf is the configuration of the rules that the plugin programs, as
+// proxy-init would with the flags of the same names.
+type ProxyInitConf struct {
+	IncomingProxyPort     int    `json:"incoming-proxy-port"`
+	OutgoingProxyPort     int    `json:"outgoing-proxy-port"`
+	ProxyUID              int    `json:"proxy-uid"`
+	PortsToRedirect       []int  `json:"ports-to-redirect,omitempty"`
+	InboundPortsToIgnore  []int  `json:"inbound-ports-to-ignore,omitempty"`
+	OutboundPortsToIgnore []int  `json:"outbound-ports-to-ignore,omitempty"`
+	IptablesMode          string `json:"iptables-mode,omitempty"`
+	IPv6Mode              string `json:"ipv6-mode,omitempty"`
+	Simulate              bool   `json:"simulate,omitempty"`
+}
+
+// Args are the arguments that the container runtime invokes the plugin with,
+// from the CNI_* environment variables.
+type Args struct {
+	Command     string
+	ContainerID string
+	Netns       string
+	IfName      string
+	Args        string
+	Path        string
+}
+
+// ArgsFromEnv reads the plugin's arguments with getenv.
+func ArgsFromEnv(getenv func(string) string) Args {
+	return Args{
+		Command:     getenv("CNI_COMMAND"),
+		ContainerID: getenv("CNI_CONTAINERID"),
+		Netns:       getenv("CNI_NETNS"),
+		IfName:      getenv("CNI_IFNAME"),
+		Args:        getenv("CNI_ARGS"),
+		Path:        getenv("CNI_PATH"),
+	}
+}
+
+// podName returns the namespace and name of the pod that the runtime creates
+// the sandbox of, from the K8S_POD_* keys of the CNI_ARGS, or "" if the
+// runtime isn't the kubelet.
+func (args Args) podName() (string, string) {
+	namespace, name := "", ""
+	for _, pair := range strings.Split(args.Args, ";") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "K8S_POD_NAMESPACE":
+			namespace = kv[1]
+		case "K8S_POD_NAME":
+			name = kv[1]
+		}
+	}
+	return namespace, name
+}
+
+// Error is a CNI error, which the plugin writes to stdout.
+type Error struct {
+	CNIVersion string `json:"cniVersion"`
+	Code       int    `json:"code"`
+	Msg        string `json:"msg"`
+	Details    string `json:"details,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Details == "" {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s: %s", e.Msg, e.Details)
+}
+
+// Plugin programs the iptables rules that redirect the traffic of injected
+// pods to their proxies, in place of the linkerd-init container.
+type Plugin struct {
+	// GetPod returns the pod of namespace and name, with the kubeconfig.
+	GetPod func(kubeconfig, namespace, name string) (*v1.Pod, error)
+
+	// ConfigureFirewall programs the rules of a pod.
+	ConfigureFirewall func(iptables.FirewallConfiguration) error
+}
+
+// Run runs the command of args with the network configuration read from stdin,
+// writing its result, or its error, to stdout. It returns the exit code of the
+// plugin.
+func (p *Plugin) Run(args Args, stdin io.Reader, stdout io.Writer) int {
+	result, err := p.run(args, stdin)
+	if err != nil {
+		cniErr, ok := err.(*Error)
+		if !ok {
+			cniErr = &Error{Code: errCodeFirewallFailure, Msg: err.Error()}
+		}
+		if cniErr.CNIVersion == "" {
+			cniErr.CNIVersion = supportedVersions[len(supportedVersions)-1]
+		}
+		json.NewEncoder(stdout).Encode(cniErr)
+		return 1
+	}
+	if result != nil {
+		stdout.Write(result)
+	}
+	return 0
+}
+
+func (p *Plugin) run(args Args, stdin io.Reader) ([]byte, error) {
+	if args.Command == "VERSION" {
+		return json.Marshal(map[string]interface{}{
+			"cniVersion":        supportedVersions[len(supportedVersions)-1],
+			"supportedVersions": supportedVersions,
+		})
+	}
+
+	config, err := ioutil.ReadAll(stdin)
+	if err != nil {
+		return nil, &Error{Code: errCodeDecodingFailure, Msg: "failed to read the network configuration", Details: err.Error()}
+	}
+	conf, err := ParseNetConf(config)
+	if err != nil {
+		return nil, err
+	}
+
+	switch args.Command {
+	case "ADD":
+		if err := p.add(args, conf); err != nil {
+			return nil, &Error{CNIVersion: conf.CNIVersion, Code: errCodeFirewallFailure, Msg: "failed to redirect the pod's traffic to its proxy", Details: err.Error()}
+		}
+		return prevResult(conf)
+	case "DEL", "CHECK":
+		// The rules are removed along with the pod's network namespace
+		return nil, nil
+	default:
+		return nil, &Error{CNIVersion: conf.CNIVersion, Code: errCodeInvalidEnvironment, Msg: fmt.Sprintf("unknown CNI_COMMAND [%s]", args.Command)}
+	}
+}
+
+// ParseNetConf parses the plugin's network configuration, checking that it
+// chains after another plugin.
+func ParseNetConf(config []byte) (*NetConf, error) {
+	conf := &NetConf{}
+	if err := json.Unmarshal(config, conf); err != nil {
+		return nil, &Error{Code: errCodeDecodingFailure, Msg: "failed to parse the network configuration", Details: err.Error()}
+	}
+	if !isSupportedVersion(conf.CNIVersion) {
+		return nil, &Error{Code: errCodeInvalidConfig, Msg: fmt.Sprintf("unsupported cniVersion [%s], must be one of: %s", conf.CNIVersion, strings.Join(supportedVersions, ", "))}
+	}
+	if conf.Kubernetes.Kubeconfig == "" {
+		return nil, &Error{CNIVersion: conf.CNIVersion, Code: errCodeInvalidConfig, Msg: "the network configuration has no kubernetes.kubeconfig"}
+	}
+	return conf, nil
+}
+
+func isSupportedVersion(version string) bool {
+	for _, supported := range supportedVersions {
+		if version == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// prevResult returns the result of the plugins that the plugin chains after,
+// which it passes through unchanged.
+func prevResult(conf *NetConf) ([]byte, error) {
+	if len(conf.PrevResult) == 0 {
+		return json.Marshal(map[string]string{"cniVersion": conf.CNIVersion})
+	}
+	return conf.PrevResult, nil
+}
+
+// add programs the rules of the pod that args creates the sandbox of, if it
+// needs them.
+func (p *Plugin) add(args Args, conf *NetConf) error {
+	namespace, name := args.podName()
+	if namespace == "" || name == "" {
+		return nil
+	}
+
+	pod, err := p.GetPod(conf.Kubernetes.Kubeconfig, namespace, name)
+	if err != nil {
+		return fmt.Errorf("failed to get pod %s/%s: %s", namespace, name, err)
+	}
+	if !RedirectsTraffic(pod) {
+		return nil
+	}
+
+	firewallConfiguration, err := FirewallConfiguration(conf.Linkerd, pod, args.Netns)
+	if err != nil {
+		return err
+	}
+
+	// proxy-init can only detect the addresses of its own network namespace,
+	// so the auto IPv6 mode is resolved with the addresses that the previous
+	// plugins gave the pod
+	if firewallConfiguration.IPv6Mode == iptables.AutoIPv6Mode {
+		hasIPv6, err := prevResultHasIPv6(conf)
+		if err != nil {
+			return err
+		}
+		firewallConfiguration.IPv6Mode = iptables.DisabledIPv6Mode
+		if hasIPv6 {
+			firewallConfiguration.IPv6Mode = iptables.EnabledIPv6Mode
+		}
+	}
+
+	return p.ConfigureFirewall(firewallConfiguration)
+}
+
+// prevResultHasIPv6 returns whether the previous plugins gave the pod an IPv6
+// address.
+func prevResultHasIPv6(conf *NetConf) (bool, error) {
+	if len(conf.PrevResult) == 0 {
+		return false, nil
+	}
+
+	result := struct {
+		IPs []struct {
+			Address string `json:"address"`
+		} `json:"ips"`
+	}{}
+	if err := json.Unmarshal(conf.PrevResult, &result); err != nil {
+		return false, fmt.Errorf("failed to parse the prevResult: %s", err)
+	}
+	for _, ip := range result.IPs {
+		addr, _, err := net.ParseCIDR(ip.Address)
+		if err != nil {
+			return false, fmt.Errorf("the prevResult has an invalid address [%s]", ip.Address)
+		}
+		if addr.To4() == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RedirectsTraffic returns whether the plugin redirects the traffic of pod to
+// its proxy, which it does for pods that inject annotated, without the
+// linkerd-init container that would redirect it instead. Pods on the host
+// network are skipped, like inject skips them, as the rules would break the
+// node's traffic.
+func RedirectsTraffic(pod *v1.Pod) bool {
+	if pod.Spec.HostNetwork {
+		return false
+	}
+	if _, ok := pod.GetAnnotations()[k8s.ProxyVersionAnnotation]; !ok {
+		return false
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == proxyInitContainerName {
+			return false
+		}
+	}
+	return true
+}
+
+// FirewallConfiguration returns the rules that the plugin programs in the
+// netns of pod, which the pod's annotations customize like they do for
+// linkerd-init.
+func FirewallConfiguration(conf ProxyInitConf, pod *v1.Pod, netns string) (iptables.FirewallConfiguration, error) {
+	firewallConfiguration := iptables.FirewallConfiguration{
+		Mode:                   iptables.RedirectAllMode,
+		PortsToRedirectInbound: conf.PortsToRedirect,
+		ProxyInboundPort:       conf.IncomingProxyPort,
+		ProxyOutgoingPort:      conf.OutgoingProxyPort,
+		ProxyUid:               conf.ProxyUID,
+		IptablesMode:           conf.IptablesMode,
+		IPv6Mode:               conf.IPv6Mode,
+		SimulateOnly:           conf.Simulate,
+		NetNs:                  netns,
+	}
+	if len(conf.PortsToRedirect) > 0 {
+		firewallConfiguration.Mode = iptables.RedirectListedMode
+	}
+
+	annotations := pod.GetAnnotations()
+	if mode, ok := annotations[k8s.ProxyInitIptablesModeAnnotation]; ok {
+		firewallConfiguration.IptablesMode = mode
+	}
+	if mode, ok := annotations[k8s.ProxyInitIPv6ModeAnnotation]; ok {
+		firewallConfiguration.IPv6Mode = mode
+	}
+
+	// The pod's skipped ports are in addition to the configured ones, which
+	// include the proxy's own
+	inboundPorts, err := parseIDs(annotations, k8s.ProxyInitSkipInboundPortsAnnotation)
+	if err != nil {
+		return iptables.FirewallConfiguration{}, err
+	}
+	firewallConfiguration.InboundPortsToIgnore = append(append([]int{}, conf.InboundPortsToIgnore...), inboundPorts...)
+	outboundPorts, err := parseIDs(annotations, k8s.ProxyInitSkipOutboundPortsAnnotation)
+	if err != nil {
+		return iptables.FirewallConfiguration{}, err
+	}
+	firewallConfiguration.OutboundPortsToIgnore = append(append([]int{}, conf.OutboundPortsToIgnore...), outboundPorts...)
+
+	if firewallConfiguration.OutboundUidsToIgnore, err = parseIDs(annotations, k8s.ProxyInitSkipOwnerUIDsAnnotation); err != nil {
+		return iptables.FirewallConfiguration{}, err
+	}
+	if firewallConfiguration.OutboundGidsToIgnore, err = parseIDs(annotations, k8s.ProxyInitSkipOwnerGIDsAnnotation); err != nil {
+		return iptables.FirewallConfiguration{}, err
+	}
+	if firewallConfiguration.OutboundCidrsToIgnore, err = parseCIDRs(annotations, k8s.ProxyInitSkipOutboundCIDRsAnnotation); err != nil {
+		return iptables.FirewallConfiguration{}, err
+	}
+
+	return firewallConfiguration, nil
+}
+
+// parseCIDRs parses the comma-separated CIDRs of the annotation name.
+func parseCIDRs(annotations map[string]string, name string) ([]string, error) {
+	value := annotations[name]
+	if value == "" {
+		return nil, nil
+	}
+
+	cidrs := []string{}
+	for _, cidr := range strings.Split(value, ",") {
+		cidr = strings.TrimSpace(cidr)
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation [%s]", name, value)
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	return cidrs, nil
+}
+
+// parseIDs parses the comma-separated user IDs, group IDs, or ports of the
+// annotation name.
+func parseIDs(annotations map[string]string, name string) ([]int, error) {
+	value := annotations[name]
+	if value == "" {
+		return nil, nil
+	}
+
+	ids := []int{}
+	for _, id := range strings.Split(value, ",") {
+		parsed, err := strconv.Atoi(strings.TrimSpace(id))
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid %s annotation [%s]", name, value)
+		}
+		ids = append(ids, parsed)
+	}
+	return ids, nil
+}
@@ -0,0 +1,431 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/proxy-init/iptables"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const netConf = `{
+  "cniVersion": "0.3.1",
+  "name": "k8s-pod-network",
+  "type": "linkerd-cni",
+  "kubernetes": {"kubeconfig": "/etc/cni/net.d/ZZZ-linkerd-cni-kubeconfig"},
+  "linkerd": {
+    "incoming-proxy-port": 4143,
+    "outgoing-proxy-port": 4140,
+    "proxy-uid": 2102,
+    "inbound-ports-to-ignore": [4190, 9998],
+    "outbound-ports-to-ignore": [443]
+  },
+  "prevResult": {"cniVersion": "0.3.1", "ips": [{"version": "4", "address": "10.1.2.3/24"}]}
+}`
+
+func injectedPod(initContainers ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-1",
+			Namespace:   "emojivoto",
+			Annotations: map[string]string{k8s.ProxyVersionAnnotation: "stable-2.1.0"},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "web"}, {Name: "linkerd-proxy"}},
+		},
+	}
+	for _, name := range initContainers {
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, v1.Container{Name: name})
+	}
+	return pod
+}
+
+func TestRedirectsTraffic(t *testing.T) {
+	hostNetwork := injectedPod()
+	hostNetwork.Spec.HostNetwork = true
+
+	testCases := []struct {
+		name     string
+		pod      *v1.Pod
+		expected bool
+	}{
+		{"injected without linkerd-init", injectedPod(), true},
+		{"injected with other init containers", injectedPod("migrate"), true},
+		{"injected with linkerd-init", injectedPod(proxyInitContainerName), false},
+		{"on the host network", hostNetwork, false},
+		{"not injected", &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web"}}}}, false},
+		{"not annotated", &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web"}, {Name: "linkerd-proxy"}}}}, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			if redirects := RedirectsTraffic(tc.pod); redirects != tc.expected {
+				t.Fatalf("Expected the traffic to be redirected: %t, got %t", tc.expected, redirects)
+			}
+		})
+	}
+}
+
+func TestFirewallConfiguration(t *testing.T) {
+	conf := ProxyInitConf{
+		IncomingProxyPort:     4143,
+		OutgoingProxyPort:     4140,
+		ProxyUID:              2102,
+		InboundPortsToIgnore:  []int{4190},
+		OutboundPortsToIgnore: []int{443},
+		IptablesMode:          "legacy",
+	}
+
+	t.Run("configures the rules like proxy-init", func(t *testing.T) {
+		firewallConfiguration, err := FirewallConfiguration(conf, injectedPod(), "/proc/42/ns/net")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := iptables.FirewallConfiguration{
+			Mode:                  iptables.RedirectAllMode,
+			InboundPortsToIgnore:  []int{4190},
+			OutboundPortsToIgnore: []int{443},
+			ProxyInboundPort:      4143,
+			ProxyOutgoingPort:     4140,
+			ProxyUid:              2102,
+			IptablesMode:          "legacy",
+			NetNs:                 "/proc/42/ns/net",
+		}
+		if !reflect.DeepEqual(firewallConfiguration, expected) {
+			t.Fatalf("Expected %+v, got %+v", expected, firewallConfiguration)
+		}
+	})
+
+	t.Run("redirects the listed ports", func(t *testing.T) {
+		conf := conf
+		conf.PortsToRedirect = []int{8080}
+		firewallConfiguration, err := FirewallConfiguration(conf, injectedPod(), "/proc/42/ns/net")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if firewallConfiguration.Mode != iptables.RedirectListedMode || !reflect.DeepEqual(firewallConfiguration.PortsToRedirectInbound, []int{8080}) {
+			t.Fatalf("Expected port 8080 to be redirected, got %+v", firewallConfiguration)
+		}
+	})
+
+	t.Run("customizes the rules with the pod's annotations", func(t *testing.T) {
+		pod := injectedPod()
+		pod.Annotations = map[string]string{
+			k8s.ProxyInitIptablesModeAnnotation:      "nft",
+			k8s.ProxyInitIPv6ModeAnnotation:          "enabled",
+			k8s.ProxyInitSkipOwnerUIDsAnnotation:     "0, 1000",
+			k8s.ProxyInitSkipOwnerGIDsAnnotation:     "3000",
+			k8s.ProxyInitSkipOutboundCIDRsAnnotation: "10.0.0.0/8, 169.254.169.254/32",
+			k8s.ProxyInitSkipInboundPortsAnnotation:  "8443",
+			k8s.ProxyInitSkipOutboundPortsAnnotation: "3306,5432",
+		}
+		firewallConfiguration, err := FirewallConfiguration(conf, pod, "/proc/42/ns/net")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if firewallConfiguration.IptablesMode != "nft" {
+			t.Fatalf("Expected the nft iptables mode, got %s", firewallConfiguration.IptablesMode)
+		}
+		if firewallConfiguration.IPv6Mode != "enabled" {
+			t.Fatalf("Expected the enabled IPv6 mode, got %s", firewallConfiguration.IPv6Mode)
+		}
+		if !reflect.DeepEqual(firewallConfiguration.OutboundUidsToIgnore, []int{0, 1000}) {
+			t.Fatalf("Expected uids 0 and 1000 to be skipped, got %v", firewallConfiguration.OutboundUidsToIgnore)
+		}
+		if !reflect.DeepEqual(firewallConfiguration.OutboundGidsToIgnore, []int{3000}) {
+			t.Fatalf("Expected gid 3000 to be skipped, got %v", firewallConfiguration.OutboundGidsToIgnore)
+		}
+		if !reflect.DeepEqual(firewallConfiguration.OutboundCidrsToIgnore, []string{"10.0.0.0/8", "169.254.169.254/32"}) {
+			t.Fatalf("Expected two CIDRs to be skipped, got %v", firewallConfiguration.OutboundCidrsToIgnore)
+		}
+		if !reflect.DeepEqual(firewallConfiguration.InboundPortsToIgnore, []int{4190, 8443}) {
+			t.Fatalf("Expected inbound ports 4190 and 8443 to be skipped, got %v", firewallConfiguration.InboundPortsToIgnore)
+		}
+		if !reflect.DeepEqual(firewallConfiguration.OutboundPortsToIgnore, []int{443, 3306, 5432}) {
+			t.Fatalf("Expected outbound ports 443, 3306 and 5432 to be skipped, got %v", firewallConfiguration.OutboundPortsToIgnore)
+		}
+	})
+
+	t.Run("rejects invalid ids", func(t *testing.T) {
+		pod := injectedPod()
+		pod.Annotations = map[string]string{k8s.ProxyInitSkipOwnerUIDsAnnotation: "0,root"}
+		_, err := FirewallConfiguration(conf, pod, "/proc/42/ns/net")
+		expected := "invalid config.linkerd.io/skip-owner-uids annotation [0,root]"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+	t.Run("rejects invalid CIDRs", func(t *testing.T) {
+		pod := injectedPod()
+		pod.Annotations = map[string]string{k8s.ProxyInitSkipOutboundCIDRsAnnotation: "10.0.0.0/8, 192.168.0.0"}
+		_, err := FirewallConfiguration(conf, pod, "/proc/42/ns/net")
+		expected := "invalid config.linkerd.io/skip-outbound-cidrs annotation [10.0.0.0/8, 192.168.0.0]"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+}
+
+type fakeCluster struct {
+	pod        *v1.Pod
+	getErr     error
+	configured []iptables.FirewallConfiguration
+}
+
+func (c *fakeCluster) plugin() *Plugin {
+	return &Plugin{
+		GetPod: func(kubeconfig, namespace, name string) (*v1.Pod, error) {
+			if c.getErr != nil {
+				return nil, c.getErr
+			}
+			return c.pod, nil
+		},
+		ConfigureFirewall: func(firewallConfiguration iptables.FirewallConfiguration) error {
+			c.configured = append(c.configured, firewallConfiguration)
+			return nil
+		},
+	}
+}
+
+func addArgs() Args {
+	return Args{
+		Command:     "ADD",
+		ContainerID: "abc123",
+		Netns:       "/proc/42/ns/net",
+		IfName:      "eth0",
+		Args:        "IgnoreUnknown=1;K8S_POD_NAMESPACE=emojivoto;K8S_POD_NAME=web-1;K8S_POD_INFRA_CONTAINER_ID=abc123",
+	}
+}
+
+func runPlugin(t *testing.T, p *Plugin, args Args, config string) (int, map[string]interface{}) {
+	t.Helper()
+	stdout := &bytes.Buffer{}
+	code := p.Run(args, strings.NewReader(config), stdout)
+	if stdout.Len() == 0 {
+		return code, nil
+	}
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("Expected a JSON result, got %q: %s", stdout.String(), err)
+	}
+	return code, result
+}
+
+func TestRun(t *testing.T) {
+	t.Run("redirects the traffic of an injected pod on ADD", func(t *testing.T) {
+		cluster := &fakeCluster{pod: injectedPod()}
+		code, result := runPlugin(t, cluster.plugin(), addArgs(), netConf)
+		if code != 0 {
+			t.Fatalf("Expected the plugin to succeed, got %d: %v", code, result)
+		}
+
+		if len(cluster.configured) != 1 {
+			t.Fatalf("Expected the rules to be configured once, got %d", len(cluster.configured))
+		}
+		configured := cluster.configured[0]
+		if configured.NetNs != "/proc/42/ns/net" || configured.ProxyUid != 2102 || !reflect.DeepEqual(configured.InboundPortsToIgnore, []int{4190, 9998}) {
+			t.Fatalf("Unexpected rules: %+v", configured)
+		}
+
+		expected := map[string]interface{}{
+			"cniVersion": "0.3.1",
+			"ips":        []interface{}{map[string]interface{}{"version": "4", "address": "10.1.2.3/24"}},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("Expected the previous result to be passed through, got %v", result)
+		}
+	})
+
+	t.Run("detects the pod's IPv6 addresses in the previous result", func(t *testing.T) {
+		ipv4Result := `"prevResult": {"cniVersion": "0.3.1", "ips": [{"version": "4", "address": "10.1.2.3/24"}]}`
+		dualStackResult := `"prevResult": {"cniVersion": "0.3.1", "ips": [{"version": "4", "address": "10.1.2.3/24"}, {"version": "6", "address": "fd00:10:244::3/64"}]}`
+		ipv6Result := `"prevResult": {"cniVersion": "0.3.1", "ips": [{"version": "6", "address": "fd00:10:244::3/64"}]}`
+
+		testCases := []struct {
+			name       string
+			ipv6Mode   string
+			prevResult string
+			expected   string
+		}{
+			{"IPv4-only pod", iptables.AutoIPv6Mode, ipv4Result, iptables.DisabledIPv6Mode},
+			{"dual-stack pod", iptables.AutoIPv6Mode, dualStackResult, iptables.EnabledIPv6Mode},
+			{"IPv6-only pod", iptables.AutoIPv6Mode, ipv6Result, iptables.EnabledIPv6Mode},
+			{"IPv6 disabled on a dual-stack pod", iptables.DisabledIPv6Mode, dualStackResult, iptables.DisabledIPv6Mode},
+		}
+
+		for _, tc := range testCases {
+			tc := tc // pin
+			t.Run(tc.name, func(t *testing.T) {
+				pod := injectedPod()
+				pod.Annotations[k8s.ProxyInitIPv6ModeAnnotation] = tc.ipv6Mode
+				cluster := &fakeCluster{pod: pod}
+				config := strings.Replace(netConf, ipv4Result, tc.prevResult, 1)
+				if code, result := runPlugin(t, cluster.plugin(), addArgs(), config); code != 0 {
+					t.Fatalf("Expected the plugin to succeed, got %d: %v", code, result)
+				}
+				if len(cluster.configured) != 1 || cluster.configured[0].IPv6Mode != tc.expected {
+					t.Fatalf("Expected the rules to be configured with the %s IPv6 mode, got %+v", tc.expected, cluster.configured)
+				}
+			})
+		}
+	})
+
+	t.Run("skips pods that it doesn't redirect the traffic of", func(t *testing.T) {
+		cluster := &fakeCluster{pod: injectedPod(proxyInitContainerName)}
+		if code, result := runPlugin(t, cluster.plugin(), addArgs(), netConf); code != 0 {
+			t.Fatalf("Expected the plugin to succeed, got %d: %v", code, result)
+		}
+		if len(cluster.configured) != 0 {
+			t.Fatalf("Expected no rules to be configured, got %v", cluster.configured)
+		}
+	})
+
+	t.Run("skips sandboxes that aren't pods", func(t *testing.T) {
+		cluster := &fakeCluster{getErr: errors.New("not a pod")}
+		args := addArgs()
+		args.Args = ""
+		if code, result := runPlugin(t, cluster.plugin(), args, netConf); code != 0 {
+			t.Fatalf("Expected the plugin to succeed, got %d: %v", code, result)
+		}
+	})
+
+	t.Run("returns a result without a previous result", func(t *testing.T) {
+		cluster := &fakeCluster{pod: injectedPod()}
+		config := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(netConf), &config); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		delete(config, "prevResult")
+		withoutPrevResult, err := json.Marshal(config)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		code, result := runPlugin(t, cluster.plugin(), addArgs(), string(withoutPrevResult))
+		if code != 0 {
+			t.Fatalf("Expected the plugin to succeed, got %d: %v", code, result)
+		}
+		if !reflect.DeepEqual(result, map[string]interface{}{"cniVersion": "0.3.1"}) {
+			t.Fatalf("Unexpected result: %v", result)
+		}
+	})
+
+	t.Run("does nothing on DEL and CHECK", func(t *testing.T) {
+		for _, command := range []string{"DEL", "CHECK"} {
+			cluster := &fakeCluster{pod: injectedPod()}
+			args := addArgs()
+			args.Command = command
+			code, result := runPlugin(t, cluster.plugin(), args, netConf)
+			if code != 0 || result != nil {
+				t.Fatalf("Expected %s to succeed without a result, got %d: %v", command, code, result)
+			}
+			if len(cluster.configured) != 0 {
+				t.Fatalf("Expected %s to configure no rules, got %v", command, cluster.configured)
+			}
+		}
+	})
+
+	t.Run("reports the supported versions", func(t *testing.T) {
+		code, result := runPlugin(t, (&fakeCluster{}).plugin(), Args{Command: "VERSION"}, "")
+		if code != 0 {
+			t.Fatalf("Expected the plugin to succeed, got %d: %v", code, result)
+		}
+		expected := map[string]interface{}{
+			"cniVersion":        "0.4.0",
+			"supportedVersions": []interface{}{"0.3.0", "0.3.1", "0.4.0"},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
+	})
+
+	errorCases := []struct {
+		name     string
+		cluster  *fakeCluster
+		command  string
+		config   string
+		expected map[string]interface{}
+	}{
+		{
+			name:    "invalid configuration",
+			cluster: &fakeCluster{},
+			command: "ADD",
+			config:  "{",
+			expected: map[string]interface{}{
+				"cniVersion": "0.4.0",
+				"code":       float64(errCodeDecodingFailure),
+				"msg":        "failed to parse the network configuration",
+				"details":    "unexpected end of JSON input",
+			},
+		},
+		{
+			name:    "unsupported version",
+			cluster: &fakeCluster{},
+			command: "ADD",
+			config:  strings.Replace(netConf, `"cniVersion": "0.3.1",`, `"cniVersion": "0.2.0",`, 1),
+			expected: map[string]interface{}{
+				"cniVersion": "0.4.0",
+				"code":       float64(errCodeInvalidConfig),
+				"msg":        "unsupported cniVersion [0.2.0], must be one of: 0.3.0, 0.3.1, 0.4.0",
+			},
+		},
+		{
+			name:    "missing kubeconfig",
+			cluster: &fakeCluster{},
+			command: "ADD",
+			config:  strings.Replace(netConf, `"kubeconfig": "/etc/cni/net.d/ZZZ-linkerd-cni-kubeconfig"`, `"kubeconfig": ""`, 1),
+			expected: map[string]interface{}{
+				"cniVersion": "0.3.1",
+				"code":       float64(errCodeInvalidConfig),
+				"msg":        "the network configuration has no kubernetes.kubeconfig",
+			},
+		},
+		{
+			name:    "unknown command",
+			cluster: &fakeCluster{},
+			command: "UPDATE",
+			config:  netConf,
+			expected: map[string]interface{}{
+				"cniVersion": "0.3.1",
+				"code":       float64(errCodeInvalidEnvironment),
+				"msg":        "unknown CNI_COMMAND [UPDATE]",
+			},
+		},
+		{
+			name:    "pod that can't be found",
+			cluster: &fakeCluster{getErr: errors.New("pods \"web-1\" not found")},
+			command: "ADD",
+			config:  netConf,
+			expected: map[string]interface{}{
+				"cniVersion": "0.3.1",
+				"code":       float64(errCodeFirewallFailure),
+				"msg":        "failed to redirect the pod's traffic to its proxy",
+				"details":    "failed to get pod emojivoto/web-1: pods \"web-1\" not found",
+			},
+		},
+	}
+
+	for _, tc := range errorCases {
+		tc := tc // pin
+		t.Run("fails on "+tc.name, func(t *testing.T) {
+			args := addArgs()
+			args.Command = tc.command
+			code, result := runPlugin(t, tc.cluster.plugin(), args, tc.config)
+			if code != 1 {
+				t.Fatalf("Expected the plugin to fail, got %d", code)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Fatalf("Expected error %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
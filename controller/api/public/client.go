@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -78,7 +79,7 @@ func (c *grpcOverHttpClient) TapByResource(ctx context.Context, req *pb.TapByRes
 		httpRsp.Body.Close()
 	}()
 
-	return &tapClient{ctx: ctx, reader: bufio.NewReader(httpRsp.Body)}, nil
+	return &tapClient{ctx: ctx, reader: bufio.NewReader(httpRsp.Body), header: httpRsp.Header}, nil
 }
 
 func (c *grpcOverHttpClient) apiRequest(ctx context.Context, endpoint string, req proto.Message, protoResponse proto.Message) error {
@@ -137,6 +138,7 @@ func (c *grpcOverHttpClient) endpointNameToPublicApiUrl(endpoint string) *url.UR
 type tapClient struct {
 	ctx    context.Context
 	reader *bufio.Reader
+	header http.Header
 }
 
 func (c tapClient) Recv() (*pb.TapEvent, error) {
@@ -145,13 +147,23 @@ func (c tapClient) Recv() (*pb.TapEvent, error) {
 	return &msg, err
 }
 
+// Header returns the header of the tap server that the session is served by.
+func (c tapClient) Header() (metadata.MD, error) {
+	md := metadata.MD{}
+	for _, key := range tapServerHeaders {
+		if values := c.header[http.CanonicalHeaderKey(key)]; len(values) > 0 {
+			md[key] = values
+		}
+	}
+	return md, nil
+}
+
 // satisfy the pb.Api_TapClient interface
-func (c tapClient) Header() (metadata.MD, error) { return nil, nil }
-func (c tapClient) Trailer() metadata.MD         { return nil }
-func (c tapClient) CloseSend() error             { return nil }
-func (c tapClient) Context() context.Context     { return c.ctx }
-func (c tapClient) SendMsg(interface{}) error    { return nil }
-func (c tapClient) RecvMsg(interface{}) error    { return nil }
+func (c tapClient) Trailer() metadata.MD      { return nil }
+func (c tapClient) CloseSend() error          { return nil }
+func (c tapClient) Context() context.Context  { return c.ctx }
+func (c tapClient) SendMsg(interface{}) error { return nil }
+func (c tapClient) RecvMsg(interface{}) error { return nil }
 
 func fromByteStreamToProtocolBuffers(byteStreamContainingMessage *bufio.Reader, out proto.Message) error {
 	messageAsBytes, err := deserializePayloadFromReader(byteStreamContainingMessage)
@@ -192,8 +204,33 @@ func NewInternalClient(kubernetesApiHost string) (pb.ApiClient, error) {
 	return newClient(apiURL, http.DefaultClient)
 }
 
+// NewInternalTLSClient returns a client for the public API served with TLS at
+// kubernetesApiHost, using tlsConfig to verify the server's certificate.
+func NewInternalTLSClient(kubernetesApiHost string, tlsConfig *tls.Config) (pb.ApiClient, error) {
+	apiURL, err := url.Parse(fmt.Sprintf("https://%s/", kubernetesApiHost))
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+
+	return newClient(apiURL, httpClient)
+}
+
 func NewExternalClient(controlPlaneNamespace string, kubeApi k8s.KubernetesApi) (pb.ApiClient, error) {
-	apiURL, err := kubeApi.UrlFor(controlPlaneNamespace, "/services/http:api:http/proxy/")
+	return newExternalClient(controlPlaneNamespace, kubeApi, "/services/http:api:http/proxy/")
+}
+
+// NewExternalTLSClient returns a client that reaches the public API's TLS
+// port through the Kubernetes API server's service proxy.
+func NewExternalTLSClient(controlPlaneNamespace string, kubeApi k8s.KubernetesApi) (pb.ApiClient, error) {
+	return newExternalClient(controlPlaneNamespace, kubeApi, "/services/https:api:https/proxy/")
+}
+
+func newExternalClient(controlPlaneNamespace string, kubeApi k8s.KubernetesApi, proxyPath string) (pb.ApiClient, error) {
+	apiURL, err := kubeApi.UrlFor(controlPlaneNamespace, proxyPath)
 	if err != nil {
 		return nil, err
 	}
@@ -7,13 +7,13 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/linkerd/linkerd2/controller/api/util"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	tapPb "github.com/linkerd/linkerd2/controller/gen/controller/tap"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/version"
-	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -23,11 +23,13 @@ import (
 
 type (
 	grpcServer struct {
-		prometheusAPI       promv1.API
+		promShards          []promShard
 		tapClient           tapPb.TapClient
 		k8sAPI              *k8s.API
 		controllerNamespace string
 		ignoredNamespaces   []string
+		recordedSeries      *recordedSeries
+		queryMetrics        *promQueryMetrics
 	}
 )
 
@@ -37,7 +39,6 @@ type podReport struct {
 }
 
 const (
-	podQuery                   = "max(process_start_time_seconds{%s}) by (pod, namespace)"
 	K8sClientSubsystemName     = "kubernetes"
 	K8sClientCheckDescription  = "control plane can talk to Kubernetes"
 	PromClientSubsystemName    = "prometheus"
@@ -45,18 +46,19 @@ const (
 )
 
 func newGrpcServer(
-	promAPI promv1.API,
+	promShards []promShard,
 	tapClient tapPb.TapClient,
 	k8sAPI *k8s.API,
 	controllerNamespace string,
 	ignoredNamespaces []string,
 ) *grpcServer {
 	return &grpcServer{
-		prometheusAPI:       promAPI,
+		promShards:          promShards,
 		tapClient:           tapClient,
 		k8sAPI:              k8sAPI,
 		controllerNamespace: controllerNamespace,
 		ignoredNamespaces:   ignoredNamespaces,
+		recordedSeries:      &recordedSeries{},
 	}
 }
 
@@ -71,14 +73,8 @@ func (s *grpcServer) ListPods(ctx context.Context, req *pb.ListPodsRequest) (*pb
 	// report from that instance and its process start time
 	reports := make(map[string]podReport)
 
-	nsQuery := ""
-	if req.GetNamespace() != "" {
-		nsQuery = fmt.Sprintf("namespace=\"%s\"", req.GetNamespace())
-	}
-	processStartTimeQuery := fmt.Sprintf(podQuery, nsQuery)
-
 	// Query Prometheus for all pods present
-	vec, err := s.queryProm(ctx, processStartTimeQuery)
+	vec, err := s.queryProm(ctx, podsQueryName, podsQuery(req.GetNamespace()), maxSamples)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +183,7 @@ func (s *grpcServer) SelfCheck(ctx context.Context, in *healthcheckPb.SelfCheckR
 		CheckDescription: PromClientCheckDescription,
 		Status:           healthcheckPb.CheckStatus_OK,
 	}
-	_, err = s.queryProm(ctx, fmt.Sprintf(podQuery, ""))
+	_, err = s.queryProm(ctx, podsQueryName, podsQuery(""), maxSamples)
 	if err != nil {
 		promClientCheck.Status = healthcheckPb.CheckStatus_ERROR
 		promClientCheck.FriendlyMessageToUser = fmt.Sprintf("Error talking to Prometheus from control plane: %s", err.Error())
@@ -214,6 +210,14 @@ func (s *grpcServer) TapByResource(req *pb.TapByResourceRequest, stream pb.Api_T
 		log.Errorf("Unexpected error tapping [%v]: %v", req, err)
 		return err
 	}
+
+	// pass on the tap server's header as soon as the session begins; a session
+	// that fails to begin has no such header, and Recv returns its error
+	header, err := tapClient.Header()
+	if err == nil && len(header[util.TapServerPodHeader]) > 0 {
+		tapStream.SendHeader(header)
+	}
+
 	for {
 		select {
 		case <-tapStream.Context().Done():
@@ -154,7 +154,7 @@ spec:
 			}
 
 			fakeGrpcServer := newGrpcServer(
-				&MockProm{Res: exp.promRes},
+				[]promShard{{api: &MockProm{Res: exp.promRes}}},
 				tap.NewTapClient(nil),
 				k8sAPI,
 				"linkerd",
@@ -0,0 +1,198 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+	k8sV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// The heartbeat metrics are a small set of mesh-wide KPIs, recomputed every
+// heartbeat interval, that are cheap enough to keep for much longer than the
+// proxy metrics they are computed from. They are served on their own metrics
+// path, for federation, and optionally pushed to a Prometheus pushgateway.
+const (
+	// heartbeatPodsMetric is the number of pending or running pods, outside
+	// of the ignored namespaces.
+	heartbeatPodsMetric = "linkerd_heartbeat_pods"
+
+	// heartbeatMeshedPodsMetric is the number of those pods that are meshed.
+	heartbeatMeshedPodsMetric = "linkerd_heartbeat_meshed_pods"
+
+	// heartbeatRequestRateMetric is the mesh-wide rate of inbound requests
+	// over the last heartbeat interval, per second.
+	heartbeatRequestRateMetric = "linkerd_heartbeat_request_rate"
+
+	// heartbeatSuccessRateMetric is the proportion of those requests that
+	// succeeded. It is unchanged by intervals without requests.
+	heartbeatSuccessRateMetric = "linkerd_heartbeat_success_rate"
+
+	// heartbeatControlPlanePodsMetric is the number of control plane pods, by
+	// component and by the version of Linkerd that installed them.
+	heartbeatControlPlanePodsMetric = "linkerd_heartbeat_control_plane_pods"
+
+	// heartbeatTimestampMetric is the time the heartbeat metrics were last
+	// computed successfully, in seconds since the epoch.
+	heartbeatTimestampMetric = "linkerd_heartbeat_timestamp_seconds"
+)
+
+const (
+	// heartbeatPushJob is the job that the heartbeat metrics are pushed to the
+	// pushgateway as, grouped by the control plane namespace so that each
+	// controller replica replaces the metrics of the others.
+	heartbeatPushJob           = "linkerd-heartbeat"
+	heartbeatPushGroupingLabel = "controller_namespace"
+
+	// unknownVersion is the version of control plane pods that have no
+	// created-by annotation.
+	unknownVersion = "unknown"
+)
+
+// HeartbeatConfig configures how often the heartbeat metrics are computed, and
+// the pushgateway they are pushed to, if any.
+type HeartbeatConfig struct {
+	Interval       time.Duration
+	PushgatewayURL string
+}
+
+// Heartbeat holds the heartbeat metrics, in a registry of their own.
+type Heartbeat struct {
+	*prometheus.Registry
+	config HeartbeatConfig
+
+	pods             prometheus.Gauge
+	meshedPods       prometheus.Gauge
+	requestRate      prometheus.Gauge
+	successRate      prometheus.Gauge
+	controlPlanePods *prometheus.GaugeVec
+	timestamp        prometheus.Gauge
+}
+
+// NewHeartbeat returns the heartbeat metrics, which the public API server that
+// it is passed to keeps up to date.
+func NewHeartbeat(config HeartbeatConfig) *Heartbeat {
+	h := &Heartbeat{
+		Registry: prometheus.NewRegistry(),
+		config:   config,
+		pods: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: heartbeatPodsMetric,
+			Help: "The number of pending or running pods, outside of ignored namespaces.",
+		}),
+		meshedPods: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: heartbeatMeshedPodsMetric,
+			Help: "The number of pending or running pods that are meshed, outside of ignored namespaces.",
+		}),
+		requestRate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: heartbeatRequestRateMetric,
+			Help: "The mesh-wide rate of inbound requests over the last heartbeat interval, per second.",
+		}),
+		successRate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: heartbeatSuccessRateMetric,
+			Help: "The proportion of inbound requests that succeeded, over the last heartbeat interval that had requests.",
+		}),
+		controlPlanePods: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: heartbeatControlPlanePodsMetric,
+				Help: "The number of control plane pods, by component and version.",
+			},
+			[]string{"component", "version"},
+		),
+		timestamp: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: heartbeatTimestampMetric,
+			Help: "The time the heartbeat metrics were last computed successfully, in seconds since the epoch.",
+		}),
+	}
+	h.MustRegister(h.pods, h.meshedPods, h.requestRate, h.successRate, h.controlPlanePods, h.timestamp)
+	return h
+}
+
+// runHeartbeat updates h every heartbeat interval, and pushes it to the
+// pushgateway if one is configured.
+func (s *grpcServer) runHeartbeat(h *Heartbeat) {
+	for range time.Tick(h.config.Interval) {
+		ctx, cancel := context.WithTimeout(context.Background(), h.config.Interval)
+		if err := s.updateHeartbeat(ctx, h, time.Now()); err != nil {
+			log.Warnf("Failed to update heartbeat metrics: %s", err)
+		}
+		cancel()
+
+		if h.config.PushgatewayURL != "" {
+			if err := h.push(s.controllerNamespace); err != nil {
+				log.Warnf("Failed to push heartbeat metrics: %s", err)
+			}
+		}
+	}
+}
+
+// updateHeartbeat recomputes the heartbeat metrics, from the pods that the
+// informers know of and the proxy metrics over the last heartbeat interval.
+func (s *grpcServer) updateHeartbeat(ctx context.Context, h *Heartbeat, now time.Time) error {
+	pods, err := s.k8sAPI.Pod().Lister().List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	var total, meshed float64
+	h.controlPlanePods.Reset()
+	for _, pod := range pods {
+		if pod.Namespace == s.controllerNamespace && pod.Labels[pkgK8s.ControllerComponentLabel] != "" {
+			h.controlPlanePods.WithLabelValues(pod.Labels[pkgK8s.ControllerComponentLabel], podVersion(pod)).Inc()
+		}
+
+		if s.shouldIgnore(pod) || (pod.Status.Phase != k8sV1.PodPending && pod.Status.Phase != k8sV1.PodRunning) {
+			continue
+		}
+		total++
+		if pkgK8s.IsMeshed(pod, s.controllerNamespace) {
+			meshed++
+		}
+	}
+	h.pods.Set(total)
+	h.meshedPods.Set(meshed)
+
+	window := fmt.Sprintf("%ds", int(h.config.Interval.Seconds()))
+	vec, err := s.queryProm(ctx, heartbeatRequestsQueryName, heartbeatRequestsQuery(window), sumSamples)
+	if err != nil {
+		return err
+	}
+
+	var requests, successes float64
+	for _, sample := range vec {
+		requests += float64(sample.Value)
+		if sample.Metric[model.LabelName("classification")] == "success" {
+			successes += float64(sample.Value)
+		}
+	}
+	h.requestRate.Set(requests)
+	if requests > 0 {
+		h.successRate.Set(successes / requests)
+	}
+
+	h.timestamp.Set(float64(now.Unix()))
+	return nil
+}
+
+// podVersion returns the version of Linkerd that created pod, according to its
+// created-by annotation.
+func podVersion(pod *k8sV1.Pod) string {
+	parts := strings.Fields(pod.Annotations[pkgK8s.CreatedByAnnotation])
+	if len(parts) == 0 {
+		return unknownVersion
+	}
+	return parts[len(parts)-1]
+}
+
+// push replaces the heartbeat metrics of the control plane in
+// controllerNamespace on the pushgateway with h.
+func (h *Heartbeat) push(controllerNamespace string) error {
+	grouping := map[string]string{heartbeatPushGroupingLabel: controllerNamespace}
+	return push.FromGatherer(heartbeatPushJob, grouping, h.config.PushgatewayURL, h)
+}
@@ -0,0 +1,283 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/k8s"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+)
+
+var heartbeatPods = []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-pending
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Pending
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-not-meshed
+  namespace: emojivoto
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-failed
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Failed
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: kube-dns
+  namespace: kube-system
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: controller-new
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: controller
+    linkerd.io/control-plane-ns: linkerd
+  annotations:
+    linkerd.io/created-by: linkerd/cli stable-2.1.0
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: controller-old
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: controller
+    linkerd.io/control-plane-ns: linkerd
+  annotations:
+    linkerd.io/created-by: linkerd/cli stable-2.0.0
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: prometheus
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: prometheus
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+}
+
+func newHeartbeatServer(t *testing.T, prom *shardProm) *grpcServer {
+	k8sAPI, err := k8s.NewFakeAPI(heartbeatPods...)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	server := newGrpcServer([]promShard{{"http://prometheus", prom}}, nil, k8sAPI, "linkerd", []string{"kube-system"})
+	k8sAPI.Sync(nil)
+	return server
+}
+
+// gatherHeartbeat returns the heartbeat metrics in h, keyed by name.
+func gatherHeartbeat(t *testing.T, h *Heartbeat) map[string][]*dto.Metric {
+	families, err := h.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	metrics := make(map[string][]*dto.Metric)
+	for _, family := range families {
+		metrics[family.GetName()] = family.GetMetric()
+	}
+	return metrics
+}
+
+func gaugeValue(t *testing.T, metrics map[string][]*dto.Metric, name string) float64 {
+	if len(metrics[name]) != 1 {
+		t.Fatalf("Expected 1 %s metric, got %v", name, metrics[name])
+	}
+	return metrics[name][0].GetGauge().GetValue()
+}
+
+func TestUpdateHeartbeat(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+
+	t.Run("Computes the heartbeat metrics", func(t *testing.T) {
+		prom := &shardProm{results: map[string]model.Vector{
+			"sum(rate(response_total": {
+				&model.Sample{Metric: model.Metric{"classification": "success"}, Value: 9},
+				&model.Sample{Metric: model.Metric{"classification": "failure"}, Value: 1},
+			},
+		}}
+		server := newHeartbeatServer(t, prom)
+		h := NewHeartbeat(HeartbeatConfig{Interval: 2 * time.Minute})
+
+		if err := server.updateHeartbeat(context.Background(), h, now); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expectedQuery := `sum(rate(response_total{direction="inbound"}[120s])) by (classification)`
+		if len(prom.QueriesExecuted) != 1 || prom.QueriesExecuted[0] != expectedQuery {
+			t.Fatalf("Expected query [%s], got %v", expectedQuery, prom.QueriesExecuted)
+		}
+
+		metrics := gatherHeartbeat(t, h)
+		expectations := map[string]float64{
+			heartbeatPodsMetric:        6,
+			heartbeatMeshedPodsMetric:  5,
+			heartbeatRequestRateMetric: 10,
+			heartbeatSuccessRateMetric: 0.9,
+			heartbeatTimestampMetric:   1500000000,
+		}
+		for name, expected := range expectations {
+			if value := gaugeValue(t, metrics, name); value != expected {
+				t.Fatalf("Expected %s to be %v, got %v", name, expected, value)
+			}
+		}
+
+		versions := make(map[[2]string]float64)
+		for _, metric := range metrics[heartbeatControlPlanePodsMetric] {
+			versions[[2]string{labelValue(metric, "component"), labelValue(metric, "version")}] = metric.GetGauge().GetValue()
+		}
+		expectedVersions := map[[2]string]float64{
+			{"controller", "stable-2.1.0"}: 1,
+			{"controller", "stable-2.0.0"}: 1,
+			{"prometheus", unknownVersion}: 1,
+		}
+		if len(versions) != len(expectedVersions) {
+			t.Fatalf("Expected control plane pods %v, got %v", expectedVersions, versions)
+		}
+		for key, count := range expectedVersions {
+			if versions[key] != count {
+				t.Fatalf("Expected control plane pods %v, got %v", expectedVersions, versions)
+			}
+		}
+	})
+
+	t.Run("Keeps the success rate when there are no requests", func(t *testing.T) {
+		prom := &shardProm{results: map[string]model.Vector{
+			"sum(rate(response_total": {
+				&model.Sample{Metric: model.Metric{"classification": "success"}, Value: 1},
+				&model.Sample{Metric: model.Metric{"classification": "failure"}, Value: 1},
+			},
+		}}
+		server := newHeartbeatServer(t, prom)
+		h := NewHeartbeat(HeartbeatConfig{Interval: time.Minute})
+
+		if err := server.updateHeartbeat(context.Background(), h, now); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		prom.results = map[string]model.Vector{}
+		if err := server.updateHeartbeat(context.Background(), h, now.Add(time.Minute)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		metrics := gatherHeartbeat(t, h)
+		if value := gaugeValue(t, metrics, heartbeatRequestRateMetric); value != 0 {
+			t.Fatalf("Expected no requests, got %v", value)
+		}
+		if value := gaugeValue(t, metrics, heartbeatSuccessRateMetric); value != 0.5 {
+			t.Fatalf("Expected the previous success rate, got %v", value)
+		}
+	})
+
+	t.Run("Does not update the timestamp when Prometheus fails", func(t *testing.T) {
+		prom := &shardProm{err: errors.New("connection refused")}
+		server := newHeartbeatServer(t, prom)
+		h := NewHeartbeat(HeartbeatConfig{Interval: time.Minute})
+
+		if err := server.updateHeartbeat(context.Background(), h, now); err == nil {
+			t.Fatalf("Expected an error")
+		}
+
+		metrics := gatherHeartbeat(t, h)
+		if value := gaugeValue(t, metrics, heartbeatMeshedPodsMetric); value != 5 {
+			t.Fatalf("Expected the meshed pods to be updated, got %v", value)
+		}
+		if value := gaugeValue(t, metrics, heartbeatTimestampMetric); value != 0 {
+			t.Fatalf("Expected no timestamp, got %v", value)
+		}
+	})
+}
+
+func TestHeartbeatPush(t *testing.T) {
+	var method, path string
+	var pushed []string
+	pushgateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		method, path = req.Method, req.URL.Path
+
+		decoder := expfmt.NewDecoder(req.Body, expfmt.ResponseFormat(req.Header))
+		for {
+			var family dto.MetricFamily
+			if err := decoder.Decode(&family); err != nil {
+				break
+			}
+			pushed = append(pushed, family.GetName())
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer pushgateway.Close()
+
+	h := NewHeartbeat(HeartbeatConfig{Interval: time.Minute, PushgatewayURL: pushgateway.URL})
+	if err := h.push("linkerd"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if method != http.MethodPut {
+		t.Fatalf("Expected a PUT request, got %s", method)
+	}
+	expectedPath := "/metrics/job/linkerd-heartbeat/controller_namespace/linkerd"
+	if path != expectedPath {
+		t.Fatalf("Expected a push to %s, got %s", expectedPath, path)
+	}
+
+	// The control plane pods metric has no series until the heartbeat is
+	// first updated.
+	expected := []string{
+		heartbeatMeshedPodsMetric,
+		heartbeatPodsMetric,
+		heartbeatRequestRateMetric,
+		heartbeatSuccessRateMetric,
+		heartbeatTimestampMetric,
+	}
+	if len(pushed) != len(expected) {
+		t.Fatalf("Expected metrics %v to be pushed, got %v", expected, pushed)
+	}
+	for i, name := range expected {
+		if pushed[i] != name {
+			t.Fatalf("Expected metrics %v to be pushed, got %v", expected, pushed)
+		}
+	}
+}
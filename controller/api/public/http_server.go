@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	tapPb "github.com/linkerd/linkerd2/controller/gen/controller/tap"
@@ -12,6 +13,7 @@ import (
 	"github.com/linkerd/linkerd2/pkg/prometheus"
 	promApi "github.com/prometheus/client_golang/api"
 	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	promClient "github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/metadata"
 )
@@ -178,35 +180,68 @@ func (s tapServer) Send(msg *pb.TapEvent) error {
 	return nil
 }
 
+// SendHeader sends the tap server's header as HTTP headers, before any
+// events.
+func (s tapServer) SendHeader(md metadata.MD) error {
+	for _, key := range tapServerHeaders {
+		for _, value := range md[key] {
+			s.w.Header().Add(key, value)
+		}
+	}
+
+	s.w.Flush()
+	return nil
+}
+
 // satisfy the pb.Api_TapServer interface
-func (s tapServer) SetHeader(metadata.MD) error  { return nil }
-func (s tapServer) SendHeader(metadata.MD) error { return nil }
-func (s tapServer) SetTrailer(metadata.MD)       {}
-func (s tapServer) Context() context.Context     { return s.req.Context() }
-func (s tapServer) SendMsg(interface{}) error    { return nil }
-func (s tapServer) RecvMsg(interface{}) error    { return nil }
+func (s tapServer) SetHeader(metadata.MD) error { return nil }
+func (s tapServer) SetTrailer(metadata.MD)      {}
+func (s tapServer) Context() context.Context    { return s.req.Context() }
+func (s tapServer) SendMsg(interface{}) error   { return nil }
+func (s tapServer) RecvMsg(interface{}) error   { return nil }
 
 func fullUrlPathFor(method string) string {
 	return apiRoot + apiPrefix + method
 }
 
+// NewServer returns the public API server. prometheusClients holds a client
+// for each of the Prometheus instances that the proxies' metrics are sharded
+// across, keyed by URL. The server keeps heartbeat up to date, if it is set.
 func NewServer(
 	addr string,
-	prometheusClient promApi.Client,
+	prometheusClients map[string]promApi.Client,
 	tapClient tapPb.TapClient,
 	k8sAPI *k8s.API,
 	controllerNamespace string,
 	ignoredNamespaces []string,
+	heartbeat *Heartbeat,
 ) *http.Server {
-	baseHandler := &handler{
-		grpcServer: newGrpcServer(
-			promv1.NewAPI(prometheusClient),
-			tapClient,
-			k8sAPI,
-			controllerNamespace,
-			ignoredNamespaces,
-		),
-	}
+	promShards := make([]promShard, 0, len(prometheusClients))
+	for url, client := range prometheusClients {
+		promShards = append(promShards, promShard{
+			url: url,
+			api: promv1.NewAPI(newRetryingClient(client)),
+		})
+	}
+	sort.Slice(promShards, func(i, j int) bool { return promShards[i].url < promShards[j].url })
+
+	server := newGrpcServer(
+		promShards,
+		tapClient,
+		k8sAPI,
+		controllerNamespace,
+		ignoredNamespaces,
+	)
+	server.queryMetrics = newPromQueryMetrics()
+	promClient.MustRegister(server.queryMetrics)
+	if len(promShards) == 1 {
+		go server.probeRecordedSeriesPeriodically()
+	}
+	if heartbeat != nil {
+		go server.runHeartbeat(heartbeat)
+	}
+
+	baseHandler := &handler{grpcServer: server}
 
 	instrumentedHandler := prometheus.WithTelemetry(baseHandler)
 
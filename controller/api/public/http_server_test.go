@@ -5,17 +5,21 @@ import (
 	"errors"
 	"net"
 	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/linkerd/linkerd2/controller/api/util"
 	healcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc/metadata"
 )
 
 type mockGrpcServer struct {
 	LastRequestReceived proto.Message
 	ResponseToReturn    proto.Message
 	TapStreamsToReturn  []*pb.TapEvent
+	TapHeaderToSend     metadata.MD
 	ErrorToReturn       error
 }
 
@@ -53,6 +57,9 @@ func (m *mockGrpcServer) Tap(req *pb.TapRequest, tapServer pb.Api_TapServer) err
 func (m *mockGrpcServer) TapByResource(req *pb.TapByResourceRequest, tapServer pb.Api_TapByResourceServer) error {
 	m.LastRequestReceived = req
 	if m.ErrorToReturn == nil {
+		if m.TapHeaderToSend != nil {
+			tapServer.SendHeader(m.TapHeaderToSend)
+		}
 		for _, msg := range m.TapStreamsToReturn {
 			tapServer.Send(msg)
 		}
@@ -176,7 +183,13 @@ func TestServer(t *testing.T) {
 				},
 			},
 		}
+		expectedTapHeader := metadata.Pairs(
+			util.TapServerPodHeader, "linkerd-tap-6c9d4c5b7f-x2w8p",
+			util.TapServerIPHeader, "10.1.2.3",
+			util.TapServerProtocolHeader, "h2c",
+		)
 		mockGrpcServer.TapStreamsToReturn = expectedTapResponses
+		mockGrpcServer.TapHeaderToSend = expectedTapHeader
 		mockGrpcServer.ErrorToReturn = nil
 
 		tapClient, err := client.TapByResource(context.TODO(), &pb.TapByResourceRequest{})
@@ -184,6 +197,14 @@ func TestServer(t *testing.T) {
 			t.Fatalf("Unexpected error: %v", err)
 		}
 
+		actualTapHeader, err := tapClient.Header()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(actualTapHeader, expectedTapHeader) {
+			t.Fatalf("Expecting tap header to be [%v], but was [%v]", expectedTapHeader, actualTapHeader)
+		}
+
 		for _, expectedTapEvent := range expectedTapResponses {
 			actualTapEvent, err := tapClient.Recv()
 			if err != nil {
@@ -0,0 +1,301 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	promApi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// promQueryTimeout bounds queries whose request context has no deadline,
+	// including all of their retries.
+	promQueryTimeout = 30 * time.Second
+
+	promQueryMaxAttempts    = 3
+	promQueryInitialBackoff = 100 * time.Millisecond
+
+	// Error types for query failures that are not Prometheus API errors.
+	promErrorConnection       = "connection"
+	promErrorUnexpectedResult = "unexpected_result"
+)
+
+// retryingClient is a Prometheus API client that retries requests that fail
+// to reach Prometheus, or that Prometheus fails with a 5xx response, up to
+// maxAttempts times in total, doubling the backoff between attempts. It stops
+// retrying once the request's context is done.
+type retryingClient struct {
+	promApi.Client
+	maxAttempts    int
+	initialBackoff time.Duration
+}
+
+func newRetryingClient(client promApi.Client) promApi.Client {
+	return &retryingClient{
+		Client:         client,
+		maxAttempts:    promQueryMaxAttempts,
+		initialBackoff: promQueryInitialBackoff,
+	}
+}
+
+func (c *retryingClient) Do(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	backoff := c.initialBackoff
+	for attempt := 1; ; attempt++ {
+		rsp, body, err := c.Client.Do(ctx, req)
+		if attempt >= c.maxAttempts || !shouldRetry(ctx, rsp, err) {
+			return rsp, body, err
+		}
+
+		if err == nil {
+			err = fmt.Errorf("Prometheus returned HTTP status [%s]", rsp.Status)
+		}
+		log.Debugf("Prometheus request %s failed on attempt %d, retrying in %s: %s", req.URL, attempt, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return rsp, body, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func shouldRetry(ctx context.Context, rsp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return rsp.StatusCode >= http.StatusInternalServerError
+}
+
+// promQueryMetrics exposes Prometheus metrics about the queries that the
+// public API makes to Prometheus, labeled by query name (see queries.go).
+//
+// A nil *promQueryMetrics is valid and records nothing.
+type promQueryMetrics struct {
+	latency *prometheus.HistogramVec
+	errors  *prometheus.CounterVec
+}
+
+// newPromQueryMetrics returns a new, unregistered, set of query metrics.
+func newPromQueryMetrics() *promQueryMetrics {
+	return &promQueryMetrics{
+		latency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "public_api_prometheus_query_duration_seconds",
+				Help:    "A histogram of the time taken by queries to Prometheus, including retries, in seconds.",
+				Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+			},
+			[]string{"query"},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "public_api_prometheus_query_errors_total",
+				Help: "A counter of failed queries to Prometheus, by error type.",
+			},
+			[]string{"query", "type"},
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *promQueryMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.latency.Describe(ch)
+	m.errors.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *promQueryMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.latency.Collect(ch)
+	m.errors.Collect(ch)
+}
+
+func (m *promQueryMetrics) observe(ctx context.Context, name string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+
+	m.latency.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(name, promErrorType(ctx, err)).Inc()
+	}
+}
+
+// promErrorType returns the type of err, as reported by the Prometheus API
+// (e.g. "bad_data" or "timeout"), or "connection" if the query did not get a
+// response. Queries that fail because ctx is done are timed out or canceled.
+func promErrorType(ctx context.Context, err error) string {
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return string(promv1.ErrTimeout)
+	case context.Canceled:
+		return string(promv1.ErrCanceled)
+	}
+
+	switch e := err.(type) {
+	case *promv1.Error:
+		return string(e.Type)
+	case unexpectedResultError:
+		return promErrorUnexpectedResult
+	}
+	return promErrorConnection
+}
+
+type unexpectedResultError struct {
+	valueType model.ValueType
+}
+
+func (e unexpectedResultError) Error() string {
+	return fmt.Sprintf("Unexpected query result type (expected Vector): %s", e.valueType)
+}
+
+// promShard is one of the Prometheus instances that the proxies' metrics are
+// sharded across.
+type promShard struct {
+	url string
+	api promv1.API
+}
+
+// sampleMerger combines the values of a sample that several shards returned.
+type sampleMerger func(a, b model.SampleValue) model.SampleValue
+
+func sumSamples(a, b model.SampleValue) model.SampleValue {
+	return a + b
+}
+
+func maxSamples(a, b model.SampleValue) model.SampleValue {
+	if b > a {
+		return b
+	}
+	return a
+}
+
+// mergeVectors merges the vectors returned by each shard into one, combining
+// the values of samples with the same labels with merge. Merged samples keep
+// the latest timestamp.
+func mergeVectors(vecs []model.Vector, merge sampleMerger) model.Vector {
+	if len(vecs) == 1 {
+		return vecs[0]
+	}
+
+	merged := model.Vector{}
+	index := make(map[model.Fingerprint]*model.Sample)
+	for _, vec := range vecs {
+		for _, sample := range vec {
+			fp := sample.Metric.Fingerprint()
+			existing, ok := index[fp]
+			if !ok {
+				copied := *sample
+				index[fp] = &copied
+				merged = append(merged, &copied)
+				continue
+			}
+
+			existing.Value = merge(existing.Value, sample.Value)
+			if sample.Timestamp.After(existing.Timestamp) {
+				existing.Timestamp = sample.Timestamp
+			}
+		}
+	}
+	return merged
+}
+
+// queryProm runs the instant query named name, which must return a vector, on
+// every shard, and merges their results with merge. It fails if any shard
+// fails.
+func (s *grpcServer) queryProm(ctx context.Context, name, query string, merge sampleMerger) (model.Vector, error) {
+	vec, promErrs, err := s.queryPromPartial(ctx, name, query, merge)
+	if err != nil {
+		return nil, err
+	}
+	if len(promErrs) > 0 {
+		return nil, fmt.Errorf("Query to Prometheus at %s failed: %s", promErrs[0].Url, promErrs[0].Error)
+	}
+	return vec, nil
+}
+
+// queryPromPartial is like queryProm, but only fails if every shard fails.
+// Otherwise it returns the merged results of the shards that answered, and the
+// errors of the ones that did not.
+func (s *grpcServer) queryPromPartial(ctx context.Context, name, query string, merge sampleMerger) (model.Vector, []*pb.PrometheusError, error) {
+	type shardResult struct {
+		vec model.Vector
+		err error
+	}
+
+	results := make([]shardResult, len(s.promShards))
+	var wg sync.WaitGroup
+	for i, shard := range s.promShards {
+		wg.Add(1)
+		go func(i int, shard promShard) {
+			defer wg.Done()
+			vec, err := s.queryShard(ctx, shard, name, query)
+			results[i] = shardResult{vec: vec, err: err}
+		}(i, shard)
+	}
+	wg.Wait()
+
+	var vecs []model.Vector
+	var promErrs []*pb.PrometheusError
+	var err error
+	for i, result := range results {
+		if result.err != nil {
+			err = result.err
+			promErrs = append(promErrs, &pb.PrometheusError{Url: s.promShards[i].url, Error: result.err.Error()})
+			continue
+		}
+		vecs = append(vecs, result.vec)
+	}
+	if len(vecs) == 0 {
+		return nil, nil, err
+	}
+
+	return mergeVectors(vecs, merge), promErrs, nil
+}
+
+// queryShard runs the instant query named name on shard. It is bounded by the
+// deadline of ctx, or by promQueryTimeout if ctx has none.
+func (s *grpcServer) queryShard(ctx context.Context, shard promShard, name, query string) (model.Vector, error) {
+	log.Debugf("Query request to %s:\n\t%+v", shard.url, query)
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, promQueryTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	vec, err := queryVector(ctx, shard.api, query)
+	s.queryMetrics.observe(ctx, name, start, err)
+	if err != nil {
+		log.Errorf("Query(%+v) to %s failed with: %+v", query, shard.url, err)
+		return nil, err
+	}
+	log.Debugf("Query response:\n\t%+v", vec)
+
+	return vec, nil
+}
+
+func queryVector(ctx context.Context, api promv1.API, query string) (model.Vector, error) {
+	// single data point (aka summary) query
+	res, err := api.Query(ctx, query, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Type() != model.ValVector {
+		return nil, unexpectedResultError{valueType: res.Type()}
+	}
+
+	return res.(model.Vector), nil
+}
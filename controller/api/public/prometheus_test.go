@@ -0,0 +1,340 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	promApi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/model"
+)
+
+const emptyVectorResponse = `{"status":"success","data":{"resultType":"vector","result":[]}}`
+
+// fakePrometheus is a Prometheus HTTP API that fails the first failures
+// requests it receives with failure, then answers with response.
+type fakePrometheus struct {
+	sync.Mutex
+	failures int
+	failure  func(w http.ResponseWriter)
+	response string
+	requests int
+}
+
+func (f *fakePrometheus) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.Lock()
+	f.requests++
+	fail := f.requests <= f.failures
+	f.Unlock()
+
+	if fail {
+		f.failure(w)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(f.response))
+}
+
+func (f *fakePrometheus) requestCount() int {
+	f.Lock()
+	defer f.Unlock()
+	return f.requests
+}
+
+func respondWithStatus(status int) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.WriteHeader(status)
+	}
+}
+
+// closeConnection fails a request without a response.
+func closeConnection(w http.ResponseWriter) {
+	conn, _, _ := w.(http.Hijacker).Hijack()
+	conn.Close()
+}
+
+func newFakePrometheusServer(t *testing.T, fake *fakePrometheus, backoff time.Duration) (*grpcServer, *promQueryMetrics, func()) {
+	ts := httptest.NewServer(fake)
+
+	client, err := promApi.NewClient(promApi.Config{Address: ts.URL})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	retrying := newRetryingClient(client).(*retryingClient)
+	retrying.initialBackoff = backoff
+
+	server := newGrpcServer([]promShard{{api: promv1.NewAPI(retrying)}}, nil, nil, "linkerd", []string{})
+	server.queryMetrics = newPromQueryMetrics()
+
+	return server, server.queryMetrics, ts.Close
+}
+
+// queryErrors returns the number of query errors recorded in metrics, keyed by
+// query name and error type.
+func queryErrors(t *testing.T, metrics *promQueryMetrics) map[[2]string]float64 {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metrics)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	errors := make(map[[2]string]float64)
+	for _, family := range families {
+		if family.GetName() != "public_api_prometheus_query_errors_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			key := [2]string{labelValue(metric, "query"), labelValue(metric, "type")}
+			errors[key] = metric.GetCounter().GetValue()
+		}
+	}
+	return errors
+}
+
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+func TestQueryProm(t *testing.T) {
+	expectations := []struct {
+		name             string
+		fake             *fakePrometheus
+		expectedRequests int
+		expectedErrors   map[[2]string]float64
+	}{
+		{
+			name:             "Succeeds without retrying",
+			fake:             &fakePrometheus{response: emptyVectorResponse},
+			expectedRequests: 1,
+			expectedErrors:   map[[2]string]float64{},
+		},
+		{
+			name:             "Retries 5xx responses",
+			fake:             &fakePrometheus{failures: 2, failure: respondWithStatus(http.StatusServiceUnavailable), response: emptyVectorResponse},
+			expectedRequests: 3,
+			expectedErrors:   map[[2]string]float64{},
+		},
+		{
+			name:             "Retries connection errors",
+			fake:             &fakePrometheus{failures: 1, failure: closeConnection, response: emptyVectorResponse},
+			expectedRequests: 2,
+			expectedErrors:   map[[2]string]float64{},
+		},
+		{
+			name:             "Gives up after the maximum number of attempts",
+			fake:             &fakePrometheus{failures: 5, failure: respondWithStatus(http.StatusInternalServerError), response: emptyVectorResponse},
+			expectedRequests: promQueryMaxAttempts,
+			expectedErrors:   map[[2]string]float64{{podsQueryName, string(promv1.ErrBadResponse)}: 1},
+		},
+		{
+			name:             "Does not retry 4xx responses",
+			fake:             &fakePrometheus{failures: 1, failure: respondWithStatus(http.StatusBadRequest), response: emptyVectorResponse},
+			expectedRequests: 1,
+			expectedErrors:   map[[2]string]float64{{podsQueryName, string(promv1.ErrBadResponse)}: 1},
+		},
+		{
+			name: "Does not retry query errors",
+			fake: &fakePrometheus{
+				failures: 1,
+				failure: func(w http.ResponseWriter) {
+					w.WriteHeader(422)
+					w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+				},
+			},
+			expectedRequests: 1,
+			expectedErrors:   map[[2]string]float64{{podsQueryName, string(promv1.ErrBadData)}: 1},
+		},
+		{
+			name:             "Rejects results that are not vectors",
+			fake:             &fakePrometheus{response: `{"status":"success","data":{"resultType":"scalar","result":[0,"1"]}}`},
+			expectedRequests: 1,
+			expectedErrors:   map[[2]string]float64{{podsQueryName, promErrorUnexpectedResult}: 1},
+		},
+	}
+
+	for _, exp := range expectations {
+		t.Run(exp.name, func(t *testing.T) {
+			server, metrics, closeServer := newFakePrometheusServer(t, exp.fake, time.Millisecond)
+			defer closeServer()
+
+			_, err := server.queryProm(context.Background(), podsQueryName, podsQuery(""), maxSamples)
+			if (err != nil) != (len(exp.expectedErrors) > 0) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if requests := exp.fake.requestCount(); requests != exp.expectedRequests {
+				t.Fatalf("Expected %d requests to Prometheus, got %d", exp.expectedRequests, requests)
+			}
+
+			errors := queryErrors(t, metrics)
+			if len(errors) != len(exp.expectedErrors) {
+				t.Fatalf("Expected query errors %v, got %v", exp.expectedErrors, errors)
+			}
+			for key, count := range exp.expectedErrors {
+				if errors[key] != count {
+					t.Fatalf("Expected query errors %v, got %v", exp.expectedErrors, errors)
+				}
+			}
+		})
+	}
+
+	t.Run("Stops retrying when the request context is done", func(t *testing.T) {
+		fake := &fakePrometheus{failures: 5, failure: respondWithStatus(http.StatusServiceUnavailable)}
+		server, metrics, closeServer := newFakePrometheusServer(t, fake, time.Hour)
+		defer closeServer()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if _, err := server.queryProm(ctx, podsQueryName, podsQuery(""), maxSamples); err == nil {
+			t.Fatalf("Expected query to time out")
+		}
+
+		if requests := fake.requestCount(); requests != 1 {
+			t.Fatalf("Expected 1 request to Prometheus, got %d", requests)
+		}
+		if errors := queryErrors(t, metrics); errors[[2]string{podsQueryName, string(promv1.ErrTimeout)}] != 1 {
+			t.Fatalf("Expected a timeout error, got %v", errors)
+		}
+	})
+}
+
+// shardProm is a Prometheus shard that answers queries that start with one of
+// the prefixes in results with that prefix's result, or fails every query with
+// err.
+type shardProm struct {
+	MockProm
+	results map[string]model.Vector
+	err     error
+}
+
+func (p *shardProm) Query(ctx context.Context, query string, ts time.Time) (model.Value, error) {
+	p.MockProm.Query(ctx, query, ts)
+	if p.err != nil {
+		return nil, p.err
+	}
+	for prefix, vec := range p.results {
+		if strings.HasPrefix(query, prefix) {
+			return vec, nil
+		}
+	}
+	return model.Vector{}, nil
+}
+
+func podSample(pod string, value float64, timestamp model.Time) *model.Sample {
+	return &model.Sample{
+		Metric:    model.Metric{"namespace": "emojivoto", "pod": model.LabelValue(pod)},
+		Value:     model.SampleValue(value),
+		Timestamp: timestamp,
+	}
+}
+
+func TestMergeVectors(t *testing.T) {
+	shard1 := model.Vector{podSample("web-1", 1, 100), podSample("web-2", 5, 100)}
+	shard2 := model.Vector{podSample("web-2", 3, 200), podSample("web-3", 7, 200)}
+
+	expectations := []struct {
+		name     string
+		merge    sampleMerger
+		expected map[model.LabelValue]float64
+	}{
+		{"Sums samples", sumSamples, map[model.LabelValue]float64{"web-1": 1, "web-2": 8, "web-3": 7}},
+		{"Takes the maximum of samples", maxSamples, map[model.LabelValue]float64{"web-1": 1, "web-2": 5, "web-3": 7}},
+	}
+
+	for _, exp := range expectations {
+		t.Run(exp.name, func(t *testing.T) {
+			merged := mergeVectors([]model.Vector{shard1, shard2}, exp.merge)
+			if len(merged) != len(exp.expected) {
+				t.Fatalf("Expected %d samples, got %v", len(exp.expected), merged)
+			}
+			for _, sample := range merged {
+				pod := sample.Metric["pod"]
+				if float64(sample.Value) != exp.expected[pod] {
+					t.Fatalf("Expected %s to be %v, got %v", pod, exp.expected[pod], sample.Value)
+				}
+				if pod == "web-2" && sample.Timestamp != 200 {
+					t.Fatalf("Expected merged sample to keep the latest timestamp, got %v", sample.Timestamp)
+				}
+			}
+		})
+	}
+
+	t.Run("Does not modify the shards' samples", func(t *testing.T) {
+		mergeVectors([]model.Vector{shard1, shard2}, sumSamples)
+		if shard1[1].Value != 5 || shard1[1].Timestamp != 100 {
+			t.Fatalf("Expected shard sample to be unchanged, got %v", shard1[1])
+		}
+	})
+}
+
+func TestQueryPromPartial(t *testing.T) {
+	healthy := func() *shardProm {
+		return &shardProm{results: map[string]model.Vector{"max(process_start_time_seconds": {podSample("web-1", 1, 100)}}}
+	}
+	failing := func() *shardProm {
+		return &shardProm{err: errors.New("connection refused")}
+	}
+
+	t.Run("Queries every shard", func(t *testing.T) {
+		shards := []*shardProm{healthy(), healthy()}
+		server := newGrpcServer([]promShard{{"http://prometheus-0", shards[0]}, {"http://prometheus-1", shards[1]}}, nil, nil, "linkerd", []string{})
+
+		vec, promErrs, err := server.queryPromPartial(context.Background(), podsQueryName, podsQuery(""), sumSamples)
+		if err != nil || len(promErrs) != 0 {
+			t.Fatalf("Unexpected errors: %v, %v", err, promErrs)
+		}
+		if len(vec) != 1 || vec[0].Value != 2 {
+			t.Fatalf("Expected the sum of both shards' samples, got %v", vec)
+		}
+		for i, shard := range shards {
+			if len(shard.QueriesExecuted) != 1 {
+				t.Fatalf("Expected 1 query to shard %d, got %v", i, shard.QueriesExecuted)
+			}
+		}
+	})
+
+	t.Run("Returns partial results when some shards fail", func(t *testing.T) {
+		server := newGrpcServer([]promShard{{"http://prometheus-0", healthy()}, {"http://prometheus-1", failing()}}, nil, nil, "linkerd", []string{})
+
+		vec, promErrs, err := server.queryPromPartial(context.Background(), podsQueryName, podsQuery(""), sumSamples)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(vec) != 1 || vec[0].Value != 1 {
+			t.Fatalf("Expected the healthy shard's samples, got %v", vec)
+		}
+		expected := []*pb.PrometheusError{{Url: "http://prometheus-1", Error: "connection refused"}}
+		if !reflect.DeepEqual(promErrs, expected) {
+			t.Fatalf("Expected errors %v, got %v", expected, promErrs)
+		}
+
+		if _, err := server.queryProm(context.Background(), podsQueryName, podsQuery(""), sumSamples); err == nil {
+			t.Fatalf("Expected queryProm to fail when a shard fails")
+		}
+	})
+
+	t.Run("Fails when every shard fails", func(t *testing.T) {
+		server := newGrpcServer([]promShard{{"http://prometheus-0", failing()}, {"http://prometheus-1", failing()}}, nil, nil, "linkerd", []string{})
+
+		if _, _, err := server.queryPromPartial(context.Background(), podsQueryName, podsQuery(""), sumSamples); err == nil {
+			t.Fatalf("Expected an error")
+		}
+	})
+}
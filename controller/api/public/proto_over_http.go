@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/linkerd/linkerd2/controller/api/util"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/status"
@@ -23,6 +24,14 @@ const (
 	numBytesForMessageLength   = 4
 )
 
+// tapServerHeaders are the tap server's headers that TapByResource passes on
+// to clients as HTTP headers.
+var tapServerHeaders = []string{
+	util.TapServerPodHeader,
+	util.TapServerIPHeader,
+	util.TapServerProtocolHeader,
+}
+
 type httpError struct {
 	Code         int
 	WrappedError error
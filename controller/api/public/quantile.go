@@ -0,0 +1,138 @@
+package public
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/common/model"
+)
+
+// bucketLabel is the label of histogram buckets that holds their upper bound.
+const bucketLabel = model.LabelName("le")
+
+type bucket struct {
+	upperBound float64
+	count      float64
+}
+
+// histogramQuantile computes the quantile of each histogram in buckets, which
+// holds the cumulative bucket counts of histograms that are identified by
+// their labels other than "le". It is the server-side equivalent of
+// Prometheus' histogram_quantile, for histograms that are merged from several
+// shards.
+func histogramQuantile(quantile promType, buckets model.Vector) model.Vector {
+	q, err := strconv.ParseFloat(string(quantile), 64)
+	if err != nil {
+		return model.Vector{}
+	}
+
+	metrics := make(map[model.Fingerprint]model.Metric)
+	histograms := make(map[model.Fingerprint][]bucket)
+	timestamps := make(map[model.Fingerprint]model.Time)
+	for _, sample := range buckets {
+		upperBound, err := strconv.ParseFloat(string(sample.Metric[bucketLabel]), 64)
+		if err != nil {
+			continue
+		}
+
+		metric := sample.Metric.Clone()
+		delete(metric, bucketLabel)
+		fp := metric.Fingerprint()
+
+		metrics[fp] = metric
+		histograms[fp] = append(histograms[fp], bucket{upperBound: upperBound, count: float64(sample.Value)})
+		if sample.Timestamp.After(timestamps[fp]) {
+			timestamps[fp] = sample.Timestamp
+		}
+	}
+
+	vec := model.Vector{}
+	for fp, histogram := range histograms {
+		vec = append(vec, &model.Sample{
+			Metric:    metrics[fp],
+			Value:     model.SampleValue(bucketQuantile(q, histogram)),
+			Timestamp: timestamps[fp],
+		})
+	}
+	return vec
+}
+
+// bucketQuantile calculates the quantile q of the histogram with the given
+// cumulative buckets, interpolating linearly within the bucket that the
+// quantile falls in, the same way Prometheus does.
+//
+// It returns NaN if the histogram has no +Inf bucket, fewer than two buckets,
+// or no observations. If the quantile falls in the +Inf bucket, it returns the
+// upper bound of the second-highest bucket.
+func bucketQuantile(q float64, buckets []bucket) float64 {
+	if q < 0 {
+		return math.Inf(-1)
+	}
+	if q > 1 {
+		return math.Inf(+1)
+	}
+
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].upperBound < buckets[j].upperBound })
+	buckets = coalesceBuckets(buckets)
+	if len(buckets) < 2 || !math.IsInf(buckets[len(buckets)-1].upperBound, +1) {
+		return math.NaN()
+	}
+	ensureMonotonic(buckets)
+
+	observations := buckets[len(buckets)-1].count
+	if observations == 0 {
+		return math.NaN()
+	}
+
+	rank := q * observations
+	b := sort.Search(len(buckets)-1, func(i int) bool { return buckets[i].count >= rank })
+
+	if b == len(buckets)-1 {
+		return buckets[len(buckets)-2].upperBound
+	}
+	if b == 0 && buckets[0].upperBound <= 0 {
+		return buckets[0].upperBound
+	}
+
+	var (
+		bucketStart float64
+		bucketEnd   = buckets[b].upperBound
+		count       = buckets[b].count
+	)
+	if b > 0 {
+		bucketStart = buckets[b-1].upperBound
+		count -= buckets[b-1].count
+		rank -= buckets[b-1].count
+	}
+	return bucketStart + (bucketEnd-bucketStart)*(rank/count)
+}
+
+// coalesceBuckets merges buckets with the same upper bound, which sorted
+// buckets hold next to each other.
+func coalesceBuckets(buckets []bucket) []bucket {
+	coalesced := buckets[:0]
+	for _, b := range buckets {
+		last := len(coalesced) - 1
+		if last >= 0 && coalesced[last].upperBound == b.upperBound {
+			coalesced[last].count += b.count
+			continue
+		}
+		coalesced = append(coalesced, b)
+	}
+	return coalesced
+}
+
+// ensureMonotonic raises the counts of buckets that are lower than the count
+// of a preceding bucket, which the rates of counters that were reset between
+// scrapes can cause.
+func ensureMonotonic(buckets []bucket) {
+	max := math.Inf(-1)
+	for i := range buckets {
+		if buckets[i].count > max {
+			max = buckets[i].count
+		} else {
+			buckets[i].count = max
+		}
+	}
+}
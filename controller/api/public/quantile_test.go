@@ -0,0 +1,165 @@
+package public
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func bucketSample(labels model.Metric, le string, count float64) *model.Sample {
+	metric := labels.Clone()
+	metric[bucketLabel] = model.LabelValue(le)
+	return &model.Sample{Metric: metric, Value: model.SampleValue(count), Timestamp: 456}
+}
+
+func TestBucketQuantile(t *testing.T) {
+	inf := math.Inf(+1)
+
+	expectations := []struct {
+		name     string
+		q        float64
+		buckets  []bucket
+		expected float64
+	}{
+		{
+			name:     "Interpolates within the first bucket",
+			q:        0.5,
+			buckets:  []bucket{{10, 100}, {100, 100}, {inf, 100}},
+			expected: 5,
+		},
+		{
+			name:     "Interpolates within a later bucket",
+			q:        0.75,
+			buckets:  []bucket{{10, 50}, {100, 100}, {inf, 100}},
+			expected: 55,
+		},
+		{
+			name:     "Sorts buckets by upper bound",
+			q:        0.75,
+			buckets:  []bucket{{inf, 100}, {100, 100}, {10, 50}},
+			expected: 55,
+		},
+		{
+			name:     "Returns the highest finite upper bound for the +Inf bucket",
+			q:        0.99,
+			buckets:  []bucket{{10, 50}, {100, 90}, {inf, 100}},
+			expected: 100,
+		},
+		{
+			name:     "Coalesces buckets with the same upper bound",
+			q:        0.5,
+			buckets:  []bucket{{10, 20}, {10, 30}, {100, 50}, {100, 50}, {inf, 100}},
+			expected: 10,
+		},
+		{
+			name:     "Makes non-monotonic buckets monotonic",
+			q:        0.5,
+			buckets:  []bucket{{10, 60}, {100, 40}, {inf, 100}},
+			expected: 10 * 50.0 / 60.0,
+		},
+		{
+			name:     "Returns -Inf for quantiles below 0",
+			q:        -1,
+			buckets:  []bucket{{10, 100}, {inf, 100}},
+			expected: math.Inf(-1),
+		},
+		{
+			name:     "Returns +Inf for quantiles above 1",
+			q:        2,
+			buckets:  []bucket{{10, 100}, {inf, 100}},
+			expected: inf,
+		},
+		{
+			name:     "Returns NaN without observations",
+			q:        0.5,
+			buckets:  []bucket{{10, 0}, {inf, 0}},
+			expected: math.NaN(),
+		},
+		{
+			name:     "Returns NaN without a +Inf bucket",
+			q:        0.5,
+			buckets:  []bucket{{10, 100}, {100, 100}},
+			expected: math.NaN(),
+		},
+		{
+			name:     "Returns NaN with fewer than two buckets",
+			q:        0.5,
+			buckets:  []bucket{{inf, 100}},
+			expected: math.NaN(),
+		},
+	}
+
+	for _, exp := range expectations {
+		t.Run(exp.name, func(t *testing.T) {
+			actual := bucketQuantile(exp.q, exp.buckets)
+			if math.IsNaN(exp.expected) {
+				if !math.IsNaN(actual) {
+					t.Fatalf("Expected NaN, got %v", actual)
+				}
+				return
+			}
+			if math.Abs(actual-exp.expected) > 1e-9 {
+				t.Fatalf("Expected %v, got %v", exp.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	web := model.Metric{"namespace": "emojivoto", "deployment": "web"}
+	voting := model.Metric{"namespace": "emojivoto", "deployment": "voting"}
+
+	// Each shard's histogram of the web deployment has half of its
+	// observations, in different buckets: merging their quantiles would give
+	// the wrong answer, but merging their buckets gives the right one.
+	shard1 := model.Vector{
+		bucketSample(web, "10", 100),
+		bucketSample(web, "100", 100),
+		bucketSample(web, "1000", 100),
+		bucketSample(web, "+Inf", 100),
+		bucketSample(voting, "10", 10),
+		bucketSample(voting, "100", 20),
+		bucketSample(voting, "1000", 20),
+		bucketSample(voting, "+Inf", 20),
+	}
+	shard2 := model.Vector{
+		bucketSample(web, "10", 0),
+		bucketSample(web, "100", 0),
+		bucketSample(web, "1000", 100),
+		bucketSample(web, "+Inf", 100),
+	}
+	buckets := mergeVectors([]model.Vector{shard1, shard2}, sumSamples)
+
+	expectations := []struct {
+		quantile promType
+		expected map[model.LabelValue]float64
+	}{
+		{promLatencyP50, map[model.LabelValue]float64{"web": 10, "voting": 10}},
+		{promLatencyP95, map[model.LabelValue]float64{"web": 910, "voting": 10 + 90*0.9}},
+		{promLatencyP99, map[model.LabelValue]float64{"web": 982, "voting": 10 + 90*0.98}},
+	}
+
+	for _, exp := range expectations {
+		t.Run(string(exp.quantile), func(t *testing.T) {
+			vec := histogramQuantile(exp.quantile, buckets)
+			if len(vec) != len(exp.expected) {
+				t.Fatalf("Expected %d quantiles, got %v", len(exp.expected), vec)
+			}
+
+			for _, sample := range vec {
+				if _, ok := sample.Metric[bucketLabel]; ok {
+					t.Fatalf("Expected quantile without an le label, got %v", sample.Metric)
+				}
+				if sample.Metric["namespace"] != "emojivoto" {
+					t.Fatalf("Expected quantile to keep its namespace label, got %v", sample.Metric)
+				}
+
+				expected := exp.expected[sample.Metric["deployment"]]
+				if math.Abs(float64(sample.Value)-expected) > 1e-9 {
+					t.Fatalf("Expected %s quantile of %v, got %v", sample.Metric["deployment"], expected, sample.Value)
+				}
+			}
+		})
+	}
+}
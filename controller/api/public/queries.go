@@ -0,0 +1,224 @@
+package public
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	requestsQueryTemplate       = "sum(increase(response_total%s[%s])) by (%s, classification, tls)"
+	latencyQueryTemplate        = "histogram_quantile(%s, sum(irate(response_latency_ms_bucket%s[%s])) by (le, %s))"
+	latencyBucketsQueryTemplate = "sum(irate(response_latency_ms_bucket%s[%s])) by (le, %s)"
+	successRatioQueryTemplate   = "sum(increase(response_total%s[%s])) by (%s) / sum(increase(response_total%s[%s])) by (%s)"
+	podsQueryTemplate           = "max(process_start_time_seconds{%s}) by (pod, namespace)"
+	proxyAgeQueryTemplate       = "max(time() - process_start_time_seconds%s) by (%s)"
+
+	recordedRequestsQueryTemplate = "sum(%s%s) by (%s, classification, tls)"
+	recordedLatencyQueryTemplate  = "max(%s%s) by (%s)"
+	recordedSeriesQueryTemplate   = "count({__name__=~\"%s\"}) by (__name__)"
+
+	heartbeatRequestsQueryTemplate = "sum(rate(response_total%s[%s])) by (classification)"
+)
+
+// podLabelPrefix is the prefix of the proxy metric labels that Prometheus
+// copies the labels of each proxy's pod to.
+const podLabelPrefix = "label_"
+
+// invalidLabelNameChars matches the characters of Kubernetes label keys that
+// Prometheus replaces with "_" in label names.
+var invalidLabelNameChars = regexp.MustCompile("[^a-zA-Z0-9_]")
+
+// podLabelName returns the name of the proxy metric label that holds the
+// value of the pod label key, e.g. label_app_kubernetes_io_version for
+// app.kubernetes.io/version.
+func podLabelName(key string) model.LabelName {
+	return model.LabelName(podLabelPrefix + PodLabelKeyName(key))
+}
+
+// PodLabelKeyName returns the pod label key as Prometheus names it in labels,
+// e.g. app_kubernetes_io_version for app.kubernetes.io/version.
+func PodLabelKeyName(key string) string {
+	return invalidLabelNameChars.ReplaceAllString(key, "_")
+}
+
+// grpcStatusCodeLabel is only set on the metrics of gRPC responses, so it is
+// what tells them apart from those of other HTTP responses.
+const grpcStatusCodeLabel = model.LabelName("grpc_status_code")
+
+// authorityLabel is the :authority of the requests that metrics are for.
+const authorityLabel = model.LabelName("authority")
+
+// regexSelector selects the series matching its labels whose regexes labels
+// also match their regular expressions.
+type regexSelector struct {
+	labels  model.LabelSet
+	regexes map[model.LabelName]string
+}
+
+func (s regexSelector) String() string {
+	matchers := make([]string, 0, len(s.labels))
+	for name, value := range s.labels {
+		matchers = append(matchers, fmt.Sprintf("%s=%q", name, value))
+	}
+	sort.Strings(matchers)
+
+	regexMatchers := make([]string, 0, len(s.regexes))
+	for name, regex := range s.regexes {
+		regexMatchers = append(regexMatchers, fmt.Sprintf("%s=~%q", name, regex))
+	}
+	sort.Strings(regexMatchers)
+
+	return fmt.Sprintf("{%s}", strings.Join(append(matchers, regexMatchers...), ", "))
+}
+
+// promSelector returns the series selector for labels, which only selects the
+// series of gRPC responses if grpcOnly is set, and those of requests whose
+// :authority matches the authority pattern if it is set.
+func promSelector(labels model.LabelSet, grpcOnly bool, authority string) fmt.Stringer {
+	regexes := make(map[model.LabelName]string)
+	if grpcOnly {
+		regexes[grpcStatusCodeLabel] = ".+"
+	}
+	if authority != "" {
+		regexes[authorityLabel] = authorityRegex(authority)
+	}
+
+	if len(regexes) == 0 {
+		return labels
+	}
+	return regexSelector{labels: labels, regexes: regexes}
+}
+
+// authorityRegex converts an authority pattern to the regular expression that
+// Prometheus matches whole label values against. The "*" and "?" wildcards
+// match any number of characters and a single character, and patterns without
+// them match the authorities they prefix.
+func authorityRegex(pattern string) string {
+	if !strings.ContainsAny(pattern, "*?") {
+		return regexp.QuoteMeta(pattern) + ".*"
+	}
+
+	regex := regexp.QuoteMeta(pattern)
+	regex = strings.Replace(regex, `\*`, ".*", -1)
+	regex = strings.Replace(regex, `\?`, ".", -1)
+	return regex
+}
+
+// Query names identify each kind of query in the public API's Prometheus
+// query metrics.
+const (
+	requestsQueryName          = "requests"
+	latencyQueryName           = "latency"
+	latencyBucketsQueryName    = "latency_buckets"
+	recordedRequestsQueryName  = "recorded_requests"
+	recordedLatencyQueryName   = "recorded_latency"
+	podsQueryName              = "pods"
+	proxyAgeQueryName          = "proxy_age"
+	recordedSeriesQueryName    = "recorded_series"
+	heartbeatRequestsQueryName = "heartbeat_requests"
+)
+
+// requestsQuery returns the query for the number of responses matching
+// selector over timeWindow, by classification and TLS status.
+func requestsQuery(selector fmt.Stringer, timeWindow string, groupBy model.LabelNames) string {
+	return fmt.Sprintf(requestsQueryTemplate, selector, timeWindow, groupBy)
+}
+
+// latencyQuery returns the query for the quantile of the latency of responses
+// matching selector over timeWindow.
+func latencyQuery(quantile promType, selector fmt.Stringer, timeWindow string, groupBy model.LabelNames) string {
+	return fmt.Sprintf(latencyQueryTemplate, quantile, selector, timeWindow, groupBy)
+}
+
+// latencyBucketsQuery returns the query for the rate of the latency histogram
+// buckets of responses matching selector over timeWindow, from which the
+// latency quantiles of histograms merged from several shards are calculated.
+func latencyBucketsQuery(selector fmt.Stringer, timeWindow string, groupBy model.LabelNames) string {
+	return fmt.Sprintf(latencyBucketsQueryTemplate, selector, timeWindow, groupBy)
+}
+
+// successRatioQuery returns the query for the proportion of responses matching
+// labels over timeWindow that were classified as successful.
+func successRatioQuery(labels model.LabelSet, timeWindow string, groupBy model.LabelNames) string {
+	successLabels := labels.Merge(model.LabelSet{"classification": "success"})
+	return fmt.Sprintf(successRatioQueryTemplate, successLabels, timeWindow, groupBy, labels, timeWindow, groupBy)
+}
+
+// podsQuery returns the query for the start time of every meshed pod in
+// namespace, or in all namespaces if namespace is empty.
+func podsQuery(namespace string) string {
+	filter := ""
+	if namespace != "" {
+		filter = fmt.Sprintf("namespace=\"%s\"", namespace)
+	}
+	return fmt.Sprintf(podsQueryTemplate, filter)
+}
+
+// proxyAgeQuery returns the query for the age, in seconds, of the oldest proxy
+// matching labels. Other processes that Prometheus scrapes are excluded.
+func proxyAgeQuery(labels model.LabelSet, groupBy model.LabelNames) string {
+	proxyLabels := labels.Merge(model.LabelSet{"job": "linkerd-proxy"})
+	return fmt.Sprintf(proxyAgeQueryTemplate, proxyLabels, groupBy)
+}
+
+// recordedRequestsQuery is the equivalent of requestsQuery for the series
+// recorded as name.
+func recordedRequestsQuery(name string, filters model.LabelSet, groupBy model.LabelNames) string {
+	return fmt.Sprintf(recordedRequestsQueryTemplate, name, filters, groupBy)
+}
+
+// recordedLatencyQuery is the equivalent of latencyQuery for the series
+// recorded as name.
+func recordedLatencyQuery(name string, quantile promType, filters model.LabelSet, groupBy model.LabelNames) string {
+	quantileFilters := filters.Merge(model.LabelSet{quantileLabel: model.LabelValue(quantile)})
+	return fmt.Sprintf(recordedLatencyQueryTemplate, name, quantileFilters, groupBy)
+}
+
+// recordedSeriesQuery returns the query for which of the series names exist.
+func recordedSeriesQuery(names []string) string {
+	return fmt.Sprintf(recordedSeriesQueryTemplate, strings.Join(names, "|"))
+}
+
+// heartbeatRequestsQuery returns the query for the mesh-wide rate of inbound
+// requests over timeWindow, by classification.
+func heartbeatRequestsQuery(timeWindow string) string {
+	return fmt.Sprintf(heartbeatRequestsQueryTemplate, promDirectionLabels("inbound"), timeWindow)
+}
+
+// promResourceTypes are the types of the resources whose stats are queried by
+// their own label. The stats of namespaces are queried by the namespace label,
+// and those of CronJobs are their most recent Job's.
+var promResourceTypes = []string{
+	k8s.Deployment,
+	k8s.Job,
+	k8s.Pod,
+	k8s.ReplicationController,
+	k8s.Service,
+	k8s.ServiceAccount,
+	k8s.Authority,
+}
+
+// RequiredLabelNames returns the names of the proxy metric labels that the
+// public API's queries filter or aggregate by. Prometheus must not drop them.
+func RequiredLabelNames() []string {
+	names := []string{
+		string(namespaceLabel),
+		string(dstNamespaceLabel),
+		"direction",
+		"classification",
+		"tls",
+		"le",
+		string(grpcStatusCodeLabel),
+	}
+	for _, resourceType := range promResourceTypes {
+		name := string(promResourceType(&pb.Resource{Type: resourceType}))
+		names = append(names, name, "dst_"+name)
+	}
+	return names
+}
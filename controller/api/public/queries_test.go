@@ -0,0 +1,170 @@
+package public
+
+import (
+	"regexp"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+)
+
+func TestQueries(t *testing.T) {
+	labels := model.LabelSet{"namespace": "emojivoto", "direction": "inbound"}
+	groupBy := model.LabelNames{"namespace", "deployment"}
+
+	expectations := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			"requests",
+			requestsQuery(labels, "1m", groupBy),
+			`sum(increase(response_total{direction="inbound", namespace="emojivoto"}[1m])) by (namespace, deployment, classification, tls)`,
+		},
+		{
+			"latency",
+			latencyQuery(promLatencyP95, labels, "1m", groupBy),
+			`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment))`,
+		},
+		{
+			"latency buckets",
+			latencyBucketsQuery(labels, "1m", groupBy),
+			`sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment)`,
+		},
+		{
+			"gRPC-only requests",
+			requestsQuery(promSelector(labels, true, ""), "1m", groupBy),
+			`sum(increase(response_total{direction="inbound", namespace="emojivoto", grpc_status_code=~".+"}[1m])) by (namespace, deployment, classification, tls)`,
+		},
+		{
+			"gRPC-only latency",
+			latencyQuery(promLatencyP95, promSelector(labels, true, ""), "1m", groupBy),
+			`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", grpc_status_code=~".+"}[1m])) by (le, namespace, deployment))`,
+		},
+		{
+			"gRPC-only requests without labels",
+			requestsQuery(promSelector(model.LabelSet{}, true, ""), "1m", groupBy),
+			`sum(increase(response_total{grpc_status_code=~".+"}[1m])) by (namespace, deployment, classification, tls)`,
+		},
+		{
+			"requests to an authority",
+			requestsQuery(promSelector(labels, false, "web.emojivoto"), "1m", groupBy),
+			`sum(increase(response_total{direction="inbound", namespace="emojivoto", authority=~"web\\.emojivoto.*"}[1m])) by (namespace, deployment, classification, tls)`,
+		},
+		{
+			"gRPC-only requests to an authority",
+			requestsQuery(promSelector(labels, true, "*.emojivoto:8080"), "1m", groupBy),
+			`sum(increase(response_total{direction="inbound", namespace="emojivoto", authority=~".*\\.emojivoto:8080", grpc_status_code=~".+"}[1m])) by (namespace, deployment, classification, tls)`,
+		},
+		{
+			"success ratio",
+			successRatioQuery(labels, "10s", groupBy),
+			`sum(increase(response_total{classification="success", direction="inbound", namespace="emojivoto"}[10s])) by (namespace, deployment) / sum(increase(response_total{direction="inbound", namespace="emojivoto"}[10s])) by (namespace, deployment)`,
+		},
+		{
+			"pods in all namespaces",
+			podsQuery(""),
+			`max(process_start_time_seconds{}) by (pod, namespace)`,
+		},
+		{
+			"pods in a namespace",
+			podsQuery("emojivoto"),
+			`max(process_start_time_seconds{namespace="emojivoto"}) by (pod, namespace)`,
+		},
+		{
+			"proxy age",
+			proxyAgeQuery(model.LabelSet{"namespace": "emojivoto"}, groupBy),
+			`max(time() - process_start_time_seconds{job="linkerd-proxy", namespace="emojivoto"}) by (namespace, deployment)`,
+		},
+		{
+			"recorded requests",
+			recordedRequestsQuery("deployment:response_total:increase1m", model.LabelSet{"namespace": "emojivoto"}, groupBy),
+			`sum(deployment:response_total:increase1m{namespace="emojivoto"}) by (namespace, deployment, classification, tls)`,
+		},
+		{
+			"recorded latency",
+			recordedLatencyQuery("deployment:response_latency_ms:quantile1m", promLatencyP99, model.LabelSet{}, groupBy),
+			`max(deployment:response_latency_ms:quantile1m{quantile="0.99"}) by (namespace, deployment)`,
+		},
+		{
+			"heartbeat requests",
+			heartbeatRequestsQuery("60s"),
+			`sum(rate(response_total{direction="inbound"}[60s])) by (classification)`,
+		},
+		{
+			"recorded series",
+			recordedSeriesQuery([]string{"a:b:c1m", "a:b:c10m"}),
+			`count({__name__=~"a:b:c1m|a:b:c10m"}) by (__name__)`,
+		},
+	}
+
+	for _, exp := range expectations {
+		t.Run(exp.name, func(t *testing.T) {
+			if exp.query != exp.expected {
+				t.Fatalf("Unexpected query.\nExpected: %s\nGot:      %s", exp.expected, exp.query)
+			}
+		})
+	}
+}
+
+func TestRequiredLabelNames(t *testing.T) {
+	required := make(map[model.LabelName]bool)
+	for _, name := range RequiredLabelNames() {
+		required[model.LabelName(name)] = true
+	}
+
+	// CronJobs are queried as their most recent Job.
+	resourceTypes := []string{
+		k8s.Namespace,
+		k8s.Deployment,
+		k8s.Job,
+		k8s.Pod,
+		k8s.ReplicationController,
+		k8s.Service,
+		k8s.ServiceAccount,
+		k8s.Authority,
+	}
+	for _, resourceType := range resourceTypes {
+		resource := &pb.Resource{Type: resourceType, Namespace: "emojivoto", Name: "web"}
+
+		var names model.LabelNames
+		names = append(names, promGroupByLabelNames(resource)...)
+		names = append(names, promDstGroupByLabelNames(resource)...)
+		for name := range promQueryLabels(resource).Merge(promDstQueryLabels(resource)) {
+			names = append(names, name)
+		}
+
+		for _, name := range names {
+			if !required[name] {
+				t.Fatalf("Stats of resource type [%s] are queried by label [%s], which is not a required label", resourceType, name)
+			}
+		}
+	}
+}
+
+func TestAuthorityRegex(t *testing.T) {
+	testCases := []struct {
+		pattern   string
+		authority string
+		matches   bool
+	}{
+		{"web.emojivoto", "web.emojivoto.svc.cluster.local:80", true},
+		{"web.emojivoto", "webXemojivoto:80", false},
+		{"web.emojivoto", "api.web.emojivoto:80", false},
+		{"*.example.com", "api.example.com", true},
+		{"*.example.com", "api.example.com:443", false},
+		{"*.example.com:*", "api.example.com:443", true},
+		{"api-v?.example.com", "api-v2.example.com", true},
+		{"api-v?.example.com", "api-v10.example.com", false},
+	}
+
+	for _, tc := range testCases {
+		// Prometheus anchors regular expressions at both ends.
+		regex := regexp.MustCompile("^(?:" + authorityRegex(tc.pattern) + ")$")
+		if regex.MatchString(tc.authority) != tc.matches {
+			t.Fatalf("Expected pattern [%s] matching [%s] to be %t", tc.pattern, tc.authority, tc.matches)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	recordedRequestsName = "%s:response_total:increase%s"
+	recordedLatencyName  = "%s:response_latency_ms:quantile%s"
+	recordedSuccessName  = "%s:response_total:success_ratio%s"
+
+	quantileLabel = model.LabelName("quantile")
+
+	// recordedSeriesProbeInterval is how often the public API checks which
+	// recorded series Prometheus has, so that it starts using them once the
+	// recording rules have been evaluated over live traffic.
+	recordedSeriesProbeInterval = 5 * time.Minute
+)
+
+// RecordedTimeWindows are the time windows that the recording rules precompute
+// stats for. StatSummary requests for other windows always query the raw
+// metrics.
+var RecordedTimeWindows = []string{"10s", "1m", "10m", "1h"}
+
+// recordedResourceTypes are the resource types that the recording rules
+// aggregate inbound stats by. Services only have outbound stats.
+var recordedResourceTypes = []string{
+	k8s.Namespace,
+	k8s.Deployment,
+	k8s.ReplicationController,
+	k8s.Pod,
+	k8s.Authority,
+}
+
+// RecordingRuleGroup is a group of Prometheus recording rules that are
+// evaluated together.
+type RecordingRuleGroup struct {
+	Name  string
+	Rules []RecordingRule
+}
+
+// RecordingRule is a Prometheus recording rule, which stores the result of Expr
+// as the series named Record, with the additional Labels.
+type RecordingRule struct {
+	Record string
+	Expr   string
+	Labels map[string]string
+}
+
+// RecordingRuleGroups returns the recording rules that precompute the stats
+// returned by StatSummary, one group per time window. The rules are built from
+// the same queries that StatSummary falls back to when the recorded series are
+// not available.
+func RecordingRuleGroups() []RecordingRuleGroup {
+	groups := make([]RecordingRuleGroup, 0, len(RecordedTimeWindows))
+
+	for _, window := range RecordedTimeWindows {
+		group := RecordingRuleGroup{Name: "linkerd-stats-" + window}
+
+		for _, resourceType := range recordedResourceTypes {
+			req := &pb.StatSummaryRequest{
+				Selector: &pb.ResourceSelection{
+					Resource: &pb.Resource{Type: resourceType},
+				},
+				TimeWindow: window,
+			}
+			labels, groupBy := buildRequestLabels(req)
+
+			group.Rules = append(group.Rules, RecordingRule{
+				Record: recordedName(recordedRequestsName, resourceType, window),
+				Expr:   requestsQuery(labels, window, groupBy),
+			})
+
+			for _, quantile := range []promType{promLatencyP50, promLatencyP95, promLatencyP99} {
+				group.Rules = append(group.Rules, RecordingRule{
+					Record: recordedName(recordedLatencyName, resourceType, window),
+					Expr:   latencyQuery(quantile, labels, window, groupBy),
+					Labels: map[string]string{string(quantileLabel): string(quantile)},
+				})
+			}
+
+			group.Rules = append(group.Rules, RecordingRule{
+				Record: recordedName(recordedSuccessName, resourceType, window),
+				Expr:   successRatioQuery(labels, window, groupBy),
+			})
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+func recordedName(format, resourceType, window string) string {
+	return fmt.Sprintf(format, resourceType, window)
+}
+
+func isRecordedTimeWindow(window string) bool {
+	for _, w := range RecordedTimeWindows {
+		if w == window {
+			return true
+		}
+	}
+	return false
+}
+
+// recordedSeries tracks which of the series produced by the recording rules
+// exist in Prometheus. It is safe for concurrent use.
+type recordedSeries struct {
+	sync.RWMutex
+	names map[string]struct{}
+}
+
+func (r *recordedSeries) has(name string) bool {
+	if r == nil {
+		return false
+	}
+
+	r.RLock()
+	defer r.RUnlock()
+	_, ok := r.names[name]
+	return ok
+}
+
+func (r *recordedSeries) set(names map[string]struct{}) {
+	r.Lock()
+	defer r.Unlock()
+	r.names = names
+}
+
+// recordedQueries returns the queries for the requests and latency stats of
+// req, using recorded series where Prometheus has them. Requests for other
+// time windows, or with outbound filtering, always use the raw queries, as do
+// all requests when metrics are sharded across several Prometheus instances.
+func (s *grpcServer) recordedQueries(req *pb.StatSummaryRequest, groupBy model.LabelNames) map[promType]string {
+	queries := make(map[promType]string)
+
+	if req.GetOutbound() != nil && req.GetNone() == nil {
+		return queries
+	}
+	if !isRecordedTimeWindow(req.TimeWindow) {
+		return queries
+	}
+	// The recorded series are not split by pod labels.
+	if req.IncludeLabel != "" {
+		return queries
+	}
+	// The recorded series include the responses of every protocol.
+	if req.GrpcOnly {
+		return queries
+	}
+	// The recorded series are not split by authority.
+	if req.Authority != "" {
+		return queries
+	}
+	// The recorded latency quantiles of several shards cannot be merged.
+	if len(s.promShards) > 1 {
+		return queries
+	}
+	resourceType := req.GetSelector().GetResource().GetType()
+
+	// The recorded series are already filtered by direction.
+	filters := promQueryLabels(req.GetSelector().GetResource())
+
+	requestsName := recordedName(recordedRequestsName, resourceType, req.TimeWindow)
+	if s.recordedSeries.has(requestsName) {
+		queries[promRequests] = recordedRequestsQuery(requestsName, filters, groupBy)
+	}
+
+	latencyName := recordedName(recordedLatencyName, resourceType, req.TimeWindow)
+	if s.recordedSeries.has(latencyName) {
+		for _, quantile := range []promType{promLatencyP50, promLatencyP95, promLatencyP99} {
+			queries[quantile] = recordedLatencyQuery(latencyName, quantile, filters, groupBy)
+		}
+	}
+
+	return queries
+}
+
+// probeRecordedSeries queries Prometheus for the series produced by the
+// recording rules, so that StatSummary only uses the ones that exist.
+func (s *grpcServer) probeRecordedSeries(ctx context.Context) error {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, group := range RecordingRuleGroups() {
+		for _, rule := range group.Rules {
+			if _, ok := seen[rule.Record]; !ok {
+				seen[rule.Record] = struct{}{}
+				names = append(names, rule.Record)
+			}
+		}
+	}
+
+	vec, err := s.queryProm(ctx, recordedSeriesQueryName, recordedSeriesQuery(names), sumSamples)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]struct{})
+	for _, sample := range vec {
+		found[string(sample.Metric[model.MetricNameLabel])] = struct{}{}
+	}
+	s.recordedSeries.set(found)
+
+	log.Debugf("Found %d of %d recorded series", len(found), len(names))
+	return nil
+}
+
+// probeRecordedSeriesPeriodically runs probeRecordedSeries every
+// recordedSeriesProbeInterval, starting immediately.
+func (s *grpcServer) probeRecordedSeriesPeriodically() {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), recordedSeriesProbeInterval)
+		if err := s.probeRecordedSeries(ctx); err != nil {
+			log.Warnf("Failed to probe for recorded series, querying raw metrics: %s", err)
+		}
+		cancel()
+
+		time.Sleep(recordedSeriesProbeInterval)
+	}
+}
@@ -0,0 +1,224 @@
+package public
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	tap "github.com/linkerd/linkerd2/controller/gen/controller/tap"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+)
+
+func newRecordingRulesTestServer(t *testing.T, mockProm *MockProm, k8sConfigs ...string) *grpcServer {
+	k8sAPI, err := k8s.NewFakeAPI(k8sConfigs...)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+
+	return newGrpcServer([]promShard{{api: mockProm}}, tap.NewTapClient(nil), k8sAPI, "linkerd", []string{})
+}
+
+func allRecordedSeries() map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, group := range RecordingRuleGroups() {
+		for _, rule := range group.Rules {
+			names[rule.Record] = struct{}{}
+		}
+	}
+	return names
+}
+
+func TestRecordingRuleGroups(t *testing.T) {
+	t.Run("Records the queries that StatSummary falls back to", func(t *testing.T) {
+		groups := RecordingRuleGroups()
+		if len(groups) != len(RecordedTimeWindows) {
+			t.Fatalf("Expected %d rule groups, got %d", len(RecordedTimeWindows), len(groups))
+		}
+
+		for i, window := range RecordedTimeWindows {
+			for _, resourceType := range recordedResourceTypes {
+				expected := []string{}
+				for _, rule := range groups[i].Rules {
+					if strings.HasPrefix(rule.Record, resourceType+":") &&
+						!strings.Contains(rule.Record, ":success_ratio") {
+						expected = append(expected, rule.Expr)
+					}
+				}
+
+				mockProm := &MockProm{Res: model.Vector{}}
+				server := newRecordingRulesTestServer(t, mockProm)
+				req := &pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{Type: resourceType},
+					},
+					TimeWindow: window,
+				}
+				if _, _, err := server.getPrometheusMetrics(context.TODO(), req, window); err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+
+				sort.Strings(expected)
+				sort.Strings(mockProm.QueriesExecuted)
+				if !reflect.DeepEqual(expected, mockProm.QueriesExecuted) {
+					t.Fatalf("Recording rules for %s over %s do not match the raw queries.\nExpected:\n%+v\nGot:\n%+v",
+						resourceType, window, expected, mockProm.QueriesExecuted)
+				}
+			}
+		}
+	})
+
+	t.Run("Records latency quantiles as labels on a single series", func(t *testing.T) {
+		for _, rule := range RecordingRuleGroups()[0].Rules {
+			isLatency := strings.Contains(rule.Record, ":response_latency_ms:")
+			if _, ok := rule.Labels[string(quantileLabel)]; ok != isLatency {
+				t.Fatalf("Expected rule [%s] to have a quantile label: %t", rule.Record, isLatency)
+			}
+		}
+	})
+}
+
+func TestStatSummaryRecordedSeries(t *testing.T) {
+	k8sConfigs := []string{`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-emoji-svc:v3
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+	}
+
+	// Recorded series keep the labels they were aggregated by.
+	sample := genPromSample("emoji", "deployment", "emojivoto", "success", false)
+	sample.Metric[quantileLabel] = "0.5"
+
+	req := &pb.StatSummaryRequest{
+		Selector: &pb.ResourceSelection{
+			Resource: &pb.Resource{
+				Namespace: "emojivoto",
+				Type:      pkgK8s.Deployment,
+			},
+		},
+		TimeWindow: "1m",
+	}
+
+	t.Run("Queries recorded series when Prometheus has them", func(t *testing.T) {
+		rawProm := &MockProm{Res: model.Vector{sample}}
+		rawServer := newRecordingRulesTestServer(t, rawProm, k8sConfigs...)
+		rawRsp, err := rawServer.StatSummary(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		recordedProm := &MockProm{Res: model.Vector{sample}}
+		recordedServer := newRecordingRulesTestServer(t, recordedProm, k8sConfigs...)
+		recordedServer.recordedSeries.set(allRecordedSeries())
+		recordedRsp, err := recordedServer.StatSummary(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expectedQueries := []string{
+			`sum(deployment:response_total:increase1m{namespace="emojivoto"}) by (namespace, deployment, classification, tls)`,
+			`max(deployment:response_latency_ms:quantile1m{namespace="emojivoto", quantile="0.5"}) by (namespace, deployment)`,
+			`max(deployment:response_latency_ms:quantile1m{namespace="emojivoto", quantile="0.95"}) by (namespace, deployment)`,
+			`max(deployment:response_latency_ms:quantile1m{namespace="emojivoto", quantile="0.99"}) by (namespace, deployment)`,
+		}
+		sort.Strings(expectedQueries)
+		sort.Strings(recordedProm.QueriesExecuted)
+		if !reflect.DeepEqual(expectedQueries, recordedProm.QueriesExecuted) {
+			t.Fatalf("Prometheus queries incorrect. \nExpected:\n%+v \nGot:\n%+v",
+				expectedQueries, recordedProm.QueriesExecuted)
+		}
+
+		if !proto.Equal(rawRsp, recordedRsp) {
+			t.Fatalf("Expected recorded series to give the same response as raw queries.\nExpected: %+v\nGot: %+v", rawRsp, recordedRsp)
+		}
+	})
+
+	t.Run("Queries raw metrics for requests to an authority", func(t *testing.T) {
+		mockProm := &MockProm{Res: model.Vector{sample}}
+		server := newRecordingRulesTestServer(t, mockProm, k8sConfigs...)
+		server.recordedSeries.set(allRecordedSeries())
+
+		authorityReq := proto.Clone(req).(*pb.StatSummaryRequest)
+		authorityReq.Authority = "web.emojivoto"
+		if _, err := server.StatSummary(context.TODO(), authorityReq); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		for _, query := range mockProm.QueriesExecuted {
+			if !strings.Contains(query, `authority=~"web\\.emojivoto.*"`) {
+				t.Fatalf("Expected a raw query filtered by authority, got [%s]", query)
+			}
+		}
+	})
+
+	t.Run("Queries raw metrics for windows that are not recorded", func(t *testing.T) {
+		mockProm := &MockProm{Res: model.Vector{sample}}
+		server := newRecordingRulesTestServer(t, mockProm, k8sConfigs...)
+		server.recordedSeries.set(allRecordedSeries())
+
+		unrecordedReq := proto.Clone(req).(*pb.StatSummaryRequest)
+		unrecordedReq.TimeWindow = "5m"
+		if _, err := server.StatSummary(context.TODO(), unrecordedReq); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		for _, query := range mockProm.QueriesExecuted {
+			if !strings.Contains(query, "response_total{") && !strings.Contains(query, "response_latency_ms_bucket{") {
+				t.Fatalf("Expected a raw query, got [%s]", query)
+			}
+		}
+	})
+}
+
+func TestProbeRecordedSeries(t *testing.T) {
+	mockProm := &MockProm{Res: model.Vector{
+		&model.Sample{
+			Metric: model.Metric{model.MetricNameLabel: "deployment:response_total:increase1m"},
+			Value:  1,
+		},
+	}}
+	server := newRecordingRulesTestServer(t, mockProm)
+
+	if err := server.probeRecordedSeries(context.TODO()); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if len(mockProm.QueriesExecuted) != 1 || !strings.HasPrefix(mockProm.QueriesExecuted[0], `count({__name__=~"`) {
+		t.Fatalf("Unexpected probe queries: %+v", mockProm.QueriesExecuted)
+	}
+	if !server.recordedSeries.has("deployment:response_total:increase1m") {
+		t.Fatalf("Expected probed series to be recorded")
+	}
+	if server.recordedSeries.has("deployment:response_latency_ms:quantile1m") {
+		t.Fatalf("Expected series missing from Prometheus not to be recorded")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
+	"strings"
 	"time"
 
 	proto "github.com/golang/protobuf/proto"
@@ -16,18 +18,21 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type promType string
 type promResult struct {
-	prom promType
-	vec  model.Vector
-	err  error
+	prom       promType
+	vec        model.Vector
+	promErrors []*pb.PrometheusError
+	err        error
 }
 
 type resourceResult struct {
-	res *pb.StatTable
-	err error
+	res        *pb.StatTable
+	promErrors []*pb.PrometheusError
+	err        error
 }
 
 type k8sStat struct {
@@ -39,12 +44,12 @@ type rKey struct {
 	Namespace string
 	Type      string
 	Name      string
+
+	// LabelValue is the value of the request's IncludeLabel, if any.
+	LabelValue string
 }
 
 const (
-	reqQuery             = "sum(increase(response_total%s[%s])) by (%s, classification, tls)"
-	latencyQuantileQuery = "histogram_quantile(%s, sum(irate(response_latency_ms_bucket%s[%s])) by (le, %s))"
-
 	promRequests   = promType("QUERY_REQUESTS")
 	promLatencyP50 = promType("0.5")
 	promLatencyP95 = promType("0.95")
@@ -55,6 +60,7 @@ const (
 )
 
 var promTypes = []promType{promRequests, promLatencyP50, promLatencyP95, promLatencyP99}
+var promLatencyTypes = []promType{promLatencyP50, promLatencyP95, promLatencyP99}
 
 type podStats struct {
 	inMesh uint64
@@ -75,14 +81,48 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		return statSummaryError(req, "service only supported as a target on 'from' queries, or as a destination on 'to' queries"), nil
 	}
 
-	switch req.Outbound.(type) {
-	case *pb.StatSummaryRequest_ToResource:
-		if req.Outbound.(*pb.StatSummaryRequest_ToResource).ToResource.Type == k8s.All {
-			return statSummaryError(req, "resource type 'all' is not supported as a filter"), nil
+	if req.IncludeLabel != "" {
+		if errs := validation.IsQualifiedName(req.IncludeLabel); len(errs) > 0 {
+			return statSummaryError(req, fmt.Sprintf("invalid label key [%s]: %s", req.IncludeLabel, strings.Join(errs, "; "))), nil
+		}
+	}
+
+	if req.MinTrafficDuration != "" {
+		if _, err := time.ParseDuration(req.MinTrafficDuration); err != nil {
+			return statSummaryError(req, fmt.Sprintf("invalid min traffic duration [%s]: %s", req.MinTrafficDuration, err)), nil
 		}
+		// authorities have no proxies of their own to tell the age of
+		if isNonK8sResourceQuery(req.Selector.Resource.Type) {
+			return statSummaryError(req, fmt.Sprintf("min traffic duration is not supported for resource type '%s'", req.Selector.Resource.Type)), nil
+		}
+	}
+
+	var filter *pb.Resource
+	switch out := req.Outbound.(type) {
+	case *pb.StatSummaryRequest_ToResource:
+		filter = out.ToResource
 	case *pb.StatSummaryRequest_FromResource:
-		if req.Outbound.(*pb.StatSummaryRequest_FromResource).FromResource.Type == k8s.All {
-			return statSummaryError(req, "resource type 'all' is not supported as a filter"), nil
+		filter = out.FromResource
+	}
+	if filter.GetType() == k8s.All || filter.GetType() == k8s.CronJob {
+		return statSummaryError(req, fmt.Sprintf("resource type '%s' is not supported as a filter", filter.GetType())), nil
+	}
+
+	// the stats of a CronJob are those of its most recent Job
+	if req.Selector.Resource.Type == k8s.CronJob {
+		cronJob := req.Selector.Resource
+		if cronJob.Name == "" {
+			return statSummaryError(req, "a CronJob name is required, to get the stats of its most recent Job"), nil
+		}
+		job, err := s.k8sAPI.GetLatestJobFor(cronJob.Namespace, cronJob.Name)
+		if err != nil {
+			return statSummaryError(req, err.Error()), nil
+		}
+		req = proto.Clone(req).(*pb.StatSummaryRequest)
+		req.Selector.Resource = &pb.Resource{
+			Type:      k8s.Job,
+			Namespace: job.Namespace,
+			Name:      job.Name,
 		}
 	}
 
@@ -111,18 +151,22 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		}()
 	}
 
+	var promErrors []*pb.PrometheusError
 	for i := 0; i < len(resourcesToQuery); i++ {
 		result := <-resultChan
 		if result.err != nil {
 			return nil, util.GRPCError(result.err)
 		}
 		statTables = append(statTables, result.res)
+		promErrors = appendPrometheusErrors(promErrors, result.promErrors...)
 	}
+	sort.Slice(promErrors, func(i, j int) bool { return promErrors[i].Url < promErrors[j].Url })
 
 	rsp := pb.StatSummaryResponse{
 		Response: &pb.StatSummaryResponse_Ok_{ // https://github.com/golang/protobuf/issues/205
 			Ok: &pb.StatSummaryResponse_Ok{
-				StatTables: statTables,
+				StatTables:       statTables,
+				PrometheusErrors: promErrors,
 			},
 		},
 	}
@@ -141,6 +185,24 @@ func statSummaryError(req *pb.StatSummaryRequest, message string) *pb.StatSummar
 	}
 }
 
+// appendPrometheusErrors appends the errors of the Prometheus instances that
+// are not in errs yet, keeping one error per instance.
+func appendPrometheusErrors(errs []*pb.PrometheusError, newErrs ...*pb.PrometheusError) []*pb.PrometheusError {
+	for _, newErr := range newErrs {
+		seen := false
+		for _, err := range errs {
+			if err.Url == newErr.Url {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			errs = append(errs, newErr)
+		}
+	}
+	return errs
+}
+
 func (s *grpcServer) getKubernetesObjectStats(req *pb.StatSummaryRequest) (map[rKey]k8sStat, error) {
 	requestedResource := req.GetSelector().GetResource()
 	objects, err := s.k8sAPI.GetObjects(requestedResource.Namespace, requestedResource.Type, requestedResource.Name)
@@ -181,37 +243,61 @@ func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRe
 		return resourceResult{res: nil, err: err}
 	}
 
-	requestMetrics, err := s.getPrometheusMetrics(ctx, req, req.TimeWindow)
+	requestMetrics, promErrors, err := s.getPrometheusMetrics(ctx, req, req.TimeWindow)
 	if err != nil {
 		return resourceResult{res: nil, err: err}
 	}
 
-	rows := make([]*pb.StatTable_PodGroup_Row, 0)
 	keys := getResultKeys(req, k8sObjects, requestMetrics)
+	if req.MinTrafficDuration != "" {
+		var ageErrors []*pb.PrometheusError
+		keys, ageErrors, err = s.filterYoungResources(ctx, req, keys)
+		if err != nil {
+			return resourceResult{res: nil, err: err}
+		}
+		promErrors = appendPrometheusErrors(promErrors, ageErrors...)
+	}
+
+	rows := make([]*pb.StatTable_PodGroup_Row, 0)
+	keys = pageKeys(keys, req.Offset, req.Limit)
+	labelValues := getLabelValues(requestMetrics)
 
 	for _, key := range keys {
 		objInfo, ok := k8sObjects[key]
 		if !ok {
 			continue
 		}
-		k8sResource := objInfo.object
-		row := pb.StatTable_PodGroup_Row{
-			Resource: &pb.Resource{
-				Name:      k8sResource.GetName(),
-				Namespace: k8sResource.GetNamespace(),
-				Type:      req.GetSelector().GetResource().GetType(),
-			},
-			TimeWindow: req.TimeWindow,
-			Stats:      requestMetrics[key],
+
+		// objects without stats get a single row, with no label value
+		values := labelValues[key]
+		if len(values) == 0 {
+			values = []string{""}
 		}
 
-		podStat := objInfo.podStats
-		row.MeshedPodCount = podStat.inMesh
-		row.RunningPodCount = podStat.total
-		row.FailedPodCount = podStat.failed
-		row.ErrorsByPod = podStat.errors
+		for _, value := range values {
+			statsKey := key
+			statsKey.LabelValue = value
+
+			k8sResource := objInfo.object
+			row := pb.StatTable_PodGroup_Row{
+				Resource: &pb.Resource{
+					Name:      k8sResource.GetName(),
+					Namespace: k8sResource.GetNamespace(),
+					Type:      req.GetSelector().GetResource().GetType(),
+				},
+				TimeWindow: req.TimeWindow,
+				Stats:      requestMetrics[statsKey],
+				LabelValue: value,
+			}
+
+			podStat := objInfo.podStats
+			row.MeshedPodCount = podStat.inMesh
+			row.RunningPodCount = podStat.total
+			row.FailedPodCount = podStat.failed
+			row.ErrorsByPod = podStat.errors
 
-		rows = append(rows, &row)
+			rows = append(rows, &row)
+		}
 	}
 
 	rsp := pb.StatTable{
@@ -222,29 +308,38 @@ func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRe
 		},
 	}
 
-	return resourceResult{res: &rsp, err: nil}
+	return resourceResult{res: &rsp, promErrors: promErrors, err: nil}
 }
 
 func (s *grpcServer) nonK8sResourceQuery(ctx context.Context, req *pb.StatSummaryRequest) resourceResult {
-	requestMetrics, err := s.getPrometheusMetrics(ctx, req, req.TimeWindow)
+	requestMetrics, promErrors, err := s.getPrometheusMetrics(ctx, req, req.TimeWindow)
 	if err != nil {
 		return resourceResult{res: nil, err: err}
 	}
 	rows := make([]*pb.StatTable_PodGroup_Row, 0)
-
-	for rkey, metrics := range requestMetrics {
-		rkey.Type = req.GetSelector().GetResource().GetType()
-
-		row := pb.StatTable_PodGroup_Row{
-			Resource: &pb.Resource{
-				Type:      rkey.Type,
-				Namespace: rkey.Namespace,
-				Name:      rkey.Name,
-			},
-			TimeWindow: req.TimeWindow,
-			Stats:      metrics,
+	labelValues := getLabelValues(requestMetrics)
+	keys := make([]rKey, 0, len(labelValues))
+	for key := range labelValues {
+		keys = append(keys, key)
+	}
+
+	for _, key := range pageKeys(keys, req.Offset, req.Limit) {
+		for _, value := range labelValues[key] {
+			statsKey := key
+			statsKey.LabelValue = value
+
+			row := pb.StatTable_PodGroup_Row{
+				Resource: &pb.Resource{
+					Type:      req.GetSelector().GetResource().GetType(),
+					Namespace: key.Namespace,
+					Name:      key.Name,
+				},
+				TimeWindow: req.TimeWindow,
+				Stats:      requestMetrics[statsKey],
+				LabelValue: value,
+			}
+			rows = append(rows, &row)
 		}
-		rows = append(rows, &row)
 	}
 
 	rsp := pb.StatTable{
@@ -254,7 +349,7 @@ func (s *grpcServer) nonK8sResourceQuery(ctx context.Context, req *pb.StatSummar
 			},
 		},
 	}
-	return resourceResult{res: &rsp, err: nil}
+	return resourceResult{res: &rsp, promErrors: promErrors, err: nil}
 }
 
 func isNonK8sResourceQuery(resourceType string) bool {
@@ -269,21 +364,97 @@ func getResultKeys(
 ) []rKey {
 	var keys []rKey
 
-	if req.GetOutbound() == nil || req.GetNone() != nil {
+	if (req.GetOutbound() == nil || req.GetNone() != nil) && !req.GrpcOnly {
 		// if the request doesn't have outbound filtering, return all rows
 		for key := range k8sObjects {
 			keys = append(keys, key)
 		}
 	} else {
-		// if the request does have outbound filtering,
-		// only return rows for which we have stats
+		// if the request does have outbound filtering, or only wants gRPC
+		// traffic, only return rows for which we have stats
+		seen := make(map[rKey]struct{})
 		for key := range metricResults {
-			keys = append(keys, key)
+			key.LabelValue = ""
+			if _, ok := k8sObjects[key]; !ok {
+				continue
+			}
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// filterYoungResources returns the keys of the resources whose oldest proxy
+// started at least req's MinTrafficDuration ago. Resources without proxies have
+// no traffic to report, and are filtered out too.
+func (s *grpcServer) filterYoungResources(ctx context.Context, req *pb.StatSummaryRequest, keys []rKey) ([]rKey, []*pb.PrometheusError, error) {
+	minDuration, err := time.ParseDuration(req.MinTrafficDuration)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groupBy := promGroupByLabelNames(req.Selector.Resource)
+	query := proxyAgeQuery(promQueryLabels(req.Selector.Resource), groupBy)
+	ages, promErrors, err := s.queryPromPartial(ctx, proxyAgeQueryName, query, maxSamples)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oldEnough := make(map[rKey]bool)
+	for _, sample := range ages {
+		key := metricToKey(req, sample.Metric, groupBy)
+		key.LabelValue = ""
+		oldEnough[key] = float64(sample.Value) >= minDuration.Seconds()
+	}
+
+	filtered := make([]rKey, 0, len(keys))
+	for _, key := range keys {
+		if oldEnough[key] {
+			filtered = append(filtered, key)
 		}
 	}
+	return filtered, promErrors, nil
+}
+
+// pageKeys orders keys by namespace and name, and returns at most limit of
+// them, after skipping the first offset. A limit of 0 returns all the keys
+// after the offset.
+func pageKeys(keys []rKey, offset, limit uint32) []rKey {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Namespace != keys[j].Namespace {
+			return keys[i].Namespace < keys[j].Namespace
+		}
+		return keys[i].Name < keys[j].Name
+	})
+
+	if int(offset) >= len(keys) {
+		return []rKey{}
+	}
+	keys = keys[offset:]
+	if limit > 0 && int(limit) < len(keys) {
+		keys = keys[:limit]
+	}
 	return keys
 }
 
+// getLabelValues returns the sorted label values that there are stats for,
+// keyed by resource.
+func getLabelValues(metricResults map[rKey]*pb.BasicStats) map[rKey][]string {
+	values := make(map[rKey][]string)
+	for key := range metricResults {
+		value := key.LabelValue
+		key.LabelValue = ""
+		values[key] = append(values[key], value)
+	}
+	for _, v := range values {
+		sort.Strings(v)
+	}
+	return values
+}
+
 // add filtering by resource type
 // note that metricToKey assumes the label ordering (namespace, name)
 func promGroupByLabelNames(resource *pb.Resource) model.LabelNames {
@@ -350,6 +521,10 @@ func promDirectionLabels(direction string) model.LabelSet {
 }
 
 func promResourceType(resource *pb.Resource) model.LabelName {
+	// "job" is Prometheus' own label for the scrape config of the series
+	if resource.Type == k8s.Job {
+		return model.LabelName("k8s_job")
+	}
 	return model.LabelName(resource.Type)
 }
 
@@ -381,38 +556,79 @@ func buildRequestLabels(req *pb.StatSummaryRequest) (labels model.LabelSet, labe
 	return
 }
 
-func (s *grpcServer) getPrometheusMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string) (map[rKey]*pb.BasicStats, error) {
+// getPrometheusMetrics queries every Prometheus shard for the stats of req. It
+// only fails if every shard fails to answer a query, and otherwise returns the
+// errors of the shards that did not answer alongside the stats of the others.
+func (s *grpcServer) getPrometheusMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string) (map[rKey]*pb.BasicStats, []*pb.PrometheusError, error) {
 	reqLabels, groupBy := buildRequestLabels(req)
+	selector := promSelector(reqLabels, req.GrpcOnly, req.Authority)
+	recorded := s.recordedQueries(req, groupBy)
+
+	// stats are additionally split by the included label, which metricToKey
+	// does not expect in groupBy
+	queryGroupBy := groupBy
+	if req.IncludeLabel != "" {
+		queryGroupBy = append(model.LabelNames{}, groupBy...)
+		queryGroupBy = append(queryGroupBy, podLabelName(req.IncludeLabel))
+	}
+
 	resultChan := make(chan promResult)
 
 	// kick off 4 asynchronous queries: 1 request volume + 3 latency
 	go func() {
 		// success/failure counts
-		requestsQuery := fmt.Sprintf(reqQuery, reqLabels, timeWindow, groupBy)
-		resultVector, err := s.queryProm(ctx, requestsQuery)
+		name, query := recordedRequestsQueryName, recorded[promRequests]
+		if query == "" {
+			name, query = requestsQueryName, requestsQuery(selector, timeWindow, queryGroupBy)
+		}
+		resultVector, promErrors, err := s.queryPromPartial(ctx, name, query, sumSamples)
 
 		resultChan <- promResult{
-			prom: promRequests,
-			vec:  resultVector,
-			err:  err,
+			prom:       promRequests,
+			vec:        resultVector,
+			promErrors: promErrors,
+			err:        err,
 		}
 	}()
 
-	for _, quantile := range []promType{promLatencyP50, promLatencyP95, promLatencyP99} {
-		go func(quantile promType) {
-			latencyQuery := fmt.Sprintf(latencyQuantileQuery, quantile, reqLabels, timeWindow, groupBy)
-			latencyResult, err := s.queryProm(ctx, latencyQuery)
-
-			resultChan <- promResult{
-				prom: quantile,
-				vec:  latencyResult,
-				err:  err,
+	if len(s.promShards) > 1 {
+		// the latency quantiles of each shard cannot be merged, so merge their
+		// latency histograms instead, and calculate the quantiles from those
+		go func() {
+			query := latencyBucketsQuery(selector, timeWindow, queryGroupBy)
+			buckets, promErrors, err := s.queryPromPartial(ctx, latencyBucketsQueryName, query, sumSamples)
+
+			for _, quantile := range promLatencyTypes {
+				resultChan <- promResult{
+					prom:       quantile,
+					vec:        histogramQuantile(quantile, buckets),
+					promErrors: promErrors,
+					err:        err,
+				}
 			}
-		}(quantile)
+		}()
+	} else {
+		for _, quantile := range promLatencyTypes {
+			go func(quantile promType) {
+				name, query := recordedLatencyQueryName, recorded[quantile]
+				if query == "" {
+					name, query = latencyQueryName, latencyQuery(quantile, selector, timeWindow, queryGroupBy)
+				}
+				latencyResult, promErrors, err := s.queryPromPartial(ctx, name, query, sumSamples)
+
+				resultChan <- promResult{
+					prom:       quantile,
+					vec:        latencyResult,
+					promErrors: promErrors,
+					err:        err,
+				}
+			}(quantile)
+		}
 	}
 
 	// process results, receive one message per prometheus query type
 	var err error
+	var promErrors []*pb.PrometheusError
 	results := []promResult{}
 	for i := 0; i < len(promTypes); i++ {
 		result := <-resultChan
@@ -421,13 +637,14 @@ func (s *grpcServer) getPrometheusMetrics(ctx context.Context, req *pb.StatSumma
 			err = result.err
 		} else {
 			results = append(results, result)
+			promErrors = appendPrometheusErrors(promErrors, result.promErrors...)
 		}
 	}
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return processPrometheusMetrics(req, results, groupBy), nil
+	return processPrometheusMetrics(req, results, groupBy), promErrors, nil
 }
 
 func processPrometheusMetrics(req *pb.StatSummaryRequest, results []promResult, groupBy model.LabelNames) map[rKey]*pb.BasicStats {
@@ -489,6 +706,10 @@ func metricToKey(req *pb.StatSummaryRequest, metric model.Metric, groupBy model.
 		key.Namespace = string(metric[groupBy[0]])
 	}
 
+	if req.IncludeLabel != "" {
+		key.LabelValue = string(metric[podLabelName(req.IncludeLabel)])
+	}
+
 	return key
 }
 
@@ -566,23 +787,3 @@ func isInvalidServiceRequest(req *pb.StatSummaryRequest) bool {
 		return req.Selector.Resource.Type == k8s.Service
 	}
 }
-
-func (s *grpcServer) queryProm(ctx context.Context, query string) (model.Vector, error) {
-	log.Debugf("Query request:\n\t%+v", query)
-
-	// single data point (aka summary) query
-	res, err := s.prometheusAPI.Query(ctx, query, time.Time{})
-	if err != nil {
-		log.Errorf("Query(%+v) failed with: %+v", query, err)
-		return nil, err
-	}
-	log.Debugf("Query response:\n\t%+v", res)
-
-	if res.Type() != model.ValVector {
-		err = fmt.Errorf("Unexpected query result type (expected Vector): %s", res.Type())
-		log.Error(err)
-		return nil, err
-	}
-
-	return res.(model.Vector), nil
-}
@@ -3,9 +3,12 @@ package public
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	tap "github.com/linkerd/linkerd2/controller/gen/controller/tap"
@@ -51,6 +54,45 @@ func genPromSample(resName string, resType string, resNs string, classification
 	}
 }
 
+// grpcProm answers every query with the samples of both gRPC and HTTP
+// responses, or with only those of gRPC responses if the query selects them,
+// as Prometheus would. The grpc_status_code label is aggregated away.
+type grpcProm struct {
+	MockProm
+	samples model.Vector
+}
+
+func (p *grpcProm) Query(ctx context.Context, query string, ts time.Time) (model.Value, error) {
+	p.MockProm.Query(ctx, query, ts)
+	grpcOnly := strings.Contains(query, `grpc_status_code=~".+"`)
+
+	vec := model.Vector{}
+	for _, sample := range p.samples {
+		if _, ok := sample.Metric["grpc_status_code"]; grpcOnly && !ok {
+			continue
+		}
+		metric := sample.Metric.Clone()
+		delete(metric, "grpc_status_code")
+		vec = append(vec, &model.Sample{Metric: metric, Value: sample.Value, Timestamp: sample.Timestamp})
+	}
+	return vec, nil
+}
+
+// proxyAgeProm answers queries for the age of proxies with ages, and all other
+// queries with no samples.
+type proxyAgeProm struct {
+	MockProm
+	ages model.Vector
+}
+
+func (p *proxyAgeProm) Query(ctx context.Context, query string, ts time.Time) (model.Value, error) {
+	p.MockProm.Query(ctx, query, ts)
+	if strings.Contains(query, "process_start_time_seconds") {
+		return p.ages, nil
+	}
+	return model.Vector{}, nil
+}
+
 func genEmptyResponse() pb.StatSummaryResponse {
 	return pb.StatSummaryResponse{
 		Response: &pb.StatSummaryResponse_Ok_{ // https://github.com/golang/protobuf/issues/205
@@ -76,7 +118,7 @@ func testStatSummary(t *testing.T, expectations []statSumExpected) {
 
 		mockProm := &MockProm{Res: exp.mockPromResponse}
 		fakeGrpcServer := newGrpcServer(
-			mockProm,
+			[]promShard{{api: mockProm}},
 			tap.NewTapClient(nil),
 			k8sAPI,
 			"linkerd",
@@ -558,6 +600,131 @@ status:
 		testStatSummary(t, expectations)
 	})
 
+	t.Run("Aggregates stats across the pods of a service account", func(t *testing.T) {
+		expectations := []statSumExpected{
+			statSumExpected{
+				err: nil,
+				k8sConfigs: []string{`
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: emoji
+  namespace: emojivoto
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emoji-1
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+spec:
+  serviceAccountName: emoji
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: voting-1
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+spec:
+  serviceAccountName: emoji
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+				},
+				mockPromResponse: prometheusMetric("emoji", "serviceaccount", "emojivoto", "success", false),
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Name:      "emoji",
+							Namespace: "emojivoto",
+							Type:      pkgK8s.ServiceAccount,
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedPrometheusQueries: []string{
+					`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", serviceaccount="emoji"}[1m])) by (le, namespace, serviceaccount))`,
+					`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", serviceaccount="emoji"}[1m])) by (le, namespace, serviceaccount))`,
+					`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", serviceaccount="emoji"}[1m])) by (le, namespace, serviceaccount))`,
+					`sum(increase(response_total{direction="inbound", namespace="emojivoto", serviceaccount="emoji"}[1m])) by (namespace, serviceaccount, classification, tls)`,
+				},
+				expectedResponse: GenStatSummaryResponse("emoji", pkgK8s.ServiceAccount, "emojivoto", &PodCounts{
+					MeshedPods:  2,
+					RunningPods: 2,
+					FailedPods:  0,
+				}),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Queries prometheus for outbound metrics to a service account", func(t *testing.T) {
+		expectations := []statSumExpected{
+			statSumExpected{
+				err: nil,
+				k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: emojivoto
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+				},
+				mockPromResponse: prometheusMetric("web-1", "pod", "emojivoto", "success", false),
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Name:      "web-1",
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow: "1m",
+					Outbound: &pb.StatSummaryRequest_ToResource{
+						ToResource: &pb.Resource{
+							Name:      "emoji",
+							Namespace: "emojivoto",
+							Type:      pkgK8s.ServiceAccount,
+						},
+					},
+				},
+				expectedPrometheusQueries: []string{
+					`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_serviceaccount="emoji", namespace="emojivoto", pod="web-1"}[1m])) by (le, namespace, pod))`,
+					`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_serviceaccount="emoji", namespace="emojivoto", pod="web-1"}[1m])) by (le, namespace, pod))`,
+					`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", dst_serviceaccount="emoji", namespace="emojivoto", pod="web-1"}[1m])) by (le, namespace, pod))`,
+					`sum(increase(response_total{direction="outbound", dst_namespace="emojivoto", dst_serviceaccount="emoji", namespace="emojivoto", pod="web-1"}[1m])) by (namespace, pod, classification, tls)`,
+				},
+				expectedResponse: GenStatSummaryResponse("web-1", pkgK8s.Pod, "emojivoto", &PodCounts{
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+				}),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
 	t.Run("Successfully queries for resource type 'all'", func(t *testing.T) {
 		expectations := []statSumExpected{
 			statSumExpected{
@@ -727,113 +894,751 @@ status:
 		testStatSummary(t, expectations)
 	})
 
-	t.Run("Given an invalid resource type, returns error", func(t *testing.T) {
-		k8sAPI, err := k8s.NewFakeAPI()
-		if err != nil {
-			t.Fatalf("NewFakeAPI returned an error: %s", err)
+	t.Run("Splits stats by the value of an included label", func(t *testing.T) {
+		blue := genPromSample("emoji", "deployment", "emojivoto", "success", false)
+		blue.Metric["label_app_kubernetes_io_version"] = "blue"
+		green := genPromSample("emoji", "deployment", "emojivoto", "success", false)
+		green.Metric["label_app_kubernetes_io_version"] = "green"
+
+		rows := []*pb.StatTable_PodGroup_Row{}
+		for _, version := range []string{"blue", "green"} {
+			rsp := GenStatSummaryResponse("emoji", pkgK8s.Deployment, "emojivoto", &PodCounts{
+				MeshedPods:  1,
+				RunningPods: 1,
+			})
+			row := rsp.GetOk().StatTables[0].GetPodGroup().Rows[0]
+			row.LabelValue = version
+			rows = append(rows, row)
 		}
 
 		expectations := []statSumExpected{
 			statSumExpected{
-				err: errors.New("rpc error: code = Unimplemented desc = unimplemented resource type: badtype"),
-				req: pb.StatSummaryRequest{
-					Selector: &pb.ResourceSelection{
-						Resource: &pb.Resource{
-							Type: "badtype",
-						},
-					},
+				err: nil,
+				k8sConfigs: []string{`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-emoji-svc:v3
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
 				},
-			},
-			statSumExpected{
-				err: errors.New("rpc error: code = Unimplemented desc = unimplemented resource type: deployments"),
+				mockPromResponse: model.Vector{blue, green},
 				req: pb.StatSummaryRequest{
 					Selector: &pb.ResourceSelection{
 						Resource: &pb.Resource{
-							Type: "deployments",
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Deployment,
 						},
 					},
+					TimeWindow:   "1m",
+					IncludeLabel: "app.kubernetes.io/version",
 				},
-			},
-			statSumExpected{
-				err: errors.New("rpc error: code = Unimplemented desc = unimplemented resource type: po"),
-				req: pb.StatSummaryRequest{
-					Selector: &pb.ResourceSelection{
-						Resource: &pb.Resource{
-							Type: "po",
+				expectedPrometheusQueries: []string{
+					`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment, label_app_kubernetes_io_version))`,
+					`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment, label_app_kubernetes_io_version))`,
+					`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment, label_app_kubernetes_io_version))`,
+					`sum(increase(response_total{direction="inbound", namespace="emojivoto"}[1m])) by (namespace, deployment, label_app_kubernetes_io_version, classification, tls)`,
+				},
+				expectedResponse: pb.StatSummaryResponse{
+					Response: &pb.StatSummaryResponse_Ok_{
+						Ok: &pb.StatSummaryResponse_Ok{
+							StatTables: []*pb.StatTable{
+								&pb.StatTable{
+									Table: &pb.StatTable_PodGroup_{
+										PodGroup: &pb.StatTable_PodGroup{Rows: rows},
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		}
 
-		for _, exp := range expectations {
-			fakeGrpcServer := newGrpcServer(
-				&MockProm{Res: exp.mockPromResponse},
-				tap.NewTapClient(nil),
-				k8sAPI,
-				"linkerd",
-				[]string{},
-			)
-
-			_, err := fakeGrpcServer.StatSummary(context.TODO(), &exp.req)
-			if err != nil || exp.err != nil {
-				if (err == nil && exp.err != nil) ||
-					(err != nil && exp.err == nil) ||
-					(err.Error() != exp.err.Error()) {
-					t.Fatalf("Unexpected error (Expected: %s, Got: %s)", exp.err, err)
-				}
-			}
-		}
+		testStatSummary(t, expectations)
 	})
 
-	t.Run("Validates service stat requests", func(t *testing.T) {
-		k8sAPI, err := k8s.NewFakeAPI()
-		if err != nil {
-			t.Fatalf("NewFakeAPI returned an error: %s", err)
+	t.Run("Only includes gRPC traffic if requested", func(t *testing.T) {
+		k8sConfigs := []string{}
+		for _, name := range []string{"emoji", "web"} {
+			k8sConfigs = append(k8sConfigs, fmt.Sprintf(`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: %[1]s
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: %[1]s-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-%[1]s-svc:v3
+`, name), fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %[1]s-meshed
+  namespace: emojivoto
+  labels:
+    app: %[1]s-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, name))
 		}
-		fakeGrpcServer := newGrpcServer(
-			&MockProm{Res: model.Vector{}},
-			tap.NewTapClient(nil),
-			k8sAPI,
-			"linkerd",
-			[]string{},
-		)
 
-		invalidRequests := []statSumExpected{
-			statSumExpected{
-				req: pb.StatSummaryRequest{},
-			},
-			statSumExpected{
-				req: pb.StatSummaryRequest{
-					Selector: &pb.ResourceSelection{
-						Resource: &pb.Resource{
-							Type: pkgK8s.Service,
-						},
-					},
+		// emoji serves gRPC, web serves plain HTTP.
+		grpc := genPromSample("emoji", "deployment", "emojivoto", "success", false)
+		grpc.Metric["grpc_status_code"] = "0"
+		http := genPromSample("web", "deployment", "emojivoto", "success", false)
+
+		expectations := []struct {
+			grpcOnly        bool
+			expectedQueries []string
+			expectedRows    []string
+		}{
+			{
+				grpcOnly: false,
+				expectedQueries: []string{
+					`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment))`,
+					`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment))`,
+					`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment))`,
+					`sum(increase(response_total{direction="inbound", namespace="emojivoto"}[1m])) by (namespace, deployment, classification, tls)`,
 				},
+				expectedRows: []string{"emoji", "web"},
 			},
-			statSumExpected{
-				req: pb.StatSummaryRequest{
-					Selector: &pb.ResourceSelection{
-						Resource: &pb.Resource{
-							Type: pkgK8s.Service,
-						},
-					},
-					Outbound: &pb.StatSummaryRequest_ToResource{
-						ToResource: &pb.Resource{
-							Type: pkgK8s.Pod,
-						},
-					},
+			{
+				grpcOnly: true,
+				expectedQueries: []string{
+					`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", grpc_status_code=~".+"}[1m])) by (le, namespace, deployment))`,
+					`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", grpc_status_code=~".+"}[1m])) by (le, namespace, deployment))`,
+					`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", grpc_status_code=~".+"}[1m])) by (le, namespace, deployment))`,
+					`sum(increase(response_total{direction="inbound", namespace="emojivoto", grpc_status_code=~".+"}[1m])) by (namespace, deployment, classification, tls)`,
 				},
+				expectedRows: []string{"emoji"},
 			},
-			statSumExpected{
-				req: pb.StatSummaryRequest{
+		}
+
+		for _, exp := range expectations {
+			t.Run(fmt.Sprintf("grpc_only=%t", exp.grpcOnly), func(t *testing.T) {
+				k8sAPI, err := k8s.NewFakeAPI(k8sConfigs...)
+				if err != nil {
+					t.Fatalf("NewFakeAPI returned an error: %s", err)
+				}
+
+				mockProm := &grpcProm{samples: model.Vector{grpc, http}}
+				fakeGrpcServer := newGrpcServer(
+					[]promShard{{api: mockProm}},
+					tap.NewTapClient(nil),
+					k8sAPI,
+					"linkerd",
+					[]string{},
+				)
+				k8sAPI.Sync(nil)
+
+				rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
 					Selector: &pb.ResourceSelection{
 						Resource: &pb.Resource{
-							Type: pkgK8s.Pod,
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Deployment,
 						},
 					},
-					Outbound: &pb.StatSummaryRequest_FromResource{
+					TimeWindow: "1m",
+					GrpcOnly:   exp.grpcOnly,
+				})
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+
+				sort.Strings(mockProm.QueriesExecuted)
+				if !reflect.DeepEqual(mockProm.QueriesExecuted, exp.expectedQueries) {
+					t.Fatalf("Prometheus queries incorrect. \nExpected:\n%+v \nGot:\n%+v", exp.expectedQueries, mockProm.QueriesExecuted)
+				}
+
+				rows := []string{}
+				for _, row := range rsp.GetOk().GetStatTables()[0].GetPodGroup().GetRows() {
+					if row.Stats == nil || row.Stats.SuccessCount != 123 {
+						t.Fatalf("Expected stats for %s, got: %+v", row.Resource.Name, row.Stats)
+					}
+					rows = append(rows, row.Resource.Name)
+				}
+				sort.Strings(rows)
+				if !reflect.DeepEqual(rows, exp.expectedRows) {
+					t.Fatalf("Expected rows for %v, got %v", exp.expectedRows, rows)
+				}
+			})
+		}
+	})
+
+	t.Run("Only includes requests to matching authorities if requested", func(t *testing.T) {
+		expectations := []statSumExpected{
+			statSumExpected{
+				err: nil,
+				k8sConfigs: []string{`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-emoji-svc:v3
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+				},
+				mockPromResponse: prometheusMetric("emoji", "deployment", "emojivoto", "success", false),
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Deployment,
+						},
+					},
+					TimeWindow: "1m",
+					Authority:  "*.example.com",
+				},
+				expectedPrometheusQueries: []string{
+					`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", authority=~".*\\.example\\.com"}[1m])) by (le, namespace, deployment))`,
+					`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", authority=~".*\\.example\\.com"}[1m])) by (le, namespace, deployment))`,
+					`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", authority=~".*\\.example\\.com"}[1m])) by (le, namespace, deployment))`,
+					`sum(increase(response_total{direction="inbound", namespace="emojivoto", authority=~".*\\.example\\.com"}[1m])) by (namespace, deployment, classification, tls)`,
+				},
+				expectedResponse: GenStatSummaryResponse("emoji", pkgK8s.Deployment, "emojivoto", &PodCounts{
+					MeshedPods:  1,
+					RunningPods: 1,
+				}),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Merges stats from every Prometheus shard", func(t *testing.T) {
+		k8sConfigs := []string{`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-emoji-svc:v3
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+		}
+
+		emoji := model.Metric{"namespace": "emojivoto", "deployment": "emoji"}
+		requests := func(classification, tls string, value float64) *model.Sample {
+			metric := emoji.Clone()
+			metric["classification"] = model.LabelValue(classification)
+			metric["tls"] = model.LabelValue(tls)
+			return &model.Sample{Metric: metric, Value: model.SampleValue(value), Timestamp: 456}
+		}
+
+		// The shards' latency histograms are merged before the quantiles are
+		// calculated, e.g. the p95 of the merged histogram is in the (10, 100]
+		// bucket, whereas it is in the (0, 10] bucket of the first shard.
+		newShards := func() []*shardProm {
+			return []*shardProm{
+				&shardProm{results: map[string]model.Vector{
+					"sum(increase(response_total": {requests("success", "true", 100)},
+					"sum(irate(response_latency_ms_bucket": {
+						bucketSample(emoji, "10", 100),
+						bucketSample(emoji, "100", 100),
+						bucketSample(emoji, "+Inf", 100),
+					},
+				}},
+				&shardProm{results: map[string]model.Vector{
+					"sum(increase(response_total": {requests("success", "false", 50), requests("failure", "false", 50)},
+					"sum(irate(response_latency_ms_bucket": {
+						bucketSample(emoji, "10", 0),
+						bucketSample(emoji, "100", 100),
+						bucketSample(emoji, "+Inf", 100),
+					},
+				}},
+			}
+		}
+
+		req := &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Deployment,
+				},
+			},
+			TimeWindow: "1m",
+		}
+
+		expectations := []struct {
+			name               string
+			failShard          bool
+			expectedStats      *pb.BasicStats
+			expectedPromErrors []*pb.PrometheusError
+		}{
+			{
+				name: "All shards answer",
+				expectedStats: &pb.BasicStats{
+					SuccessCount:    150,
+					FailureCount:    50,
+					LatencyMsP50:    10,
+					LatencyMsP95:    91,
+					LatencyMsP99:    98,
+					TlsRequestCount: 100,
+				},
+			},
+			{
+				name:      "A shard fails",
+				failShard: true,
+				expectedStats: &pb.BasicStats{
+					SuccessCount:    100,
+					LatencyMsP50:    5,
+					LatencyMsP95:    10,
+					LatencyMsP99:    10,
+					TlsRequestCount: 100,
+				},
+				expectedPromErrors: []*pb.PrometheusError{
+					&pb.PrometheusError{Url: "http://prometheus-1", Error: "connection refused"},
+				},
+			},
+		}
+
+		for _, exp := range expectations {
+			t.Run(exp.name, func(t *testing.T) {
+				k8sAPI, err := k8s.NewFakeAPI(k8sConfigs...)
+				if err != nil {
+					t.Fatalf("NewFakeAPI returned an error: %s", err)
+				}
+
+				shards := newShards()
+				if exp.failShard {
+					shards[1].err = errors.New("connection refused")
+				}
+				fakeGrpcServer := newGrpcServer(
+					[]promShard{{"http://prometheus-0", shards[0]}, {"http://prometheus-1", shards[1]}},
+					tap.NewTapClient(nil),
+					k8sAPI,
+					"linkerd",
+					[]string{},
+				)
+				k8sAPI.Sync(nil)
+
+				rsp, err := fakeGrpcServer.StatSummary(context.TODO(), req)
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+
+				expectedQueries := []string{
+					`sum(increase(response_total{direction="inbound", namespace="emojivoto"}[1m])) by (namespace, deployment, classification, tls)`,
+					`sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, deployment)`,
+				}
+				for i, shard := range shards {
+					sort.Strings(shard.QueriesExecuted)
+					if !reflect.DeepEqual(shard.QueriesExecuted, expectedQueries) {
+						t.Fatalf("Prometheus queries to shard %d incorrect. \nExpected:\n%+v \nGot:\n%+v", i, expectedQueries, shard.QueriesExecuted)
+					}
+				}
+
+				rows := rsp.GetOk().GetStatTables()[0].GetPodGroup().GetRows()
+				if len(rows) != 1 {
+					t.Fatalf("Expected 1 row, got %v", rows)
+				}
+				if !proto.Equal(rows[0].Stats, exp.expectedStats) {
+					t.Fatalf("Expected stats: %+v\n Got: %+v", exp.expectedStats, rows[0].Stats)
+				}
+
+				promErrors := rsp.GetOk().GetPrometheusErrors()
+				if len(promErrors) != len(exp.expectedPromErrors) {
+					t.Fatalf("Expected Prometheus errors: %v\n Got: %v", exp.expectedPromErrors, promErrors)
+				}
+				for i, promErr := range promErrors {
+					if !proto.Equal(promErr, exp.expectedPromErrors[i]) {
+						t.Fatalf("Expected Prometheus errors: %v\n Got: %v", exp.expectedPromErrors, promErrors)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("Queries prometheus for the most recent Job of a CronJob", func(t *testing.T) {
+		expectations := []statSumExpected{
+			statSumExpected{
+				err: nil,
+				k8sConfigs: []string{`
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: backup-1539561600
+  namespace: emojivoto
+  uid: backup-1
+  creationTimestamp: 2018-10-15T00:00:00Z
+  ownerReferences:
+  - apiVersion: batch/v1beta1
+    kind: CronJob
+    name: backup
+    uid: backup-uid
+    controller: true
+spec:
+  selector:
+    matchLabels:
+      controller-uid: backup-1
+  template:
+    metadata:
+      labels:
+        controller-uid: backup-1
+`, `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: backup-1539648000
+  namespace: emojivoto
+  uid: backup-2
+  creationTimestamp: 2018-10-16T00:00:00Z
+  ownerReferences:
+  - apiVersion: batch/v1beta1
+    kind: CronJob
+    name: backup
+    uid: backup-uid
+    controller: true
+spec:
+  selector:
+    matchLabels:
+      controller-uid: backup-2
+  template:
+    metadata:
+      labels:
+        controller-uid: backup-2
+`, `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: report-1539734400
+  namespace: emojivoto
+  uid: report-1
+  creationTimestamp: 2018-10-17T00:00:00Z
+  ownerReferences:
+  - apiVersion: batch/v1beta1
+    kind: CronJob
+    name: report
+    uid: report-uid
+    controller: true
+spec:
+  selector:
+    matchLabels:
+      controller-uid: report-1
+  template:
+    metadata:
+      labels:
+        controller-uid: report-1
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: backup-1539648000-x2x7k
+  namespace: emojivoto
+  labels:
+    controller-uid: backup-2
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+				},
+				mockPromResponse: prometheusMetric("backup-1539648000", "k8s_job", "emojivoto", "success", false),
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Name:      "backup",
+							Namespace: "emojivoto",
+							Type:      pkgK8s.CronJob,
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedPrometheusQueries: []string{
+					`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", k8s_job="backup-1539648000", namespace="emojivoto"}[1m])) by (le, namespace, k8s_job))`,
+					`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", k8s_job="backup-1539648000", namespace="emojivoto"}[1m])) by (le, namespace, k8s_job))`,
+					`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", k8s_job="backup-1539648000", namespace="emojivoto"}[1m])) by (le, namespace, k8s_job))`,
+					`sum(increase(response_total{direction="inbound", k8s_job="backup-1539648000", namespace="emojivoto"}[1m])) by (namespace, k8s_job, classification, tls)`,
+				},
+				expectedResponse: GenStatSummaryResponse("backup-1539648000", pkgK8s.Job, "emojivoto", &PodCounts{
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+				}),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Rejects CronJob requests that can't be resolved to a Job", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: report-1539734400
+  namespace: emojivoto
+  uid: report-1
+  creationTimestamp: 2018-10-17T00:00:00Z
+  ownerReferences:
+  - apiVersion: batch/v1beta1
+    kind: CronJob
+    name: report
+    uid: report-uid
+    controller: true
+spec:
+  selector:
+    matchLabels:
+      controller-uid: report-1
+  template:
+    metadata:
+      labels:
+        controller-uid: report-1
+`)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+		fakeGrpcServer := newGrpcServer(
+			[]promShard{{api: &MockProm{Res: model.Vector{}}}},
+			tap.NewTapClient(nil),
+			k8sAPI,
+			"linkerd",
+			[]string{},
+		)
+		k8sAPI.Sync(nil)
+
+		testCases := []struct {
+			req             *pb.StatSummaryRequest
+			expectedMessage string
+		}{
+			{
+				&pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.CronJob, Name: "backup"},
+					},
+				},
+				"no Jobs found for CronJob emojivoto/backup",
+			},
+			{
+				&pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.CronJob},
+					},
+				},
+				"a CronJob name is required, to get the stats of its most recent Job",
+			},
+			{
+				&pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment},
+					},
+					Outbound: &pb.StatSummaryRequest_ToResource{
+						ToResource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.CronJob, Name: "report"},
+					},
+				},
+				"resource type 'cronjob' is not supported as a filter",
+			},
+		}
+
+		for _, tc := range testCases {
+			rsp, err := fakeGrpcServer.StatSummary(context.TODO(), tc.req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if rsp.GetError().GetError() != tc.expectedMessage {
+				t.Fatalf("Expected error [%s], got %v", tc.expectedMessage, rsp)
+			}
+		}
+	})
+
+	t.Run("Rejects an invalid included label", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI()
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+		fakeGrpcServer := newGrpcServer(
+			[]promShard{{api: &MockProm{Res: model.Vector{}}}},
+			tap.NewTapClient(nil),
+			k8sAPI,
+			"linkerd",
+			[]string{},
+		)
+
+		req := &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Type: pkgK8s.Deployment,
+				},
+			},
+			IncludeLabel: "version=blue",
+		}
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), req)
+		if err != nil || rsp.GetError() == nil {
+			t.Fatalf("Expected validation error on StatSummaryResponse, got %v, %v", rsp, err)
+		}
+	})
+
+	t.Run("Given an invalid resource type, returns error", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI()
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		expectations := []statSumExpected{
+			statSumExpected{
+				err: errors.New("rpc error: code = Unimplemented desc = unimplemented resource type: badtype"),
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: "badtype",
+						},
+					},
+				},
+			},
+			statSumExpected{
+				err: errors.New("rpc error: code = Unimplemented desc = unimplemented resource type: deployments"),
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: "deployments",
+						},
+					},
+				},
+			},
+			statSumExpected{
+				err: errors.New("rpc error: code = Unimplemented desc = unimplemented resource type: po"),
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: "po",
+						},
+					},
+				},
+			},
+		}
+
+		for _, exp := range expectations {
+			fakeGrpcServer := newGrpcServer(
+				[]promShard{{api: &MockProm{Res: exp.mockPromResponse}}},
+				tap.NewTapClient(nil),
+				k8sAPI,
+				"linkerd",
+				[]string{},
+			)
+
+			_, err := fakeGrpcServer.StatSummary(context.TODO(), &exp.req)
+			if err != nil || exp.err != nil {
+				if (err == nil && exp.err != nil) ||
+					(err != nil && exp.err == nil) ||
+					(err.Error() != exp.err.Error()) {
+					t.Fatalf("Unexpected error (Expected: %s, Got: %s)", exp.err, err)
+				}
+			}
+		}
+	})
+
+	t.Run("Validates service stat requests", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI()
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+		fakeGrpcServer := newGrpcServer(
+			[]promShard{{api: &MockProm{Res: model.Vector{}}}},
+			tap.NewTapClient(nil),
+			k8sAPI,
+			"linkerd",
+			[]string{},
+		)
+
+		invalidRequests := []statSumExpected{
+			statSumExpected{
+				req: pb.StatSummaryRequest{},
+			},
+			statSumExpected{
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Service,
+						},
+					},
+				},
+			},
+			statSumExpected{
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Service,
+						},
+					},
+					Outbound: &pb.StatSummaryRequest_ToResource{
+						ToResource: &pb.Resource{
+							Type: pkgK8s.Pod,
+						},
+					},
+				},
+			},
+			statSumExpected{
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Pod,
+						},
+					},
+					Outbound: &pb.StatSummaryRequest_FromResource{
 						FromResource: &pb.Resource{
 							Type: pkgK8s.Service,
 						},
@@ -1154,3 +1959,206 @@ status:
 		testStatSummary(t, expectations)
 	})
 }
+
+func TestStatSummaryPages(t *testing.T) {
+	k8sConfigs := []string{}
+	for _, pod := range []string{"emojivoto/web", "emojivoto/emoji", "books/webapp", "books/authors"} {
+		parts := strings.Split(pod, "/")
+		k8sConfigs = append(k8sConfigs, fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %s
+  namespace: %s
+status:
+  phase: Running
+`, parts[1], parts[0]))
+	}
+
+	testCases := []struct {
+		limit    uint32
+		offset   uint32
+		expected []string
+	}{
+		{0, 0, []string{"books/authors", "books/webapp", "emojivoto/emoji", "emojivoto/web"}},
+		{3, 0, []string{"books/authors", "books/webapp", "emojivoto/emoji"}},
+		{3, 3, []string{"emojivoto/web"}},
+		{2, 2, []string{"emojivoto/emoji", "emojivoto/web"}},
+		{0, 1, []string{"books/webapp", "emojivoto/emoji", "emojivoto/web"}},
+		{3, 4, []string{}},
+		{3, 6, []string{}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("limit=%d,offset=%d", tc.limit, tc.offset), func(t *testing.T) {
+			k8sAPI, err := k8s.NewFakeAPI(k8sConfigs...)
+			if err != nil {
+				t.Fatalf("NewFakeAPI returned an error: %s", err)
+			}
+
+			fakeGrpcServer := newGrpcServer(
+				[]promShard{{api: &MockProm{Res: model.Vector{}}}},
+				tap.NewTapClient(nil),
+				k8sAPI,
+				"linkerd",
+				[]string{},
+			)
+
+			k8sAPI.Sync(nil)
+
+			rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+				Selector: &pb.ResourceSelection{
+					Resource: &pb.Resource{
+						Type: pkgK8s.Pod,
+					},
+				},
+				TimeWindow: "1m",
+				Limit:      tc.limit,
+				Offset:     tc.offset,
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			pods := []string{}
+			for _, row := range rsp.GetOk().StatTables[0].GetPodGroup().Rows {
+				pods = append(pods, row.Resource.Namespace+"/"+row.Resource.Name)
+			}
+			if !reflect.DeepEqual(pods, tc.expected) {
+				t.Fatalf("Expected pods %v, got %v", tc.expected, pods)
+			}
+		})
+	}
+}
+
+func TestStatSummaryMinTrafficDuration(t *testing.T) {
+	k8sConfigs := []string{}
+	for _, pod := range []string{"emojivoto/web", "emojivoto/emoji", "emojivoto/voting"} {
+		parts := strings.Split(pod, "/")
+		k8sConfigs = append(k8sConfigs, fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %s
+  namespace: %s
+status:
+  phase: Running
+`, parts[1], parts[0]))
+	}
+
+	proxyAge := func(pod string, seconds float64) *model.Sample {
+		return &model.Sample{
+			Metric: model.Metric{"namespace": "emojivoto", "pod": model.LabelValue(pod)},
+			Value:  model.SampleValue(seconds),
+		}
+	}
+	// voting has no proxy
+	ages := model.Vector{proxyAge("web", 3600), proxyAge("emoji", 30)}
+
+	testCases := []struct {
+		minTrafficDuration string
+		expected           []string
+		err                string
+	}{
+		{"", []string{"emojivoto/emoji", "emojivoto/voting", "emojivoto/web"}, ""},
+		{"1m", []string{"emojivoto/web"}, ""},
+		{"30s", []string{"emojivoto/emoji", "emojivoto/web"}, ""},
+		{"2h", []string{}, ""},
+		{"soon", nil, "invalid min traffic duration [soon]"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("min traffic duration %q", tc.minTrafficDuration), func(t *testing.T) {
+			k8sAPI, err := k8s.NewFakeAPI(k8sConfigs...)
+			if err != nil {
+				t.Fatalf("NewFakeAPI returned an error: %s", err)
+			}
+
+			mockProm := &proxyAgeProm{ages: ages}
+			fakeGrpcServer := newGrpcServer(
+				[]promShard{{api: mockProm}},
+				tap.NewTapClient(nil),
+				k8sAPI,
+				"linkerd",
+				[]string{},
+			)
+
+			k8sAPI.Sync(nil)
+
+			rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+				Selector: &pb.ResourceSelection{
+					Resource: &pb.Resource{
+						Namespace: "emojivoto",
+						Type:      pkgK8s.Pod,
+					},
+				},
+				TimeWindow:         "1m",
+				MinTrafficDuration: tc.minTrafficDuration,
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if tc.err != "" {
+				if !strings.HasPrefix(rsp.GetError().GetError(), tc.err) {
+					t.Fatalf("Expected error starting with [%s], got [%s]", tc.err, rsp.GetError().GetError())
+				}
+				return
+			}
+
+			pods := []string{}
+			for _, row := range rsp.GetOk().StatTables[0].GetPodGroup().Rows {
+				pods = append(pods, row.Resource.Namespace+"/"+row.Resource.Name)
+			}
+			if !reflect.DeepEqual(pods, tc.expected) {
+				t.Fatalf("Expected pods %v, got %v", tc.expected, pods)
+			}
+
+			queriedAges := false
+			for _, query := range mockProm.QueriesExecuted {
+				if strings.Contains(query, "process_start_time_seconds") {
+					queriedAges = true
+					expected := `max(time() - process_start_time_seconds{job="linkerd-proxy", namespace="emojivoto"}) by (namespace, pod)`
+					if query != expected {
+						t.Fatalf("Expected query [%s], got [%s]", expected, query)
+					}
+				}
+			}
+			if queriedAges != (tc.minTrafficDuration != "") {
+				t.Fatalf("Expected proxy ages to be queried only with a min traffic duration, queried: %t", queriedAges)
+			}
+		})
+	}
+
+	t.Run("Rejects a min traffic duration for authorities", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI()
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+		fakeGrpcServer := newGrpcServer(
+			[]promShard{{api: &MockProm{Res: model.Vector{}}}},
+			tap.NewTapClient(nil),
+			k8sAPI,
+			"linkerd",
+			[]string{},
+		)
+
+		rsp, err := fakeGrpcServer.StatSummary(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Type: pkgK8s.Authority,
+				},
+			},
+			TimeWindow:         "1m",
+			MinTrafficDuration: "1m",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := "min traffic duration is not supported for resource type 'authority'"
+		if rsp.GetError().GetError() != expected {
+			t.Fatalf("Expected error [%s], got [%s]", expected, rsp.GetError().GetError())
+		}
+	})
+}
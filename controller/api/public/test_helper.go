@@ -11,6 +11,7 @@ import (
 	"github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type MockApiClient struct {
@@ -72,9 +73,14 @@ func (a *MockApi_TapClient) Recv() (*pb.TapEvent, error) {
 type MockApi_TapByResourceClient struct {
 	TapEventsToReturn []pb.TapEvent
 	ErrorsToReturn    []error
+	HeaderToReturn    metadata.MD
 	grpc.ClientStream
 }
 
+func (a *MockApi_TapByResourceClient) Header() (metadata.MD, error) {
+	return a.HeaderToReturn, nil
+}
+
 func (a *MockApi_TapByResourceClient) Recv() (*pb.TapEvent, error) {
 	var eventPopped pb.TapEvent
 	var errorPopped error
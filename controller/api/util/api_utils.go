@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/duration"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/addr"
 	"github.com/linkerd/linkerd2/pkg/k8s"
@@ -20,6 +21,15 @@ import (
   Shared utilities for interacting with the controller public api
 */
 
+const (
+	// TapServerPodHeader, TapServerIPHeader and TapServerProtocolHeader are
+	// the headers that a tap server sends when a tap session begins, with the
+	// name and IP of its pod and the protocol it serves the session over.
+	TapServerPodHeader      = "l5d-tap-server-pod"
+	TapServerIPHeader       = "l5d-tap-server-ip"
+	TapServerProtocolHeader = "l5d-tap-server-protocol"
+)
+
 var (
 	defaultMetricTimeWindow = "1m"
 
@@ -59,6 +69,22 @@ type StatSummaryRequestParams struct {
 	FromType      string
 	FromName      string
 	AllNamespaces bool
+	IncludeLabel  string
+	GrpcOnly      bool
+
+	// PageSize, if set, limits the number of resources returned to a page of
+	// this size. Page is the 1-based page that is returned, and defaults to the
+	// first one.
+	PageSize uint32
+	Page     uint32
+
+	// MinTrafficDuration, if set, omits the resources whose oldest proxy
+	// started less than this long ago.
+	MinTrafficDuration string
+
+	// Authority, if set, only includes the requests whose :authority matches
+	// this glob or prefix pattern in the stats.
+	Authority string
 }
 
 type TapRequestParams struct {
@@ -67,6 +93,7 @@ type TapRequestParams struct {
 	ToResource  string
 	ToNamespace string
 	MaxRps      float32
+	BufferSize  uint32
 	Scheme      string
 	Method      string
 	Authority   string
@@ -131,6 +158,20 @@ func BuildStatSummaryRequest(p StatSummaryRequestParams) (*pb.StatSummaryRequest
 		return nil, err
 	}
 
+	if p.MinTrafficDuration != "" {
+		if _, err := time.ParseDuration(p.MinTrafficDuration); err != nil {
+			return nil, err
+		}
+	}
+
+	var offset uint32
+	if p.Page > 1 {
+		if p.PageSize == 0 {
+			return nil, errors.New("a page cannot be requested without a page size")
+		}
+		offset = (p.Page - 1) * p.PageSize
+	}
+
 	statRequest := &pb.StatSummaryRequest{
 		Selector: &pb.ResourceSelection{
 			Resource: &pb.Resource{
@@ -139,7 +180,13 @@ func BuildStatSummaryRequest(p StatSummaryRequestParams) (*pb.StatSummaryRequest
 				Type:      resourceType,
 			},
 		},
-		TimeWindow: window,
+		TimeWindow:         window,
+		IncludeLabel:       p.IncludeLabel,
+		GrpcOnly:           p.GrpcOnly,
+		Limit:              p.PageSize,
+		Offset:             offset,
+		MinTrafficDuration: p.MinTrafficDuration,
+		Authority:          p.Authority,
 	}
 
 	if p.ToName != "" || p.ToType != "" || p.ToNamespace != "" {
@@ -305,7 +352,8 @@ func BuildTapByResourceRequest(params TapRequestParams) (*pb.TapByResourceReques
 		Target: &pb.ResourceSelection{
 			Resource: &target,
 		},
-		MaxRps: params.MaxRps,
+		MaxRps:     params.MaxRps,
+		BufferSize: params.BufferSize,
 		Match: &pb.TapByResourceRequest_Match{
 			Match: &pb.TapByResourceRequest_Match_All{
 				All: &pb.TapByResourceRequest_Match_Seq{
@@ -333,7 +381,29 @@ func contains(list []string, s string) bool {
 	return false
 }
 
+// RenderTapEvent renders event in the standard tap format, with latencies and
+// durations in microseconds.
 func RenderTapEvent(event *pb.TapEvent) string {
+	return renderTapEvent(event, formatMicroseconds)
+}
+
+// RenderTapEventHumanReadable renders event like RenderTapEvent, but with
+// latencies and durations formatted by FormatTapDuration, e.g. 12ms.
+func RenderTapEventHumanReadable(event *pb.TapEvent) string {
+	return renderTapEvent(event, FormatTapDuration)
+}
+
+// FormatTapDuration formats a tap event duration like time.Duration, e.g.
+// 120µs, 12ms or 1.2s.
+func FormatTapDuration(d *duration.Duration) string {
+	return (time.Duration(d.GetSeconds())*time.Second + time.Duration(d.GetNanos())).String()
+}
+
+func formatMicroseconds(d *duration.Duration) string {
+	return fmt.Sprintf("%dµs", d.GetNanos()/1000)
+}
+
+func renderTapEvent(event *pb.TapEvent, formatDuration func(*duration.Duration) string) string {
 	dstLabels := event.GetDestinationMeta().GetLabels()
 
 	dst := addr.PublicAddressToString(event.GetDestination())
@@ -374,42 +444,42 @@ func RenderTapEvent(event *pb.TapEvent) string {
 		)
 
 	case *pb.TapEvent_Http_ResponseInit_:
-		return fmt.Sprintf("rsp id=%d:%d %s :status=%d latency=%dµs",
+		return fmt.Sprintf("rsp id=%d:%d %s :status=%d latency=%s",
 			ev.ResponseInit.GetId().GetBase(),
 			ev.ResponseInit.GetId().GetStream(),
 			flow,
 			ev.ResponseInit.GetHttpStatus(),
-			ev.ResponseInit.GetSinceRequestInit().GetNanos()/1000,
+			formatDuration(ev.ResponseInit.GetSinceRequestInit()),
 		)
 
 	case *pb.TapEvent_Http_ResponseEnd_:
 		switch eos := ev.ResponseEnd.GetEos().GetEnd().(type) {
 		case *pb.Eos_GrpcStatusCode:
-			return fmt.Sprintf("end id=%d:%d %s grpc-status=%s duration=%dµs response-length=%dB",
+			return fmt.Sprintf("end id=%d:%d %s grpc-status=%s duration=%s response-length=%dB",
 				ev.ResponseEnd.GetId().GetBase(),
 				ev.ResponseEnd.GetId().GetStream(),
 				flow,
 				codes.Code(eos.GrpcStatusCode),
-				ev.ResponseEnd.GetSinceResponseInit().GetNanos()/1000,
+				formatDuration(ev.ResponseEnd.GetSinceResponseInit()),
 				ev.ResponseEnd.GetResponseBytes(),
 			)
 
 		case *pb.Eos_ResetErrorCode:
-			return fmt.Sprintf("end id=%d:%d %s reset-error=%+v duration=%dµs response-length=%dB",
+			return fmt.Sprintf("end id=%d:%d %s reset-error=%+v duration=%s response-length=%dB",
 				ev.ResponseEnd.GetId().GetBase(),
 				ev.ResponseEnd.GetId().GetStream(),
 				flow,
 				eos.ResetErrorCode,
-				ev.ResponseEnd.GetSinceResponseInit().GetNanos()/1000,
+				formatDuration(ev.ResponseEnd.GetSinceResponseInit()),
 				ev.ResponseEnd.GetResponseBytes(),
 			)
 
 		default:
-			return fmt.Sprintf("end id=%d:%d %s duration=%dµs response-length=%dB",
+			return fmt.Sprintf("end id=%d:%d %s duration=%s response-length=%dB",
 				ev.ResponseEnd.GetId().GetBase(),
 				ev.ResponseEnd.GetId().GetStream(),
 				flow,
-				ev.ResponseEnd.GetSinceResponseInit().GetNanos()/1000,
+				formatDuration(ev.ResponseEnd.GetSinceResponseInit()),
 				ev.ResponseEnd.GetResponseBytes(),
 			)
 		}
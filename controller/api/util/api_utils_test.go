@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/duration"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"google.golang.org/grpc/codes"
@@ -107,6 +108,31 @@ func TestBuildStatSummaryRequest(t *testing.T) {
 		}
 	})
 
+	t.Run("Parses min traffic durations", func(t *testing.T) {
+		statSummaryRequest, err := BuildStatSummaryRequest(
+			StatSummaryRequestParams{
+				ResourceType:       k8s.Deployment,
+				MinTrafficDuration: "10m",
+			},
+		)
+		if err != nil {
+			t.Fatalf("Unexpected error from BuildStatSummaryRequest: %s", err)
+		}
+		if statSummaryRequest.MinTrafficDuration != "10m" {
+			t.Fatalf("Unexpected MinTrafficDuration from BuildStatSummaryRequest: %s", statSummaryRequest.MinTrafficDuration)
+		}
+
+		_, err = BuildStatSummaryRequest(
+			StatSummaryRequestParams{
+				ResourceType:       k8s.Deployment,
+				MinTrafficDuration: "10",
+			},
+		)
+		if err == nil {
+			t.Fatalf("BuildStatSummaryRequest unexpectedly accepted min traffic duration 10")
+		}
+	})
+
 	t.Run("Rejects invalid Kubernetes resource types", func(t *testing.T) {
 		expectations := map[string]string{
 			"foo": "cannot find Kubernetes canonical name from friendly name [foo]",
@@ -129,6 +155,25 @@ func TestBuildStatSummaryRequest(t *testing.T) {
 	})
 }
 
+func TestFormatTapDuration(t *testing.T) {
+	testCases := []struct {
+		duration *duration.Duration
+		expected string
+	}{
+		{nil, "0s"},
+		{&duration.Duration{Nanos: 120000}, "120µs"},
+		{&duration.Duration{Nanos: 12000000}, "12ms"},
+		{&duration.Duration{Seconds: 1, Nanos: 200000000}, "1.2s"},
+	}
+
+	for _, tc := range testCases {
+		formatted := FormatTapDuration(tc.duration)
+		if formatted != tc.expected {
+			t.Fatalf("Expected FormatTapDuration(%v) to be [%s], got [%s]", tc.duration, tc.expected, formatted)
+		}
+	}
+}
+
 func TestBuildResource(t *testing.T) {
 	type resourceExp struct {
 		namespace string
@@ -1,6 +1,7 @@
 package ca
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -8,10 +9,12 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"math/big"
+	"sync"
 	"time"
 )
 
-// Issuing certificates concurrently is not supported.
+// CA issues the end-entity certificates of the pods in the mesh. It is safe
+// for concurrent use.
 type CA struct {
 	// validity is the duration for which issued certificates are valid. This
 	// is approximately cert.NotAfter - cert.NotBefore with some additional
@@ -39,12 +42,14 @@ type CA struct {
 	// nextSerialNumber is the serial number of the next certificate to issue.
 	// Serial numbers must not be reused.
 	//
-	// It is assumed there is only one instance of CA and it is assumed that a
-	// given CA object isn't requested to issue certificates concurrently.
+	// It is assumed there is only one instance of CA. Certificates may be
+	// issued concurrently, by the certificate controller and by CSRs, so the
+	// serial number is guarded by serialNumberMu.
 	//
 	// For now we do not attempt to meet CABForum requirements (e.g. regarding
 	// randomness).
 	nextSerialNumber uint64
+	serialNumberMu   sync.Mutex
 }
 
 type CertificateAndPrivateKey struct {
@@ -110,6 +115,12 @@ func (ca *CA) TrustAnchorPEM() string {
 	return ca.rootPEM
 }
 
+// TrustAnchor returns the X.509 certificate of the trust anchor (root CA),
+// which is also the certificate that issues end-entity certificates.
+func (ca *CA) TrustAnchor() *x509.Certificate {
+	return ca.root
+}
+
 // IssueEndEntityCertificate creates a new certificate that is valid for the
 // given DNS name, generating a new keypair for it.
 func (ca *CA) IssueEndEntityCertificate(dnsName string) (*CertificateAndPrivateKey, error) {
@@ -134,10 +145,20 @@ func (ca *CA) IssueEndEntityCertificate(dnsName string) (*CertificateAndPrivateK
 	}, nil
 }
 
+// SignCSR creates a new certificate for the public key and DNS names in csr,
+// valid for the given lifetime. The caller is responsible for verifying the
+// CSR's signature and that the requester is entitled to its names.
+func (ca *CA) SignCSR(csr *x509.CertificateRequest, lifetime time.Duration) ([]byte, error) {
+	template := ca.createTemplate(csr.PublicKey)
+	template.DNSNames = csr.DNSNames
+	template.NotAfter = time.Now().Add(lifetime).Add(ca.clockSkewAllocance)
+	return x509.CreateCertificate(rand.Reader, &template, ca.root, csr.PublicKey, ca.privateKey)
+}
+
 // createTemplate returns a certificate template for a non-CA certificate with
 // no subject name, no subjectAltNames. The template can then be modified into
 // a (root) CA template or an end-entity template by the caller.
-func (ca *CA) createTemplate(publicKey *ecdsa.PublicKey) x509.Certificate {
+func (ca *CA) createTemplate(publicKey crypto.PublicKey) x509.Certificate {
 	// ECDSA is used instead of RSA because ECDSA key generation is
 	// straightforward and fast whereas RSA key generation is extremely slow
 	// and error-prone.
@@ -151,8 +172,10 @@ func (ca *CA) createTemplate(publicKey *ecdsa.PublicKey) x509.Certificate {
 	// anyway since a P-256 scalar is only 256 bits long.
 	const SignatureAlgorithm = x509.ECDSAWithSHA256
 
+	ca.serialNumberMu.Lock()
 	serialNumber := big.NewInt(int64(ca.nextSerialNumber))
 	ca.nextSerialNumber += 1
+	ca.serialNumberMu.Unlock()
 
 	notBefore := time.Now()
 
@@ -1,12 +1,14 @@
 package ca
 
 import (
+	"crypto/x509"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -18,10 +20,12 @@ import (
 )
 
 type CertificateController struct {
-	namespace   string
-	k8sAPI      *k8s.API
-	ca          *CA
-	syncHandler func(key string) error
+	namespace        string
+	clusterDNSDomain string
+	k8sAPI           *k8s.API
+	ca               *CA
+	metrics          *tls.CertificateMetrics
+	syncHandler      func(key string) error
 
 	// The queue is keyed on a string. If the string doesn't contain any dots
 	// then it is a namespace name and the task is to create the CA bundle
@@ -31,25 +35,30 @@ type CertificateController struct {
 	queue workqueue.RateLimitingInterface
 }
 
-func NewCertificateController(controllerNamespace string, k8sAPI *k8s.API) (*CertificateController, error) {
+func NewCertificateController(controllerNamespace, clusterDNSDomain string, k8sAPI *k8s.API) (*CertificateController, error) {
 	ca, err := NewCA()
 	if err != nil {
 		return nil, err
 	}
 
+	metrics := tls.NewCertificateMetrics()
+	metrics.SetIssuer(ca.TrustAnchor())
+
 	c := &CertificateController{
-		namespace: controllerNamespace,
-		k8sAPI:    k8sAPI,
-		ca:        ca,
+		namespace:        controllerNamespace,
+		clusterDNSDomain: clusterDNSDomain,
+		k8sAPI:           k8sAPI,
+		ca:               ca,
+		metrics:          metrics,
 		queue: workqueue.NewNamedRateLimitingQueue(
 			workqueue.DefaultControllerRateLimiter(), "certificates"),
 	}
 
 	k8sAPI.Pod().Informer().AddEventHandler(
-		cache.ResourceEventHandlerFuncs{
+		k8s.InstrumentHandler(k8s.Pod, cache.ResourceEventHandlerFuncs{
 			AddFunc:    c.handlePodAdd,
 			UpdateFunc: c.handlePodUpdate,
-		},
+		}),
 	)
 
 	c.syncHandler = c.syncObject
@@ -57,6 +66,19 @@ func NewCertificateController(controllerNamespace string, k8sAPI *k8s.API) (*Cer
 	return c, nil
 }
 
+// Metrics returns the controller's certificate metrics, to be registered by
+// the caller.
+func (c *CertificateController) Metrics() *tls.CertificateMetrics {
+	return c.metrics
+}
+
+// Certifier returns a Certifier that signs proxies' certificate signing
+// requests with the controller's CA, and records them in its metrics.
+func (c *CertificateController) Certifier() *tls.Certifier {
+	reviewer := c.k8sAPI.Client.AuthenticationV1().TokenReviews()
+	return tls.NewCertifier(c.namespace, c.clusterDNSDomain, 0, reviewer, c.ca).WithMetrics(c.metrics)
+}
+
 func (c *CertificateController) Run(readyCh <-chan struct{}, stopCh <-chan struct{}) {
 	defer runtime.HandleCrash()
 	defer c.queue.ShutDown()
@@ -104,16 +126,22 @@ func (c *CertificateController) syncObject(key string) error {
 
 func (c *CertificateController) syncNamespace(ns string) error {
 	log.Debugf("syncNamespace(%s)", ns)
+	bundle, err := c.trustAnchorBundle(ns)
+	if err != nil {
+		return err
+	}
+
 	configMap := &v1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{Name: pkgK8s.TLSTrustAnchorConfigMapName},
 		Data: map[string]string{
-			pkgK8s.TLSTrustAnchorFileName: c.ca.TrustAnchorPEM(),
+			pkgK8s.TLSTrustAnchorFileName: bundle,
+			pkgK8s.TLSIssuerFileName:      c.ca.TrustAnchorPEM(),
 		},
 	}
 
 	log.Debugf("adding configmap [%s] to namespace [%s]",
 		pkgK8s.TLSTrustAnchorConfigMapName, ns)
-	_, err := c.k8sAPI.Client.CoreV1().ConfigMaps(ns).Create(configMap)
+	_, err = c.k8sAPI.Client.CoreV1().ConfigMaps(ns).Create(configMap)
 	if apierrors.IsAlreadyExists(err) {
 		_, err = c.k8sAPI.Client.CoreV1().ConfigMaps(ns).Update(configMap)
 	}
@@ -121,6 +149,55 @@ func (c *CertificateController) syncNamespace(ns string) error {
 	return err
 }
 
+// trustAnchorBundle returns the PEM bundle of trust anchors to distribute to
+// the given namespace. In addition to the CA's own trust anchor, the bundle
+// includes the trust anchors configured in the control plane namespace, and
+// keeps the unexpired trust anchors already distributed to the namespace so
+// that certificates issued under a previous trust anchor remain valid while
+// they are replaced.
+func (c *CertificateController) trustAnchorBundle(ns string) (string, error) {
+	anchors := []*x509.Certificate{c.ca.TrustAnchor()}
+
+	configured, err := c.getTrustAnchors(c.namespace, pkgK8s.TLSIdentityTrustAnchorsConfigMapName)
+	if err != nil {
+		return "", err
+	}
+	anchors = tls.AppendTrustAnchors(anchors, configured...)
+	c.metrics.SetTrustAnchors(anchors)
+
+	existing, err := c.getTrustAnchors(ns, pkgK8s.TLSTrustAnchorConfigMapName)
+	if err != nil {
+		// The existing bundle is replaced below, so it is not fatal if it can't
+		// be read.
+		log.Warnf("ignoring existing trust anchors in [%s]: %s", ns, err)
+	}
+	anchors = tls.AppendTrustAnchors(anchors, existing...)
+
+	return tls.EncodeTrustAnchors(tls.PruneExpiredTrustAnchors(anchors, time.Now())), nil
+}
+
+// getTrustAnchors returns the trust anchors in the named ConfigMap, or none if
+// the ConfigMap does not exist.
+func (c *CertificateController) getTrustAnchors(ns, name string) ([]*x509.Certificate, error) {
+	configMap, err := c.k8sAPI.Client.CoreV1().ConfigMaps(ns).Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, ok := configMap.Data[pkgK8s.TLSTrustAnchorFileName]
+	if !ok {
+		return nil, nil
+	}
+	anchors, err := tls.ParseTrustAnchors(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust anchors in configmap [%s/%s]: %s", ns, name, err)
+	}
+	return anchors, nil
+}
+
 func (c *CertificateController) syncSecret(key string) error {
 	log.Debugf("syncSecret(%s)", key)
 	parts := strings.Split(key, ".")
@@ -133,16 +210,22 @@ func (c *CertificateController) syncSecret(key string) error {
 		Kind:                parts[1],
 		Namespace:           parts[2],
 		ControllerNamespace: c.namespace,
+		ClusterDNSDomain:    c.clusterDNSDomain,
 	}
 	dnsName := identity.ToDNSName()
 	secretName := identity.ToSecretName()
+	start := time.Now()
 	certAndPrivateKey, err := c.ca.IssueEndEntityCertificate(dnsName)
+	c.metrics.ObserveIssuance(start, err)
 	if err != nil {
 		log.Errorf("Failed to issue certificate for %s", dnsName)
 		return err
 	}
 	secret := &v1.Secret{
-		ObjectMeta: metav1.ObjectMeta{Name: secretName},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   secretName,
+			Labels: map[string]string{pkgK8s.TLSSecretLabel: "true"},
+		},
 		Data: map[string][]byte{
 			pkgK8s.TLSCertFileName:       certAndPrivateKey.Certificate,
 			pkgK8s.TLSPrivateKeyFileName: certAndPrivateKey.PrivateKey,
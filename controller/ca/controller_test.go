@@ -1,12 +1,15 @@
 package ca
 
 import (
+	"crypto/x509"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/linkerd/linkerd2/controller/k8s"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	"k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -47,27 +50,93 @@ func TestCertificateController(t *testing.T) {
 			t.Fatal("timed out waiting for sync")
 		}
 
-		actions := controller.k8sAPI.Client.(*fake.Clientset).Actions()
-		action := actions[len(actions)-2] // configmap create
+		found := false
+		for _, action := range controller.k8sAPI.Client.(*fake.Clientset).Actions() {
+			if action.Matches("create", "configmaps") && action.GetNamespace() == injectedNS {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			t.Fatalf("expected configmap to be created in [%s] namespace", injectedNS)
+		}
+	})
+
+	t.Run("distributes configured and existing trust anchors", func(t *testing.T) {
+		configuredCA, err := NewCA()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		previousCA, err := NewCA()
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		k8sAPI, err := k8s.NewFakeAPI(
+			injectedNSConfig,
+			trustAnchorsConfigMap(controllerNS, pkgK8s.TLSIdentityTrustAnchorsConfigMapName, configuredCA.TrustAnchorPEM()),
+			trustAnchorsConfigMap(injectedNS, pkgK8s.TLSTrustAnchorConfigMapName, previousCA.TrustAnchorPEM()),
+		)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		controller, err := NewCertificateController(controllerNS, "", k8sAPI)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		// syncing twice must not duplicate any trust anchors
+		for i := 0; i < 2; i++ {
+			if err := controller.syncNamespace(injectedNS); err != nil {
+				t.Fatal(err.Error())
+			}
+		}
+
+		configMap, err := k8sAPI.Client.CoreV1().ConfigMaps(injectedNS).Get(pkgK8s.TLSTrustAnchorConfigMapName, meta.GetOptions{})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
 
-		if !action.Matches("create", "configmaps") {
-			t.Fatalf("expected action to be configmap create, got: %+v", action)
+		anchors, err := tls.ParseTrustAnchors(configMap.Data[pkgK8s.TLSTrustAnchorFileName])
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		expected := []*x509.Certificate{controller.ca.TrustAnchor(), configuredCA.TrustAnchor(), previousCA.TrustAnchor()}
+		if len(anchors) != len(expected) {
+			t.Fatalf("expected %d trust anchors, got %d", len(expected), len(anchors))
+		}
+		for i := range expected {
+			if !anchors[i].Equal(expected[i]) {
+				t.Fatalf("expected trust anchor %d to be [%s], got [%s]", i, expected[i].Subject, anchors[i].Subject)
+			}
 		}
 
-		if action.GetNamespace() != injectedNS {
-			t.Fatalf("expected action to happen in [%s] namespace, got [%s] namespace",
-				injectedNS, action.GetNamespace())
+		if issuer := configMap.Data[pkgK8s.TLSIssuerFileName]; issuer != controller.ca.TrustAnchorPEM() {
+			t.Fatalf("expected issuer to be the CA's trust anchor, got:\n%s", issuer)
 		}
 	})
 }
 
+func trustAnchorsConfigMap(namespace, name, bundle string) string {
+	return fmt.Sprintf(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+  namespace: %s
+data:
+  %s: |
+    %s`, name, namespace, pkgK8s.TLSTrustAnchorFileName, strings.Replace(strings.TrimSpace(bundle), "\n", "\n    ", -1))
+}
+
 func new(fixtures ...string) (*CertificateController, chan bool, chan struct{}, error) {
 	k8sAPI, err := k8s.NewFakeAPI(fixtures...)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("NewFakeAPI returned an error: %s", err)
 	}
 
-	controller, err := NewCertificateController(controllerNS, k8sAPI)
+	controller, err := NewCertificateController(controllerNS, "", k8sAPI)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("NewCertificateController returned an error: %s", err)
 	}
@@ -0,0 +1,73 @@
+package ca
+
+import (
+	"context"
+	"net"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/linkerd/linkerd2/controller/gen/controller/identity"
+	"github.com/linkerd/linkerd2/pkg/prometheus"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type identityServer struct {
+	certifier *tls.Certifier
+}
+
+// NewIdentityServer returns a gRPC server, listening on addr, that issues
+// proxies certificates for their pods' service accounts with certifier.
+func NewIdentityServer(addr string, certifier *tls.Certifier) (*grpc.Server, net.Listener, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s := prometheus.NewGrpcServer()
+	pb.RegisterIdentityServer(s, &identityServer{certifier: certifier})
+
+	return s, lis, nil
+}
+
+func (s *identityServer) Certify(ctx context.Context, req *pb.CertifyRequest) (*pb.CertifyResponse, error) {
+	rsp, err := s.certifier.Certify(&tls.CertifyRequest{
+		Token: req.GetToken(),
+		CSR:   req.GetCertificateSigningRequest(),
+	})
+	if err != nil {
+		return nil, certifyErrorStatus(err)
+	}
+
+	validUntil, err := ptypes.TimestampProto(rsp.ValidUntil)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.CertifyResponse{
+		LeafCertificate: rsp.Certificate,
+		Identity:        rsp.Identity,
+		ValidUntil:      validUntil,
+	}, nil
+}
+
+// certifyErrorStatus converts an error returned by a Certifier into a gRPC
+// status, so that proxies can tell refused requests from failed ones.
+func certifyErrorStatus(err error) error {
+	rejection, ok := err.(*tls.RejectionError)
+	if !ok {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	code := codes.InvalidArgument
+	switch rejection.Reason {
+	case tls.ReasonUnauthenticated:
+		code = codes.Unauthenticated
+	case tls.ReasonNotServiceAccount, tls.ReasonIdentityMismatch:
+		code = codes.PermissionDenied
+	case tls.ReasonTokenReviewFailed:
+		code = codes.Unavailable
+	}
+	return status.Error(code, rejection.Error())
+}
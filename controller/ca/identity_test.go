@@ -0,0 +1,154 @@
+package ca
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"sync"
+	"testing"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/controller/identity"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	authV1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func TestIdentityServer(t *testing.T) {
+	webIdentity := "web.serviceaccount.emojivoto.linkerd-managed.controllertest.svc.cluster.local"
+	statuses := map[string]authV1.TokenReviewStatus{
+		"web-token": {
+			Authenticated: true,
+			User:          authV1.UserInfo{Username: "system:serviceaccount:emojivoto:web"},
+		},
+		"user-token": {
+			Authenticated: true,
+			User:          authV1.UserInfo{Username: "jane@example.com"},
+		},
+		"expired-token": {Authenticated: false, Error: "token has expired"},
+	}
+
+	k8sAPI, err := k8s.NewFakeAPI()
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	k8sAPI.Client.(*fake.Clientset).PrependReactor("create", "tokenreviews", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8sTesting.CreateAction).GetObject().(*authV1.TokenReview)
+		reviewStatus, ok := statuses[review.Spec.Token]
+		if !ok {
+			return true, &authV1.TokenReview{}, errors.New("unknown token")
+		}
+		review.Status = reviewStatus
+		return true, review, nil
+	})
+
+	controller, err := NewCertificateController(controllerNS, "", k8sAPI)
+	if err != nil {
+		t.Fatalf("NewCertificateController returned an error: %s", err)
+	}
+	server := &identityServer{certifier: controller.Certifier()}
+
+	newCSR := func(dnsNames ...string) []byte {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: dnsNames}, key)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return csr
+	}
+
+	t.Run("issues a certificate signed by the CA", func(t *testing.T) {
+		rsp, err := server.Certify(context.Background(), &pb.CertifyRequest{
+			Token:                     "web-token",
+			CertificateSigningRequest: newCSR(webIdentity),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		crt, err := x509.ParseCertificate(rsp.GetLeafCertificate())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		roots := x509.NewCertPool()
+		roots.AddCert(controller.ca.root)
+		if _, err := crt.Verify(x509.VerifyOptions{Roots: roots, DNSName: webIdentity}); err != nil {
+			t.Fatalf("Expected the certificate to be valid for [%s], got: %v", webIdentity, err)
+		}
+		if rsp.GetIdentity() != webIdentity {
+			t.Fatalf("Expected identity [%s], got [%s]", webIdentity, rsp.GetIdentity())
+		}
+		if rsp.GetValidUntil() == nil {
+			t.Fatalf("Expected the certificate's expiry to be set")
+		}
+	})
+
+	t.Run("issues unique serial numbers to concurrent requests", func(t *testing.T) {
+		const requests = 20
+		serials := make(chan string, requests)
+		var wg sync.WaitGroup
+		for i := 0; i < requests; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				rsp, err := server.Certify(context.Background(), &pb.CertifyRequest{
+					Token:                     "web-token",
+					CertificateSigningRequest: newCSR(webIdentity),
+				})
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+					return
+				}
+				crt, err := x509.ParseCertificate(rsp.GetLeafCertificate())
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+					return
+				}
+				serials <- crt.SerialNumber.String()
+			}()
+		}
+		wg.Wait()
+		close(serials)
+
+		seen := map[string]bool{}
+		for serial := range serials {
+			if seen[serial] {
+				t.Fatalf("Expected unique serial numbers, got [%s] more than once", serial)
+			}
+			seen[serial] = true
+		}
+	})
+
+	t.Run("returns a status code for each rejection", func(t *testing.T) {
+		testCases := []struct {
+			token    string
+			csr      []byte
+			expected codes.Code
+		}{
+			{"web-token", []byte("not a csr"), codes.InvalidArgument},
+			{"expired-token", newCSR(webIdentity), codes.Unauthenticated},
+			{"user-token", newCSR(webIdentity), codes.PermissionDenied},
+			{"web-token", newCSR("voting.serviceaccount.emojivoto.linkerd-managed.controllertest.svc.cluster.local"), codes.PermissionDenied},
+			{"unknown-token", newCSR(webIdentity), codes.Unavailable},
+		}
+
+		for _, tc := range testCases {
+			_, err := server.Certify(context.Background(), &pb.CertifyRequest{
+				Token:                     tc.token,
+				CertificateSigningRequest: tc.csr,
+			})
+			if code := status.Code(err); code != tc.expected {
+				t.Fatalf("Expected status code %s for token [%s], got: %v", tc.expected, tc.token, err)
+			}
+		}
+	})
+}
@@ -1,4 +1,4 @@
-package main
+package ca
 
 import (
 	"flag"
@@ -10,14 +10,22 @@ import (
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/flags"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 )
 
-func main() {
-	metricsAddr := flag.String("metrics-addr", ":9997", "address to serve scrapable metrics on")
-	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
-	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
-	flags.ConfigureAndParse()
+// Main runs the ca component with args, which exclude the program and
+// component names.
+func Main(args []string) {
+	cmd := flag.NewFlagSet("ca", flag.ExitOnError)
+
+	addr := cmd.String("addr", ":8083", "address to serve the identity API on")
+	metricsAddr := cmd.String("metrics-addr", ":9997", "address to serve scrapable metrics on")
+	controllerNamespace := cmd.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	kubeConfigPath := cmd.String("kubeconfig", "", "path to kube config")
+	clusterDNSDomain := cmd.String("cluster-dns-domain", pkgK8s.DefaultClusterDNSDomain, "DNS domain of the cluster, which the issued identities are in")
+	flags.ConfigureAndParse(cmd, args)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
@@ -28,14 +36,21 @@ func main() {
 	}
 	k8sAPI := k8s.NewAPI(
 		k8sClient,
+		nil,
 		k8s.Pod,
 		k8s.RS,
 	)
 
-	controller, err := ca.NewCertificateController(*controllerNamespace, k8sAPI)
+	controller, err := ca.NewCertificateController(*controllerNamespace, *clusterDNSDomain, k8sAPI)
 	if err != nil {
 		log.Fatalf("Failed to create CertificateController: %v", err)
 	}
+	prometheus.MustRegister(controller.Metrics())
+
+	server, lis, err := ca.NewIdentityServer(*addr, controller.Certifier())
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	stopCh := make(chan struct{})
 	ready := make(chan struct{})
@@ -47,10 +62,16 @@ func main() {
 		controller.Run(ready, stopCh)
 	}()
 
+	go func() {
+		log.Infof("starting gRPC server on %s", *addr)
+		server.Serve(lis)
+	}()
+
 	go admin.StartServer(*metricsAddr, ready)
 
 	<-stop
 
 	log.Info("shutting down")
+	server.GracefulStop()
 	close(stopCh)
 }
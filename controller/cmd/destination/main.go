@@ -1,4 +1,4 @@
-package main
+package destination
 
 import (
 	"flag"
@@ -13,13 +13,18 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func main() {
-	addr := flag.String("addr", "127.0.0.1:8089", "address to serve on")
-	metricsAddr := flag.String("metrics-addr", ":9999", "address to serve scrapable metrics on")
-	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
-	k8sDNSZone := flag.String("kubernetes-dns-zone", "", "The DNS suffix for the local Kubernetes zone.")
-	enableTLS := flag.Bool("enable-tls", false, "Enable TLS connections among pods in the service mesh")
-	flags.ConfigureAndParse()
+// Main runs the destination component with args, which exclude the program and
+// component names.
+func Main(args []string) {
+	cmd := flag.NewFlagSet("destination", flag.ExitOnError)
+
+	addr := cmd.String("addr", "127.0.0.1:8089", "address to serve on")
+	metricsAddr := cmd.String("metrics-addr", ":9999", "address to serve scrapable metrics on")
+	kubeConfigPath := cmd.String("kubeconfig", "", "path to kube config")
+	k8sDNSZone := cmd.String("kubernetes-dns-zone", "", "The DNS suffix for the local Kubernetes zone.")
+	enableTLS := cmd.Bool("enable-tls", false, "Enable TLS connections among pods in the service mesh")
+	informerConfig := k8s.AddInformerFlags(cmd, "the endpoints of pods that aren't meshed are then not resolved")
+	flags.ConfigureAndParse(cmd, args)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
@@ -28,11 +33,18 @@ func main() {
 	if err != nil {
 		log.Fatal(err.Error())
 	}
-	k8sAPI := k8s.NewAPI(
+	spClient, err := k8s.NewSpClientSet(*kubeConfigPath)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	k8sAPI := k8s.NewAPIWithConfig(
 		k8sClient,
+		spClient,
+		informerConfig(),
 		k8s.Endpoint,
 		k8s.Pod,
 		k8s.RS,
+		k8s.SP,
 		k8s.Svc,
 	)
 
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/linkerd/linkerd2/controller/cmd/ca"
+	"github.com/linkerd/linkerd2/controller/cmd/destination"
+	"github.com/linkerd/linkerd2/controller/cmd/proxy-api"
+	"github.com/linkerd/linkerd2/controller/cmd/public-api"
+	"github.com/linkerd/linkerd2/controller/cmd/sp-validator"
+	"github.com/linkerd/linkerd2/controller/cmd/tap"
+)
+
+// components are the control plane components that the controller binary
+// runs, by the name that selects each of them as the binary's first argument.
+// The flags after the name are the component's.
+var components = map[string]func(args []string){
+	"ca":           ca.Main,
+	"destination":  destination.Main,
+	"proxy-api":    proxyapi.Main,
+	"public-api":   publicapi.Main,
+	"sp-validator": spvalidator.Main,
+	"tap":          tap.Main,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintf(os.Stderr, "Usage: %s <component> [flags]\n%s", os.Args[0], componentsUsage())
+		os.Exit(1)
+	}
+
+	run, ok := components[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown component: %s\n%s", os.Args[1], componentsUsage())
+		os.Exit(1)
+	}
+
+	run(os.Args[2:])
+}
+
+func componentsUsage() string {
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("component must be one of: %s\n", strings.Join(names, ", "))
+}
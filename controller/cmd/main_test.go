@@ -0,0 +1,88 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	pkgPrometheus "github.com/linkerd/linkerd2/pkg/prometheus"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TestMain runs the controller binary's main, with the args in
+// CONTROLLER_ARGS, when the test binary is run by runController.
+func TestMain(m *testing.M) {
+	if args, ok := os.LookupEnv("CONTROLLER_ARGS"); ok {
+		os.Args = append([]string{"controller"}, strings.Fields(args)...)
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runController runs the controller binary with args, and returns its output.
+func runController(args ...string) (string, error) {
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), "CONTROLLER_ARGS="+strings.Join(args, " "))
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func TestMainExitsOnBadArgs(t *testing.T) {
+	for name := range components {
+		name := name // pin
+		t.Run("Exits non-zero on bad "+name+" flags", func(t *testing.T) {
+			out, err := runController(name, "-not-a-flag")
+			if _, ok := err.(*exec.ExitError); !ok {
+				t.Fatalf("Expected %s to exit non-zero, got: %v\n%s", name, err, out)
+			}
+			if !strings.Contains(out, "-not-a-flag") {
+				t.Fatalf("Expected %s to report the bad flag, got:\n%s", name, out)
+			}
+		})
+	}
+
+	t.Run("Exits non-zero on an unknown component", func(t *testing.T) {
+		out, err := runController("proxy-injector")
+		if _, ok := err.(*exec.ExitError); !ok {
+			t.Fatalf("Expected an unknown component to exit non-zero, got: %v\n%s", err, out)
+		}
+		if !strings.Contains(out, "unknown component: proxy-injector") {
+			t.Fatalf("Expected an unknown component error, got:\n%s", out)
+		}
+	})
+
+	t.Run("Exits non-zero without a component", func(t *testing.T) {
+		out, err := runController()
+		if _, ok := err.(*exec.ExitError); !ok {
+			t.Fatalf("Expected no component to exit non-zero, got: %v\n%s", err, out)
+		}
+	})
+}
+
+func TestMetricNamesDontCollide(t *testing.T) {
+	// Every component's package registers its metrics with the default
+	// registry as the binary starts, which panics on collisions. The metrics
+	// that components register once they run, under the shared admin server,
+	// must not collide with those either.
+	if err := prometheus.Register(tls.NewCertificateMetrics()); err != nil {
+		t.Fatalf("Unexpected error registering the CA's metrics: %v", err)
+	}
+	pkgPrometheus.WithTelemetry(http.NotFoundHandler())
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error gathering metrics: %v", err)
+	}
+
+	names := map[string]bool{}
+	for _, family := range families {
+		if names[family.GetName()] {
+			t.Fatalf("Metric %s is exported more than once", family.GetName())
+		}
+		names[family.GetName()] = true
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package proxyapi
 
 import (
 	"flag"
@@ -13,11 +13,15 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func main() {
-	addr := flag.String("addr", ":8086", "address to serve on")
-	metricsAddr := flag.String("metrics-addr", ":9996", "address to serve scrapable metrics on")
-	destinationAddr := flag.String("destination-addr", "127.0.0.1:8089", "address of destination service")
-	flags.ConfigureAndParse()
+// Main runs the proxy-api component with args, which exclude the program and
+// component names.
+func Main(args []string) {
+	cmd := flag.NewFlagSet("proxy-api", flag.ExitOnError)
+
+	addr := cmd.String("addr", ":8086", "address to serve on")
+	metricsAddr := cmd.String("metrics-addr", ":9996", "address to serve scrapable metrics on")
+	destinationAddr := cmd.String("destination-addr", "127.0.0.1:8089", "address of destination service")
+	flags.ConfigureAndParse(cmd, args)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
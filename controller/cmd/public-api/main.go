@@ -1,31 +1,47 @@
-package main
+package publicapi
 
 import (
 	"context"
 	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/controller/tap"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/flags"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	promApi "github.com/prometheus/client_golang/api"
 	log "github.com/sirupsen/logrus"
 )
 
-func main() {
-	addr := flag.String("addr", ":8085", "address to serve on")
-	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
-	prometheusUrl := flag.String("prometheus-url", "http://127.0.0.1:9090", "prometheus url")
-	metricsAddr := flag.String("metrics-addr", ":9995", "address to serve scrapable metrics on")
-	tapAddr := flag.String("tap-addr", "127.0.0.1:8088", "address of tap service")
-	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
-	ignoredNamespaces := flag.String("ignore-namespaces", "kube-system", "comma separated list of namespaces to not list pods from")
-	flags.ConfigureAndParse()
+// Main runs the public-api component with args, which exclude the program and
+// component names.
+func Main(args []string) {
+	cmd := flag.NewFlagSet("public-api", flag.ExitOnError)
+
+	addr := cmd.String("addr", ":8085", "address to serve on")
+	kubeConfigPath := cmd.String("kubeconfig", "", "path to kube config")
+	prometheusUrls := cmd.String("prometheus-url", "http://127.0.0.1:9090", "comma separated list of prometheus urls; stats are merged from all of them if the proxies' metrics are sharded across several prometheus instances")
+	metricsAddr := cmd.String("metrics-addr", ":9995", "address to serve scrapable metrics on")
+	tapAddr := cmd.String("tap-addr", "127.0.0.1:8088", "address of tap service")
+	controllerNamespace := cmd.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	ignoredNamespaces := cmd.String("ignore-namespaces", "kube-system", "comma separated list of namespaces to not list pods from")
+	tlsAddr := cmd.String("tls-addr", "", "address to serve on with TLS, in addition to addr; requires tls-cert and tls-key")
+	tlsCert := cmd.String("tls-cert", "", "path to the PEM-encoded certificate to serve with on tls-addr")
+	tlsKey := cmd.String("tls-key", "", "path to the PEM-encoded private key to serve with on tls-addr")
+	prometheusCAFile := cmd.String("prometheus-ca-file", "", "path to the PEM-encoded CA certificate that issued prometheus' serving certificate, for https prometheus urls")
+	heartbeatInterval := cmd.Duration("heartbeat-interval", time.Minute, "how often to compute the heartbeat metrics, which are served on the metrics address at /metrics/heartbeat")
+	informerConfig := k8s.AddInformerFlags(cmd, "pods that aren't meshed are then missing from stats and pod lists")
+	heartbeatPushgatewayUrl := cmd.String("heartbeat-pushgateway-url", "", "url of a prometheus pushgateway to push the heartbeat metrics to; they are not pushed if unset")
+	flags.ConfigureAndParse(cmd, args)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
@@ -40,28 +56,48 @@ func main() {
 	if err != nil {
 		log.Fatal(err.Error())
 	}
-	k8sAPI := k8s.NewAPI(
+	k8sAPI := k8s.NewAPIWithConfig(
 		k8sClient,
+		nil,
+		informerConfig(),
 		k8s.Deploy,
+		k8s.Job,
 		k8s.NS,
 		k8s.Pod,
 		k8s.RC,
 		k8s.RS,
+		k8s.SA,
 		k8s.Svc,
 	)
 
-	prometheusClient, err := promApi.NewClient(promApi.Config{Address: *prometheusUrl})
-	if err != nil {
-		log.Fatal(err.Error())
+	prometheusClients := make(map[string]promApi.Client)
+	for _, prometheusUrl := range strings.Split(*prometheusUrls, ",") {
+		prometheusConfig := promApi.Config{Address: prometheusUrl}
+		if *prometheusCAFile != "" {
+			prometheusConfig.RoundTripper, err = newPrometheusTLSTransport(prometheusUrl, *prometheusCAFile)
+			if err != nil {
+				log.Fatal(err.Error())
+			}
+		}
+		prometheusClients[prometheusUrl], err = promApi.NewClient(prometheusConfig)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
 	}
 
+	heartbeat := public.NewHeartbeat(public.HeartbeatConfig{
+		Interval:       *heartbeatInterval,
+		PushgatewayURL: *heartbeatPushgatewayUrl,
+	})
+
 	server := public.NewServer(
 		*addr,
-		prometheusClient,
+		prometheusClients,
 		tapClient,
 		k8sAPI,
 		*controllerNamespace,
 		strings.Split(*ignoredNamespaces, ","),
+		heartbeat,
 	)
 
 	ready := make(chan struct{})
@@ -73,10 +109,48 @@ func main() {
 		server.ListenAndServe()
 	}()
 
-	go admin.StartServer(*metricsAddr, ready)
+	var tlsServer *http.Server
+	if *tlsAddr != "" {
+		tlsConfig, err := tls.NewServerTLSConfig(*tlsCert, *tlsKey)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		tlsServer = &http.Server{Addr: *tlsAddr, Handler: server.Handler, TLSConfig: tlsConfig}
+
+		go func() {
+			log.Infof("starting HTTPS server on %+v", *tlsAddr)
+			tlsServer.ListenAndServeTLS("", "")
+		}()
+	}
+
+	go admin.StartServer(*metricsAddr, ready, admin.MetricsGroup{Name: "heartbeat", Gatherer: heartbeat})
 
 	<-stop
 
 	log.Infof("shutting down HTTP server on %+v", *addr)
 	server.Shutdown(context.Background())
+	if tlsServer != nil {
+		log.Infof("shutting down HTTPS server on %+v", *tlsAddr)
+		tlsServer.Shutdown(context.Background())
+	}
+}
+
+// newPrometheusTLSTransport returns a transport that verifies that the
+// Prometheus server at prometheusUrl presents a certificate for its host name,
+// issued by the CA in caFile.
+func newPrometheusTLSTransport(prometheusUrl, caFile string) (http.RoundTripper, error) {
+	u, err := url.Parse(prometheusUrl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("prometheus-ca-file requires an https prometheus url, got %s", prometheusUrl)
+	}
+
+	tlsConfig, err := tls.NewClientTLSConfig(caFile, u.Hostname())
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Transport{TLSClientConfig: tlsConfig}, nil
 }
@@ -0,0 +1,46 @@
+package spvalidator
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/linkerd/linkerd2/controller/spvalidator"
+	"github.com/linkerd/linkerd2/pkg/admin"
+	"github.com/linkerd/linkerd2/pkg/flags"
+	log "github.com/sirupsen/logrus"
+)
+
+// Main runs the sp-validator component with args, which exclude the program and
+// component names.
+func Main(args []string) {
+	cmd := flag.NewFlagSet("sp-validator", flag.ExitOnError)
+
+	addr := cmd.String("addr", ":8443", "address to serve the admission webhook on")
+	metricsAddr := cmd.String("metrics-addr", ":9994", "address to serve scrapable metrics on")
+	tlsCert := cmd.String("tls-cert", "/var/linkerd-io/sp-validator-tls/tls.crt", "path to the PEM-encoded certificate to serve the webhook with")
+	tlsKey := cmd.String("tls-key", "/var/linkerd-io/sp-validator-tls/tls.key", "path to the PEM-encoded private key to serve the webhook with")
+	flags.ConfigureAndParse(cmd, args)
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+	server, err := spvalidator.NewServer(*addr, *tlsCert, *tlsKey)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	go func() {
+		log.Infof("starting HTTPS server on %+v", *addr)
+		server.ListenAndServeTLS("", "")
+	}()
+
+	go admin.StartServer(*metricsAddr, nil)
+
+	<-stop
+
+	log.Infof("shutting down HTTPS server on %+v", *addr)
+	server.Shutdown(context.Background())
+}
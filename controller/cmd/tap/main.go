@@ -1,4 +1,4 @@
-package main
+package tap
 
 import (
 	"flag"
@@ -13,12 +13,18 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func main() {
-	addr := flag.String("addr", "127.0.0.1:8088", "address to serve on")
-	metricsAddr := flag.String("metrics-addr", ":9998", "address to serve scrapable metrics on")
-	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
-	tapPort := flag.Uint("tap-port", 4190, "proxy tap port to connect to")
-	flags.ConfigureAndParse()
+// Main runs the tap component with args, which exclude the program and
+// component names.
+func Main(args []string) {
+	cmd := flag.NewFlagSet("tap", flag.ExitOnError)
+
+	addr := cmd.String("addr", "127.0.0.1:8088", "address to serve on")
+	metricsAddr := cmd.String("metrics-addr", ":9998", "address to serve scrapable metrics on")
+	kubeConfigPath := cmd.String("kubeconfig", "", "path to kube config")
+	tapPort := cmd.Uint("tap-port", 4190, "proxy tap port to connect to")
+	podName := cmd.String("pod-name", "", "name of the pod that the tap server runs in, reported to tap clients")
+	podIP := cmd.String("pod-ip", "", "IP of the pod that the tap server runs in, reported to tap clients")
+	flags.ConfigureAndParse(cmd, args)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
@@ -29,6 +35,7 @@ func main() {
 	}
 	k8sAPI := k8s.NewAPI(
 		clientSet,
+		nil,
 		k8s.Deploy,
 		k8s.NS,
 		k8s.Pod,
@@ -36,7 +43,7 @@ func main() {
 		k8s.Svc,
 	)
 
-	server, lis, err := tap.NewServer(*addr, *tapPort, k8sAPI)
+	server, lis, err := tap.NewServer(*addr, *tapPort, *podName, *podIP, k8sAPI)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
@@ -46,18 +46,18 @@ func newEndpointsWatcher(k8sAPI *k8s.API) *endpointsWatcher {
 	}
 
 	k8sAPI.Svc().Informer().AddEventHandler(
-		cache.ResourceEventHandlerFuncs{
+		k8s.InstrumentHandler(k8s.Svc, cache.ResourceEventHandlerFuncs{
 			AddFunc:    watcher.addService,
 			UpdateFunc: watcher.updateService,
-		},
+		}),
 	)
 
 	k8sAPI.Endpoint().Informer().AddEventHandler(
-		cache.ResourceEventHandlerFuncs{
+		k8s.InstrumentHandler(k8s.Endpoint, cache.ResourceEventHandlerFuncs{
 			AddFunc:    watcher.addEndpoints,
 			UpdateFunc: watcher.updateEndpoints,
 			DeleteFunc: watcher.deleteEndpoints,
-		},
+		}),
 	)
 
 	return watcher
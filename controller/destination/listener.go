@@ -65,6 +65,7 @@ type endpointListener struct {
 	ownerKindAndName ownerKindAndNameFn
 	labels           map[string]string
 	enableTLS        bool
+	clusterDNSDomain string
 	stopCh           chan struct{}
 }
 
@@ -72,12 +73,14 @@ func newEndpointListener(
 	stream pb.Destination_GetServer,
 	ownerKindAndName ownerKindAndNameFn,
 	enableTLS bool,
+	clusterDNSDomain string,
 ) *endpointListener {
 	return &endpointListener{
 		stream:           stream,
 		ownerKindAndName: ownerKindAndName,
 		labels:           make(map[string]string),
 		enableTLS:        enableTLS,
+		clusterDNSDomain: clusterDNSDomain,
 		stopCh:           make(chan struct{}),
 	}
 }
@@ -200,6 +203,7 @@ func (l *endpointListener) getAddrMetadata(pod *coreV1.Pod) (map[string]string,
 		Kind:                ownerKind,
 		Namespace:           pod.Namespace,
 		ControllerNamespace: controllerNs,
+		ClusterDNSDomain:    l.clusterDNSDomain,
 	}
 
 	return labels, hint, &pb.TlsIdentity{
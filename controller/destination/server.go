@@ -14,9 +14,10 @@ import (
 )
 
 type server struct {
-	k8sAPI    *k8s.API
-	resolvers []streamingDestinationResolver
-	enableTLS bool
+	k8sAPI     *k8s.API
+	resolvers  []streamingDestinationResolver
+	enableTLS  bool
+	k8sDNSZone string
 }
 
 // The Destination service serves service discovery information to the proxy.
@@ -36,9 +37,10 @@ func NewServer(addr, k8sDNSZone string, enableTLS bool, k8sAPI *k8s.API, done ch
 	}
 
 	srv := server{
-		k8sAPI:    k8sAPI,
-		resolvers: resolvers,
-		enableTLS: enableTLS,
+		k8sAPI:     k8sAPI,
+		resolvers:  resolvers,
+		enableTLS:  enableTLS,
+		k8sDNSZone: k8sDNSZone,
 	}
 
 	lis, err := net.Listen("tcp", addr)
@@ -88,7 +90,7 @@ func (s *server) Get(dest *pb.GetDestination, stream pb.Destination_GetServer) e
 }
 
 func (s *server) streamResolutionUsingCorrectResolverFor(host string, port int, stream pb.Destination_GetServer) error {
-	listener := newEndpointListener(stream, s.k8sAPI.GetOwnerKindAndName, s.enableTLS)
+	listener := newEndpointListener(stream, s.k8sAPI.GetOwnerKindAndName, s.enableTLS, s.k8sDNSZone)
 
 	for _, resolver := range s.resolvers {
 		resolverCanResolve, err := resolver.canResolve(host, port)
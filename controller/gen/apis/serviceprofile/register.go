@@ -0,0 +1,6 @@
+package serviceprofile
+
+const (
+	// GroupName is the API group for Linkerd's custom resources.
+	GroupName = "linkerd.io"
+)
@@ -0,0 +1,5 @@
+// +k8s:deepcopy-gen=package
+
+// Package v1alpha1 is the v1alpha1 version of the ServiceProfile API.
+// +groupName=linkerd.io
+package v1alpha1
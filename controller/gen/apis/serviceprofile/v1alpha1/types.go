@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceProfile describes the routes served by a service, and how the
+// responses on each of those routes should be classified.
+type ServiceProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceProfileSpec `json:"spec"`
+}
+
+// ServiceProfileSpec is the spec for a ServiceProfile resource
+type ServiceProfileSpec struct {
+	Routes      []*RouteSpec `json:"routes"`
+	RetryBudget *RetryBudget `json:"retryBudget,omitempty"`
+}
+
+// RouteSpec names a route and specifies which requests belong to it.
+// Timeout is validated, but not yet enforced: the destination service doesn't
+// serve profiles to proxies, so no requests time out.
+type RouteSpec struct {
+	Name            string           `json:"name"`
+	Condition       *RequestMatch    `json:"condition"`
+	ResponseClasses []*ResponseClass `json:"responseClasses,omitempty"`
+	IsRetryable     bool             `json:"isRetryable,omitempty"`
+	Timeout         string           `json:"timeout,omitempty"`
+}
+
+// RetryBudget limits the retries of requests to retryable routes, so that
+// retries can't overwhelm a struggling service. Retries are allowed as long
+// as they add no more than RetryRatio to the number of original requests, in
+// addition to MinRetriesPerSecond, measured over a window of TTL.
+//
+// Retry budgets and IsRetryable are validated, but not yet enforced: the
+// destination service doesn't serve profiles to proxies, so no requests are
+// retried.
+type RetryBudget struct {
+	RetryRatio          float32 `json:"retryRatio"`
+	MinRetriesPerSecond uint32  `json:"minRetriesPerSecond"`
+	TTL                 string  `json:"ttl"`
+}
+
+// RequestMatch describes the conditions under which a request matches a
+// route. Exactly one field should be set.
+type RequestMatch struct {
+	All       []*RequestMatch `json:"all,omitempty"`
+	Not       *RequestMatch   `json:"not,omitempty"`
+	Any       []*RequestMatch `json:"any,omitempty"`
+	PathRegex string          `json:"pathRegex,omitempty"`
+	Method    string          `json:"method,omitempty"`
+}
+
+// ResponseClass describes how to classify the responses that match its
+// condition.
+type ResponseClass struct {
+	Condition *ResponseMatch `json:"condition"`
+	IsFailure bool           `json:"isFailure,omitempty"`
+}
+
+// ResponseMatch describes the conditions under which a response belongs to
+// a response class. Exactly one field should be set.
+type ResponseMatch struct {
+	All        []*ResponseMatch `json:"all,omitempty"`
+	Not        *ResponseMatch   `json:"not,omitempty"`
+	Any        []*ResponseMatch `json:"any,omitempty"`
+	Status     *Range           `json:"status,omitempty"`
+	GRPCStatus *Range           `json:"grpcStatus,omitempty"`
+}
+
+// Range is an inclusive range of HTTP or gRPC status codes. An unset bound
+// is unbounded.
+type Range struct {
+	Min uint32 `json:"min,omitempty"`
+	Max uint32 `json:"max,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceProfileList is a list of ServiceProfile resources
+type ServiceProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ServiceProfile `json:"items"`
+}
@@ -35,17 +35,22 @@ const (
 	CheckStatus_OK    CheckStatus = 0
 	CheckStatus_FAIL  CheckStatus = 1
 	CheckStatus_ERROR CheckStatus = 2
+	// SKIPPED checks could not be run because of how Linkerd was installed,
+	// and don't affect the overall status.
+	CheckStatus_SKIPPED CheckStatus = 3
 )
 
 var CheckStatus_name = map[int32]string{
 	0: "OK",
 	1: "FAIL",
 	2: "ERROR",
+	3: "SKIPPED",
 }
 var CheckStatus_value = map[string]int32{
-	"OK":    0,
-	"FAIL":  1,
-	"ERROR": 2,
+	"OK":      0,
+	"FAIL":    1,
+	"ERROR":   2,
+	"SKIPPED": 3,
 }
 
 func (x CheckStatus) String() string {
@@ -127,25 +132,25 @@ func init() {
 func init() { proto.RegisterFile("common/healthcheck.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 306 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0x4f, 0x4b, 0xc3, 0x40,
-	0x10, 0xc5, 0x4d, 0x5b, 0xab, 0x9d, 0xa2, 0xc4, 0x05, 0x61, 0xc1, 0x4b, 0x29, 0x1e, 0x42, 0x0f,
-	0x09, 0x54, 0xaf, 0xa2, 0x56, 0x2d, 0x88, 0x7f, 0x0a, 0x5b, 0x45, 0xf0, 0x96, 0xa6, 0x63, 0x13,
-	0xba, 0xd9, 0xad, 0x3b, 0x9b, 0x43, 0x3f, 0xa9, 0x5f, 0x47, 0xba, 0x49, 0xa1, 0x52, 0x11, 0x4f,
-	0xbb, 0xbc, 0x7d, 0xbf, 0x9d, 0x37, 0x33, 0xc0, 0x13, 0x9d, 0xe7, 0x5a, 0x45, 0x29, 0xc6, 0xd2,
-	0xa6, 0x49, 0x8a, 0xc9, 0x3c, 0x5c, 0x18, 0x6d, 0x35, 0x3b, 0x91, 0x99, 0x9a, 0xa3, 0x99, 0xf6,
-	0xc3, 0xd2, 0x12, 0x6e, 0x58, 0xba, 0x5f, 0x1e, 0xb4, 0x6f, 0x56, 0x37, 0x81, 0x54, 0x48, 0xcb,
-	0x4e, 0xe1, 0x60, 0x5c, 0x4c, 0x68, 0x49, 0x16, 0xf3, 0xe7, 0x38, 0x47, 0xee, 0x75, 0xbc, 0xa0,
-	0x25, 0x7e, 0x8a, 0xac, 0x07, 0xbe, 0x83, 0x6e, 0x91, 0x12, 0x93, 0x2d, 0x6c, 0xa6, 0x15, 0xaf,
-	0x39, 0xe3, 0x96, 0xce, 0xae, 0xa0, 0x39, 0xb6, 0xb1, 0x2d, 0x88, 0xd7, 0x3b, 0x5e, 0x70, 0xd8,
-	0x0f, 0xc2, 0x3f, 0xf2, 0x84, 0x0e, 0x2f, 0xfd, 0xa2, 0xe2, 0xd8, 0x39, 0x1c, 0x0f, 0x4d, 0x86,
-	0x6a, 0x2a, 0x97, 0x4f, 0x48, 0x14, 0xcf, 0xf0, 0x45, 0xbf, 0x12, 0x1a, 0xde, 0x70, 0x25, 0x7f,
-	0x7f, 0xec, 0x32, 0xf0, 0xc7, 0x28, 0x3f, 0xaa, 0xe6, 0x3e, 0x0b, 0x24, 0xdb, 0x7d, 0x83, 0xa3,
-	0x0d, 0x8d, 0x16, 0x5a, 0x11, 0xb2, 0x01, 0xec, 0x19, 0xd7, 0x3c, 0x71, 0xaf, 0x53, 0x0f, 0xda,
-	0xff, 0x49, 0x58, 0x4e, 0x4b, 0xac, 0xc1, 0x5e, 0xaf, 0x9a, 0x62, 0x95, 0xb8, 0x09, 0xb5, 0xd1,
-	0x83, 0xbf, 0xc3, 0xf6, 0xa1, 0x31, 0xbc, 0xbe, 0x7f, 0xf4, 0x3d, 0xd6, 0x82, 0xdd, 0x3b, 0x21,
-	0x46, 0xc2, 0xaf, 0x0d, 0x2e, 0xdf, 0x2f, 0x66, 0x99, 0x4d, 0x8b, 0xc9, 0xea, 0xf7, 0xa8, 0x2a,
-	0xb5, 0x3e, 0xfb, 0x51, 0xa2, 0x95, 0x35, 0x5a, 0x4a, 0x34, 0xd1, 0x0c, 0x55, 0xb4, 0xbd, 0xd6,
-	0x49, 0xd3, 0xed, 0xf5, 0xec, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x78, 0x7f, 0xc1, 0x03, 0xf3, 0x01,
-	0x00, 0x00,
+	// 314 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x51, 0xd1, 0x4a, 0x02, 0x41,
+	0x14, 0x6d, 0xd5, 0x34, 0xaf, 0x14, 0xdb, 0x40, 0xb0, 0xd0, 0x8b, 0x48, 0x0f, 0x8b, 0x0f, 0xbb,
+	0x60, 0x41, 0x4f, 0x51, 0x99, 0x0a, 0x62, 0xa5, 0xcc, 0x16, 0x41, 0x6f, 0xeb, 0x7a, 0x73, 0x17,
+	0x67, 0x67, 0x6c, 0xee, 0xec, 0x83, 0x5f, 0xda, 0xef, 0x84, 0xeb, 0x0a, 0x86, 0x11, 0x3d, 0xcd,
+	0x70, 0xee, 0x39, 0x9c, 0x73, 0xef, 0x01, 0x27, 0x52, 0x69, 0xaa, 0xa4, 0x1f, 0x63, 0x28, 0x4c,
+	0x1c, 0xc5, 0x18, 0x2d, 0xbc, 0xa5, 0x56, 0x46, 0xb1, 0x73, 0x91, 0xc8, 0x05, 0xea, 0x59, 0xc7,
+	0xdb, 0x50, 0xbc, 0x1d, 0x4a, 0xeb, 0xcb, 0x82, 0xc6, 0xc3, 0xfa, 0xc7, 0x91, 0x32, 0x61, 0xd8,
+	0x05, 0x1c, 0x07, 0xd9, 0x94, 0x56, 0x64, 0x30, 0x7d, 0x0e, 0x53, 0x74, 0xac, 0xa6, 0xe5, 0xd6,
+	0xf9, 0x4f, 0x90, 0xb5, 0xc1, 0xce, 0x45, 0x3d, 0xa4, 0x48, 0x27, 0x4b, 0x93, 0x28, 0xe9, 0x94,
+	0x72, 0xe2, 0x1e, 0xce, 0xee, 0xa0, 0x1a, 0x98, 0xd0, 0x64, 0xe4, 0x94, 0x9b, 0x96, 0x7b, 0xd2,
+	0x71, 0xbd, 0x3f, 0xf2, 0x78, 0xb9, 0x7c, 0xc3, 0xe7, 0x85, 0x8e, 0x5d, 0xc1, 0xd9, 0x40, 0x27,
+	0x28, 0x67, 0x62, 0xf5, 0x84, 0x44, 0xe1, 0x1c, 0x5f, 0xd4, 0x2b, 0xa1, 0x76, 0x2a, 0xb9, 0xe5,
+	0xef, 0xc3, 0x16, 0x03, 0x3b, 0x40, 0xf1, 0x51, 0x2c, 0xf7, 0x99, 0x21, 0x99, 0xd6, 0x1b, 0x9c,
+	0xee, 0x60, 0xb4, 0x54, 0x92, 0x90, 0x75, 0xa1, 0xa6, 0xf3, 0xe5, 0xc9, 0xb1, 0x9a, 0x65, 0xb7,
+	0xf1, 0x9f, 0x84, 0x9b, 0x6b, 0xf1, 0xad, 0xb0, 0x7d, 0x5d, 0x5c, 0xb1, 0x48, 0x5c, 0x85, 0xd2,
+	0x78, 0x64, 0x1f, 0xb0, 0x23, 0xa8, 0x0c, 0xee, 0x87, 0x8f, 0xb6, 0xc5, 0xea, 0x70, 0xd8, 0xe7,
+	0x7c, 0xcc, 0xed, 0x12, 0x6b, 0x40, 0x2d, 0x18, 0x0d, 0x27, 0x93, 0x7e, 0xcf, 0x2e, 0x77, 0x6f,
+	0xdf, 0x6f, 0xe6, 0x89, 0x89, 0xb3, 0xe9, 0xda, 0xca, 0x2f, 0x7c, 0xb7, 0x6f, 0xc7, 0x8f, 0x94,
+	0x34, 0x5a, 0x09, 0x81, 0xda, 0x9f, 0xa3, 0xf4, 0xf7, 0x3b, 0x9e, 0x56, 0xf3, 0x92, 0x2f, 0xbf,
+	0x07, 0x00, 0xeb, 0x3a, 0x0e, 0xd0, 0x00, 0x02, 0x00, 0x00,
 }
@@ -29,6 +29,7 @@ It has these top-level messages:
 	ResourceError
 	StatSummaryRequest
 	StatSummaryResponse
+	PrometheusError
 	BasicStats
 	StatTable
 */
@@ -690,6 +691,10 @@ type TapByResourceRequest struct {
 	Match *TapByResourceRequest_Match `protobuf:"bytes,2,opt,name=match" json:"match,omitempty"`
 	// Limits the number of events to be inspected.
 	MaxRps float32 `protobuf:"fixed32,3,opt,name=maxRps" json:"maxRps,omitempty"`
+	// Limits the number of events that the tap server buffers while they are
+	// sent back. Events are dropped while the buffer is full. If zero, the
+	// server's default is used.
+	BufferSize uint32 `protobuf:"varint,4,opt,name=bufferSize" json:"bufferSize,omitempty"`
 }
 
 func (m *TapByResourceRequest) Reset()                    { *m = TapByResourceRequest{} }
@@ -718,6 +723,13 @@ func (m *TapByResourceRequest) GetMaxRps() float32 {
 	return 0
 }
 
+func (m *TapByResourceRequest) GetBufferSize() uint32 {
+	if m != nil {
+		return m.BufferSize
+	}
+	return 0
+}
+
 type TapByResourceRequest_Match struct {
 	// Types that are valid to be assigned to Match:
 	//	*TapByResourceRequest_Match_All
@@ -1615,6 +1627,9 @@ type TapEvent struct {
 	// Types that are valid to be assigned to Event:
 	//	*TapEvent_Http_
 	Event isTapEvent_Event `protobuf_oneof:"event"`
+	// The number of events that the tap server dropped, while its buffer was
+	// full, since the previous event of the stream.
+	DroppedEvents uint64 `protobuf:"varint,7,opt,name=dropped_events,json=droppedEvents" json:"dropped_events,omitempty"`
 }
 
 func (m *TapEvent) Reset()                    { *m = TapEvent{} }
@@ -1679,6 +1694,13 @@ func (m *TapEvent) GetHttp() *TapEvent_Http {
 	return nil
 }
 
+func (m *TapEvent) GetDroppedEvents() uint64 {
+	if m != nil {
+		return m.DroppedEvents
+	}
+	return 0
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TapEvent) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TapEvent_OneofMarshaler, _TapEvent_OneofUnmarshaler, _TapEvent_OneofSizer, []interface{}{
@@ -2327,6 +2349,23 @@ type StatSummaryRequest struct {
 	//	*StatSummaryRequest_ToResource
 	//	*StatSummaryRequest_FromResource
 	Outbound isStatSummaryRequest_Outbound `protobuf_oneof:"outbound"`
+	// If set, stats are split by the value of this pod label, e.g. "version",
+	// with one row per resource and label value.
+	IncludeLabel string `protobuf:"bytes,6,opt,name=include_label,json=includeLabel" json:"include_label,omitempty"`
+	// If set, only gRPC traffic is included in the stats, and resources that
+	// received no gRPC traffic are omitted.
+	GrpcOnly bool `protobuf:"varint,7,opt,name=grpc_only,json=grpcOnly" json:"grpc_only,omitempty"`
+	// If set, at most this many resources are returned, after skipping the
+	// first offset resources. Resources are ordered by namespace and name.
+	Limit  uint32 `protobuf:"varint,8,opt,name=limit" json:"limit,omitempty"`
+	Offset uint32 `protobuf:"varint,9,opt,name=offset" json:"offset,omitempty"`
+	// If set, e.g. to "10m", resources whose oldest proxy started less than
+	// this long ago are omitted, as their stats are based on too little traffic.
+	MinTrafficDuration string `protobuf:"bytes,10,opt,name=min_traffic_duration,json=minTrafficDuration" json:"min_traffic_duration,omitempty"`
+	// If set, only requests whose :authority matches this pattern are included
+	// in the stats. Patterns with "*" or "?" wildcards, e.g. "*.example.com",
+	// match whole authorities, and others match authorities they prefix.
+	Authority string `protobuf:"bytes,11,opt,name=authority" json:"authority,omitempty"`
 }
 
 func (m *StatSummaryRequest) Reset()                    { *m = StatSummaryRequest{} }
@@ -2392,6 +2431,48 @@ func (m *StatSummaryRequest) GetFromResource() *Resource {
 	return nil
 }
 
+func (m *StatSummaryRequest) GetIncludeLabel() string {
+	if m != nil {
+		return m.IncludeLabel
+	}
+	return ""
+}
+
+func (m *StatSummaryRequest) GetGrpcOnly() bool {
+	if m != nil {
+		return m.GrpcOnly
+	}
+	return false
+}
+
+func (m *StatSummaryRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *StatSummaryRequest) GetOffset() uint32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *StatSummaryRequest) GetMinTrafficDuration() string {
+	if m != nil {
+		return m.MinTrafficDuration
+	}
+	return ""
+}
+
+func (m *StatSummaryRequest) GetAuthority() string {
+	if m != nil {
+		return m.Authority
+	}
+	return ""
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*StatSummaryRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _StatSummaryRequest_OneofMarshaler, _StatSummaryRequest_OneofUnmarshaler, _StatSummaryRequest_OneofSizer, []interface{}{
@@ -2606,6 +2687,10 @@ func _StatSummaryResponse_OneofSizer(msg proto.Message) (n int) {
 
 type StatSummaryResponse_Ok struct {
 	StatTables []*StatTable `protobuf:"bytes,1,rep,name=stat_tables,json=statTables" json:"stat_tables,omitempty"`
+	// The errors of the Prometheus instances that failed to answer, when
+	// metrics are sharded across several of them. If set, the stats only
+	// include the metrics of the instances that answered.
+	PrometheusErrors []*PrometheusError `protobuf:"bytes,2,rep,name=prometheus_errors,json=prometheusErrors" json:"prometheus_errors,omitempty"`
 }
 
 func (m *StatSummaryResponse_Ok) Reset()                    { *m = StatSummaryResponse_Ok{} }
@@ -2620,6 +2705,38 @@ func (m *StatSummaryResponse_Ok) GetStatTables() []*StatTable {
 	return nil
 }
 
+func (m *StatSummaryResponse_Ok) GetPrometheusErrors() []*PrometheusError {
+	if m != nil {
+		return m.PrometheusErrors
+	}
+	return nil
+}
+
+type PrometheusError struct {
+	// The URL of the Prometheus instance.
+	Url   string `protobuf:"bytes,1,opt,name=url" json:"url,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *PrometheusError) Reset()                    { *m = PrometheusError{} }
+func (m *PrometheusError) String() string            { return proto.CompactTextString(m) }
+func (*PrometheusError) ProtoMessage()               {}
+func (*PrometheusError) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *PrometheusError) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *PrometheusError) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type BasicStats struct {
 	SuccessCount    uint64 `protobuf:"varint,1,opt,name=success_count,json=successCount" json:"success_count,omitempty"`
 	FailureCount    uint64 `protobuf:"varint,2,opt,name=failure_count,json=failureCount" json:"failure_count,omitempty"`
@@ -2632,7 +2749,7 @@ type BasicStats struct {
 func (m *BasicStats) Reset()                    { *m = BasicStats{} }
 func (m *BasicStats) String() string            { return proto.CompactTextString(m) }
 func (*BasicStats) ProtoMessage()               {}
-func (*BasicStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*BasicStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *BasicStats) GetSuccessCount() uint64 {
 	if m != nil {
@@ -2685,7 +2802,7 @@ type StatTable struct {
 func (m *StatTable) Reset()                    { *m = StatTable{} }
 func (m *StatTable) String() string            { return proto.CompactTextString(m) }
 func (*StatTable) ProtoMessage()               {}
-func (*StatTable) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*StatTable) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 type isStatTable_Table interface{ isStatTable_Table() }
 
@@ -2771,7 +2888,7 @@ type StatTable_PodGroup struct {
 func (m *StatTable_PodGroup) Reset()                    { *m = StatTable_PodGroup{} }
 func (m *StatTable_PodGroup) String() string            { return proto.CompactTextString(m) }
 func (*StatTable_PodGroup) ProtoMessage()               {}
-func (*StatTable_PodGroup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22, 0} }
+func (*StatTable_PodGroup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23, 0} }
 
 func (m *StatTable_PodGroup) GetRows() []*StatTable_PodGroup_Row {
 	if m != nil {
@@ -2792,12 +2909,15 @@ type StatTable_PodGroup_Row struct {
 	Stats          *BasicStats `protobuf:"bytes,5,opt,name=stats" json:"stats,omitempty"`
 	// Stores a set of errors for each pod name. If a pod has no errors, it may be omitted.
 	ErrorsByPod map[string]*PodErrors `protobuf:"bytes,7,rep,name=errors_by_pod,json=errorsByPod" json:"errors_by_pod,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The value of the request's include_label on the pods these stats are
+	// for, if the request has one.
+	LabelValue string `protobuf:"bytes,8,opt,name=label_value,json=labelValue" json:"label_value,omitempty"`
 }
 
 func (m *StatTable_PodGroup_Row) Reset()                    { *m = StatTable_PodGroup_Row{} }
 func (m *StatTable_PodGroup_Row) String() string            { return proto.CompactTextString(m) }
 func (*StatTable_PodGroup_Row) ProtoMessage()               {}
-func (*StatTable_PodGroup_Row) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22, 0, 0} }
+func (*StatTable_PodGroup_Row) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23, 0, 0} }
 
 func (m *StatTable_PodGroup_Row) GetResource() *Resource {
 	if m != nil {
@@ -2848,6 +2968,13 @@ func (m *StatTable_PodGroup_Row) GetErrorsByPod() map[string]*PodErrors {
 	return nil
 }
 
+func (m *StatTable_PodGroup_Row) GetLabelValue() string {
+	if m != nil {
+		return m.LabelValue
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "linkerd2.public.Empty")
 	proto.RegisterType((*VersionInfo)(nil), "linkerd2.public.VersionInfo")
@@ -2882,6 +3009,7 @@ func init() {
 	proto.RegisterType((*StatSummaryRequest)(nil), "linkerd2.public.StatSummaryRequest")
 	proto.RegisterType((*StatSummaryResponse)(nil), "linkerd2.public.StatSummaryResponse")
 	proto.RegisterType((*StatSummaryResponse_Ok)(nil), "linkerd2.public.StatSummaryResponse.Ok")
+	proto.RegisterType((*PrometheusError)(nil), "linkerd2.public.PrometheusError")
 	proto.RegisterType((*BasicStats)(nil), "linkerd2.public.BasicStats")
 	proto.RegisterType((*StatTable)(nil), "linkerd2.public.StatTable")
 	proto.RegisterType((*StatTable_PodGroup)(nil), "linkerd2.public.StatTable.PodGroup")
@@ -3190,160 +3318,172 @@ var _Api_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("public.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2477 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x39, 0x3b, 0x73, 0x1b, 0xc9,
-	0xd1, 0x78, 0x2c, 0x5e, 0x0d, 0x80, 0x84, 0x46, 0x3a, 0x7d, 0x10, 0xee, 0x4a, 0x47, 0xad, 0x74,
-	0x3a, 0x96, 0xee, 0x33, 0x48, 0x41, 0x27, 0x9d, 0x28, 0x9d, 0x1f, 0x04, 0x09, 0x0b, 0xb4, 0x29,
-	0x12, 0x1a, 0x40, 0xbe, 0x2a, 0xd5, 0x55, 0xa1, 0x16, 0xd8, 0x21, 0xb1, 0xe6, 0x62, 0x67, 0xb5,
-	0x3b, 0x10, 0x0f, 0xa9, 0x23, 0x07, 0x0e, 0xed, 0xd8, 0xb1, 0x9d, 0xb8, 0x9c, 0xf8, 0x47, 0xf8,
-	0x0f, 0x38, 0xb3, 0x33, 0xa7, 0x4e, 0x1c, 0xdb, 0xae, 0x79, 0x2d, 0x16, 0x04, 0xf8, 0x90, 0x9c,
-	0x38, 0xc2, 0x74, 0x4f, 0x77, 0x6f, 0x4f, 0xbf, 0x67, 0x00, 0x25, 0x7f, 0x32, 0x70, 0x9d, 0x61,
-	0xdd, 0x0f, 0x28, 0xa3, 0x68, 0xd5, 0x75, 0xbc, 0x13, 0x12, 0xd8, 0x8d, 0xba, 0x44, 0xd7, 0x6e,
-	0x1f, 0x53, 0x7a, 0xec, 0x92, 0x0d, 0xb1, 0x3d, 0x98, 0x1c, 0x6d, 0xd8, 0x93, 0xc0, 0x62, 0x0e,
-	0xf5, 0x24, 0x43, 0xad, 0x3a, 0xa4, 0xe3, 0x31, 0xf5, 0x36, 0x46, 0xc4, 0x72, 0xd9, 0x68, 0x38,
-	0x22, 0xc3, 0x13, 0xb9, 0x63, 0xe6, 0x20, 0xd3, 0x1a, 0xfb, 0x6c, 0x6a, 0xbe, 0x85, 0xe2, 0xcf,
-	0x48, 0x10, 0x3a, 0xd4, 0xdb, 0xf3, 0x8e, 0x28, 0xfa, 0x04, 0x0a, 0xc7, 0x54, 0x21, 0xaa, 0xc9,
-	0xb5, 0xe4, 0x7a, 0x01, 0xcf, 0x10, 0x7c, 0x77, 0x30, 0x71, 0x5c, 0x7b, 0xd7, 0x62, 0xa4, 0x9a,
-	0x92, 0xbb, 0x11, 0x02, 0xdd, 0x87, 0x95, 0x80, 0xb8, 0xc4, 0x0a, 0x89, 0x16, 0x90, 0x16, 0x24,
-	0x67, 0xb0, 0xe6, 0x06, 0xac, 0xee, 0x3b, 0x21, 0xeb, 0x50, 0x3b, 0xc4, 0xe4, 0xed, 0x84, 0x84,
-	0x8c, 0x0b, 0xf6, 0xac, 0x31, 0x09, 0x7d, 0x6b, 0x48, 0xf4, 0x67, 0x23, 0x84, 0xf9, 0x35, 0x54,
-	0x66, 0x0c, 0xa1, 0x4f, 0xbd, 0x90, 0xa0, 0x75, 0x30, 0x7c, 0x6a, 0x87, 0xd5, 0xe4, 0x5a, 0x7a,
-	0xbd, 0xd8, 0xb8, 0x51, 0x3f, 0x63, 0x9a, 0x7a, 0x87, 0xda, 0x58, 0x50, 0x98, 0xbf, 0x32, 0x20,
-	0xdd, 0xa1, 0x36, 0x42, 0x60, 0x70, 0x91, 0x4a, 0xbc, 0x58, 0xa3, 0x1b, 0x90, 0xf1, 0xa9, 0xbd,
-	0xd7, 0x51, 0x87, 0x91, 0x00, 0x5a, 0x03, 0xb0, 0x89, 0xef, 0xd2, 0xe9, 0x98, 0x78, 0x4c, 0x1e,
-	0xa2, 0x9d, 0xc0, 0x31, 0x1c, 0xba, 0x03, 0xc5, 0x80, 0xf8, 0xae, 0x33, 0xb4, 0xfa, 0x21, 0x61,
-	0x55, 0xd0, 0x24, 0x0a, 0xd9, 0x25, 0x0c, 0x7d, 0x05, 0x37, 0x15, 0xc4, 0x1d, 0xd2, 0x1f, 0x52,
-	0x8f, 0x05, 0xd4, 0x75, 0x49, 0x50, 0x2d, 0x2a, 0xea, 0x8f, 0x62, 0xfb, 0x3b, 0xd1, 0x36, 0xba,
-	0x0b, 0xa5, 0x90, 0x59, 0x8c, 0x1c, 0x4d, 0x5c, 0x21, 0xbc, 0xa4, 0xc8, 0x8b, 0x1a, 0xcb, 0xa5,
-	0x7f, 0x0a, 0x60, 0x5b, 0x64, 0x4c, 0x3d, 0x41, 0x52, 0x56, 0x24, 0x05, 0x89, 0xe3, 0x04, 0x08,
-	0xd2, 0x3f, 0xa7, 0x83, 0xea, 0x8a, 0xda, 0xe1, 0x00, 0xba, 0x09, 0x59, 0x2e, 0x63, 0x12, 0x56,
-	0x0d, 0x71, 0x5c, 0x05, 0x71, 0x2b, 0x58, 0xb6, 0x4d, 0xec, 0x6a, 0x66, 0x2d, 0xb9, 0x9e, 0xc7,
-	0x12, 0x40, 0x3b, 0xb0, 0x1a, 0x3a, 0xde, 0x90, 0xec, 0x5b, 0x21, 0xc3, 0xc4, 0xa7, 0x01, 0xab,
-	0x66, 0xd7, 0x92, 0xeb, 0xc5, 0xc6, 0xad, 0xba, 0x0c, 0xbb, 0xba, 0x0e, 0xbb, 0xfa, 0xae, 0x0a,
-	0x3b, 0x7c, 0x96, 0x03, 0x6d, 0xc2, 0xf5, 0xd9, 0xc9, 0x0f, 0x22, 0x17, 0xe7, 0xc4, 0xf7, 0x97,
-	0x6d, 0x21, 0x13, 0x4a, 0x0a, 0xdd, 0x71, 0x2d, 0x8f, 0x54, 0xf3, 0x42, 0xa7, 0x39, 0x1c, 0x7a,
-	0x08, 0xd9, 0x89, 0xcf, 0x9c, 0x31, 0xa9, 0x16, 0x2e, 0xd3, 0x48, 0x11, 0x36, 0x73, 0x90, 0xa1,
-	0xa7, 0x1e, 0x09, 0xcc, 0xdf, 0xa7, 0x00, 0x7a, 0x96, 0xaf, 0x23, 0x0f, 0x41, 0xda, 0xa7, 0xb6,
-	0x0c, 0x0a, 0x6e, 0x27, 0x9f, 0xda, 0x67, 0xfc, 0x9f, 0x5a, 0xe2, 0xff, 0x9b, 0x90, 0x1d, 0x5b,
-	0xdf, 0x61, 0x3f, 0x14, 0xd1, 0x91, 0xc2, 0x0a, 0xe2, 0x78, 0x46, 0x3b, 0xdc, 0x54, 0xdc, 0xc2,
-	0x65, 0xac, 0x20, 0x1e, 0x7b, 0x8c, 0xee, 0x75, 0x84, 0x81, 0x0b, 0x58, 0xac, 0x51, 0x0d, 0xf2,
-	0x47, 0x01, 0x1d, 0x77, 0xb4, 0x61, 0xcb, 0x38, 0x82, 0xb9, 0x1c, 0xbe, 0xde, 0xeb, 0x28, 0x4b,
-	0x29, 0x48, 0x78, 0x70, 0x38, 0x22, 0x63, 0x69, 0x16, 0xee, 0x41, 0x01, 0x09, 0x7d, 0x08, 0x1b,
-	0x51, 0x5b, 0x18, 0xa4, 0x80, 0x15, 0xc4, 0xf3, 0xca, 0x9a, 0xb0, 0x11, 0x0d, 0x1c, 0x36, 0x95,
-	0x51, 0x8a, 0x67, 0x08, 0xae, 0x95, 0x6f, 0xb1, 0x91, 0x0c, 0x48, 0x2c, 0xd6, 0xcf, 0x52, 0xd5,
-	0x64, 0x33, 0x0f, 0x59, 0x66, 0x05, 0xc7, 0x84, 0x99, 0x7f, 0xcf, 0xc0, 0x8d, 0x9e, 0xe5, 0x37,
-	0xa7, 0x98, 0x84, 0x74, 0x12, 0x0c, 0x89, 0x36, 0xdb, 0x33, 0x4d, 0x22, 0x2c, 0x57, 0x6c, 0x98,
-	0x0b, 0x09, 0xa8, 0x39, 0xba, 0xc4, 0x25, 0x43, 0xe9, 0x0a, 0xc9, 0x81, 0xb6, 0x21, 0x33, 0xb6,
-	0xd8, 0x70, 0x24, 0x2c, 0x5b, 0x6c, 0x7c, 0xb1, 0xc0, 0xba, 0xec, 0x8b, 0xf5, 0x97, 0x9c, 0x05,
-	0x4b, 0xce, 0xf3, 0xec, 0x5f, 0xfb, 0x93, 0x01, 0x19, 0x41, 0x88, 0x76, 0x20, 0x6d, 0xb9, 0xae,
-	0xd2, 0x6e, 0xe3, 0x3d, 0x3e, 0x51, 0xef, 0x92, 0xb7, 0x3c, 0x10, 0x2c, 0xd7, 0x15, 0x42, 0xbc,
-	0xa9, 0xd2, 0xf3, 0x83, 0x84, 0x78, 0x53, 0xf4, 0x43, 0x48, 0x7b, 0x54, 0x96, 0x91, 0xf7, 0x3b,
-	0x2c, 0x17, 0xe0, 0x51, 0x86, 0xda, 0x50, 0xb2, 0x49, 0xc8, 0x1c, 0x4f, 0x44, 0xb4, 0x4c, 0xde,
-	0x2b, 0x59, 0xbc, 0x9d, 0xc0, 0x73, 0x9c, 0xe8, 0xc7, 0x60, 0x8c, 0x18, 0xf3, 0x45, 0x18, 0x16,
-	0x1b, 0x9b, 0xef, 0x73, 0xa0, 0x36, 0x63, 0x7e, 0x3b, 0x81, 0x05, 0x7f, 0x6d, 0x1f, 0xd2, 0x5d,
-	0xf2, 0x16, 0xb5, 0x20, 0x27, 0xdc, 0x41, 0x74, 0x19, 0x7e, 0x2f, 0x57, 0x6a, 0xde, 0xda, 0x14,
-	0x0c, 0x2e, 0x1d, 0x55, 0xa3, 0xe0, 0xd6, 0xd9, 0xa8, 0xc3, 0xbb, 0x1a, 0x85, 0xb7, 0x4e, 0x46,
-	0x1d, 0xe0, 0xb7, 0xe3, 0x01, 0xae, 0x2b, 0x75, 0x2c, 0xc4, 0x6f, 0xa8, 0x10, 0x37, 0xd4, 0x96,
-	0x80, 0x78, 0x31, 0x10, 0x1f, 0x8f, 0x16, 0xe6, 0x3f, 0x93, 0x00, 0x5c, 0x89, 0x97, 0x52, 0x6c,
-	0x1b, 0x20, 0x20, 0xc7, 0x4e, 0xc8, 0x48, 0x40, 0x64, 0x71, 0x58, 0x69, 0xdc, 0x5f, 0x38, 0xdc,
-	0x8c, 0xa1, 0x8e, 0x23, 0x6a, 0xd9, 0x06, 0x34, 0x84, 0xee, 0x41, 0x69, 0xe2, 0xc5, 0x64, 0xe9,
-	0x03, 0xcc, 0x61, 0x4d, 0x0f, 0x60, 0x26, 0x01, 0xe5, 0x20, 0xfd, 0xa2, 0xd5, 0xab, 0x24, 0x50,
-	0x1e, 0x8c, 0xce, 0x61, 0xb7, 0x57, 0x49, 0x72, 0x54, 0xe7, 0x75, 0xaf, 0x92, 0x42, 0x00, 0xd9,
-	0xdd, 0xd6, 0x7e, 0xab, 0xd7, 0xaa, 0xa4, 0x51, 0x01, 0x32, 0x9d, 0xed, 0xde, 0x4e, 0xbb, 0x62,
-	0xa0, 0x22, 0xe4, 0x0e, 0x3b, 0xbd, 0xbd, 0xc3, 0x83, 0x6e, 0x25, 0xc3, 0x81, 0x9d, 0xc3, 0x83,
-	0x83, 0xd6, 0x4e, 0xaf, 0x92, 0xe5, 0x32, 0xda, 0xad, 0xed, 0xdd, 0x4a, 0x8e, 0x93, 0xf7, 0xf0,
-	0xf6, 0x4e, 0xab, 0x92, 0x6f, 0x66, 0xc1, 0x60, 0x53, 0x9f, 0x98, 0xbf, 0x4d, 0x42, 0xb6, 0x2b,
-	0x6d, 0xbc, 0xbb, 0xe4, 0xc8, 0x8b, 0x31, 0x26, 0x89, 0xff, 0xdb, 0xe3, 0xde, 0x99, 0x3b, 0x2e,
-	0xd7, 0xb0, 0xd7, 0xeb, 0x54, 0x12, 0x5c, 0x43, 0xbe, 0xea, 0x56, 0x92, 0x91, 0x86, 0x3d, 0x28,
-	0xec, 0x75, 0xb6, 0x6d, 0x3b, 0x20, 0x21, 0x6f, 0x54, 0x86, 0xe3, 0xbf, 0xfb, 0x52, 0x68, 0x97,
-	0xe3, 0xde, 0xe4, 0x10, 0xfa, 0x42, 0x60, 0x9f, 0xa8, 0x34, 0xfd, 0x68, 0x41, 0xe7, 0xbd, 0xce,
-	0xbb, 0x27, 0x8a, 0xf8, 0x49, 0xd3, 0x80, 0x94, 0xe3, 0x9b, 0x9b, 0x60, 0x70, 0x2c, 0xef, 0x7c,
-	0x47, 0x4e, 0x10, 0xca, 0x2a, 0x96, 0xc5, 0x12, 0xe0, 0x75, 0xd1, 0xb5, 0x42, 0x59, 0xf9, 0xb3,
-	0x58, 0xac, 0xcd, 0x7d, 0x80, 0xde, 0xd0, 0xd7, 0x8a, 0x3c, 0xe0, 0x52, 0x54, 0x71, 0xa9, 0x2d,
-	0xf9, 0xa0, 0xa2, 0xc3, 0x29, 0xc7, 0x17, 0x55, 0x96, 0xd7, 0xf8, 0x94, 0xa8, 0xf1, 0x62, 0x6d,
-	0xda, 0x90, 0x6e, 0x51, 0x2e, 0xa6, 0x72, 0x1c, 0xf8, 0xc3, 0xbe, 0xec, 0xc3, 0xfd, 0x21, 0xb5,
-	0x65, 0xec, 0x97, 0xdb, 0x09, 0xbc, 0xc2, 0x77, 0xba, 0x62, 0x63, 0x87, 0xda, 0x84, 0xd3, 0x06,
-	0x24, 0x24, 0xac, 0x4f, 0x82, 0x80, 0x06, 0x92, 0x36, 0xa5, 0x69, 0xc5, 0x4e, 0x8b, 0x6f, 0x70,
-	0xda, 0x66, 0x06, 0xd2, 0xc4, 0xb3, 0xcd, 0x7f, 0x97, 0x20, 0xdf, 0xb3, 0xfc, 0xd6, 0x3b, 0xde,
-	0xb2, 0x1e, 0x41, 0x56, 0x66, 0xa1, 0x52, 0xfb, 0xe3, 0xc5, 0x5c, 0x8d, 0xce, 0x87, 0x15, 0x29,
-	0x7a, 0x01, 0x45, 0xb9, 0xea, 0x8f, 0x09, 0xb3, 0x54, 0xdd, 0xb8, 0xbf, 0x2c, 0xcb, 0xc5, 0x47,
-	0xea, 0x2d, 0xcf, 0xf6, 0xa9, 0xe3, 0xb1, 0x97, 0x84, 0x59, 0x18, 0x24, 0x2b, 0x5f, 0xa3, 0xef,
-	0x43, 0x31, 0x56, 0x89, 0x94, 0xab, 0x2e, 0x54, 0x21, 0x4e, 0x8f, 0x5e, 0x41, 0x25, 0x06, 0x4a,
-	0x65, 0x8c, 0xf7, 0x52, 0x66, 0x35, 0xc6, 0x2f, 0x34, 0x7a, 0x05, 0xab, 0x7e, 0x40, 0xbf, 0x9b,
-	0xf6, 0x6d, 0x27, 0x90, 0xe5, 0x52, 0x74, 0xe1, 0x95, 0xc6, 0xfa, 0xf9, 0x12, 0x3b, 0x9c, 0x61,
-	0x57, 0xd3, 0xe3, 0x15, 0x7f, 0x0e, 0x46, 0x5f, 0xaa, 0xf2, 0x2a, 0x4b, 0xfd, 0xed, 0xf3, 0xe5,
-	0xcc, 0x15, 0xd3, 0xdf, 0x24, 0xa1, 0x14, 0x57, 0x15, 0xfd, 0x04, 0xb2, 0xae, 0x35, 0x20, 0xae,
-	0xae, 0xaa, 0x8d, 0xab, 0x1d, 0xb1, 0xbe, 0x2f, 0x98, 0x5a, 0x1e, 0x0b, 0xa6, 0x58, 0x49, 0xa8,
-	0x6d, 0x41, 0x31, 0x86, 0x46, 0x15, 0x48, 0x9f, 0x90, 0xa9, 0x1a, 0x81, 0xf9, 0x92, 0x67, 0xc0,
-	0x3b, 0xcb, 0x9d, 0xe8, 0x71, 0x5e, 0x02, 0xcf, 0x52, 0x4f, 0x93, 0xb5, 0x7f, 0xe5, 0x54, 0x5d,
-	0x3e, 0x84, 0x52, 0x20, 0x2b, 0x77, 0xdf, 0xf1, 0x1c, 0xdd, 0xf1, 0x1f, 0x5c, 0x7c, 0xbc, 0xba,
-	0x2a, 0xf6, 0x7b, 0x9e, 0xc3, 0xf8, 0xf0, 0x1a, 0xcc, 0x40, 0x84, 0xa1, 0x1c, 0xa8, 0x39, 0x5e,
-	0x4a, 0xbc, 0x60, 0x10, 0x98, 0x93, 0x28, 0x79, 0x94, 0xc8, 0x52, 0x10, 0x83, 0xa5, 0x92, 0x4a,
-	0x26, 0xf1, 0x6c, 0xe5, 0x83, 0x07, 0x57, 0x14, 0xd9, 0xf2, 0x6c, 0xa9, 0x64, 0x04, 0xd6, 0x9e,
-	0x40, 0xbe, 0xcb, 0x02, 0x62, 0x8d, 0xf7, 0xc4, 0xd5, 0x61, 0x60, 0x85, 0x2a, 0x37, 0xb1, 0x58,
-	0xcb, 0x61, 0x9a, 0xef, 0x0b, 0xed, 0x0d, 0xac, 0xa0, 0xda, 0x5f, 0x93, 0x50, 0x8c, 0x9d, 0x1d,
-	0x7d, 0x05, 0x29, 0xc7, 0x56, 0x36, 0xfb, 0xfc, 0x12, 0x75, 0xf4, 0x07, 0x71, 0xca, 0xb1, 0x79,
-	0xc2, 0xc6, 0x9a, 0xde, 0xb2, 0x6c, 0x99, 0xf5, 0x9f, 0xa8, 0x1f, 0x6e, 0x44, 0x3d, 0x54, 0x1a,
-	0xe0, 0xff, 0xce, 0xa9, 0xe0, 0x51, 0x6b, 0x9d, 0x9b, 0x10, 0x8d, 0xf3, 0x26, 0xc4, 0xcc, 0x6c,
-	0x42, 0xac, 0xfd, 0x31, 0x09, 0xa5, 0xb8, 0x2b, 0x3e, 0xfc, 0x84, 0x2f, 0x00, 0x89, 0xfb, 0x42,
-	0x7f, 0x2e, 0xbc, 0x52, 0x97, 0x8d, 0xf4, 0x15, 0xc1, 0x14, 0xb7, 0xf1, 0xa7, 0x50, 0xe4, 0xa9,
-	0xa4, 0xea, 0xa8, 0x38, 0x7a, 0x19, 0x03, 0x47, 0xc9, 0x02, 0x5a, 0xfb, 0x5d, 0x8a, 0x3b, 0x25,
-	0x72, 0xee, 0xff, 0x80, 0xca, 0x7b, 0x70, 0x5d, 0x0b, 0x8a, 0x67, 0x42, 0xfa, 0x32, 0x49, 0xd7,
-	0x94, 0xa4, 0x98, 0xfd, 0x3f, 0xe3, 0xf7, 0x6e, 0x25, 0x64, 0x30, 0x65, 0x44, 0x4e, 0x88, 0x06,
-	0x8e, 0x92, 0xac, 0xc9, 0x91, 0xe8, 0x3e, 0xa4, 0x09, 0x0d, 0x55, 0x0d, 0x5f, 0xbc, 0x30, 0xb7,
-	0x68, 0x88, 0x39, 0x01, 0x9f, 0x89, 0x08, 0x3f, 0xbd, 0xf9, 0x14, 0x56, 0xe6, 0x0b, 0x1e, 0x1f,
-	0x2c, 0x5e, 0x1f, 0xfc, 0xf4, 0xe0, 0xf0, 0x9b, 0x83, 0x4a, 0x82, 0x03, 0x7b, 0x07, 0xcd, 0xc3,
-	0xd7, 0x07, 0xbb, 0x95, 0x24, 0x2a, 0x41, 0xfe, 0xf0, 0x75, 0x4f, 0x42, 0xa9, 0x99, 0x88, 0x35,
-	0xc8, 0x6f, 0xfb, 0x8e, 0x68, 0x4c, 0xbc, 0xd2, 0x88, 0xd6, 0xa5, 0xaa, 0x8f, 0x04, 0xf8, 0x75,
-	0xac, 0xd0, 0xa1, 0xb6, 0x20, 0x09, 0xd1, 0x73, 0xc8, 0x0a, 0xb4, 0x2e, 0x7d, 0x77, 0x97, 0xdd,
-	0xeb, 0x25, 0x6d, 0xb4, 0xc2, 0x8a, 0xa5, 0xf6, 0xb7, 0x24, 0xe4, 0x35, 0x12, 0x61, 0x28, 0xf0,
-	0x2b, 0xa3, 0xe5, 0x78, 0x24, 0x50, 0x8e, 0x6e, 0x5c, 0x41, 0x58, 0x7d, 0x47, 0x33, 0x09, 0x90,
-	0x0f, 0x93, 0x91, 0x98, 0xda, 0x3b, 0x58, 0x99, 0xdf, 0x46, 0x55, 0xc8, 0x8d, 0x49, 0x18, 0x5a,
-	0xc7, 0xfa, 0x59, 0x41, 0x83, 0x3c, 0xaf, 0x66, 0xdf, 0x57, 0x4f, 0x25, 0x11, 0x82, 0xdb, 0xc2,
-	0x19, 0x73, 0x2e, 0xf9, 0x42, 0x22, 0x01, 0x5e, 0x52, 0x02, 0x62, 0x85, 0xd4, 0xd3, 0xf7, 0x73,
-	0x09, 0x09, 0x73, 0x0a, 0x63, 0x75, 0x20, 0xaf, 0x67, 0xe9, 0x8b, 0x9f, 0x4c, 0xc4, 0x85, 0x73,
-	0xea, 0xeb, 0xaa, 0x2e, 0xd6, 0xd1, 0x03, 0x48, 0x7a, 0xf6, 0x00, 0x62, 0xbe, 0x85, 0x6b, 0x0b,
-	0xd7, 0x06, 0xf4, 0x18, 0xf2, 0x01, 0x99, 0x1b, 0x16, 0x6e, 0x9d, 0x7b, 0xd9, 0xc0, 0x11, 0x29,
-	0x8f, 0x43, 0xd1, 0x75, 0xfa, 0xa1, 0x90, 0x44, 0xf5, 0xb9, 0xcb, 0x02, 0xdb, 0x55, 0x48, 0xf3,
-	0x5b, 0x28, 0x6b, 0x66, 0x69, 0xc4, 0x0f, 0xfc, 0x5c, 0x14, 0x4f, 0xa9, 0x78, 0x3c, 0xfd, 0x21,
-	0x05, 0x88, 0x27, 0x7d, 0x77, 0x32, 0x1e, 0x5b, 0xc1, 0x54, 0xdf, 0x57, 0x7f, 0x00, 0xf9, 0x48,
-	0xab, 0xab, 0xdf, 0x58, 0x23, 0x1e, 0x5e, 0x61, 0x98, 0x33, 0x26, 0xfd, 0x53, 0xc7, 0xb3, 0xe9,
-	0xa9, 0xfa, 0x24, 0x70, 0xd4, 0x37, 0x02, 0x83, 0xfe, 0x1f, 0x0c, 0x8f, 0x7a, 0xba, 0xec, 0xde,
-	0x5c, 0x4c, 0xaf, 0xb1, 0xcf, 0xa6, 0xbc, 0xe7, 0x73, 0x2a, 0xf4, 0x35, 0x14, 0x19, 0xed, 0x47,
-	0xa7, 0x36, 0x2e, 0x39, 0x35, 0x1f, 0xb2, 0x19, 0x8d, 0x5c, 0xff, 0x23, 0x28, 0x1f, 0x05, 0x74,
-	0x3c, 0xe3, 0xcf, 0x5c, 0xce, 0x5f, 0xe2, 0x1c, 0x1a, 0x6e, 0x02, 0xe4, 0xe9, 0x84, 0x0d, 0xe8,
-	0xc4, 0xb3, 0xcd, 0xbf, 0x24, 0xe1, 0xfa, 0x9c, 0xc5, 0xd4, 0x0b, 0xdb, 0x16, 0xa4, 0xe8, 0xc9,
-	0xb9, 0x35, 0x72, 0x09, 0x47, 0xfd, 0xf0, 0xa4, 0x9d, 0xc0, 0x29, 0x7a, 0x82, 0x9e, 0xc4, 0x5d,
-	0xb3, 0x6c, 0x12, 0x9a, 0x0b, 0x80, 0x76, 0x42, 0x39, 0xaf, 0xb6, 0x0d, 0xa9, 0xc3, 0x13, 0xf4,
-	0x1c, 0xc4, 0x53, 0x57, 0x9f, 0x59, 0x03, 0x37, 0xba, 0x5a, 0xd6, 0x96, 0x6a, 0xd0, 0xe3, 0x24,
-	0x18, 0x42, 0xbd, 0x0c, 0xf9, 0xc9, 0x74, 0xd9, 0x13, 0x97, 0xba, 0xa6, 0x15, 0x3a, 0x62, 0x8c,
-	0x0e, 0xd1, 0x5d, 0x28, 0x87, 0x93, 0xe1, 0x90, 0x84, 0x7c, 0xd2, 0x9e, 0x78, 0x72, 0x90, 0x31,
-	0x70, 0x49, 0x21, 0x77, 0x38, 0x8e, 0x13, 0x1d, 0x59, 0x8e, 0x3b, 0x09, 0x88, 0x22, 0x92, 0xdd,
-	0xbd, 0xa4, 0x90, 0x92, 0xe8, 0x1e, 0x8f, 0x74, 0x46, 0xbc, 0xe1, 0xb4, 0x3f, 0x0e, 0xfb, 0xfe,
-	0xe3, 0x4d, 0xe1, 0x76, 0x03, 0x97, 0x14, 0xf6, 0x65, 0xd8, 0x79, 0xbc, 0x79, 0x96, 0x6a, 0xeb,
-	0xb1, 0xaa, 0xcb, 0x31, 0xaa, 0xad, 0xc7, 0x0b, 0x54, 0x5b, 0xc2, 0x9b, 0xf3, 0x54, 0x5b, 0xe8,
-	0x01, 0x5c, 0x63, 0x6e, 0x18, 0x75, 0x1d, 0xa9, 0x5a, 0x56, 0x10, 0xae, 0x32, 0x57, 0xbf, 0xa3,
-	0x0a, 0xed, 0xcc, 0x7f, 0x18, 0x50, 0x88, 0x8c, 0x83, 0x9a, 0x50, 0xf0, 0xa9, 0xdd, 0x3f, 0x0e,
-	0xe8, 0x44, 0xdf, 0x58, 0xee, 0x9e, 0x6f, 0x4b, 0x5e, 0x08, 0x5f, 0x70, 0xd2, 0x76, 0x02, 0xe7,
-	0x7d, 0xb5, 0xae, 0xfd, 0xda, 0x10, 0x95, 0x55, 0x00, 0xe8, 0x39, 0x18, 0x01, 0x3d, 0xd5, 0x7e,
-	0xf9, 0xfc, 0x0a, 0xb2, 0xea, 0x98, 0x9e, 0x62, 0xc1, 0x54, 0xfb, 0x73, 0x1a, 0xd2, 0x98, 0x9e,
-	0x7e, 0x68, 0xce, 0x5f, 0x9a, 0x86, 0xeb, 0x50, 0x19, 0x93, 0x70, 0x44, 0xec, 0x3e, 0x3f, 0xb4,
-	0x34, 0x93, 0xf4, 0xcd, 0x8a, 0xc4, 0x77, 0xa8, 0x2d, 0x7d, 0xf8, 0x00, 0xae, 0x05, 0x13, 0xcf,
-	0x73, 0xbc, 0xe3, 0x18, 0xa9, 0x74, 0xd0, 0xaa, 0xda, 0x88, 0x68, 0xd7, 0xa1, 0xc2, 0xfd, 0x3f,
-	0x27, 0x55, 0x1a, 0x7f, 0x45, 0xe2, 0x23, 0xca, 0x87, 0x90, 0xe1, 0xc1, 0xa8, 0xdb, 0xec, 0xe2,
-	0xcc, 0x36, 0x8b, 0x47, 0x2c, 0x29, 0xd1, 0xb7, 0x50, 0x96, 0x0d, 0xac, 0x3f, 0x98, 0x72, 0xf9,
-	0xd5, 0x9c, 0x30, 0xec, 0xd3, 0x2b, 0x1a, 0xb6, 0x2e, 0x3b, 0x58, 0x73, 0xca, 0x5b, 0x98, 0x98,
-	0xfd, 0x8b, 0x64, 0x86, 0xa9, 0xbd, 0x81, 0xca, 0x59, 0x82, 0x25, 0xb7, 0x80, 0xcd, 0xf8, 0x2d,
-	0x60, 0x59, 0xb2, 0x45, 0x9d, 0x32, 0x76, 0x43, 0xe0, 0x7d, 0x49, 0xe4, 0x68, 0xe3, 0x17, 0x06,
-	0xa4, 0xb7, 0x7d, 0x07, 0xbd, 0x81, 0x62, 0xac, 0x2e, 0xa0, 0xbb, 0x17, 0x57, 0x0d, 0x11, 0xb2,
-	0xb5, 0x7b, 0x57, 0x29, 0x2d, 0x66, 0x02, 0xbd, 0x82, 0xbc, 0xfe, 0x13, 0x00, 0xad, 0x2d, 0xf0,
-	0x9c, 0xf9, 0x43, 0xa1, 0x76, 0xe7, 0x02, 0x8a, 0x48, 0xe4, 0x2e, 0xa4, 0x7b, 0x96, 0x8f, 0x3e,
-	0x5e, 0x36, 0x00, 0x6a, 0x41, 0xb7, 0xce, 0x9d, 0x0e, 0xcd, 0xf4, 0x2f, 0x53, 0xc9, 0xcd, 0x24,
-	0x7a, 0x0d, 0xe5, 0xb9, 0x57, 0x2e, 0xf4, 0xd9, 0x95, 0x5e, 0xc1, 0x2e, 0x92, 0x9c, 0xd8, 0x4c,
-	0xa2, 0x6d, 0xc8, 0xe9, 0xbf, 0x5d, 0xce, 0xe9, 0x26, 0xb5, 0x4f, 0x16, 0xf0, 0xb1, 0xbf, 0x72,
-	0xcc, 0x04, 0x72, 0xa1, 0xd0, 0x25, 0xee, 0xd1, 0xce, 0x88, 0x0c, 0x4f, 0xd0, 0xf7, 0x66, 0xc4,
-	0xf2, 0x5f, 0xa1, 0x7a, 0xfc, 0x5f, 0xa1, 0x88, 0x4e, 0x6b, 0x57, 0xbf, 0x2a, 0xb9, 0xb6, 0x66,
-	0xf3, 0xd1, 0x9b, 0x87, 0xc7, 0x0e, 0x1b, 0x4d, 0x06, 0x9c, 0x61, 0x43, 0x71, 0xeb, 0xdf, 0xc6,
-	0xc6, 0xec, 0xad, 0x7f, 0xe3, 0x98, 0x78, 0x1b, 0x52, 0xe1, 0x41, 0x56, 0x4c, 0xb8, 0x8f, 0xfe,
-	0x13, 0x00, 0x00, 0xff, 0xff, 0xff, 0x14, 0xb8, 0x67, 0xe9, 0x1a, 0x00, 0x00,
+	// 2663 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x39, 0x4b, 0x73, 0x1b, 0xc7,
+	0xd1, 0x78, 0x2c, 0x40, 0xa0, 0x01, 0x90, 0xd0, 0x58, 0xd6, 0x07, 0xc3, 0x2e, 0x99, 0x86, 0x6c,
+	0x99, 0x25, 0x7f, 0x1f, 0x48, 0xd3, 0x96, 0x6c, 0xda, 0xfe, 0x92, 0xf0, 0x81, 0x88, 0x4c, 0x24,
+	0x12, 0x1e, 0x40, 0x76, 0x95, 0xcb, 0x55, 0xa8, 0x05, 0x76, 0x40, 0x6e, 0xb8, 0xd8, 0x59, 0xed,
+	0xce, 0x4a, 0x46, 0x8e, 0x39, 0xa4, 0x72, 0xc8, 0xc1, 0x97, 0x9c, 0x73, 0x4b, 0x2a, 0xb9, 0xe5,
+	0x90, 0xfc, 0x9c, 0xe4, 0x07, 0xe4, 0x90, 0x4b, 0xce, 0xa9, 0x54, 0xcf, 0x63, 0xb1, 0x20, 0x40,
+	0x91, 0x52, 0x2e, 0x39, 0x61, 0xba, 0xa7, 0xbb, 0xb7, 0xa7, 0xa7, 0x9f, 0x03, 0xa8, 0x06, 0xf1,
+	0xd0, 0x73, 0x47, 0xed, 0x20, 0xe4, 0x82, 0x93, 0x35, 0xcf, 0xf5, 0xcf, 0x59, 0xe8, 0x6c, 0xb7,
+	0x15, 0xba, 0x79, 0xfb, 0x94, 0xf3, 0x53, 0x8f, 0x6d, 0xca, 0xed, 0x61, 0x3c, 0xde, 0x74, 0xe2,
+	0xd0, 0x16, 0x2e, 0xf7, 0x15, 0x43, 0xb3, 0x31, 0xe2, 0x93, 0x09, 0xf7, 0x37, 0xcf, 0x98, 0xed,
+	0x89, 0xb3, 0xd1, 0x19, 0x1b, 0x9d, 0xab, 0x9d, 0xd6, 0x0a, 0x14, 0x3a, 0x93, 0x40, 0x4c, 0x5b,
+	0x4f, 0xa1, 0xf2, 0x15, 0x0b, 0x23, 0x97, 0xfb, 0x47, 0xfe, 0x98, 0x93, 0xb7, 0xa0, 0x7c, 0xca,
+	0x35, 0xa2, 0x91, 0x5d, 0xcf, 0x6e, 0x94, 0xe9, 0x0c, 0x81, 0xbb, 0xc3, 0xd8, 0xf5, 0x9c, 0x03,
+	0x5b, 0xb0, 0x46, 0x4e, 0xed, 0x26, 0x08, 0x72, 0x17, 0x56, 0x43, 0xe6, 0x31, 0x3b, 0x62, 0x46,
+	0x40, 0x5e, 0x92, 0x5c, 0xc0, 0xb6, 0x36, 0x61, 0xed, 0x91, 0x1b, 0x89, 0x2e, 0x77, 0x22, 0xca,
+	0x9e, 0xc6, 0x2c, 0x12, 0x28, 0xd8, 0xb7, 0x27, 0x2c, 0x0a, 0xec, 0x11, 0x33, 0x9f, 0x4d, 0x10,
+	0xad, 0x2f, 0xa0, 0x3e, 0x63, 0x88, 0x02, 0xee, 0x47, 0x8c, 0x6c, 0x80, 0x15, 0x70, 0x27, 0x6a,
+	0x64, 0xd7, 0xf3, 0x1b, 0x95, 0xed, 0x9b, 0xed, 0x0b, 0xa6, 0x69, 0x77, 0xb9, 0x43, 0x25, 0x45,
+	0xeb, 0xd7, 0x16, 0xe4, 0xbb, 0xdc, 0x21, 0x04, 0x2c, 0x14, 0xa9, 0xc5, 0xcb, 0x35, 0xb9, 0x09,
+	0x85, 0x80, 0x3b, 0x47, 0x5d, 0x7d, 0x18, 0x05, 0x90, 0x75, 0x00, 0x87, 0x05, 0x1e, 0x9f, 0x4e,
+	0x98, 0x2f, 0xd4, 0x21, 0x0e, 0x33, 0x34, 0x85, 0x23, 0xef, 0x40, 0x25, 0x64, 0x81, 0xe7, 0x8e,
+	0xec, 0x41, 0xc4, 0x44, 0x03, 0x0c, 0x89, 0x46, 0xf6, 0x98, 0x20, 0x9f, 0xc0, 0x2d, 0x0d, 0xe1,
+	0x85, 0x0c, 0x46, 0xdc, 0x17, 0x21, 0xf7, 0x3c, 0x16, 0x36, 0x2a, 0x9a, 0xfa, 0xf5, 0xd4, 0xfe,
+	0x7e, 0xb2, 0x4d, 0xee, 0x40, 0x35, 0x12, 0xb6, 0x60, 0xe3, 0xd8, 0x93, 0xc2, 0xab, 0x9a, 0xbc,
+	0x62, 0xb0, 0x28, 0xfd, 0x6d, 0x00, 0xc7, 0x66, 0x13, 0xee, 0x4b, 0x92, 0x9a, 0x26, 0x29, 0x2b,
+	0x1c, 0x12, 0x10, 0xc8, 0xff, 0x8c, 0x0f, 0x1b, 0xab, 0x7a, 0x07, 0x01, 0x72, 0x0b, 0x8a, 0x28,
+	0x23, 0x8e, 0x1a, 0x96, 0x3c, 0xae, 0x86, 0xd0, 0x0a, 0xb6, 0xe3, 0x30, 0xa7, 0x51, 0x58, 0xcf,
+	0x6e, 0x94, 0xa8, 0x02, 0xc8, 0x3e, 0xac, 0x45, 0xae, 0x3f, 0x62, 0x8f, 0xec, 0x48, 0x50, 0x16,
+	0xf0, 0x50, 0x34, 0x8a, 0xeb, 0xd9, 0x8d, 0xca, 0xf6, 0x1b, 0x6d, 0xe5, 0x76, 0x6d, 0xe3, 0x76,
+	0xed, 0x03, 0xed, 0x76, 0xf4, 0x22, 0x07, 0xd9, 0x82, 0xd7, 0x66, 0x27, 0x3f, 0x4e, 0xae, 0x78,
+	0x45, 0x7e, 0x7f, 0xd9, 0x16, 0x69, 0x41, 0x55, 0xa3, 0xbb, 0x9e, 0xed, 0xb3, 0x46, 0x49, 0xea,
+	0x34, 0x87, 0x23, 0x1f, 0x42, 0x31, 0x0e, 0x84, 0x3b, 0x61, 0x8d, 0xf2, 0x55, 0x1a, 0x69, 0xc2,
+	0xbd, 0x15, 0x28, 0xf0, 0xe7, 0x3e, 0x0b, 0x5b, 0x7f, 0xcc, 0x01, 0xf4, 0xed, 0xc0, 0x78, 0x1e,
+	0x81, 0x7c, 0xc0, 0x9d, 0x46, 0xd6, 0xd8, 0x29, 0xe0, 0xce, 0x85, 0xfb, 0xcf, 0x2d, 0xb9, 0xff,
+	0x5b, 0x50, 0x9c, 0xd8, 0xdf, 0xd1, 0x20, 0x92, 0xde, 0x91, 0xa3, 0x1a, 0x42, 0xbc, 0xe0, 0x5d,
+	0x34, 0x15, 0x5a, 0xb8, 0x46, 0x35, 0x84, 0xbe, 0x27, 0xf8, 0x51, 0x57, 0x1a, 0xb8, 0x4c, 0xe5,
+	0x9a, 0x34, 0xa1, 0x34, 0x0e, 0xf9, 0xa4, 0x6b, 0x0c, 0x5b, 0xa3, 0x09, 0x8c, 0x72, 0x70, 0x7d,
+	0xd4, 0xd5, 0x96, 0xd2, 0x90, 0xbc, 0xc1, 0xd1, 0x19, 0x9b, 0x28, 0xb3, 0x94, 0xa9, 0x86, 0xa4,
+	0x3e, 0x4c, 0x9c, 0x71, 0x47, 0x1a, 0xa4, 0x4c, 0x35, 0x84, 0x71, 0x65, 0xc7, 0xe2, 0x8c, 0x87,
+	0xae, 0x98, 0x2a, 0x2f, 0xa5, 0x33, 0x04, 0x6a, 0x15, 0xd8, 0xe2, 0x4c, 0x39, 0x24, 0x95, 0xeb,
+	0xcf, 0x72, 0x8d, 0xec, 0x5e, 0x09, 0x8a, 0xc2, 0x0e, 0x4f, 0x99, 0x68, 0xfd, 0xb2, 0x08, 0x37,
+	0xfb, 0x76, 0xb0, 0x37, 0xa5, 0x2c, 0xe2, 0x71, 0x38, 0x62, 0xc6, 0x6c, 0x9f, 0x19, 0x12, 0x69,
+	0xb9, 0xca, 0x76, 0x6b, 0x21, 0x00, 0x0d, 0x47, 0x8f, 0x79, 0x6c, 0xa4, 0xae, 0x42, 0x71, 0x90,
+	0x5d, 0x28, 0x4c, 0x6c, 0x31, 0x3a, 0x93, 0x96, 0xad, 0x6c, 0x7f, 0xb0, 0xc0, 0xba, 0xec, 0x8b,
+	0xed, 0xc7, 0xc8, 0x42, 0x15, 0xe7, 0xa5, 0xf6, 0xbf, 0x0d, 0x30, 0x8c, 0xc7, 0x63, 0x16, 0xf6,
+	0xdc, 0x9f, 0x33, 0x7d, 0x07, 0x29, 0x4c, 0xf3, 0x2f, 0x16, 0x14, 0xa4, 0x20, 0xb2, 0x0f, 0x79,
+	0xdb, 0xf3, 0xb4, 0xf6, 0x9b, 0x2f, 0xa1, 0x42, 0xbb, 0xc7, 0x9e, 0xa2, 0xa3, 0xd8, 0x9e, 0x27,
+	0x85, 0xf8, 0xd3, 0x46, 0xee, 0xd5, 0x85, 0xf8, 0x53, 0xf2, 0x43, 0xc8, 0xfb, 0x5c, 0xa5, 0x99,
+	0x97, 0x33, 0x06, 0x0a, 0xf0, 0xb9, 0x20, 0x87, 0x50, 0x75, 0x58, 0x24, 0x5c, 0x5f, 0x7a, 0xbc,
+	0x0a, 0xee, 0x6b, 0xdd, 0xc8, 0x61, 0x86, 0xce, 0x71, 0x92, 0x1f, 0x83, 0x75, 0x26, 0x44, 0x20,
+	0xdd, 0xb4, 0xb2, 0xbd, 0xf5, 0x32, 0x07, 0x3a, 0x14, 0x22, 0x38, 0xcc, 0x50, 0xc9, 0xdf, 0x7c,
+	0x04, 0xf9, 0x1e, 0x7b, 0x4a, 0x3a, 0xb0, 0x22, 0xaf, 0x8b, 0x99, 0x34, 0xfd, 0x52, 0x57, 0x6d,
+	0x78, 0x9b, 0x53, 0xb0, 0x50, 0x3a, 0x69, 0x24, 0xce, 0x6f, 0xa2, 0x55, 0xc3, 0xb8, 0xa3, 0xdd,
+	0xdf, 0x04, 0xab, 0x86, 0xc9, 0xed, 0x74, 0x00, 0x98, 0x4c, 0x3e, 0x43, 0x91, 0x9b, 0x3a, 0x04,
+	0x2c, 0xbd, 0x25, 0x21, 0x4c, 0x16, 0xf2, 0xe3, 0xc9, 0xa2, 0xf5, 0xcf, 0x2c, 0x00, 0x2a, 0xf1,
+	0x58, 0x89, 0x3d, 0x04, 0x08, 0xd9, 0xa9, 0x1b, 0x09, 0x16, 0x32, 0x95, 0x3c, 0x56, 0xb7, 0xef,
+	0x2e, 0x1c, 0x6e, 0xc6, 0xd0, 0xa6, 0x09, 0xb5, 0x2a, 0x13, 0x06, 0x22, 0xef, 0x42, 0x35, 0xf6,
+	0x53, 0xb2, 0xcc, 0x01, 0xe6, 0xb0, 0x2d, 0x1f, 0x60, 0x26, 0x81, 0xac, 0x40, 0xfe, 0x61, 0xa7,
+	0x5f, 0xcf, 0x90, 0x12, 0x58, 0xdd, 0x93, 0x5e, 0xbf, 0x9e, 0x45, 0x54, 0xf7, 0x49, 0xbf, 0x9e,
+	0x23, 0x00, 0xc5, 0x83, 0xce, 0xa3, 0x4e, 0xbf, 0x53, 0xcf, 0x93, 0x32, 0x14, 0xba, 0xbb, 0xfd,
+	0xfd, 0xc3, 0xba, 0x45, 0x2a, 0xb0, 0x72, 0xd2, 0xed, 0x1f, 0x9d, 0x1c, 0xf7, 0xea, 0x05, 0x04,
+	0xf6, 0x4f, 0x8e, 0x8f, 0x3b, 0xfb, 0xfd, 0x7a, 0x11, 0x65, 0x1c, 0x76, 0x76, 0x0f, 0xea, 0x2b,
+	0x48, 0xde, 0xa7, 0xbb, 0xfb, 0x9d, 0x7a, 0x69, 0xaf, 0x08, 0x96, 0x98, 0x06, 0xac, 0xf5, 0xdb,
+	0x2c, 0x14, 0x7b, 0xca, 0xc6, 0x07, 0x4b, 0x8e, 0xbc, 0xe8, 0x63, 0x8a, 0xf8, 0x3f, 0x3d, 0xee,
+	0x3b, 0x73, 0xc7, 0x45, 0x0d, 0xfb, 0xfd, 0x6e, 0x3d, 0x83, 0x1a, 0xe2, 0xaa, 0x57, 0xcf, 0x26,
+	0x1a, 0xf6, 0xa1, 0x7c, 0xd4, 0xdd, 0x75, 0x9c, 0x90, 0x45, 0x58, 0xc8, 0x2c, 0x37, 0x78, 0xf6,
+	0xb1, 0xd4, 0x6e, 0x05, 0x6f, 0x13, 0x21, 0xf2, 0x81, 0xc4, 0x3e, 0xd0, 0x61, 0xfa, 0xfa, 0x82,
+	0xce, 0x47, 0xdd, 0x67, 0x0f, 0x34, 0xf1, 0x83, 0x3d, 0x0b, 0x72, 0x6e, 0xd0, 0xda, 0x02, 0x0b,
+	0xb1, 0x58, 0x19, 0xc7, 0x6e, 0x18, 0xa9, 0x2c, 0x57, 0xa4, 0x0a, 0xc0, 0xbc, 0xe9, 0xd9, 0x91,
+	0xaa, 0x0c, 0x45, 0x2a, 0xd7, 0xad, 0x47, 0x00, 0xfd, 0x51, 0x60, 0x14, 0xb9, 0x87, 0x52, 0x74,
+	0x72, 0x69, 0x2e, 0xf9, 0xa0, 0xa6, 0xa3, 0x39, 0x37, 0x90, 0x59, 0x98, 0x87, 0x4a, 0x5a, 0x8d,
+	0xca, 0x75, 0xcb, 0x81, 0x7c, 0x87, 0xa3, 0x98, 0xfa, 0x69, 0x18, 0x8c, 0x06, 0xaa, 0x4e, 0x0f,
+	0x46, 0xdc, 0x51, 0xbe, 0x5f, 0x3b, 0xcc, 0xd0, 0x55, 0xdc, 0xe9, 0xc9, 0x8d, 0x7d, 0xee, 0x30,
+	0xa4, 0x0d, 0x59, 0xc4, 0xc4, 0x80, 0x85, 0x21, 0x0f, 0x15, 0x6d, 0xce, 0xd0, 0xca, 0x9d, 0x0e,
+	0x6e, 0x20, 0xed, 0x5e, 0x01, 0xf2, 0xcc, 0x77, 0x5a, 0xbf, 0xab, 0x41, 0xa9, 0x6f, 0x07, 0x9d,
+	0x67, 0x58, 0xd2, 0x3e, 0x82, 0xa2, 0x8a, 0x42, 0xad, 0xf6, 0x9b, 0x8b, 0xb1, 0x9a, 0x9c, 0x8f,
+	0x6a, 0x52, 0xf2, 0x10, 0x2a, 0x6a, 0x35, 0x98, 0x30, 0x61, 0xeb, 0xbc, 0x71, 0x77, 0x59, 0x94,
+	0xcb, 0x8f, 0xb4, 0x3b, 0xbe, 0x13, 0x70, 0xd7, 0x17, 0x8f, 0x99, 0xb0, 0x29, 0x28, 0x56, 0x5c,
+	0x93, 0xff, 0x87, 0x4a, 0x2a, 0x13, 0x35, 0x72, 0x57, 0xab, 0x90, 0xa6, 0x27, 0x5f, 0x42, 0x3d,
+	0x05, 0x2a, 0x65, 0xac, 0x97, 0x52, 0x66, 0x2d, 0xc5, 0x2f, 0x35, 0xfa, 0x12, 0xd6, 0x82, 0x90,
+	0x7f, 0x37, 0x1d, 0x38, 0x6e, 0xa8, 0xd2, 0xa5, 0xac, 0xd2, 0xab, 0xdb, 0x1b, 0x97, 0x4b, 0xec,
+	0x22, 0xc3, 0x81, 0xa1, 0xa7, 0xab, 0xc1, 0x1c, 0x4c, 0x3e, 0xd6, 0xe9, 0x55, 0xa5, 0xfa, 0xdb,
+	0x97, 0xcb, 0x49, 0x27, 0x53, 0xf2, 0x1e, 0xac, 0x3a, 0x21, 0x0f, 0x02, 0xe6, 0x0c, 0x18, 0xee,
+	0x46, 0xb2, 0x27, 0xb0, 0x68, 0x4d, 0x63, 0x25, 0x4b, 0xd4, 0xfc, 0x4d, 0x16, 0xaa, 0xe9, 0x13,
+	0x91, 0x9f, 0x40, 0xd1, 0xb3, 0x87, 0xcc, 0x33, 0xc9, 0x77, 0xfb, 0x7a, 0x96, 0x68, 0x3f, 0x92,
+	0x4c, 0x1d, 0x5f, 0x84, 0x53, 0xaa, 0x25, 0x34, 0x77, 0xa0, 0x92, 0x42, 0x93, 0x3a, 0xe4, 0xcf,
+	0xd9, 0x54, 0x77, 0xd2, 0xb8, 0xc4, 0x40, 0x79, 0x66, 0x7b, 0xb1, 0x99, 0x0a, 0x14, 0xf0, 0x59,
+	0xee, 0xd3, 0x6c, 0xf3, 0x5f, 0x2b, 0x3a, 0x7d, 0x9f, 0x40, 0x35, 0x54, 0x09, 0x7e, 0xe0, 0xfa,
+	0xae, 0x69, 0x1c, 0xee, 0xbd, 0xd8, 0x0a, 0x6d, 0x5d, 0x13, 0x8e, 0x7c, 0x57, 0x60, 0x0f, 0x1c,
+	0xce, 0x40, 0x42, 0xa1, 0x16, 0xea, 0x71, 0x40, 0x49, 0x7c, 0x41, 0x3f, 0x31, 0x27, 0x51, 0xf1,
+	0x68, 0x91, 0xd5, 0x30, 0x05, 0x2b, 0x25, 0xb5, 0x4c, 0xe6, 0x3b, 0x8d, 0xfc, 0x35, 0x95, 0x54,
+	0x2c, 0x1d, 0xdf, 0x51, 0x4a, 0x26, 0x60, 0xf3, 0x01, 0x94, 0x7a, 0x22, 0x64, 0xf6, 0xe4, 0x48,
+	0x4e, 0x20, 0x43, 0x3b, 0xd2, 0x21, 0x4c, 0xe5, 0x5a, 0xf5, 0xe4, 0xb8, 0x2f, 0xb5, 0xb7, 0xa8,
+	0x86, 0x9a, 0x7f, 0xcd, 0x42, 0x25, 0x75, 0x76, 0xf2, 0x09, 0xe4, 0x5c, 0x47, 0xdb, 0xec, 0xfd,
+	0x2b, 0xd4, 0x31, 0x1f, 0xa4, 0x39, 0xd7, 0xc1, 0xb8, 0x4e, 0xd5, 0xc6, 0x65, 0x41, 0x35, 0x2b,
+	0x53, 0x49, 0xd9, 0xdc, 0x4c, 0x4a, 0xad, 0x32, 0xc0, 0xff, 0x5c, 0x92, 0xe8, 0x93, 0x0a, 0x3c,
+	0xd7, 0x68, 0x5a, 0x97, 0x35, 0x9a, 0x85, 0x59, 0xa3, 0xd9, 0xfc, 0x53, 0x16, 0xaa, 0xe9, 0xab,
+	0x78, 0xf5, 0x13, 0x3e, 0x04, 0x22, 0xc7, 0x8e, 0xc1, 0x9c, 0x7b, 0xe5, 0xae, 0x9a, 0x0c, 0xea,
+	0x92, 0x29, 0x6d, 0xe3, 0xb7, 0xa1, 0x82, 0x11, 0xa7, 0xd3, 0xad, 0x3c, 0x7a, 0x8d, 0x02, 0xa2,
+	0x54, 0x9e, 0x6d, 0xfe, 0x21, 0x07, 0x15, 0xa3, 0x73, 0xc7, 0x77, 0xfe, 0x0b, 0x54, 0x3e, 0x82,
+	0xd7, 0x8c, 0xa0, 0x74, 0x24, 0xe4, 0xaf, 0x92, 0x74, 0x43, 0x4b, 0x4a, 0xd9, 0xff, 0x3d, 0x1c,
+	0xdf, 0xb5, 0x90, 0xe1, 0x54, 0x30, 0xd5, 0x48, 0x5a, 0x34, 0x09, 0xb2, 0x3d, 0x44, 0x92, 0xbb,
+	0x90, 0x67, 0x3c, 0xd2, 0xa9, 0x7e, 0x71, 0xee, 0xee, 0xf0, 0x88, 0x22, 0x01, 0xb6, 0x4e, 0x32,
+	0x5d, 0xb5, 0x3e, 0x85, 0xd5, 0xf9, 0xbc, 0x88, 0xfd, 0xc7, 0x93, 0xe3, 0x9f, 0x1e, 0x9f, 0x7c,
+	0x7d, 0x5c, 0xcf, 0x20, 0x70, 0x74, 0xbc, 0x77, 0xf2, 0xe4, 0xf8, 0xa0, 0x9e, 0x25, 0x55, 0x28,
+	0x9d, 0x3c, 0xe9, 0x2b, 0x28, 0x37, 0x13, 0xb1, 0x0e, 0xa5, 0xdd, 0xc0, 0x95, 0xf5, 0x0b, 0x33,
+	0x8d, 0xac, 0x70, 0x3a, 0xfb, 0x28, 0x00, 0xa7, 0xba, 0x72, 0x97, 0x3b, 0x92, 0x24, 0x22, 0x9f,
+	0x43, 0x51, 0xa2, 0x4d, 0xea, 0xbb, 0xb3, 0xec, 0x79, 0x40, 0xd1, 0x26, 0x2b, 0xaa, 0x59, 0x9a,
+	0x7f, 0xcb, 0x42, 0xc9, 0x20, 0x09, 0x85, 0x32, 0x4e, 0x9e, 0xb6, 0xeb, 0xb3, 0x50, 0x5f, 0xf4,
+	0xf6, 0x35, 0x84, 0xb5, 0xf7, 0x0d, 0x93, 0x04, 0xb1, 0xe7, 0x4c, 0xc4, 0x34, 0x9f, 0xc1, 0xea,
+	0xfc, 0x36, 0x69, 0xc0, 0xca, 0x84, 0x45, 0x91, 0x7d, 0x6a, 0x5e, 0x27, 0x0c, 0x88, 0x71, 0x35,
+	0xfb, 0xbe, 0x7e, 0x71, 0x49, 0x10, 0x68, 0x0b, 0x77, 0x82, 0x5c, 0xea, 0xa1, 0x45, 0x01, 0x98,
+	0x52, 0x42, 0x66, 0x47, 0xdc, 0x37, 0x63, 0xbe, 0x82, 0xa4, 0x39, 0xa5, 0xb1, 0xba, 0x50, 0x32,
+	0x2d, 0xf7, 0x8b, 0x5f, 0x5e, 0xe4, 0xdc, 0x3a, 0x0d, 0x4c, 0x56, 0x97, 0xeb, 0xe4, 0x1d, 0x25,
+	0x3f, 0x7b, 0x47, 0x69, 0x3d, 0x85, 0x1b, 0x0b, 0xd3, 0x05, 0xb9, 0x0f, 0xa5, 0x90, 0xcd, 0xf5,
+	0x14, 0x6f, 0x5c, 0x3a, 0x93, 0xd0, 0x84, 0x14, 0xfd, 0x50, 0x56, 0x9d, 0x41, 0x24, 0x25, 0x71,
+	0x73, 0xee, 0x9a, 0xc4, 0xf6, 0x34, 0xb2, 0xf5, 0x2d, 0xd4, 0x0c, 0xb3, 0x32, 0xe2, 0x2b, 0x7e,
+	0x2e, 0xf1, 0xa7, 0x5c, 0xda, 0x9f, 0xfe, 0x91, 0x07, 0x82, 0x41, 0xdf, 0x8b, 0x27, 0x13, 0x3b,
+	0x9c, 0x9a, 0xb1, 0xf7, 0x07, 0x50, 0x4a, 0xb4, 0xba, 0xfe, 0xe0, 0x9b, 0xf0, 0x60, 0x86, 0xc1,
+	0xd7, 0x88, 0xc1, 0x73, 0xd7, 0x77, 0xf8, 0x73, 0xfd, 0x49, 0x40, 0xd4, 0xd7, 0x12, 0x43, 0xfe,
+	0x17, 0x2c, 0x9f, 0xfb, 0x26, 0xed, 0xde, 0x5a, 0x0c, 0x2f, 0x7c, 0xb4, 0xc3, 0xd6, 0x00, 0xa9,
+	0xc8, 0x17, 0x50, 0x11, 0x7c, 0x90, 0x9c, 0xda, 0xba, 0xe2, 0xd4, 0xd8, 0x8b, 0x0b, 0x6e, 0x20,
+	0xf2, 0x23, 0xa8, 0xe1, 0xb3, 0xc2, 0x8c, 0xbf, 0x70, 0x35, 0x7f, 0x15, 0x39, 0x12, 0x09, 0x77,
+	0xa0, 0xe6, 0xfa, 0x23, 0x2f, 0x76, 0xd8, 0x40, 0x5e, 0x8e, 0xec, 0x90, 0xca, 0xb4, 0xaa, 0x91,
+	0xb2, 0x65, 0x20, 0x6f, 0x42, 0x59, 0x36, 0xb1, 0xdc, 0xf7, 0xa6, 0xb2, 0x75, 0x29, 0xd1, 0x12,
+	0x22, 0x4e, 0x7c, 0x4f, 0xf6, 0x0d, 0x9e, 0x3b, 0x71, 0x85, 0x7c, 0xcf, 0xa8, 0x51, 0x05, 0xa0,
+	0x07, 0xf3, 0xf1, 0x18, 0x5f, 0xb6, 0xca, 0x12, 0xad, 0x21, 0xb2, 0x05, 0x37, 0x27, 0xae, 0x3f,
+	0x10, 0xa1, 0x3d, 0x1e, 0xbb, 0xa3, 0x81, 0x79, 0xed, 0xd4, 0x2f, 0x1b, 0x64, 0xe2, 0xfa, 0x7d,
+	0xb5, 0x65, 0xf2, 0xdc, 0x7c, 0x5d, 0xaa, 0x5c, 0xa8, 0x4b, 0x7b, 0x00, 0x25, 0x1e, 0x8b, 0x21,
+	0x8f, 0x7d, 0xa7, 0xf5, 0xfb, 0x1c, 0xbc, 0x36, 0x77, 0xe3, 0xfa, 0xa1, 0x71, 0x07, 0x72, 0xfc,
+	0xfc, 0xd2, 0x1c, 0xbf, 0x84, 0xa3, 0x7d, 0x72, 0x7e, 0x98, 0xa1, 0x39, 0x7e, 0x4e, 0x1e, 0xa4,
+	0x5d, 0x6b, 0x59, 0xc3, 0x37, 0xe7, 0xc0, 0x87, 0x19, 0xed, 0x7c, 0xcd, 0xef, 0xb3, 0x90, 0x3b,
+	0x39, 0x27, 0x9f, 0x83, 0x7c, 0xf2, 0x1b, 0x08, 0x7b, 0xe8, 0x25, 0x23, 0x74, 0x73, 0xa9, 0x0a,
+	0x7d, 0x24, 0xa1, 0x10, 0x99, 0x65, 0x44, 0x1e, 0xc3, 0x8d, 0x20, 0xe4, 0x58, 0xce, 0x59, 0x1c,
+	0x0d, 0x74, 0x36, 0xcc, 0x49, 0x11, 0xeb, 0x8b, 0x09, 0x2c, 0xa1, 0x54, 0xa9, 0xb0, 0x1e, 0xcc,
+	0x23, 0x22, 0xb4, 0x94, 0x29, 0x03, 0xad, 0x1d, 0x58, 0xbb, 0xc0, 0x80, 0x0d, 0x61, 0x1c, 0x7a,
+	0xa6, 0x21, 0x8c, 0x43, 0xef, 0x92, 0xb0, 0xc2, 0x31, 0x7a, 0xcf, 0x8e, 0x5c, 0x39, 0xb8, 0x44,
+	0xe8, 0x3f, 0x51, 0x3c, 0x1a, 0xb1, 0x08, 0x67, 0x9b, 0xd8, 0x57, 0x3d, 0xa1, 0x45, 0xab, 0x1a,
+	0xb9, 0x8f, 0x38, 0x24, 0x1a, 0xdb, 0xae, 0x17, 0x87, 0x4c, 0x13, 0xa9, 0x46, 0xa9, 0xaa, 0x91,
+	0x8a, 0xe8, 0x5d, 0x4c, 0x1a, 0x82, 0xf9, 0xa3, 0xe9, 0x60, 0x12, 0x0d, 0x82, 0xfb, 0x5b, 0x32,
+	0x82, 0x2c, 0x5a, 0xd5, 0xd8, 0xc7, 0x51, 0xf7, 0xfe, 0xd6, 0x45, 0xaa, 0x9d, 0xfb, 0x0d, 0xeb,
+	0x22, 0xd5, 0xce, 0xfd, 0x05, 0xaa, 0x9d, 0x46, 0x61, 0x81, 0x6a, 0x87, 0xdc, 0x83, 0x1b, 0xc2,
+	0x8b, 0x92, 0x02, 0xae, 0x54, 0x2b, 0x4a, 0xc2, 0x35, 0xe1, 0x99, 0x97, 0x6d, 0xa9, 0x5d, 0xeb,
+	0xfb, 0x02, 0x94, 0x93, 0x6b, 0x22, 0x7b, 0x50, 0x0e, 0xb8, 0x33, 0x38, 0x0d, 0x79, 0x6c, 0x66,
+	0xc4, 0x3b, 0x97, 0xdf, 0x2a, 0xd6, 0x94, 0x87, 0x48, 0x7a, 0x98, 0xa1, 0xa5, 0x40, 0xaf, 0x9b,
+	0x7f, 0xb6, 0x64, 0x91, 0x92, 0x00, 0xf9, 0x1c, 0xac, 0x90, 0x3f, 0x37, 0x1e, 0xf2, 0xfe, 0x35,
+	0x64, 0xb5, 0x29, 0x7f, 0x4e, 0x25, 0x53, 0xf3, 0xef, 0x79, 0xc8, 0x53, 0xfe, 0xfc, 0x55, 0xd3,
+	0xe7, 0x95, 0x19, 0x6d, 0x03, 0xea, 0x13, 0x16, 0x9d, 0x31, 0x67, 0x80, 0x87, 0x56, 0x66, 0x52,
+	0x77, 0xb3, 0xaa, 0xf0, 0x5d, 0xee, 0xa8, 0x3b, 0xbc, 0x07, 0x37, 0xc2, 0xd8, 0xf7, 0x5d, 0xff,
+	0x34, 0x45, 0xaa, 0x2e, 0x68, 0x4d, 0x6f, 0x24, 0xb4, 0x1b, 0x50, 0xc7, 0xfb, 0x9f, 0x93, 0xaa,
+	0x8c, 0xbf, 0xaa, 0xf0, 0x09, 0xe5, 0x87, 0x50, 0xc0, 0xb0, 0x30, 0x1d, 0xcb, 0x62, 0xfb, 0x3b,
+	0xf3, 0x47, 0xaa, 0x28, 0xc9, 0xb7, 0x50, 0x53, 0x01, 0x33, 0x18, 0x4e, 0x51, 0x7e, 0x63, 0x45,
+	0x1a, 0xf6, 0xd3, 0x6b, 0x1a, 0xb6, 0xad, 0x63, 0x66, 0x8a, 0xdd, 0x80, 0x1c, 0xa3, 0x2a, 0x6c,
+	0x86, 0x41, 0x8b, 0xa9, 0xfa, 0xa6, 0x06, 0x26, 0xf5, 0x90, 0x0b, 0x12, 0xf5, 0x15, 0x62, 0x9a,
+	0xdf, 0x40, 0xfd, 0xa2, 0x84, 0x25, 0x13, 0xd7, 0x56, 0x7a, 0xe2, 0x5a, 0x96, 0x17, 0x92, 0xae,
+	0x24, 0x35, 0x8d, 0x61, 0x0f, 0x20, 0xd3, 0xc9, 0xf6, 0x2f, 0x2c, 0xc8, 0xef, 0x06, 0x2e, 0xf9,
+	0x06, 0x2a, 0xa9, 0x1c, 0x46, 0xee, 0xbc, 0x38, 0xc3, 0x49, 0x9f, 0x6e, 0xbe, 0x7b, 0x9d, 0x34,
+	0xd8, 0xca, 0x90, 0x2f, 0xa1, 0x64, 0xfe, 0xb7, 0x21, 0x8b, 0x49, 0xe7, 0xc2, 0x7f, 0x40, 0xcd,
+	0x77, 0x5e, 0x40, 0x91, 0x88, 0x3c, 0x80, 0x7c, 0xdf, 0x0e, 0xc8, 0x9b, 0xcb, 0x9a, 0x6d, 0x23,
+	0xe8, 0x8d, 0x4b, 0x3b, 0xf1, 0x56, 0xfe, 0x57, 0xb9, 0xec, 0x56, 0x96, 0x3c, 0x81, 0xda, 0xdc,
+	0xc3, 0x23, 0x79, 0xef, 0x5a, 0x0f, 0x93, 0x2f, 0x92, 0x9c, 0xd9, 0xca, 0x92, 0x5d, 0x58, 0x31,
+	0xff, 0x94, 0x5d, 0x52, 0xb9, 0x9b, 0x6f, 0x2d, 0xe0, 0x53, 0xff, 0xbe, 0xb5, 0x32, 0xc4, 0x83,
+	0x72, 0x8f, 0x79, 0xe3, 0x7d, 0xfc, 0xab, 0x8e, 0xfc, 0xdf, 0x8c, 0x58, 0xfd, 0x91, 0xd7, 0x4e,
+	0xff, 0x91, 0x97, 0xd0, 0x19, 0xed, 0xda, 0xd7, 0x25, 0x37, 0xd6, 0xdc, 0xfb, 0xe8, 0x9b, 0x0f,
+	0x4f, 0x5d, 0x71, 0x16, 0x0f, 0x91, 0x61, 0x53, 0x73, 0x9b, 0xdf, 0xed, 0xcd, 0xd9, 0xdf, 0x33,
+	0x9b, 0xa7, 0xcc, 0xdf, 0x54, 0x0a, 0x0f, 0x8b, 0x72, 0x9a, 0xf8, 0xe8, 0xdf, 0x03, 0x00, 0x0c,
+	0x6c, 0xca, 0xda, 0x9c, 0x1c, 0x00, 0x00,
 }
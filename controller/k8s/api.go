@@ -2,20 +2,27 @@ package k8s
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"strings"
 	"time"
 
+	spclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
+	sp "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions"
+	spinformers "github.com/linkerd/linkerd2/controller/gen/client/informers/externalversions/serviceprofile/v1alpha1"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	batchv1 "k8s.io/api/batch/v1"
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
 	appinformers "k8s.io/client-go/informers/apps/v1beta2"
+	batchinformers "k8s.io/client-go/informers/batch/v1"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -27,13 +34,45 @@ const (
 	CM ApiResource = iota
 	Deploy
 	Endpoint
+	Job
 	NS
 	Pod
 	RC
 	RS
+	SA
+	SP
 	Svc
 )
 
+func (resource ApiResource) String() string {
+	switch resource {
+	case CM:
+		return "configmap"
+	case Deploy:
+		return k8s.Deployment
+	case Endpoint:
+		return "endpoints"
+	case Job:
+		return k8s.Job
+	case NS:
+		return k8s.Namespace
+	case Pod:
+		return k8s.Pod
+	case RC:
+		return k8s.ReplicationController
+	case RS:
+		return "replicaset"
+	case SA:
+		return k8s.ServiceAccount
+	case SP:
+		return "serviceprofile"
+	case Svc:
+		return k8s.Service
+	default:
+		return fmt.Sprintf("ApiResource(%d)", int(resource))
+	}
+}
+
 // API provides shared informers for all Kubernetes objects
 type API struct {
 	Client kubernetes.Interface
@@ -41,53 +80,137 @@ type API struct {
 	cm       coreinformers.ConfigMapInformer
 	deploy   appinformers.DeploymentInformer
 	endpoint coreinformers.EndpointsInformer
+	job      batchinformers.JobInformer
 	ns       coreinformers.NamespaceInformer
 	pod      coreinformers.PodInformer
 	rc       coreinformers.ReplicationControllerInformer
 	rs       appinformers.ReplicaSetInformer
+	sa       coreinformers.ServiceAccountInformer
+	sp       spinformers.ServiceProfileInformer
 	svc      coreinformers.ServiceInformer
 
-	syncChecks      []cache.InformerSynced
-	sharedInformers informers.SharedInformerFactory
+	syncChecks         []cache.InformerSynced
+	sharedInformers    informers.SharedInformerFactory
+	podSharedInformers informers.SharedInformerFactory
+	spSharedInformers  sp.SharedInformerFactory
 }
 
-// NewAPI takes a Kubernetes client and returns an initialized API
-func NewAPI(k8sClient kubernetes.Interface, resources ...ApiResource) *API {
-	sharedInformers := informers.NewSharedInformerFactory(k8sClient, 10*time.Minute)
+// DefaultResyncPeriod is how often the informers of the API that NewAPI
+// returns replay their caches to their event handlers.
+const DefaultResyncPeriod = 10 * time.Minute
+
+// InformerConfig configures the informers of an API.
+type InformerConfig struct {
+	// ResyncPeriod is how often the informers replay every object in their
+	// caches to their event handlers, as update events. Resyncs don't query
+	// the apiserver, but they wake up every handler for every object. Zero
+	// disables them.
+	ResyncPeriod time.Duration
+
+	// MeshedPodsOnly restricts the pod informer to the pods with the
+	// ControllerNSLabel, which cuts the number of pods that are listed and
+	// watched in clusters where few pods are meshed. The pods that aren't
+	// meshed are then missing from the API.
+	MeshedPodsOnly bool
+}
+
+// AddInformerFlags adds the flags that configure the informers of an API to
+// cmd, and returns the func that reads their InformerConfig once cmd is
+// parsed. meshedPodsOnlyUsage says what the process misses out on with
+// -meshed-pods-only.
+func AddInformerFlags(cmd *flag.FlagSet, meshedPodsOnlyUsage string) func() InformerConfig {
+	resyncPeriod := cmd.Duration("informer-resync-period", DefaultResyncPeriod, "how often the Kubernetes informers replay their caches to their event handlers")
+	disableResync := cmd.Bool("disable-informer-resync", false, "disable the Kubernetes informers' resyncs, ignoring informer-resync-period")
+	meshedPodsOnly := cmd.Bool("meshed-pods-only", false, "only list and watch meshed pods; "+meshedPodsOnlyUsage)
+
+	return func() InformerConfig {
+		config := InformerConfig{
+			ResyncPeriod:   *resyncPeriod,
+			MeshedPodsOnly: *meshedPodsOnly,
+		}
+		if *disableResync {
+			config.ResyncPeriod = 0
+		}
+		return config
+	}
+}
+
+// NewAPI takes a Kubernetes client and returns an initialized API. The
+// ServiceProfile client may be nil if the SP resource is not requested.
+func NewAPI(k8sClient kubernetes.Interface, spClient spclient.Interface, resources ...ApiResource) *API {
+	return NewAPIWithConfig(k8sClient, spClient, InformerConfig{ResyncPeriod: DefaultResyncPeriod}, resources...)
+}
+
+// NewAPIWithConfig is like NewAPI, with informers configured by config.
+func NewAPIWithConfig(k8sClient kubernetes.Interface, spClient spclient.Interface, config InformerConfig, resources ...ApiResource) *API {
+	sharedInformers := informers.NewSharedInformerFactory(k8sClient, config.ResyncPeriod)
+
+	podSharedInformers := sharedInformers
+	if config.MeshedPodsOnly {
+		podSharedInformers = informers.NewFilteredSharedInformerFactory(k8sClient, config.ResyncPeriod, metav1.NamespaceAll,
+			func(options *metav1.ListOptions) {
+				options.LabelSelector = k8s.ControllerNSLabel
+			},
+		)
+	}
+
+	var spSharedInformers sp.SharedInformerFactory
+	if spClient != nil {
+		spSharedInformers = sp.NewSharedInformerFactory(spClient, config.ResyncPeriod)
+	}
 
 	api := &API{
-		Client:          k8sClient,
-		syncChecks:      make([]cache.InformerSynced, 0),
-		sharedInformers: sharedInformers,
+		Client:             k8sClient,
+		syncChecks:         make([]cache.InformerSynced, 0),
+		sharedInformers:    sharedInformers,
+		podSharedInformers: podSharedInformers,
+		spSharedInformers:  spSharedInformers,
 	}
 
 	for _, resource := range resources {
+		var informer cache.SharedIndexInformer
 		switch resource {
 		case CM:
 			api.cm = sharedInformers.Core().V1().ConfigMaps()
-			api.syncChecks = append(api.syncChecks, api.cm.Informer().HasSynced)
+			informer = api.cm.Informer()
 		case Deploy:
 			api.deploy = sharedInformers.Apps().V1beta2().Deployments()
-			api.syncChecks = append(api.syncChecks, api.deploy.Informer().HasSynced)
+			informer = api.deploy.Informer()
 		case Endpoint:
 			api.endpoint = sharedInformers.Core().V1().Endpoints()
-			api.syncChecks = append(api.syncChecks, api.endpoint.Informer().HasSynced)
+			informer = api.endpoint.Informer()
+		case Job:
+			api.job = sharedInformers.Batch().V1().Jobs()
+			informer = api.job.Informer()
 		case NS:
 			api.ns = sharedInformers.Core().V1().Namespaces()
-			api.syncChecks = append(api.syncChecks, api.ns.Informer().HasSynced)
+			informer = api.ns.Informer()
 		case Pod:
-			api.pod = sharedInformers.Core().V1().Pods()
-			api.syncChecks = append(api.syncChecks, api.pod.Informer().HasSynced)
+			api.pod = podSharedInformers.Core().V1().Pods()
+			informer = api.pod.Informer()
 		case RC:
 			api.rc = sharedInformers.Core().V1().ReplicationControllers()
-			api.syncChecks = append(api.syncChecks, api.rc.Informer().HasSynced)
+			informer = api.rc.Informer()
 		case RS:
 			api.rs = sharedInformers.Apps().V1beta2().ReplicaSets()
-			api.syncChecks = append(api.syncChecks, api.rs.Informer().HasSynced)
+			informer = api.rs.Informer()
+		case SA:
+			api.sa = sharedInformers.Core().V1().ServiceAccounts()
+			informer = api.sa.Informer()
+		case SP:
+			if spSharedInformers == nil {
+				panic("SP informer requires a ServiceProfile client")
+			}
+			api.sp = spSharedInformers.Linkerd().V1alpha1().ServiceProfiles()
+			informer = api.sp.Informer()
 		case Svc:
 			api.svc = sharedInformers.Core().V1().Services()
-			api.syncChecks = append(api.syncChecks, api.svc.Informer().HasSynced)
+			informer = api.svc.Informer()
+		default:
+			continue
 		}
+		api.syncChecks = append(api.syncChecks, informer.HasSynced)
+		instrumentInformer(resource, informer)
 	}
 
 	return api
@@ -98,6 +221,10 @@ func NewAPI(k8sClient kubernetes.Interface, resources ...ApiResource) *API {
 // For testing, call this synchronously.
 func (api *API) Sync(readyCh chan<- struct{}) {
 	api.sharedInformers.Start(nil)
+	api.podSharedInformers.Start(nil)
+	if api.spSharedInformers != nil {
+		api.spSharedInformers.Start(nil)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
@@ -134,6 +261,13 @@ func (api *API) RS() appinformers.ReplicaSetInformer {
 	return api.rs
 }
 
+func (api *API) Job() batchinformers.JobInformer {
+	if api.job == nil {
+		panic("Job informer not configured")
+	}
+	return api.job
+}
+
 func (api *API) Pod() coreinformers.PodInformer {
 	if api.pod == nil {
 		panic("Pod informer not configured")
@@ -148,6 +282,20 @@ func (api *API) RC() coreinformers.ReplicationControllerInformer {
 	return api.rc
 }
 
+func (api *API) SA() coreinformers.ServiceAccountInformer {
+	if api.sa == nil {
+		panic("SA informer not configured")
+	}
+	return api.sa
+}
+
+func (api *API) SP() spinformers.ServiceProfileInformer {
+	if api.sp == nil {
+		panic("SP informer not configured")
+	}
+	return api.sp
+}
+
 func (api *API) Svc() coreinformers.ServiceInformer {
 	if api.svc == nil {
 		panic("Svc informer not configured")
@@ -178,18 +326,48 @@ func (api *API) GetObjects(namespace, restype, name string) ([]runtime.Object, e
 		return api.getNamespaces(name)
 	case k8s.Deployment:
 		return api.getDeployments(namespace, name)
+	case k8s.Job:
+		return api.getJobs(namespace, name)
 	case k8s.Pod:
 		return api.getPods(namespace, name)
 	case k8s.ReplicationController:
 		return api.getRCs(namespace, name)
 	case k8s.Service:
 		return api.getServices(namespace, name)
+	case k8s.ServiceAccount:
+		return api.getServiceAccounts(namespace, name)
 	default:
 		// TODO: ReplicaSet
 		return nil, status.Errorf(codes.Unimplemented, "unimplemented resource type: %s", restype)
 	}
 }
 
+// GetLatestJobFor returns the most recently created Job of the CronJob with the
+// given namespace and name, which may be running or have completed. It returns
+// an error if the CronJob has no Jobs.
+func (api *API) GetLatestJobFor(namespace, cronJobName string) (*batchv1.Job, error) {
+	jobs, err := api.Job().Lister().Jobs(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *batchv1.Job
+	for _, job := range jobs {
+		owner := metav1.GetControllerOf(job)
+		if owner == nil || owner.Kind != "CronJob" || owner.Name != cronJobName {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&job.CreationTimestamp) {
+			latest = job
+		}
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("no Jobs found for CronJob %s/%s", namespace, cronJobName)
+	}
+	return latest, nil
+}
+
 // GetOwnerKindAndName returns the pod owner's kind and name, using owner
 // references from the Kubernetes API. The kind is represented as the Kubernetes
 // singular resource type (e.g. deployment, daemonset, job, etc.)
@@ -232,6 +410,10 @@ func (api *API) GetPodsFor(obj runtime.Object, includeFailed bool) ([]*apiv1.Pod
 		namespace = typed.Namespace
 		selector = labels.Set(typed.Spec.Selector.MatchLabels).AsSelector()
 
+	case *batchv1.Job:
+		namespace = typed.Namespace
+		selector = labels.Set(typed.Spec.Selector.MatchLabels).AsSelector()
+
 	case *apiv1.ReplicationController:
 		namespace = typed.Namespace
 		selector = labels.Set(typed.Spec.Selector).AsSelector()
@@ -240,6 +422,23 @@ func (api *API) GetPodsFor(obj runtime.Object, includeFailed bool) ([]*apiv1.Pod
 		namespace = typed.Namespace
 		selector = labels.Set(typed.Spec.Selector).AsSelector()
 
+	case *apiv1.ServiceAccount:
+		// Special case for service accounts:
+		// a service account's pods are the ones that run as it
+		namespace = typed.Namespace
+		nsPods, err := api.Pod().Lister().Pods(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range nsPods {
+			if k8s.GetServiceAccountName(&pod.Spec) == typed.Name {
+				pods = append(pods, pod)
+			}
+		}
+		if len(pods) == 0 {
+			return []*apiv1.Pod{}, nil
+		}
+
 	case *apiv1.Pod:
 		// Special case for pods:
 		// GetPodsFor a pod should just return the pod itself
@@ -323,6 +522,32 @@ func (api *API) getDeployments(namespace, name string) ([]runtime.Object, error)
 	return objects, nil
 }
 
+func (api *API) getJobs(namespace, name string) ([]runtime.Object, error) {
+	var err error
+	var jobs []*batchv1.Job
+
+	if namespace == "" {
+		jobs, err = api.Job().Lister().List(labels.Everything())
+	} else if name == "" {
+		jobs, err = api.Job().Lister().Jobs(namespace).List(labels.Everything())
+	} else {
+		var job *batchv1.Job
+		job, err = api.Job().Lister().Jobs(namespace).Get(name)
+		jobs = []*batchv1.Job{job}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []runtime.Object{}
+	for _, job := range jobs {
+		objects = append(objects, job)
+	}
+
+	return objects, nil
+}
+
 func (api *API) getPods(namespace, name string) ([]runtime.Object, error) {
 	var err error
 	var pods []*apiv1.Pod
@@ -404,6 +629,32 @@ func (api *API) getServices(namespace, name string) ([]runtime.Object, error) {
 	return objects, nil
 }
 
+func (api *API) getServiceAccounts(namespace, name string) ([]runtime.Object, error) {
+	var err error
+	var serviceAccounts []*apiv1.ServiceAccount
+
+	if namespace == "" {
+		serviceAccounts, err = api.SA().Lister().List(labels.Everything())
+	} else if name == "" {
+		serviceAccounts, err = api.SA().Lister().ServiceAccounts(namespace).List(labels.Everything())
+	} else {
+		var sa *apiv1.ServiceAccount
+		sa, err = api.SA().Lister().ServiceAccounts(namespace).Get(name)
+		serviceAccounts = []*apiv1.ServiceAccount{sa}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []runtime.Object{}
+	for _, sa := range serviceAccounts {
+		objects = append(objects, sa)
+	}
+
+	return objects, nil
+}
+
 func isPendingOrRunning(pod *apiv1.Pod) bool {
 	pending := pod.Status.Phase == apiv1.PodPending
 	running := pod.Status.Phase == apiv1.PodRunning
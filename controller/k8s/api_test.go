@@ -4,13 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 )
 
 func newAPI(resourceConfigs []string, extraConfigs ...string) (*API, []runtime.Object, error) {
@@ -377,6 +383,44 @@ metadata:
   namespace: emojivoto
   labels:
     app: emoji-svc
+status:
+  phase: Running`,
+				},
+			},
+			getPodsForExpected{
+				err: nil,
+				k8sResInput: `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: emoji
+  namespace: emojivoto`,
+				k8sResResults: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-emoji
+  namespace: emojivoto
+spec:
+  serviceAccountName: emoji
+status:
+  phase: Running`,
+				},
+				k8sResMisc: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-default
+  namespace: emojivoto
+status:
+  phase: Running`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-emoji
+  namespace: other
+spec:
+  serviceAccountName: emoji
 status:
   phase: Running`,
 				},
@@ -513,3 +557,168 @@ metadata:
 		}
 	}
 }
+
+func TestServiceProfileInformer(t *testing.T) {
+	t.Run("Lists ServiceProfiles from the shared informer", func(t *testing.T) {
+		api, objs, err := newAPI([]string{`
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: books.default.svc.cluster.local
+  namespace: linkerd
+spec:
+  routes:
+  - name: GET /books
+    condition:
+      all:
+      - method: GET
+      - pathRegex: /books
+`,
+		})
+		if err != nil {
+			t.Fatalf("newAPI error: %s", err)
+		}
+
+		profile, err := api.SP().Lister().ServiceProfiles("linkerd").Get("books.default.svc.cluster.local")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if !reflect.DeepEqual(profile, objs[0]) {
+			t.Fatalf("Expected: %+v, Got: %+v", objs[0], profile)
+		}
+	})
+}
+
+// eventCounter counts the events delivered to an informer event handler.
+type eventCounter struct {
+	sync.Mutex
+	events map[string]map[string]int
+}
+
+func (c *eventCounter) handler() cache.ResourceEventHandler {
+	count := func(event string, obj interface{}) {
+		c.Lock()
+		defer c.Unlock()
+		name := obj.(*apiv1.Pod).Name
+		if c.events[event] == nil {
+			c.events[event] = map[string]int{}
+		}
+		c.events[event][name]++
+	}
+
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) { count(addEvent, obj) },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			count(updateEventType(oldObj, newObj), newObj)
+		},
+		DeleteFunc: func(obj interface{}) { count(deleteEvent, obj) },
+	}
+}
+
+// objects returns the number of objects that received event.
+func (c *eventCounter) objects(event string) int {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.events[event])
+}
+
+func (c *eventCounter) received(event, name string) bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.events[event][name] > 0
+}
+
+func TestNewAPIWithConfig(t *testing.T) {
+	// A cluster where only a few of the pods are meshed.
+	const podCount, meshedPodCount = 50, 5
+	newClientset := func() *fake.Clientset {
+		pods := []runtime.Object{}
+		for i := 0; i < podCount; i++ {
+			pod := &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            fmt.Sprintf("pod-%d", i),
+					Namespace:       "emojivoto",
+					ResourceVersion: "1",
+				},
+			}
+			if i < meshedPodCount {
+				pod.Labels = map[string]string{k8s.ControllerNSLabel: "linkerd"}
+			}
+			pods = append(pods, pod)
+		}
+		return fake.NewSimpleClientset(pods...)
+	}
+
+	// Informers resync their handlers at most once a second.
+	const resyncPeriod = time.Second
+
+	newSyncedAPI := func(config InformerConfig) (*API, *eventCounter) {
+		api := NewAPIWithConfig(newClientset(), nil, config, Pod)
+		counter := &eventCounter{events: map[string]map[string]int{}}
+		api.Pod().Informer().AddEventHandler(counter.handler())
+		api.Sync(nil)
+		return api, counter
+	}
+
+	// Handlers receive events asynchronously, even once the API is synced.
+	waitForEvents := func(counter *eventCounter, event string, expected int) {
+		deadline := time.Now().Add(5 * resyncPeriod)
+		for counter.objects(event) < expected {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected %d pods to receive %s events, got %d", expected, event, counter.objects(event))
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	t.Run("Resyncs every pod", func(t *testing.T) {
+		_, counter := newSyncedAPI(InformerConfig{ResyncPeriod: resyncPeriod})
+
+		waitForEvents(counter, addEvent, podCount)
+		waitForEvents(counter, resyncEvent, podCount)
+	})
+
+	t.Run("Doesn't resync when resyncs are disabled", func(t *testing.T) {
+		_, counter := newSyncedAPI(InformerConfig{ResyncPeriod: 0})
+
+		waitForEvents(counter, addEvent, podCount)
+		time.Sleep(resyncPeriod + resyncPeriod/2)
+		if counter.objects(resyncEvent) != 0 {
+			t.Fatalf("Expected no pods to be resynced, got %d", counter.objects(resyncEvent))
+		}
+	})
+
+	t.Run("Only lists and resyncs meshed pods", func(t *testing.T) {
+		api, counter := newSyncedAPI(InformerConfig{ResyncPeriod: resyncPeriod, MeshedPodsOnly: true})
+
+		pods, err := api.Pod().Lister().List(labels.Everything())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(pods) != meshedPodCount {
+			t.Fatalf("Expected %d pods to be cached, got %d", meshedPodCount, len(pods))
+		}
+
+		waitForEvents(counter, resyncEvent, meshedPodCount)
+		for i := meshedPodCount; i < podCount; i++ {
+			name := fmt.Sprintf("pod-%d", i)
+			if counter.received(addEvent, name) || counter.received(resyncEvent, name) {
+				t.Fatalf("Expected no events for unmeshed pod %s", name)
+			}
+		}
+	})
+}
+
+func TestUpdateEventType(t *testing.T) {
+	pod := func(resourceVersion string) *apiv1.Pod {
+		return &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", ResourceVersion: resourceVersion}}
+	}
+
+	if eventType := updateEventType(pod("1"), pod("1")); eventType != resyncEvent {
+		t.Fatalf("Expected an update to the same resource version to be a %s, got %s", resyncEvent, eventType)
+	}
+	if eventType := updateEventType(pod("1"), pod("2")); eventType != updateEvent {
+		t.Fatalf("Expected an update to a new resource version to be an %s, got %s", updateEvent, eventType)
+	}
+}
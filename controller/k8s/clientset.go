@@ -1,6 +1,7 @@
 package k8s
 
 import (
+	spclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -9,20 +10,31 @@ import (
 )
 
 func NewClientSet(kubeConfig string) (*kubernetes.Clientset, error) {
-	var config *rest.Config
-	var err error
+	config, err := getConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(config)
+}
+
+// NewSpClientSet returns a client for the ServiceProfile custom resource.
+func NewSpClientSet(kubeConfig string) (*spclient.Clientset, error) {
+	config, err := getConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return spclient.NewForConfig(config)
+}
 
+func getConfig(kubeConfig string) (*rest.Config, error) {
 	if kubeConfig == "" {
 		// configure client while running inside the k8s cluster
 		// uses Service Acct token mounted in the Pod
-		config, err = rest.InClusterConfig()
-	} else {
-		// configure access to the cluster from outside
-		config, err = clientcmd.BuildConfigFromFlags("", kubeConfig)
-	}
-	if err != nil {
-		return nil, err
+		return rest.InClusterConfig()
 	}
 
-	return kubernetes.NewForConfig(config)
+	// configure access to the cluster from outside
+	return clientcmd.BuildConfigFromFlags("", kubeConfig)
 }
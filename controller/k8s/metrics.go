@@ -0,0 +1,191 @@
+package k8s
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// The types of informer events, as labeled in the informer metrics. A resync
+// event is an update event that replays an unchanged object from the
+// informer's cache, and that the apiserver didn't send.
+const (
+	addEvent    = "add"
+	updateEvent = "update"
+	resyncEvent = "resync"
+	deleteEvent = "delete"
+)
+
+var (
+	informerEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "informer_events_total",
+			Help: "A counter for the events delivered by the Kubernetes informers.",
+		},
+		[]string{"resource", "event"},
+	)
+
+	informerObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "informer_objects",
+			Help: "A gauge for the number of objects cached by the Kubernetes informers.",
+		},
+		[]string{"resource"},
+	)
+
+	informerHandlerDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "informer_handler_duration_seconds",
+			Help:    "A histogram of the time taken by informer event handlers in seconds.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 8),
+		},
+		[]string{"resource", "event"},
+	)
+
+	workqueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "workqueue_depth",
+			Help: "A gauge for the number of items waiting in a work queue.",
+		},
+		[]string{"name"},
+	)
+
+	workqueueAdds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "workqueue_adds_total",
+			Help: "A counter for the items added to a work queue.",
+		},
+		[]string{"name"},
+	)
+
+	workqueueLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "workqueue_queue_latency_microseconds",
+			Help: "A summary of how long items wait in a work queue before being processed.",
+		},
+		[]string{"name"},
+	)
+
+	workqueueWorkDuration = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "workqueue_work_duration_microseconds",
+			Help: "A summary of how long processing an item from a work queue takes.",
+		},
+		[]string{"name"},
+	)
+
+	workqueueRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "workqueue_retries_total",
+			Help: "A counter for the items requeued to a work queue to be retried.",
+		},
+		[]string{"name"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		informerEvents,
+		informerObjects,
+		informerHandlerDuration,
+		workqueueDepth,
+		workqueueAdds,
+		workqueueLatency,
+		workqueueWorkDuration,
+		workqueueRetries,
+	)
+
+	// Queues only pick their metrics up from the provider that's set when
+	// they are created.
+	workqueue.SetProvider(workqueueMetricsProvider{})
+}
+
+// instrumentInformer counts the events that informer delivers, and the
+// objects that it caches, in the metrics of resource.
+func instrumentInformer(resource ApiResource, informer cache.SharedIndexInformer) {
+	name := resource.String()
+	informer.AddEventHandler(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				informerEvents.WithLabelValues(name, addEvent).Inc()
+				informerObjects.WithLabelValues(name).Inc()
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				informerEvents.WithLabelValues(name, updateEventType(oldObj, newObj)).Inc()
+			},
+			DeleteFunc: func(obj interface{}) {
+				informerEvents.WithLabelValues(name, deleteEvent).Inc()
+				informerObjects.WithLabelValues(name).Dec()
+			},
+		},
+	)
+}
+
+// InstrumentHandler returns an event handler for the informer of resource,
+// which calls handler and records how long each of its calls takes.
+func InstrumentHandler(resource ApiResource, handler cache.ResourceEventHandler) cache.ResourceEventHandler {
+	name := resource.String()
+	observe := func(event string, start time.Time) {
+		informerHandlerDuration.WithLabelValues(name, event).Observe(time.Since(start).Seconds())
+	}
+
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			defer observe(addEvent, time.Now())
+			handler.OnAdd(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			defer observe(updateEventType(oldObj, newObj), time.Now())
+			handler.OnUpdate(oldObj, newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			defer observe(deleteEvent, time.Now())
+			handler.OnDelete(obj)
+		},
+	}
+}
+
+// updateEventType returns resyncEvent if an update event from oldObj to
+// newObj is a resync, whose objects have the same resource version, and
+// updateEvent otherwise.
+func updateEventType(oldObj, newObj interface{}) string {
+	oldMeta, err := meta.Accessor(oldObj)
+	if err != nil {
+		return updateEvent
+	}
+	newMeta, err := meta.Accessor(newObj)
+	if err != nil {
+		return updateEvent
+	}
+	if oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() {
+		return resyncEvent
+	}
+	return updateEvent
+}
+
+// workqueueMetricsProvider exports the metrics of the work queues created with
+// a name, such as the CA controller's, labeled with their name.
+type workqueueMetricsProvider struct{}
+
+func (workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return workqueueDepth.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return workqueueAdds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.SummaryMetric {
+	return workqueueLatency.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.SummaryMetric {
+	return workqueueWorkDuration.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return workqueueRetries.WithLabelValues(name)
+}
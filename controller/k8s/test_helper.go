@@ -1,11 +1,18 @@
 package k8s
 
 import (
+	spv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	spfake "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/fake"
+	spscheme "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/scheme"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 )
 
+func init() {
+	spscheme.AddToScheme(scheme.Scheme)
+}
+
 func toRuntimeObject(config string) (runtime.Object, error) {
 	decode := scheme.Codecs.UniversalDeserializer().Decode
 	obj, _, err := decode([]byte(config), nil, nil)
@@ -14,24 +21,34 @@ func toRuntimeObject(config string) (runtime.Object, error) {
 
 func NewFakeAPI(configs ...string) (*API, error) {
 	objs := []runtime.Object{}
+	spObjs := []runtime.Object{}
 	for _, config := range configs {
 		obj, err := toRuntimeObject(config)
 		if err != nil {
 			return nil, err
 		}
-		objs = append(objs, obj)
+		if _, ok := obj.(*spv1alpha1.ServiceProfile); ok {
+			spObjs = append(spObjs, obj)
+		} else {
+			objs = append(objs, obj)
+		}
 	}
 
 	clientSet := fake.NewSimpleClientset(objs...)
+	spClientSet := spfake.NewSimpleClientset(spObjs...)
 	return NewAPI(
 		clientSet,
+		spClientSet,
 		CM,
 		Deploy,
 		Endpoint,
+		Job,
 		NS,
 		Pod,
 		RC,
 		RS,
+		SA,
+		SP,
 		Svc,
 	), nil
 }
@@ -0,0 +1,113 @@
+package spvalidator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	"github.com/linkerd/linkerd2/pkg/tls"
+	log "github.com/sirupsen/logrus"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxReviewBytes bounds the size of the AdmissionReviews that the webhook
+// reads. The API server limits objects to a few megabytes.
+const maxReviewBytes = 8 << 20
+
+// NewServer returns an HTTPS server that serves the ServiceProfile validating
+// admission webhook on addr, with the given certificate and key.
+func NewServer(addr, certFile, keyFile string) (*http.Server, error) {
+	tlsConfig, err := tls.NewServerTLSConfig(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", NewHandler())
+
+	return &http.Server{Addr: addr, Handler: mux, TLSConfig: tlsConfig}, nil
+}
+
+// NewHandler returns a handler that reads an AdmissionReview of a
+// ServiceProfile and writes it back with a response that admits the profile
+// only if it is valid.
+func NewHandler() http.Handler {
+	return http.HandlerFunc(serveReview)
+}
+
+func serveReview(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, maxReviewBytes))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read request body: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var review admissionv1beta1.AdmissionReview
+	if err := json.Unmarshal(body, &review); err != nil {
+		http.Error(w, fmt.Sprintf("failed to parse AdmissionReview: %s", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "AdmissionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = Review(review.Request)
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&review); err != nil {
+		log.Errorf("failed to write AdmissionReview response: %s", err)
+	}
+}
+
+// Review validates the ServiceProfile in an admission request. Profiles with
+// the SkipProfileValidationAnnotation set to "true" are admitted without being
+// validated.
+func Review(req *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	rsp := &admissionv1beta1.AdmissionResponse{UID: req.UID, Allowed: true}
+
+	// Only the metadata is decoded up front, so that profiles whose spec
+	// can't even be parsed can still skip validation.
+	var profile struct {
+		metav1.ObjectMeta `json:"metadata"`
+	}
+	if err := json.Unmarshal(req.Object.Raw, &profile); err != nil {
+		return deny(rsp, fmt.Sprintf("failed to parse ServiceProfile: %s", err))
+	}
+
+	if profile.Annotations[pkgK8s.SkipProfileValidationAnnotation] == "true" {
+		log.Warnf("admitting ServiceProfile %s/%s without validation, as it is annotated with %s",
+			req.Namespace, profile.Name, pkgK8s.SkipProfileValidationAnnotation)
+		return rsp
+	}
+
+	// The object is JSON, which ValidateYAML accepts since YAML is a superset
+	// of it; unlike Validate, it also rejects unknown fields.
+	if err := profiles.ValidateYAML(req.Object.Raw); err != nil {
+		log.Infof("rejecting ServiceProfile %s/%s: %s", req.Namespace, profile.Name, err)
+		return deny(rsp, fmt.Sprintf("ServiceProfile %q is invalid: %s", profile.Name, err))
+	}
+
+	return rsp
+}
+
+func deny(rsp *admissionv1beta1.AdmissionResponse, message string) *admissionv1beta1.AdmissionResponse {
+	rsp.Allowed = false
+	rsp.Result = &metav1.Status{
+		Status:  metav1.StatusFailure,
+		Reason:  metav1.StatusReasonInvalid,
+		Code:    http.StatusUnprocessableEntity,
+		Message: message,
+	}
+	return rsp
+}
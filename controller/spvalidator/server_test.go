@@ -0,0 +1,197 @@
+package spvalidator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// The handler is tested against the same corpus of profiles as the profiles
+// package, where each invalid profile starts with a comment containing a
+// substring of the expected error.
+const (
+	validProfiles   = "../../pkg/profiles/testdata/valid/*.yaml"
+	invalidProfiles = "../../pkg/profiles/testdata/invalid/*.yaml"
+	errorPrefix     = "# error:"
+)
+
+func readProfiles(t *testing.T, pattern string) map[string][]byte {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("No profiles found matching %s", pattern)
+	}
+
+	profiles := make(map[string][]byte)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		profiles[file] = data
+	}
+	return profiles
+}
+
+// postReview sends an AdmissionReview of the YAML profile to the handler,
+// the way the API server would, and returns the response.
+func postReview(t *testing.T, server *httptest.Server, profile []byte) *admissionv1beta1.AdmissionResponse {
+	object, err := yaml.YAMLToJSON(profile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	review := admissionv1beta1.AdmissionReview{
+		Request: &admissionv1beta1.AdmissionRequest{
+			UID:       "review-uid",
+			Namespace: "linkerd",
+			Operation: admissionv1beta1.Create,
+			Object:    runtime.RawExtension{Raw: object},
+		},
+	}
+	body, err := json.Marshal(&review)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rsp, err := http.Post(server.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, rsp.StatusCode)
+	}
+
+	var result admissionv1beta1.AdmissionReview
+	if err := json.NewDecoder(rsp.Body).Decode(&result); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Response == nil {
+		t.Fatal("Expected AdmissionReview to have a response")
+	}
+	if result.Response.UID != "review-uid" {
+		t.Fatalf("Expected response UID [review-uid], got [%s]", result.Response.UID)
+	}
+	return result.Response
+}
+
+func TestHandler(t *testing.T) {
+	server := httptest.NewServer(NewHandler())
+	defer server.Close()
+
+	t.Run("Admits valid profiles", func(t *testing.T) {
+		for file, profile := range readProfiles(t, validProfiles) {
+			rsp := postReview(t, server, profile)
+			if !rsp.Allowed {
+				t.Errorf("%s: expected profile to be admitted, got: %+v", file, rsp.Result)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid profiles with the reason", func(t *testing.T) {
+		for file, profile := range readProfiles(t, invalidProfiles) {
+			line, err := bufio.NewReader(bytes.NewReader(profile)).ReadString('\n')
+			if err != nil || !strings.HasPrefix(line, errorPrefix) {
+				t.Fatalf("%s: expected first line to start with %q", file, errorPrefix)
+			}
+			expected := strings.TrimSpace(strings.TrimPrefix(line, errorPrefix))
+
+			rsp := postReview(t, server, profile)
+			if rsp.Allowed {
+				t.Errorf("%s: expected profile to be rejected", file)
+				continue
+			}
+			if rsp.Result == nil || !strings.Contains(rsp.Result.Message, expected) {
+				t.Errorf("%s: expected message containing %q, got: %+v", file, expected, rsp.Result)
+			}
+		}
+	})
+
+	t.Run("Admits invalid profiles annotated to skip validation", func(t *testing.T) {
+		for file, profile := range readProfiles(t, invalidProfiles) {
+			var obj map[string]interface{}
+			if err := yaml.Unmarshal(profile, &obj); err != nil {
+				t.Fatalf("%s: unexpected error: %v", file, err)
+			}
+			metadata, ok := obj["metadata"].(map[string]interface{})
+			if !ok {
+				t.Fatalf("%s: expected profile to have metadata", file)
+			}
+			metadata["annotations"] = map[string]string{pkgK8s.SkipProfileValidationAnnotation: "true"}
+			annotated, err := yaml.Marshal(obj)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", file, err)
+			}
+
+			rsp := postReview(t, server, annotated)
+			if !rsp.Allowed {
+				t.Errorf("%s: expected annotated profile to be admitted, got: %+v", file, rsp.Result)
+			}
+		}
+	})
+
+	t.Run("Validates profiles annotated with other values", func(t *testing.T) {
+		profile := []byte(`apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: books.default.svc.cluster.local
+  namespace: linkerd
+  annotations:
+    linkerd.io/skip-profile-validation: "false"
+spec:
+  routes: []
+`)
+
+		rsp := postReview(t, server, profile)
+		if rsp.Allowed {
+			t.Fatal("Expected profile to be rejected")
+		}
+		expected := `ServiceProfile "books.default.svc.cluster.local" is invalid: profile must have at least one route`
+		if rsp.Result.Message != expected {
+			t.Fatalf("Expected message %q, got %q", expected, rsp.Result.Message)
+		}
+	})
+
+	t.Run("Returns an error for malformed requests", func(t *testing.T) {
+		testCases := []struct {
+			method string
+			body   string
+			status int
+		}{
+			{http.MethodGet, "", http.StatusMethodNotAllowed},
+			{http.MethodPost, "not json", http.StatusBadRequest},
+			{http.MethodPost, "{}", http.StatusBadRequest},
+		}
+
+		for _, tc := range testCases {
+			req, err := http.NewRequest(tc.method, server.URL, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			rsp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			rsp.Body.Close()
+
+			if rsp.StatusCode != tc.status {
+				t.Errorf("%s %q: expected status %d, got %d", tc.method, tc.body, tc.status, rsp.StatusCode)
+			}
+		}
+	})
+}
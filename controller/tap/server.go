@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	netpb "github.com/linkerd/linkerd2-proxy-api/go/net"
@@ -19,6 +20,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	apiv1 "k8s.io/api/core/v1"
 )
@@ -26,6 +28,8 @@ import (
 type (
 	server struct {
 		tapPort uint
+		podName string
+		podIP   string
 		k8sAPI  *k8s.API
 	}
 )
@@ -34,6 +38,15 @@ var (
 	tapInterval = 10 * time.Second
 )
 
+const (
+	// defaultTapBufferSize is the number of events that are buffered while
+	// they are sent back, if the request does not set a buffer size.
+	defaultTapBufferSize = 1000
+
+	// maxTapBufferSize bounds the memory that a single tap request can use.
+	maxTapBufferSize = 100000
+)
+
 func (s *server) Tap(req *public.TapRequest, stream pb.Tap_TapServer) error {
 	return status.Error(codes.Unimplemented, "Tap is deprecated, use TapByResource")
 }
@@ -65,14 +78,21 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 		return status.Errorf(codes.NotFound, "no pods found for ResourceSelection: %+v", *req.Target)
 	}
 
-	log.Infof("Tapping %d pods for target: %+v", len(pods), *req.Target.Resource)
+	bufferSize := req.BufferSize
+	if bufferSize == 0 {
+		bufferSize = defaultTapBufferSize
+	}
+	if bufferSize > maxTapBufferSize {
+		return status.Errorf(codes.InvalidArgument, "buffer size must be at most %d, was %d", maxTapBufferSize, bufferSize)
+	}
 
-	events := make(chan *public.TapEvent)
+	log.Infof("Tapping %d pods for target: %+v", len(pods), *req.Target.Resource)
 
-	go func() { // Stop sending back events if the request is cancelled
-		<-stream.Context().Done()
-		close(events)
-	}()
+	// The taps drop events rather than wait for room in the buffer, so that a
+	// slow client never holds up the proxies. The number of dropped events is
+	// sent to the client with the next event.
+	events := make(chan *public.TapEvent, bufferSize)
+	var dropped uint64
 
 	// divide the rps evenly between all pods to tap
 	rpsPerPod := req.MaxRps / float32(len(pods))
@@ -85,19 +105,36 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 		return apiUtil.GRPCError(err)
 	}
 
+	// let the client know which tap server it's connected to before the first
+	// event, which can take a while to arrive
+	err = stream.SendHeader(metadata.Pairs(
+		apiUtil.TapServerPodHeader, s.podName,
+		apiUtil.TapServerIPHeader, s.podIP,
+		apiUtil.TapServerProtocolHeader, "h2c",
+	))
+	if err != nil {
+		return apiUtil.GRPCError(err)
+	}
+
 	for _, pod := range pods {
 		// initiate a tap on the pod
-		go s.tapProxy(stream.Context(), rpsPerPod, match, pod.Status.PodIP, events)
+		go s.tapProxy(stream.Context(), rpsPerPod, match, pod.Status.PodIP, events, &dropped)
 	}
 
-	// read events from the taps and send them back
-	for event := range events {
-		err := stream.Send(event)
-		if err != nil {
-			return apiUtil.GRPCError(err)
+	// read events from the taps and send them back, until the request is
+	// cancelled
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			event.DroppedEvents = atomic.SwapUint64(&dropped, 0)
+			err := stream.Send(event)
+			if err != nil {
+				return apiUtil.GRPCError(err)
+			}
 		}
 	}
-	return nil
 }
 
 // TODO: validate scheme
@@ -239,7 +276,9 @@ func destinationLabels(resource *public.Resource) map[string]string {
 // of maxRps * 10s at most once per 10s window.  If this limit is reached in
 // less than 10s, we sleep until the end of the window before calling Observe
 // again.
-func (s *server) tapProxy(ctx context.Context, maxRps float32, match *proxy.ObserveRequest_Match, addr string, events chan *public.TapEvent) {
+// Events are dropped, rather than waited on, while the events buffer is full,
+// and counted in dropped.
+func (s *server) tapProxy(ctx context.Context, maxRps float32, match *proxy.ObserveRequest_Match, addr string, events chan<- *public.TapEvent, dropped *uint64) {
 	tapAddr := fmt.Sprintf("%s:%d", addr, s.tapPort)
 	log.Infof("Establishing tap on %s", tapAddr)
 	conn, err := grpc.DialContext(ctx, tapAddr, grpc.WithInsecure())
@@ -257,28 +296,50 @@ func (s *server) tapProxy(ctx context.Context, maxRps float32, match *proxy.Obse
 	for { // Request loop
 		windowStart := time.Now()
 		windowEnd := windowStart.Add(tapInterval)
-		rsp, err := client.Observe(ctx, req)
-		if err != nil {
+		if err := observe(ctx, client, req, tapAddr, events, dropped); err != nil {
 			log.Error(err)
 			return
 		}
-		for { // Stream loop
-			event, err := rsp.Recv()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				log.Error(err)
-				return
-			}
-			events <- translateEvent(event)
-		}
 		if time.Now().Before(windowEnd) {
 			time.Sleep(time.Until(windowEnd))
 		}
 	}
 }
 
+// observe sends the events of a single Observe call to the proxy at tapAddr to
+// events, until the proxy ends the call. Events are dropped while the events
+// buffer is full, and counted in dropped; they're logged however the call
+// ends.
+func observe(ctx context.Context, client proxy.TapClient, req *proxy.ObserveRequest, tapAddr string, events chan<- *public.TapEvent, dropped *uint64) error {
+	rsp, err := client.Observe(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	observeDropped := 0
+	defer func() {
+		if observeDropped > 0 {
+			log.Warnf("Dropped %d events from %s because the tap buffer was full", observeDropped, tapAddr)
+		}
+	}()
+
+	for { // Stream loop
+		event, err := rsp.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		select {
+		case events <- translateEvent(event):
+		default:
+			observeDropped++
+			atomic.AddUint64(dropped, 1)
+		}
+	}
+}
+
 func translateEvent(orig *proxy.TapEvent) *public.TapEvent {
 	direction := func(orig proxy.TapEvent_ProxyDirection) public.TapEvent_ProxyDirection {
 		switch orig {
@@ -452,6 +513,8 @@ func translateEvent(orig *proxy.TapEvent) *public.TapEvent {
 func NewServer(
 	addr string,
 	tapPort uint,
+	podName string,
+	podIP string,
 	k8sAPI *k8s.API,
 ) (*grpc.Server, net.Listener, error) {
 
@@ -463,6 +526,8 @@ func NewServer(
 	s := prometheus.NewGrpcServer()
 	srv := server{
 		tapPort: tapPort,
+		podName: podName,
+		podIP:   podIP,
 		k8sAPI:  k8sAPI,
 	}
 	pb.RegisterTapServer(s, &srv)
@@ -2,12 +2,17 @@ package tap
 
 import (
 	"context"
+	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	proxy "github.com/linkerd/linkerd2-proxy-api/go/tap"
+	apiUtil "github.com/linkerd/linkerd2/controller/api/util"
 	public "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"google.golang.org/grpc"
 )
 
 type tapExpected struct {
@@ -21,7 +26,7 @@ func TestTapByResource(t *testing.T) {
 	t.Run("Returns expected response", func(t *testing.T) {
 		expectations := []tapExpected{
 			tapExpected{
-				msg:    "rpc error: code = InvalidArgument desc = TapByResource received nil target ResourceSelection: {Target:<nil> Match:<nil> MaxRps:0}",
+				msg:    "rpc error: code = InvalidArgument desc = TapByResource received nil target ResourceSelection: {Target:<nil> Match:<nil> MaxRps:0 BufferSize:0}",
 				k8sRes: []string{},
 				req:    public.TapByResourceRequest{},
 			},
@@ -121,6 +126,33 @@ status:
 					},
 				},
 			},
+			tapExpected{
+				msg: "rpc error: code = InvalidArgument desc = buffer size must be at most 100000, was 100001",
+				k8sRes: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+  annotations:
+    linkerd.io/proxy-version: testinjectversion
+status:
+  phase: Running
+`,
+				},
+				req: public.TapByResourceRequest{
+					Target: &public.ResourceSelection{
+						Resource: &public.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+							Name:      "emojivoto-meshed",
+						},
+					},
+					BufferSize: maxTapBufferSize + 1,
+				},
+			},
 			tapExpected{
 				// indicates we will accept EOF, in addition to the deadline exceeded message
 				eofOk: true,
@@ -163,7 +195,7 @@ status:
 				t.Fatalf("NewFakeAPI returned an error: %s", err)
 			}
 
-			server, listener, err := NewServer("localhost:0", 0, k8sAPI)
+			server, listener, err := NewServer("localhost:0", 0, "tap-pod", "10.1.2.3", k8sAPI)
 			if err != nil {
 				t.Fatalf("NewServer error: %s", err)
 			}
@@ -196,3 +228,143 @@ status:
 		}
 	})
 }
+
+func TestTapByResourceHeader(t *testing.T) {
+	t.Run("Sends the tap server's pod and protocol when the session begins", func(t *testing.T) {
+		k8sAPI, err := k8s.NewFakeAPI(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  annotations:
+    linkerd.io/proxy-version: testinjectversion
+status:
+  phase: Running
+`)
+		if err != nil {
+			t.Fatalf("NewFakeAPI returned an error: %s", err)
+		}
+
+		server, listener, err := NewServer("localhost:0", 0, "tap-pod", "10.1.2.3", k8sAPI)
+		if err != nil {
+			t.Fatalf("NewServer error: %s", err)
+		}
+
+		go func() { server.Serve(listener) }()
+		defer server.GracefulStop()
+
+		k8sAPI.Sync(nil)
+
+		client, conn, err := NewClient(listener.Addr().String())
+		if err != nil {
+			t.Fatalf("NewClient error: %v", err)
+		}
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		tapClient, err := client.TapByResource(ctx, &public.TapByResourceRequest{
+			Target: &public.ResourceSelection{
+				Resource: &public.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Pod,
+					Name:      "emojivoto-meshed",
+				},
+			},
+			Match: &public.TapByResourceRequest_Match{
+				Match: &public.TapByResourceRequest_Match_All{
+					All: &public.TapByResourceRequest_Match_Seq{},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("TapByResource failed: %v", err)
+		}
+
+		// no events are sent, so the header must arrive on its own
+		header, err := tapClient.Header()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := map[string]string{
+			apiUtil.TapServerPodHeader:      "tap-pod",
+			apiUtil.TapServerIPHeader:       "10.1.2.3",
+			apiUtil.TapServerProtocolHeader: "h2c",
+		}
+		for key, value := range expected {
+			if values := header[key]; len(values) != 1 || values[0] != value {
+				t.Fatalf("Expected header %s to be [%s], got: %v", key, value, values)
+			}
+		}
+	})
+}
+
+// observeServer is a proxy's tap server that sends events on every Observe
+// call, and reports each call on observed.
+type observeServer struct {
+	events   int
+	observed chan struct{}
+}
+
+func (s *observeServer) Observe(req *proxy.ObserveRequest, stream proxy.Tap_ObserveServer) error {
+	for i := 0; i < s.events; i++ {
+		if err := stream.Send(&proxy.TapEvent{}); err != nil {
+			return err
+		}
+	}
+	select {
+	case s.observed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func TestTapProxy(t *testing.T) {
+	t.Run("Drops events without blocking the proxy when the buffer is full", func(t *testing.T) {
+		defaultTapInterval := tapInterval
+		tapInterval = time.Millisecond
+		defer func() { tapInterval = defaultTapInterval }()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		proxyServer := grpc.NewServer()
+		observe := &observeServer{events: 5, observed: make(chan struct{}, 2)}
+		proxy.RegisterTapServer(proxyServer, observe)
+		go proxyServer.Serve(listener)
+		defer proxyServer.Stop()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Nothing reads from the buffer, so all but its first two events are
+		// dropped.
+		events := make(chan *public.TapEvent, 2)
+		var dropped uint64
+		s := &server{tapPort: uint(listener.Addr().(*net.TCPAddr).Port)}
+		go s.tapProxy(ctx, 1000, &proxy.ObserveRequest_Match{}, "127.0.0.1", events, &dropped)
+
+		// The tap only observes the proxy again once it has received all of
+		// the events of the previous observation.
+		for i := 0; i < 2; i++ {
+			select {
+			case <-observe.observed:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Expected the tap to keep observing the proxy while the buffer is full")
+			}
+		}
+
+		if len(events) != cap(events) {
+			t.Fatalf("Expected the buffer to be full, got %d events", len(events))
+		}
+		// At least the first observation's events that didn't fit in the
+		// buffer were counted.
+		if count := atomic.LoadUint64(&dropped); count < 3 {
+			t.Fatalf("Expected at least 3 dropped events, got %d", count)
+		}
+	})
+}
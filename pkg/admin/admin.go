@@ -5,22 +5,35 @@ import (
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 )
 
+// MetricsGroup is a group of metrics that the admin server serves on
+// /metrics/<Name>, separately from the default registry's metrics.
+type MetricsGroup struct {
+	Name     string
+	Gatherer prometheus.Gatherer
+}
+
 type handler struct {
-	promHandler http.Handler
-	ready       bool
+	promHandler   http.Handler
+	groupHandlers map[string]http.Handler
+	ready         bool
 	sync.RWMutex
 }
 
-func StartServer(addr string, readyCh <-chan struct{}) {
+func StartServer(addr string, readyCh <-chan struct{}, groups ...MetricsGroup) {
 	log.Infof("starting admin server on %s", addr)
 
 	h := &handler{
-		promHandler: promhttp.Handler(),
-		ready:       readyCh == nil,
+		promHandler:   promhttp.Handler(),
+		groupHandlers: make(map[string]http.Handler),
+		ready:         readyCh == nil,
+	}
+	for _, group := range groups {
+		h.groupHandlers["/metrics/"+group.Name] = promhttp.HandlerFor(group.Gatherer, promhttp.HandlerOpts{})
 	}
 
 	if readyCh != nil {
@@ -49,6 +62,10 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	case "/ready":
 		h.serveReady(w, req)
 	default:
+		if groupHandler, ok := h.groupHandlers[req.URL.Path]; ok {
+			groupHandler.ServeHTTP(w, req)
+			return
+		}
 		http.NotFound(w, req)
 	}
 }
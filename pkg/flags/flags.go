@@ -9,21 +9,32 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-// ConfigureAndParse adds flags that are common to all go processes, and
+// The formats that the log-format flag accepts. Plain logs are logrus' default
+// text format.
+const (
+	PlainLogFormat = "plain"
+	JSONLogFormat  = "json"
+)
+
+// ConfigureAndParse adds flags that are common to all go processes to cmd, and
 // overrides the default flag for glog logging, which we can't disable. This
-// func calls flag.Parse(), so it should be called after all other flags have
-// been configured.
-func ConfigureAndParse() {
+// func parses args with cmd, so it should be called after all other flags have
+// been configured. Processes with a single set of flags pass flag.CommandLine
+// and os.Args[1:].
+func ConfigureAndParse(cmd *flag.FlagSet, args []string) {
 	// override glog's default configuration
 	flag.Set("logtostderr", "true")
 
-	logLevel := flag.String("log-level", log.InfoLevel.String(),
+	logLevel := cmd.String("log-level", log.InfoLevel.String(),
 		"log level, must be one of: panic, fatal, error, warn, info, debug")
-	printVersion := flag.Bool("version", false, "print version and exit")
+	logFormat := cmd.String("log-format", PlainLogFormat,
+		fmt.Sprintf("log format, must be one of: %s, %s", PlainLogFormat, JSONLogFormat))
+	printVersion := cmd.Bool("version", false, "print version and exit")
 
-	flag.Parse()
+	cmd.Parse(args)
 
 	setLogLevel(*logLevel)
+	setLogFormat(*logFormat)
 	maybePrintVersionAndExit(*printVersion)
 }
 
@@ -35,6 +46,17 @@ func setLogLevel(logLevel string) {
 	log.SetLevel(level)
 }
 
+func setLogFormat(logFormat string) {
+	switch logFormat {
+	case PlainLogFormat:
+		log.SetFormatter(&log.TextFormatter{})
+	case JSONLogFormat:
+		log.SetFormatter(&log.JSONFormatter{})
+	default:
+		log.Fatalf("invalid log-format: %s", logFormat)
+	}
+}
+
 func maybePrintVersionAndExit(printVersion bool) {
 	if printVersion {
 		fmt.Println(version.Version)
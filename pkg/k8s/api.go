@@ -7,10 +7,13 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	spclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/rest"
 	// Load all the auth plugins for the cloud providers.
@@ -18,14 +21,28 @@ import (
 )
 
 const (
-	KubeapiSubsystemName           = "kubernetes-api"
-	KubeapiClientCheckDescription  = "can initialize the client"
-	KubeapiAccessCheckDescription  = "can query the Kubernetes API"
-	KubeapiVersionCheckDescription = "is running the minimum Kubernetes API version"
+	KubeapiSubsystemName             = "kubernetes-api"
+	KubeapiClientCheckDescription    = "can initialize the client"
+	KubeapiAccessCheckDescription    = "can query the Kubernetes API"
+	KubeapiVersionCheckDescription   = "is running the minimum Kubernetes API version"
+	KubeapiResourcesCheckDescription = "has the API resources that Linkerd requires"
 )
 
 var minApiVersion = [3]int{1, 8, 0}
 
+// requiredApiResources are the resources, by group version, that the control
+// plane is installed as and that the controller watches.
+var requiredApiResources = []struct {
+	groupVersion string
+	resources    []string
+}{
+	{"v1", []string{"configmaps", "endpoints", "namespaces", "pods", "replicationcontrollers", "secrets", "serviceaccounts", "services"}},
+	{"apps/v1beta2", []string{"deployments", "replicasets"}},
+	{"batch/v1", []string{"jobs"}},
+	{"extensions/v1beta1", []string{"deployments"}},
+	{"rbac.authorization.k8s.io/v1beta1", []string{"clusterrolebindings", "clusterroles"}},
+}
+
 type KubernetesApi interface {
 	UrlFor(namespace string, extraPathStartingWithSlash string) (*url.URL, error)
 	NewClient() (*http.Client, error)
@@ -61,6 +78,7 @@ func (kubeapi *kubernetesApi) SelfCheck() (checks []*healthcheckPb.CheckResult)
 	}
 
 	checks = append(checks, kubeapi.checkApiVersion(versionRsp))
+	checks = append(checks, kubeapi.checkApiResources(client))
 	return
 }
 
@@ -158,17 +176,99 @@ func (kubeapi *kubernetesApi) checkApiVersion(versionRsp string) *healthcheckPb.
 	return checkResult
 }
 
+func (kubeapi *kubernetesApi) checkApiResources(client *http.Client) *healthcheckPb.CheckResult {
+	checkResult := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    KubeapiSubsystemName,
+		CheckDescription: KubeapiResourcesCheckDescription,
+	}
+
+	missing := make([]string, 0)
+	for _, required := range requiredApiResources {
+		served, err := kubeapi.getApiResources(client, required.groupVersion)
+		if err != nil {
+			checkResult.Status = healthcheckPb.CheckStatus_ERROR
+			checkResult.FriendlyMessageToUser = err.Error()
+			return checkResult
+		}
+
+		for _, resource := range required.resources {
+			if _, ok := served[resource]; !ok {
+				missing = append(missing, fmt.Sprintf("%s/%s", required.groupVersion, resource))
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		checkResult.Status = healthcheckPb.CheckStatus_FAIL
+		checkResult.FriendlyMessageToUser = fmt.Sprintf("Kubernetes does not serve the resources [%s], which Linkerd requires.", strings.Join(missing, ", "))
+		return checkResult
+	}
+
+	return checkResult
+}
+
+// getApiResources returns the names of the resources that the API server
+// serves in groupVersion, which are none if it does not serve groupVersion.
+func (kubeapi *kubernetesApi) getApiResources(client *http.Client, groupVersion string) (map[string]struct{}, error) {
+	path := "/apis/" + groupVersion
+	if groupVersion == "v1" {
+		path = "/api/v1"
+	}
+	endpointToCheck := kubeapi.Host + path
+
+	req, _ := http.NewRequest("GET", endpointToCheck, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("HTTP GET request to endpoint [%s] resulted in error: [%s]", endpointToCheck, err.Error())
+	}
+	defer resp.Body.Close()
+
+	served := make(map[string]struct{})
+	if resp.StatusCode == http.StatusNotFound {
+		return served, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP GET request to endpoint [%s] resulted in Status: [%s]", endpointToCheck, resp.Status)
+	}
+
+	var resourceList metaV1.APIResourceList
+	if err := json.NewDecoder(resp.Body).Decode(&resourceList); err != nil {
+		return nil, fmt.Errorf("Discovery endpoint [%s] returned invalid JSON: [%s]", endpointToCheck, err)
+	}
+	for _, resource := range resourceList.APIResources {
+		served[resource.Name] = struct{}{}
+	}
+	return served, nil
+}
+
 // UrlFor generates a URL based on the Kubernetes config.
 func (kubeapi *kubernetesApi) UrlFor(namespace string, extraPathStartingWithSlash string) (*url.URL, error) {
 	return generateKubernetesApiBaseUrlFor(kubeapi.Host, namespace, extraPathStartingWithSlash)
 }
 
-// NewAPI returns a new KubernetesApi interface
-func NewAPI(configPath string) (KubernetesApi, error) {
-	config, err := getConfig(configPath)
+// NewAPI returns a new KubernetesApi interface, configured with the
+// kubeContext context of the kubeconfig file at configPath. Empty values use
+// the default kubeconfig files and their current context.
+func NewAPI(configPath, kubeContext string) (KubernetesApi, error) {
+	config, err := getConfig(configPath, kubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("error configuring Kubernetes API client: %v", err)
 	}
 
 	return &kubernetesApi{Config: config}, nil
 }
+
+// NewServiceProfileClient returns a client for the ServiceProfile custom
+// resource, configured the same way as the client returned by NewAPI.
+func NewServiceProfileClient(configPath, kubeContext string) (spclient.Interface, error) {
+	config, err := getConfig(configPath, kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring Kubernetes API client: %v", err)
+	}
+
+	return spclient.NewForConfig(config)
+}
@@ -1,8 +1,16 @@
 package k8s
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
 )
 
 func TestKubernetesApiUrlFor(t *testing.T) {
@@ -11,7 +19,7 @@ func TestKubernetesApiUrlFor(t *testing.T) {
 
 	t.Run("Returns base config containing k8s endpoint listed in config.test", func(t *testing.T) {
 		expected := fmt.Sprintf("https://55.197.171.239/api/v1/namespaces/%s%s", namespace, extraPath)
-		api, err := NewAPI("testdata/config.test")
+		api, err := NewAPI("testdata/config.test", "")
 		if err != nil {
 			t.Fatalf("Unexpected error creating Kubernetes API: %+v", err)
 		}
@@ -24,3 +32,98 @@ func TestKubernetesApiUrlFor(t *testing.T) {
 		}
 	})
 }
+
+// discoveryServer returns an API server that serves the given resources, by
+// group version, on its discovery endpoints.
+func discoveryServer(groupVersions map[string][]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		groupVersion := strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, "/apis/"), "/api/")
+		resources, ok := groupVersions[groupVersion]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+
+		resourceList := metaV1.APIResourceList{GroupVersion: groupVersion}
+		for _, resource := range resources {
+			resourceList.APIResources = append(resourceList.APIResources, metaV1.APIResource{Name: resource})
+		}
+		json.NewEncoder(w).Encode(resourceList)
+	}))
+}
+
+func TestCheckApiResources(t *testing.T) {
+	core := []string{"configmaps", "endpoints", "namespaces", "pods", "replicationcontrollers", "secrets", "serviceaccounts", "services"}
+	rbac := []string{"clusterrolebindings", "clusterroles"}
+
+	testCases := []struct {
+		name            string
+		groupVersions   map[string][]string
+		expectedStatus  healthcheckPb.CheckStatus
+		expectedMessage string
+	}{
+		{
+			name: "Kubernetes 1.8",
+			groupVersions: map[string][]string{
+				"v1":                                core,
+				"apps/v1beta2":                      {"deployments", "replicasets", "statefulsets"},
+				"extensions/v1beta1":                {"deployments", "replicasets"},
+				"batch/v1":                          {"jobs"},
+				"rbac.authorization.k8s.io/v1beta1": rbac,
+			},
+			expectedStatus: healthcheckPb.CheckStatus_OK,
+		},
+		{
+			name: "Kubernetes 1.7",
+			groupVersions: map[string][]string{
+				"v1":                                core,
+				"extensions/v1beta1":                {"deployments", "replicasets"},
+				"batch/v1":                          {"jobs"},
+				"rbac.authorization.k8s.io/v1beta1": rbac,
+			},
+			expectedStatus:  healthcheckPb.CheckStatus_FAIL,
+			expectedMessage: "Kubernetes does not serve the resources [apps/v1beta2/deployments, apps/v1beta2/replicasets], which Linkerd requires.",
+		},
+		{
+			name: "Kubernetes 1.16",
+			groupVersions: map[string][]string{
+				"v1":                                core,
+				"extensions/v1beta1":                {"ingresses"},
+				"batch/v1":                          {"jobs"},
+				"rbac.authorization.k8s.io/v1beta1": rbac,
+			},
+			expectedStatus:  healthcheckPb.CheckStatus_FAIL,
+			expectedMessage: "Kubernetes does not serve the resources [apps/v1beta2/deployments, apps/v1beta2/replicasets, extensions/v1beta1/deployments], which Linkerd requires.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := discoveryServer(tc.groupVersions)
+			defer server.Close()
+
+			kubeapi := &kubernetesApi{Config: &rest.Config{Host: server.URL}}
+			result := kubeapi.checkApiResources(server.Client())
+
+			if result.Status != tc.expectedStatus {
+				t.Fatalf("Expected status %s, got %s: %s", tc.expectedStatus, result.Status, result.FriendlyMessageToUser)
+			}
+			if result.FriendlyMessageToUser != tc.expectedMessage {
+				t.Fatalf("Expected message [%s], got [%s]", tc.expectedMessage, result.FriendlyMessageToUser)
+			}
+		})
+	}
+
+	t.Run("Returns an error if discovery fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		kubeapi := &kubernetesApi{Config: &rest.Config{Host: server.URL}}
+		result := kubeapi.checkApiResources(server.Client())
+		if result.Status != healthcheckPb.CheckStatus_ERROR {
+			t.Fatalf("Expected status %s, got %s", healthcheckPb.CheckStatus_ERROR, result.Status)
+		}
+	})
+}
@@ -11,11 +11,14 @@ import (
 const (
 	All                   = "all"
 	Authority             = "authority"
+	CronJob               = "cronjob"
 	Deployment            = "deployment"
+	Job                   = "job"
 	Namespace             = "namespace"
 	Pod                   = "pod"
 	ReplicationController = "replicationcontroller"
 	Service               = "service"
+	ServiceAccount        = "serviceaccount"
 )
 
 // resources to query in StatSummary when Resource.Type is "all"
@@ -55,12 +58,15 @@ func generateBaseKubernetesApiUrl(schemeHostAndPort string) (*url.URL, error) {
 	return url, nil
 }
 
-func getConfig(fpath string) (*rest.Config, error) {
+// getConfig returns the configuration of the kubeconfig file at fpath, or of
+// the default kubeconfig files if it's empty. The configuration is of
+// kubeContext, or of the file's current context if it's empty.
+func getConfig(fpath, kubeContext string) (*rest.Config, error) {
 	rules := clientcmd.NewDefaultClientConfigLoadingRules()
 	if fpath != "" {
 		rules.ExplicitPath = fpath
 	}
-	overrides := &clientcmd.ConfigOverrides{}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
 	return clientcmd.
 		NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).
 		ClientConfig()
@@ -73,6 +79,10 @@ func CanonicalResourceNameFromFriendlyName(friendlyName string) (string, error)
 	switch friendlyName {
 	case "deploy", "deployment", "deployments":
 		return Deployment, nil
+	case "job", "jobs":
+		return Job, nil
+	case "cj", "cronjob", "cronjobs":
+		return CronJob, nil
 	case "ns", "namespace", "namespaces":
 		return Namespace, nil
 	case "po", "pod", "pods":
@@ -81,6 +91,8 @@ func CanonicalResourceNameFromFriendlyName(friendlyName string) (string, error)
 		return ReplicationController, nil
 	case "svc", "service", "services":
 		return Service, nil
+	case "sa", "serviceaccount", "serviceaccounts":
+		return ServiceAccount, nil
 	case "au", "authority", "authorities":
 		return Authority, nil
 	case "all":
@@ -96,6 +108,10 @@ func ShortNameFromCanonicalResourceName(canonicalName string) string {
 	switch canonicalName {
 	case Deployment:
 		return "deploy"
+	case Job:
+		return "job"
+	case CronJob:
+		return "cj"
 	case Namespace:
 		return "ns"
 	case Pod:
@@ -104,6 +120,8 @@ func ShortNameFromCanonicalResourceName(canonicalName string) string {
 		return "rc"
 	case Service:
 		return "svc"
+	case ServiceAccount:
+		return "sa"
 	case Authority:
 		return "au"
 	default:
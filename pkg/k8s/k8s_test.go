@@ -60,7 +60,7 @@ func TestGenerateBaseKubernetesApiUrl(t *testing.T) {
 
 func TestGetConfig(t *testing.T) {
 	t.Run("Gets host correctly form existing file", func(t *testing.T) {
-		config, err := getConfig("testdata/config.test")
+		config, err := getConfig("testdata/config.test", "")
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -71,8 +71,33 @@ func TestGetConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("Gets host of the given context", func(t *testing.T) {
+		expectedHosts := map[string]string{
+			"cluster1": "https://55.197.171.239",
+			"cluster2": "https://30.88.172.234",
+			"dev":      "https://13.184.231.31",
+		}
+		for kubeContext, expectedHost := range expectedHosts {
+			config, err := getConfig("testdata/config.test", kubeContext)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if config.Host != expectedHost {
+				t.Fatalf("Expected host of context [%s] to be [%s] got [%s]", kubeContext, expectedHost, config.Host)
+			}
+		}
+	})
+
+	t.Run("Returns error if context cannot be found", func(t *testing.T) {
+		_, err := getConfig("testdata/config.test", "does-not-exist")
+		if err == nil {
+			t.Fatalf("Expecting error when context doesnt exist, got nothing")
+		}
+	})
+
 	t.Run("Returns error if configuration cannot be found", func(t *testing.T) {
-		_, err := getConfig("/this/doest./not/exist.config")
+		_, err := getConfig("/this/doest./not/exist.config", "")
 		if err == nil {
 			t.Fatalf("Expecting error when config file doesnt exist, got nothing")
 		}
@@ -88,6 +113,9 @@ func TestCanonicalResourceNameFromFriendlyName(t *testing.T) {
 			"deployments": Deployment,
 			"au":          Authority,
 			"authorities": Authority,
+			"jobs":        Job,
+			"cj":          CronJob,
+			"cronjob":     CronJob,
 		}
 
 		for input, expectedName := range expectations {
@@ -50,6 +50,14 @@ const (
 	// StatefulSet that this proxy belongs to.
 	ProxyStatefulSetLabel = "linkerd.io/proxy-statefulset"
 
+	// ProxyServiceAccountLabel is injected into mesh-enabled apps, identifying
+	// the ServiceAccount that the pod runs as.
+	ProxyServiceAccountLabel = "linkerd.io/proxy-serviceaccount"
+
+	// TLSSecretLabel is set to "true" on the Secrets that hold the
+	// certificates that Linkerd issues, so that their expiry can be checked.
+	TLSSecretLabel = "linkerd.io/tls-secret"
+
 	/*
 	 * Annotations
 	 */
@@ -62,6 +70,50 @@ const (
 	// (e.g. v0.1.3).
 	ProxyVersionAnnotation = "linkerd.io/proxy-version"
 
+	// ProxyEnablePprofAnnotation indicates that CPU profiling is enabled on
+	// the injected proxy.
+	ProxyEnablePprofAnnotation = "config.linkerd.io/enable-pprof"
+
+	// ProxyInitIptablesModeAnnotation overrides the iptables backend that the
+	// injected init container programs the pod's rules with, e.g. nft.
+	ProxyInitIptablesModeAnnotation = "config.linkerd.io/iptables-mode"
+
+	// ProxyInitIPv6ModeAnnotation overrides whether the injected init
+	// container also redirects the pod's IPv6 traffic to the proxy, e.g.
+	// disabled.
+	ProxyInitIPv6ModeAnnotation = "config.linkerd.io/ipv6-mode"
+
+	// ProxyInitSkipOwnerUIDsAnnotation records the user IDs whose outbound
+	// traffic the injected init container doesn't redirect to the proxy.
+	ProxyInitSkipOwnerUIDsAnnotation = "config.linkerd.io/skip-owner-uids"
+
+	// ProxyInitSkipOwnerGIDsAnnotation records the group IDs whose outbound
+	// traffic the injected init container doesn't redirect to the proxy.
+	ProxyInitSkipOwnerGIDsAnnotation = "config.linkerd.io/skip-owner-gids"
+
+	// ProxyInitSkipOutboundCIDRsAnnotation records the outbound destinations
+	// that the injected init container doesn't redirect to the proxy.
+	ProxyInitSkipOutboundCIDRsAnnotation = "config.linkerd.io/skip-outbound-cidrs"
+
+	// ProxyInitSkipInboundPortsAnnotation records the inbound ports, other
+	// than the proxy's own, that the pod's rules don't redirect to the proxy.
+	ProxyInitSkipInboundPortsAnnotation = "config.linkerd.io/skip-inbound-ports"
+
+	// ProxyInitSkipOutboundPortsAnnotation records the outbound ports that the
+	// pod's rules don't redirect to the proxy.
+	ProxyInitSkipOutboundPortsAnnotation = "config.linkerd.io/skip-outbound-ports"
+
+	// SkipProfileValidationAnnotation, when set to "true" on a ServiceProfile,
+	// makes the sp-validator admission webhook accept the profile without
+	// validating it. It is meant for emergencies, where a profile must be
+	// applied even though the validator rejects it.
+	SkipProfileValidationAnnotation = "linkerd.io/skip-profile-validation"
+
+	// CertManagerInjectCAFromSecretAnnotation names the Secret, as
+	// <namespace>/<name>, whose CA certificate cert-manager's cainjector
+	// injects into the annotated resource.
+	CertManagerInjectCAFromSecretAnnotation = "cert-manager.io/inject-ca-from-secret"
+
 	/*
 	 * Component Names
 	 */
@@ -74,6 +126,73 @@ const (
 	// that contains the actual trust anchor bundle.
 	TLSTrustAnchorFileName = "trust-anchors.pem"
 
+	// TLSIssuerFileName is the name (key) within the trust anchor ConfigMap
+	// that contains the certificate currently used to issue proxy certificates.
+	TLSIssuerFileName = "issuer.pem"
+
+	// TLSIdentityTrustAnchorsConfigMapName is the name of the ConfigMap in the
+	// control plane namespace that holds additional trust anchors, configured
+	// at install time, to be distributed alongside the CA's own trust anchor.
+	TLSIdentityTrustAnchorsConfigMapName = "linkerd-identity-trust-anchors"
+
+	// TrustBundleConfigMapName is the name of the ConfigMap in the control
+	// plane namespace that holds the trust anchors configured at install time
+	// for cert-manager's cainjector, when the control plane is installed with
+	// --generate-trust-bundle-configmap.
+	TrustBundleConfigMapName = "linkerd-trust-bundle"
+
+	// TrustAnchorSecretName is the name of the Secret in the control plane
+	// namespace, e.g. issued by cert-manager, that the trust bundle ConfigMap
+	// points cert-manager's cainjector to.
+	TrustAnchorSecretName = "linkerd-trust-anchor"
+
+	// GrafanaAdminSecretName is the name of the Secret in the control plane
+	// namespace that holds the Grafana admin credentials, when Grafana is
+	// installed with authentication enabled.
+	GrafanaAdminSecretName = "linkerd-grafana-admin"
+
+	// GrafanaAdminUsernameKey and GrafanaAdminPasswordKey are the keys within
+	// the Grafana admin Secret that hold the admin username and password.
+	GrafanaAdminUsernameKey = "username"
+	GrafanaAdminPasswordKey = "password"
+
+	// LinkerdConfigMapName is the name of the ConfigMap in the control plane
+	// namespace that holds the install-time configuration that the CLI and
+	// the web UI share.
+	LinkerdConfigMapName = "linkerd-config"
+
+	// LinkerdConfigGrafanaURLKey is the key within the Linkerd config
+	// ConfigMap that holds the base URL of an external Grafana, which is
+	// empty when the bundled Grafana is installed.
+	LinkerdConfigGrafanaURLKey = "grafanaUrl"
+
+	// LinkerdConfigPrometheusURLKey is the key within the Linkerd config
+	// ConfigMap that holds the URL of an external Prometheus, which is empty
+	// when the bundled Prometheus is installed.
+	LinkerdConfigPrometheusURLKey = "prometheusUrl"
+
+	// LinkerdConfigClusterDNSDomainKey is the key within the Linkerd config
+	// ConfigMap that holds the DNS domain of the cluster that the control
+	// plane was installed into, which is empty for control planes installed
+	// before it was recorded.
+	LinkerdConfigClusterDNSDomainKey = "clusterDnsDomain"
+
+	// InstallNotesConfigMapName is the name of the ConfigMap in the control
+	// plane namespace that holds the next steps to take after installing, in
+	// its InstallNotesKey key, when the control plane is installed with
+	// --notes.
+	InstallNotesConfigMapName = "linkerd-install-notes"
+	InstallNotesKey           = "NOTES.txt"
+
+	// CNIConfigMapName is the name of the ConfigMap in the linkerd-cni
+	// DaemonSet's namespace that holds the hash of the plugin binary that
+	// the DaemonSet was installed with.
+	CNIConfigMapName = "linkerd-cni"
+
+	// CNIPluginSHA256Key is the key within the CNI ConfigMap that holds the
+	// hex-encoded SHA-256 hash of the plugin binary.
+	CNIPluginSHA256Key = "plugin-sha256"
+
 	TLSCertFileName       = "certificate.crt"
 	TLSPrivateKeyFileName = "private-key.p8"
 )
@@ -101,13 +220,33 @@ func GetPodLabels(ownerKind, ownerName string, pod *coreV1.Pod) map[string]strin
 		labels["pod_template_hash"] = pth
 	}
 
+	labels["serviceaccount"] = GetServiceAccountName(&pod.Spec)
+
 	return labels
 }
 
+// GetServiceAccountName returns the name of the ServiceAccount that pods with
+// the given spec run as. Kubernetes falls back to the deprecated
+// serviceAccount field, and then to the "default" ServiceAccount, if the spec
+// doesn't set serviceAccountName.
+func GetServiceAccountName(spec *coreV1.PodSpec) string {
+	if spec.ServiceAccountName != "" {
+		return spec.ServiceAccountName
+	}
+	if spec.DeprecatedServiceAccount != "" {
+		return spec.DeprecatedServiceAccount
+	}
+	return "default"
+}
+
 func IsMeshed(pod *coreV1.Pod, controllerNS string) bool {
 	return pod.Labels[ControllerNSLabel] == controllerNS
 }
 
+// DefaultClusterDNSDomain is the DNS domain of Kubernetes clusters that don't
+// configure their own.
+const DefaultClusterDNSDomain = "cluster.local"
+
 // TLSIdentity is the identity of a pod owner (Deployment, Pod,
 // ReplicationController, etc.).
 type TLSIdentity struct {
@@ -124,11 +263,19 @@ type TLSIdentity struct {
 
 	// ControllerNamespace is the namespace of the controller for the pod.
 	ControllerNamespace string
+
+	// ClusterDNSDomain is the DNS domain of the pod's cluster, which the
+	// identity's DNS name is in. It is DefaultClusterDNSDomain if empty.
+	ClusterDNSDomain string
 }
 
 func (i TLSIdentity) ToDNSName() string {
-	return fmt.Sprintf("%s.%s.%s.linkerd-managed.%s.svc.cluster.local", i.Name,
-		i.Kind, i.Namespace, i.ControllerNamespace)
+	clusterDNSDomain := i.ClusterDNSDomain
+	if clusterDNSDomain == "" {
+		clusterDNSDomain = DefaultClusterDNSDomain
+	}
+	return fmt.Sprintf("%s.%s.%s.linkerd-managed.%s.svc.%s", i.Name,
+		i.Kind, i.Namespace, i.ControllerNamespace, clusterDNSDomain)
 }
 
 func (i TLSIdentity) ToSecretName() string {
@@ -141,5 +288,6 @@ func (i TLSIdentity) ToControllerIdentity() TLSIdentity {
 		Kind:                "deployment",
 		Namespace:           i.ControllerNamespace,
 		ControllerNamespace: i.ControllerNamespace,
+		ClusterDNSDomain:    i.ClusterDNSDomain,
 	}
 }
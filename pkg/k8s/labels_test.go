@@ -19,6 +19,9 @@ func TestGetPodLabels(t *testing.T) {
 					appsV1.DefaultDeploymentUniqueLabelKey: "test-pth",
 				},
 			},
+			Spec: coreV1.PodSpec{
+				ServiceAccountName: "test-sa",
+			},
 		}
 
 		ownerKind := "deployment"
@@ -29,6 +32,7 @@ func TestGetPodLabels(t *testing.T) {
 			"deployment":        "test-deployment",
 			"pod":               "test-pod",
 			"pod_template_hash": "test-pth",
+			"serviceaccount":    "test-sa",
 		}
 
 		podLabels := GetPodLabels(ownerKind, ownerName, pod)
@@ -38,3 +42,33 @@ func TestGetPodLabels(t *testing.T) {
 		}
 	})
 }
+
+func TestTLSIdentityToDNSName(t *testing.T) {
+	identity := TLSIdentity{
+		Name:                "web",
+		Kind:                "deployment",
+		Namespace:           "emojivoto",
+		ControllerNamespace: "linkerd",
+	}
+
+	t.Run("Uses the default cluster DNS domain", func(t *testing.T) {
+		expected := "web.deployment.emojivoto.linkerd-managed.linkerd.svc.cluster.local"
+		if name := identity.ToDNSName(); name != expected {
+			t.Fatalf("Expected %s, got %s", expected, name)
+		}
+	})
+
+	t.Run("Uses the identity's cluster DNS domain, as does its controller's identity", func(t *testing.T) {
+		identity := identity
+		identity.ClusterDNSDomain = "cluster.example.com"
+
+		expected := "web.deployment.emojivoto.linkerd-managed.linkerd.svc.cluster.example.com"
+		if name := identity.ToDNSName(); name != expected {
+			t.Fatalf("Expected %s, got %s", expected, name)
+		}
+		expected = "controller.deployment.linkerd.linkerd-managed.linkerd.svc.cluster.example.com"
+		if name := identity.ToControllerIdentity().ToDNSName(); name != expected {
+			t.Fatalf("Expected %s, got %s", expected, name)
+		}
+	})
+}
@@ -18,9 +18,10 @@ type KubernetesProxy struct {
 }
 
 // NewProxy returns a new KubernetesProxy object and starts listening on a
-// network address.
-func NewProxy(configPath string, proxyPort int) (*KubernetesProxy, error) {
-	config, err := getConfig(configPath)
+// network address. The proxy forwards requests to the cluster of the
+// kubeContext context of the kubeconfig file at configPath.
+func NewProxy(configPath, kubeContext string, proxyPort int) (*KubernetesProxy, error) {
+	config, err := getConfig(configPath, kubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("error configuring Kubernetes API client: %v", err)
 	}
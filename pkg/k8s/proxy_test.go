@@ -2,13 +2,18 @@ package k8s
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"os"
 	"testing"
 )
 
 func TestInitK8sProxy(t *testing.T) {
 	t.Run("Returns an initialized Kubernetes Proxy object", func(t *testing.T) {
-		kp, err := NewProxy( "testdata/config.test", 0)
+		kp, err := NewProxy("testdata/config.test", "", 0)
 		if err != nil {
 			t.Fatalf("Unexpected error creating Kubernetes API: %+v", err)
 		}
@@ -24,7 +29,7 @@ func TestKubernetesProxyUrlFor(t *testing.T) {
 	const extraPath = "/some/extra/path"
 
 	t.Run("Returns proxy URL based on the initialized KubernetesProxy", func(t *testing.T) {
-		kp, err := NewProxy( "testdata/config.test", 0)
+		kp, err := NewProxy("testdata/config.test", "", 0)
 		if err != nil {
 			t.Fatalf("Unexpected error creating Kubernetes API: %+v", err)
 		}
@@ -41,4 +46,92 @@ func TestKubernetesProxyUrlFor(t *testing.T) {
 	})
 }
 
-// TODO: test kb.Run()
+func TestKubernetesProxyRun(t *testing.T) {
+	const grafanaPath = "/api/v1/namespaces/linkerd/services/grafana:http/proxy/"
+
+	t.Run("Passes Grafana's login redirect and session cookie through", func(t *testing.T) {
+		// The API server stands in for Grafana, behind its service proxy, with
+		// login required.
+		apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			switch req.URL.Path {
+			case grafanaPath + "login":
+				http.SetCookie(w, &http.Cookie{Name: "grafana_sess", Value: "session", Path: grafanaPath})
+				w.Write([]byte("login"))
+			case grafanaPath:
+				if cookie, err := req.Cookie("grafana_sess"); err != nil || cookie.Value != "session" {
+					http.Redirect(w, req, grafanaPath+"login", http.StatusFound)
+					return
+				}
+				w.Write([]byte("home"))
+			default:
+				http.NotFound(w, req)
+			}
+		}))
+		defer apiServer.Close()
+
+		kubeconfig := writeKubeconfig(t, apiServer.URL)
+		defer os.Remove(kubeconfig)
+
+		kp, err := NewProxy(kubeconfig, "", 0)
+		if err != nil {
+			t.Fatalf("Unexpected error creating Kubernetes proxy: %+v", err)
+		}
+		go kp.Run()
+
+		url, err := kp.URLFor("linkerd", "/services/grafana:http/proxy/")
+		if err != nil {
+			t.Fatalf("Unexpected error generating URL: %+v", err)
+		}
+
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		client := &http.Client{Jar: jar}
+
+		for _, expected := range []string{"login", "home"} {
+			rsp, err := client.Get(url.String())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			body, err := ioutil.ReadAll(rsp.Body)
+			rsp.Body.Close()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if string(body) != expected {
+				t.Fatalf("Expected the %s page, got [%s]", expected, body)
+			}
+		}
+	})
+}
+
+// writeKubeconfig writes a kubeconfig for the API server at server to a
+// temporary file, and returns its path.
+func writeKubeconfig(t *testing.T, server string) string {
+	file, err := ioutil.TempFile("", "kubeconfig")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer file.Close()
+
+	fmt.Fprintf(file, `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: %s
+  name: test
+contexts:
+- context:
+    cluster: test
+    user: test
+  name: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test
+`, server)
+	return file.Name()
+}
@@ -0,0 +1,74 @@
+package profiles
+
+import (
+	"fmt"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+)
+
+// ClusterDefaultName returns the name of the ServiceProfile, in the control
+// plane namespace, that provides defaults for every service in the cluster
+// whose DNS domain is clusterDNSDomain.
+func ClusterDefaultName(clusterDNSDomain string) string {
+	return fmt.Sprintf("svc.%s", clusterDNSDomain)
+}
+
+// ServiceProfileName returns the name of the ServiceProfile of a service,
+// which is its fully qualified name in the cluster's DNS domain.
+func ServiceProfileName(service, namespace, clusterDNSDomain string) string {
+	return fmt.Sprintf("%s.%s.svc.%s", service, namespace, clusterDNSDomain)
+}
+
+// NamespaceDefaultName returns the name of the ServiceProfile, in the control
+// plane namespace, that provides defaults for every service in namespace.
+// Like the profiles of services, it is named after the DNS suffix that it
+// covers, so it can't collide with the profile of a service.
+func NamespaceDefaultName(namespace, clusterDNSDomain string) string {
+	return fmt.Sprintf("%s.svc.%s", namespace, clusterDNSDomain)
+}
+
+// Merge layers profiles, which are ordered from most to least specific, such
+// as a service's profile followed by its namespace's default and the cluster
+// default. Nil profiles are skipped. It returns the resulting spec and, for
+// each of its routes, the name of the profile that the route came from.
+//
+// Profiles are merged as follows:
+//
+//   - The routes of a more specific profile come first, in their own order,
+//     followed by the routes of less specific profiles. Since requests belong
+//     to the first route that they match, more specific routes take precedence
+//     over default routes that match the same requests.
+//   - A default route with the same name as a more specific route is dropped.
+//     Routes are never merged field by field: the more specific route,
+//     including its response classes, timeout, and retryability, replaces the
+//     default one entirely.
+//   - The retry budget is the one of the most specific profile that has one.
+//
+// The profiles themselves are not modified; the merged spec holds copies of
+// their routes.
+func Merge(profiles ...*sp.ServiceProfile) (*sp.ServiceProfileSpec, []string) {
+	spec := &sp.ServiceProfileSpec{Routes: []*sp.RouteSpec{}}
+	sources := []string{}
+	names := make(map[string]bool)
+
+	for _, profile := range profiles {
+		if profile == nil {
+			continue
+		}
+
+		for _, route := range profile.Spec.Routes {
+			if route == nil || names[route.Name] {
+				continue
+			}
+			spec.Routes = append(spec.Routes, route.DeepCopy())
+			sources = append(sources, profile.Name)
+			names[route.Name] = true
+		}
+
+		if spec.RetryBudget == nil && profile.Spec.RetryBudget != nil {
+			spec.RetryBudget = profile.Spec.RetryBudget.DeepCopy()
+		}
+	}
+
+	return spec, sources
+}
@@ -0,0 +1,199 @@
+package profiles
+
+import (
+	"reflect"
+	"testing"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProfileNames(t *testing.T) {
+	testCases := []struct {
+		clusterDNSDomain string
+		service          string
+		namespace        string
+		cluster          string
+	}{
+		{"cluster.local", "books.library.svc.cluster.local", "library.svc.cluster.local", "svc.cluster.local"},
+		{"example.com", "books.library.svc.example.com", "library.svc.example.com", "svc.example.com"},
+	}
+
+	for _, tc := range testCases {
+		if name := ServiceProfileName("books", "library", tc.clusterDNSDomain); name != tc.service {
+			t.Fatalf("Unexpected service profile name: %s", name)
+		}
+		if name := NamespaceDefaultName("library", tc.clusterDNSDomain); name != tc.namespace {
+			t.Fatalf("Unexpected namespace default name: %s", name)
+		}
+		if name := ClusterDefaultName(tc.clusterDNSDomain); name != tc.cluster {
+			t.Fatalf("Unexpected cluster default name: %s", name)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	route := func(name, method, timeout string) *sp.RouteSpec {
+		return &sp.RouteSpec{
+			Name:      name,
+			Condition: &sp.RequestMatch{Method: method},
+			Timeout:   timeout,
+		}
+	}
+	profile := func(name string, budget *sp.RetryBudget, routes ...*sp.RouteSpec) *sp.ServiceProfile {
+		return &sp.ServiceProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       sp.ServiceProfileSpec{Routes: routes, RetryBudget: budget},
+		}
+	}
+	budget := func(ttl string) *sp.RetryBudget {
+		return &sp.RetryBudget{RetryRatio: 0.2, MinRetriesPerSecond: 10, TTL: ttl}
+	}
+
+	const (
+		service   = "books.library.svc.cluster.local"
+		namespace = "library.svc.cluster.local"
+		cluster   = "svc.cluster.local"
+	)
+
+	testCases := []struct {
+		name            string
+		profiles        []*sp.ServiceProfile
+		expectedRoutes  []*sp.RouteSpec
+		expectedSources []string
+		expectedBudget  *sp.RetryBudget
+	}{
+		{
+			name:            "no profiles",
+			profiles:        nil,
+			expectedRoutes:  []*sp.RouteSpec{},
+			expectedSources: []string{},
+		},
+		{
+			name:            "only nil profiles",
+			profiles:        []*sp.ServiceProfile{nil, nil, nil},
+			expectedRoutes:  []*sp.RouteSpec{},
+			expectedSources: []string{},
+		},
+		{
+			name:            "service profile alone",
+			profiles:        []*sp.ServiceProfile{profile(service, budget("10s"), route("a", "GET", ""), route("b", "POST", ""))},
+			expectedRoutes:  []*sp.RouteSpec{route("a", "GET", ""), route("b", "POST", "")},
+			expectedSources: []string{service, service},
+			expectedBudget:  budget("10s"),
+		},
+		{
+			name:            "defaults alone",
+			profiles:        []*sp.ServiceProfile{nil, profile(namespace, nil, route("a", "GET", "")), profile(cluster, budget("10s"), route("b", "POST", ""))},
+			expectedRoutes:  []*sp.RouteSpec{route("a", "GET", ""), route("b", "POST", "")},
+			expectedSources: []string{namespace, cluster},
+			expectedBudget:  budget("10s"),
+		},
+		{
+			name: "more specific routes come first",
+			profiles: []*sp.ServiceProfile{
+				profile(service, nil, route("a", "GET", "")),
+				profile(namespace, nil, route("b", "POST", "")),
+				profile(cluster, nil, route("c", "PUT", "")),
+			},
+			expectedRoutes:  []*sp.RouteSpec{route("a", "GET", ""), route("b", "POST", ""), route("c", "PUT", "")},
+			expectedSources: []string{service, namespace, cluster},
+		},
+		{
+			name: "order within a profile is kept",
+			profiles: []*sp.ServiceProfile{
+				profile(service, nil, route("z", "GET", ""), route("a", "POST", "")),
+				profile(namespace, nil, route("y", "PUT", ""), route("b", "DELETE", "")),
+			},
+			expectedRoutes:  []*sp.RouteSpec{route("z", "GET", ""), route("a", "POST", ""), route("y", "PUT", ""), route("b", "DELETE", "")},
+			expectedSources: []string{service, service, namespace, namespace},
+		},
+		{
+			name: "more specific routes replace default routes with the same name entirely",
+			profiles: []*sp.ServiceProfile{
+				profile(service, nil, route("a", "GET", "")),
+				profile(namespace, nil, route("b", "POST", ""), route("a", "PUT", "1s")),
+			},
+			expectedRoutes:  []*sp.RouteSpec{route("a", "GET", ""), route("b", "POST", "")},
+			expectedSources: []string{service, namespace},
+		},
+		{
+			name: "namespace default routes replace cluster default routes with the same name",
+			profiles: []*sp.ServiceProfile{
+				nil,
+				profile(namespace, nil, route("a", "GET", "1s")),
+				profile(cluster, nil, route("a", "GET", "2s"), route("b", "POST", "")),
+			},
+			expectedRoutes:  []*sp.RouteSpec{route("a", "GET", "1s"), route("b", "POST", "")},
+			expectedSources: []string{namespace, cluster},
+		},
+		{
+			name: "routes with different names that match the same requests are all kept",
+			profiles: []*sp.ServiceProfile{
+				profile(service, nil, route("get books", "GET", "")),
+				profile(namespace, nil, route("get", "GET", "")),
+			},
+			expectedRoutes:  []*sp.RouteSpec{route("get books", "GET", ""), route("get", "GET", "")},
+			expectedSources: []string{service, namespace},
+		},
+		{
+			name: "the most specific retry budget wins",
+			profiles: []*sp.ServiceProfile{
+				profile(service, nil, route("a", "GET", "")),
+				profile(namespace, budget("10s")),
+				profile(cluster, budget("20s")),
+			},
+			expectedRoutes:  []*sp.RouteSpec{route("a", "GET", "")},
+			expectedSources: []string{service},
+			expectedBudget:  budget("10s"),
+		},
+		{
+			name: "a service's retry budget replaces the defaults'",
+			profiles: []*sp.ServiceProfile{
+				profile(service, budget("5s"), route("a", "GET", "")),
+				profile(namespace, budget("10s")),
+			},
+			expectedRoutes:  []*sp.RouteSpec{route("a", "GET", "")},
+			expectedSources: []string{service},
+			expectedBudget:  budget("5s"),
+		},
+		{
+			name: "nil routes are skipped",
+			profiles: []*sp.ServiceProfile{
+				profile(service, nil, nil, route("a", "GET", "")),
+			},
+			expectedRoutes:  []*sp.RouteSpec{route("a", "GET", "")},
+			expectedSources: []string{service},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			spec, sources := Merge(tc.profiles...)
+
+			if !reflect.DeepEqual(spec.Routes, tc.expectedRoutes) {
+				t.Errorf("Expected routes %+v, got %+v", tc.expectedRoutes, spec.Routes)
+			}
+			if !reflect.DeepEqual(sources, tc.expectedSources) {
+				t.Errorf("Expected sources %v, got %v", tc.expectedSources, sources)
+			}
+			if !reflect.DeepEqual(spec.RetryBudget, tc.expectedBudget) {
+				t.Errorf("Expected retry budget %+v, got %+v", tc.expectedBudget, spec.RetryBudget)
+			}
+		})
+	}
+
+	t.Run("does not share routes with the merged profiles", func(t *testing.T) {
+		service := profile(service, budget("10s"), route("a", "GET", ""))
+		spec, _ := Merge(service)
+
+		spec.Routes[0].Timeout = "1s"
+		spec.Routes[0].Condition.Method = "POST"
+		spec.RetryBudget.TTL = "1s"
+
+		if !reflect.DeepEqual(service.Spec.Routes[0], route("a", "GET", "")) || service.Spec.RetryBudget.TTL != "10s" {
+			t.Fatalf("Expected the merged profile to be unchanged, got %+v", service.Spec)
+		}
+	})
+}
@@ -0,0 +1,377 @@
+package profiles
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+)
+
+const (
+	minStatus = 100
+	maxStatus = 599
+
+	minGRPCStatus = 0
+	maxGRPCStatus = 16
+)
+
+// ValidateYAML parses a ServiceProfile from YAML and validates it. Fields
+// that are not part of the ServiceProfile spec are rejected, since they
+// usually indicate a typo that would otherwise be silently ignored.
+func ValidateYAML(data []byte) error {
+	j, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse profile: %s", err)
+	}
+
+	var profile sp.ServiceProfile
+	decoder := json.NewDecoder(bytes.NewReader(j))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&profile); err != nil {
+		return fmt.Errorf("failed to parse profile: %s", err)
+	}
+
+	gvk := sp.SchemeGroupVersion.WithKind("ServiceProfile")
+	if profile.APIVersion != gvk.GroupVersion().String() {
+		return fmt.Errorf("apiVersion must be %s, got %q", gvk.GroupVersion(), profile.APIVersion)
+	}
+	if profile.Kind != gvk.Kind {
+		return fmt.Errorf("kind must be %s, got %q", gvk.Kind, profile.Kind)
+	}
+
+	return Validate(&profile)
+}
+
+// Validate returns an error describing the first problem found in the
+// ServiceProfile, or nil if the profile is valid.
+func Validate(profile *sp.ServiceProfile) error {
+	if len(profile.Spec.Routes) == 0 {
+		return errors.New("profile must have at least one route")
+	}
+
+	names := make(map[string]bool)
+	for i, route := range profile.Spec.Routes {
+		if route == nil {
+			return fmt.Errorf("route %d is empty", i)
+		}
+		if route.Name == "" {
+			return fmt.Errorf("route %d has no name", i)
+		}
+		if names[route.Name] {
+			return fmt.Errorf("duplicate route name %q", route.Name)
+		}
+		names[route.Name] = true
+
+		if route.Condition == nil {
+			return fmt.Errorf("route %q has no condition", route.Name)
+		}
+		if err := validateRequestMatch(route.Condition); err != nil {
+			return fmt.Errorf("route %q: %s", route.Name, err)
+		}
+
+		if route.Timeout != "" {
+			timeout, err := time.ParseDuration(route.Timeout)
+			if err != nil {
+				return fmt.Errorf("route %q: invalid timeout %q: %s", route.Name, route.Timeout, err)
+			}
+			if timeout <= 0 {
+				return fmt.Errorf("route %q: timeout %s must be positive", route.Name, route.Timeout)
+			}
+		}
+
+		for j, rc := range route.ResponseClasses {
+			if rc == nil || rc.Condition == nil {
+				return fmt.Errorf("route %q: response class %d has no condition", route.Name, j)
+			}
+			if err := validateResponseMatch(rc.Condition); err != nil {
+				return fmt.Errorf("route %q: response class %d: %s", route.Name, j, err)
+			}
+		}
+	}
+
+	if profile.Spec.RetryBudget != nil {
+		if err := validateRetryBudget(profile.Spec.RetryBudget); err != nil {
+			return fmt.Errorf("retryBudget: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func validateRetryBudget(budget *sp.RetryBudget) error {
+	if budget.RetryRatio < 0 || budget.RetryRatio > 1 {
+		return fmt.Errorf("retryRatio %g must be between 0 and 1", budget.RetryRatio)
+	}
+
+	ttl, err := time.ParseDuration(budget.TTL)
+	if err != nil {
+		return fmt.Errorf("invalid ttl %q: %s", budget.TTL, err)
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("ttl %s must be positive", budget.TTL)
+	}
+
+	return nil
+}
+
+func validateRequestMatch(match *sp.RequestMatch) error {
+	set := 0
+	if match.All != nil {
+		set++
+	}
+	if match.Not != nil {
+		set++
+	}
+	if match.Any != nil {
+		set++
+	}
+	if match.PathRegex != "" {
+		set++
+	}
+	if match.Method != "" {
+		set++
+	}
+	if set == 0 {
+		return errors.New("request match is empty")
+	}
+	if set > 1 {
+		return errors.New("request match must set exactly one of all, not, any, pathRegex, or method")
+	}
+
+	switch {
+	case match.All != nil:
+		if len(match.All) == 0 {
+			return errors.New("\"all\" must have at least one condition")
+		}
+		for _, m := range match.All {
+			if m == nil {
+				return errors.New("request match is empty")
+			}
+			if err := validateRequestMatch(m); err != nil {
+				return err
+			}
+		}
+		return checkSatisfiableRequestMatch(match.All)
+
+	case match.Not != nil:
+		return validateRequestMatch(match.Not)
+
+	case match.Any != nil:
+		if len(match.Any) == 0 {
+			return errors.New("\"any\" must have at least one condition")
+		}
+		for _, m := range match.Any {
+			if m == nil {
+				return errors.New("request match is empty")
+			}
+			if err := validateRequestMatch(m); err != nil {
+				return err
+			}
+		}
+
+	case match.PathRegex != "":
+		return validatePathRegex(match.PathRegex)
+
+	case match.Method != "":
+		if !isToken(match.Method) {
+			return fmt.Errorf("invalid method %q", match.Method)
+		}
+	}
+
+	return nil
+}
+
+// checkSatisfiableRequestMatch rejects "all" conditions that can never match:
+// ones that require two different methods, or that require both a condition
+// and its negation.
+func checkSatisfiableRequestMatch(all []*sp.RequestMatch) error {
+	method := ""
+	for _, m := range all {
+		if m.Method == "" {
+			continue
+		}
+		if method != "" && method != m.Method {
+			return fmt.Errorf("\"all\" condition can never match: method cannot be both %s and %s", method, m.Method)
+		}
+		method = m.Method
+	}
+
+	for _, m := range all {
+		if m.Not == nil {
+			continue
+		}
+		for _, other := range all {
+			if reflect.DeepEqual(m.Not, other) {
+				return errors.New("\"all\" condition can never match: it contains both a condition and its negation")
+			}
+		}
+	}
+
+	return nil
+}
+
+func validatePathRegex(pathRegex string) error {
+	re, err := regexp.Compile(pathRegex)
+	if err != nil {
+		return fmt.Errorf("invalid pathRegex %q: %s", pathRegex, err)
+	}
+
+	// The regex must match the entire request path, and request paths always
+	// begin with "/", so a regex whose literal prefix doesn't can never match.
+	prefix, _ := re.LiteralPrefix()
+	if unanchored, err := regexp.Compile(strings.TrimPrefix(pathRegex, "^")); err == nil {
+		prefix, _ = unanchored.LiteralPrefix()
+	}
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		return fmt.Errorf("pathRegex %q can never match: paths must begin with \"/\"", pathRegex)
+	}
+
+	return nil
+}
+
+func validateResponseMatch(match *sp.ResponseMatch) error {
+	set := 0
+	if match.All != nil {
+		set++
+	}
+	if match.Not != nil {
+		set++
+	}
+	if match.Any != nil {
+		set++
+	}
+	if match.Status != nil {
+		set++
+	}
+	if match.GRPCStatus != nil {
+		set++
+	}
+	if set == 0 {
+		return errors.New("response match is empty")
+	}
+	if set > 1 {
+		return errors.New("response match must set exactly one of all, not, any, status, or grpcStatus")
+	}
+
+	switch {
+	case match.All != nil:
+		if len(match.All) == 0 {
+			return errors.New("\"all\" must have at least one condition")
+		}
+		for _, m := range match.All {
+			if m == nil {
+				return errors.New("response match is empty")
+			}
+			if err := validateResponseMatch(m); err != nil {
+				return err
+			}
+		}
+		return checkSatisfiableResponseMatch(match.All)
+
+	case match.Not != nil:
+		return validateResponseMatch(match.Not)
+
+	case match.Any != nil:
+		if len(match.Any) == 0 {
+			return errors.New("\"any\" must have at least one condition")
+		}
+		for _, m := range match.Any {
+			if m == nil {
+				return errors.New("response match is empty")
+			}
+			if err := validateResponseMatch(m); err != nil {
+				return err
+			}
+		}
+
+	case match.Status != nil:
+		return validateRange("status", match.Status, minStatus, maxStatus)
+
+	case match.GRPCStatus != nil:
+		return validateRange("grpcStatus", match.GRPCStatus, minGRPCStatus, maxGRPCStatus)
+	}
+
+	return nil
+}
+
+// checkSatisfiableResponseMatch rejects "all" conditions whose status or
+// grpcStatus ranges don't overlap.
+func checkSatisfiableResponseMatch(all []*sp.ResponseMatch) error {
+	var status, grpcStatus []*sp.Range
+	for _, m := range all {
+		if m.Status != nil {
+			status = append(status, m.Status)
+		}
+		if m.GRPCStatus != nil {
+			grpcStatus = append(grpcStatus, m.GRPCStatus)
+		}
+	}
+	if !overlap(status, minStatus, maxStatus) {
+		return errors.New("\"all\" condition can never match: status ranges do not overlap")
+	}
+	if !overlap(grpcStatus, minGRPCStatus, maxGRPCStatus) {
+		return errors.New("\"all\" condition can never match: grpcStatus ranges do not overlap")
+	}
+
+	return nil
+}
+
+// overlap reports whether there is a status within [min, max] that is in
+// every one of ranges.
+func overlap(ranges []*sp.Range, min, max uint32) bool {
+	for _, r := range ranges {
+		lo, hi := bounds(r, min, max)
+		if lo > min {
+			min = lo
+		}
+		if hi < max {
+			max = hi
+		}
+	}
+	return min <= max
+}
+
+func validateRange(field string, r *sp.Range, min, max uint32) error {
+	if r.Min != 0 && (r.Min < min || r.Min > max) {
+		return fmt.Errorf("%s range min %d is not between %d and %d", field, r.Min, min, max)
+	}
+	if r.Max != 0 && (r.Max < min || r.Max > max) {
+		return fmt.Errorf("%s range max %d is not between %d and %d", field, r.Max, min, max)
+	}
+	if lo, hi := bounds(r, min, max); lo > hi {
+		return fmt.Errorf("%s range min %d is greater than max %d", field, r.Min, r.Max)
+	}
+
+	return nil
+}
+
+// bounds returns the inclusive bounds of a status range, treating an unset
+// bound as unbounded.
+func bounds(r *sp.Range, min, max uint32) (uint32, uint32) {
+	lo, hi := r.Min, r.Max
+	if lo == 0 {
+		lo = min
+	}
+	if hi == 0 {
+		hi = max
+	}
+	return lo, hi
+}
+
+// isToken reports whether s is a valid HTTP method token, as defined by
+// RFC 7230 section 3.2.6.
+func isToken(s string) bool {
+	for _, c := range s {
+		if c >= 0x80 || c <= ' ' || c == 0x7f || strings.ContainsRune("()<>@,;:\\\"/[]?={}", c) {
+			return false
+		}
+	}
+	return true
+}
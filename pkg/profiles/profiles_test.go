@@ -0,0 +1,69 @@
+package profiles
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const errorPrefix = "# error:"
+
+func TestValidateYAML(t *testing.T) {
+	t.Run("Accepts valid profiles", func(t *testing.T) {
+		files, err := filepath.Glob("testdata/valid/*.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) == 0 {
+			t.Fatal("No valid profiles found in testdata")
+		}
+
+		for _, file := range files {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := ValidateYAML(data); err != nil {
+				t.Errorf("%s: unexpected error: %s", file, err)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid profiles", func(t *testing.T) {
+		files, err := filepath.Glob("testdata/invalid/*.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) == 0 {
+			t.Fatal("No invalid profiles found in testdata")
+		}
+
+		for _, file := range files {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Each invalid profile starts with a comment containing a
+			// substring of the expected error.
+			line, err := bufio.NewReader(bytes.NewReader(data)).ReadString('\n')
+			if err != nil || !strings.HasPrefix(line, errorPrefix) {
+				t.Fatalf("%s: expected first line to start with %q", file, errorPrefix)
+			}
+			expected := strings.TrimSpace(strings.TrimPrefix(line, errorPrefix))
+
+			err = ValidateYAML(data)
+			if err == nil {
+				t.Errorf("%s: expected error containing %q, got none", file, expected)
+				continue
+			}
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("%s: expected error containing %q, got %q", file, expected, err.Error())
+			}
+		}
+	})
+}
@@ -0,0 +1,272 @@
+package registry
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	dockerHubRegistry  = "registry-1.docker.io"
+	dockerHubNamespace = "library"
+	defaultTag         = "latest"
+
+	digestHeader = "Docker-Content-Digest"
+)
+
+// manifestMediaTypes are the manifest types accepted when resolving a tag.
+// Manifest lists are preferred so that the digest is the same for every
+// platform.
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+}
+
+// Reference is a tagged image reference, e.g. gcr.io/linkerd-io/proxy:v18.8.1.
+type Reference struct {
+	// Registry is the host (and optional port) of the registry serving the
+	// image. Images without a registry are served by Docker Hub.
+	Registry string
+
+	// Repository is the name of the image within the registry.
+	Repository string
+
+	// Tag is the image tag, "latest" if the reference has none.
+	Tag string
+}
+
+// ParseReference parses a tagged image reference. References that are
+// already pinned to a digest are rejected.
+func ParseReference(ref string) (Reference, error) {
+	if ref == "" {
+		return Reference{}, errors.New("image reference is empty")
+	}
+	if strings.Contains(ref, "@") {
+		return Reference{}, fmt.Errorf("image reference [%s] is already pinned to a digest", ref)
+	}
+
+	name, tag := ref, defaultTag
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		name, tag = ref[:i], ref[i+1:]
+	}
+
+	registry, repository := dockerHubRegistry, name
+	if i := strings.Index(name, "/"); i >= 0 {
+		host := name[:i]
+		// As in Docker, the first component is a registry host if it looks
+		// like one.
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			registry, repository = host, name[i+1:]
+		}
+	}
+	if registry == dockerHubRegistry && !strings.Contains(repository, "/") {
+		repository = dockerHubNamespace + "/" + repository
+	}
+
+	if repository == "" || tag == "" {
+		return Reference{}, fmt.Errorf("invalid image reference [%s]", ref)
+	}
+
+	return Reference{Registry: registry, Repository: repository, Tag: tag}, nil
+}
+
+// Resolver resolves image tags to digests by querying the registries that
+// serve them, using the Docker Registry HTTP API V2.
+type Resolver struct {
+	client *http.Client
+}
+
+// NewResolver returns a Resolver that makes requests with client.
+func NewResolver(client *http.Client) *Resolver {
+	return &Resolver{client: client}
+}
+
+// Digest returns the digest (e.g. sha256:...) of the manifest that ref's tag
+// currently points to.
+func (r *Resolver) Digest(ref string) (string, error) {
+	parsed, err := ParseReference(ref)
+	if err != nil {
+		return "", err
+	}
+
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", parsed.Registry, parsed.Repository, parsed.Tag)
+	rsp, err := r.headManifest(manifestURL, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve [%s]: %s", ref, err)
+	}
+
+	// Registries that require a token, even for anonymous pulls, say where to
+	// get one.
+	if rsp.StatusCode == http.StatusUnauthorized {
+		token, err := r.fetchToken(rsp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", fmt.Errorf("failed to authenticate to resolve [%s]: %s", ref, err)
+		}
+		rsp, err = r.headManifest(manifestURL, token)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve [%s]: %s", ref, err)
+		}
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to resolve [%s]: registry returned HTTP status [%s]", ref, rsp.Status)
+	}
+
+	digest := rsp.Header.Get(digestHeader)
+	if err := validateDigest(digest); err != nil {
+		return "", fmt.Errorf("failed to resolve [%s]: %s", ref, err)
+	}
+	return digest, nil
+}
+
+func (r *Resolver) headManifest(manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rsp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	rsp.Body.Close()
+	return rsp, nil
+}
+
+// fetchToken obtains an anonymous bearer token as directed by a
+// `WWW-Authenticate: Bearer realm="...",service="...",scope="..."` challenge.
+func (r *Resolver) fetchToken(challenge string) (string, error) {
+	params, err := parseBearerChallenge(challenge)
+	if err != nil {
+		return "", err
+	}
+
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil || !tokenURL.IsAbs() {
+		return "", fmt.Errorf("invalid token realm [%s]", params["realm"])
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if value, ok := params[key]; ok {
+			query.Set(key, value)
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	rsp, err := r.client.Get(tokenURL.String())
+	if err != nil {
+		return "", err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request returned HTTP status [%s]", rsp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(rsp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("invalid token response: %s", err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", errors.New("token response did not contain a token")
+}
+
+func parseBearerChallenge(challenge string) (map[string]string, error) {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(challenge, prefix) {
+		return nil, fmt.Errorf("unsupported authentication challenge [%s]", challenge)
+	}
+
+	params := make(map[string]string)
+	for _, param := range strings.Split(strings.TrimPrefix(challenge, prefix), ",") {
+		parts := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		params[parts[0]] = strings.Trim(parts[1], `"`)
+	}
+	if params["realm"] == "" {
+		return nil, fmt.Errorf("authentication challenge [%s] has no realm", challenge)
+	}
+	return params, nil
+}
+
+// ReadDigestFile reads a file mapping tagged image references to digests, one
+// per line, e.g.:
+//
+//	gcr.io/linkerd-io/proxy:v18.8.1 sha256:4c2a...
+//
+// Blank lines and lines starting with # are ignored.
+func ReadDigestFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseDigests(file)
+}
+
+func parseDigests(r io.Reader) (map[string]string, error) {
+	digests := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected an image reference and a digest", lineNumber)
+		}
+		if err := validateDigest(fields[1]); err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNumber, err)
+		}
+		digests[fields[0]] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return digests, nil
+}
+
+// PinnedReference returns ref with its tag replaced by digest, e.g.
+// gcr.io/linkerd-io/proxy@sha256:4c2a...
+func PinnedReference(ref, digest string) string {
+	name := ref
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		name = ref[:i]
+	}
+	return name + "@" + digest
+}
+
+func validateDigest(digest string) error {
+	const prefix = "sha256:"
+	hex := strings.TrimPrefix(digest, prefix)
+	if !strings.HasPrefix(digest, prefix) || len(hex) != 64 || strings.Trim(hex, "0123456789abcdef") != "" {
+		return fmt.Errorf("invalid digest [%s], expected sha256:<64 hex characters>", digest)
+	}
+	return nil
+}
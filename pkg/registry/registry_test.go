@@ -0,0 +1,172 @@
+package registry
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	proxyDigest      = "sha256:4c2a6f1b3e8d0a5c7b9e1f3a5c7e9b1d3f5a7c9e1b3d5f7a9c1e3b5d7f9a1c3e"
+	prometheusDigest = "sha256:0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0"
+)
+
+// newMockRegistry returns a registry that serves the given manifest digests,
+// keyed by "<repository>:<tag>". If token is set, manifest requests must be
+// authenticated with it.
+func newMockRegistry(t *testing.T, digests map[string]string, token string) *httptest.Server {
+	var server *httptest.Server
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("service") != "mock-registry" {
+			http.Error(w, "unknown service", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"token": "%s"}`, token)
+	})
+
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			http.Error(w, "unexpected method", http.StatusMethodNotAllowed)
+			return
+		}
+		if !strings.Contains(r.Header.Get("Accept"), "application/vnd.docker.distribution.manifest.v2+json") {
+			http.Error(w, "unexpected Accept header", http.StatusBadRequest)
+			return
+		}
+
+		parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/v2/"), "/manifests/", 2)
+		if len(parts) != 2 {
+			http.NotFound(w, r)
+			return
+		}
+		repository, tag := parts[0], parts[1]
+
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="mock-registry",scope="repository:%s:pull"`, server.URL, repository))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		digest, ok := digests[repository+":"+tag]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set(digestHeader, digest)
+	})
+
+	server = httptest.NewTLSServer(mux)
+	return server
+}
+
+func TestParseReference(t *testing.T) {
+	testCases := []struct {
+		ref      string
+		expected Reference
+	}{
+		{"gcr.io/linkerd-io/proxy:v18.8.1", Reference{"gcr.io", "linkerd-io/proxy", "v18.8.1"}},
+		{"gcr.io/linkerd-io/proxy", Reference{"gcr.io", "linkerd-io/proxy", "latest"}},
+		{"localhost:5000/proxy:dev", Reference{"localhost:5000", "proxy", "dev"}},
+		{"prom/prometheus:v2.3.1", Reference{"registry-1.docker.io", "prom/prometheus", "v2.3.1"}},
+		{"nginx", Reference{"registry-1.docker.io", "library/nginx", "latest"}},
+	}
+
+	for _, tc := range testCases {
+		ref, err := ParseReference(tc.ref)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing [%s]: %v", tc.ref, err)
+		}
+		if ref != tc.expected {
+			t.Fatalf("Expected [%s] to parse as %+v, got %+v", tc.ref, tc.expected, ref)
+		}
+	}
+
+	for _, ref := range []string{"", "gcr.io/linkerd-io/proxy@" + proxyDigest, "gcr.io/linkerd-io/proxy:"} {
+		if _, err := ParseReference(ref); err == nil {
+			t.Fatalf("Expected error parsing [%s], got nil", ref)
+		}
+	}
+}
+
+func TestResolverDigest(t *testing.T) {
+	digests := map[string]string{
+		"linkerd-io/proxy:v18.8.1": proxyDigest,
+		"prom/prometheus:v2.3.1":   prometheusDigest,
+	}
+
+	for _, token := range []string{"", "anonymous-pull-token"} {
+		t.Run(fmt.Sprintf("token=%q", token), func(t *testing.T) {
+			server := newMockRegistry(t, digests, token)
+			defer server.Close()
+			registry := strings.TrimPrefix(server.URL, "https://")
+			resolver := NewResolver(server.Client())
+
+			digest, err := resolver.Digest(registry + "/linkerd-io/proxy:v18.8.1")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if digest != proxyDigest {
+				t.Fatalf("Expected digest [%s], got [%s]", proxyDigest, digest)
+			}
+
+			if _, err := resolver.Digest(registry + "/linkerd-io/proxy:unknown"); err == nil {
+				t.Fatalf("Expected error resolving an unknown tag, got nil")
+			}
+		})
+	}
+}
+
+func TestReadDigestFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "digests")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	content := fmt.Sprintf(`# linkerd images
+gcr.io/linkerd-io/proxy:v18.8.1 %s
+
+prom/prometheus:v2.3.1   %s
+`, proxyDigest, prometheusDigest)
+	if _, err := file.WriteString(content); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	file.Close()
+
+	digests, err := ReadDigestFile(file.Name())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[string]string{
+		"gcr.io/linkerd-io/proxy:v18.8.1": proxyDigest,
+		"prom/prometheus:v2.3.1":          prometheusDigest,
+	}
+	if !reflect.DeepEqual(digests, expected) {
+		t.Fatalf("Expected digests %v, got %v", expected, digests)
+	}
+
+	for _, bad := range []string{"gcr.io/linkerd-io/proxy:v18.8.1", "gcr.io/linkerd-io/proxy:v18.8.1 sha256:abc"} {
+		if _, err := parseDigests(strings.NewReader(bad)); err == nil {
+			t.Fatalf("Expected error parsing [%s], got nil", bad)
+		}
+	}
+}
+
+func TestPinnedReference(t *testing.T) {
+	testCases := map[string]string{
+		"gcr.io/linkerd-io/proxy:v18.8.1": "gcr.io/linkerd-io/proxy@" + proxyDigest,
+		"localhost:5000/proxy":            "localhost:5000/proxy@" + proxyDigest,
+	}
+	for ref, expected := range testCases {
+		if pinned := PinnedReference(ref, proxyDigest); pinned != expected {
+			t.Fatalf("Expected [%s] to be pinned as [%s], got [%s]", ref, expected, pinned)
+		}
+	}
+}
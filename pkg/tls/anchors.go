@@ -0,0 +1,114 @@
+package tls
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ParseTrustAnchors decodes a PEM bundle of one or more trust anchor (root)
+// certificates. Every PEM block in the bundle must be a valid certificate.
+func ParseTrustAnchors(bundle string) ([]*x509.Certificate, error) {
+	anchors := make([]*x509.Certificate, 0)
+
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("trust anchor bundle contains a PEM block of type [%s], expected only CERTIFICATE blocks", block.Type)
+		}
+
+		anchor, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse trust anchor %d: %s", len(anchors)+1, err)
+		}
+		anchors = append(anchors, anchor)
+	}
+
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, errors.New("trust anchor bundle contains data that is not PEM-encoded")
+	}
+	if len(anchors) == 0 {
+		return nil, errors.New("trust anchor bundle does not contain any certificates")
+	}
+
+	return anchors, nil
+}
+
+// EncodeTrustAnchors returns the PEM bundle of the given trust anchors.
+func EncodeTrustAnchors(anchors []*x509.Certificate) string {
+	var buf bytes.Buffer
+	for _, anchor := range anchors {
+		pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: anchor.Raw})
+	}
+	return buf.String()
+}
+
+// AppendTrustAnchors adds each of the new trust anchors to anchors, unless it
+// is already present.
+func AppendTrustAnchors(anchors []*x509.Certificate, newAnchors ...*x509.Certificate) []*x509.Certificate {
+	for _, newAnchor := range newAnchors {
+		found := false
+		for _, anchor := range anchors {
+			if anchor.Equal(newAnchor) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			anchors = append(anchors, newAnchor)
+		}
+	}
+	return anchors
+}
+
+// PruneExpiredTrustAnchors returns the trust anchors that are still valid at
+// now.
+func PruneExpiredTrustAnchors(anchors []*x509.Certificate, now time.Time) []*x509.Certificate {
+	valid := make([]*x509.Certificate, 0, len(anchors))
+	for _, anchor := range anchors {
+		if now.Before(anchor.NotAfter) {
+			valid = append(valid, anchor)
+		}
+	}
+	return valid
+}
+
+// ExpiringTrustAnchors returns the trust anchors that will have expired
+// within the given duration of now.
+func ExpiringTrustAnchors(anchors []*x509.Certificate, now time.Time, within time.Duration) []*x509.Certificate {
+	expiring := make([]*x509.Certificate, 0)
+	for _, anchor := range anchors {
+		if !now.Add(within).Before(anchor.NotAfter) {
+			expiring = append(expiring, anchor)
+		}
+	}
+	return expiring
+}
+
+// VerifyIssuer returns an error unless issuer chains to at least one of the
+// trust anchors. The issuer may itself be one of the trust anchors.
+func VerifyIssuer(issuer *x509.Certificate, anchors []*x509.Certificate, now time.Time) error {
+	roots := x509.NewCertPool()
+	for _, anchor := range anchors {
+		roots.AddCert(anchor)
+	}
+
+	_, err := issuer.Verify(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("issuer [%s] does not chain to any of the %d trust anchors: %s", issuer.Subject, len(anchors), err)
+	}
+
+	return nil
+}
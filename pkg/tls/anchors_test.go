@@ -0,0 +1,201 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
+)
+
+var testNow = time.Date(2018, 8, 1, 0, 0, 0, 0, time.UTC)
+
+type testCA struct {
+	crt *x509.Certificate
+	key *ecdsa.PrivateKey
+}
+
+// newTestCA creates a CA certificate named name that is valid until notAfter.
+// It is self-signed unless a parent is given.
+func newTestCA(t *testing.T, name string, notAfter time.Time, parent *testCA) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             testNow.Add(-24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	issuer, issuerKey := template, key
+	if parent != nil {
+		issuer, issuerKey = parent.crt, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, issuerKey)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	crt, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return &testCA{crt: crt, key: key}
+}
+
+func (ca *testCA) pem() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.crt.Raw}))
+}
+
+func TestParseTrustAnchors(t *testing.T) {
+	oldAnchor := newTestCA(t, "old", testNow.Add(365*24*time.Hour), nil)
+	newAnchor := newTestCA(t, "new", testNow.Add(2*365*24*time.Hour), nil)
+
+	t.Run("Parses a bundle of multiple trust anchors", func(t *testing.T) {
+		anchors, err := ParseTrustAnchors(oldAnchor.pem() + "\n" + newAnchor.pem())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(anchors) != 2 || !anchors[0].Equal(oldAnchor.crt) || !anchors[1].Equal(newAnchor.crt) {
+			t.Fatalf("Expected [old new] trust anchors, got %v", anchors)
+		}
+
+		if bundle := EncodeTrustAnchors(anchors); bundle != oldAnchor.pem()+newAnchor.pem() {
+			t.Fatalf("Expected trust anchors to be re-encoded as:\n%s\ngot:\n%s", oldAnchor.pem()+newAnchor.pem(), bundle)
+		}
+	})
+
+	t.Run("Rejects invalid bundles", func(t *testing.T) {
+		keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")}))
+		badCrtPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("crt")}))
+
+		testCases := map[string]string{
+			"empty":               "",
+			"not PEM":             "not a certificate",
+			"trailing garbage":    oldAnchor.pem() + "garbage",
+			"private key":         oldAnchor.pem() + keyPEM,
+			"invalid certificate": oldAnchor.pem() + badCrtPEM,
+		}
+
+		for name, bundle := range testCases {
+			if _, err := ParseTrustAnchors(bundle); err == nil {
+				t.Fatalf("Expected error for %s bundle, got nil", name)
+			}
+		}
+	})
+}
+
+func TestAppendTrustAnchors(t *testing.T) {
+	oldAnchor := newTestCA(t, "old", testNow.Add(365*24*time.Hour), nil)
+	newAnchor := newTestCA(t, "new", testNow.Add(2*365*24*time.Hour), nil)
+
+	anchors := AppendTrustAnchors([]*x509.Certificate{oldAnchor.crt}, newAnchor.crt, oldAnchor.crt, newAnchor.crt)
+	if len(anchors) != 2 || !anchors[0].Equal(oldAnchor.crt) || !anchors[1].Equal(newAnchor.crt) {
+		t.Fatalf("Expected [old new] trust anchors, got %v", anchors)
+	}
+}
+
+func TestTrustAnchorExpiry(t *testing.T) {
+	expired := newTestCA(t, "expired", testNow.Add(-time.Hour), nil)
+	expiring := newTestCA(t, "expiring", testNow.Add(24*time.Hour), nil)
+	valid := newTestCA(t, "valid", testNow.Add(365*24*time.Hour), nil)
+	anchors := []*x509.Certificate{expired.crt, expiring.crt, valid.crt}
+
+	unexpired := PruneExpiredTrustAnchors(anchors, testNow)
+	if len(unexpired) != 2 || !unexpired[0].Equal(expiring.crt) || !unexpired[1].Equal(valid.crt) {
+		t.Fatalf("Expected [expiring valid] trust anchors, got %v", unexpired)
+	}
+
+	soon := ExpiringTrustAnchors(anchors, testNow, 7*24*time.Hour)
+	if len(soon) != 2 || !soon[0].Equal(expired.crt) || !soon[1].Equal(expiring.crt) {
+		t.Fatalf("Expected [expired expiring] trust anchors, got %v", soon)
+	}
+}
+
+func TestVerifyIssuer(t *testing.T) {
+	oldAnchor := newTestCA(t, "old", testNow.Add(365*24*time.Hour), nil)
+	newAnchor := newTestCA(t, "new", testNow.Add(2*365*24*time.Hour), nil)
+	issuer := newTestCA(t, "issuer", testNow.Add(30*24*time.Hour), newAnchor)
+
+	testCases := []struct {
+		issuer  *x509.Certificate
+		anchors []*x509.Certificate
+		valid   bool
+	}{
+		{issuer.crt, []*x509.Certificate{oldAnchor.crt, newAnchor.crt}, true},
+		{issuer.crt, []*x509.Certificate{newAnchor.crt}, true},
+		{issuer.crt, []*x509.Certificate{oldAnchor.crt}, false},
+		{oldAnchor.crt, []*x509.Certificate{oldAnchor.crt, newAnchor.crt}, true},
+		{oldAnchor.crt, []*x509.Certificate{newAnchor.crt}, false},
+	}
+
+	for i, tc := range testCases {
+		err := VerifyIssuer(tc.issuer, tc.anchors, testNow)
+		if tc.valid && err != nil {
+			t.Fatalf("%d: Unexpected error: %v", i, err)
+		}
+		if !tc.valid && err == nil {
+			t.Fatalf("%d: Expected issuer [%s] not to chain to the trust anchors", i, tc.issuer.Subject)
+		}
+	}
+}
+
+func TestCheckTrustAnchors(t *testing.T) {
+	oldAnchor := newTestCA(t, "old", testNow.Add(7*24*time.Hour), nil)
+	newAnchor := newTestCA(t, "new", testNow.Add(365*24*time.Hour), nil)
+	bundle := oldAnchor.pem() + newAnchor.pem()
+
+	expectStatuses := func(t *testing.T, results []*healthcheckPb.CheckResult, expected ...healthcheckPb.CheckStatus) {
+		if len(results) != len(expected) {
+			t.Fatalf("Expected %d results, got %d: %v", len(expected), len(results), results)
+		}
+		for i, result := range results {
+			if result.Status != expected[i] {
+				t.Fatalf("Expected [%s] to be %s, got %s: %s",
+					result.CheckDescription, expected[i], result.Status, result.FriendlyMessageToUser)
+			}
+		}
+	}
+
+	t.Run("Passes when the issuer chains to a trust anchor", func(t *testing.T) {
+		results, warnings := CheckTrustAnchors(bundle, newAnchor.pem(), testNow)
+		expectStatuses(t, results, healthcheckPb.CheckStatus_OK, healthcheckPb.CheckStatus_OK)
+
+		if len(warnings) != 1 || !strings.Contains(warnings[0], "[CN=old]") {
+			t.Fatalf("Expected a warning about the old trust anchor, got %v", warnings)
+		}
+	})
+
+	t.Run("Fails when the issuer does not chain to any trust anchor", func(t *testing.T) {
+		other := newTestCA(t, "other", testNow.Add(365*24*time.Hour), nil)
+		results, _ := CheckTrustAnchors(bundle, other.pem(), testNow)
+		expectStatuses(t, results, healthcheckPb.CheckStatus_OK, healthcheckPb.CheckStatus_FAIL)
+	})
+
+	t.Run("Errors when the issuer is missing", func(t *testing.T) {
+		results, _ := CheckTrustAnchors(bundle, "", testNow)
+		expectStatuses(t, results, healthcheckPb.CheckStatus_OK, healthcheckPb.CheckStatus_ERROR)
+	})
+
+	t.Run("Errors when the bundle is invalid", func(t *testing.T) {
+		results, warnings := CheckTrustAnchors("not a bundle", newAnchor.pem(), testNow)
+		expectStatuses(t, results, healthcheckPb.CheckStatus_ERROR)
+		if len(warnings) != 0 {
+			t.Fatalf("Expected no warnings, got %v", warnings)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package tls
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	authV1 "k8s.io/api/authentication/v1"
+	authV1Client "k8s.io/client-go/kubernetes/typed/authentication/v1"
+)
+
+const (
+	// DefaultCertificateLifetime is the lifetime of leaf certificates issued by
+	// a Certifier, unless configured otherwise.
+	DefaultCertificateLifetime = 24 * time.Hour
+
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+	serviceAccountKind           = "serviceaccount"
+)
+
+// Signer issues a DER-encoded leaf certificate for the public key and
+// subjectAltNames of a certificate signing request.
+type Signer interface {
+	SignCSR(csr *x509.CertificateRequest, lifetime time.Duration) ([]byte, error)
+}
+
+// CertifyRequest is a request for a leaf certificate, sent by a proxy on
+// behalf of its pod.
+type CertifyRequest struct {
+	// Token is the pod's service account token.
+	Token string
+
+	// CSR is the ASN.1 DER-encoded (binary, not PEM) certificate signing
+	// request.
+	CSR []byte
+}
+
+// CertifyResponse holds a leaf certificate issued by a Certifier.
+type CertifyResponse struct {
+	// Certificate is the ASN.1 DER-encoded (binary, not PEM) leaf certificate.
+	Certificate []byte
+
+	// Identity is the identity that the certificate is valid for.
+	Identity string
+
+	// ValidUntil is the time after which the certificate should no longer be
+	// used; proxies are expected to request a new certificate before then.
+	ValidUntil time.Time
+}
+
+// Certifier validates that a proxy is entitled to the identity it requests
+// before signing its certificate signing request. A proxy is entitled to the
+// identity of its pod's service account, as established by a Kubernetes
+// TokenReview of the pod's service account token.
+//
+// A token may be presented any number of times: the replicas of a workload
+// share their service account's token, and proxies present it again each time
+// they renew their certificates.
+type Certifier struct {
+	controllerNamespace string
+	clusterDNSDomain    string
+	lifetime            time.Duration
+	reviewer            authV1Client.TokenReviewInterface
+	signer              Signer
+	metrics             *CertificateMetrics
+}
+
+// NewCertifier returns a Certifier that issues certificates valid for
+// lifetime. A lifetime of zero means DefaultCertificateLifetime.
+func NewCertifier(controllerNamespace, clusterDNSDomain string, lifetime time.Duration, reviewer authV1Client.TokenReviewInterface, signer Signer) *Certifier {
+	if lifetime == 0 {
+		lifetime = DefaultCertificateLifetime
+	}
+
+	return &Certifier{
+		controllerNamespace: controllerNamespace,
+		clusterDNSDomain:    clusterDNSDomain,
+		lifetime:            lifetime,
+		reviewer:            reviewer,
+		signer:              signer,
+	}
+}
+
+// WithMetrics configures the Certifier to record the outcome of each request
+// in metrics.
+func (c *Certifier) WithMetrics(metrics *CertificateMetrics) *Certifier {
+	c.metrics = metrics
+	return c
+}
+
+// Certify validates req and, if the requester is entitled to the identity
+// named in the CSR, returns a newly-issued leaf certificate. Requests that are
+// refused are reported with a *RejectionError.
+func (c *Certifier) Certify(req *CertifyRequest) (*CertifyResponse, error) {
+	start := time.Now()
+	rsp, err := c.certify(req)
+	c.metrics.ObserveIssuance(start, err)
+	return rsp, err
+}
+
+func (c *Certifier) certify(req *CertifyRequest) (*CertifyResponse, error) {
+	if req.Token == "" {
+		return nil, reject(ReasonUnauthenticated, "no service account token provided")
+	}
+
+	csr, err := x509.ParseCertificateRequest(req.CSR)
+	if err != nil {
+		return nil, reject(ReasonInvalidCSR, "failed to parse CSR: %s", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, reject(ReasonInvalidCSR, "invalid CSR signature: %s", err)
+	}
+
+	identity, err := c.reviewToken(req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSANs(csr, identity); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	crt, err := c.signer.SignCSR(csr, c.lifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("issued certificate for %s", identity)
+	return &CertifyResponse{
+		Certificate: crt,
+		Identity:    identity,
+		ValidUntil:  now.Add(c.lifetime),
+	}, nil
+}
+
+// reviewToken validates token with the Kubernetes API and returns the identity
+// of the service account that it belongs to.
+func (c *Certifier) reviewToken(token string) (string, error) {
+	review, err := c.reviewer.Create(&authV1.TokenReview{
+		Spec: authV1.TokenReviewSpec{Token: token},
+	})
+	if err != nil {
+		return "", reject(ReasonTokenReviewFailed, "failed to review token: %s", err)
+	}
+
+	if review.Status.Error != "" {
+		return "", reject(ReasonUnauthenticated, "token could not be authenticated: %s", review.Status.Error)
+	}
+	if !review.Status.Authenticated {
+		return "", reject(ReasonUnauthenticated, "token could not be authenticated")
+	}
+
+	username := review.Status.User.Username
+	if !strings.HasPrefix(username, serviceAccountUsernamePrefix) {
+		return "", reject(ReasonNotServiceAccount, "token belongs to [%s], which is not a service account", username)
+	}
+
+	// system:serviceaccount:<namespace>:<name>
+	parts := strings.Split(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", reject(ReasonNotServiceAccount, "malformed service account username [%s]", username)
+	}
+
+	return ServiceAccountIdentity(parts[0], parts[1], c.controllerNamespace, c.clusterDNSDomain), nil
+}
+
+// ServiceAccountIdentity returns the identity that a proxy running as the
+// given service account is entitled to.
+func ServiceAccountIdentity(namespace, serviceAccount, controllerNamespace, clusterDNSDomain string) string {
+	return k8s.TLSIdentity{
+		Name:                serviceAccount,
+		Kind:                serviceAccountKind,
+		Namespace:           namespace,
+		ControllerNamespace: controllerNamespace,
+		ClusterDNSDomain:    clusterDNSDomain,
+	}.ToDNSName()
+}
+
+// validateSANs ensures that the only subjectAltName in csr is identity.
+func validateSANs(csr *x509.CertificateRequest, identity string) error {
+	if len(csr.IPAddresses) > 0 || len(csr.EmailAddresses) > 0 || len(csr.URIs) > 0 {
+		return reject(ReasonIdentityMismatch, "CSR may only contain a DNS subjectAltName")
+	}
+
+	if len(csr.DNSNames) != 1 || csr.DNSNames[0] != identity {
+		return reject(ReasonIdentityMismatch, "CSR requests %v but token is only entitled to [%s]", csr.DNSNames, identity)
+	}
+
+	return nil
+}
+
+// RejectionReason describes why a Certifier refused to issue a certificate.
+type RejectionReason string
+
+const (
+	// ReasonInvalidCSR indicates that the CSR could not be parsed or that its
+	// signature is invalid.
+	ReasonInvalidCSR RejectionReason = "InvalidCSR"
+
+	// ReasonTokenReviewFailed indicates that the Kubernetes API could not
+	// review the token.
+	ReasonTokenReviewFailed RejectionReason = "TokenReviewFailed"
+
+	// ReasonUnauthenticated indicates that the token is missing, invalid, or
+	// expired.
+	ReasonUnauthenticated RejectionReason = "Unauthenticated"
+
+	// ReasonNotServiceAccount indicates that the token does not belong to a
+	// service account.
+	ReasonNotServiceAccount RejectionReason = "NotServiceAccount"
+
+	// ReasonIdentityMismatch indicates that the CSR requests an identity other
+	// than the one the token is entitled to.
+	ReasonIdentityMismatch RejectionReason = "IdentityMismatch"
+)
+
+// RejectionError is returned by Certify when a certificate is refused.
+type RejectionError struct {
+	Reason  RejectionReason
+	Message string
+}
+
+func (e *RejectionError) Error() string {
+	return fmt.Sprintf("certificate request rejected (%s): %s", e.Reason, e.Message)
+}
+
+func reject(reason RejectionReason, format string, args ...interface{}) *RejectionError {
+	return &RejectionError{
+		Reason:  reason,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,185 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	authV1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+type fakeSigner struct {
+	signed []*x509.CertificateRequest
+}
+
+func (s *fakeSigner) SignCSR(csr *x509.CertificateRequest, lifetime time.Duration) ([]byte, error) {
+	s.signed = append(s.signed, csr)
+	return []byte(fmt.Sprintf("certificate for %v", csr.DNSNames)), nil
+}
+
+// newFakeCertifier returns a Certifier whose TokenReviews are answered from
+// the given map of token to review status.
+func newFakeCertifier(statuses map[string]authV1.TokenReviewStatus) (*Certifier, *fakeSigner) {
+	clientSet := fake.NewSimpleClientset()
+	clientSet.PrependReactor("create", "tokenreviews", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8sTesting.CreateAction).GetObject().(*authV1.TokenReview)
+		status, ok := statuses[review.Spec.Token]
+		if !ok {
+			return true, &authV1.TokenReview{}, errors.New("unknown token")
+		}
+		review.Status = status
+		return true, review, nil
+	})
+
+	signer := &fakeSigner{}
+	return NewCertifier("linkerd", "", time.Hour, clientSet.AuthenticationV1().TokenReviews(), signer), signer
+}
+
+func newCSR(t *testing.T, template *x509.CertificateRequest) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return csr
+}
+
+func authenticated(username string) authV1.TokenReviewStatus {
+	return authV1.TokenReviewStatus{
+		Authenticated: true,
+		User:          authV1.UserInfo{Username: username},
+	}
+}
+
+func expectRejection(t *testing.T, err error, reason RejectionReason) {
+	rejection, ok := err.(*RejectionError)
+	if !ok {
+		t.Fatalf("Expected RejectionError with reason [%s], got: %v", reason, err)
+	}
+	if rejection.Reason != reason {
+		t.Fatalf("Expected rejection reason [%s], got [%s]: %s", reason, rejection.Reason, rejection.Message)
+	}
+}
+
+func TestCertify(t *testing.T) {
+	webIdentity := "web.serviceaccount.emojivoto.linkerd-managed.linkerd.svc.cluster.local"
+	statuses := map[string]authV1.TokenReviewStatus{
+		"web-token":     authenticated("system:serviceaccount:emojivoto:web"),
+		"user-token":    authenticated("jane@example.com"),
+		"expired-token": {Authenticated: false, Error: "token has expired"},
+	}
+
+	t.Run("Derives the identity from the service account", func(t *testing.T) {
+		identity := ServiceAccountIdentity("emojivoto", "web", "linkerd", "")
+		if identity != webIdentity {
+			t.Fatalf("Expected identity [%s], got [%s]", webIdentity, identity)
+		}
+
+		expected := "web.serviceaccount.emojivoto.linkerd-managed.linkerd.svc.example.org"
+		if identity := ServiceAccountIdentity("emojivoto", "web", "linkerd", "example.org"); identity != expected {
+			t.Fatalf("Expected identity [%s], got [%s]", expected, identity)
+		}
+	})
+
+	t.Run("Issues a certificate for a matching identity", func(t *testing.T) {
+		certifier, signer := newFakeCertifier(statuses)
+		csr := newCSR(t, &x509.CertificateRequest{DNSNames: []string{webIdentity}})
+
+		before := time.Now()
+		rsp, err := certifier.Certify(&CertifyRequest{Token: "web-token", CSR: csr})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if rsp.Identity != webIdentity {
+			t.Fatalf("Expected identity [%s], got [%s]", webIdentity, rsp.Identity)
+		}
+		if len(signer.signed) != 1 {
+			t.Fatalf("Expected 1 CSR to be signed, got %d", len(signer.signed))
+		}
+		if rsp.ValidUntil.Before(before.Add(time.Hour)) || rsp.ValidUntil.After(time.Now().Add(time.Hour)) {
+			t.Fatalf("Expected certificate to be valid for 1h, was valid until %s", rsp.ValidUntil)
+		}
+	})
+
+	t.Run("Accepts a token more than once", func(t *testing.T) {
+		certifier, signer := newFakeCertifier(statuses)
+
+		// Replicas share their service account's token, and each renewal
+		// presents it again, with the same or a new key.
+		first := newCSR(t, &x509.CertificateRequest{DNSNames: []string{webIdentity}})
+		second := newCSR(t, &x509.CertificateRequest{DNSNames: []string{webIdentity}})
+		for _, csr := range [][]byte{first, first, second} {
+			if _, err := certifier.Certify(&CertifyRequest{Token: "web-token", CSR: csr}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		if len(signer.signed) != 3 {
+			t.Fatalf("Expected 3 CSRs to be signed, got %d", len(signer.signed))
+		}
+	})
+
+	t.Run("Rejects CSRs that do not match the token's identity", func(t *testing.T) {
+		testCases := []*x509.CertificateRequest{
+			{DNSNames: []string{"voting.serviceaccount.emojivoto.linkerd-managed.linkerd.svc.cluster.local"}},
+			{DNSNames: []string{webIdentity, "evil.example.com"}},
+			{DNSNames: []string{webIdentity}, EmailAddresses: []string{"web@example.com"}},
+			{},
+		}
+
+		for i, template := range testCases {
+			t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+				certifier, signer := newFakeCertifier(statuses)
+				csr := newCSR(t, template)
+
+				_, err := certifier.Certify(&CertifyRequest{Token: "web-token", CSR: csr})
+				expectRejection(t, err, ReasonIdentityMismatch)
+				if len(signer.signed) != 0 {
+					t.Fatalf("Expected no CSRs to be signed, got %d", len(signer.signed))
+				}
+			})
+		}
+	})
+
+	t.Run("Rejects an expired token", func(t *testing.T) {
+		certifier, _ := newFakeCertifier(statuses)
+		csr := newCSR(t, &x509.CertificateRequest{DNSNames: []string{webIdentity}})
+
+		_, err := certifier.Certify(&CertifyRequest{Token: "expired-token", CSR: csr})
+		expectRejection(t, err, ReasonUnauthenticated)
+	})
+
+	t.Run("Rejects a token that does not belong to a service account", func(t *testing.T) {
+		certifier, _ := newFakeCertifier(statuses)
+		csr := newCSR(t, &x509.CertificateRequest{DNSNames: []string{webIdentity}})
+
+		_, err := certifier.Certify(&CertifyRequest{Token: "user-token", CSR: csr})
+		expectRejection(t, err, ReasonNotServiceAccount)
+	})
+
+	t.Run("Rejects a token that cannot be reviewed", func(t *testing.T) {
+		certifier, _ := newFakeCertifier(statuses)
+		csr := newCSR(t, &x509.CertificateRequest{DNSNames: []string{webIdentity}})
+
+		_, err := certifier.Certify(&CertifyRequest{Token: "unknown-token", CSR: csr})
+		expectRejection(t, err, ReasonTokenReviewFailed)
+	})
+
+	t.Run("Rejects a malformed CSR", func(t *testing.T) {
+		certifier, _ := newFakeCertifier(statuses)
+
+		_, err := certifier.Certify(&CertifyRequest{Token: "web-token", CSR: []byte("not a csr")})
+		expectRejection(t, err, ReasonInvalidCSR)
+	})
+}
@@ -0,0 +1,72 @@
+package tls
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
+)
+
+const (
+	TrustAnchorsSubsystemName         = "linkerd-identity"
+	TrustAnchorsValidCheckDescription = "trust anchors are valid"
+	IssuerCheckDescription            = "issuer chains to a trust anchor"
+
+	// TrustAnchorExpiryWarningPeriod is how long before a trust anchor expires
+	// that CheckTrustAnchors starts warning about it.
+	TrustAnchorExpiryWarningPeriod = 30 * 24 * time.Hour
+)
+
+// CheckTrustAnchors validates a PEM bundle of trust anchors and verifies that
+// the PEM-encoded issuer certificate chains to one of them.
+//
+// A trust anchor that is close to expiry is not a failure, since the bundle
+// may already contain its replacement, so it is reported as a warning rather
+// than as a check result.
+func CheckTrustAnchors(bundle, issuer string, now time.Time) ([]*healthcheckPb.CheckResult, []string) {
+	validCheck := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    TrustAnchorsSubsystemName,
+		CheckDescription: TrustAnchorsValidCheckDescription,
+	}
+
+	anchors, err := ParseTrustAnchors(bundle)
+	if err != nil {
+		validCheck.Status = healthcheckPb.CheckStatus_ERROR
+		validCheck.FriendlyMessageToUser = err.Error()
+		return []*healthcheckPb.CheckResult{validCheck}, nil
+	}
+
+	warnings := make([]string, 0)
+	for _, anchor := range ExpiringTrustAnchors(anchors, now, TrustAnchorExpiryWarningPeriod) {
+		warnings = append(warnings, fmt.Sprintf("trust anchor [%s] expires at %s",
+			anchor.Subject, anchor.NotAfter.UTC().Format(time.RFC3339)))
+	}
+
+	issuerCheck := &healthcheckPb.CheckResult{
+		Status:           healthcheckPb.CheckStatus_OK,
+		SubsystemName:    TrustAnchorsSubsystemName,
+		CheckDescription: IssuerCheckDescription,
+	}
+	issuerCrt, err := parseCertificatePEM(issuer)
+	if err != nil {
+		issuerCheck.Status = healthcheckPb.CheckStatus_ERROR
+		issuerCheck.FriendlyMessageToUser = fmt.Sprintf("the issuer certificate is invalid: %s", err)
+	} else if err := VerifyIssuer(issuerCrt, anchors, now); err != nil {
+		issuerCheck.Status = healthcheckPb.CheckStatus_FAIL
+		issuerCheck.FriendlyMessageToUser = err.Error()
+	}
+
+	return []*healthcheckPb.CheckResult{validCheck, issuerCheck}, warnings
+}
+
+func parseCertificatePEM(crtPEM string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(crtPEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("expected a PEM-encoded CERTIFICATE")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,172 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	cryptoTls "crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"time"
+)
+
+// InternalCA issues the serving certificates that control plane components use
+// to talk to each other over TLS. Unlike the proxies' CA, it only exists for
+// as long as it takes to render the install configuration; its private key is
+// never persisted.
+type InternalCA struct {
+	crt              *x509.Certificate
+	key              *ecdsa.PrivateKey
+	validity         time.Duration
+	nextSerialNumber int64
+}
+
+// ServingCertificate is a PEM-encoded certificate and private key.
+type ServingCertificate struct {
+	CertificatePEM string
+	PrivateKeyPEM  string
+}
+
+// NewInternalCA creates a self-signed CA, named commonName, whose certificate
+// and the certificates it issues are valid for the given duration.
+func NewInternalCA(commonName string, validity time.Duration) (*InternalCA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := &InternalCA{key: key, validity: validity, nextSerialNumber: 1}
+
+	template := ca.createTemplate()
+	template.Subject = pkix.Name{CommonName: commonName}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	ca.crt, err = x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return ca, nil
+}
+
+// CertificatePEM returns the PEM-encoded certificate of the CA, which clients
+// use to verify the serving certificates that it issues.
+func (ca *InternalCA) CertificatePEM() string {
+	return EncodeTrustAnchors([]*x509.Certificate{ca.crt})
+}
+
+// IssueServingCertificate creates a new key pair and a certificate for it that
+// is valid for serving TLS as dnsName.
+func (ca *InternalCA) IssueServingCertificate(dnsName string) (*ServingCertificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	template := ca.createTemplate()
+	template.Subject = pkix.Name{CommonName: dnsName}
+	template.DNSNames = []string{dnsName}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, ca.crt, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, err
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServingCertificate{
+		CertificatePEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		PrivateKeyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})),
+	}, nil
+}
+
+func (ca *InternalCA) createTemplate() x509.Certificate {
+	serialNumber := big.NewInt(ca.nextSerialNumber)
+	ca.nextSerialNumber++
+
+	now := time.Now()
+	return x509.Certificate{
+		SerialNumber:       serialNumber,
+		SignatureAlgorithm: x509.ECDSAWithSHA256,
+		NotBefore:          now.Add(-time.Hour),
+		NotAfter:           now.Add(ca.validity),
+	}
+}
+
+// NewServerTLSConfig returns the TLS configuration for a control plane
+// component serving with the PEM-encoded certificate and private key in the
+// given files.
+func NewServerTLSConfig(certFile, keyFile string) (*cryptoTls.Config, error) {
+	crt, err := cryptoTls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load serving certificate: %s", err)
+	}
+
+	return &cryptoTls.Config{
+		Certificates: []cryptoTls.Certificate{crt},
+		MinVersion:   cryptoTls.VersionTLS12,
+	}, nil
+}
+
+// NewClientTLSConfig returns the TLS configuration for a control plane
+// component connecting to serverName, which must present a certificate issued
+// by one of the PEM-encoded CA certificates in caFile.
+func NewClientTLSConfig(caFile, serverName string) (*cryptoTls.Config, error) {
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %s", err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no CA certificates found in %s", caFile)
+	}
+
+	return &cryptoTls.Config{
+		RootCAs:    roots,
+		ServerName: serverName,
+		MinVersion: cryptoTls.VersionTLS12,
+	}, nil
+}
+
+// VerifyServingCertificate returns an error unless the PEM-encoded certificate
+// is valid for serving TLS as serverName and was issued by one of the
+// PEM-encoded CA certificates.
+func VerifyServingCertificate(crtPEM, caPEM []byte, serverName string, now time.Time) error {
+	crt, err := parseCertificatePEM(string(crtPEM))
+	if err != nil {
+		return fmt.Errorf("the serving certificate is invalid: %s", err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return errors.New("no CA certificates found")
+	}
+
+	_, err = crt.Verify(x509.VerifyOptions{
+		DNSName:     serverName,
+		Roots:       roots,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	if err != nil {
+		return fmt.Errorf("serving certificate [%s] is not valid for %s: %s", crt.Subject, serverName, err)
+	}
+
+	return nil
+}
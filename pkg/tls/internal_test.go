@@ -0,0 +1,131 @@
+package tls
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testServerName = "api.linkerd.svc.cluster.local"
+
+// writeServingFixtures issues a serving certificate for serverName from a new
+// CA and writes the CA certificate, the serving certificate, and its key to
+// the named subdirectory of dir.
+func writeServingFixtures(t *testing.T, dir, name, serverName string) (caFile, crtFile, keyFile string) {
+	dir = filepath.Join(dir, name)
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ca, err := NewInternalCA("test-ca", time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	crt, err := ca.IssueServingCertificate(serverName)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	caFile = filepath.Join(dir, "ca.crt")
+	crtFile = filepath.Join(dir, "api.crt")
+	keyFile = filepath.Join(dir, "api.key")
+	for file, content := range map[string]string{
+		caFile:  ca.CertificatePEM(),
+		crtFile: crt.CertificatePEM,
+		keyFile: crt.PrivateKeyPEM,
+	} {
+		if err := ioutil.WriteFile(file, []byte(content), 0600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	return caFile, crtFile, keyFile
+}
+
+func TestInternalTLSHandshake(t *testing.T) {
+	dir, err := ioutil.TempDir("", "internal-tls")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	caFile, crtFile, keyFile := writeServingFixtures(t, dir, "api", testServerName)
+	otherCAFile, _, _ := writeServingFixtures(t, dir, "other", testServerName)
+
+	serverConfig, err := NewServerTLSConfig(crtFile, keyFile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	server.TLS = serverConfig
+	server.StartTLS()
+	defer server.Close()
+
+	testCases := []struct {
+		name       string
+		caFile     string
+		serverName string
+		err        string
+	}{
+		{"verifies the server", caFile, testServerName, ""},
+		{"rejects the wrong server name", caFile, "web.linkerd.svc.cluster.local", "certificate is valid for"},
+		{"rejects the wrong CA", otherCAFile, testServerName, "certificate signed by unknown authority"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clientConfig, err := NewClientTLSConfig(tc.caFile, tc.serverName)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientConfig}}
+
+			rsp, err := client.Get(server.URL)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				rsp.Body.Close()
+				return
+			}
+			if err == nil {
+				rsp.Body.Close()
+				t.Fatalf("Expected handshake to fail with [%s], got nil", tc.err)
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("Expected handshake to fail with [%s], got: %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestVerifyServingCertificate(t *testing.T) {
+	ca, err := NewInternalCA("test-ca", time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	crt, err := ca.IssueServingCertificate(testServerName)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	caPEM := []byte(ca.CertificatePEM())
+	crtPEM := []byte(crt.CertificatePEM)
+
+	if err := VerifyServingCertificate(crtPEM, caPEM, testServerName, time.Now()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := VerifyServingCertificate(crtPEM, caPEM, "other", time.Now()); err == nil {
+		t.Fatalf("Expected certificate not to be valid for the wrong server name")
+	}
+	if err := VerifyServingCertificate(crtPEM, caPEM, testServerName, time.Now().Add(2*time.Hour)); err == nil {
+		t.Fatalf("Expected certificate not to be valid after it expires")
+	}
+}
@@ -0,0 +1,126 @@
+package tls
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// failureReasonSigningFailed is the reason recorded for issuance failures that
+// are not rejections, e.g. when the CA fails to sign a certificate.
+const failureReasonSigningFailed = "SigningFailed"
+
+// CertificateMetrics exposes Prometheus metrics about the certificates that
+// the identity component distributes and issues. None of the metrics are
+// labeled by pod or identity, so their cardinality does not grow with the
+// size of the mesh.
+//
+// A nil *CertificateMetrics is valid and records nothing.
+type CertificateMetrics struct {
+	trustAnchorExpiry *prometheus.GaugeVec
+	issuerExpiry      prometheus.Gauge
+	issued            prometheus.Counter
+	failures          *prometheus.CounterVec
+	issuanceDuration  prometheus.Histogram
+}
+
+// NewCertificateMetrics returns a new, unregistered, set of certificate
+// metrics.
+func NewCertificateMetrics() *CertificateMetrics {
+	return &CertificateMetrics{
+		trustAnchorExpiry: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "identity_trust_anchor_expiry_timestamp_seconds",
+				Help: "The time at which each distributed trust anchor expires, in seconds since the epoch.",
+			},
+			[]string{"subject"},
+		),
+		issuerExpiry: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "identity_issuer_expiry_timestamp_seconds",
+				Help: "The time at which the certificate used to issue proxy certificates expires, in seconds since the epoch.",
+			},
+		),
+		issued: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "identity_certificates_issued_total",
+				Help: "A counter of issued proxy certificates.",
+			},
+		),
+		failures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "identity_certificate_issuance_failures_total",
+				Help: "A counter of failed proxy certificate issuances, by reason.",
+			},
+			[]string{"reason"},
+		),
+		issuanceDuration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "identity_certificate_issuance_duration_seconds",
+				Help:    "A histogram of the time taken to issue proxy certificates, in seconds.",
+				Buckets: prometheus.ExponentialBuckets(0.001, 2, 12),
+			},
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *CertificateMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.trustAnchorExpiry.Describe(ch)
+	m.issuerExpiry.Describe(ch)
+	m.issued.Describe(ch)
+	m.failures.Describe(ch)
+	m.issuanceDuration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *CertificateMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.trustAnchorExpiry.Collect(ch)
+	m.issuerExpiry.Collect(ch)
+	m.issued.Collect(ch)
+	m.failures.Collect(ch)
+	m.issuanceDuration.Collect(ch)
+}
+
+// SetTrustAnchors records the expiry of each of the trust anchors, replacing
+// any previously recorded trust anchors.
+func (m *CertificateMetrics) SetTrustAnchors(anchors []*x509.Certificate) {
+	if m == nil {
+		return
+	}
+
+	m.trustAnchorExpiry.Reset()
+	for _, anchor := range anchors {
+		m.trustAnchorExpiry.WithLabelValues(anchor.Subject.String()).Set(float64(anchor.NotAfter.Unix()))
+	}
+}
+
+// SetIssuer records the expiry of the issuer certificate.
+func (m *CertificateMetrics) SetIssuer(issuer *x509.Certificate) {
+	if m == nil {
+		return
+	}
+
+	m.issuerExpiry.Set(float64(issuer.NotAfter.Unix()))
+}
+
+// ObserveIssuance records the outcome of a certificate issuance that started
+// at start. Failures are counted by their RejectionReason.
+func (m *CertificateMetrics) ObserveIssuance(start time.Time, err error) {
+	if m == nil {
+		return
+	}
+
+	if err != nil {
+		reason := failureReasonSigningFailed
+		if rejection, ok := err.(*RejectionError); ok {
+			reason = string(rejection.Reason)
+		}
+		m.failures.WithLabelValues(reason).Inc()
+		return
+	}
+
+	m.issued.Inc()
+	m.issuanceDuration.Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,126 @@
+package tls
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	authV1 "k8s.io/api/authentication/v1"
+)
+
+// gatherMetrics returns the metrics collected from m, keyed by name.
+func gatherMetrics(t *testing.T, m *CertificateMetrics) map[string][]*dto.Metric {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(m)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	metrics := make(map[string][]*dto.Metric)
+	for _, family := range families {
+		metrics[family.GetName()] = family.GetMetric()
+	}
+	return metrics
+}
+
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+func TestCertificateMetrics(t *testing.T) {
+	webIdentity := "web.serviceaccount.emojivoto.linkerd-managed.linkerd.svc.cluster.local"
+	statuses := map[string]authV1.TokenReviewStatus{
+		"web-token":  authenticated("system:serviceaccount:emojivoto:web"),
+		"user-token": authenticated("jane@example.com"),
+	}
+
+	t.Run("Records issued certificates", func(t *testing.T) {
+		metrics := NewCertificateMetrics()
+		certifier, _ := newFakeCertifier(statuses)
+		certifier.WithMetrics(metrics)
+
+		csr := newCSR(t, &x509.CertificateRequest{DNSNames: []string{webIdentity}})
+		if _, err := certifier.Certify(&CertifyRequest{Token: "web-token", CSR: csr}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		gathered := gatherMetrics(t, metrics)
+		if issued := gathered["identity_certificates_issued_total"]; len(issued) != 1 || issued[0].GetCounter().GetValue() != 1 {
+			t.Fatalf("Expected 1 issued certificate, got %v", issued)
+		}
+		if duration := gathered["identity_certificate_issuance_duration_seconds"]; len(duration) != 1 || duration[0].GetHistogram().GetSampleCount() != 1 {
+			t.Fatalf("Expected 1 issuance duration sample, got %v", duration)
+		}
+		if failures := gathered["identity_certificate_issuance_failures_total"]; len(failures) != 0 {
+			t.Fatalf("Expected no issuance failures, got %v", failures)
+		}
+	})
+
+	t.Run("Records issuance failures by reason", func(t *testing.T) {
+		metrics := NewCertificateMetrics()
+		certifier, _ := newFakeCertifier(statuses)
+		certifier.WithMetrics(metrics)
+
+		csr := newCSR(t, &x509.CertificateRequest{DNSNames: []string{webIdentity}})
+		for _, token := range []string{"user-token", "user-token", "unknown-token"} {
+			if _, err := certifier.Certify(&CertifyRequest{Token: token, CSR: csr}); err == nil {
+				t.Fatalf("Expected token [%s] to be rejected", token)
+			}
+		}
+
+		gathered := gatherMetrics(t, metrics)
+		failures := make(map[string]float64)
+		for _, metric := range gathered["identity_certificate_issuance_failures_total"] {
+			failures[labelValue(metric, "reason")] = metric.GetCounter().GetValue()
+		}
+		if len(failures) != 2 ||
+			failures[string(ReasonNotServiceAccount)] != 2 ||
+			failures[string(ReasonTokenReviewFailed)] != 1 {
+			t.Fatalf("Expected 2 NotServiceAccount and 1 TokenReviewFailed failures, got %v", failures)
+		}
+		if issued := gathered["identity_certificates_issued_total"]; len(issued) != 1 || issued[0].GetCounter().GetValue() != 0 {
+			t.Fatalf("Expected no issued certificates, got %v", issued)
+		}
+	})
+
+	t.Run("Records trust anchor and issuer expiry", func(t *testing.T) {
+		oldAnchor := newTestCA(t, "old", testNow.Add(24*time.Hour), nil)
+		newAnchor := newTestCA(t, "new", testNow.Add(365*24*time.Hour), nil)
+
+		metrics := NewCertificateMetrics()
+		metrics.SetTrustAnchors([]*x509.Certificate{newAnchor.crt})
+		metrics.SetTrustAnchors([]*x509.Certificate{oldAnchor.crt, newAnchor.crt})
+		metrics.SetIssuer(newAnchor.crt)
+
+		gathered := gatherMetrics(t, metrics)
+		expiries := make(map[string]float64)
+		for _, metric := range gathered["identity_trust_anchor_expiry_timestamp_seconds"] {
+			expiries[labelValue(metric, "subject")] = metric.GetGauge().GetValue()
+		}
+		if len(expiries) != 2 ||
+			expiries["CN=old"] != float64(oldAnchor.crt.NotAfter.Unix()) ||
+			expiries["CN=new"] != float64(newAnchor.crt.NotAfter.Unix()) {
+			t.Fatalf("Expected expiry of the old and new trust anchors, got %v", expiries)
+		}
+
+		issuer := gathered["identity_issuer_expiry_timestamp_seconds"]
+		if len(issuer) != 1 || issuer[0].GetGauge().GetValue() != float64(newAnchor.crt.NotAfter.Unix()) {
+			t.Fatalf("Expected issuer expiry of %d, got %v", newAnchor.crt.NotAfter.Unix(), issuer)
+		}
+	})
+
+	t.Run("A nil CertificateMetrics records nothing", func(t *testing.T) {
+		var metrics *CertificateMetrics
+		metrics.ObserveIssuance(time.Now(), nil)
+		metrics.SetTrustAnchors(nil)
+	})
+}
@@ -18,6 +18,12 @@ import (
 // This var is updated automatically as part of the build process
 var Version = undefinedVersion
 
+// CNIPluginSHA256 is the SHA-256 hash of the linkerd-cni plugin binary of the
+// release, which `linkerd check` verifies the installed plugin binaries
+// against. Like Version, it's set as part of the build process; builds that
+// don't set it leave it empty.
+var CNIPluginSHA256 = ""
+
 const (
 	undefinedVersion             = "undefined"
 	VersionSubsystemName         = "linkerd-version"
@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"net"
+	"os"
+	"strings"
 
 	"github.com/linkerd/linkerd2/proxy-init/iptables"
 	"github.com/spf13/cobra"
@@ -14,7 +17,13 @@ type rootOptions struct {
 	portsToRedirect       []int
 	inboundPortsToIgnore  []int
 	outboundPortsToIgnore []int
+	skipOwnerUids         []int
+	skipOwnerGids         []int
+	skipOutboundCidrs     []string
+	iptablesMode          string
+	ipv6Mode              string
 	simulateOnly          bool
+	cleanup               bool
 }
 
 func newRootOptions() *rootOptions {
@@ -25,10 +34,36 @@ func newRootOptions() *rootOptions {
 		portsToRedirect:       make([]int, 0),
 		inboundPortsToIgnore:  make([]int, 0),
 		outboundPortsToIgnore: make([]int, 0),
+		skipOwnerUids:         make([]int, 0),
+		skipOwnerGids:         make([]int, 0),
+		skipOutboundCidrs:     make([]string, 0),
+		iptablesMode:          defaultIptablesMode(),
+		ipv6Mode:              defaultIPv6Mode(),
 		simulateOnly:          false,
+		cleanup:               false,
 	}
 }
 
+// defaultIptablesMode returns the iptables mode set by the environment, so
+// that it can be overridden without changing the init container's arguments,
+// or auto otherwise.
+func defaultIptablesMode() string {
+	if mode := os.Getenv(iptables.IptablesModeEnvVarName); mode != "" {
+		return mode
+	}
+	return iptables.AutoIptablesMode
+}
+
+// defaultIPv6Mode returns the IPv6 mode set by the environment, like
+// defaultIptablesMode, or disabled otherwise, as the proxy only accepts IPv6
+// traffic if it's injected to.
+func defaultIPv6Mode() string {
+	if mode := os.Getenv(iptables.IPv6ModeEnvVarName); mode != "" {
+		return mode
+	}
+	return iptables.DisabledIPv6Mode
+}
+
 func NewRootCmd() *cobra.Command {
 	options := newRootOptions()
 
@@ -36,11 +71,17 @@ func NewRootCmd() *cobra.Command {
 		Use:   "proxy-init",
 		Short: "proxy-init adds a Kubernetes pod to the Linkerd service mesh",
 		Long:  "proxy-init adds a Kubernetes pod to the Linkerd service mesh.",
+		// Usage would push the error out of the init container's termination
+		// message.
+		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			config, err := buildFirewallConfiguration(options)
 			if err != nil {
 				return err
 			}
+			if options.cleanup {
+				return iptables.CleanupFirewall(*config)
+			}
 			return iptables.ConfigureFirewall(*config)
 		},
 	}
@@ -51,6 +92,12 @@ func NewRootCmd() *cobra.Command {
 	cmd.PersistentFlags().IntSliceVarP(&options.portsToRedirect, "ports-to-redirect", "r", options.portsToRedirect, "Port to redirect to proxy, if no port is specified then ALL ports are redirected")
 	cmd.PersistentFlags().IntSliceVar(&options.inboundPortsToIgnore, "inbound-ports-to-ignore", options.inboundPortsToIgnore, "Inbound ports to ignore and not redirect to proxy. This has higher precedence than any other parameters.")
 	cmd.PersistentFlags().IntSliceVar(&options.outboundPortsToIgnore, "outbound-ports-to-ignore", options.outboundPortsToIgnore, "Outbound ports to ignore and not redirect to proxy. This has higher precedence than any other parameters.")
+	cmd.PersistentFlags().IntSliceVar(&options.skipOwnerUids, "skip-owner-uid", options.skipOwnerUids, "User IDs whose outbound traffic is not redirected to the proxy, e.g. of sidecars that must bypass it")
+	cmd.PersistentFlags().IntSliceVar(&options.skipOwnerGids, "skip-owner-gid", options.skipOwnerGids, "Group IDs whose outbound traffic is not redirected to the proxy")
+	cmd.PersistentFlags().StringSliceVar(&options.skipOutboundCidrs, "skip-outbound-cidrs", options.skipOutboundCidrs, "Outbound destinations, as IPv4 or IPv6 CIDRs, that are not redirected to the proxy")
+	cmd.PersistentFlags().StringVar(&options.iptablesMode, "iptables-mode", options.iptablesMode, fmt.Sprintf("Backend to program iptables rules with, one of: %s; auto detects the one the node uses (defaults to $%s, or auto)", strings.Join(iptables.IptablesModes, ", "), iptables.IptablesModeEnvVarName))
+	cmd.PersistentFlags().StringVar(&options.ipv6Mode, "ipv6-mode", options.ipv6Mode, fmt.Sprintf("Whether to also redirect IPv6 traffic, with ip6tables, one of: %s; auto does if the pod has IPv6 addresses (defaults to $%s, or %s)", strings.Join(iptables.IPv6Modes, ", "), iptables.IPv6ModeEnvVarName, iptables.DisabledIPv6Mode))
+	cmd.PersistentFlags().BoolVar(&options.cleanup, "cleanup", options.cleanup, "Remove all the chains and rules that proxy-init added, instead of adding them, so that the pod's traffic is no longer redirected to the proxy")
 	cmd.PersistentFlags().BoolVar(&options.simulateOnly, "simulate", options.simulateOnly, "Don't execute any command, just print what would be executed")
 
 	return cmd
@@ -65,6 +112,32 @@ func buildFirewallConfiguration(options *rootOptions) (*iptables.FirewallConfigu
 		return nil, fmt.Errorf("--outgoing-proxy-port must be a valid TCP port number")
 	}
 
+	for _, uid := range options.skipOwnerUids {
+		if uid < 0 {
+			return nil, fmt.Errorf("--skip-owner-uid must be a valid user ID, was: %d", uid)
+		}
+	}
+
+	for _, gid := range options.skipOwnerGids {
+		if gid < 0 {
+			return nil, fmt.Errorf("--skip-owner-gid must be a valid group ID, was: %d", gid)
+		}
+	}
+
+	for _, cidr := range options.skipOutboundCidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("--skip-outbound-cidrs has an invalid CIDR [%s]", cidr)
+		}
+	}
+
+	if !isValidIptablesMode(options.iptablesMode) {
+		return nil, fmt.Errorf("--iptables-mode must be one of: %s", strings.Join(iptables.IptablesModes, ", "))
+	}
+
+	if !isValidIPv6Mode(options.ipv6Mode) {
+		return nil, fmt.Errorf("--ipv6-mode must be one of: %s", strings.Join(iptables.IPv6Modes, ", "))
+	}
+
 	firewallConfiguration := &iptables.FirewallConfiguration{
 		ProxyInboundPort:       options.incomingProxyPort,
 		ProxyOutgoingPort:      options.outgoingProxyPort,
@@ -72,6 +145,11 @@ func buildFirewallConfiguration(options *rootOptions) (*iptables.FirewallConfigu
 		PortsToRedirectInbound: options.portsToRedirect,
 		InboundPortsToIgnore:   options.inboundPortsToIgnore,
 		OutboundPortsToIgnore:  options.outboundPortsToIgnore,
+		OutboundUidsToIgnore:   options.skipOwnerUids,
+		OutboundGidsToIgnore:   options.skipOwnerGids,
+		OutboundCidrsToIgnore:  options.skipOutboundCidrs,
+		IptablesMode:           options.iptablesMode,
+		IPv6Mode:               options.ipv6Mode,
 		SimulateOnly:           options.simulateOnly,
 	}
 
@@ -83,3 +161,21 @@ func buildFirewallConfiguration(options *rootOptions) (*iptables.FirewallConfigu
 
 	return firewallConfiguration, nil
 }
+
+func isValidIptablesMode(mode string) bool {
+	for _, valid := range iptables.IptablesModes {
+		if mode == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidIPv6Mode(mode string) bool {
+	for _, valid := range iptables.IPv6Modes {
+		if mode == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"os"
 	"reflect"
 	"testing"
 
@@ -17,9 +18,14 @@ func TestBuildFirewallConfiguration(t *testing.T) {
 			PortsToRedirectInbound: make([]int, 0),
 			InboundPortsToIgnore:   make([]int, 0),
 			OutboundPortsToIgnore:  make([]int, 0),
+			OutboundUidsToIgnore:   make([]int, 0),
+			OutboundGidsToIgnore:   make([]int, 0),
+			OutboundCidrsToIgnore:  make([]string, 0),
 			ProxyInboundPort:       expectedIncomingProxyPort,
 			ProxyOutgoingPort:      expectedOutgoingProxyPort,
 			ProxyUid:               expectedProxyUserId,
+			IptablesMode:           iptables.AutoIptablesMode,
+			IPv6Mode:               iptables.DisabledIPv6Mode,
 			SimulateOnly:           false,
 		}
 
@@ -38,6 +44,55 @@ func TestBuildFirewallConfiguration(t *testing.T) {
 		}
 	})
 
+	t.Run("It defaults to the iptables mode of the environment", func(t *testing.T) {
+		os.Setenv(iptables.IptablesModeEnvVarName, iptables.NftIptablesMode)
+		defer os.Unsetenv(iptables.IptablesModeEnvVarName)
+
+		options := newRootOptions()
+		options.incomingProxyPort = 1234
+		options.outgoingProxyPort = 2345
+
+		config, err := buildFirewallConfiguration(options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if config.IptablesMode != iptables.NftIptablesMode {
+			t.Fatalf("Expected iptables mode [%s] but got [%s]", iptables.NftIptablesMode, config.IptablesMode)
+		}
+	})
+
+	t.Run("It defaults to the IPv6 mode of the environment", func(t *testing.T) {
+		os.Setenv(iptables.IPv6ModeEnvVarName, iptables.AutoIPv6Mode)
+		defer os.Unsetenv(iptables.IPv6ModeEnvVarName)
+
+		options := newRootOptions()
+		options.incomingProxyPort = 1234
+		options.outgoingProxyPort = 2345
+
+		config, err := buildFirewallConfiguration(options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if config.IPv6Mode != iptables.AutoIPv6Mode {
+			t.Fatalf("Expected IPv6 mode [%s] but got [%s]", iptables.AutoIPv6Mode, config.IPv6Mode)
+		}
+	})
+
+	t.Run("It accepts IPv4 and IPv6 CIDRs", func(t *testing.T) {
+		options := newRootOptions()
+		options.incomingProxyPort = 1234
+		options.outgoingProxyPort = 2345
+		options.skipOutboundCidrs = []string{"10.0.0.0/8", "fd00::/8"}
+
+		config, err := buildFirewallConfiguration(options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(config.OutboundCidrsToIgnore, options.skipOutboundCidrs) {
+			t.Fatalf("Expected CIDRs %v but got %v", options.skipOutboundCidrs, config.OutboundCidrsToIgnore)
+		}
+	})
+
 	t.Run("It rejects invalid config options", func(t *testing.T) {
 		for _, tt := range []struct {
 			options      *rootOptions
@@ -71,6 +126,68 @@ func TestBuildFirewallConfiguration(t *testing.T) {
 				},
 				errorMessage: "--outgoing-proxy-port must be a valid TCP port number",
 			},
+			{
+				options: &rootOptions{
+					incomingProxyPort: 1234,
+					outgoingProxyPort: 2345,
+					iptablesMode:      "xtables",
+				},
+				errorMessage: "--iptables-mode must be one of: auto, legacy, nft",
+			},
+			{
+				options: &rootOptions{
+					incomingProxyPort: 1234,
+					outgoingProxyPort: 2345,
+					skipOwnerUids:     []int{-1},
+					iptablesMode:      iptables.AutoIptablesMode,
+				},
+				errorMessage: "--skip-owner-uid must be a valid user ID, was: -1",
+			},
+			{
+				options: &rootOptions{
+					incomingProxyPort: 1234,
+					outgoingProxyPort: 2345,
+					skipOwnerGids:     []int{-2},
+					iptablesMode:      iptables.AutoIptablesMode,
+				},
+				errorMessage: "--skip-owner-gid must be a valid group ID, was: -2",
+			},
+			{
+				options: &rootOptions{
+					incomingProxyPort: 1234,
+					outgoingProxyPort: 2345,
+					skipOutboundCidrs: []string{"10.0.0.0/8", "10.0.0/8"},
+					iptablesMode:      iptables.AutoIptablesMode,
+				},
+				errorMessage: "--skip-outbound-cidrs has an invalid CIDR [10.0.0/8]",
+			},
+			{
+				options: &rootOptions{
+					incomingProxyPort: 1234,
+					outgoingProxyPort: 2345,
+					skipOutboundCidrs: []string{"169.254.0.0"},
+					iptablesMode:      iptables.AutoIptablesMode,
+				},
+				errorMessage: "--skip-outbound-cidrs has an invalid CIDR [169.254.0.0]",
+			},
+			{
+				options: &rootOptions{
+					incomingProxyPort: 1234,
+					outgoingProxyPort: 2345,
+					skipOutboundCidrs: []string{"fd00::8"},
+					iptablesMode:      iptables.AutoIptablesMode,
+				},
+				errorMessage: "--skip-outbound-cidrs has an invalid CIDR [fd00::8]",
+			},
+			{
+				options: &rootOptions{
+					incomingProxyPort: 1234,
+					outgoingProxyPort: 2345,
+					iptablesMode:      iptables.AutoIptablesMode,
+					ipv6Mode:          "on",
+				},
+				errorMessage: "--ipv6-mode must be one of: auto, enabled, disabled",
+			},
 		} {
 			_, err := buildFirewallConfiguration(tt.options)
 			if err == nil {
@@ -0,0 +1,165 @@
+package iptables
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// AutoIptablesMode detects the backend that the node uses.
+	AutoIptablesMode = "auto"
+	// LegacyIptablesMode programs rules with the legacy, xtables-based
+	// iptables.
+	LegacyIptablesMode = "legacy"
+	// NftIptablesMode programs rules with the nftables-based iptables.
+	NftIptablesMode = "nft"
+
+	// IptablesModeEnvVarName is the environment variable that overrides the
+	// default --iptables-mode.
+	IptablesModeEnvVarName = "LINKERD2_PROXY_INIT_IPTABLES_MODE"
+)
+
+// IptablesModes are the valid values of --iptables-mode.
+var IptablesModes = []string{AutoIptablesMode, LegacyIptablesMode, NftIptablesMode}
+
+// Backend is one of the implementations of iptables. Both accept the same
+// rules, but program them into different parts of the kernel, and only the
+// rules of the backend that the node uses take effect. Each backend programs
+// IPv6 rules with its own ip6tables.
+type Backend struct {
+	Mode       string
+	Binary     string
+	SaveBinary string
+	IP6Binary  string
+}
+
+var (
+	LegacyBackend = Backend{Mode: LegacyIptablesMode, Binary: "iptables-legacy", SaveBinary: "iptables-legacy-save", IP6Binary: "ip6tables-legacy"}
+	NftBackend    = Backend{Mode: NftIptablesMode, Binary: "iptables-nft", SaveBinary: "iptables-nft-save", IP6Binary: "ip6tables-nft"}
+
+	// PlainBackend is the iptables whose binaries don't name a backend. It is
+	// used when neither backend's binaries are installed, as on nodes with an
+	// iptables older than 1.8, which only provides the legacy backend under
+	// these names.
+	PlainBackend = Backend{Mode: LegacyIptablesMode, Binary: "iptables", SaveBinary: "iptables-save", IP6Binary: "ip6tables"}
+)
+
+// binary returns the iptables command that programs family's rules.
+func (b Backend) binary(family IPFamily) string {
+	if family == IPv6 {
+		return b.IP6Binary
+	}
+	return b.Binary
+}
+
+func (b Backend) command(family IPFamily, args []string) *exec.Cmd {
+	return exec.Command(b.binary(family), args...)
+}
+
+// saveRules returns the output of backend's iptables-save. It is replaced in
+// tests.
+var saveRules = func(backend Backend) ([]byte, error) {
+	out, err := exec.Command(backend.SaveBinary).Output()
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		err = fmt.Errorf("%s: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return out, err
+}
+
+// lookPath finds the installed binary of an iptables command. It is replaced
+// in tests.
+var lookPath = exec.LookPath
+
+// installed returns true if backend's iptables and iptables-save binaries are
+// installed.
+func installed(backend Backend) bool {
+	for _, binary := range []string{backend.Binary, backend.SaveBinary} {
+		if _, err := lookPath(binary); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// orPlainBackend returns backend, or PlainBackend if backend's binaries aren't
+// installed but the plain ones are.
+func orPlainBackend(backend Backend) Backend {
+	if installed(backend) || !installed(PlainBackend) {
+		return backend
+	}
+	log.Printf("%s is not installed, using %s", backend.Binary, PlainBackend.Binary)
+	return PlainBackend
+}
+
+// SelectBackend returns the backend of mode, detecting the one that the node
+// uses if mode is auto or empty. If the backend's binaries aren't installed,
+// the plain iptables binaries are used instead.
+func SelectBackend(mode string) (Backend, error) {
+	switch mode {
+	case LegacyIptablesMode:
+		return orPlainBackend(LegacyBackend), nil
+	case NftIptablesMode:
+		return orPlainBackend(NftBackend), nil
+	case AutoIptablesMode, "":
+		return DetectBackend()
+	default:
+		return Backend{}, fmt.Errorf("iptables mode must be one of: %s, was: %s", strings.Join(IptablesModes, ", "), mode)
+	}
+}
+
+// DetectBackend detects the backend that the node uses by listing the existing
+// rules with both backends, as each backend only sees its own rules. The
+// backend with the most rules is used, or the legacy one if neither has any.
+// It fails if neither backend can list rules, as neither could program them.
+// If neither backend's binaries are installed, the plain iptables binaries are
+// used without detection.
+func DetectBackend() (Backend, error) {
+	if !installed(LegacyBackend) && !installed(NftBackend) && installed(PlainBackend) {
+		log.Printf("Neither %s nor %s is installed, using %s", LegacyBackend.Binary, NftBackend.Binary, PlainBackend.Binary)
+		return PlainBackend, nil
+	}
+
+	legacyRules, legacyErr := countRules(LegacyBackend)
+	nftRules, nftErr := countRules(NftBackend)
+
+	switch {
+	case legacyErr != nil && nftErr != nil:
+		return Backend{}, fmt.Errorf(
+			"neither iptables backend works, so the pod's traffic cannot be redirected to the proxy:\n  %s: %s\n  %s: %s\nCheck that the node's kernel supports iptables, or set %s to %s or %s to use a backend without detecting it",
+			LegacyBackend.SaveBinary, legacyErr, NftBackend.SaveBinary, nftErr,
+			IptablesModeEnvVarName, LegacyIptablesMode, NftIptablesMode)
+	case nftErr != nil:
+		log.Printf("Detected %s iptables mode, %s failed: %s", LegacyIptablesMode, NftBackend.SaveBinary, nftErr)
+		return LegacyBackend, nil
+	case legacyErr != nil:
+		log.Printf("Detected %s iptables mode, %s failed: %s", NftIptablesMode, LegacyBackend.SaveBinary, legacyErr)
+		return NftBackend, nil
+	case nftRules > legacyRules:
+		log.Printf("Detected %s iptables mode, with %d %s rules and %d %s rules", NftIptablesMode, nftRules, NftIptablesMode, legacyRules, LegacyIptablesMode)
+		return NftBackend, nil
+	default:
+		log.Printf("Detected %s iptables mode, with %d %s rules and %d %s rules", LegacyIptablesMode, legacyRules, LegacyIptablesMode, nftRules, NftIptablesMode)
+		return LegacyBackend, nil
+	}
+}
+
+// countRules returns the number of rules that backend lists.
+func countRules(backend Backend) (int, error) {
+	out, err := saveRules(backend)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), "-A ") {
+			count++
+		}
+	}
+	return count, scanner.Err()
+}
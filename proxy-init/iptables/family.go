@@ -0,0 +1,132 @@
+package iptables
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+)
+
+const (
+	// AutoIPv6Mode programs IPv6 rules if the pod has IPv6 addresses.
+	AutoIPv6Mode = "auto"
+	// EnabledIPv6Mode programs IPv6 rules whether or not the pod has IPv6
+	// addresses.
+	EnabledIPv6Mode = "enabled"
+	// DisabledIPv6Mode only programs IPv4 rules, so the pod's IPv6 traffic
+	// skips the proxy.
+	DisabledIPv6Mode = "disabled"
+
+	// IPv6ModeEnvVarName is the environment variable that overrides the
+	// default --ipv6-mode.
+	IPv6ModeEnvVarName = "LINKERD2_PROXY_INIT_IPV6_MODE"
+)
+
+// IPv6Modes are the valid values of --ipv6-mode.
+var IPv6Modes = []string{AutoIPv6Mode, EnabledIPv6Mode, DisabledIPv6Mode}
+
+// IPFamily is the IP version that rules apply to. iptables programs the IPv4
+// rules and ip6tables the IPv6 ones, and neither sees the other's rules.
+type IPFamily int
+
+const (
+	IPv4 IPFamily = 4
+	IPv6 IPFamily = 6
+)
+
+func (family IPFamily) String() string {
+	if family == IPv6 {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
+// tool is the name of the iptables command that programs family's rules.
+func (family IPFamily) tool() string {
+	if family == IPv6 {
+		return "ip6tables"
+	}
+	return "iptables"
+}
+
+// interfaceAddrs returns the addresses of the interfaces of the current
+// network namespace. It is replaced in tests.
+var interfaceAddrs = net.InterfaceAddrs
+
+// selectIPFamilies returns the families that firewallConfiguration's rules
+// are programmed for. With the auto IPv6 mode, IPv6 rules are programmed if
+// the pod has IPv6 addresses, and IPv4 rules unless it only has IPv6
+// addresses. Addresses are not detected when only simulating, as if the pod
+// only had IPv4 addresses, nor in another network namespace, whose addresses
+// the caller must detect instead.
+func selectIPFamilies(firewallConfiguration FirewallConfiguration) ([]IPFamily, error) {
+	switch firewallConfiguration.IPv6Mode {
+	case DisabledIPv6Mode, "":
+		return []IPFamily{IPv4}, nil
+	case EnabledIPv6Mode:
+		return []IPFamily{IPv4, IPv6}, nil
+	case AutoIPv6Mode:
+	default:
+		return nil, fmt.Errorf("IPv6 mode must be one of: %s, was: %s", strings.Join(IPv6Modes, ", "), firewallConfiguration.IPv6Mode)
+	}
+
+	if firewallConfiguration.SimulateOnly {
+		log.Printf("Not detecting the pod's IP families when simulating, using %s", IPv4)
+		return []IPFamily{IPv4}, nil
+	}
+	if firewallConfiguration.NetNs != "" {
+		return nil, fmt.Errorf("the %s IPv6 mode can't detect the IP families of another network namespace, use %s or %s", AutoIPv6Mode, EnabledIPv6Mode, DisabledIPv6Mode)
+	}
+
+	hasIPv4, hasIPv6, err := detectIPFamilies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect the pod's IP families: %s", err)
+	}
+	switch {
+	case hasIPv4 && hasIPv6:
+		log.Printf("Detected a dual-stack pod, programming %s and %s rules", IPv4, IPv6)
+		return []IPFamily{IPv4, IPv6}, nil
+	case hasIPv6:
+		log.Printf("Detected an %s-only pod, programming %s rules", IPv6, IPv6)
+		return []IPFamily{IPv6}, nil
+	default:
+		log.Printf("Detected an %s-only pod, programming %s rules", IPv4, IPv4)
+		return []IPFamily{IPv4}, nil
+	}
+}
+
+// detectIPFamilies returns whether the current network namespace has IPv4 and
+// IPv6 addresses that other hosts can reach. Loopback and link-local
+// addresses don't count, as every interface with IPv6 enabled has one.
+func detectIPFamilies() (bool, bool, error) {
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		return false, false, err
+	}
+
+	hasIPv4, hasIPv6 := false, false
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || !ipNet.IP.IsGlobalUnicast() {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			hasIPv4 = true
+		} else {
+			hasIPv6 = true
+		}
+	}
+	return hasIPv4, hasIPv6, nil
+}
+
+// cidrsOfFamily returns the CIDRs of family, as ip6tables rejects IPv4 CIDRs
+// and iptables IPv6 ones.
+func cidrsOfFamily(cidrs []string, family IPFamily) []string {
+	matching := []string{}
+	for _, cidr := range cidrs {
+		if strings.Contains(cidr, ":") == (family == IPv6) {
+			matching = append(matching, cidr)
+		}
+	}
+	return matching
+}
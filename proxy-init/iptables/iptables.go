@@ -14,21 +14,50 @@ const (
 	RedirectListedMode          = "redirect-listed"
 	IptablesPreroutingChainName = "PREROUTING"
 	IptablesOutputChainName     = "OUTPUT"
+
+	redirectChainName = "PROXY_INIT_REDIRECT"
+	outputChainName   = "PROXY_INIT_OUTPUT"
 )
 
 var (
 	ExecutionTraceId = strconv.Itoa(int(time.Now().Unix()))
+
+	// proxyInitChainNames are the chains that proxy-init adds, which mark the
+	// rules of previous runs.
+	proxyInitChainNames = []string{redirectChainName, outputChainName}
 )
 
+// runCommand runs an iptables command, returning its combined output. It is
+// replaced in tests.
+var runCommand = func(cmd *exec.Cmd) ([]byte, error) {
+	return cmd.CombinedOutput()
+}
+
+// installedRules are the chains that previous runs of proxy-init added, and
+// the rules that jump to them from the built-in chains.
+type installedRules struct {
+	chains []string
+	jumps  [][]string
+}
+
 type FirewallConfiguration struct {
 	Mode                   string
 	PortsToRedirectInbound []int
 	InboundPortsToIgnore   []int
 	OutboundPortsToIgnore  []int
+	OutboundUidsToIgnore   []int
+	OutboundGidsToIgnore   []int
+	OutboundCidrsToIgnore  []string
 	ProxyInboundPort       int
 	ProxyOutgoingPort      int
 	ProxyUid               int
+	IptablesMode           string
+	IPv6Mode               string
 	SimulateOnly           bool
+
+	// NetNs is the path of the network namespace that the rules are
+	// programmed in, e.g. by the CNI plugin, or the current one if empty.
+	NetNs string
 }
 
 //ConfigureFirewall configures a pod's internal iptables to redirect all desired traffic through the proxy, allowing for
@@ -38,44 +67,217 @@ func ConfigureFirewall(firewallConfiguration FirewallConfiguration) error {
 
 	log.Printf("Tracing this script execution as [%s]\n", ExecutionTraceId)
 
-	log.Println("State of iptables rules before run:")
-	err := executeCommand(firewallConfiguration, makeShowAllRules())
+	backend, err := selectBackend(firewallConfiguration)
 	if err != nil {
 		log.Println("Aborting firewall configuration")
 		return err
 	}
+	log.Printf("Using the %s iptables backend (%s)", backend.Mode, backend.Binary)
+
+	families, err := selectIPFamilies(firewallConfiguration)
+	if err != nil {
+		log.Println("Aborting firewall configuration")
+		return err
+	}
+
+	for _, family := range families {
+		if err := configureFamily(firewallConfiguration, backend, family); err != nil {
+			log.Println("Aborting firewall configuration")
+			return err
+		}
+	}
+	return nil
+}
 
-	commands := make([]*exec.Cmd, 0)
+// configureFamily programs firewallConfiguration's rules for family, in place
+// of the rules of previous runs.
+func configureFamily(firewallConfiguration FirewallConfiguration, backend Backend, family IPFamily) error {
+	log.Printf("State of %s rules before run:", family.tool())
+	err := executeCommand(firewallConfiguration, firewallConfiguration.command(backend, family, makeShowAllRules()))
+	if err != nil {
+		return err
+	}
 
-	commands = addIncomingTrafficRules(commands, firewallConfiguration)
+	// Remove the rules of previous runs, so that running again replaces them
+	// instead of adding duplicates
+	if err := removeInstalledRules(firewallConfiguration, backend, family); err != nil {
+		return err
+	}
 
-	commands = addOutgoingTrafficRules(commands, firewallConfiguration)
+	rules := makeFirewallRules(firewallConfiguration, family)
 
-	commands = append(commands, makeShowAllRules())
+	rules = append(rules, makeShowAllRules())
 
 	log.Println("Executing commands:")
 
-	for _, cmd := range commands {
-		err := executeCommand(firewallConfiguration, cmd)
+	for _, rule := range rules {
+		err := executeCommand(firewallConfiguration, firewallConfiguration.command(backend, family, rule))
 		if err != nil {
-			log.Println("Aborting firewall configuration")
+			return fmt.Errorf("failed to program the %s %s backend: %s; if the node uses the other backend, set %s to it", backend.Mode, family.tool(), err, IptablesModeEnvVarName)
+		}
+	}
+	return nil
+}
+
+// CleanupFirewall removes all the chains and rules that proxy-init added to a
+// pod's iptables, so that its traffic is no longer redirected to the proxy.
+func CleanupFirewall(firewallConfiguration FirewallConfiguration) error {
+
+	log.Printf("Tracing this script execution as [%s]\n", ExecutionTraceId)
+
+	backend, err := selectBackend(firewallConfiguration)
+	if err != nil {
+		log.Println("Aborting firewall cleanup")
+		return err
+	}
+	log.Printf("Using the %s iptables backend (%s)", backend.Mode, backend.Binary)
+
+	families, err := selectIPFamilies(firewallConfiguration)
+	if err != nil {
+		log.Println("Aborting firewall cleanup")
+		return err
+	}
+
+	for _, family := range families {
+		if err := removeInstalledRules(firewallConfiguration, backend, family); err != nil {
+			log.Println("Aborting firewall cleanup")
+			return err
+		}
+
+		log.Printf("State of %s rules after cleanup:", family.tool())
+		if err := executeCommand(firewallConfiguration, firewallConfiguration.command(backend, family, makeShowAllRules())); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// removeInstalledRules removes the rules for family that previous runs of
+// proxy-init installed with backend, if any.
+func removeInstalledRules(firewallConfiguration FirewallConfiguration, backend Backend, family IPFamily) error {
+	installed, err := listInstalledRules(firewallConfiguration, backend, family)
+	if err != nil {
+		return fmt.Errorf("failed to list the %s %s rules: %s", backend.Mode, family.tool(), err)
+	}
+	if len(installed.chains) == 0 && len(installed.jumps) == 0 {
+		log.Println("No rules of previous runs found")
+		return nil
+	}
+
+	log.Printf("Removing the rules of previous runs, %d chains and %d jumps to them", len(installed.chains), len(installed.jumps))
+	for _, rule := range makeCleanupRules(installed) {
+		if err := executeCommand(firewallConfiguration, firewallConfiguration.command(backend, family, rule)); err != nil {
+			return fmt.Errorf("failed to remove the rules of previous runs: %s", err)
+		}
+	}
+	return nil
+}
+
+// listInstalledRules returns the rules for family that previous runs of
+// proxy-init installed with backend. Nothing is listed when only simulating,
+// as listing runs iptables.
+func listInstalledRules(firewallConfiguration FirewallConfiguration, backend Backend, family IPFamily) (installedRules, error) {
+	if firewallConfiguration.SimulateOnly {
+		return installedRules{}, nil
+	}
+
+	out, err := runCommand(firewallConfiguration.command(backend, family, makeListRules()))
+	if err != nil {
+		return installedRules{}, fmt.Errorf("%s: %s", err, strings.TrimSpace(string(out)))
+	}
+	return parseInstalledRules(string(out)), nil
+}
+
+// parseInstalledRules finds proxy-init's chains, by name, and the rules that
+// jump to them in the output of iptables -S.
+func parseInstalledRules(rules string) installedRules {
+	installed := installedRules{chains: []string{}, jumps: [][]string{}}
+	for _, line := range strings.Split(rules, "\n") {
+		fields := strings.Fields(line)
+		for i, field := range fields {
+			fields[i] = strings.Trim(field, `"`)
+		}
+
+		switch {
+		case len(fields) == 2 && fields[0] == "-N" && isProxyInitChain(fields[1]):
+			installed.chains = append(installed.chains, fields[1])
+		case len(fields) > 2 && fields[0] == "-A" && !isProxyInitChain(fields[1]):
+			for i := 2; i < len(fields)-1; i++ {
+				if fields[i] == "-j" && isProxyInitChain(fields[i+1]) {
+					installed.jumps = append(installed.jumps, fields[1:])
+					break
+				}
+			}
+		}
+	}
+	return installed
+}
+
+func isProxyInitChain(name string) bool {
+	for _, chain := range proxyInitChainNames {
+		if name == chain {
+			return true
+		}
+	}
+	return false
+}
+
+// command returns the command that runs backend's iptables for family with
+// args, in firewallConfiguration's network namespace if it has one.
+func (firewallConfiguration FirewallConfiguration) command(backend Backend, family IPFamily, args []string) *exec.Cmd {
+	if firewallConfiguration.NetNs == "" {
+		return backend.command(family, args)
+	}
+	return exec.Command("nsenter", append([]string{"--net=" + firewallConfiguration.NetNs, backend.binary(family)}, args...)...)
+}
+
+// selectBackend returns the backend that firewallConfiguration's rules are
+// programmed with. The backend is not detected when only simulating, as
+// detection runs iptables.
+func selectBackend(firewallConfiguration FirewallConfiguration) (Backend, error) {
+	if firewallConfiguration.SimulateOnly && (firewallConfiguration.IptablesMode == AutoIptablesMode || firewallConfiguration.IptablesMode == "") {
+		log.Printf("Not detecting the iptables mode when simulating, using %s", LegacyIptablesMode)
+		return LegacyBackend, nil
+	}
+	return SelectBackend(firewallConfiguration.IptablesMode)
+}
+
+// makeFirewallRules returns the rules that redirect firewallConfiguration's
+// traffic of family to the proxy, as the arguments of the iptables commands
+// that add them. Any backend can program them. The rules of both families are
+// the same, except for the destinations that skip the proxy, which are only
+// those of family.
+func makeFirewallRules(firewallConfiguration FirewallConfiguration, family IPFamily) [][]string {
+	rules := make([][]string, 0)
+
+	rules = addIncomingTrafficRules(rules, firewallConfiguration)
+
+	rules = addOutgoingTrafficRules(rules, firewallConfiguration, family)
+
+	return rules
+}
+
+// makeCleanupRules returns the rules that remove the installed chains. The
+// jumps to the chains are deleted first, as a chain can't be deleted while
+// other rules refer to it.
+func makeCleanupRules(installed installedRules) [][]string {
+	rules := make([][]string, 0)
+	for _, jump := range installed.jumps {
+		rules = append(rules, makeDeleteRule(jump))
+	}
+	for _, chain := range installed.chains {
+		rules = append(rules, makeFlushChain(chain), makeDeleteChain(chain))
+	}
+	return rules
+}
+
 //formatComment is used to format iptables comments in such way that it is possible to identify when the rules were added.
 // This helps debug when iptables has some stale rules from previous runs, something that can happen frequently on minikube.
 func formatComment(text string) string {
 	return fmt.Sprintf("proxy-init/%s/%s", text, ExecutionTraceId)
 }
 
-func addOutgoingTrafficRules(commands []*exec.Cmd, firewallConfiguration FirewallConfiguration) []*exec.Cmd {
-	outputChainName := "PROXY_INIT_OUTPUT"
-	executeCommand(firewallConfiguration, makeFlushChain(outputChainName))
-	executeCommand(firewallConfiguration, makeDeleteChain(outputChainName))
-
+func addOutgoingTrafficRules(commands [][]string, firewallConfiguration FirewallConfiguration, family IPFamily) [][]string {
 	commands = append(commands, makeCreateNewChain(outputChainName, "redirect-common-chain"))
 
 	// Ingore traffic from the proxy
@@ -86,10 +288,25 @@ func addOutgoingTrafficRules(commands []*exec.Cmd, firewallConfiguration Firewal
 		log.Println("Not ignoring any uid")
 	}
 
+	// Ignore traffic from other users and groups, e.g. of sidecars that must bypass the proxy
+	for _, uid := range firewallConfiguration.OutboundUidsToIgnore {
+		log.Printf("Ignoring uid %d", uid)
+		commands = append(commands, makeIgnoreUserId(outputChainName, uid, fmt.Sprintf("ignore-user-id-%d", uid)))
+	}
+	for _, gid := range firewallConfiguration.OutboundGidsToIgnore {
+		log.Printf("Ignoring gid %d", gid)
+		commands = append(commands, makeIgnoreGroupId(outputChainName, gid, fmt.Sprintf("ignore-group-id-%d", gid)))
+	}
+
 	// Ignore loopback
 	commands = append(commands, makeIgnoreLoopback(outputChainName, "ignore-loopback"))
 	// Ignore ports
 	commands = addRulesForIgnoredPorts(firewallConfiguration.OutboundPortsToIgnore, outputChainName, commands)
+	// Ignore destinations
+	for _, cidr := range cidrsOfFamily(firewallConfiguration.OutboundCidrsToIgnore, family) {
+		log.Printf("Will ignore destination %s on chain %s", cidr, outputChainName)
+		commands = append(commands, makeIgnoreDestination(outputChainName, cidr, fmt.Sprintf("ignore-cidr-%s", cidr)))
+	}
 
 	log.Printf("Redirecting all OUTPUT to %d", firewallConfiguration.ProxyOutgoingPort)
 	commands = append(commands, makeRedirectChainToPort(outputChainName, firewallConfiguration.ProxyOutgoingPort, "redirect-all-outgoing-to-proxy-port"))
@@ -99,11 +316,7 @@ func addOutgoingTrafficRules(commands []*exec.Cmd, firewallConfiguration Firewal
 	return commands
 }
 
-func addIncomingTrafficRules(commands []*exec.Cmd, firewallConfiguration FirewallConfiguration) []*exec.Cmd {
-	redirectChainName := "PROXY_INIT_REDIRECT"
-	executeCommand(firewallConfiguration, makeFlushChain(redirectChainName))
-	executeCommand(firewallConfiguration, makeDeleteChain(redirectChainName))
-
+func addIncomingTrafficRules(commands [][]string, firewallConfiguration FirewallConfiguration) [][]string {
 	commands = append(commands, makeCreateNewChain(redirectChainName, "redirect-common-chain"))
 	commands = addRulesForIgnoredPorts(firewallConfiguration.InboundPortsToIgnore, redirectChainName, commands)
 	commands = addRulesForInboundPortRedirect(firewallConfiguration, redirectChainName, commands)
@@ -114,7 +327,7 @@ func addIncomingTrafficRules(commands []*exec.Cmd, firewallConfiguration Firewal
 	return commands
 }
 
-func addRulesForInboundPortRedirect(firewallConfiguration FirewallConfiguration, chainName string, commands []*exec.Cmd) []*exec.Cmd {
+func addRulesForInboundPortRedirect(firewallConfiguration FirewallConfiguration, chainName string, commands [][]string) [][]string {
 	if firewallConfiguration.Mode == RedirectAllMode {
 		log.Print("Will redirect all INPUT ports to proxy")
 		//Create a new chain for redirecting inbound and outbound traffic to the proxy port.
@@ -134,7 +347,7 @@ func addRulesForInboundPortRedirect(firewallConfiguration FirewallConfiguration,
 	return commands
 }
 
-func addRulesForIgnoredPorts(portsToIgnore []int, chainName string, commands []*exec.Cmd) []*exec.Cmd {
+func addRulesForIgnoredPorts(portsToIgnore []int, chainName string, commands [][]string) [][]string {
 	for _, ignoredPort := range portsToIgnore {
 		log.Printf("Will ignore port %d on chain %s", ignoredPort, chainName)
 
@@ -148,7 +361,7 @@ func executeCommand(firewallConfiguration FirewallConfiguration, cmd *exec.Cmd)
 	log.Printf("> %s", strings.Trim(fmt.Sprintf("%v", cmd.Args), "[]"))
 
 	if !firewallConfiguration.SimulateOnly {
-		out, err := cmd.CombinedOutput()
+		out, err := runCommand(cmd)
 		log.Printf("< %s\n", string(out))
 		if err != nil {
 			return err
@@ -157,71 +370,105 @@ func executeCommand(firewallConfiguration FirewallConfiguration, cmd *exec.Cmd)
 	return nil
 }
 
-func makeIgnoreUserId(chainName string, uid int, comment string) *exec.Cmd {
-	return exec.Command("iptables",
+func makeIgnoreUserId(chainName string, uid int, comment string) []string {
+	return []string{
 		"-t", "nat",
 		"-A", chainName,
 		"-m", "owner",
 		"--uid-owner", strconv.Itoa(uid),
 		"-j", "RETURN",
 		"-m", "comment",
-		"--comment", formatComment(comment))
+		"--comment", formatComment(comment),
+	}
 }
 
-func makeCreateNewChain(name string, comment string) *exec.Cmd {
-	return exec.Command("iptables",
+func makeIgnoreGroupId(chainName string, gid int, comment string) []string {
+	return []string{
+		"-t", "nat",
+		"-A", chainName,
+		"-m", "owner",
+		"--gid-owner", strconv.Itoa(gid),
+		"-j", "RETURN",
+		"-m", "comment",
+		"--comment", formatComment(comment),
+	}
+}
+
+func makeCreateNewChain(name string, comment string) []string {
+	return []string{
 		"-t", "nat",
 		"-N", name,
 		"-m", "comment",
-		"--comment", formatComment(comment))
+		"--comment", formatComment(comment),
+	}
 }
 
-func makeFlushChain(name string) *exec.Cmd {
-	return exec.Command("iptables",
+func makeFlushChain(name string) []string {
+	return []string{
 		"-t", "nat",
-		"-F", name)
+		"-F", name,
+	}
 }
 
-func makeDeleteChain(name string) *exec.Cmd {
-	return exec.Command("iptables",
+func makeDeleteRule(rule []string) []string {
+	return append([]string{"-t", "nat", "-D"}, rule...)
+}
+
+func makeDeleteChain(name string) []string {
+	return []string{
 		"-t", "nat",
-		"-X", name)
+		"-X", name,
+	}
 }
 
-func makeRedirectChainToPort(chainName string, portToRedirect int, comment string) *exec.Cmd {
-	return exec.Command("iptables",
+func makeRedirectChainToPort(chainName string, portToRedirect int, comment string) []string {
+	return []string{
 		"-t", "nat",
 		"-A", chainName,
 		"-p", "tcp",
 		"-j", "REDIRECT",
 		"--to-port", strconv.Itoa(portToRedirect),
 		"-m", "comment",
-		"--comment", formatComment(comment))
+		"--comment", formatComment(comment),
+	}
 }
 
-func makeIgnorePort(chainName string, portToIgnore int, comment string) *exec.Cmd {
-	return exec.Command("iptables",
+func makeIgnorePort(chainName string, portToIgnore int, comment string) []string {
+	return []string{
 		"-t", "nat",
 		"-A", chainName,
 		"-p", "tcp",
 		"--destination-port", strconv.Itoa(portToIgnore),
 		"-j", "RETURN",
 		"-m", "comment",
-		"--comment", formatComment(comment))
+		"--comment", formatComment(comment),
+	}
+}
+
+func makeIgnoreDestination(chainName string, cidr string, comment string) []string {
+	return []string{
+		"-t", "nat",
+		"-A", chainName,
+		"-d", cidr,
+		"-j", "RETURN",
+		"-m", "comment",
+		"--comment", formatComment(comment),
+	}
 }
 
-func makeIgnoreLoopback(chainName string, comment string) *exec.Cmd {
-	return exec.Command("iptables",
+func makeIgnoreLoopback(chainName string, comment string) []string {
+	return []string{
 		"-t", "nat",
 		"-A", chainName,
 		"-o", "lo",
 		"-j", "RETURN",
 		"-m", "comment",
-		"--comment", formatComment(comment))
+		"--comment", formatComment(comment),
+	}
 }
 
-func makeRedirectChainToPortBasedOnDestinationPort(chainName string, destinationPort int, portToRedirect int, comment string) *exec.Cmd {
-	return exec.Command("iptables",
+func makeRedirectChainToPortBasedOnDestinationPort(chainName string, destinationPort int, portToRedirect int, comment string) []string {
+	return []string{
 		"-t", "nat",
 		"-A", chainName,
 		"-p", "tcp",
@@ -229,18 +476,24 @@ func makeRedirectChainToPortBasedOnDestinationPort(chainName string, destination
 		"-j", "REDIRECT",
 		"--to-port", strconv.Itoa(portToRedirect),
 		"-m", "comment",
-		"--comment", formatComment(comment))
+		"--comment", formatComment(comment),
+	}
 }
 
-func makeJumpFromChainToAnotherForAllProtocols(chainName string, targetChain string, comment string) *exec.Cmd {
-	return exec.Command("iptables",
+func makeJumpFromChainToAnotherForAllProtocols(chainName string, targetChain string, comment string) []string {
+	return []string{
 		"-t", "nat",
 		"-A", chainName,
 		"-j", targetChain,
 		"-m", "comment",
-		"--comment", formatComment(comment))
+		"--comment", formatComment(comment),
+	}
+}
+
+func makeListRules() []string {
+	return []string{"-t", "nat", "-S"}
 }
 
-func makeShowAllRules() *exec.Cmd {
-	return exec.Command("iptables", "-t", "nat", "-vnL")
+func makeShowAllRules() []string {
+	return []string{"-t", "nat", "-vnL"}
 }
@@ -0,0 +1,495 @@
+package iptables
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMakeFirewallRules(t *testing.T) {
+	ExecutionTraceId = "1"
+
+	t.Run("It redirects all ports", func(t *testing.T) {
+		config := FirewallConfiguration{
+			Mode:                  RedirectAllMode,
+			InboundPortsToIgnore:  []int{4190, 4191},
+			OutboundPortsToIgnore: []int{3306},
+			ProxyInboundPort:      4143,
+			ProxyOutgoingPort:     4140,
+			ProxyUid:              2102,
+		}
+
+		expected := []string{
+			"-t nat -N PROXY_INIT_REDIRECT -m comment --comment proxy-init/redirect-common-chain/1",
+			"-t nat -A PROXY_INIT_REDIRECT -p tcp --destination-port 4190 -j RETURN -m comment --comment proxy-init/ignore-port-4190/1",
+			"-t nat -A PROXY_INIT_REDIRECT -p tcp --destination-port 4191 -j RETURN -m comment --comment proxy-init/ignore-port-4191/1",
+			"-t nat -A PROXY_INIT_REDIRECT -p tcp -j REDIRECT --to-port 4143 -m comment --comment proxy-init/redirect-all-incoming-to-proxy-port/1",
+			"-t nat -A PREROUTING -j PROXY_INIT_REDIRECT -m comment --comment proxy-init/install-proxy-init-prerouting/1",
+			"-t nat -N PROXY_INIT_OUTPUT -m comment --comment proxy-init/redirect-common-chain/1",
+			"-t nat -A PROXY_INIT_OUTPUT -m owner --uid-owner 2102 -j RETURN -m comment --comment proxy-init/ignore-proxy-user-id/1",
+			"-t nat -A PROXY_INIT_OUTPUT -o lo -j RETURN -m comment --comment proxy-init/ignore-loopback/1",
+			"-t nat -A PROXY_INIT_OUTPUT -p tcp --destination-port 3306 -j RETURN -m comment --comment proxy-init/ignore-port-3306/1",
+			"-t nat -A PROXY_INIT_OUTPUT -p tcp -j REDIRECT --to-port 4140 -m comment --comment proxy-init/redirect-all-outgoing-to-proxy-port/1",
+			"-t nat -A OUTPUT -j PROXY_INIT_OUTPUT -m comment --comment proxy-init/install-proxy-init-output/1",
+		}
+
+		assertRules(t, makeFirewallRules(config, IPv4), expected)
+	})
+
+	t.Run("It redirects listed ports", func(t *testing.T) {
+		config := FirewallConfiguration{
+			Mode:                   RedirectListedMode,
+			PortsToRedirectInbound: []int{8080, 9090},
+			ProxyInboundPort:       4143,
+			ProxyOutgoingPort:      4140,
+		}
+
+		expected := []string{
+			"-t nat -N PROXY_INIT_REDIRECT -m comment --comment proxy-init/redirect-common-chain/1",
+			"-t nat -A PROXY_INIT_REDIRECT -p tcp --destination-port 8080 -j REDIRECT --to-port 4143 -m comment --comment proxy-init/redirect-port-8080-to-proxy-port/1",
+			"-t nat -A PROXY_INIT_REDIRECT -p tcp --destination-port 9090 -j REDIRECT --to-port 4143 -m comment --comment proxy-init/redirect-port-9090-to-proxy-port/1",
+			"-t nat -A PREROUTING -j PROXY_INIT_REDIRECT -m comment --comment proxy-init/install-proxy-init-prerouting/1",
+			"-t nat -N PROXY_INIT_OUTPUT -m comment --comment proxy-init/redirect-common-chain/1",
+			"-t nat -A PROXY_INIT_OUTPUT -o lo -j RETURN -m comment --comment proxy-init/ignore-loopback/1",
+			"-t nat -A PROXY_INIT_OUTPUT -p tcp -j REDIRECT --to-port 4140 -m comment --comment proxy-init/redirect-all-outgoing-to-proxy-port/1",
+			"-t nat -A OUTPUT -j PROXY_INIT_OUTPUT -m comment --comment proxy-init/install-proxy-init-output/1",
+		}
+
+		assertRules(t, makeFirewallRules(config, IPv4), expected)
+	})
+
+	t.Run("It skips outbound traffic by owner and destination before redirecting it", func(t *testing.T) {
+		config := FirewallConfiguration{
+			Mode:                  RedirectAllMode,
+			OutboundPortsToIgnore: []int{3306},
+			OutboundUidsToIgnore:  []int{1337, 2103},
+			OutboundGidsToIgnore:  []int{1500},
+			OutboundCidrsToIgnore: []string{"169.254.20.10/32", "fd00:10:96::a/128", "10.0.0.0/8"},
+			ProxyInboundPort:      4143,
+			ProxyOutgoingPort:     4140,
+			ProxyUid:              2102,
+		}
+
+		assertGoldenRules(t, makeFirewallRules(config, IPv4), "testdata/skip_outbound.golden")
+		assertGoldenRules(t, makeFirewallRules(config, IPv6), "testdata/skip_outbound_ipv6.golden")
+	})
+
+	t.Run("It programs the same rules with every backend", func(t *testing.T) {
+		rule := makeIgnoreLoopback(outputChainName, "ignore-loopback")
+
+		for _, backend := range []Backend{LegacyBackend, NftBackend} {
+			for family, binary := range map[IPFamily]string{IPv4: backend.Binary, IPv6: backend.IP6Binary} {
+				cmd := backend.command(family, rule)
+				expected := append([]string{binary}, rule...)
+				if !reflect.DeepEqual(cmd.Args, expected) {
+					t.Fatalf("Expected %s %s command %v, got %v", backend.Mode, family, expected, cmd.Args)
+				}
+			}
+		}
+	})
+}
+
+// fakeIptables is a scripted iptables that records the commands it runs, and
+// lists the rules in listOutput.
+type fakeIptables struct {
+	listOutput string
+	listErr    error
+	commands   []string
+}
+
+func (f *fakeIptables) run(cmd *exec.Cmd) ([]byte, error) {
+	command := strings.Join(cmd.Args, " ")
+	f.commands = append(f.commands, command)
+	if strings.HasSuffix(command, " -t nat -S") {
+		return []byte(f.listOutput), f.listErr
+	}
+	return []byte{}, nil
+}
+
+// fakeLookPath finds only the given iptables binaries, as if they were the
+// only ones installed.
+func fakeLookPath(binaries ...string) func(string) (string, error) {
+	return func(file string) (string, error) {
+		for _, binary := range binaries {
+			if file == binary {
+				return "/usr/sbin/" + file, nil
+			}
+		}
+		return "", &exec.Error{Name: file, Err: exec.ErrNotFound}
+	}
+}
+
+func TestConfigureFirewall(t *testing.T) {
+	ExecutionTraceId = "2"
+	defer func(original func(*exec.Cmd) ([]byte, error)) { runCommand = original }(runCommand)
+	defer func(original func(string) (string, error)) { lookPath = original }(lookPath)
+	lookPath = fakeLookPath("iptables-legacy", "iptables-legacy-save", "iptables-nft", "iptables-nft-save")
+
+	config := FirewallConfiguration{
+		Mode:              RedirectAllMode,
+		ProxyInboundPort:  4143,
+		ProxyOutgoingPort: 4140,
+		ProxyUid:          2102,
+		IptablesMode:      LegacyIptablesMode,
+	}
+
+	// The rules of a previous run, as listed by iptables-nft, which quotes
+	// comments, alongside rules that proxy-init didn't install.
+	previousRun := `-P PREROUTING ACCEPT
+-P OUTPUT ACCEPT
+-N KUBE-SERVICES
+-N PROXY_INIT_REDIRECT
+-N PROXY_INIT_OUTPUT
+-A PREROUTING -j KUBE-SERVICES
+-A PREROUTING -m comment --comment "proxy-init/install-proxy-init-prerouting/1" -j PROXY_INIT_REDIRECT
+-A OUTPUT -m comment --comment "proxy-init/install-proxy-init-output/1" -j PROXY_INIT_OUTPUT
+-A PROXY_INIT_REDIRECT -p tcp -m comment --comment "proxy-init/redirect-all-incoming-to-proxy-port/1" -j REDIRECT --to-ports 4143
+-A PROXY_INIT_OUTPUT -o lo -m comment --comment "proxy-init/ignore-loopback/1" -j RETURN
+-A PROXY_INIT_OUTPUT -p tcp -m comment --comment "proxy-init/redirect-all-outgoing-to-proxy-port/1" -j REDIRECT --to-ports 4140
+`
+	removePreviousRun := []string{
+		"iptables-legacy -t nat -D PREROUTING -m comment --comment proxy-init/install-proxy-init-prerouting/1 -j PROXY_INIT_REDIRECT",
+		"iptables-legacy -t nat -D OUTPUT -m comment --comment proxy-init/install-proxy-init-output/1 -j PROXY_INIT_OUTPUT",
+		"iptables-legacy -t nat -F PROXY_INIT_REDIRECT",
+		"iptables-legacy -t nat -X PROXY_INIT_REDIRECT",
+		"iptables-legacy -t nat -F PROXY_INIT_OUTPUT",
+		"iptables-legacy -t nat -X PROXY_INIT_OUTPUT",
+	}
+
+	install := []string{}
+	for _, rule := range makeFirewallRules(config, IPv4) {
+		install = append(install, "iptables-legacy "+strings.Join(rule, " "))
+	}
+
+	testCases := []struct {
+		name       string
+		cleanup    bool
+		listOutput string
+		expected   [][]string
+	}{
+		{
+			name:       "installs the rules",
+			listOutput: "-P PREROUTING ACCEPT\n-P OUTPUT ACCEPT\n",
+			expected:   [][]string{{"iptables-legacy -t nat -vnL", "iptables-legacy -t nat -S"}, install, {"iptables-legacy -t nat -vnL"}},
+		},
+		{
+			name:       "replaces the rules of a previous run",
+			listOutput: previousRun,
+			expected:   [][]string{{"iptables-legacy -t nat -vnL", "iptables-legacy -t nat -S"}, removePreviousRun, install, {"iptables-legacy -t nat -vnL"}},
+		},
+		{
+			name:       "removes the rules of a previous run",
+			cleanup:    true,
+			listOutput: previousRun,
+			expected:   [][]string{{"iptables-legacy -t nat -S"}, removePreviousRun, {"iptables-legacy -t nat -vnL"}},
+		},
+		{
+			name:       "removes nothing without a previous run",
+			cleanup:    true,
+			listOutput: "-P PREROUTING ACCEPT\n-N KUBE-SERVICES\n-A PREROUTING -j KUBE-SERVICES\n",
+			expected:   [][]string{{"iptables-legacy -t nat -S", "iptables-legacy -t nat -vnL"}},
+		},
+		{
+			name:       "removes a jump left without its chain",
+			cleanup:    true,
+			listOutput: "-A OUTPUT -j PROXY_INIT_OUTPUT\n",
+			expected:   [][]string{{"iptables-legacy -t nat -S", "iptables-legacy -t nat -D OUTPUT -j PROXY_INIT_OUTPUT", "iptables-legacy -t nat -vnL"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeIptables{listOutput: tc.listOutput}
+			runCommand = fake.run
+
+			var err error
+			if tc.cleanup {
+				err = CleanupFirewall(config)
+			} else {
+				err = ConfigureFirewall(config)
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			expected := []string{}
+			for _, commands := range tc.expected {
+				expected = append(expected, commands...)
+			}
+			if !reflect.DeepEqual(fake.commands, expected) {
+				t.Fatalf("Expected commands:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(fake.commands, "\n"))
+			}
+		})
+	}
+
+	t.Run("fails if the rules can't be listed", func(t *testing.T) {
+		fake := &fakeIptables{listOutput: "iptables: can't initialize iptables table `nat'", listErr: errors.New("exit status 3")}
+		runCommand = fake.run
+
+		err := ConfigureFirewall(config)
+		expected := "failed to list the legacy iptables rules: exit status 3: iptables: can't initialize iptables table `nat'"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+
+	t.Run("programs the rules in the network namespace", func(t *testing.T) {
+		fake := &fakeIptables{listOutput: "-A OUTPUT -j PROXY_INIT_OUTPUT\n"}
+		runCommand = fake.run
+
+		inNetNs := config
+		inNetNs.NetNs = "/var/run/netns/cni-1234"
+		if err := CleanupFirewall(inNetNs); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := []string{
+			"nsenter --net=/var/run/netns/cni-1234 iptables-legacy -t nat -S",
+			"nsenter --net=/var/run/netns/cni-1234 iptables-legacy -t nat -D OUTPUT -j PROXY_INIT_OUTPUT",
+			"nsenter --net=/var/run/netns/cni-1234 iptables-legacy -t nat -vnL",
+		}
+		if !reflect.DeepEqual(fake.commands, expected) {
+			t.Fatalf("Expected commands:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(fake.commands, "\n"))
+		}
+	})
+
+	t.Run("runs nothing when simulating", func(t *testing.T) {
+		fake := &fakeIptables{listOutput: previousRun}
+		runCommand = fake.run
+
+		simulated := config
+		simulated.SimulateOnly = true
+		if err := ConfigureFirewall(simulated); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := CleanupFirewall(simulated); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(fake.commands) != 0 {
+			t.Fatalf("Expected no commands, got: %v", fake.commands)
+		}
+	})
+
+	t.Run("programs the rules of the pod's IP families", func(t *testing.T) {
+		defer func(original func() ([]net.Addr, error)) { interfaceAddrs = original }(interfaceAddrs)
+
+		commandsFor := func(binary string, family IPFamily) []string {
+			commands := []string{binary + " -t nat -vnL", binary + " -t nat -S"}
+			for _, rule := range makeFirewallRules(config, family) {
+				commands = append(commands, binary+" "+strings.Join(rule, " "))
+			}
+			return append(commands, binary+" -t nat -vnL")
+		}
+		ipv4Rules := commandsFor("iptables-legacy", IPv4)
+		ipv6Rules := commandsFor("ip6tables-legacy", IPv6)
+
+		// Every pod has loopback addresses, and a link-local IPv6 address
+		// unless IPv6 is disabled
+		ipv4Pod := []string{"127.0.0.1/8", "::1/128", "10.1.2.3/24", "fe80::1/64"}
+		ipv6Pod := []string{"127.0.0.1/8", "::1/128", "fd00:10:244::3/64", "fe80::1/64"}
+		dualStackPod := []string{"127.0.0.1/8", "::1/128", "10.1.2.3/24", "fd00:10:244::3/64", "fe80::1/64"}
+
+		testCases := []struct {
+			name     string
+			ipv6Mode string
+			addrs    []string
+			expected [][]string
+		}{
+			{"IPv4-only pod", AutoIPv6Mode, ipv4Pod, [][]string{ipv4Rules}},
+			{"IPv6-only pod", AutoIPv6Mode, ipv6Pod, [][]string{ipv6Rules}},
+			{"dual-stack pod", AutoIPv6Mode, dualStackPod, [][]string{ipv4Rules, ipv6Rules}},
+			{"IPv6 enabled on an IPv4-only pod", EnabledIPv6Mode, ipv4Pod, [][]string{ipv4Rules, ipv6Rules}},
+			{"IPv6 disabled on a dual-stack pod", DisabledIPv6Mode, dualStackPod, [][]string{ipv4Rules}},
+		}
+
+		for _, tc := range testCases {
+			tc := tc // pin
+			t.Run(tc.name, func(t *testing.T) {
+				fake := &fakeIptables{listOutput: "-P OUTPUT ACCEPT\n"}
+				runCommand = fake.run
+				interfaceAddrs = func() ([]net.Addr, error) {
+					addrs := []net.Addr{}
+					for _, cidr := range tc.addrs {
+						ip, ipNet, err := net.ParseCIDR(cidr)
+						if err != nil {
+							return nil, err
+						}
+						ipNet.IP = ip
+						addrs = append(addrs, ipNet)
+					}
+					return addrs, nil
+				}
+
+				withIPv6Mode := config
+				withIPv6Mode.IPv6Mode = tc.ipv6Mode
+				if err := ConfigureFirewall(withIPv6Mode); err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+
+				expected := []string{}
+				for _, commands := range tc.expected {
+					expected = append(expected, commands...)
+				}
+				if !reflect.DeepEqual(fake.commands, expected) {
+					t.Fatalf("Expected commands:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(fake.commands, "\n"))
+				}
+			})
+		}
+	})
+
+	t.Run("doesn't detect the IP families of another network namespace", func(t *testing.T) {
+		fake := &fakeIptables{}
+		runCommand = fake.run
+
+		inNetNs := config
+		inNetNs.NetNs = "/var/run/netns/cni-1234"
+		inNetNs.IPv6Mode = AutoIPv6Mode
+		err := ConfigureFirewall(inNetNs)
+		expected := "the auto IPv6 mode can't detect the IP families of another network namespace, use enabled or disabled"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+		if len(fake.commands) != 0 {
+			t.Fatalf("Expected no commands, got: %v", fake.commands)
+		}
+	})
+}
+
+func assertRules(t *testing.T, rules [][]string, expected []string) {
+	t.Helper()
+
+	actual := make([]string, len(rules))
+	for i, rule := range rules {
+		actual[i] = strings.Join(rule, " ")
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected rules:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func assertGoldenRules(t *testing.T, rules [][]string, goldenFileName string) {
+	t.Helper()
+
+	golden, err := ioutil.ReadFile(goldenFileName)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertRules(t, rules, strings.Split(strings.TrimSuffix(string(golden), "\n"), "\n"))
+}
+
+func TestSelectBackend(t *testing.T) {
+	legacySave := `# Generated by iptables-save v1.8.2 on Thu Oct  1 10:00:00 2020
+*nat
+:PREROUTING ACCEPT [0:0]
+:KUBE-SERVICES - [0:0]
+-A PREROUTING -j KUBE-SERVICES
+-A KUBE-SERVICES -j RETURN
+COMMIT
+`
+	nftSave := `# Generated by iptables-nft-save v1.8.2 on Thu Oct  1 10:00:00 2020
+*nat
+:PREROUTING ACCEPT [0:0]
+-A PREROUTING -j KUBE-SERVICES
+COMMIT
+`
+	emptySave := `# Warning: iptables-legacy tables present, use iptables-legacy-save to see them
+`
+
+	fakeSaveRules := func(legacy, nft string, legacyErr, nftErr error) func(Backend) ([]byte, error) {
+		return func(backend Backend) ([]byte, error) {
+			if backend == LegacyBackend {
+				return []byte(legacy), legacyErr
+			}
+			return []byte(nft), nftErr
+		}
+	}
+	defer func(original func(Backend) ([]byte, error)) { saveRules = original }(saveRules)
+	defer func(original func(string) (string, error)) { lookPath = original }(lookPath)
+	lookPath = fakeLookPath("iptables-legacy", "iptables-legacy-save", "iptables-nft", "iptables-nft-save")
+
+	testCases := []struct {
+		name      string
+		mode      string
+		saveRules func(Backend) ([]byte, error)
+		expected  Backend
+	}{
+		{"legacy overrides detection", LegacyIptablesMode, fakeSaveRules(emptySave, nftSave, nil, nil), LegacyBackend},
+		{"nft overrides detection", NftIptablesMode, fakeSaveRules(legacySave, emptySave, nil, nil), NftBackend},
+		{"detects legacy rules", AutoIptablesMode, fakeSaveRules(legacySave, emptySave, nil, nil), LegacyBackend},
+		{"detects nft rules", AutoIptablesMode, fakeSaveRules(emptySave, nftSave, nil, nil), NftBackend},
+		{"detects the backend with the most rules", "", fakeSaveRules(legacySave, nftSave, nil, nil), LegacyBackend},
+		{"defaults to legacy without rules", AutoIptablesMode, fakeSaveRules(emptySave, emptySave, nil, nil), LegacyBackend},
+		{"uses nft if legacy fails", AutoIptablesMode, fakeSaveRules("", emptySave, errors.New("exit status 1"), nil), NftBackend},
+		{"uses legacy if nft fails", AutoIptablesMode, fakeSaveRules(emptySave, "", nil, errors.New("exit status 1")), LegacyBackend},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			saveRules = tc.saveRules
+
+			backend, err := SelectBackend(tc.mode)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if backend != tc.expected {
+				t.Fatalf("Expected the %s backend, got the %s backend", tc.expected.Mode, backend.Mode)
+			}
+		})
+	}
+
+	t.Run("fails if neither backend works", func(t *testing.T) {
+		saveRules = fakeSaveRules("", "", errors.New("ip_tables not loaded"), errors.New("nf_tables not loaded"))
+
+		_, err := SelectBackend(AutoIptablesMode)
+		if err == nil {
+			t.Fatal("Expected an error, got nil")
+		}
+		for _, diagnostic := range []string{"iptables-legacy-save: ip_tables not loaded", "iptables-nft-save: nf_tables not loaded", IptablesModeEnvVarName} {
+			if !strings.Contains(err.Error(), diagnostic) {
+				t.Fatalf("Expected error to contain %q, got: %s", diagnostic, err)
+			}
+		}
+	})
+
+	t.Run("falls back to plain iptables without the backends' binaries", func(t *testing.T) {
+		saveRules = fakeSaveRules(legacySave, nftSave, nil, nil)
+		testCases := []struct {
+			name      string
+			mode      string
+			installed []string
+			expected  Backend
+		}{
+			{"auto without either backend", AutoIptablesMode, []string{"iptables", "iptables-save"}, PlainBackend},
+			{"legacy without the legacy backend", LegacyIptablesMode, []string{"iptables", "iptables-save", "iptables-nft", "iptables-nft-save"}, PlainBackend},
+			{"nft without the nft backend", NftIptablesMode, []string{"iptables", "iptables-save", "iptables-legacy", "iptables-legacy-save"}, PlainBackend},
+			{"auto with a single backend", AutoIptablesMode, []string{"iptables", "iptables-save", "iptables-nft", "iptables-nft-save"}, LegacyBackend},
+			{"legacy without plain iptables", LegacyIptablesMode, []string{"iptables-nft", "iptables-nft-save"}, LegacyBackend},
+		}
+
+		for _, tc := range testCases {
+			lookPath = fakeLookPath(tc.installed...)
+
+			backend, err := SelectBackend(tc.mode)
+			if err != nil {
+				t.Fatalf("%s: Unexpected error: %s", tc.name, err)
+			}
+			if backend != tc.expected {
+				t.Fatalf("%s: Expected the %s backend, got the %s backend", tc.name, tc.expected.Binary, backend.Binary)
+			}
+		}
+	})
+
+	t.Run("rejects unknown modes", func(t *testing.T) {
+		_, err := SelectBackend("xtables")
+		expected := "iptables mode must be one of: auto, legacy, nft, was: xtables"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got: %v", expected, err)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -8,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/linkerd/linkerd2/cli/install"
 	"github.com/linkerd/linkerd2/testutil"
 )
 
@@ -167,6 +169,44 @@ func TestDashboard(t *testing.T) {
 	}
 }
 
+func TestGrafanaDashboards(t *testing.T) {
+	grafanaURL, err := TestHelper.ProxyURLFor(TestHelper.GetLinkerdNamespace(), "grafana", "http")
+	if err != nil {
+		t.Fatalf("Failed to get proxy URL: %s", err)
+	}
+
+	if _, err := TestHelper.HTTPGetURL(grafanaURL + "api/health"); err != nil {
+		t.Fatalf("Grafana is not healthy: %s", err)
+	}
+
+	resp, err := TestHelper.HTTPGetURL(grafanaURL + "api/search")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var results []struct {
+		Title string `json:"title"`
+	}
+	if err := json.Unmarshal([]byte(resp), &results); err != nil {
+		t.Fatalf("Unexpected error: %v %s", err, resp)
+	}
+	titles := make(map[string]struct{})
+	for _, result := range results {
+		titles[result.Title] = struct{}{}
+	}
+
+	for name, dashboard := range install.GrafanaDashboards {
+		var expected struct {
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal([]byte(dashboard), &expected); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, ok := titles[expected.Title]; !ok {
+			t.Fatalf("Expected Grafana to have loaded dashboard [%s] (%s), got %v", name, expected.Title, resp)
+		}
+	}
+}
+
 func TestInject(t *testing.T) {
 	cmd := []string{"inject", "testdata/smoke_test.yaml"}
 	if TestHelper.TLS() {
@@ -1,22 +1,40 @@
 import PropTypes from 'prop-types';
 import React from 'react';
 
-const GrafanaLink = ({PrefixedLink, name, namespace, resource}) => {
+const GrafanaLink = ({PrefixedLink, grafanaUrl, name, namespace, resource}) => {
+  let dashboardPath = `/dashboard/db/linkerd-${resource}?var-namespace=${namespace}&var-${resource}=${name}`;
+  let contents = <React.Fragment>{name}&nbsp;&nbsp;<i className="fa fa-external-link" /></React.Fragment>;
+
+  // an external Grafana is linked to directly, rather than through the
+  // Kubernetes API proxy that serves the bundled one
+  if (grafanaUrl) {
+    return (
+      <a href={`${grafanaUrl}${dashboardPath}`} target="_blank" rel="noopener noreferrer">
+        {contents}
+      </a>
+    );
+  }
+
   return (
     <PrefixedLink
-      to={`/dashboard/db/linkerd-${resource}?var-namespace=${namespace}&var-${resource}=${name}`}
+      to={dashboardPath}
       deployment="grafana"
       targetBlank={true}>
-      {name}&nbsp;&nbsp;<i className="fa fa-external-link" />
+      {contents}
     </PrefixedLink>
   );
 };
 
 GrafanaLink.propTypes = {
+  grafanaUrl: PropTypes.string,
   name: PropTypes.string.isRequired,
   namespace: PropTypes.string.isRequired,
   PrefixedLink: PropTypes.func.isRequired,
   resource: PropTypes.string.isRequired,
 };
 
+GrafanaLink.defaultProps = {
+  grafanaUrl: "",
+};
+
 export default GrafanaLink;
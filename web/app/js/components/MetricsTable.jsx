@@ -43,7 +43,7 @@ const formatTitle = (title, tooltipText) => {
 
 };
 
-const columnDefinitions = (resource, namespaces, onFilterClick, showNamespaceColumn, PrefixedLink, showGrafanaLink) => {
+const columnDefinitions = (resource, namespaces, onFilterClick, showNamespaceColumn, PrefixedLink, showGrafanaLink, grafanaUrl) => {
   let nsColumn = [
     {
       title: formatTitle("Namespace"),
@@ -78,6 +78,7 @@ const columnDefinitions = (resource, namespaces, onFilterClick, showNamespaceCol
                 name={row.name}
                 namespace={row.namespace}
                 resource={resource}
+                grafanaUrl={grafanaUrl}
                 PrefixedLink={PrefixedLink} />
             );
           } else {
@@ -152,6 +153,7 @@ const columnDefinitions = (resource, namespaces, onFilterClick, showNamespaceCol
 /** @extends React.Component */
 export class MetricsTableBase extends BaseTable {
   static defaultProps = {
+    grafanaUrl: "",
     showGrafanaLink: true,
     showNamespaceColumn: true,
   }
@@ -160,6 +162,7 @@ export class MetricsTableBase extends BaseTable {
     api: PropTypes.shape({
       PrefixedLink: PropTypes.func.isRequired,
     }).isRequired,
+    grafanaUrl: PropTypes.string,
     metrics: PropTypes.arrayOf(processedMetricsPropType.isRequired).isRequired,
     resource: PropTypes.string.isRequired,
     showGrafanaLink: PropTypes.bool,
@@ -227,7 +230,8 @@ export class MetricsTableBase extends BaseTable {
       this.onFilterDropdownVisibleChange,
       showNsColumn,
       this.api.PrefixedLink,
-      showGrafanaLink
+      showGrafanaLink,
+      this.props.grafanaUrl
     ));
 
     let locale = {
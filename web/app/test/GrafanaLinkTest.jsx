@@ -30,4 +30,34 @@ describe('GrafanaLink', () => {
     expect(component.html()).to.contain(expectedNsStr);
     expect(component.html()).to.contain(expectedVarNameStr);
   });
+
+  it('links to an external Grafana when one is configured', () => {
+    let api = ApiHelpers('/api/v1/namespaces/linkerd/services/web:http/proxy');
+    let linkProps = {
+      resource: "deployment",
+      name: "voting",
+      namespace: "emojivoto",
+      grafanaUrl: "https://grafana.example.com",
+      PrefixedLink: api.PrefixedLink
+    };
+    let component = mount(routerWrap(GrafanaLink, linkProps));
+
+    let link = component.find("a");
+    expect(link).to.have.length(1);
+    expect(link.prop("href")).to.equal("https://grafana.example.com/dashboard/db/linkerd-deployment?var-namespace=emojivoto&var-deployment=voting");
+    expect(link.prop("target")).to.equal("_blank");
+  });
+
+  it('links to the bundled Grafana through the proxy otherwise', () => {
+    let api = ApiHelpers('/api/v1/namespaces/linkerd/services/web:http/proxy');
+    let linkProps = {
+      resource: "deployment",
+      name: "voting",
+      namespace: "emojivoto",
+      PrefixedLink: api.PrefixedLink
+    };
+    let component = mount(routerWrap(GrafanaLink, linkProps));
+
+    expect(component.find("a").prop("href")).to.equal("/api/v1/namespaces/linkerd/services/grafana:http/proxy/dashboard/db/linkerd-deployment?var-namespace=emojivoto&var-deployment=voting");
+  });
 });
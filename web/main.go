@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	cryptoTls "crypto/tls"
 	"flag"
 	"net"
 	"os"
@@ -10,8 +11,10 @@ import (
 	"time"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/flags"
+	"github.com/linkerd/linkerd2/pkg/tls"
 	"github.com/linkerd/linkerd2/web/srv"
 	log "github.com/sirupsen/logrus"
 )
@@ -20,19 +23,30 @@ func main() {
 	addr := flag.String("addr", ":8084", "address to serve on")
 	metricsAddr := flag.String("metrics-addr", ":9994", "address to serve scrapable metrics on")
 	kubernetesApiHost := flag.String("api-addr", ":8085", "host address of kubernetes public api")
-	templateDir := flag.String("template-dir", "templates", "directory to search for template files")
 	staticDir := flag.String("static-dir", "app/dist", "directory to search for static files")
 	uuid := flag.String("uuid", "", "unique linkerd install id")
-	reload := flag.Bool("reload", true, "reloading set to true or false")
 	webpackDevServer := flag.String("webpack-dev-server", "", "use webpack to serve static assets; frontend will use this instead of static-dir")
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
-	flags.ConfigureAndParse()
+	grafanaURL := flag.String("grafana-url", "", "base URL of an external Grafana to link to, instead of the bundled one")
+	apiCAFile := flag.String("api-ca-file", "", "path to the PEM-encoded CA certificate that issued the public api's serving certificate; if set, the public api is called over TLS")
+	flags.ConfigureAndParse(flag.CommandLine, os.Args[1:])
 
-	_, _, err := net.SplitHostPort(*kubernetesApiHost) // Verify kubernetesApiHost is of the form host:port.
+	apiHost, _, err := net.SplitHostPort(*kubernetesApiHost) // Verify kubernetesApiHost is of the form host:port.
 	if err != nil {
 		log.Fatalf("failed to parse API server address: %s", *kubernetesApiHost)
 	}
-	client, err := public.NewInternalClient(*kubernetesApiHost)
+
+	var client pb.ApiClient
+	if *apiCAFile != "" {
+		var tlsConfig *cryptoTls.Config
+		tlsConfig, err = tls.NewClientTLSConfig(*apiCAFile, apiHost)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		client, err = public.NewInternalTLSClient(*kubernetesApiHost, tlsConfig)
+	} else {
+		client, err = public.NewInternalClient(*kubernetesApiHost)
+	}
 	if err != nil {
 		log.Fatalf("failed to construct client for API server URL %s", *kubernetesApiHost)
 	}
@@ -40,7 +54,7 @@ func main() {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
-	server := srv.NewServer(*addr, *templateDir, *staticDir, *uuid, *controllerNamespace, *webpackDevServer, *reload, client)
+	server := srv.NewServer(*addr, *staticDir, *uuid, *controllerNamespace, *grafanaURL, *webpackDevServer, client)
 
 	go func() {
 		log.Infof("starting HTTP server on %+v", *addr)
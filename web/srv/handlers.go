@@ -12,15 +12,15 @@ import (
 var proxyPathRegexp = regexp.MustCompile("/api/v1/namespaces/.*/proxy/")
 
 type (
-	renderTemplate func(http.ResponseWriter, string, string, interface{}) error
-	serveFile      func(http.ResponseWriter, string, string, interface{}) error
+	renderTemplate func(http.ResponseWriter, string, interface{}) error
 
 	handler struct {
 		render              renderTemplate
-		serveFile           serveFile
 		apiClient           pb.ApiClient
 		uuid                string
 		controllerNamespace string
+		grafanaURL          string
+		webpackDevServer    string
 	}
 )
 
@@ -31,22 +31,25 @@ func (h *handler) handleIndex(w http.ResponseWriter, req *http.Request, p httpro
 		pathPfx = "/"
 	}
 
-	params := appParams{
+	page := &indexPage{
+		WebpackDevServer:    h.webpackDevServer,
+		PathPrefix:          pathPfx,
 		UUID:                h.uuid,
 		ControllerNamespace: h.controllerNamespace,
-		PathPrefix:          pathPfx,
+		GrafanaURL:          h.grafanaURL,
 	}
 
 	version, err := h.apiClient.Version(req.Context(), &pb.Empty{}) // TODO: remove and call /api/version from web app
 	if err != nil {
-		params.Error = true
-		params.ErrorMessage = err.Error()
+		page.Error = true
+		page.ErrorMessage = err.Error()
 		log.Error(err.Error())
 	} else {
-		params.Data = version
+		page.ReleaseVersion = version.GetReleaseVersion()
+		page.GoVersion = version.GetGoVersion()
 	}
 
-	err = h.render(w, "app.tmpl.html", "base", params)
+	err = h.render(w, "base", page)
 
 	if err != nil {
 		log.Error(err.Error())
@@ -1,6 +1,9 @@
 package srv
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -24,8 +27,9 @@ func TestHandleIndex(t *testing.T) {
 	server := FakeServer()
 
 	handler := &handler{
-		render:    server.RenderTemplate,
-		apiClient: mockApiClient,
+		render:     server.RenderTemplate,
+		apiClient:  mockApiClient,
+		grafanaURL: "https://grafana.example.com",
 	}
 
 	recorder := httptest.NewRecorder()
@@ -53,6 +57,7 @@ func TestHandleIndex(t *testing.T) {
 		"data-go-version=\"the best one\"",
 		"data-controller-namespace=\"\"",
 		"data-uuid=\"\"",
+		"data-grafana-url=\"https://grafana.example.com\"",
 	}
 	for _, expectedSubstring := range expectedSubstrings {
 		if !strings.Contains(actualBody, expectedSubstring) {
@@ -60,3 +65,111 @@ func TestHandleIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestHandleIndexGolden(t *testing.T) {
+	version := &pb.VersionInfo{
+		GoVersion:      "go1.10.2",
+		BuildDate:      "never",
+		ReleaseVersion: "stable-2.0.0",
+	}
+
+	testCases := []struct {
+		path             string
+		webpackDevServer string
+		grafanaURL       string
+		apiClient        *public.MockApiClient
+		goldenFileName   string
+	}{
+		{
+			path:           "/",
+			apiClient:      &public.MockApiClient{VersionInfoToReturn: version},
+			goldenFileName: "testdata/index.golden",
+		},
+		{
+			path:           "/",
+			apiClient:      &public.MockApiClient{ErrorToReturn: errors.New(`rpc error: <unavailable> & "down"`)},
+			goldenFileName: "testdata/index_api_error.golden",
+		},
+		{
+			path:             "/",
+			webpackDevServer: "http://localhost:8080",
+			apiClient:        &public.MockApiClient{VersionInfoToReturn: version},
+			goldenFileName:   "testdata/index_webpack_dev_server.golden",
+		},
+		{
+			path:           "/api/v1/namespaces/linkerd/services/web:http/proxy/namespaces",
+			grafanaURL:     `https://grafana.example.com/?a=1&b="2"`,
+			apiClient:      &public.MockApiClient{VersionInfoToReturn: version},
+			goldenFileName: "testdata/index_dashboard_proxy.golden",
+		},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d: %s", i, tc.goldenFileName), func(t *testing.T) {
+			server := FakeServer()
+			handler := &handler{
+				render:              server.RenderTemplate,
+				apiClient:           tc.apiClient,
+				uuid:                "c6f7e1a6-uuid",
+				controllerNamespace: "linkerd",
+				grafanaURL:          tc.grafanaURL,
+				webpackDevServer:    tc.webpackDevServer,
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.handleIndex(recorder, httptest.NewRequest("GET", tc.path, nil), httprouter.Params{})
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("Unexpected StatusCode: %d", recorder.Code)
+			}
+
+			goldenFileBytes, err := ioutil.ReadFile(tc.goldenFileName)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if recorder.Body.String() != string(goldenFileBytes) {
+				t.Fatalf("Expected:\n%s\nGot:\n%s", goldenFileBytes, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestIndexPageBundleURL(t *testing.T) {
+	testCases := []struct {
+		page     indexPage
+		expected string
+	}{
+		{indexPage{PathPrefix: "/"}, "/dist/index_bundle.js"},
+		{indexPage{PathPrefix: "/api/v1/namespaces/linkerd/services/web:http/proxy/"}, "/api/v1/namespaces/linkerd/services/web:http/proxy/dist/index_bundle.js"},
+		{indexPage{PathPrefix: "/", WebpackDevServer: "http://localhost:8080"}, "http://localhost:8080/dist/index_bundle.js"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d: %s", i, tc.expected), func(t *testing.T) {
+			if url := tc.page.BundleURL(); url != tc.expected {
+				t.Fatalf("Expected bundle URL [%s], got [%s]", tc.expected, url)
+			}
+		})
+	}
+}
+
+func BenchmarkHandleIndex(b *testing.B) {
+	server := FakeServer()
+	handler := &handler{
+		render: server.RenderTemplate,
+		apiClient: &public.MockApiClient{
+			VersionInfoToReturn: &pb.VersionInfo{GoVersion: "go1.10.2", ReleaseVersion: "stable-2.0.0"},
+		},
+		uuid:                "c6f7e1a6-uuid",
+		controllerNamespace: "linkerd",
+	}
+	req := httptest.NewRequest("GET", "/", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		handler.handleIndex(httptest.NewRecorder(), req, httprouter.Params{})
+	}
+}
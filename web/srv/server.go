@@ -1,11 +1,10 @@
 package srv
 
 import (
-	"fmt"
+	"bytes"
 	"html/template"
 	"net/http"
-	"path"
-	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
@@ -21,42 +20,57 @@ const (
 
 type (
 	Server struct {
-		templateDir     string
-		staticDir       string
-		reload          bool
-		templateContext templateContext
-		templates       map[string]*template.Template
-		router          *httprouter.Router
+		staticDir        string
+		webpackDevServer string
+		templates        *template.Template
+		router           *httprouter.Router
 	}
 
-	templateContext struct {
+	// indexPage is the data that the index page is rendered with.
+	indexPage struct {
+		// WebpackDevServer serves the JS bundle instead of the server, when
+		// set.
 		WebpackDevServer string
-	}
-	templatePayload struct {
-		Context  templateContext
-		Contents interface{}
-	}
-	appParams struct {
-		Data                *pb.VersionInfo
+		// PathPrefix is the path that the server is served at, which isn't
+		// "/" when it's served through the Kubernetes API server's proxy.
+		PathPrefix          string
+		ReleaseVersion      string
+		GoVersion           string
 		UUID                string
 		ControllerNamespace string
+		GrafanaURL          string
 		Error               bool
 		ErrorMessage        string
-		PathPrefix          string
 	}
 )
 
+// BundleURL returns the URL of the web app's JS bundle.
+func (page *indexPage) BundleURL() string {
+	if page.WebpackDevServer != "" {
+		return page.WebpackDevServer + "/dist/index_bundle.js"
+	}
+	return page.PathPrefix + "dist/index_bundle.js"
+}
+
+// pageTemplates are the compiled templates of the web app's pages.
+var pageTemplates = template.Must(template.New("pages").Parse(baseTemplate + appTemplate))
+
+// renderBuffers are reused to render pages, which are only written once they
+// are rendered in full.
+var renderBuffers = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
 // this is called by the HTTP server to actually respond to a request
 func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	s.router.ServeHTTP(w, req)
 }
 
-func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackDevServer string, reload bool, apiClient pb.ApiClient) *http.Server {
+func NewServer(addr, staticDir, uuid, controllerNamespace, grafanaURL, webpackDevServer string, apiClient pb.ApiClient) *http.Server {
 	server := &Server{
-		templateDir:     templateDir,
-		staticDir:       staticDir,
-		templateContext: templateContext{webpackDevServer},
-		reload:          reload,
+		staticDir:        staticDir,
+		webpackDevServer: webpackDevServer,
+		templates:        pageTemplates,
 	}
 
 	server.router = &httprouter.Router{
@@ -69,9 +83,10 @@ func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackD
 	handler := &handler{
 		apiClient:           apiClient,
 		render:              server.RenderTemplate,
-		serveFile:           server.serveFile,
 		uuid:                uuid,
 		controllerNamespace: controllerNamespace,
+		grafanaURL:          grafanaURL,
+		webpackDevServer:    webpackDevServer,
 	}
 
 	httpServer := &http.Server{
@@ -104,60 +119,20 @@ func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackD
 	return httpServer
 }
 
-func (s *Server) RenderTemplate(w http.ResponseWriter, templateFile, templateName string, args interface{}) error {
-	log.Debugf("emitting template %s", templateFile)
-	template, err := s.loadTemplate(templateFile)
+// RenderTemplate renders the template templateName with data, and writes it
+// once it's rendered.
+func (s *Server) RenderTemplate(w http.ResponseWriter, templateName string, data interface{}) error {
+	log.Debugf("emitting template %s", templateName)
 
-	if err != nil {
-		log.Error(err.Error())
-		http.Error(w, "internal server error", http.StatusInternalServerError)
-		return nil
-	}
+	buf := renderBuffers.Get().(*bytes.Buffer)
+	defer renderBuffers.Put(buf)
+	buf.Reset()
 
-	w.Header().Set("Content-Type", "text/html")
-	if templateName == "" {
-		return template.Execute(w, args)
-	} else {
-		return template.ExecuteTemplate(w, templateName, templatePayload{Context: s.templateContext, Contents: args})
-	}
-}
-
-func (s *Server) loadTemplate(templateFile string) (template *template.Template, err error) {
-	// load template from disk if necessary
-	template = s.templates[templateFile]
-
-	if template == nil || s.reload {
-		templatePath := safelyJoinPath(s.templateDir, templateFile)
-		includes, err := filepath.Glob(filepath.Join(s.templateDir, "includes", "*.tmpl.html"))
-		if err != nil {
-			return nil, err
-		}
-		// for cases where you're not calling a named template, the passed-in path needs to be first
-		templateFiles := append([]string{templatePath}, includes...)
-		log.Debugf("loading templates from %v", templateFiles)
-		template, err = template.ParseFiles(templateFiles...)
-		if err == nil && !s.reload {
-			s.templates[templateFile] = template
-		}
-	}
-	return template, err
-}
-
-func safelyJoinPath(rootPath, userPath string) string {
-	return filepath.Join(rootPath, path.Clean("/"+userPath))
-}
-
-func (s *Server) serveFile(w http.ResponseWriter, fileName string, templateName string, args interface{}) error {
-	dispositionHeaderVal := fmt.Sprintf("attachment; filename='%s'", fileName)
-
-	w.Header().Set("Content-Type", "text/yaml")
-	w.Header().Set("Content-Disposition", dispositionHeaderVal)
-
-	template, err := s.loadTemplate(templateName)
-	if err != nil {
+	if err := s.templates.ExecuteTemplate(buf, templateName, data); err != nil {
 		return err
 	}
 
-	template.Execute(w, args)
-	return nil
+	w.Header().Set("Content-Type", "text/html")
+	_, err := buf.WriteTo(w)
+	return err
 }
@@ -0,0 +1,43 @@
+package srv
+
+// The templates of the web app's pages, which are compiled into the server.
+// The "base" template renders a page, with the "script-tags" and "content"
+// templates that each page defines.
+
+const baseTemplate = `{{ define "base" }}
+<!doctype html>
+<html lang="en">
+  <head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width">
+    <title>Linkerd</title>
+    <meta name="description" content="Linkerd">
+    <meta name="keywords" content="Linkerd">
+    <link rel="icon" type="image/png" href="{{.PathPrefix}}dist/img/favicon.png">
+    <link href="https://fonts.googleapis.com/css?family=Lato:300,400,700,900" rel="stylesheet">
+    {{ template "script-tags" . }}
+  </head>
+  <body>
+    {{ template "content" . }}
+  </body>
+</html>
+{{ end }}
+`
+
+const appTemplate = `{{ define "content" }}
+  <div class="main" id="main"
+    data-release-version="{{.ReleaseVersion}}"
+    data-go-version="{{.GoVersion}}"
+    data-controller-namespace="{{.ControllerNamespace}}"
+    data-grafana-url="{{.GrafanaURL}}"
+    data-uuid="{{.UUID}}">
+    {{ if .Error }}
+      <p>Failed to call public API: {{ .ErrorMessage }}</p>
+    {{ end }}
+  </div>
+{{ end }}
+
+{{ define "script-tags" }}
+  <script type="text/javascript" src="{{.BundleURL}}" async></script>
+{{end}}
+`
@@ -2,7 +2,6 @@ package srv
 
 func FakeServer() Server {
 	return Server{
-		templateDir: "../templates",
-		reload:      true,
+		templates: pageTemplates,
 	}
 }